@@ -5,11 +5,12 @@ import (
 	"os"
 
 	"k8stool/internal/cli"
+	"k8stool/internal/k8s/errs"
 )
 
 func main() {
 	if err := cli.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(errs.ExitCode(err))
 	}
 }
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8stool/internal/k8s/apis"
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+
+	"github.com/spf13/cobra"
+)
+
+func getAPIResourcesCmd() *cobra.Command {
+	var apiGroup string
+	var namespaced bool
+	var verbs []string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "api-resources",
+		Short: "List the resource types served by the cluster",
+		Long: `List the resource types served by the cluster, discovered from the API server, with their
+short names, preferred API version, scope, kind and supported verbs.
+
+Custom resources are included. Groups whose API service is unavailable are skipped.
+
+Examples:
+  # List all resource types
+  k8stool api-resources
+
+  # List the resource types of the apps group
+  k8stool api-resources --api-group apps
+
+  # List cluster-scoped resource types that can be listed and watched
+  k8stool api-resources --namespaced=false --verbs list,watch`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			opts := k8s.APIResourceOptions{
+				APIGroup: apiGroup,
+				Verbs:    verbs,
+			}
+			if cmd.Flags().Changed("namespaced") {
+				opts.Namespaced = &namespaced
+			}
+
+			resources, err := client.APIResources(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(resources)
+			}
+
+			printAPIResources(resources)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiGroup, "api-group", "", "Only list resource types of this API group; use \"core\" for the core group")
+	cmd.Flags().BoolVar(&namespaced, "namespaced", true, "Only list namespaced (true) or cluster-scoped (false) resource types")
+	cmd.Flags().StringSliceVar(&verbs, "verbs", nil, "Only list resource types supporting all of these verbs")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printAPIResources(resources []apis.Resource) {
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "NAME\tSHORTNAMES\tAPIVERSION\tNAMESPACED\tKIND\tVERBS")
+	for _, r := range resources {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\n",
+			r.Name,
+			strings.Join(r.ShortNames, ","),
+			r.APIVersion,
+			r.Namespaced,
+			r.Kind,
+			strings.Join(r.Verbs, ","),
+		)
+	}
+	w.Flush()
+}
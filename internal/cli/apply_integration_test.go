@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	manifest := filepath.Join(t.TempDir(), "configmap.yaml")
+	content := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: k8stool-apply-test
+data:
+  key: value
+`
+	if err := os.WriteFile(manifest, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	overlay := filepath.Dir(manifest)
+	kustomization := `resources:
+- configmap.yaml
+nameSuffix: -kustomized
+`
+	if err := os.WriteFile(filepath.Join(overlay, "kustomization.yaml"), []byte(kustomization), 0o644); err != nil {
+		t.Fatalf("failed to write kustomization: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "apply dry run",
+			args:    []string{"-f", manifest, "--namespace", "integration-test", "--dry-run"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "integration-test/configmap/k8stool-apply-test created (dry run)")
+			},
+		},
+		{
+			name:    "apply creates object",
+			args:    []string{"-f", manifest, "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "integration-test/configmap/k8stool-apply-test created")
+			},
+		},
+		{
+			name:    "apply again leaves object unchanged",
+			args:    []string{"-f", manifest, "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "integration-test/configmap/k8stool-apply-test unchanged")
+			},
+		},
+		{
+			name:    "apply kustomization dry run",
+			args:    []string{"-k", overlay, "--namespace", "integration-test", "--dry-run"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "integration-test/configmap/k8stool-apply-test-kustomized created (dry run)")
+			},
+		},
+		{
+			name:     "apply file and kustomization",
+			args:     []string{"-f", manifest, "-k", overlay},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+		{
+			name:     "apply missing file",
+			args:     []string{"-f", "nonexistent.yaml"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := getApplyCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
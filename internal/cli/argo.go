@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"k8stool/internal/k8s/argo"
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// commitSHA matches full git commit hashes, which are shortened for display
+var commitSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+func getArgoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "argo",
+		Short: "Show ArgoCD GitOps state",
+		Long:  "Show the state of ArgoCD resources in the cluster.",
+	}
+
+	cmd.AddCommand(getArgoAppsCmd())
+
+	return cmd
+}
+
+func getArgoAppsCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var selector string
+	var problemsOnly bool
+
+	cmd := &cobra.Command{
+		Use:     "apps",
+		Aliases: []string{"app", "applications"},
+		Short:   "List ArgoCD applications with sync and health status",
+		Long: `List ArgoCD Application resources with their sync status, health, target revision and the
+revision they were last synced to.
+
+Applications are read directly from the cluster, so neither the argocd CLI nor access to the
+ArgoCD API server is needed. Error conditions and failed sync operations are listed below the
+table.
+
+Examples:
+  # List applications in the argocd namespace
+  k8stool argo apps
+
+  # List applications in all namespaces
+  k8stool argo apps -A
+
+  # Only show applications that are out of sync or not healthy
+  k8stool argo apps --problems`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			apps, err := client.ArgoApplications(context.Background(), k8s.ArgoOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+				Selector:      selector,
+			})
+			if err != nil {
+				return err
+			}
+
+			if problemsOnly {
+				var filtered []argo.Application
+				for _, app := range apps {
+					if app.SyncStatus != "Synced" || app.Health != "Healthy" {
+						filtered = append(filtered, app)
+					}
+				}
+				apps = filtered
+			}
+
+			if len(apps) == 0 {
+				fmt.Println("No applications found")
+				return nil
+			}
+
+			printArgoApps(apps, allNamespaces)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "argocd", "Namespace the applications are in")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List applications in all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().BoolVar(&problemsOnly, "problems", false, "Only show applications that are out of sync or not healthy")
+
+	return cmd
+}
+
+func printArgoApps(apps []argo.Application, showNamespace bool) {
+	w := newTable(os.Stdout)
+
+	if showNamespace {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintln(w, "NAME\tPROJECT\tSYNC\tHEALTH\tTARGET\tREVISION\tLAST SYNC\tDESTINATION")
+	for _, app := range apps {
+		if showNamespace {
+			fmt.Fprintf(w, "%s\t", app.Namespace)
+		}
+		lastSync := "<never>"
+		if !app.LastSynced.IsZero() {
+			lastSync = formatAgo(app.LastSynced)
+		}
+		destination := app.DestServer
+		if app.DestNamespace != "" {
+			destination += "/" + app.DestNamespace
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			app.Name,
+			app.Project,
+			argoSyncStatus(app.SyncStatus),
+			argoHealth(app.Health),
+			orNone(app.TargetRevision),
+			orNone(shortRevision(app.Revision)),
+			lastSync,
+			destination,
+		)
+	}
+	w.Flush()
+
+	var printedHeader bool
+	for _, app := range apps {
+		var messages []string
+		if app.OperationPhase == "Failed" || app.OperationPhase == "Error" {
+			messages = append(messages, fmt.Sprintf("sync %s: %s", app.OperationPhase, app.OperationMessage))
+		}
+		if app.Health != "Healthy" && app.HealthMessage != "" {
+			messages = append(messages, "health: "+app.HealthMessage)
+		}
+		messages = append(messages, app.Conditions...)
+		if len(messages) == 0 {
+			continue
+		}
+		if !printedHeader {
+			fmt.Println("\nMessages:")
+			printedHeader = true
+		}
+		for _, m := range messages {
+			fmt.Printf("  %s/%s: %s\n", app.Namespace, app.Name, m)
+		}
+	}
+}
+
+func argoSyncStatus(status string) string {
+	switch status {
+	case "Synced":
+		return utils.Green(status)
+	case "OutOfSync":
+		return utils.Yellow(status)
+	default:
+		return status
+	}
+}
+
+func argoHealth(health string) string {
+	switch health {
+	case "Healthy":
+		return utils.Green(health)
+	case "Progressing", "Suspended":
+		return utils.Yellow(health)
+	case "Degraded", "Missing":
+		return utils.Red(health)
+	default:
+		return health
+	}
+}
+
+// shortRevision shortens git commit hashes, keeping each revision of multi-source applications
+func shortRevision(revision string) string {
+	revisions := strings.Split(revision, ",")
+	for i, r := range revisions {
+		if commitSHA.MatchString(r) {
+			revisions[i] = r[:7]
+		}
+	}
+	return strings.Join(revisions, ",")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
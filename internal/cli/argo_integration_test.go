@@ -0,0 +1,317 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/resources"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// argoCRD is a minimal Application CRD, without a status subresource so that the test
+// applications can be created with their status
+const argoCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: applications.argoproj.io
+spec:
+  group: argoproj.io
+  names:
+    kind: Application
+    listKind: ApplicationList
+    plural: applications
+    singular: application
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        x-kubernetes-preserve-unknown-fields: true
+`
+
+// argoApps are an application in sync and one that is out of sync and degraded
+const argoApps = `apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: k8stool-argo-web
+  labels:
+    team: web
+spec:
+  project: default
+  source:
+    repoURL: https://github.com/example/web
+    path: deploy
+    targetRevision: main
+  destination:
+    server: https://kubernetes.default.svc
+    namespace: web
+status:
+  sync:
+    status: Synced
+    revision: 0123456789abcdef0123456789abcdef01234567
+  health:
+    status: Healthy
+  operationState:
+    phase: Succeeded
+    finishedAt: "2024-05-02T09:00:00Z"
+---
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: k8stool-argo-api
+  labels:
+    team: api
+spec:
+  project: backend
+  source:
+    repoURL: https://github.com/example/api
+    path: deploy
+    targetRevision: v1.2.0
+  destination:
+    name: in-cluster
+    namespace: api
+status:
+  sync:
+    status: OutOfSync
+    revision: 89abcdef0123456789abcdef0123456789abcdef
+  health:
+    status: Degraded
+    message: Deployment api exceeded its progress deadline
+`
+
+func TestArgoCommands_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	rootCmd := getArgoCmd()
+
+	// ArgoCD is not installed in the test cluster
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "argo apps without ArgoCD",
+			args:    []string{"apps"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "ArgoCD is not installed: applications.argoproj.io not found")
+			},
+		},
+		{
+			name:    "argo apps in all namespaces without ArgoCD",
+			args:    []string{"apps", "-A"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "ArgoCD is not installed: applications.argoproj.io not found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := rootCmd
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+func TestArgoApps_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	installArgoApps(t)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "list applications",
+			args:    []string{"apps", "-n", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "NAME")
+				assert.Contains(t, output, "PROJECT")
+				assert.Contains(t, output, "SYNC")
+				assert.Contains(t, output, "HEALTH")
+				assert.Contains(t, output, "REVISION")
+				assert.Contains(t, output, "DESTINATION")
+				assert.NotContains(t, output, "NAMESPACE")
+				assert.Regexp(t, `k8stool-argo-web\s+default\s+Synced\s+Healthy\s+main\s+0123456\s+`, output)
+				assert.Contains(t, output, "https://kubernetes.default.svc/web")
+				assert.Regexp(t, `k8stool-argo-api\s+backend\s+OutOfSync\s+Degraded\s+v1.2.0\s+89abcde\s+<never>\s+in-cluster/api`, output)
+				assert.Contains(t, output, "Messages:")
+				assert.Contains(t, output, "integration-test/k8stool-argo-api: health: Deployment api exceeded its progress deadline")
+			},
+		},
+		{
+			name:    "list applications in all namespaces",
+			args:    []string{"apps", "-A"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "NAMESPACE")
+				assert.Regexp(t, `integration-test\s+k8stool-argo-api\s+`, output)
+				assert.Regexp(t, `integration-test\s+k8stool-argo-web\s+`, output)
+			},
+		},
+		{
+			name:    "list applications with problems",
+			args:    []string{"apps", "-n", "integration-test", "--problems"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "k8stool-argo-api")
+				assert.NotContains(t, output, "k8stool-argo-web")
+			},
+		},
+		{
+			name:    "list applications by label",
+			args:    []string{"apps", "-n", "integration-test", "-l", "team=web"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "k8stool-argo-web")
+				assert.NotContains(t, output, "k8stool-argo-api")
+				assert.NotContains(t, output, "Messages:")
+			},
+		},
+		{
+			name:    "list applications in a namespace without any",
+			args:    []string{"apps", "-n", "default"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Equal(t, "No applications found\n", output)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getArgoCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+// installArgoApps creates the Application CRD and the test applications in the
+// integration-test namespace, and deletes them when the test ends
+func installArgoApps(t *testing.T) {
+	ctx := context.Background()
+	crd, err := resources.Decode([]byte(argoCRD))
+	if err != nil {
+		t.Fatalf("failed to decode CRD: %v", err)
+	}
+	apps, err := resources.Decode([]byte(argoApps))
+	if err != nil {
+		t.Fatalf("failed to decode applications: %v", err)
+	}
+
+	client, err := k8s.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if err := applyTestObjects(client, crd, ""); err != nil {
+		t.Fatalf("failed to create CRD: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Delete(ctx, crd, k8s.DeleteOptions{IgnoreNotFound: true, Wait: true, Timeout: time.Minute})
+	})
+
+	// The applications can be listed once the CRD is established
+	deadline := time.Now().Add(time.Minute)
+	for {
+		_, err := client.ArgoApplications(ctx, k8s.ArgoOptions{Namespace: "integration-test"})
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("CRD not established: %v", err)
+		}
+		time.Sleep(time.Second)
+	}
+
+	// A new client discovers the resource of the CRD
+	client, err = k8s.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if err := applyTestObjects(client, apps, "integration-test"); err != nil {
+		t.Fatalf("failed to create applications: %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8stool/internal/k8s/audit"
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getAuditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Audit workloads for risky configuration",
+		Long:  "Audit the configuration of live workloads for security risks.",
+	}
+
+	cmd.AddCommand(getAuditSecurityCmd())
+
+	return cmd
+}
+
+func getAuditSecurityCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var selector string
+	var output string
+	var minSeverity string
+	var failOn string
+
+	cmd := &cobra.Command{
+		Use:   "security",
+		Short: "Check the security posture of pods",
+		Long: `Check the pods of deployments, statefulsets, daemonsets, cronjobs, jobs and bare pods
+for privileged containers, host namespaces and hostPath volumes, containers running as root,
+missing security contexts and images using the latest tag.
+
+Each finding has a severity of low, medium, high or critical. With --fail-on the command
+exits with an error when any finding reaches that severity, so it can gate CI pipelines.
+
+Examples:
+  # Audit the current namespace
+  k8stool audit security
+
+  # Only show high and critical findings across all namespaces
+  k8stool audit security -A --severity high
+
+  # Fail a pipeline on high findings, with machine readable output
+  k8stool audit security -n prod -o json --fail-on high`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			minimum, err := audit.ParseSeverity(minSeverity)
+			if err != nil {
+				return err
+			}
+			var threshold audit.Severity
+			if failOn != "" {
+				if threshold, err = audit.ParseSeverity(failOn); err != nil {
+					return err
+				}
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			all, err := client.AuditSecurity(context.Background(), k8s.AuditOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+				Selector:      selector,
+			})
+			if err != nil {
+				return err
+			}
+
+			findings := []audit.Finding{}
+			failed := 0
+			for _, f := range all {
+				if f.Severity.Rank() >= minimum.Rank() {
+					findings = append(findings, f)
+				}
+				if threshold != "" && f.Severity.Rank() >= threshold.Rank() {
+					failed++
+				}
+			}
+
+			if output == "json" {
+				if err := printJSON(findings); err != nil {
+					return err
+				}
+			} else if len(findings) == 0 {
+				fmt.Println("No security issues found")
+			} else {
+				printAuditFindings(findings)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d findings at or above %s severity", failed, threshold)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Audit across all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
+	markQueryable(cmd)
+	cmd.Flags().StringVar(&minSeverity, "severity", string(audit.Low), "Minimum severity to show: low, medium, high or critical")
+	cmd.Flags().StringVar(&failOn, "fail-on", "", "Exit with an error when a finding reaches this severity")
+
+	return cmd
+}
+
+func printAuditFindings(findings []audit.Finding) {
+	w := newTable(os.Stdout)
+
+	counts := make(map[audit.Severity]int)
+	fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tCONTAINER\tSEVERITY\tRULE\tMESSAGE")
+	for _, f := range findings {
+		container := f.Container
+		if container == "" {
+			container = "-"
+		}
+		counts[f.Severity]++
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", f.Namespace, f.Workload, container, auditSeverity(f.Severity), f.Rule, f.Message)
+	}
+	w.Flush()
+
+	var summary []string
+	for i := len(audit.Severities) - 1; i >= 0; i-- {
+		s := audit.Severities[i]
+		summary = append(summary, fmt.Sprintf("%d %s", counts[s], s))
+	}
+	fmt.Printf("\n%s\n", strings.Join(summary, ", "))
+}
+
+func auditSeverity(s audit.Severity) string {
+	switch s {
+	case audit.Critical, audit.High:
+		return utils.Red(string(s))
+	case audit.Medium:
+		return utils.Yellow(string(s))
+	default:
+		return string(s)
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditCommands_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	rootCmd := getAuditCmd()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "audit security",
+			args:    []string{"security"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Pod/nginx-default")
+				assert.Contains(t, output, "missing-security-context")
+				assert.Contains(t, output, "run-as-root")
+			},
+		},
+		{
+			name:    "audit security as json",
+			args:    []string{"security", "--namespace", "integration-test", "--output", "json"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `"workload": "Deployment/nginx-deploy"`)
+				assert.NotContains(t, output, "nginx-default")
+			},
+		},
+		{
+			name:    "audit security fails on medium",
+			args:    []string{"security", "--namespace", "integration-test", "--fail-on", "medium"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Deployment/nginx-deploy")
+			},
+		},
+		{
+			name:    "audit security hides lower severities",
+			args:    []string{"security", "--namespace", "integration-test", "--severity", "critical", "--fail-on", ""},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "No security issues found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := rootCmd
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+
+	"k8stool/internal/k8s/backup"
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/resources"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func getBackupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up cluster resources",
+		Long:  "Export cluster resources as clean manifests into an archive that can be restored later.",
+	}
+
+	cmd.AddCommand(getBackupNamespaceCmd())
+
+	return cmd
+}
+
+func getBackupNamespaceCmd() *cobra.Command {
+	var output string
+	var opts k8s.BackupOptions
+
+	cmd := &cobra.Command{
+		Use:     "ns NAME",
+		Aliases: []string{"namespace"},
+		Short:   "Back up all objects of a namespace",
+		Long: `Export the namespace and every object in it as clean YAML into a gzipped tarball.
+
+Objects are cleaned the same way as by the export command. Objects owned by a controller, such
+as the pods of a deployment, are skipped because restoring their owner recreates them. Events,
+endpoints, leases and other runtime state are skipped unless explicitly included, as are the
+default service account, its tokens and the root CA configmap.
+
+Resource types for --include and --exclude can be given by kind, name, short name or
+resource.group, e.g. Secret, deployments, svc or certificates.cert-manager.io.
+
+Examples:
+  # Back up a namespace
+  k8stool backup ns shop -o shop.tar.gz
+
+  # Back up only configuration
+  k8stool backup ns shop -o shop-config.tar.gz --include configmaps,secrets
+
+  # Back up everything except secrets
+  k8stool backup ns shop -o shop.tar.gz --exclude secrets`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Namespace = args[0]
+			if output == "" {
+				output = opts.Namespace + ".tar.gz"
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			objs, err := client.BackupNamespace(ctx, opts)
+			if err != nil {
+				return err
+			}
+
+			// The summary goes to stderr when the archive is written to stdout
+			summary := io.Writer(os.Stdout)
+			if output == "-" {
+				summary = os.Stderr
+				if err := backup.WriteArchive(os.Stdout, objs); err != nil {
+					return err
+				}
+			} else if err := writeBackupFile(output, objs); err != nil {
+				return err
+			}
+
+			printBackupSummary(summary, objs)
+			fmt.Fprintf(summary, "\nBacked up %d objects from namespace %s to %s\n", len(objs), utils.Bold(opts.Namespace), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Archive to write, - for stdout (default NAME.tar.gz)")
+	cmd.Flags().StringSliceVar(&opts.Include, "include", nil, "Only back up these resource types")
+	cmd.Flags().StringSliceVar(&opts.Exclude, "exclude", nil, "Skip these resource types")
+
+	return cmd
+}
+
+// writeBackupFile writes the archive next to its destination first, so a failed backup never
+// replaces an existing one
+func writeBackupFile(path string, objs []*unstructured.Unstructured) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".k8stool-backup-*")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if err := backup.WriteArchive(f, objs); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+	return nil
+}
+
+func printBackupSummary(out io.Writer, objs []*unstructured.Unstructured) {
+	counts := make(map[string]int)
+	for _, obj := range objs {
+		counts[obj.GetKind()]++
+	}
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	w := newTable(out)
+	fmt.Fprintln(w, "KIND\tCOUNT")
+	for _, kind := range kinds {
+		fmt.Fprintf(w, "%s\t%d\n", kind, counts[kind])
+	}
+	w.Flush()
+}
+
+func getRestoreCmd() *cobra.Command {
+	var file string
+	var opts k8s.RestoreOptions
+
+	cmd := &cobra.Command{
+		Use:   "restore -f FILENAME",
+		Short: "Restore a namespace backup",
+		Long: `Re-apply the objects of a backup archive created with "backup ns".
+
+Objects are applied with server-side apply in dependency order: the namespace first, then
+quotas, service accounts, secrets, configmaps and claims, then RBAC, services and workloads,
+and custom resources last. Fields changed since the backup are taken back.
+
+With --into the objects are restored into another namespace, which is created if needed.
+Role binding subjects pointing at service accounts of the backed up namespace are moved along.
+
+Examples:
+  # Restore a namespace
+  k8stool restore -f shop.tar.gz
+
+  # Restore a copy into another namespace
+  k8stool restore -f shop.tar.gz --into shop-copy`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			objs, err := backup.ReadArchive(f)
+			if err != nil {
+				return err
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			results, err := client.Restore(ctx, objs, opts)
+			printResults(results, opts.DryRun)
+			if err != nil {
+				return err
+			}
+			return resultsError(results)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "filename", "f", "", "Backup archive to restore")
+	cmd.Flags().StringVar(&opts.Into, "into", "", "Restore into this namespace instead of the backed up one")
+	cmd.Flags().StringVar(&opts.FieldManager, "field-manager", resources.DefaultFieldManager, "Field manager recorded for the restored fields")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Submit a server-side dry run without persisting changes")
+	cmd.MarkFlagRequired("filename")
+	markMutating(cmd)
+
+	return cmd
+}
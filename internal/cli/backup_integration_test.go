@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupRestore_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	archive := filepath.Join(t.TempDir(), "integration-test.tar.gz")
+
+	tests := []struct {
+		name     string
+		cmd      func() *cobra.Command
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "backup namespace",
+			cmd:     getBackupCmd,
+			args:    []string{"ns", "integration-test", "-o", archive},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Deployment")
+				assert.Contains(t, output, "Backed up")
+				assert.FileExists(t, archive)
+			},
+		},
+		{
+			name:    "restore dry run",
+			cmd:     getRestoreCmd,
+			args:    []string{"-f", archive, "--dry-run"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "integration-test/deployment.apps/nginx-deploy")
+				assert.Contains(t, output, "(dry run)")
+			},
+		},
+		{
+			name:     "backup missing namespace",
+			cmd:      getBackupCmd,
+			args:     []string{"ns", "nonexistent-namespace", "-o", archive},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+		{
+			name:     "restore missing file",
+			cmd:      getRestoreCmd,
+			args:     []string{"-f", "nonexistent.tar.gz"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := tt.cmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
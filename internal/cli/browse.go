@@ -0,0 +1,311 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/deployments"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/pods"
+	"k8stool/internal/k8s/portforward"
+	"k8stool/pkg/utils"
+
+	"github.com/manifoldco/promptui"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// browseLogLines is how many of the last lines of logs the browser shows
+const browseLogLines int64 = 200
+
+// browseShell starts bash when the image has it, and sh otherwise
+var browseShell = []string{"sh", "-c", "command -v bash >/dev/null && exec bash || exec sh"}
+
+// errBrowseQuit leaves the browser from any of its menus
+var errBrowseQuit = errors.New("quit")
+
+// browseAction is an entry of the menu of a pod or deployment
+type browseAction struct {
+	label string
+	run   func() error
+}
+
+// browseChoice shows a menu and returns the chosen entry. Ctrl+C and Ctrl+D leave the browser.
+func browseChoice(label string, items []string) (int, error) {
+	idx, err := pick(label, items)
+	if errors.Is(err, promptui.ErrInterrupt) || errors.Is(err, promptui.ErrEOF) {
+		return 0, errBrowseQuit
+	}
+	return idx, err
+}
+
+// runBrowseMenu shows the actions of an object until Back is chosen, coming back to the menu
+// after each one. An action that fails is reported without leaving the menu.
+func runBrowseMenu(label string, actions []browseAction) error {
+	items := make([]string, 0, len(actions)+2)
+	for _, a := range actions {
+		items = append(items, a.label)
+	}
+	items = append(items, "Back", "Quit")
+
+	for {
+		idx, err := browseChoice(label, items)
+		if err != nil {
+			return err
+		}
+		switch idx {
+		case len(actions):
+			return nil
+		case len(actions) + 1:
+			return errBrowseQuit
+		}
+		if err := actions[idx].run(); err != nil {
+			if errors.Is(err, errBrowseQuit) {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, utils.Red("Error:"), err)
+		}
+	}
+}
+
+// browseRows renders rows as aligned table lines, to be shown as menu entries
+func browseRows(header string, rows []string) (string, []string) {
+	var buf bytes.Buffer
+	w := newTable(&buf)
+	fmt.Fprintln(w, header)
+	for _, row := range rows {
+		fmt.Fprintln(w, row)
+	}
+	w.Flush()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	return lines[0], lines[1:]
+}
+
+// browsePods lets the user pick a pod from list, read again each time the picker is shown so
+// it follows the pods as they change, and act on it. nested adds Back, to return to the
+// deployment the pods belong to.
+func browsePods(client *k8s.Client, list func() ([]pods.Pod, error), nested bool) error {
+	for {
+		podList, err := list()
+		if err != nil {
+			return err
+		}
+		if len(podList) == 0 && !nested {
+			fmt.Println("No pods found")
+			return nil
+		}
+
+		showNamespace := false
+		for _, pod := range podList {
+			showNamespace = showNamespace || pod.Namespace != podList[0].Namespace
+		}
+		rows := make([]string, 0, len(podList))
+		for _, pod := range podList {
+			row := fmt.Sprintf("%s\t%s\t%d\t%s\t%s", pod.Name, pod.Ready, pod.Restarts, formatAge(pod.Age), pod.Status)
+			if showNamespace {
+				row = pod.Namespace + "\t" + row
+			}
+			rows = append(rows, row)
+		}
+		header := "NAME\tREADY\tRESTARTS\tAGE\tSTATUS"
+		if showNamespace {
+			header = "NAMESPACE\t" + header
+		}
+		header, items := browseRows(header, rows)
+		if nested {
+			items = append(items, "Back")
+		}
+		items = append(items, "Quit")
+
+		idx, err := browseChoice("    "+header, items)
+		if err != nil {
+			return err
+		}
+		switch {
+		case idx == len(items)-1:
+			return errBrowseQuit
+		case idx == len(podList):
+			return nil
+		}
+
+		if err := browsePod(client, podList[idx]); err != nil {
+			return err
+		}
+	}
+}
+
+// browsePod shows the menu of a pod
+func browsePod(client *k8s.Client, pod pods.Pod) error {
+	namespace, name := pod.Namespace, pod.Name
+	return runBrowseMenu(fmt.Sprintf("pod %s/%s", namespace, name), []browseAction{
+		{"Describe", func() error {
+			details, err := client.PodService.Describe(namespace, name)
+			if err != nil {
+				return err
+			}
+			defer startPager()()
+			return printPodDetails(details)
+		}},
+		{"Logs", func() error {
+			current, err := client.PodService.Get(namespace, name)
+			if err != nil {
+				return err
+			}
+			container, err := pickContainer(current, false)
+			if err != nil {
+				return err
+			}
+			defer startPager()()
+			tail := browseLogLines
+			return client.GetPodLogs(namespace, name, container, k8s.LogOptions{TailLines: &tail, Writer: os.Stdout})
+		}},
+		{"Exec shell", func() error {
+			current, err := client.PodService.Get(namespace, name)
+			if err != nil {
+				return err
+			}
+			container, err := pickContainer(current, false)
+			if err != nil {
+				return err
+			}
+			execOpts := pods.ExecOptions{Command: browseShell, TTY: true, Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+			restore, err := attachTerminal(&execOpts)
+			if err != nil {
+				return err
+			}
+			defer restore()
+			return client.PodService.Exec(namespace, name, container, execOpts)
+		}},
+		{"Events", func() error {
+			return browseEvents(client, namespace, "Pod", name)
+		}},
+		{"Port-forward", func() error {
+			ports, err := podPortChoices(client, namespace, name)
+			if err != nil {
+				return err
+			}
+			return browsePortForward(client, namespace, "pod", name, ports)
+		}},
+	})
+}
+
+// browseDeployments lets the user pick a deployment from list, read again each time the
+// picker is shown, and act on it
+func browseDeployments(client *k8s.Client, list func() ([]deployments.Deployment, error)) error {
+	for {
+		deploymentList, err := list()
+		if err != nil {
+			return err
+		}
+		if len(deploymentList) == 0 {
+			fmt.Println("No deployments found")
+			return nil
+		}
+
+		showNamespace := false
+		for _, d := range deploymentList {
+			showNamespace = showNamespace || d.Namespace != deploymentList[0].Namespace
+		}
+		rows := make([]string, 0, len(deploymentList))
+		for _, d := range deploymentList {
+			row := fmt.Sprintf("%s\t%d/%d\t%d\t%d\t%s\t%s", d.Name, d.ReadyReplicas, d.Replicas, d.UpdatedReplicas, d.AvailableReplicas, formatAge(d.Age), d.Status)
+			if showNamespace {
+				row = d.Namespace + "\t" + row
+			}
+			rows = append(rows, row)
+		}
+		header := "NAME\tREADY\tUP-TO-DATE\tAVAILABLE\tAGE\tSTATUS"
+		if showNamespace {
+			header = "NAMESPACE\t" + header
+		}
+		header, items := browseRows(header, rows)
+		items = append(items, "Quit")
+
+		idx, err := browseChoice("    "+header, items)
+		if err != nil {
+			return err
+		}
+		if idx == len(deploymentList) {
+			return errBrowseQuit
+		}
+
+		if err := browseDeployment(client, deploymentList[idx]); err != nil {
+			return err
+		}
+	}
+}
+
+// browseDeployment shows the menu of a deployment, with its pods one level down
+func browseDeployment(client *k8s.Client, d deployments.Deployment) error {
+	namespace, name := d.Namespace, d.Name
+	selector := labels.SelectorFromSet(d.Selector).String()
+	return runBrowseMenu(fmt.Sprintf("deployment %s/%s", namespace, name), []browseAction{
+		{"Pods", func() error {
+			return browsePods(client, func() ([]pods.Pod, error) {
+				return client.PodService.List(namespace, false, selector, "")
+			}, true)
+		}},
+		{"Describe", func() error {
+			details, err := client.DeploymentService.Describe(namespace, name)
+			if err != nil {
+				return err
+			}
+			defer startPager()()
+			return printDeploymentDetails(details)
+		}},
+		{"Events", func() error {
+			return browseEvents(client, namespace, "Deployment", name)
+		}},
+		{"Port-forward", func() error {
+			ports, err := deploymentPortChoices(client, namespace, name)
+			if err != nil {
+				return err
+			}
+			return browsePortForward(client, namespace, "deployment", name, ports)
+		}},
+	})
+}
+
+// browseEvents shows the events of an object
+func browseEvents(client *k8s.Client, namespace, kind, name string) error {
+	list, err := client.EventService.ListForObject(context.Background(), namespace, kind, name)
+	if err != nil {
+		return err
+	}
+	if len(list.Items) == 0 {
+		fmt.Printf("No events for %s %s\n", strings.ToLower(kind), name)
+		return nil
+	}
+	defer startPager()()
+	return printEvents(list.Items, false)
+}
+
+// browsePortForward forwards a port of the object until Ctrl+C, which returns to the menu
+func browsePortForward(client *k8s.Client, namespace, resourceType, name string, ports []string) error {
+	return forwardChosenPort(client, namespace, resourceType, name, ports, "localhost", string(portforward.TCP), 0, portforward.DefaultKeepAlive)
+}
+
+// endBrowse returns the error of the browser, quitting being none
+func endBrowse(err error) error {
+	if errors.Is(err, errBrowseQuit) {
+		return nil
+	}
+	return err
+}
+
+// checkBrowse checks that the browser can run with the other flags of a command
+func checkBrowse(output string, contexts []string) error {
+	switch {
+	case output != "table":
+		return errs.Validationf("--interactive cannot be used with -o %s", output)
+	case contexts != nil:
+		return errs.Validationf("--interactive cannot be used with --contexts or --all-contexts")
+	case !canPrompt():
+		return errs.Validationf("--interactive needs a terminal")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getCanICmd() *cobra.Command {
+	var opts k8s.AccessOptions
+
+	cmd := &cobra.Command{
+		Use:   "can-i VERB RESOURCE [NAME]",
+		Short: "Check whether an action is allowed",
+		Long: `Check whether the current user may perform an action, using a SelfSubjectAccessReview.
+
+With --as and --as-group the check is made for another user or group instead, using a
+SubjectAccessReview. This requires permission to create subjectaccessreviews.
+
+Resources can be given by name, short name or resource.group, optionally with a subresource
+such as pods/log or pods/exec. Paths starting with / are checked as non-resource URLs.
+
+Examples:
+  # Check whether you can create deployments in the current namespace
+  k8stool can-i create deploy
+
+  # Check whether you can read pod logs in another namespace
+  k8stool can-i get pods/log -n prod
+
+  # Check whether a service account can list secrets in all namespaces
+  k8stool can-i list secrets -A --as system:serviceaccount:ci:deployer
+
+  # Check access to a non-resource URL
+  k8stool can-i get /metrics`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Verb, opts.Resource = args[0], args[1]
+			if len(args) == 3 {
+				opts.Name = args[2]
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !opts.AllNamespaces && opts.Namespace == "" {
+				opts.Namespace = client.GetCurrentNamespace()
+			}
+
+			access, err := client.CanI(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+
+			answer := utils.Red("no")
+			if access.Allowed {
+				answer = utils.Green("yes")
+			}
+			if access.Reason != "" {
+				fmt.Printf("%s - %s\n", answer, access.Reason)
+			} else {
+				fmt.Println(answer)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&opts.AllNamespaces, "all-namespaces", "A", false, "Check the action in all namespaces")
+	cmd.Flags().StringVar(&opts.User, "as", "", "User to check instead of the current user")
+	cmd.Flags().StringSliceVar(&opts.Groups, "as-group", nil, "Group to check, can be repeated")
+
+	return cmd
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"k8stool/internal/k8s/certs"
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getCertsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "certs",
+		Aliases: []string{"certificates"},
+		Short:   "Inspect certificates used in the cluster",
+		Long:    "Inspect TLS certificates stored and used in the cluster.",
+	}
+
+	cmd.AddCommand(getCertsCheckCmd())
+
+	return cmd
+}
+
+func getCertsCheckCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var warnDays int
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Report certificate expiry dates",
+		Long: `Report the expiry of certificates in kubernetes.io/tls secrets, in the caBundles of
+validating and mutating webhook configurations and, where it can be reached, the serving
+certificate of the API server. Certificates expiring within --warn-days are highlighted.
+
+Examples:
+  # Check certificates in the current namespace
+  k8stool certs check
+
+  # Check across all namespaces and warn 60 days ahead
+  k8stool certs check -A --warn-days 60`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			report, err := client.CheckCerts(context.Background(), k8s.CertOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+				Threshold:     time.Duration(warnDays) * 24 * time.Hour,
+			})
+			if err != nil {
+				return err
+			}
+
+			return printCertReport(report)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the TLS secrets")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Check TLS secrets in all namespaces")
+	cmd.Flags().IntVar(&warnDays, "warn-days", 30, "Highlight certificates expiring within this many days")
+
+	return cmd
+}
+
+func printCertReport(report *certs.Report) error {
+	w := newTable(os.Stdout)
+
+	var expired, expiring int
+	fmt.Fprintln(w, "SOURCE\tNAMESPACE\tNAME\tSUBJECT\tEXPIRES\tDAYS LEFT")
+	for _, c := range report.Certificates {
+		ns := c.Namespace
+		if ns == "" {
+			ns = "-"
+		}
+		if c.Error != "" {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n", c.Source, ns, c.Name, "-", utils.Yellow(c.Error))
+			continue
+		}
+
+		days := fmt.Sprintf("%d", int(c.Remaining.Hours()/24))
+		switch {
+		case c.Expired:
+			expired++
+			days = utils.Red("expired")
+		case c.Expiring:
+			expiring++
+			days = utils.Yellow(days)
+		default:
+			days = utils.Green(days)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Source, ns, c.Name, utils.TruncateString(c.Subject, 40), c.NotAfter.Format("2006-01-02"), days)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d certificates, %s expired, %s expiring within %d days\n",
+		len(report.Certificates), utils.Red(expired), utils.Yellow(expiring), int(report.Threshold.Hours()/24))
+	return nil
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// expiringSecret is a TLS secret, filled with a certificate and its key
+const expiringSecret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: k8stool-expiring-tls
+type: kubernetes.io/tls
+data:
+  tls.crt: %s
+  tls.key: %s
+`
+
+func TestCertsCheckCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	// A TLS secret whose certificate expires in 10 days
+	certPEM, keyPEM := selfSignedCert(t, "k8stool-test.example.com", time.Now().Add(10*24*time.Hour+time.Hour))
+	createTestObjects(t, fmt.Sprintf(expiringSecret,
+		base64.StdEncoding.EncodeToString(certPEM), base64.StdEncoding.EncodeToString(keyPEM)), "integration-test")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "check certificates in namespace",
+			args:    []string{"check", "-n", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "SOURCE")
+				assert.Contains(t, output, "DAYS LEFT")
+				assert.Regexp(t, `secret\s+integration-test\s+k8stool-expiring-tls\s+k8stool-test.example.com\s+\d{4}-\d{2}-\d{2}\s+10\n`, output)
+				assert.Regexp(t, `apiserver\s+-\s+`, output)
+				assert.Regexp(t, `\d+ certificates, 0 expired, 1 expiring within 30 days`, output)
+			},
+		},
+		{
+			name:    "check with a shorter warning window",
+			args:    []string{"check", "-n", "integration-test", "--warn-days", "7"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "k8stool-expiring-tls")
+				assert.Regexp(t, `\d+ certificates, 0 expired, 0 expiring within 7 days`, output)
+			},
+		},
+		{
+			name:    "check in namespace without TLS secrets",
+			args:    []string{"check", "-n", "default"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.NotContains(t, output, "k8stool-expiring-tls")
+				assert.Contains(t, output, "apiserver")
+			},
+		},
+		{
+			name:     "invalid warning window",
+			args:     []string{"check", "--warn-days", "month"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getCertsCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+// selfSignedCert returns a PEM certificate for a common name expiring at notAfter, and its key
+func selfSignedCert(t *testing.T, commonName string, notAfter time.Time) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
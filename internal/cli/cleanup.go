@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/cleanup"
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getCleanupCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var evicted bool
+	var completedPods bool
+	var completedJobs bool
+	var olderThan string
+	var dryRun bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Delete evicted pods, completed pods and completed jobs",
+		Long: `Delete the leftovers of finished workloads that clutter every list: pods evicted by the
+kubelet, succeeded pods not owned by a job, and successfully completed jobs together with their
+pods. Jobs created by a CronJob are left to the CronJob's history limits.
+
+Without a category flag all categories are cleaned up. The objects to delete are listed and
+confirmed before anything is removed; --dry-run only lists them.
+
+Examples:
+  # List what would be cleaned up in the current namespace
+  k8stool cleanup --dry-run
+
+  # Delete evicted pods in all namespaces
+  k8stool cleanup --evicted -A
+
+  # Delete jobs that completed more than a week ago without confirmation
+  k8stool cleanup --completed-jobs --older-than 7d --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			age, err := parseAge(olderThan)
+			if err != nil {
+				return err
+			}
+
+			opts := k8s.CleanupOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+				OlderThan:     age,
+			}
+			if evicted {
+				opts.Kinds = append(opts.Kinds, cleanup.EvictedPods)
+			}
+			if completedPods {
+				opts.Kinds = append(opts.Kinds, cleanup.CompletedPods)
+			}
+			if completedJobs {
+				opts.Kinds = append(opts.Kinds, cleanup.CompletedJobs)
+			}
+			if len(opts.Kinds) == 0 {
+				opts.Kinds = []cleanup.Kind{cleanup.EvictedPods, cleanup.CompletedPods, cleanup.CompletedJobs}
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				opts.Namespace = client.GetCurrentNamespace()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			candidates, err := client.FindCleanup(ctx, opts)
+			if err != nil {
+				return err
+			}
+			if len(candidates) == 0 {
+				fmt.Println("Nothing to clean up")
+				return nil
+			}
+
+			printCleanupCandidates(candidates)
+			if dryRun {
+				return nil
+			}
+
+			if !yes {
+				ok, err := confirm(fmt.Sprintf("Delete %d objects", len(candidates)))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("aborted")
+				}
+			}
+
+			results, err := client.Cleanup(ctx, candidates, false)
+			printResults(results, false)
+			if err != nil {
+				return err
+			}
+			return resultsError(results)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Clean up across all namespaces")
+	cmd.Flags().BoolVar(&evicted, "evicted", false, "Delete pods evicted by the kubelet")
+	cmd.Flags().BoolVar(&completedPods, "completed-pods", false, "Delete succeeded pods not owned by a job")
+	cmd.Flags().BoolVar(&completedJobs, "completed-jobs", false, "Delete completed jobs not owned by a CronJob, with their pods")
+	cmd.Flags().StringVar(&olderThan, "older-than", "0s", "Only delete objects that finished at least this long ago, e.g. 12h or 7d")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list what would be deleted")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	markMutating(cmd)
+
+	return cmd
+}
+
+func printCleanupCandidates(candidates []cleanup.Candidate) {
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tFINISHED\tREASON")
+	for _, c := range candidates {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s ago\t%s\n",
+			c.Kind,
+			c.Namespace,
+			c.Name,
+			formatSince(c.Finished),
+			utils.TruncateString(c.Reason, 80),
+		)
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+// parseAge parses a duration that may also be given in days, e.g. 7d
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, errs.Validationf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, errs.Validationf("invalid duration %q", s)
+	}
+	return d, nil
+}
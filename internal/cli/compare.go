@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/compare"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// maxCompareValue is the longest value shown in the comparison table
+const maxCompareValue = 60
+
+func getCompareCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare resources between namespaces or clusters",
+		Long:  "Compare resources between two namespaces, or the same namespace in two clusters, to find drift.",
+	}
+
+	cmd.AddCommand(getCompareNamespaceCmd())
+
+	return cmd
+}
+
+func getCompareNamespaceCmd() *cobra.Command {
+	var otherContext string
+
+	cmd := &cobra.Command{
+		Use:     "ns A [B]",
+		Aliases: []string{"namespace", "namespaces"},
+		Short:   "Compare deployments, configmaps and services of two namespaces",
+		Long: `Compare the deployments, configmaps and services of two namespaces and list every difference.
+
+Deployments are compared by replicas and, per container, by image, environment variables and
+resource requests and limits. Configmaps are compared key by key, services by type, selector
+and ports. Objects that exist on one side only are listed too.
+
+With --context the second namespace is read from another kubeconfig context, which compares
+the same namespace across clusters when B is omitted.
+
+Examples:
+  # Compare staging with production in the current cluster
+  k8stool compare ns staging production
+
+  # Compare the shop namespace between two clusters
+  k8stool compare ns shop --context prod-cluster
+
+  # Compare a namespace with a differently named one in another cluster
+  k8stool compare ns shop-staging shop --context prod-cluster`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			left, right := args[0], args[0]
+			if len(args) == 2 {
+				right = args[1]
+			} else if otherContext == "" {
+				return fmt.Errorf("a second namespace or --context is required")
+			}
+
+			leftClient, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			rightClient := leftClient
+			leftLabel, rightLabel := left, right
+			if otherContext != "" {
+				if rightClient, err = k8s.NewClientForContext(otherContext); err != nil {
+					return err
+				}
+				rightLabel = otherContext + "/" + right
+			}
+
+			ctx := context.Background()
+			leftSnapshot, err := leftClient.SnapshotNamespace(ctx, left)
+			if err != nil {
+				return err
+			}
+			rightSnapshot, err := rightClient.SnapshotNamespace(ctx, right)
+			if err != nil {
+				return err
+			}
+
+			diffs := compare.Namespaces(leftSnapshot, rightSnapshot)
+			if len(diffs) == 0 {
+				fmt.Printf("No differences between %s and %s\n", leftLabel, rightLabel)
+				return nil
+			}
+			printDifferences(diffs, leftLabel, rightLabel)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&otherContext, "context", "", "Kubeconfig context to read the second namespace from")
+
+	return cmd
+}
+
+func printDifferences(diffs []compare.Difference, leftLabel, rightLabel string) {
+	w := newTable(os.Stdout)
+
+	objects := make(map[string]bool)
+	fmt.Fprintf(w, "KIND\tNAME\tFIELD\t%s\t%s\n", leftLabel, rightLabel)
+	for _, d := range diffs {
+		objects[d.Kind+"/"+d.Name] = true
+		if d.Field == "" {
+			fmt.Fprintf(w, "%s\t%s\t\t%s\t%s\n", d.Kind, d.Name, compareValue(d.Left), compareValue(d.Right))
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", d.Kind, d.Name, d.Field, utils.Yellow(compareText(d.Left)), utils.Yellow(compareText(d.Right)))
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d differences in %d objects\n", len(diffs), len(objects))
+}
+
+// compareValue renders presence: an absent object in red, a present one plainly
+func compareValue(v string) string {
+	if v == "" {
+		return utils.Red("<missing>")
+	}
+	return v
+}
+
+// compareText renders a value on a single table line
+func compareText(v string) string {
+	return utils.TruncateString(utils.FormatResourceValue(strings.ReplaceAll(v, "\n", `\n`)), maxCompareValue)
+}
@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// completionCacheTTL is how long completion values fetched from the cluster are reused.
+	// Every TAB runs a new process, so values are cached on disk.
+	completionCacheTTL = 30 * time.Second
+
+	// completionTimeout bounds the API calls of a completion, so an unreachable cluster does not
+	// hang the shell
+	completionTimeout = 3 * time.Second
+)
+
+// completionFunc completes the value of a flag or argument
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// registerCompletions adds value completion to the namespace, context and container flags of
+// every command, and to the arguments of the context and namespace switch commands
+func registerCompletions(root *cobra.Command) {
+	completions := map[string]completionFunc{
+		"namespace": completeNamespaces,
+		"context":   completeContexts,
+		"container": completeContainers,
+	}
+
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for name, fn := range completions {
+			if cmd.Flags().Lookup(name) != nil || cmd.PersistentFlags().Lookup(name) != nil {
+				// A flag inherited from a parent is already registered there
+				_ = cmd.RegisterFlagCompletionFunc(name, fn)
+			}
+		}
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+
+	for _, cmd := range root.Commands() {
+		for _, sub := range cmd.Commands() {
+			if sub.Name() != "switch" {
+				continue
+			}
+			switch cmd.Name() {
+			case "context":
+				sub.ValidArgsFunction = firstArg(completeContexts)
+			case "namespace":
+				sub.ValidArgsFunction = firstArg(completeNamespaces)
+			}
+		}
+	}
+}
+
+// firstArg limits a completion to the first positional argument
+func firstArg(fn completionFunc) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fn(cmd, args, toComplete)
+	}
+}
+
+// completeContexts lists the contexts of the kubeconfig
+func completeContexts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	config, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeNamespaces lists the namespaces of the cluster
+func completeNamespaces(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := cachedCompletion("namespaces", func(ctx context.Context, client *k8s.Client) ([]string, error) {
+		objs, err := client.ListResources(ctx, "namespaces", k8s.ResourceListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(objs))
+		for _, obj := range objs {
+			names = append(names, obj.GetName())
+		}
+		return names, nil
+	})
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeContainers lists the containers of the pod or workload named in the arguments, as
+// POD, TYPE/NAME or TYPE NAME
+func completeContainers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	resourceType, name := "pod", args[0]
+	if parts := strings.SplitN(args[0], "/", 2); len(parts) == 2 {
+		resourceType, name = parts[0], parts[1]
+	} else if len(args) > 1 && isWorkloadType(args[0]) {
+		resourceType, name = args[0], args[1]
+	}
+
+	namespace, _ := cmd.Flags().GetString("namespace")
+	key := strings.Join([]string{"containers", namespace, resourceType, name}, "-")
+	names := cachedCompletion(key, func(ctx context.Context, client *k8s.Client) ([]string, error) {
+		if namespace == "" {
+			namespace = client.GetCurrentNamespace()
+		}
+		obj, err := client.GetResource(ctx, resourceType, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		return containerNames(obj), nil
+	})
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// isWorkloadType reports whether an argument names a resource type with containers
+func isWorkloadType(arg string) bool {
+	switch strings.ToLower(arg) {
+	case "pod", "pods", "po", "deployment", "deployments", "deploy", "statefulset", "statefulsets", "sts",
+		"daemonset", "daemonsets", "ds", "job", "jobs", "replicaset", "replicasets", "rs":
+		return true
+	}
+	return false
+}
+
+// containerNames returns the init and regular containers of a pod or of a workload's pod template
+func containerNames(obj *unstructured.Unstructured) []string {
+	path := []string{"spec", "template", "spec"}
+	if obj.GetKind() == "Pod" {
+		path = []string{"spec"}
+	}
+	var names []string
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(obj.Object, append(path, field)...)
+		for _, c := range containers {
+			if m, ok := c.(map[string]interface{}); ok {
+				if name, ok := m["name"].(string); ok {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// completionCacheEntry is a cached list of completion values
+type completionCacheEntry struct {
+	Time   time.Time `json:"time"`
+	Values []string  `json:"values"`
+}
+
+var unsafeCacheKey = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// cachedCompletion returns the values cached for a key in the current context, fetching and
+// caching them when missing or older than completionCacheTTL. Errors yield no values, since
+// completions must never fail loudly.
+func cachedCompletion(key string, fetch func(ctx context.Context, client *k8s.Client) ([]string, error)) []string {
+	contextName := ""
+	if config, err := clientcmd.NewDefaultClientConfigLoadingRules().Load(); err == nil {
+		contextName = config.CurrentContext
+	}
+
+	var path string
+	if dir, err := os.UserCacheDir(); err == nil {
+		path = filepath.Join(dir, "k8stool", "completion", unsafeCacheKey.ReplaceAllString(contextName+"-"+key, "_")+".json")
+		if data, err := os.ReadFile(path); err == nil {
+			var entry completionCacheEntry
+			if json.Unmarshal(data, &entry) == nil && time.Since(entry.Time) < completionCacheTTL {
+				return entry.Values
+			}
+		}
+	}
+
+	client, err := k8s.NewClient()
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	values, err := fetch(ctx, client)
+	if err != nil {
+		return nil
+	}
+	sort.Strings(values)
+
+	if path != "" {
+		if data, err := json.Marshal(completionCacheEntry{Time: time.Now(), Values: values}); err == nil {
+			if os.MkdirAll(filepath.Dir(path), 0o700) == nil {
+				_ = os.WriteFile(path, data, 0o600)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestFlagCompletion_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() {
+		os.Stdout = oldStdout
+		namespace = ""
+	}()
+
+	// Start without cached completions
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+
+	config, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+	if err != nil {
+		t.Fatalf("failed to load kubeconfig: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "complete namespace flag",
+			args:    []string{"__complete", "get", "pods", "-n", ""},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "default\n")
+				assert.Contains(t, output, "integration-test\n")
+				assert.Contains(t, output, ":4\n")
+				cached, _ := filepath.Glob(filepath.Join(cacheDir, "k8stool", "completion", "*namespaces.json"))
+				assert.Len(t, cached, 1)
+			},
+		},
+		{
+			name:    "complete namespace switch argument",
+			args:    []string{"__complete", "namespace", "switch", "int"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "integration-test\n")
+				assert.Contains(t, output, ":4\n")
+			},
+		},
+		{
+			name:    "complete namespace switch after its argument",
+			args:    []string{"__complete", "namespace", "switch", "default", ""},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.NotContains(t, output, "integration-test")
+				assert.Contains(t, output, ":4\n")
+			},
+		},
+		{
+			name:    "complete context flag",
+			args:    []string{"__complete", "compare", "ns", "default", "--context", ""},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, config.CurrentContext+"\n")
+				assert.Contains(t, output, ":4\n")
+			},
+		},
+		{
+			name:    "complete context switch argument",
+			args:    []string{"__complete", "context", "switch", ""},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, config.CurrentContext+"\n")
+				assert.Contains(t, output, ":4\n")
+			},
+		},
+		{
+			name:    "complete container flag of a pod",
+			args:    []string{"__complete", "exec", "nginx-default", "-c", ""},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Regexp(t, "^nginx-default\n:4\n", output)
+			},
+		},
+		{
+			name:    "complete container flag of a deployment",
+			args:    []string{"__complete", "logs", "deploy/nginx-deploy", "-n", "integration-test", "-c", ""},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Regexp(t, "^nginx\n:4\n", output)
+			},
+		},
+		{
+			name:    "complete container flag of a non-existent pod",
+			args:    []string{"__complete", "exec", "nonexistent-pod", "-c", ""},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Regexp(t, "^:4\n", output)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Run through the root command, as the shell does
+			cmd := rootCmd
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+			namespace = ""
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// confirm asks a yes/no question and reports whether the user answered yes
+func confirm(label string) (bool, error) {
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+	}
+	if _, err := prompt.Run(); err != nil {
+		if errors.Is(err, promptui.ErrAbort) {
+			return false, nil
+		}
+		return false, fmt.Errorf("confirmation failed (use --yes to skip it): %w", err)
+	}
+	return true, nil
+}
+
+// confirmSelection lists the objects a selector matched and asks the user to type how many
+// there are before acting on them, so a selector matching more than expected is caught
+func confirmSelection(verb string, objs []*unstructured.Unstructured) (bool, error) {
+	for _, obj := range objs {
+		fmt.Printf("  %s\n", describeObject(obj))
+	}
+
+	want := strconv.Itoa(len(objs))
+	prompt := promptui.Prompt{
+		Label: fmt.Sprintf("%s %s objects matching the selector? Type %s to confirm", verb, want, want),
+	}
+	answer, err := prompt.Run()
+	if err != nil {
+		if errors.Is(err, promptui.ErrInterrupt) {
+			return false, nil
+		}
+		return false, fmt.Errorf("confirmation failed (use --yes to skip it): %w", err)
+	}
+	return strings.TrimSpace(answer) == want, nil
+}
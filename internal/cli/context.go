@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"text/tabwriter"
 
 	"k8stool/internal/k8s/context"
 
@@ -20,7 +19,7 @@ func getContextCmd() *cobra.Command {
 		Long:    "Manage Kubernetes contexts, including switching between contexts and viewing context information.",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Skip cluster connection for context commands
-			return nil
+			return prepareCommand(cmd)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Initialize context service without cluster access
@@ -95,7 +94,7 @@ func listContextsCmd() *cobra.Command {
 			// Sort contexts by name
 			contexts = contextService.Sort(contexts, context.SortByName)
 
-			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			w := newTable(os.Stdout)
 			fmt.Fprintln(w, "NAME\tCLUSTER\tUSER\tNAMESPACE\tACTIVE")
 			for _, ctx := range contexts {
 				active := ""
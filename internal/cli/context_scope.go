@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/context"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// contextFlags are the --contexts and --all-contexts flags of read-only commands that can read
+// from several clusters at once
+type contextFlags struct {
+	names []string
+	all   bool
+}
+
+func (f *contextFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&f.names, "contexts", nil, "Read from these kubeconfig contexts (comma-separated) instead of the current one")
+	cmd.Flags().BoolVar(&f.all, "all-contexts", false, "Read from every kubeconfig context")
+}
+
+// resolve returns the contexts to read from, or nil for the current context only. Unknown
+// contexts are rejected before any cluster is queried.
+func (f *contextFlags) resolve() ([]string, error) {
+	if !f.all && len(f.names) == 0 {
+		return nil, nil
+	}
+	if f.all && len(f.names) > 0 {
+		return nil, errs.Validationf("--contexts and --all-contexts cannot be used together")
+	}
+	if fromSnapshot != "" {
+		return nil, errs.Validationf("--contexts and --all-contexts cannot be used with --from-snapshot")
+	}
+
+	contextService, err := context.NewContextOnlyService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize context service: %w", err)
+	}
+	available, err := contextService.List()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(available))
+	var all []string
+	for _, c := range available {
+		known[c.Name] = true
+		all = append(all, c.Name)
+	}
+	if f.all {
+		if len(all) == 0 {
+			return nil, errs.NotFoundf("no contexts found in the kubeconfig")
+		}
+		sort.Strings(all)
+		return all, nil
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range f.names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if !known[name] {
+			return nil, errs.NotFoundf("context %q not found in the kubeconfig", name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// listInContexts runs list against every context concurrently and merges the results in the
+// order of the contexts, recording on each item the context it came from. A context that fails,
+// such as an unreachable cluster, is reported on stderr without hiding the others; the command
+// only fails when every context does.
+func listInContexts[T any](contexts []string, setContext func(*T, string), list func(client *k8s.Client) ([]T, error)) ([]T, error) {
+	results := make([][]T, len(contexts))
+	failures := make([]error, len(contexts))
+	var wg sync.WaitGroup
+	for i, name := range contexts {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			client, err := k8s.NewClientForContext(name)
+			if err != nil {
+				failures[i] = err
+				return
+			}
+			results[i], failures[i] = list(client)
+		}(i, name)
+	}
+	wg.Wait()
+
+	if len(contexts) == 1 && failures[0] != nil {
+		return nil, failures[0]
+	}
+
+	var merged []T
+	failed := 0
+	for i, err := range failures {
+		if err != nil {
+			failed++
+			fmt.Fprintln(os.Stderr, utils.Yellow(fmt.Sprintf("Warning: context %s: %v", contexts[i], err)))
+			continue
+		}
+		for j := range results[i] {
+			setContext(&results[i][j], contexts[i])
+		}
+		merged = append(merged, results[i]...)
+	}
+	if failed == len(contexts) {
+		return nil, fmt.Errorf("failed to read from any of the %d contexts", len(contexts))
+	}
+	return merged, nil
+}
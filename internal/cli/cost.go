@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/cost"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getCostCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var url string
+	var service string
+	var window string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "Show the cost of workloads from OpenCost",
+		Long: `Show what each workload cost over a time window, next to its replicas and its average CPU
+and memory requests and usage. Costs come from the allocation API of OpenCost or Kubecost and
+are in the currency it is configured with.
+
+By default OpenCost is queried through the API server proxy at the service opencost/opencost
+on port 9003, so no port-forward is needed. Use --service for another service, or --url for
+an endpoint reachable from this machine, such as a Kubecost frontend (http://host/model).
+
+Examples:
+  # Cost of the workloads in the current namespace over the last 7 days
+  k8stool cost
+
+  # Cost across the cluster over the last day
+  k8stool cost -A --window 24h
+
+  # Query Kubecost through a port-forward
+  k8stool cost -n shop --url http://localhost:9090/model`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			report, err := client.WorkloadCosts(context.Background(), k8s.CostOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+				URL:           url,
+				Service:       service,
+				Window:        window,
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(report)
+			}
+
+			if len(report.Workloads) == 0 {
+				fmt.Println("No workloads found")
+				return nil
+			}
+			return printCosts(report, allNamespaces)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Show workloads in all namespaces")
+	cmd.Flags().StringVar(&url, "url", "", "Base URL of the OpenCost or Kubecost API; overrides --service")
+	cmd.Flags().StringVar(&service, "service", cost.DefaultService, "OpenCost service to query through the API server, as namespace/name:port")
+	cmd.Flags().StringVar(&window, "window", "7d", "Time window to report, e.g. 24h, 7d, today or month")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printCosts(report *k8s.CostReport, showNamespace bool) error {
+	w := newTable(os.Stdout)
+	defer w.Flush()
+
+	if showNamespace {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintf(w, "KIND\tNAME\tREPLICAS\tCPU REQ\tCPU USE\tMEM REQ\tMEM USE\tCOST (%s)\n", report.Window)
+
+	for _, wl := range report.Workloads {
+		if showNamespace {
+			fmt.Fprintf(w, "%s\t", wl.Namespace)
+		}
+		replicas := "-"
+		if wl.Replicas > 0 || wl.Kind == "Deployment" || wl.Kind == "StatefulSet" || wl.Kind == "DaemonSet" {
+			replicas = fmt.Sprintf("%d/%d", wl.ReadyReplicas, wl.Replicas)
+		}
+		if !wl.HasCost {
+			fmt.Fprintf(w, "%s\t%s\t%s\t-\t-\t-\t-\t-\n", wl.Kind, wl.Name, replicas)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%.2f\n",
+			wl.Kind,
+			wl.Name,
+			replicas,
+			utils.FormatMilliCPU(int64(wl.CPURequest*1000)),
+			utils.FormatMilliCPU(int64(wl.CPUUsage*1000)),
+			utils.FormatBytes(int64(wl.MemoryRequest)),
+			utils.FormatBytes(int64(wl.MemoryUsage)),
+			wl.TotalCost,
+		)
+	}
+
+	if showNamespace {
+		fmt.Fprint(w, "\t")
+	}
+	fmt.Fprintf(w, "TOTAL\t\t\t\t\t\t\t%.2f\n", report.TotalCost)
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/cronjobs"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// cronJobTimeLayout is how scheduled times are shown, in local time
+const cronJobTimeLayout = "Mon Jan 2 15:04 MST"
+
+func getCronJobsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cronjobs",
+		Aliases: []string{"cronjob", "cj"},
+		Short:   "Show cronjobs with their recent runs and next scheduled run",
+		Long: `Show cronjobs with the result of their recent runs and when they run next, so whether last
+night's job ran is one command.
+
+Schedules are evaluated in the time zone of the cronjob: its timeZone field or a CRON_TZ=
+prefix, and UTC otherwise, which is where kube-controller-manager usually runs. Times are
+shown in local time, or in UTC with --utc.`,
+	}
+
+	cmd.AddCommand(getCronJobsListCmd())
+	cmd.AddCommand(getCronJobsHistoryCmd())
+
+	return cmd
+}
+
+func getCronJobsListCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List cronjobs with their last result and next run",
+		Long: `List cronjobs with their schedule, the result of their most recent run and their next
+scheduled run. Cronjobs that missed a run, for instance because the controller was down or a
+previous run was still active, are reported below the table.
+
+Examples:
+  # List the cronjobs of the current namespace
+  k8stool cronjobs list
+
+  # List cronjobs in all namespaces as JSON
+  k8stool cronjobs list -A -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			list, err := client.ListCronJobs(context.Background(), k8s.CronJobListOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(list)
+			}
+			if len(list) == 0 {
+				fmt.Println("No cronjobs found")
+				return nil
+			}
+			printCronJobs(list, allNamespaces)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List cronjobs in all namespaces")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func getCronJobsHistoryCmd() *cobra.Command {
+	var namespace string
+	var limit int
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "history NAME",
+		Short: "Show the recent runs of a cronjob and its next run",
+		Long: `Show the schedule of a cronjob, when it last ran and succeeded, when it runs next, and its
+most recent Jobs with their result, duration and number of attempts.
+
+Only the Jobs the cluster still keeps are shown; how many are kept is set by the
+successfulJobsHistoryLimit and failedJobsHistoryLimit of the cronjob.
+
+Examples:
+  # Did last night's backup run?
+  k8stool cronjobs history backup -n prod
+
+  # Show the last 10 runs
+  k8stool cronjobs history report --limit 10`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			cj, err := client.CronJobHistory(context.Background(), namespace, args[0], k8s.CronJobHistoryOptions{Limit: limit})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(cj)
+			}
+			printCronJobHistory(cj)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().IntVar(&limit, "limit", cronjobs.DefaultHistoryLimit, "Number of most recent runs to show")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printCronJobs(list []k8s.CronJob, showNamespace bool) {
+	w := newTable(os.Stdout)
+
+	var header []string
+	if showNamespace {
+		header = append(header, "NAMESPACE")
+	}
+	header = append(header, "NAME", "SCHEDULE", "TIMEZONE", "ACTIVE", "LAST RUN", "NEXT RUN", "LAST RESULT")
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, cj := range list {
+		var row []string
+		if showNamespace {
+			row = append(row, cj.Namespace)
+		}
+		lastRun := "-"
+		if cj.LastRun != nil {
+			lastRun = formatAgo(cj.LastRun.Created)
+		}
+		row = append(row, cj.Name, cj.Schedule, orDash(cj.TimeZone), fmt.Sprintf("%d", cj.Active), lastRun, formatNextRun(cj), formatRunResult(cj.LastRun))
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	for _, cj := range list {
+		if cj.MissedRun != nil {
+			fmt.Printf("\n%s %s/%s missed its run due %s\n", utils.Yellow("Warning:"), cj.Namespace, cj.Name, formatTimestamp(*cj.MissedRun, cronJobTimeLayout))
+		}
+		if cj.ScheduleError != "" {
+			fmt.Printf("\n%s %s/%s has an invalid schedule: %s\n", utils.Yellow("Warning:"), cj.Namespace, cj.Name, cj.ScheduleError)
+		}
+	}
+}
+
+func printCronJobHistory(cj *k8s.CronJob) {
+	w := newTable(os.Stdout)
+	fmt.Fprintf(w, "Name:\t%s\n", cj.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", cj.Namespace)
+	fmt.Fprintf(w, "Schedule:\t%s\n", cj.Schedule)
+	if cj.ScheduleError != "" {
+		fmt.Fprintf(w, "Schedule Error:\t%s\n", cj.ScheduleError)
+	} else {
+		fmt.Fprintf(w, "Time Zone:\t%s\n", cj.TimeZone)
+	}
+	fmt.Fprintf(w, "Last Schedule:\t%s\n", formatCronJobTime(cj.LastSchedule))
+	fmt.Fprintf(w, "Last Successful:\t%s\n", formatCronJobTime(cj.LastSuccessful))
+	fmt.Fprintf(w, "Next Run:\t%s\n", formatNextRun(*cj))
+	if cj.MissedRun != nil {
+		fmt.Fprintf(w, "Missed Run:\t%s\n", formatTimestamp(*cj.MissedRun, cronJobTimeLayout))
+	}
+	fmt.Fprintf(w, "Active Jobs:\t%d\n", cj.Active)
+	w.Flush()
+
+	fmt.Println()
+	if len(cj.Runs) == 0 {
+		fmt.Println("No runs found")
+		return
+	}
+	w = newTable(os.Stdout)
+	defer w.Flush()
+	fmt.Fprintln(w, "JOB\tSTARTED\tDURATION\tATTEMPTS\tRESULT")
+	for _, run := range cj.Runs {
+		started, duration := "-", "-"
+		if run.Start != nil {
+			started = formatTimestamp(*run.Start, cronJobTimeLayout)
+			duration = utils.FormatDuration(run.Duration)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", run.Name, started, duration, run.Attempts, formatRunResult(&run))
+	}
+}
+
+// formatNextRun renders when a cronjob runs next, in local time
+func formatNextRun(cj k8s.CronJob) string {
+	switch {
+	case cj.Suspended:
+		return "suspended"
+	case cj.NextRun == nil:
+		return "-"
+	}
+	return fmt.Sprintf("%s (in %s)", formatTimestamp(*cj.NextRun, cronJobTimeLayout), utils.FormatDuration(time.Until(*cj.NextRun)))
+}
+
+// formatCronJobTime renders a past time of a cronjob with how long ago it was
+func formatCronJobTime(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	return fmt.Sprintf("%s (%s)", formatTimestamp(*t, cronJobTimeLayout), formatAgo(*t))
+}
+
+// formatRunResult colors the result of a run, with the reason of a failure
+func formatRunResult(run *k8s.CronJobRun) string {
+	if run == nil {
+		return "-"
+	}
+	switch run.Result {
+	case cronjobs.Succeeded:
+		return utils.Green(run.Result)
+	case cronjobs.Failed:
+		if run.Reason != "" {
+			return utils.Red(run.Result + " (" + run.Reason + ")")
+		}
+		return utils.Red(run.Result)
+	}
+	return utils.Yellow(run.Result)
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+
+	"github.com/spf13/cobra"
+)
+
+// applyDefaults sets the flags the config file has defaults for on the command being run,
+// unless they were given on the command line. Every command of the defaults is checked, so a
+// misspelled one is reported whichever command runs.
+func applyDefaults(cmd *cobra.Command) error {
+	paths := make([]string, 0, len(userConfig.Defaults))
+	for path := range userConfig.Defaults {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	root := cmd.Root()
+	for _, path := range paths {
+		target, rest, err := root.Find(strings.Fields(path))
+		if err != nil || len(rest) > 0 || target == root {
+			return errs.New(errs.Validation, "invalid defaults in %s: unknown command %q", userConfigPath, path)
+		}
+		if target != cmd {
+			continue
+		}
+
+		flags := userConfig.Defaults[path]
+		names := make([]string, 0, len(flags))
+		for name := range flags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			flag := cmd.Flags().Lookup(name)
+			if flag == nil {
+				return errs.New(errs.Validation, "invalid defaults in %s: unknown flag --%s for %q", userConfigPath, name, path)
+			}
+			if flag.Changed {
+				continue
+			}
+			// The value is set directly so the flag is not marked as changed, and commands can
+			// still tell a default from a flag given on the command line
+			if err := flag.Value.Set(defaultValue(flags[name])); err != nil {
+				return errs.New(errs.Validation, "invalid defaults in %s: --%s for %q: %v", userConfigPath, name, path, err)
+			}
+			flag.DefValue = flag.Value.String()
+		}
+	}
+	return nil
+}
+
+// defaultValue formats a value of the config file as it would be typed on the command line;
+// lists become comma-separated
+func defaultValue(value interface{}) string {
+	if list, ok := value.([]interface{}); ok {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"testing"
+
+	"k8stool/internal/config"
+	"k8stool/internal/k8s/errs"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	oldConfig := userConfig
+	defer func() { userConfig = oldConfig }()
+
+	// newPods returns a get pods command under a root, with the flags it was given parsed
+	newPods := func(args ...string) *cobra.Command {
+		root := &cobra.Command{Use: "k8stool"}
+		get := &cobra.Command{Use: "get"}
+		pods := &cobra.Command{Use: "pods", Run: func(cmd *cobra.Command, args []string) {}}
+		pods.Flags().StringP("output", "o", "", "")
+		pods.Flags().StringSlice("columns", nil, "")
+		pods.Flags().Bool("wide", false, "")
+		get.AddCommand(pods)
+		root.AddCommand(get)
+		require.NoError(t, pods.ParseFlags(args))
+		return pods
+	}
+
+	t.Run("defaults are set without marking flags changed", func(t *testing.T) {
+		userConfig = &config.Config{Defaults: map[string]map[string]interface{}{
+			"get pods": {"output": "wide", "columns": []interface{}{"name", "status"}, "wide": true},
+		}}
+		pods := newPods()
+		require.NoError(t, applyDefaults(pods))
+
+		for name, want := range map[string]string{"output": "wide", "columns": "[name,status]", "wide": "true"} {
+			flag := pods.Flags().Lookup(name)
+			assert.Equal(t, want, flag.Value.String(), name)
+			assert.Equal(t, want, flag.DefValue, name)
+			assert.False(t, flag.Changed, name)
+		}
+	})
+
+	t.Run("command line flags win", func(t *testing.T) {
+		userConfig = &config.Config{Defaults: map[string]map[string]interface{}{
+			"get pods": {"output": "wide"},
+		}}
+		pods := newPods("-o", "json")
+		require.NoError(t, applyDefaults(pods))
+
+		flag := pods.Flags().Lookup("output")
+		assert.Equal(t, "json", flag.Value.String())
+		assert.True(t, flag.Changed)
+	})
+
+	t.Run("other commands are left alone", func(t *testing.T) {
+		userConfig = &config.Config{Defaults: map[string]map[string]interface{}{
+			"get": {"output": "wide"},
+		}}
+		pods := newPods()
+		require.NoError(t, applyDefaults(pods))
+		assert.Equal(t, "", pods.Flags().Lookup("output").Value.String())
+	})
+
+	errorTests := []struct {
+		name     string
+		defaults map[string]map[string]interface{}
+		wantErr  string
+	}{
+		{
+			name:     "unknown command",
+			defaults: map[string]map[string]interface{}{"get nodes": {"output": "wide"}},
+			wantErr:  `unknown command "get nodes"`,
+		},
+		{
+			name:     "unknown flag",
+			defaults: map[string]map[string]interface{}{"get pods": {"sort": "age"}},
+			wantErr:  `unknown flag --sort for "get pods"`,
+		},
+		{
+			name:     "invalid value",
+			defaults: map[string]map[string]interface{}{"get pods": {"wide": "sometimes"}},
+			wantErr:  `--wide for "get pods"`,
+		},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			userConfig = &config.Config{Defaults: tt.defaults}
+			pods := newPods()
+			err := applyDefaults(pods)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, errs.Validation, errs.CategoryOf(err))
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func getDeleteCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var selector string
+	var files []string
+	var recursive bool
+	var cascade string
+	var yes bool
+	var opts resources.DeleteOptions
+
+	cmd := &cobra.Command{
+		Use:   "delete ([-f FILENAME] | TYPE [NAME...] | TYPE -l SELECTOR)",
+		Short: "Delete resources from manifests, by name or by selector",
+		Long: `Delete resources listed in manifests, named on the command line, or matching a label selector.
+
+The objects to delete are listed and confirmed before anything is removed; with a selector
+their number must be typed back. Objects are deleted --concurrency at a time. By default the
+command waits until every object is gone; --cascade controls how dependents are deleted.
+
+Examples:
+  # Delete everything in a manifest
+  k8stool delete -f deployment.yaml
+
+  # Delete pods by name
+  k8stool delete pods web-1 web-2
+
+  # Delete deployments matching a selector without confirmation
+  k8stool delete deploy -l app=web --yes
+
+  # Delete a deployment but keep its pods
+  k8stool delete deploy web --cascade orphan`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(files) > 0 && len(args) > 0 {
+				return errs.Validationf("specify either -f or a resource type, not both")
+			}
+			if len(files) == 0 && len(args) == 0 {
+				return errs.Validationf("specify -f or a resource type")
+			}
+			if len(args) == 1 && selector == "" {
+				return errs.Validationf("specify resource names or a selector with -l")
+			}
+			if len(args) > 1 && selector != "" {
+				return errs.Validationf("specify either resource names or a selector, not both")
+			}
+
+			propagation, err := parseCascade(cascade)
+			if err != nil {
+				return err
+			}
+			opts.Propagation = propagation
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+			opts.Namespace = namespace
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			var objs []*unstructured.Unstructured
+			var missing []resources.Result
+			switch {
+			case len(files) > 0:
+				objs, err = resources.Load(files, recursive)
+			case selector != "":
+				objs, err = client.ListResources(ctx, args[0], resources.ListOptions{
+					Namespace:     namespace,
+					AllNamespaces: allNamespaces,
+					Selector:      selector,
+				})
+			default:
+				objs, missing, err = getNamedResources(ctx, client, args[0], namespace, args[1:], opts.IgnoreNotFound)
+			}
+			if err != nil {
+				return err
+			}
+
+			printResults(missing, false)
+			if len(objs) == 0 {
+				fmt.Println("No resources found")
+				return nil
+			}
+
+			if !yes && !opts.DryRun {
+				var ok bool
+				if selector != "" {
+					ok, err = confirmSelection("Delete", objs)
+				} else {
+					for _, obj := range objs {
+						fmt.Printf("  %s\n", describeObject(obj))
+					}
+					ok, err = confirm(fmt.Sprintf("Delete %d objects", len(objs)))
+				}
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("aborted")
+				}
+			}
+
+			results, err := client.Delete(ctx, objs, opts)
+			printResults(results, opts.DryRun)
+			if err != nil {
+				return err
+			}
+			return resultsError(results)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Match the selector across all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().StringSliceVarP(&files, "filename", "f", nil, "File, directory or URL with the resources to delete (repeatable, - for stdin)")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, "Process directories recursively")
+	cmd.Flags().StringVar(&cascade, "cascade", "background", "How dependents are deleted: background, foreground or orphan")
+	cmd.Flags().BoolVar(&opts.Wait, "wait", true, "Wait until the resources are gone")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 2*time.Minute, "Maximum time to wait for deletion")
+	cmd.Flags().BoolVar(&opts.IgnoreNotFound, "ignore-not-found", false, "Do not fail on resources that do not exist")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Submit a server-side dry run without deleting anything")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", resources.DefaultConcurrency, "Number of objects deleted at a time")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	markMutating(cmd)
+
+	return cmd
+}
+
+func parseCascade(cascade string) (metav1.DeletionPropagation, error) {
+	switch strings.ToLower(cascade) {
+	case "background", "true":
+		return metav1.DeletePropagationBackground, nil
+	case "foreground":
+		return metav1.DeletePropagationForeground, nil
+	case "orphan", "false":
+		return metav1.DeletePropagationOrphan, nil
+	default:
+		return "", errs.Validationf("invalid cascade %q, use background, foreground or orphan", cascade)
+	}
+}
+
+// getNamedResources fetches objects by name, returning missing ones separately when ignored
+func getNamedResources(ctx context.Context, client *k8s.Client, resourceType, namespace string, names []string, ignoreNotFound bool) ([]*unstructured.Unstructured, []resources.Result, error) {
+	var objs []*unstructured.Unstructured
+	var missing []resources.Result
+	for _, name := range names {
+		obj, err := client.GetResource(ctx, resourceType, namespace, name)
+		if apierrors.IsNotFound(err) && ignoreNotFound {
+			missing = append(missing, resources.Result{Kind: resourceType, Namespace: namespace, Name: name, Action: resources.NotFound})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, missing, nil
+}
+
+// describeObject returns namespace/kind/name of an object for confirmation prompts
+func describeObject(obj *unstructured.Unstructured) string {
+	ref := strings.ToLower(obj.GetKind()) + "/" + obj.GetName()
+	if ns := obj.GetNamespace(); ns != "" {
+		return ns + "/" + ref
+	}
+	return ref
+}
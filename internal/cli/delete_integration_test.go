@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	manifest := filepath.Join(t.TempDir(), "configmap.yaml")
+	content := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: k8stool-delete-test
+  labels:
+    app: k8stool-delete-test
+data:
+  key: value
+`
+	if err := os.WriteFile(manifest, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		cmd      func() *cobra.Command
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "apply object to delete",
+			cmd:     getApplyCmd,
+			args:    []string{"-f", manifest, "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "configmap/k8stool-delete-test created")
+			},
+		},
+		{
+			name:    "delete by selector dry run",
+			cmd:     getDeleteCmd,
+			args:    []string{"configmaps", "-l", "app=k8stool-delete-test", "--namespace", "integration-test", "--dry-run"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "integration-test/configmap/k8stool-delete-test deleted (dry run)")
+			},
+		},
+		{
+			name:    "delete from manifest",
+			cmd:     getDeleteCmd,
+			args:    []string{"-f", manifest, "--namespace", "integration-test", "--yes"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "integration-test/configmap/k8stool-delete-test deleted")
+			},
+		},
+		{
+			name:    "delete missing object by name",
+			cmd:     getDeleteCmd,
+			args:    []string{"cm", "k8stool-delete-test", "--namespace", "integration-test", "--yes", "--ignore-not-found"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "k8stool-delete-test not found")
+			},
+		},
+		{
+			name:     "delete without target",
+			cmd:      getDeleteCmd,
+			args:     []string{"configmaps"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := tt.cmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/pkg/utils"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+func getDeployCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy",
+		Short: "Follow deployment rollouts",
+		Long:  "Follow the rollout of deployments.",
+	}
+
+	cmd.AddCommand(getDeployWatchCmd())
+
+	return cmd
+}
+
+func getDeployWatchCmd() *cobra.Command {
+	var namespace string
+	var timeout time.Duration
+	var failOnCrashLoop bool
+
+	cmd := &cobra.Command{
+		Use:     "watch NAME",
+		Aliases: []string{"status"},
+		Short:   "Wait for a deployment rollout to finish",
+		Long: `Follow the rollout of a deployment until all replicas are updated and available, and exit
+non-zero when it fails. A rollout fails when the deployment exceeds its progress deadline
+(10 minutes by default) or when --timeout expires.
+
+On a terminal the rollout is drawn as a progress bar of updated and available replicas, with
+an ETA based on how fast the new pods have become ready so far. Otherwise, such as in CI logs,
+a line is printed whenever the rollout moves on.
+
+Pods of the new revision that crash loop or cannot pull their image are reported as they
+appear. With --fail-on-crashloop the rollout fails on the first of them instead of waiting
+for the progress deadline, which keeps CI pipelines from hanging on a broken release.
+
+Exit codes: 0 when the rollout completed, 1 when it failed, 3 when the deployment does not
+exist and 5 when --timeout expired.
+
+Examples:
+  # Wait for a rollout
+  k8stool deploy watch my-app
+
+  # Fail a CI job as soon as the new pods crash
+  k8stool deploy watch my-app -n prod --fail-on-crashloop --timeout 5m`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			// On a terminal the rollout is drawn as a progress bar, otherwise as messages
+			var progress *rolloutProgress
+			if isatty.IsTerminal(os.Stdout.Fd()) {
+				progress = &rolloutProgress{out: os.Stdout}
+			}
+
+			var lastMessage string
+			reported := make(map[string]bool)
+			status, err := client.WatchRollout(ctx, namespace, args[0], k8s.RolloutOptions{
+				Timeout:         timeout,
+				FailOnCrashLoop: failOnCrashLoop,
+				OnProgress: func(status k8s.RolloutStatus) {
+					if progress == nil && status.Message != lastMessage {
+						fmt.Println(status.Message)
+						lastMessage = status.Message
+					}
+					for _, pod := range status.FailingPods {
+						key := pod.Name + "/" + pod.Container + "/" + pod.Reason
+						if reported[key] {
+							continue
+						}
+						reported[key] = true
+						line := fmt.Sprintf("  pod %s: container %s is in %s", pod.Name, pod.Container, pod.Reason)
+						if pod.Restarts > 0 {
+							line += fmt.Sprintf(" (%d restarts)", pod.Restarts)
+						}
+						if pod.Message != "" {
+							line += ": " + pod.Message
+						}
+						if progress != nil {
+							progress.println(utils.Red(line))
+						} else {
+							fmt.Println(utils.Red(line))
+						}
+					}
+					if progress != nil {
+						progress.update(status)
+					}
+				},
+			})
+			if progress != nil {
+				progress.done()
+				if status != nil && status.Complete {
+					fmt.Println(status.Message)
+				}
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum time to wait for the rollout; 0 waits until the progress deadline")
+	cmd.Flags().BoolVar(&failOnCrashLoop, "fail-on-crashloop", false, "Fail as soon as a new pod crash loops or cannot pull its image")
+
+	return cmd
+}
@@ -0,0 +1,128 @@
+//go:build linux
+// +build linux
+
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rolloutDeployment is a deployment that rolls out as the test watches it
+const rolloutDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: k8stool-rollout
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: k8stool-rollout
+  template:
+    metadata:
+      labels:
+        app: k8stool-rollout
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:alpine
+`
+
+func TestDeployWatchProgress_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	createTestObjects(t, rolloutDeployment, "integration-test")
+
+	tests := []struct {
+		name     string
+		args     []string
+		terminal bool
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:     "rollout on a terminal is drawn as a bar",
+			args:     []string{"watch", "k8stool-rollout", "-n", "integration-test", "--timeout", "3m"},
+			terminal: true,
+			wantErr:  false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "\r\033[K[")
+				assert.Contains(t, output, "2/2 updated, 2/2 available, ETA ")
+				assert.Contains(t, output, `deployment "k8stool-rollout" successfully rolled out`)
+			},
+		},
+		{
+			name:     "rollout status into a pipe is printed as messages",
+			args:     []string{"status", "k8stool-rollout", "-n", "integration-test", "--timeout", "3m"},
+			terminal: false,
+			wantErr:  false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `deployment "k8stool-rollout" successfully rolled out`)
+				assert.NotContains(t, output, "\033[K")
+				assert.NotContains(t, output, "ETA")
+			},
+		},
+		{
+			name:     "non-existent deployment on a terminal",
+			args:     []string{"watch", "non-existent", "-n", "integration-test"},
+			terminal: true,
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {
+				assert.NotContains(t, output, "ETA")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Capture output through a pipe, or through a pseudo-terminal like a user's
+			r, w, _ := os.Pipe()
+			if tt.terminal {
+				r, w = openPTY(t)
+			}
+			os.Stdout = w
+
+			var buf bytes.Buffer
+			copied := make(chan struct{})
+			go func() {
+				// Reading the terminal fails once it is closed
+				io.Copy(&buf, r)
+				close(copied)
+			}()
+
+			// Create fresh command for each test
+			cmd := getDeployCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			<-copied
+			r.Close()
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
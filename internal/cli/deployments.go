@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"os"
 	"sort"
-	"text/tabwriter"
 
 	k8s "k8stool/internal/k8s/client"
 	"k8stool/internal/k8s/deployments"
+	"k8stool/internal/k8s/errs"
 	"k8stool/pkg/utils"
 
 	"github.com/spf13/cobra"
@@ -20,28 +20,78 @@ func getDeploymentsCmd() *cobra.Command {
 	var sortBy string
 	var reverse bool
 	var showMetrics bool
+	var excludeNamespaces []string
+	var clusters contextFlags
+	var output string
+	var interactive bool
 
 	cmd := &cobra.Command{
 		Use:     "deployments",
 		Aliases: []string{"deploy"},
 		Short:   "Get deployments",
+		Long: `List deployments with their replicas, age and status.
+
+With -i the deployments are shown in a picker instead. Choosing one opens a menu to list its
+pods, describe it, list its events or forward one of its ports; its pods have a menu of their
+own to describe them, read their logs, open a shell, list their events or port-forward. Each
+action comes back to its menu, Back goes up a level and Quit or Ctrl+C ends.
+
+Examples:
+  # List deployments in the current namespace
+  k8stool deployments
+
+  # Pick a deployment of the shop namespace and drill down to its pods
+  k8stool deployments -n shop -i`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := k8s.NewClient()
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			contexts, err := clusters.resolve()
 			if err != nil {
 				return err
 			}
+			if interactive {
+				if err := checkBrowse(output, contexts); err != nil {
+					return err
+				}
+			}
 
-			// If namespace flag not provided and not all namespaces, use the client's current namespace
-			if !allNamespaces && namespace == "" {
-				ctx, err := client.GetCurrentContext()
+			// List deployments in every selected namespace, with their metrics if requested
+			list := func(client *k8s.Client) ([]deployments.Deployment, error) {
+				scope := resolveNamespaceScope(client, namespace, allNamespaces, excludeNamespaces)
+				deploymentList, err := listInScope(scope, func(d deployments.Deployment) string { return d.Namespace }, func(ns string) ([]deployments.Deployment, error) {
+					return client.DeploymentService.List(ns, ns == "", selector)
+				})
 				if err != nil {
-					return err
+					return nil, err
 				}
-				namespace = ctx.Namespace
+				if showMetrics {
+					if err := client.DeploymentService.AddMetrics(deploymentList); err != nil {
+						return nil, fmt.Errorf("failed to get metrics: %v", err)
+					}
+				}
+				return deploymentList, nil
 			}
 
-			// List deployments using the deployments service
-			deploymentList, err := client.DeploymentService.List(namespace, allNamespaces, selector)
+			var deploymentList []deployments.Deployment
+			if contexts != nil {
+				deploymentList, err = listInContexts(contexts, func(d *deployments.Deployment, name string) { d.Context = name }, list)
+			} else {
+				var client *k8s.Client
+				if client, err = k8s.NewClient(); err == nil && interactive {
+					return endBrowse(browseDeployments(client, func() ([]deployments.Deployment, error) {
+						deploymentList, err := list(client)
+						if err != nil {
+							return nil, err
+						}
+						return deploymentList, sortDeployments(deploymentList, sortBy, reverse)
+					}))
+				}
+				if err == nil {
+					deploymentList, err = list(client)
+				}
+			}
 			if err != nil {
 				return err
 			}
@@ -51,23 +101,25 @@ func getDeploymentsCmd() *cobra.Command {
 				return err
 			}
 
-			// If metrics flag is set, add metrics information
-			if showMetrics {
-				if err := client.DeploymentService.AddMetrics(deploymentList); err != nil {
-					return fmt.Errorf("failed to get metrics: %v", err)
-				}
+			if output == "json" {
+				return printJSON(deploymentList)
 			}
 
 			return printDeployments(deploymentList, showMetrics)
 		},
 	}
 
-	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (comma-separated for several)")
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List deployments across all namespaces")
+	cmd.Flags().StringSliceVar(&excludeNamespaces, "exclude-namespace", nil, "Namespaces to leave out; implies all namespaces when -n is not set")
 	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
 	cmd.Flags().StringVar(&sortBy, "sort", "", "Sort by (name, status, age)")
 	cmd.Flags().BoolVar(&reverse, "reverse", false, "Reverse sort order")
 	cmd.Flags().BoolVar(&showMetrics, "metrics", false, "Show resource metrics")
+	clusters.register(cmd)
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Pick a deployment and drill down to its pods, logs, events and ports")
+	markQueryable(cmd)
 
 	return cmd
 }
@@ -98,13 +150,13 @@ func sortDeployments(deployments []deployments.Deployment, sortBy string, revers
 			return deployments[i].Age < deployments[j].Age
 		})
 	default:
-		return fmt.Errorf("invalid sort key: %s", sortBy)
+		return errs.Validationf("invalid sort key: %s", sortBy)
 	}
 	return nil
 }
 
 func printDeployments(deployments []deployments.Deployment, showMetrics bool) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := newTable(os.Stdout)
 	defer w.Flush()
 
 	// Check if we need to show namespace column by checking if deployments are from different namespaces
@@ -119,7 +171,19 @@ func printDeployments(deployments []deployments.Deployment, showMetrics bool) er
 		}
 	}
 
+	// Deployments read from several contexts are told apart by a context column
+	showContext := false
+	for _, d := range deployments {
+		if d.Context != "" {
+			showContext = true
+			break
+		}
+	}
+
 	// Print header based on what columns we're showing
+	if showContext {
+		fmt.Fprint(w, "CONTEXT\t")
+	}
 	if showNamespace {
 		if showMetrics {
 			fmt.Fprintln(w, "NAMESPACE\tNAME\tREADY\tUP-TO-DATE\tAVAILABLE\tAGE\tCPU\tMEMORY\tSTATUS")
@@ -136,8 +200,11 @@ func printDeployments(deployments []deployments.Deployment, showMetrics bool) er
 
 	for _, d := range deployments {
 		ready := fmt.Sprintf("%d/%d", d.ReadyReplicas, d.Replicas)
-		age := utils.FormatDuration(d.Age)
+		age := formatAge(d.Age)
 
+		if showContext {
+			fmt.Fprint(w, d.Context+"\t")
+		}
 		if showNamespace {
 			if showMetrics && d.Metrics != nil {
 				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\n",
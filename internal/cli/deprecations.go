@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/deprecations"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getDeprecationsCmd() *cobra.Command {
+	var targetVersion string
+	var files []string
+	var recursive bool
+	var live bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "deprecations",
+		Short: "Find objects using deprecated or removed API versions",
+		Long: `Find objects using built-in API versions that are deprecated or removed in a target
+Kubernetes version, together with the version to migrate to. Run it before upgrading a cluster.
+
+The API server converts objects to every version it serves, so the version an object is read
+with does not reveal what its owners use. Instead, the API versions recorded for each field
+manager and in the last-applied-configuration annotation of live objects are checked. A
+finding names the manager, e.g. helm or kubectl-client-side-apply, that still sends the
+deprecated version.
+
+Manifests given with -f are checked as well. By default the target is the minor version after
+the cluster's. The command fails if any object uses an API version removed in the target.
+
+Examples:
+  # Check live objects against the next Kubernetes version
+  k8stool deprecations
+
+  # Check against a specific version
+  k8stool deprecations --target-version 1.32
+
+  # Check manifests only, without scanning the cluster
+  k8stool deprecations -f manifests/ -R --live=false --target-version 1.29`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			if !live && len(files) == 0 {
+				return fmt.Errorf("nothing to check: pass manifests with -f or enable --live")
+			}
+
+			var docs []resources.Document
+			if len(files) > 0 {
+				var err error
+				if docs, err = resources.LoadDocuments(files, recursive); err != nil {
+					return err
+				}
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			report, err := client.ScanDeprecations(ctx, k8s.DeprecationOptions{
+				TargetVersion: targetVersion,
+				Live:          live,
+				Documents:     docs,
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				if err := printJSON(report); err != nil {
+					return err
+				}
+			} else {
+				printDeprecations(report)
+			}
+
+			removed := 0
+			for _, f := range report.Findings {
+				if f.Removed {
+					removed++
+				}
+			}
+			if removed > 0 {
+				return fmt.Errorf("%d objects use API versions removed in %s", removed, report.TargetVersion)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&targetVersion, "target-version", "", "Kubernetes version to check against (default: the minor version after the cluster's)")
+	cmd.Flags().StringSliceVarP(&files, "filename", "f", nil, "Manifest file, directory or URL to check (repeatable, - for stdin)")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, "Process directories recursively")
+	cmd.Flags().BoolVar(&live, "live", true, "Check the objects in the cluster")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printDeprecations(report *deprecations.Report) {
+	if report.ServerVersion != "" {
+		fmt.Printf("Server version: %s, checking against %s\n\n", report.ServerVersion, report.TargetVersion)
+	} else {
+		fmt.Printf("Checking against %s\n\n", report.TargetVersion)
+	}
+
+	for _, warning := range report.Warnings {
+		fmt.Fprintf(os.Stderr, "%s %s\n", utils.Yellow("Warning:"), warning)
+	}
+
+	if len(report.Findings) == 0 {
+		fmt.Println("No deprecated API versions found")
+		return
+	}
+
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "STATUS\tKIND\tNAME\tAPI VERSION\tREPLACEMENT\tSOURCE")
+	for _, f := range report.Findings {
+		status := utils.Yellow("deprecated in " + f.DeprecatedIn)
+		if f.Removed {
+			status = utils.Red("removed in " + f.RemovedIn)
+		}
+		name := f.Name
+		if f.Namespace != "" {
+			name = f.Namespace + "/" + name
+		}
+		replacement := f.Replacement
+		if replacement == "" {
+			replacement = "<none>"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", status, f.Kind, name, f.APIVersion, replacement, f.Source)
+	}
+	w.Flush()
+}
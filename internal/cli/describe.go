@@ -1,14 +1,16 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
-	"text/tabwriter"
 	"time"
 
 	k8s "k8stool/internal/k8s/client"
 	"k8stool/internal/k8s/deployments"
+	"k8stool/internal/k8s/errs"
 	"k8stool/internal/k8s/pods"
 
 	"github.com/spf13/cobra"
@@ -20,6 +22,11 @@ var resourceTypeAliases = map[string]string{
 	"pods":        "pod",
 	"deploy":      "deployment",
 	"deployments": "deployment",
+	"cm":          "configmap",
+	"configmaps":  "configmap",
+	"secrets":     "secret",
+	"no":          "node",
+	"nodes":       "node",
 }
 
 func getDescribeCmd() *cobra.Command {
@@ -34,6 +41,16 @@ func getDescribeCmd() *cobra.Command {
 Supported resource types:
   - pod (po, pods)
   - deployment (deploy, deployments)
+  - configmap (cm, configmaps)
+  - secret (secrets)
+  - node (no, nodes)
+
+ConfigMaps and Secrets are shown with the size of each key, when and by whom they were last
+modified, and the workloads in the namespace that use them through env, envFrom, volumes or
+image pull secrets. Secret values are never shown.
+
+Nodes are shown with their cloud provider, instance type, region and zone, capacity type
+(on-demand or spot) and node group when their labels reveal them.
 
 Examples:
   # Describe a pod
@@ -42,6 +59,12 @@ Examples:
   # Describe a deployment
   k8stool describe deploy my-deployment
 
+  # Find out which workloads use a configmap
+  k8stool describe cm app-config
+
+  # Describe a node with its instance type and node group
+  k8stool describe node worker-1
+
   # Describe a pod in a specific namespace
   k8stool describe pod my-pod --namespace my-namespace`,
 		Args: cobra.ExactArgs(2),
@@ -69,6 +92,8 @@ Examples:
 				ns = currentCtx.Namespace
 			}
 
+			defer startPager()()
+
 			switch resourceType {
 			case "pod":
 				details, err := client.PodService.Describe(ns, name)
@@ -82,8 +107,26 @@ Examples:
 					return err
 				}
 				return printDeploymentDetails(details)
+			case "configmap":
+				desc, err := client.DescribeConfigMap(context.Background(), ns, name)
+				if err != nil {
+					return err
+				}
+				return printConfigDataDetails(desc)
+			case "secret":
+				desc, err := client.DescribeSecret(context.Background(), ns, name)
+				if err != nil {
+					return err
+				}
+				return printConfigDataDetails(desc)
+			case "node":
+				desc, err := client.DescribeNode(context.Background(), name)
+				if err != nil {
+					return err
+				}
+				return printNodeDetails(desc)
 			default:
-				return fmt.Errorf("unsupported resource type: %s", resourceType)
+				return errs.Validationf("unsupported resource type: %s", resourceType)
 			}
 		},
 	}
@@ -93,7 +136,11 @@ Examples:
 }
 
 func printPodDetails(details *pods.PodDetails) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	return writePodDetails(os.Stdout, details)
+}
+
+func writePodDetails(out io.Writer, details *pods.PodDetails) error {
+	w := newTable(out)
 	defer w.Flush()
 
 	// Basic Info
@@ -107,7 +154,7 @@ func printPodDetails(details *pods.PodDetails) error {
 	if details.NodeIP != "" {
 		fmt.Fprintf(w, "Node IP:\t%s\n", details.NodeIP)
 	}
-	fmt.Fprintf(w, "Start Time:\t%s\n", details.StartTime.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	fmt.Fprintf(w, "Start Time:\t%s\n", formatTimestamp(details.StartTime, "Mon, 02 Jan 2006 15:04:05 -0700"))
 
 	// Labels and Annotations
 	if len(details.Labels) > 0 {
@@ -137,6 +184,16 @@ func printPodDetails(details *pods.PodDetails) error {
 		fmt.Fprintf(w, "Controlled By:\t%s\n", details.ControlledBy)
 	}
 
+	// Service mesh sidecar
+	if details.Mesh.Sidecar != "" {
+		fmt.Fprintf(w, "Mesh:\t%s\n", details.Mesh.Sidecar)
+	}
+	if details.Mesh.Missing() {
+		fmt.Fprintf(w, "Mesh:\tmissing (%s)\n", details.Mesh.Expected)
+		fmt.Fprintf(w, "Warning: this pod should run a %s sidecar but has none, so its traffic bypasses the mesh. Restart it to inject the sidecar.\n",
+			details.Mesh.Expected)
+	}
+
 	// Containers
 	fmt.Fprintf(w, "Containers:\n")
 	for _, c := range details.Containers {
@@ -162,7 +219,12 @@ func printPodDetails(details *pods.PodDetails) error {
 		// Container State
 		fmt.Fprintf(w, "    State:\t%s\n", c.State.Status)
 		if !c.State.Started.IsZero() {
-			fmt.Fprintf(w, "      Started:\t%s\n", c.State.Started.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+			fmt.Fprintf(w, "      Started:\t%s\n", formatTimestamp(c.State.Started, "Mon, 02 Jan 2006 15:04:05 -0700"))
+		}
+		if c.LastState.Status != "" {
+			fmt.Fprintf(w, "    Last State:\t%s\n", c.LastState.Status)
+			fmt.Fprintf(w, "      Reason:\t%s\n", c.LastState.Reason)
+			fmt.Fprintf(w, "      Exit Code:\t%d\n", c.LastState.ExitCode)
 		}
 		fmt.Fprintf(w, "    Ready:\t%v\n", c.Ready)
 		fmt.Fprintf(w, "    Restart Count:\t%d\n", c.RestartCount)
@@ -178,17 +240,15 @@ func printPodDetails(details *pods.PodDetails) error {
 			}
 		}
 
-		// Readiness Probe
+		// Probes
+		if c.LivenessProbe != nil {
+			fmt.Fprintf(w, "    Liveness:\t%s\n", formatProbe(c.LivenessProbe))
+		}
 		if c.ReadinessProbe != nil {
-			fmt.Fprintf(w, "    Readiness:\t%s :%d delay=%s timeout=%s period=%s #success=%d #failure=%d\n",
-				c.ReadinessProbe.Type,
-				c.ReadinessProbe.Port,
-				c.ReadinessProbe.Delay,
-				c.ReadinessProbe.Timeout,
-				c.ReadinessProbe.Period,
-				c.ReadinessProbe.SuccessThreshold,
-				c.ReadinessProbe.FailureThreshold,
-			)
+			fmt.Fprintf(w, "    Readiness:\t%s\n", formatProbe(c.ReadinessProbe))
+		}
+		if c.StartupProbe != nil {
+			fmt.Fprintf(w, "    Startup:\t%s\n", formatProbe(c.StartupProbe))
 		}
 
 		// Environment Variables
@@ -221,10 +281,32 @@ func printPodDetails(details *pods.PodDetails) error {
 	// Conditions
 	if len(details.Conditions) > 0 {
 		fmt.Fprintf(w, "Conditions:\n")
-		fmt.Fprintf(w, "  Type\tStatus\n")
-		fmt.Fprintf(w, "  ----\t------\n")
+		fmt.Fprintf(w, "  Type\tStatus\tLast Transition\n")
+		fmt.Fprintf(w, "  ----\t------\t---------------\n")
 		for _, c := range details.Conditions {
-			fmt.Fprintf(w, "  %s\t%s\n", c.Type, c.Status)
+			fmt.Fprintf(w, "  %s\t%s\t%s", c.Type, c.Status, formatTransition(c.LastTransitionTime))
+			if c.Custom {
+				fmt.Fprintf(w, "\t(custom)")
+			}
+			fmt.Fprintf(w, "\n")
+		}
+	}
+
+	// Readiness Gates
+	if len(details.ReadinessGates) > 0 {
+		fmt.Fprintf(w, "Readiness Gates:\n")
+		fmt.Fprintf(w, "  Type\tStatus\tLast Transition\tMessage\n")
+		fmt.Fprintf(w, "  ----\t------\t---------------\t-------\n")
+		for _, g := range details.ReadinessGates {
+			status := g.Status
+			if status == "" {
+				status = "<none>"
+			}
+			message := g.Message
+			if message == "" {
+				message = g.Reason
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", g.ConditionType, status, formatTransition(g.LastTransitionTime), message)
 		}
 	}
 
@@ -290,6 +372,9 @@ func printPodDetails(details *pods.PodDetails) error {
 		}
 	}
 
+	// Affinity and topology spread constraints
+	writeScheduling(w, "", details.Affinity, details.TopologySpreadConstraints)
+
 	// Events
 	if len(details.Events) > 0 {
 		fmt.Fprintf(w, "Events:\n")
@@ -299,7 +384,7 @@ func printPodDetails(details *pods.PodDetails) error {
 			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
 				e.Type,
 				e.Reason,
-				e.Age.Round(time.Second),
+				formatAge(e.Age),
 				e.From,
 				e.Message,
 			)
@@ -311,14 +396,39 @@ func printPodDetails(details *pods.PodDetails) error {
 	return nil
 }
 
+// formatTransition renders when a condition last changed, or - when it is unknown
+func formatTransition(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return formatAgo(t)
+}
+
+// formatProbe renders a probe in the same compact form as kubectl describe
+func formatProbe(p *pods.Probe) string {
+	target := fmt.Sprintf(":%d", p.Port)
+	if p.Type == "http-get" {
+		target += p.Path
+	}
+	return fmt.Sprintf("%s %s delay=%s timeout=%s period=%s #success=%d #failure=%d",
+		p.Type,
+		target,
+		p.Delay,
+		p.Timeout,
+		p.Period,
+		p.SuccessThreshold,
+		p.FailureThreshold,
+	)
+}
+
 func printDeploymentDetails(details *deployments.DeploymentDetails) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := newTable(os.Stdout)
 	defer w.Flush()
 
 	// Basic Info
 	fmt.Fprintf(w, "Name:\t%s\n", details.Name)
 	fmt.Fprintf(w, "Namespace:\t%s\n", details.Namespace)
-	fmt.Fprintf(w, "CreationTimestamp:\t%s\n", details.CreationTime.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	fmt.Fprintf(w, "CreationTimestamp:\t%s\n", formatTimestamp(details.CreationTime, "Mon, 02 Jan 2006 15:04:05 -0700"))
 
 	// Labels and Annotations
 	if len(details.Labels) > 0 {
@@ -435,6 +545,9 @@ func printDeploymentDetails(details *deployments.DeploymentDetails) error {
 		}
 	}
 
+	// Affinity and topology spread constraints
+	writeScheduling(w, "  ", details.Affinity, details.TopologySpreadConstraints)
+
 	// Conditions
 	if len(details.Conditions) > 0 {
 		fmt.Fprintf(w, "Conditions:\n")
@@ -469,7 +582,7 @@ func printDeploymentDetails(details *deployments.DeploymentDetails) error {
 			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
 				e.Type,
 				e.Reason,
-				e.Age.Round(time.Second),
+				formatAge(e.Age),
 				e.From,
 				e.Message,
 			)
@@ -478,3 +591,152 @@ func printDeploymentDetails(details *deployments.DeploymentDetails) error {
 
 	return nil
 }
+
+func printConfigDataDetails(desc *k8s.ResourceDescription) error {
+	details, ok := desc.Details.(*k8s.ConfigDataDetails)
+	if !ok {
+		return fmt.Errorf("unexpected details for %s %s", desc.Type, desc.Name)
+	}
+
+	w := newTable(os.Stdout)
+	defer w.Flush()
+
+	// Basic Info
+	fmt.Fprintf(w, "Name:\t%s\n", desc.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", desc.Namespace)
+	if details.Type != "" {
+		fmt.Fprintf(w, "Type:\t%s\n", details.Type)
+	}
+	fmt.Fprintf(w, "CreationTimestamp:\t%s\n", formatTimestamp(desc.CreationTimestamp, "Mon, 02 Jan 2006 15:04:05 -0700"))
+	if !details.LastModified.IsZero() {
+		modified := formatAgo(details.LastModified)
+		if details.LastModifiedBy != "" {
+			modified += " by " + details.LastModifiedBy
+		}
+		fmt.Fprintf(w, "Last Modified:\t%s\n", modified)
+	}
+	fmt.Fprintf(w, "Immutable:\t%v\n", details.Immutable)
+
+	// Labels and Annotations
+	if len(desc.Labels) > 0 {
+		fmt.Fprintf(w, "Labels:\t\n")
+		for k, v := range desc.Labels {
+			fmt.Fprintf(w, "  %s=%s\n", k, v)
+		}
+	}
+	if len(desc.Annotations) > 0 {
+		fmt.Fprintf(w, "Annotations:\t\n")
+		for k, v := range desc.Annotations {
+			fmt.Fprintf(w, "  %s=%s\n", k, v)
+		}
+	}
+
+	// Keys
+	fmt.Fprintf(w, "Data:\n")
+	if len(details.Keys) == 0 {
+		fmt.Fprintf(w, "  <none>\n")
+	}
+	for _, key := range details.Keys {
+		size := fmt.Sprintf("%d bytes", key.Size)
+		if key.Binary {
+			size += " (binary)"
+		}
+		fmt.Fprintf(w, "  %s:\t%s\n", key.Name, size)
+	}
+
+	// Workloads using the object
+	fmt.Fprintf(w, "Used By:\n")
+	if len(details.UsedBy) == 0 {
+		fmt.Fprintf(w, "  <none>\n")
+	}
+	for _, ref := range details.UsedBy {
+		via := ref.Via
+		if ref.Container != "" {
+			via += " in container " + ref.Container
+		}
+		if len(ref.Keys) > 0 {
+			via += " (" + strings.Join(ref.Keys, ", ") + ")"
+		}
+		fmt.Fprintf(w, "  %s/%s:\t%s\n", ref.Kind, ref.Name, via)
+	}
+
+	return nil
+}
+
+func printNodeDetails(desc *k8s.ResourceDescription) error {
+	details, ok := desc.Details.(*k8s.NodeDetails)
+	if !ok {
+		return fmt.Errorf("unexpected details for %s %s", desc.Type, desc.Name)
+	}
+
+	w := newTable(os.Stdout)
+	defer w.Flush()
+
+	// Basic Info
+	fmt.Fprintf(w, "Name:\t%s\n", desc.Name)
+	fmt.Fprintf(w, "Status:\t%s\n", desc.Status)
+	fmt.Fprintf(w, "CreationTimestamp:\t%s\n", formatTimestamp(desc.CreationTimestamp, "Mon, 02 Jan 2006 15:04:05 -0700"))
+
+	// Cloud instance
+	if cloud := details.Cloud; cloud != (k8s.NodeCloudInfo{}) {
+		fmt.Fprintf(w, "Cloud:\n")
+		for _, field := range []struct{ name, value string }{
+			{"Provider", cloud.Provider},
+			{"Instance ID", cloud.InstanceID},
+			{"Instance Type", cloud.InstanceType},
+			{"Region", cloud.Region},
+			{"Zone", cloud.Zone},
+			{"Capacity", cloud.Capacity},
+			{"Node Group", cloud.NodeGroup},
+		} {
+			if field.value != "" {
+				fmt.Fprintf(w, "  %s:\t%s\n", field.name, field.value)
+			}
+		}
+	}
+
+	// Labels and Annotations
+	if len(desc.Labels) > 0 {
+		fmt.Fprintf(w, "Labels:\t\n")
+		for k, v := range desc.Labels {
+			fmt.Fprintf(w, "  %s=%s\n", k, v)
+		}
+	}
+
+	// Addresses
+	fmt.Fprintf(w, "Addresses:\n")
+	for _, addr := range details.Addresses {
+		fmt.Fprintf(w, "  %s:\t%s\n", addr.Type, addr.Address)
+	}
+
+	// Capacity and Allocatable
+	fmt.Fprintf(w, "Capacity:\n")
+	for _, resource := range []string{"cpu", "memory", "ephemeral-storage", "pods"} {
+		if v, ok := details.Capacity[resource]; ok {
+			fmt.Fprintf(w, "  %s:\t%s\n", resource, v)
+		}
+	}
+	fmt.Fprintf(w, "Allocatable:\n")
+	for _, resource := range []string{"cpu", "memory", "ephemeral-storage", "pods"} {
+		if v, ok := details.Allocatable[resource]; ok {
+			fmt.Fprintf(w, "  %s:\t%s\n", resource, v)
+		}
+	}
+
+	// System Info
+	fmt.Fprintf(w, "System Info:\n")
+	fmt.Fprintf(w, "  OS Image:\t%s\n", details.Info.OSImage)
+	fmt.Fprintf(w, "  Kernel Version:\t%s\n", details.Info.KernelVersion)
+	fmt.Fprintf(w, "  Architecture:\t%s\n", details.Info.Architecture)
+	fmt.Fprintf(w, "  Container Runtime:\t%s\n", details.Info.ContainerRuntimeVersion)
+	fmt.Fprintf(w, "  Kubelet Version:\t%s\n", details.Info.KubeletVersion)
+
+	// Conditions
+	fmt.Fprintf(w, "Conditions:\n")
+	fmt.Fprintf(w, "  Type\tStatus\tLast Transition\tReason\n")
+	for _, c := range details.Conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", c.Type, c.Status, formatAgo(c.LastTransitionTime), c.Reason)
+	}
+
+	return nil
+}
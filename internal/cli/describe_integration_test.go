@@ -2,13 +2,123 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
 )
 
+// schedulingObjects are a pod and a deployment with affinity rules and a topology spread
+// constraint that every node of the test cluster satisfies
+const schedulingObjects = `apiVersion: v1
+kind: Pod
+metadata:
+  name: k8stool-scheduling
+  labels:
+    app: k8stool-scheduling
+spec:
+  affinity:
+    nodeAffinity:
+      requiredDuringSchedulingIgnoredDuringExecution:
+        nodeSelectorTerms:
+        - matchExpressions:
+          - key: kubernetes.io/arch
+            operator: Exists
+      preferredDuringSchedulingIgnoredDuringExecution:
+      - weight: 10
+        preference:
+          matchExpressions:
+          - key: kubernetes.io/os
+            operator: In
+            values: [linux]
+    podAntiAffinity:
+      preferredDuringSchedulingIgnoredDuringExecution:
+      - weight: 50
+        podAffinityTerm:
+          topologyKey: kubernetes.io/hostname
+          labelSelector:
+            matchLabels:
+              app: k8stool-scheduling
+  topologySpreadConstraints:
+  - maxSkew: 1
+    topologyKey: topology.kubernetes.io/zone
+    whenUnsatisfiable: ScheduleAnyway
+    labelSelector:
+      matchLabels:
+        app: k8stool-scheduling
+  containers:
+  - name: nginx
+    image: nginx:alpine
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: k8stool-scheduling-deploy
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: k8stool-scheduling
+  template:
+    metadata:
+      labels:
+        app: k8stool-scheduling
+    spec:
+      affinity:
+        nodeAffinity:
+          requiredDuringSchedulingIgnoredDuringExecution:
+            nodeSelectorTerms:
+            - matchExpressions:
+              - key: kubernetes.io/arch
+                operator: Exists
+          preferredDuringSchedulingIgnoredDuringExecution:
+          - weight: 10
+            preference:
+              matchExpressions:
+              - key: kubernetes.io/os
+                operator: In
+                values: [linux]
+        podAntiAffinity:
+          preferredDuringSchedulingIgnoredDuringExecution:
+          - weight: 50
+            podAffinityTerm:
+              topologyKey: kubernetes.io/hostname
+              labelSelector:
+                matchLabels:
+                  app: k8stool-scheduling
+      topologySpreadConstraints:
+      - maxSkew: 1
+        topologyKey: topology.kubernetes.io/zone
+        whenUnsatisfiable: ScheduleAnyway
+        labelSelector:
+          matchLabels:
+            app: k8stool-scheduling
+      containers:
+      - name: nginx
+        image: nginx:alpine
+`
+
+// gatedPod is a pod with two readiness gates, of which the test sets only the first
+const gatedPod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: k8stool-gated
+spec:
+  readinessGates:
+  - conditionType: k8stool.io/load-balancer
+  - conditionType: k8stool.io/mesh
+  containers:
+  - name: nginx
+    image: nginx:alpine
+`
+
 func TestDescribeCommands_Integration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test")
@@ -102,6 +212,26 @@ func TestDescribeCommands_Integration(t *testing.T) {
 				assert.Contains(t, output, "Containers:")
 			},
 		},
+		{
+			name:    "describe configmap",
+			args:    []string{"cm", "kube-root-ca.crt", "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Name:")
+				assert.Contains(t, output, "Last Modified:")
+				assert.Contains(t, output, "ca.crt:")
+				assert.Contains(t, output, "Used By:")
+				assert.Contains(t, output, "projected volume")
+			},
+		},
+		{
+			name:    "describe invalid secret",
+			args:    []string{"secret", "nonexistent-secret", "--namespace", "integration-test"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "secrets \"nonexistent-secret\" not found")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -138,3 +268,211 @@ func TestDescribeCommands_Integration(t *testing.T) {
 		})
 	}
 }
+
+func TestDescribeScheduling_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	createTestObjects(t, schedulingObjects, "integration-test")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "describe pod with affinity and topology spread",
+			args:    []string{"pod", "k8stool-scheduling", "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Affinity:\n  Node Affinity:\n")
+				assert.Contains(t, output, "    Required: nodes with kubernetes.io/arch\n")
+				assert.Contains(t, output, "    Preferred (weight 10): nodes with kubernetes.io/os=linux\n")
+				assert.Contains(t, output, "  Pod Anti-Affinity (schedule away from):\n")
+				assert.Contains(t, output, "    Preferred (weight 50): pods with app=k8stool-scheduling, per kubernetes.io/hostname\n")
+				assert.NotContains(t, output, "Pod Affinity (schedule near):")
+				assert.Contains(t, output, "Topology Spread Constraints:\n")
+				assert.Contains(t, output, "  topology.kubernetes.io/zone: max skew 1 of pods with app=k8stool-scheduling, else schedule anyway")
+			},
+		},
+		{
+			name:    "describe deployment with affinity and topology spread",
+			args:    []string{"deployment", "k8stool-scheduling-deploy", "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "  Affinity:\n    Node Affinity:\n")
+				assert.Contains(t, output, "      Required: nodes with kubernetes.io/arch\n")
+				assert.Contains(t, output, "      Preferred (weight 50): pods with app=k8stool-scheduling, per kubernetes.io/hostname\n")
+				assert.Contains(t, output, "  Topology Spread Constraints:\n")
+				assert.Contains(t, output, "    topology.kubernetes.io/zone: max skew 1 of pods with app=k8stool-scheduling, else schedule anyway")
+			},
+		},
+		{
+			name:    "describe pod without scheduling constraints",
+			args:    []string{"pod", "nginx", "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.NotContains(t, output, "Affinity:")
+				assert.NotContains(t, output, "Topology Spread Constraints:")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getDescribeCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+func TestDescribeReadinessGates_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	createTestObjects(t, gatedPod, "integration-test")
+	setPodCondition(t, "integration-test", "k8stool-gated", corev1.PodCondition{
+		Type:    "k8stool.io/load-balancer",
+		Status:  corev1.ConditionTrue,
+		Reason:  "Registered",
+		Message: "registered with target group",
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "describe pod with readiness gates",
+			args:    []string{"pod", "k8stool-gated", "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Readiness Gates:")
+				assert.Regexp(t, `Type\s+Status\s+Last Transition\s+Message`, output)
+				assert.Regexp(t, `k8stool.io/load-balancer\s+True\s+\S+ ago\s+registered with target group`, output)
+				assert.Regexp(t, `k8stool.io/mesh\s+<none>\s+-`, output)
+			},
+		},
+		{
+			name:    "describe pod with a custom condition",
+			args:    []string{"pod", "k8stool-gated", "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Regexp(t, `k8stool.io/load-balancer\s+True\s+\S+ ago\s+\(custom\)`, output)
+				assert.NotRegexp(t, `PodScheduled.*\(custom\)`, output)
+				assert.Regexp(t, `Ready\s+False\s+`, output)
+			},
+		},
+		{
+			name:    "describe pod without readiness gates",
+			args:    []string{"pod", "nginx", "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.NotContains(t, output, "Readiness Gates:")
+				assert.NotContains(t, output, "(custom)")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getDescribeCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+// setPodCondition sets a condition on the status of a pod, as the controller of a readiness
+// gate does
+func setPodCondition(t *testing.T, namespace, name string, condition corev1.PodCondition) {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		t.Fatalf("failed to load kubeconfig: %v", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatalf("failed to create clientset: %v", err)
+	}
+
+	condition.LastTransitionTime = metav1.Now()
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		pod.Status.Conditions = append(pod.Status.Conditions, condition)
+		_, err = clientset.CoreV1().Pods(namespace).UpdateStatus(context.Background(), pod, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to set pod condition: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// writeScheduling writes the affinity rules and topology spread constraints of a pod spec
+// as readable sentences, indented by indent. Nothing is written when neither is set.
+func writeScheduling(w io.Writer, indent string, affinity *corev1.Affinity, constraints []corev1.TopologySpreadConstraint) {
+	if affinity != nil && (affinity.NodeAffinity != nil || affinity.PodAffinity != nil || affinity.PodAntiAffinity != nil) {
+		fmt.Fprintf(w, "%sAffinity:\n", indent)
+		if na := affinity.NodeAffinity; na != nil {
+			fmt.Fprintf(w, "%s  Node Affinity:\n", indent)
+			if req := na.RequiredDuringSchedulingIgnoredDuringExecution; req != nil {
+				for i, term := range req.NodeSelectorTerms {
+					label := "Required:"
+					if i > 0 {
+						label = "  or:"
+					}
+					fmt.Fprintf(w, "%s    %s %s\n", indent, label, formatNodeSelectorTerm(term))
+				}
+			}
+			for _, pref := range na.PreferredDuringSchedulingIgnoredDuringExecution {
+				fmt.Fprintf(w, "%s    Preferred (weight %d): %s\n", indent, pref.Weight, formatNodeSelectorTerm(pref.Preference))
+			}
+		}
+		if pa := affinity.PodAffinity; pa != nil {
+			fmt.Fprintf(w, "%s  Pod Affinity (schedule near):\n", indent)
+			writePodAffinityTerms(w, indent+"    ", pa.RequiredDuringSchedulingIgnoredDuringExecution, pa.PreferredDuringSchedulingIgnoredDuringExecution)
+		}
+		if paa := affinity.PodAntiAffinity; paa != nil {
+			fmt.Fprintf(w, "%s  Pod Anti-Affinity (schedule away from):\n", indent)
+			writePodAffinityTerms(w, indent+"    ", paa.RequiredDuringSchedulingIgnoredDuringExecution, paa.PreferredDuringSchedulingIgnoredDuringExecution)
+		}
+	}
+
+	if len(constraints) > 0 {
+		fmt.Fprintf(w, "%sTopology Spread Constraints:\n", indent)
+		for _, c := range constraints {
+			fmt.Fprintf(w, "%s  %s\n", indent, formatTopologySpread(c))
+		}
+	}
+}
+
+// writePodAffinityTerms writes required and preferred pod (anti-)affinity terms
+func writePodAffinityTerms(w io.Writer, indent string, required []corev1.PodAffinityTerm, preferred []corev1.WeightedPodAffinityTerm) {
+	for _, term := range required {
+		fmt.Fprintf(w, "%sRequired: %s\n", indent, formatPodAffinityTerm(term))
+	}
+	for _, pref := range preferred {
+		fmt.Fprintf(w, "%sPreferred (weight %d): %s\n", indent, pref.Weight, formatPodAffinityTerm(pref.PodAffinityTerm))
+	}
+}
+
+// formatNodeSelectorTerm renders the requirements of a node selector term, which must all match
+func formatNodeSelectorTerm(term corev1.NodeSelectorTerm) string {
+	var parts []string
+	for _, req := range term.MatchExpressions {
+		parts = append(parts, formatNodeSelectorRequirement(req))
+	}
+	for _, req := range term.MatchFields {
+		parts = append(parts, formatNodeSelectorRequirement(req))
+	}
+	if len(parts) == 0 {
+		return "any node"
+	}
+	return "nodes with " + strings.Join(parts, " and ")
+}
+
+// formatNodeSelectorRequirement renders a requirement in label selector syntax
+func formatNodeSelectorRequirement(req corev1.NodeSelectorRequirement) string {
+	switch req.Operator {
+	case corev1.NodeSelectorOpIn:
+		if len(req.Values) == 1 {
+			return req.Key + "=" + req.Values[0]
+		}
+		return fmt.Sprintf("%s in (%s)", req.Key, strings.Join(req.Values, ", "))
+	case corev1.NodeSelectorOpNotIn:
+		return fmt.Sprintf("%s notin (%s)", req.Key, strings.Join(req.Values, ", "))
+	case corev1.NodeSelectorOpExists:
+		return req.Key
+	case corev1.NodeSelectorOpDoesNotExist:
+		return "!" + req.Key
+	case corev1.NodeSelectorOpGt:
+		return fmt.Sprintf("%s > %s", req.Key, strings.Join(req.Values, ""))
+	case corev1.NodeSelectorOpLt:
+		return fmt.Sprintf("%s < %s", req.Key, strings.Join(req.Values, ""))
+	default:
+		return fmt.Sprintf("%s %s %s", req.Key, req.Operator, strings.Join(req.Values, ","))
+	}
+}
+
+// formatPodAffinityTerm renders which pods a term refers to and the topology it applies in
+func formatPodAffinityTerm(term corev1.PodAffinityTerm) string {
+	pods := "pods with " + formatLabelSelector(term.LabelSelector)
+	if len(term.MatchLabelKeys) > 0 {
+		pods += " and the same " + strings.Join(term.MatchLabelKeys, ", ")
+	}
+	switch {
+	case len(term.Namespaces) > 0:
+		pods += " in namespaces " + strings.Join(term.Namespaces, ", ")
+	case term.NamespaceSelector != nil:
+		pods += " in namespaces with " + formatLabelSelector(term.NamespaceSelector)
+	}
+	return fmt.Sprintf("%s, per %s", pods, term.TopologyKey)
+}
+
+// formatTopologySpread renders a topology spread constraint
+func formatTopologySpread(c corev1.TopologySpreadConstraint) string {
+	s := fmt.Sprintf("%s: max skew %d of pods with %s", c.TopologyKey, c.MaxSkew, formatLabelSelector(c.LabelSelector))
+	if len(c.MatchLabelKeys) > 0 {
+		s += " and the same " + strings.Join(c.MatchLabelKeys, ", ")
+	}
+	if c.MinDomains != nil {
+		s += fmt.Sprintf(", at least %d domains", *c.MinDomains)
+	}
+	if c.WhenUnsatisfiable == corev1.DoNotSchedule {
+		s += ", else do not schedule"
+	} else {
+		s += ", else schedule anyway"
+	}
+	if c.NodeAffinityPolicy != nil {
+		s += fmt.Sprintf(", node affinity %s", *c.NodeAffinityPolicy)
+	}
+	if c.NodeTaintsPolicy != nil {
+		s += fmt.Sprintf(", node taints %s", *c.NodeTaintsPolicy)
+	}
+	return s
+}
+
+// formatLabelSelector renders a label selector; a nil selector matches no pods
+func formatLabelSelector(selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return "no selector (matches none)"
+	}
+	s := metav1.FormatLabelSelector(selector)
+	if s == "<none>" {
+		return "any labels"
+	}
+	return s
+}
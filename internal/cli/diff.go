@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/resources"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// diffIgnoredFields are left out of diffs; they are maintained by the server and never set by apply
+var diffIgnoredFields = [][]string{
+	{"status"},
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "uid"},
+	{"metadata", "creationTimestamp"},
+}
+
+func getDiffCmd() *cobra.Command {
+	var namespace string
+	var files []string
+	var recursive bool
+	var opts resources.ApplyOptions
+
+	cmd := &cobra.Command{
+		Use:   "diff -f FILENAME",
+		Short: "Show what applying manifests would change",
+		Long: `Compare manifests against the live objects in the cluster.
+
+Each object is server-side applied as a dry run, so defaults, admission webhooks and fields
+owned by other managers are taken into account. The result is shown as a colored diff against
+the live object, ignoring status, managed fields and other server-maintained metadata. Objects
+that do not exist yet are shown in full.
+
+Examples:
+  # Diff a single manifest
+  k8stool diff -f deployment.yaml
+
+  # Diff every manifest in a directory tree
+  k8stool diff -f manifests/ -R
+
+  # Diff with the field manager used by your deploy pipeline
+  k8stool diff -f app.yaml --field-manager ci`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			objs, err := resources.Load(files, recursive)
+			if err != nil {
+				return err
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			opts.Namespace = namespace
+			if opts.Namespace == "" {
+				opts.Namespace = client.GetCurrentNamespace()
+			}
+
+			comparisons, err := client.Diff(context.Background(), objs, opts)
+			if err != nil {
+				return err
+			}
+
+			results := make([]resources.Result, 0, len(comparisons))
+			changed := 0
+			for _, c := range comparisons {
+				results = append(results, c.Result)
+				ref := c.Ref()
+				if c.Namespace != "" {
+					ref = c.Namespace + "/" + ref
+				}
+				if c.Action == resources.Failed {
+					fmt.Fprintf(os.Stderr, "%s %s: %s\n", utils.Red("error"), ref, c.Error)
+					continue
+				}
+
+				live, err := diffYAML(c.Live)
+				if err != nil {
+					return err
+				}
+				applied, err := diffYAML(c.Applied)
+				if err != nil {
+					return err
+				}
+
+				if diff := utils.UnifiedDiff(live, applied, "live/"+ref, "applied/"+ref); diff != "" {
+					changed++
+					fmt.Print(utils.ColorizeDiff(diff))
+				}
+			}
+
+			if changed == 0 && len(comparisons) > 0 {
+				fmt.Println("No differences")
+			}
+			return resultsError(results)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace for objects that do not set one")
+	cmd.Flags().StringSliceVarP(&files, "filename", "f", nil, "File, directory or URL to diff (repeatable, - for stdin)")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, "Process directories recursively")
+	cmd.Flags().StringVar(&opts.FieldManager, "field-manager", resources.DefaultFieldManager, "Field manager the apply is simulated with")
+	cmd.Flags().BoolVar(&opts.Force, "force-conflicts", false, "Take ownership of fields managed by other field managers")
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+// diffYAML renders an object as YAML for diffing, or an empty string for nil
+func diffYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	obj = obj.DeepCopy()
+	for _, field := range diffIgnoredFields {
+		unstructured.RemoveNestedField(obj.Object, field...)
+	}
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode object: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/netcheck"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getDNSCheckCmd() *cobra.Command {
+	var opts netcheck.DNSOptions
+
+	cmd := &cobra.Command{
+		Use:   "dnscheck",
+		Short: "Check cluster DNS health",
+		Long: `Resolve a set of names from inside a short-lived test pod against the cluster DNS service
+and against every CoreDNS pod directly, reporting latency and failures per backend.
+
+By default the API server service, a cross-namespace service and an external domain are resolved.
+The test pod is deleted when the check finishes. The image must provide dig.
+
+Examples:
+  # Check cluster DNS with the default names
+  k8stool dnscheck
+
+  # Resolve specific names from a pod in the monitoring namespace
+  k8stool dnscheck -n monitoring --name prometheus.monitoring --name github.com`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if opts.Namespace == "" {
+				opts.Namespace = client.GetCurrentNamespace()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			fmt.Fprintln(os.Stderr, "Starting DNS test pod...")
+			report, err := client.CheckDNS(ctx, opts)
+			if err != nil {
+				return err
+			}
+
+			failed := printDNSReport(report)
+			if failed > 0 {
+				return fmt.Errorf("%d of %d queries failed", failed, len(report.Results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "Namespace to run the test pod in")
+	cmd.Flags().StringSliceVar(&opts.Names, "name", nil, "Name to resolve (repeatable, replaces the defaults)")
+	cmd.Flags().StringVar(&opts.Image, "image", netcheck.DefaultImage, "Test pod image; must provide dig")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 2*time.Second, "Timeout of each query")
+	markMutating(cmd)
+
+	return cmd
+}
+
+// printDNSReport prints every query and a summary per backend, returning the number of failed queries
+func printDNSReport(report *netcheck.DNSReport) int {
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "BACKEND\tNAME\tSTATUS\tLATENCY\tANSWER")
+	for _, r := range report.Results {
+		status := utils.Green(r.Status)
+		if !r.OK() {
+			status = utils.Red(r.Status)
+		}
+		answer := r.Answer
+		if r.Error != "" {
+			answer = r.Error
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Backend, r.Name, status, r.Latency, answer)
+	}
+	w.Flush()
+
+	fmt.Println()
+	var failed int
+	w = newTable(os.Stdout)
+	fmt.Fprintln(w, "BACKEND\tADDRESS\tFAILURES\tAVG LATENCY\tMAX LATENCY")
+	for _, b := range report.Backends {
+		var failures, answered int
+		var total, slowest time.Duration
+		for _, r := range report.Results {
+			if r.Backend != b.Name {
+				continue
+			}
+			if !r.OK() {
+				failures++
+				continue
+			}
+			answered++
+			total += r.Latency
+			slowest = max(slowest, r.Latency)
+		}
+		failed += failures
+
+		avg := time.Duration(0)
+		if answered > 0 {
+			avg = total / time.Duration(answered)
+		}
+		failuresStr := utils.Green(failures)
+		if failures > 0 {
+			failuresStr = utils.Red(failures)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", b.Name, b.Address, failuresStr, avg, slowest)
+	}
+	w.Flush()
+
+	return failed
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSCheckCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "resolve a service name",
+			args:    []string{"-n", "integration-test", "--name", "kubernetes.default"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "BACKEND")
+				assert.Contains(t, output, "LATENCY")
+				assert.Regexp(t, `service/kube-dns\s+kubernetes.default\s+NOERROR\s+\S+\s+\d+\.\d+\.\d+\.\d+`, output)
+				assert.Regexp(t, `coredns-\S+\s+kubernetes.default\s+NOERROR`, output)
+				assert.Contains(t, output, "AVG LATENCY")
+				assert.Regexp(t, `service/kube-dns\s+\d+\.\d+\.\d+\.\d+\s+0\s+`, output)
+			},
+		},
+		{
+			name:    "resolve a name that does not exist",
+			args:    []string{"-n", "integration-test", "--name", "k8stool-missing.invalid"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Regexp(t, `service/kube-dns\s+k8stool-missing.invalid\s+NXDOMAIN`, output)
+				assert.Regexp(t, `\d+ of \d+ queries failed`, output)
+			},
+		},
+		{
+			name:     "invalid timeout",
+			args:     []string{"--timeout", "fast"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getDNSCheckCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
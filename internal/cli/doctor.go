@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/doctor"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getDoctorCmd() *cobra.Command {
+	var certDays int
+	var skip []string
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Run cluster health checks",
+		Long: `Run a battery of cluster health checks and print a pass/warn/fail report with remediation hints.
+
+Checks:
+  - api-server: API server reachability and version
+  - metrics-server: metrics.k8s.io API availability
+  - nodes: NotReady nodes, pressure conditions and cordoned nodes
+  - pods: crashlooping, image pull failures and long-pending pods
+  - webhooks: admission webhooks whose backing service has no endpoints
+  - certificates: expired or soon-to-expire TLS secrets
+  - deprecated-apis: deprecated API versions requested since API server start
+  - image-pinning: workload images using mutable tags such as latest
+
+Examples:
+  # Run all checks
+  k8stool doctor
+
+  # Warn about certificates expiring within 14 days
+  k8stool doctor --cert-days 14
+
+  # Skip some checks
+  k8stool doctor --skip webhooks,deprecated-apis`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			report, err := client.DoctorService.Run(context.Background(), doctor.Options{
+				CertExpiryDays: certDays,
+				Skip:           skip,
+			})
+			if err != nil {
+				return err
+			}
+
+			printDoctorReport(report)
+
+			if failed := report.Count(doctor.Fail); failed > 0 {
+				return fmt.Errorf("%d checks failed", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&certDays, "cert-days", 30, "Warn about certificates expiring within this many days")
+	cmd.Flags().StringSliceVar(&skip, "skip", nil, "Checks to skip (comma separated)")
+
+	return cmd
+}
+
+func printDoctorReport(report *doctor.Report) {
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "CHECK\tSTATUS\tMESSAGE")
+	for _, r := range report.Results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Name, colorizeCheckStatus(r.Status), r.Message)
+	}
+	w.Flush()
+
+	for _, r := range report.Results {
+		if r.Status == doctor.Pass || (len(r.Details) == 0 && r.Hint == "") {
+			continue
+		}
+		fmt.Printf("\n%s [%s]\n", utils.Bold(r.Name), colorizeCheckStatus(r.Status))
+		for _, d := range r.Details {
+			fmt.Printf("  - %s\n", d)
+		}
+		if r.Hint != "" {
+			fmt.Printf("  Hint: %s\n", r.Hint)
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d warnings, %d failed\n",
+		report.Count(doctor.Pass), report.Count(doctor.Warn), report.Count(doctor.Fail))
+}
+
+func colorizeCheckStatus(status doctor.Status) string {
+	switch status {
+	case doctor.Pass:
+		return utils.StatusOK(string(status))
+	case doctor.Warn:
+		return utils.StatusWarn(string(status))
+	case doctor.Fail:
+		return utils.StatusFail(string(status))
+	default:
+		return string(status)
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoctorCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	// The test cluster runs without metrics-server
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "run all checks",
+			args:    []string{},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "CHECK")
+				assert.Contains(t, output, "STATUS")
+				assert.Contains(t, output, "MESSAGE")
+				for _, check := range []string{"api-server", "metrics-server", "nodes", "pods", "webhooks", "certificates", "deprecated-apis", "image-pinning"} {
+					assert.Contains(t, output, check)
+				}
+				assert.Regexp(t, `\d+ passed, \d+ warnings, 0 failed`, output)
+			},
+		},
+		{
+			name:    "skip checks",
+			args:    []string{"--skip", "nodes,pods,webhooks,certificates,deprecated-apis,image-pinning"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Regexp(t, `api-server\s+PASS\s+reachable, version v`, output)
+				assert.Regexp(t, `metrics-server\s+WARN\s+metrics.k8s.io API is not available`, output)
+				assert.Contains(t, output, "Hint: Install metrics-server")
+				assert.NotContains(t, output, "image-pinning")
+				assert.NotContains(t, output, "webhooks")
+				assert.Contains(t, output, "1 passed, 1 warnings, 0 failed")
+			},
+		},
+		{
+			name:     "invalid certificate days",
+			args:     []string{"--cert-days", "soon"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getDoctorCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const editHeader = `# Edit the object below. Lines beginning with '#' at the top are ignored,
+# and an empty file aborts the edit. The result is validated with a server-side
+# dry run before it is saved; if validation fails, this file is reopened.
+#
+`
+
+func getEditCmd() *cobra.Command {
+	var namespace string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "edit TYPE NAME",
+		Short: "Edit a resource in your editor",
+		Long: `Open the live object in your editor and save the result back to the cluster.
+
+The editor is taken from KUBE_EDITOR or EDITOR, falling back to vi (notepad on Windows).
+The edited object is validated with a server-side dry run; if validation fails the editor
+reopens with the error. Once valid, the diff is shown and the change is saved. The save fails
+if someone else changed the object while it was being edited.
+
+When the pod template of a deployment changed, which rolls out new pods, the live and edited
+objects are shown side by side and the change is only saved once confirmed, or with --yes.
+
+Examples:
+  # Edit a deployment
+  k8stool edit deploy web
+
+  # Edit a configmap in another namespace
+  k8stool edit configmap app-config -n staging
+
+  # Edit with a specific editor
+  EDITOR="code --wait" k8stool edit svc web`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			return editResource(context.Background(), client, args[0], namespace, args[1], yes)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Save changes to the pod template of a deployment without confirmation")
+	markMutating(cmd)
+
+	return cmd
+}
+
+func editResource(ctx context.Context, client *k8s.Client, resourceType, namespace, name string, yes bool) error {
+	live, err := client.GetResource(ctx, resourceType, namespace, name)
+	if err != nil {
+		return err
+	}
+	// Managed fields are noise when editing and are kept by the server when omitted
+	live.SetManagedFields(nil)
+
+	original, err := yaml.Marshal(live.Object)
+	if err != nil {
+		return fmt.Errorf("failed to encode object: %w", err)
+	}
+
+	content := original
+	var problem string
+	for {
+		edited, err := runEditor(editHeader+commentLines(problem)+string(content), name)
+		if err != nil {
+			return err
+		}
+		edited = stripLeadingComments(edited)
+
+		if len(bytes.TrimSpace(edited)) == 0 || bytes.Equal(edited, original) {
+			fmt.Println("Edit cancelled, no changes made.")
+			return nil
+		}
+		if problem != "" && bytes.Equal(edited, content) {
+			return fmt.Errorf("edit cancelled, the object is still invalid: %s", problem)
+		}
+		content = edited
+
+		obj, err := decodeEdited(edited, live)
+		if err == nil {
+			_, err = client.UpdateResource(ctx, obj, true)
+		}
+		if err != nil {
+			problem = err.Error()
+			continue
+		}
+
+		if isDeployment(live) && podTemplateChanged(live, obj) {
+			fmt.Print(utils.SideBySideDiff(string(original), string(edited), "live", "edited", terminalWidth()))
+			if !yes {
+				ok, err := confirm(fmt.Sprintf("Roll out the new pod template of deployment/%s", name))
+				if err != nil {
+					return saveEdited(edited, err)
+				}
+				if !ok {
+					return saveEdited(edited, fmt.Errorf("aborted"))
+				}
+			}
+		} else {
+			fmt.Print(utils.ColorizeDiff(utils.UnifiedDiff(string(original), string(edited), "live", "edited")))
+		}
+
+		if _, err := client.UpdateResource(ctx, obj, false); err != nil {
+			return saveEdited(edited, err)
+		}
+		fmt.Printf("%s edited\n", resources.Result{Group: obj.GroupVersionKind().Group, Kind: obj.GetKind(), Name: obj.GetName()}.Ref())
+		return nil
+	}
+}
+
+// isDeployment reports whether an object is an apps/v1 Deployment
+func isDeployment(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	return gvk.Group == "apps" && gvk.Kind == "Deployment"
+}
+
+// podTemplateChanged reports whether the pod template of a workload differs between two objects
+func podTemplateChanged(from, to *unstructured.Unstructured) bool {
+	before, _, _ := unstructured.NestedMap(from.Object, "spec", "template")
+	after, _, _ := unstructured.NestedMap(to.Object, "spec", "template")
+	// Compare the encoded templates, since decoding may yield different number types
+	a, errA := yaml.Marshal(before)
+	b, errB := yaml.Marshal(after)
+	return errA != nil || errB != nil || !bytes.Equal(a, b)
+}
+
+// decodeEdited parses the edited object and makes sure it still is the object being edited
+func decodeEdited(data []byte, live *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	objs, err := resources.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) != 1 {
+		return nil, errs.Validationf("expected exactly one object, found %d", len(objs))
+	}
+	obj := objs[0]
+	if obj.GetKind() != live.GetKind() || obj.GetName() != live.GetName() || obj.GetNamespace() != live.GetNamespace() {
+		return nil, fmt.Errorf("the kind, name and namespace of the object cannot be changed")
+	}
+	return obj, nil
+}
+
+// runEditor writes content to a temporary file, opens it in the user's editor and returns the result
+func runEditor(content, name string) ([]byte, error) {
+	f, err := os.CreateTemp("", "k8stool-edit-"+name+"-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	f.Close()
+
+	editor := strings.Fields(editorCommand())
+	cmd := exec.Command(editor[0], append(editor[1:], f.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %s failed: %w", editor[0], err)
+	}
+
+	return os.ReadFile(f.Name())
+}
+
+func editorCommand() string {
+	for _, env := range []string{"KUBE_EDITOR", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// stripLeadingComments removes the comment block at the top of the file
+func stripLeadingComments(data []byte) []byte {
+	for len(data) > 0 && data[0] == '#' {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			return nil
+		}
+		data = data[i+1:]
+	}
+	return data
+}
+
+func commentLines(text string) string {
+	if text == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("# The object could not be saved:\n")
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString("#   " + line + "\n")
+	}
+	b.WriteString("#\n")
+	return b.String()
+}
+
+// saveEdited keeps the user's changes in a file when the final save fails
+func saveEdited(data []byte, cause error) error {
+	f, err := os.CreateTemp("", "k8stool-edit-*.yaml")
+	if err != nil {
+		return cause
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return cause
+	}
+	return fmt.Errorf("%w\nyour changes were saved to %s", cause, f.Name())
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// editConfigMap is the object edited by the tests
+const editConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: k8stool-edit-config
+data:
+  key: value-one
+`
+
+func TestEditCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	createTestObjects(t, editConfigMap, "integration-test")
+
+	// The editor is a command run on the file, such as sed changing it in place
+	tests := []struct {
+		name     string
+		args     []string
+		editor   string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "edit a configmap",
+			args:    []string{"configmap", "k8stool-edit-config", "-n", "integration-test"},
+			editor:  "sed -i s/value-one/value-two/",
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "--- live")
+				assert.Contains(t, output, "+++ edited")
+				assert.Contains(t, output, "-  key: value-one")
+				assert.Contains(t, output, "+  key: value-two")
+				assert.Contains(t, output, "configmap/k8stool-edit-config edited")
+			},
+		},
+		{
+			name:    "edit without changes",
+			args:    []string{"configmap", "k8stool-edit-config", "-n", "integration-test"},
+			editor:  "true",
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Equal(t, "Edit cancelled, no changes made.\n", output)
+			},
+		},
+		{
+			name:    "edit the name",
+			args:    []string{"configmap", "k8stool-edit-config", "-n", "integration-test"},
+			editor:  "sed -i s/k8stool-edit-config/k8stool-edit-renamed/",
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "edit cancelled, the object is still invalid: the kind, name and namespace of the object cannot be changed")
+				assert.NotContains(t, output, "edited\n")
+			},
+		},
+		{
+			name:    "edit a non-existent resource",
+			args:    []string{"configmap", "non-existent-config", "-n", "integration-test"},
+			editor:  "true",
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "not found")
+			},
+		},
+		{
+			name:     "edit without a name",
+			args:     []string{"configmap"},
+			editor:   "true",
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			t.Setenv("KUBE_EDITOR", tt.editor)
+
+			// Create fresh command for each test
+			cmd := getEditCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"text/tabwriter"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
 	"k8stool/internal/k8s/events"
 	"k8stool/pkg/utils"
 
@@ -25,23 +27,27 @@ func getEventsCmd() *cobra.Command {
 	var since time.Duration
 	var watch bool
 	var warningsOnly bool
+	var excludeNamespaces []string
+	var clusters contextFlags
+	var output string
 
 	cmd := &cobra.Command{
 		Use:   "events",
 		Short: "Get events",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := k8s.NewClient()
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			if watch && output == "json" {
+				return errs.Validationf("--watch does not support json output")
+			}
+
+			contexts, err := clusters.resolve()
 			if err != nil {
 				return err
 			}
-
-			// If namespace flag not provided and not all namespaces, use the client's current namespace
-			if !allNamespaces && namespace == "" {
-				ctx, err := client.GetCurrentContext()
-				if err != nil {
-					return err
-				}
-				namespace = ctx.Namespace
+			if watch && contexts != nil {
+				return errs.Validationf("--watch cannot be used with --contexts or --all-contexts")
 			}
 
 			// Create event filter
@@ -75,38 +81,75 @@ func getEventsCmd() *cobra.Command {
 
 			ctx := context.Background()
 
-			if watch {
-				// Watch events
-				opts := &events.EventOptions{
-					Filter:         filter,
-					IncludeManaged: false,
-					BufferSize:     100,
+			// List events in every selected namespace
+			var multipleNamespaces atomic.Bool
+			list := func(client *k8s.Client) ([]events.Event, error) {
+				scope := resolveNamespaceScope(client, namespace, allNamespaces, excludeNamespaces)
+				if scope.multiple() {
+					multipleNamespaces.Store(true)
 				}
+				return listInScope(scope, func(e events.Event) string { return e.Namespace }, func(ns string) ([]events.Event, error) {
+					list, err := client.EventService.List(ctx, ns, filter)
+					if err != nil {
+						return nil, err
+					}
+					return list.Items, nil
+				})
+			}
 
-				eventChan, err := client.EventService.Watch(ctx, namespace, opts)
-				if err != nil {
-					return err
+			var eventList []events.Event
+			if contexts != nil {
+				eventList, err = listInContexts(contexts, func(e *events.Event, name string) { e.Context = name }, list)
+			} else {
+				client, clientErr := k8s.NewClient()
+				if clientErr != nil {
+					return clientErr
 				}
 
-				for event := range eventChan {
-					printEvent(&event)
+				if watch {
+					// Watch events
+					opts := &events.EventOptions{
+						Filter:         filter,
+						IncludeManaged: false,
+						BufferSize:     100,
+					}
+
+					scope := resolveNamespaceScope(client, namespace, allNamespaces, excludeNamespaces)
+					eventChan, err := watchEventsInScope(ctx, client, scope, opts)
+					if err != nil {
+						return err
+					}
+
+					for event := range eventChan {
+						printEvent(&event)
+					}
+
+					return nil
 				}
 
-				return nil
+				eventList, err = list(client)
 			}
-
-			// List events
-			eventList, err := client.EventService.List(ctx, namespace, filter)
 			if err != nil {
 				return err
 			}
 
-			return printEvents(eventList.Items)
+			// Restore the requested order across namespaces and contexts
+			if multipleNamespaces.Load() || contexts != nil {
+				events.Sort(eventList, filter.SortBy)
+			}
+
+			if output == "json" {
+				return printJSON(eventList)
+			}
+
+			defer startPager()()
+			return printEvents(eventList, multipleNamespaces.Load())
 		},
 	}
 
-	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (comma-separated for several)")
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List events across all namespaces")
+	cmd.Flags().StringSliceVar(&excludeNamespaces, "exclude-namespace", nil, "Namespaces to leave out; implies all namespaces when -n is not set")
 	cmd.Flags().StringVar(&resourceType, "resource-type", "", "Filter events by resource type")
 	cmd.Flags().StringVar(&resourceName, "resource-name", "", "Filter events by resource name")
 	cmd.Flags().StringVar(&component, "component", "", "Filter events by component")
@@ -115,19 +158,43 @@ func getEventsCmd() *cobra.Command {
 	cmd.Flags().DurationVar(&since, "since", 0, "Show events newer than a relative duration")
 	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Watch events")
 	cmd.Flags().BoolVar(&warningsOnly, "warnings", false, "Show only warning events")
+	clusters.register(cmd)
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
 
 	return cmd
 }
 
-func printEvents(events []events.Event) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+func printEvents(events []events.Event, showNamespace bool) error {
+	w := newTable(os.Stdout)
 	defer w.Flush()
 
+	// Events read from several contexts are told apart by a context column
+	showContext := false
+	for _, e := range events {
+		if e.Context != "" {
+			showContext = true
+			break
+		}
+	}
+
+	if showContext {
+		fmt.Fprint(w, "CONTEXT\t")
+	}
+	if showNamespace {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
 	fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE")
 
 	for _, e := range events {
-		age := utils.FormatDuration(time.Since(e.LastTimestamp))
+		age := formatSince(e.LastTimestamp)
 		object := fmt.Sprintf("%s/%s", e.ResourceKind, e.ResourceName)
+		if showContext {
+			fmt.Fprintf(w, "%s\t", e.Context)
+		}
+		if showNamespace {
+			fmt.Fprintf(w, "%s\t", e.Namespace)
+		}
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
 			age,
 			utils.ColorizeEventType(string(e.Type)),
@@ -139,8 +206,41 @@ func printEvents(events []events.Event) error {
 	return nil
 }
 
+// watchEventsInScope merges the event watches of every namespace in the scope into one channel
+func watchEventsInScope(ctx context.Context, client *k8s.Client, scope namespaceScope, opts *events.EventOptions) (<-chan events.Event, error) {
+	namespaces := scope.namespaces
+	if scope.all() {
+		namespaces = []string{""}
+	}
+
+	merged := make(chan events.Event, opts.BufferSize)
+	var wg sync.WaitGroup
+	for _, ns := range namespaces {
+		eventChan, err := client.EventService.Watch(ctx, ns, opts)
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range eventChan {
+				if !scope.exclude[event.Namespace] {
+					merged <- event
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+
+	return merged, nil
+}
+
 func printEvent(e *events.Event) {
-	age := utils.FormatDuration(time.Since(e.LastTimestamp))
+	age := formatSince(e.LastTimestamp)
 	object := fmt.Sprintf("%s/%s", e.ResourceKind, e.ResourceName)
 	fmt.Printf("%s\t%s\t%s\t%s\t%s\n",
 		age,
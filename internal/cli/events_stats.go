@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/events"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getEventsGroupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "events",
+		Aliases: []string{"ev"},
+		Short:   "Analyse cluster events",
+		Long:    "Analyse cluster events. Use get events to list them.",
+	}
+
+	cmd.AddCommand(getEventsStatsCmd())
+
+	return cmd
+}
+
+func getEventsStatsCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var since time.Duration
+	var warningsOnly bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Count events by reason and kind, compared with the period before",
+		Long: `Count the events of the last --since by reason and kind of object, next to the count of
+the same period before it, to surface patterns such as a spike in FailedMount across the
+cluster.
+
+Events seen several times are counted as often as they occurred in each period, taken as
+spread evenly between their first and last timestamps. The API server only keeps events for
+an hour by default (its --event-ttl flag), so longer periods may miss older events and show
+more of them as new.
+
+Examples:
+  # Events of the current namespace over the last 6 hours
+  k8stool events stats
+
+  # Warnings across the cluster over the last hour
+  k8stool events stats -A --since 1h --warnings`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if allNamespaces {
+				namespace = ""
+			} else if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			stats, err := client.EventStats(context.Background(), k8s.EventStatsOptions{
+				Namespace:    namespace,
+				Window:       since,
+				WarningsOnly: warningsOnly,
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(stats)
+			}
+			printEventStats(stats, since)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Count events across all namespaces")
+	cmd.Flags().DurationVar(&since, "since", 6*time.Hour, "Period to count, compared with the same period before it")
+	cmd.Flags().BoolVar(&warningsOnly, "warnings", false, "Count only warning events")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+// printEventStats prints the counts with an arrow for the trend. Rising warnings are red and
+// falling ones green; the trend of normal events is not colored.
+func printEventStats(stats *k8s.EventStats, window time.Duration) {
+	period := utils.FormatDuration(window)
+	if len(stats.Reasons) == 0 {
+		fmt.Printf("No events in the last %s or the %s before\n", period, period)
+		return
+	}
+
+	fmt.Printf("Events in the last %s, compared with the %s before\n\n", period, period)
+	w := newTable(os.Stdout)
+	defer w.Flush()
+	fmt.Fprintln(w, "REASON\tKIND\tTYPE\tCOUNT\tPREVIOUS\tTREND\tOBJECTS")
+	for _, r := range stats.Reasons {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\t%d\n",
+			r.Reason,
+			r.Kind,
+			utils.ColorizeEventType(string(r.Type)),
+			r.Count,
+			r.Previous,
+			formatTrend(r),
+			r.Objects)
+	}
+}
+
+// formatTrend shows the trend of a reason as an arrow, or new
+func formatTrend(r k8s.EventReasonStats) string {
+	warning := r.Type == events.Warning
+	switch r.Trend() {
+	case "new":
+		if warning {
+			return utils.Red("new")
+		}
+		return "new"
+	case "up":
+		if warning {
+			return utils.Red("↑")
+		}
+		return "↑"
+	case "down":
+		if warning {
+			return utils.Green("↓")
+		}
+		return "↓"
+	}
+	return "→"
+}
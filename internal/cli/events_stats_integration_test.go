@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+// statsEvents returns events around now with a trend for each reason over an hour: a new
+// warning, a rising one, a falling one and a normal event seen as often as the hour before
+func statsEvents(now time.Time) string {
+	event := func(name, reason, eventType, kind, object string, count int, first, last time.Duration) string {
+		return fmt.Sprintf(`apiVersion: v1
+kind: Event
+metadata:
+  name: %s
+type: %s
+reason: %s
+message: k8stool test event
+involvedObject:
+  apiVersion: v1
+  kind: %s
+  name: %s
+  namespace: integration-test
+source:
+  component: k8stool-test
+count: %d
+firstTimestamp: %s
+lastTimestamp: %s
+`, name, eventType, reason, kind, object, count,
+			now.Add(-first).UTC().Format(time.RFC3339), now.Add(-last).UTC().Format(time.RFC3339))
+	}
+	return strings.Join([]string{
+		event("k8stool-stats-mount", "K8stoolFailedMount", "Warning", "Pod", "nginx", 3, 10*time.Minute, 10*time.Minute),
+		event("k8stool-stats-backoff", "K8stoolBackOff", "Warning", "Pod", "nginx", 10, 100*time.Minute, 10*time.Minute),
+		event("k8stool-stats-unhealthy", "K8stoolUnhealthy", "Warning", "Pod", "nginx", 4, 90*time.Minute, 90*time.Minute),
+		event("k8stool-stats-scaled", "K8stoolScaled", "Normal", "Deployment", "nginx-deploy", 1, 5*time.Minute, 5*time.Minute),
+		event("k8stool-stats-scaled-before", "K8stoolScaled", "Normal", "Deployment", "nginx-deploy", 1, 70*time.Minute, 70*time.Minute),
+	}, "---\n")
+}
+
+func TestEventsStatsCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and colors and restore them after tests
+	oldStdout, oldNoColor := os.Stdout, color.NoColor
+	defer func() { os.Stdout, color.NoColor = oldStdout, oldNoColor }()
+	color.NoColor = true
+
+	createTestObjects(t, statsEvents(time.Now()), "integration-test")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "stats of a namespace",
+			args:    []string{"stats", "-n", "integration-test", "--since", "1h"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Events in the last 1h, compared with the 1h before")
+				assert.Regexp(t, `REASON\s+KIND\s+TYPE\s+COUNT\s+PREVIOUS\s+TREND\s+OBJECTS`, output)
+				assert.Regexp(t, `K8stoolFailedMount\s+Pod\s+Warning\s+3\s+0\s+new\s+1\n`, output)
+				assert.Regexp(t, `K8stoolBackOff\s+Pod\s+Warning\s+6\s+4\s+↑\s+1\n`, output)
+				assert.Regexp(t, `K8stoolScaled\s+Deployment\s+Normal\s+1\s+1\s+→\s+1\n`, output)
+				assert.Regexp(t, `K8stoolUnhealthy\s+Pod\s+Warning\s+0\s+4\s+↓\s+0\n`, output)
+				assert.Less(t, strings.Index(output, "K8stoolBackOff"), strings.Index(output, "K8stoolFailedMount"))
+			},
+		},
+		{
+			name:    "stats of warnings",
+			args:    []string{"stats", "-n", "integration-test", "--since", "1h", "--warnings"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "K8stoolFailedMount")
+				assert.NotContains(t, output, "K8stoolScaled")
+			},
+		},
+		{
+			name:    "stats as json",
+			args:    []string{"stats", "-n", "integration-test", "--since", "1h", "-o", "json"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `"namespace": "integration-test"`)
+				assert.Regexp(t, `"reason": "K8stoolFailedMount",\s+"kind": "Pod",\s+"type": "Warning",\s+"count": 3,\s+"previous": 0`, output)
+			},
+		},
+		{
+			name:    "stats of all namespaces",
+			args:    []string{"stats", "-A", "--since", "1h"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "K8stoolFailedMount")
+			},
+		},
+		{
+			name:    "stats of another namespace",
+			args:    []string{"stats", "-n", "default", "--since", "1h"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.NotContains(t, output, "K8stool")
+			},
+		},
+		{
+			name:    "stats with invalid period",
+			args:    []string{"stats", "--since", "0s"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "invalid window 0s: must be positive")
+			},
+		},
+		{
+			name:    "stats with invalid output format",
+			args:    []string{"stats", "-o", "yaml"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `invalid output format "yaml": must be table or json`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getEventsGroupCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getEvictCmd() *cobra.Command {
+	var namespace string
+	var timeout time.Duration
+	var opts k8s.PodEvictOptions
+
+	cmd := &cobra.Command{
+		Use:   "evict POD [POD...]",
+		Short: "Evict pods while respecting disruption budgets",
+		Long: `Evict pods through the Eviction API instead of deleting them. Unlike a delete, an eviction
+is refused when it would violate a PodDisruptionBudget, so a workload never drops below the
+number of healthy pods its budget requires. This is how node drain removes pods.
+
+When a budget blocks the eviction the command fails with exit code 6 and names the budget with
+its healthy and required pods. Retry once the other pods are healthy, or delete the pod to
+bypass the budget.
+
+With --dry-run the API server checks whether the eviction is allowed without evicting.
+
+Examples:
+  # Evict a pod and wait until it is gone
+  k8stool evict web-7d9c6b5f4-x2k8p
+
+  # Check whether a pod may be evicted right now
+  k8stool evict web-7d9c6b5f4-x2k8p -n shop --dry-run
+
+  # Evict with a shorter grace period without waiting
+  k8stool evict worker-0 --grace-period 10 --wait=false`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			var lastErr error
+			failed := 0
+			for _, name := range args {
+				if err := client.EvictPod(ctx, namespace, name, opts); err != nil {
+					if len(args) > 1 {
+						fmt.Println(utils.Red(fmt.Sprintf("✗ pod/%s: %v", name, err)))
+					}
+					lastErr = err
+					failed++
+					continue
+				}
+				if opts.DryRun {
+					fmt.Printf("✓ pod/%s can be evicted (dry run)\n", name)
+				} else {
+					fmt.Printf("✓ pod/%s evicted\n", name)
+				}
+			}
+
+			// A single failure keeps its category, so a blocked eviction exits with its code
+			if failed == 1 {
+				return lastErr
+			}
+			if failed > 1 {
+				return fmt.Errorf("failed to evict %d of %d pods", failed, len(args))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().Int64Var(&opts.GracePeriod, "grace-period", -1, "Seconds the pods get to terminate; -1 uses the pod's own period")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Only check whether the eviction is allowed")
+	cmd.Flags().BoolVar(&opts.Wait, "wait", true, "Wait until the pods are gone")
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait for the pods to terminate")
+	markMutating(cmd)
+
+	return cmd
+}
@@ -1,58 +1,97 @@
-//go:build !windows
-// +build !windows
-
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/signal"
-	"syscall"
+	"regexp"
+	"strings"
 
 	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
 	"k8stool/internal/k8s/pods"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
 )
 
 func getExecCmd() *cobra.Command {
 	var container string
 	var tty bool
+	var interactive bool
+	var ordinal int
+	var record string
+	var noInteractive bool
+	var env []string
+	var workdir string
 
 	cmd := &cobra.Command{
-		Use:   "exec [-c CONTAINER] POD COMMAND [args...]",
+		Use:   "exec [-c CONTAINER] (POD | statefulset/NAME) COMMAND [args...]",
 		Short: "Execute a command in a container",
-		Long:  "Execute a command in a container. If the pod has multiple containers, use -c to specify which container to execute in.",
-		Args:  cobra.MinimumNArgs(2),
+		Long: `Execute a command in a container. If the pod has multiple containers, use -c to specify which container to execute in.
+When run in a terminal without -c, you are asked to pick one; --no-interactive turns this off for scripts.
+
+A StatefulSet can be targeted as statefulset/NAME (or sts/NAME). The command runs in the replica
+chosen with --ordinal, or in the lowest ready ordinal when --ordinal is not set.
+
+--env and --workdir run the command with extra environment variables or in another directory,
+without changing the workload. The command is then run through env and sh, which the image
+must provide.
+
+Examples:
+  # Run a command in a pod
+  k8stool exec nginx -- ls /usr/share/nginx/html
+
+  # Open a shell in the primary of a database StatefulSet
+  k8stool exec sts/postgres --ordinal 0 -it -- psql
+
+  # Reproduce a request with debug logging, from the directory of the app
+  k8stool exec web-7d4b9c8f6-x2x9k --env LOG_LEVEL=debug --workdir /app -- ./bin/healthcheck
+
+  # Record an interactive session for later replay with asciinema
+  k8stool exec nginx -it --record session.cast -- sh`,
+		Args: cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			podName := args[0]
+			command, err := execCommand(args[1:], env, workdir)
+			if err != nil {
+				return err
+			}
+
 			client, err := k8s.NewClient()
 			if err != nil {
 				return fmt.Errorf("failed to initialize client: %w", err)
 			}
 
-			podName := args[0]
-			command := args[1:]
-
 			// Get current namespace
 			currentCtx, err := client.ContextService.GetCurrent()
 			if err != nil {
 				return fmt.Errorf("failed to get current context: %w", err)
 			}
 
+			// Resolve a StatefulSet target to one of its replicas
+			if statefulSet, ok := statefulSetTarget(podName); ok {
+				podName, err = client.PodService.StatefulSetPod(currentCtx.Namespace, statefulSet, ordinal)
+				if err != nil {
+					return err
+				}
+			} else if cmd.Flags().Changed("ordinal") {
+				return errs.Validationf("--ordinal can only be used with a statefulset target")
+			}
+
 			// Get pod to validate it exists and get container info
 			pod, err := client.PodService.Get(currentCtx.Namespace, podName)
 			if err != nil {
 				return fmt.Errorf("failed to get pod: %w", err)
 			}
 
-			// If container not specified and pod has multiple containers, error out
-			if container == "" && len(pod.Containers) > 1 {
-				return fmt.Errorf("pod has multiple containers, use -c to specify which container to execute in")
-			}
-
-			// If container not specified, use the first container
+			// If container not specified, use the only one or let the user pick
 			if container == "" {
-				container = pod.Containers[0].Name
+				container, err = pickContainer(pod, noInteractive)
+				if err != nil {
+					return err
+				}
 			}
 
 			// Validate container exists in pod
@@ -67,27 +106,162 @@ func getExecCmd() *cobra.Command {
 				return fmt.Errorf("container %q not found in pod %q", container, podName)
 			}
 
-			// Set up signal handling
-			sigChan := make(chan os.Signal, 1)
-			signal.Notify(sigChan, syscall.SIGWINCH)
-			defer signal.Stop(sigChan)
-
 			// Create exec options
 			execOpts := pods.ExecOptions{
 				Command: command,
 				TTY:     tty,
-				Stdin:   os.Stdin,
 				Stdout:  os.Stdout,
 				Stderr:  os.Stderr,
 			}
+			if interactive || tty {
+				execOpts.Stdin = os.Stdin
+			}
+
+			if tty {
+				restore, err := attachTerminal(&execOpts)
+				if err != nil {
+					return err
+				}
+				defer restore()
+			}
+
+			// Record the session in asciicast format
+			if record != "" {
+				width, height, err := term.GetSize(int(os.Stdout.Fd()))
+				if err != nil {
+					width, height = 80, 24
+				}
+				title := fmt.Sprintf("%s/%s (%s)", currentCtx.Namespace, podName, container)
+				recorder, err := newSessionRecorder(record, width, height, title, args[1:])
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if err := recorder.Close(); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+					}
+				}()
+
+				execOpts.Stdout = recorder.Output(execOpts.Stdout)
+				if execOpts.Stderr != nil {
+					execOpts.Stderr = recorder.Output(execOpts.Stderr)
+				}
+				if execOpts.Stdin != nil {
+					execOpts.Stdin = recorder.Input(execOpts.Stdin)
+				}
+			}
 
 			// Execute command in container
 			return client.PodService.Exec(currentCtx.Namespace, podName, container, execOpts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&container, "container", "c", "", "Container name. If omitted, the first container in the pod will be chosen")
+	cmd.Flags().StringVarP(&container, "container", "c", "", "Container name. If omitted, the only container in the pod is used")
 	cmd.Flags().BoolVarP(&tty, "tty", "t", false, "Allocate a pseudo-TTY")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Keep stdin open and pass it to the container")
+	cmd.Flags().BoolVar(&noInteractive, "no-interactive", false, "Never prompt for a container; fail if -c is needed")
+	cmd.Flags().StringVar(&record, "record", "", "Record the session to a file in asciicast v2 format")
+	cmd.Flags().IntVar(&ordinal, "ordinal", -1, "StatefulSet replica to target; -1 picks the lowest ready ordinal")
+	cmd.Flags().StringArrayVar(&env, "env", nil, "Environment variable to set for the command as KEY=VALUE, can be repeated")
+	cmd.Flags().StringVar(&workdir, "workdir", "", "Directory to run the command in")
 
 	return cmd
 }
+
+// attachTerminal sets up exec options for a TTY. A TTY merges stderr into stdout. When stdin
+// and stdout are a terminal, it is put in raw mode and its size is sent to the container until
+// the returned function restores it.
+func attachTerminal(execOpts *pods.ExecOptions) (func(), error) {
+	execOpts.Stderr = nil
+
+	stdinFd, stdoutFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(stdinFd) || !term.IsTerminal(stdoutFd) {
+		return func() {}, nil
+	}
+	state, err := term.MakeRaw(stdinFd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put terminal into raw mode: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	execOpts.SizeQueue = newTerminalSizeQueue(ctx, stdoutFd)
+	return func() {
+		cancel()
+		term.Restore(stdinFd, state)
+	}, nil
+}
+
+// envName matches the name of an environment variable
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// execCommand wraps command so it runs with the extra environment variables, through env, and
+// in workdir, through sh. Every value is passed as its own argument, so nothing is quoted or
+// expanded by the shell.
+func execCommand(command, env []string, workdir string) ([]string, error) {
+	var wrapped []string
+	if len(env) > 0 {
+		wrapped = append(wrapped, "env")
+		for _, kv := range env {
+			name, _, found := strings.Cut(kv, "=")
+			if !found || !envName.MatchString(name) {
+				return nil, errs.Validationf("invalid --env %q: must be KEY=VALUE", kv)
+			}
+			wrapped = append(wrapped, kv)
+		}
+	}
+	if workdir != "" {
+		wrapped = append(wrapped, "sh", "-c", `cd "$1" && shift && exec "$@"`, "sh", workdir)
+	}
+	return append(wrapped, command...), nil
+}
+
+// statefulSetTarget returns the StatefulSet name of a statefulset/NAME or sts/NAME target
+func statefulSetTarget(target string) (string, bool) {
+	kind, name, found := strings.Cut(target, "/")
+	if !found || name == "" {
+		return "", false
+	}
+	switch kind {
+	case "statefulset", "statefulsets", "sts":
+		return name, true
+	}
+	return "", false
+}
+
+// terminalSizeQueue feeds the local terminal size to a TTY exec session. The size is
+// watched by a platform specific watchTerminalSize.
+type terminalSizeQueue struct {
+	sizes chan remotecommand.TerminalSize
+}
+
+// newTerminalSizeQueue starts watching the size of the terminal until ctx is done
+func newTerminalSizeQueue(ctx context.Context, fd int) *terminalSizeQueue {
+	q := &terminalSizeQueue{sizes: make(chan remotecommand.TerminalSize, 1)}
+	go func() {
+		defer close(q.sizes)
+		watchTerminalSize(ctx, fd, q.send)
+	}()
+	return q
+}
+
+// send queues the current size of the terminal, replacing a size not yet consumed
+func (q *terminalSizeQueue) send(fd int) {
+	width, height, err := term.GetSize(fd)
+	if err != nil || width <= 0 || height <= 0 {
+		return
+	}
+	size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+	select {
+	case <-q.sizes:
+	default:
+	}
+	q.sizes <- size
+}
+
+// Next returns the next terminal size, or nil once the session ended
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
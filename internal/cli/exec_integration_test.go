@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +21,7 @@ func TestExecCommands_Integration(t *testing.T) {
 	defer func() { os.Stdout = oldStdout }()
 
 	rootCmd := getExecCmd()
+	recordPath := filepath.Join(t.TempDir(), "session.cast")
 
 	tests := []struct {
 		name     string
@@ -68,6 +71,63 @@ func TestExecCommands_Integration(t *testing.T) {
 				assert.Contains(t, output, "container \"nonexistent-container\" not found in pod \"nginx-default\"")
 			},
 		},
+		{
+			name:    "exec with session recording",
+			args:    []string{"nginx-default", "--record", recordPath, "ls", "/"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "etc")
+				data, err := os.ReadFile(recordPath)
+				if err != nil {
+					t.Fatalf("failed to read recording: %v", err)
+				}
+				lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+				assert.Contains(t, lines[0], `"version":2`)
+				assert.Contains(t, lines[0], `"command":"ls /"`)
+				assert.Greater(t, len(lines), 1)
+				assert.Contains(t, string(data), `"o","`)
+			},
+		},
+		{
+			name:    "exec with environment variable",
+			args:    []string{"nginx-default", "--env", "GREETING=hello", "sh", "-c", "echo $GREETING"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "hello")
+			},
+		},
+		{
+			name:    "exec with working directory",
+			args:    []string{"nginx-default", "--workdir", "/etc", "pwd"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "/etc")
+			},
+		},
+		{
+			name:    "exec with nonexistent statefulset",
+			args:    []string{"sts/nonexistent-sts", "ls"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "statefulset \"nonexistent-sts\" not found")
+			},
+		},
+		{
+			name:    "exec with ordinal on a pod",
+			args:    []string{"nginx-default", "--ordinal", "0", "ls"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "--ordinal can only be used with a statefulset target")
+			},
+		},
+		{
+			name:    "exec with invalid environment variable",
+			args:    []string{"nginx-default", "--env", "=oops", "ls"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "must be KEY=VALUE")
+			},
+		},
 	}
 
 	for _, tt := range tests {
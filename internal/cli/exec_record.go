@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// asciicastHeader is the first line of an asciicast v2 recording
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// sessionRecorder writes an exec session to a file in asciicast v2 format, which can be
+// replayed with asciinema play or uploaded to asciinema.org
+type sessionRecorder struct {
+	mu    sync.Mutex
+	file  *os.File
+	out   *bufio.Writer
+	start time.Time
+	err   error
+}
+
+// newSessionRecorder creates the recording file and writes its header
+func newSessionRecorder(path string, width, height int, title string, command []string) (*sessionRecorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording file: %w", err)
+	}
+
+	r := &sessionRecorder{file: file, out: bufio.NewWriter(file), start: time.Now()}
+	header, err := json.Marshal(asciicastHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: r.start.Unix(),
+		Command:   strings.Join(command, " "),
+		Title:     title,
+		Env:       map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	})
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to encode recording header: %w", err)
+	}
+	r.out.Write(header)
+	r.out.WriteByte('\n')
+	return r, nil
+}
+
+// Output returns a writer that records everything written to w as output events
+func (r *sessionRecorder) Output(w io.Writer) io.Writer {
+	return &recordingWriter{recorder: r, out: w, stream: &recordStream{kind: "o"}}
+}
+
+// Input returns a reader that records everything read from in as input events
+func (r *sessionRecorder) Input(in io.Reader) io.Reader {
+	return &recordingReader{recorder: r, in: in, stream: &recordStream{kind: "i"}}
+}
+
+// Close flushes and closes the recording file
+func (r *sessionRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.out.Flush(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if r.err != nil {
+		return fmt.Errorf("failed to write recording: %w", r.err)
+	}
+	return nil
+}
+
+// record appends an event to the recording. Errors are kept for Close so a full disk
+// does not interrupt the session itself.
+func (r *sessionRecorder) record(stream *recordStream, p []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data := stream.complete(p)
+	if len(data) == 0 || r.err != nil {
+		return
+	}
+	event, err := json.Marshal([]interface{}{time.Since(r.start).Seconds(), stream.kind, string(data)})
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.out.Write(event)
+	if err := r.out.WriteByte('\n'); err != nil {
+		r.err = err
+	}
+}
+
+// recordStream holds the bytes of a UTF-8 sequence split across two writes, as asciicast
+// events must contain valid text
+type recordStream struct {
+	kind    string
+	pending []byte
+}
+
+// complete returns the pending bytes plus p, keeping back a trailing incomplete rune
+func (s *recordStream) complete(p []byte) []byte {
+	data := append(s.pending, p...)
+	s.pending = nil
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				s.pending = append([]byte(nil), data[i:]...)
+				data = data[:i]
+			}
+			break
+		}
+	}
+	return data
+}
+
+type recordingWriter struct {
+	recorder *sessionRecorder
+	out      io.Writer
+	stream   *recordStream
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	n, err := w.out.Write(p)
+	if n > 0 {
+		w.recorder.record(w.stream, p[:n])
+	}
+	return n, err
+}
+
+type recordingReader struct {
+	recorder *sessionRecorder
+	in       io.Reader
+	stream   *recordStream
+}
+
+func (r *recordingReader) Read(p []byte) (int, error) {
+	n, err := r.in.Read(p)
+	if n > 0 {
+		r.recorder.record(r.stream, p[:n])
+	}
+	return n, err
+}
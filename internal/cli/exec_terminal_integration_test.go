@@ -0,0 +1,138 @@
+//go:build linux
+// +build linux
+
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestExecTerminal_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdin and stdout and restore them after tests
+	oldStdin, oldStdout := os.Stdin, os.Stdout
+	defer func() { os.Stdin, os.Stdout = oldStdin, oldStdout }()
+
+	tests := []struct {
+		name     string
+		args     []string
+		terminal bool
+		size     unix.Winsize
+		resize   *unix.Winsize
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:     "terminal size is sent to the container",
+			args:     []string{"nginx-default", "-it", "--", "stty", "size"},
+			terminal: true,
+			size:     unix.Winsize{Row: 40, Col: 120},
+			wantErr:  false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "40 120")
+			},
+		},
+		{
+			name:     "terminal resize is sent to the container",
+			args:     []string{"nginx-default", "-it", "--", "sh", "-c", "sleep 3; stty size"},
+			terminal: true,
+			size:     unix.Winsize{Row: 40, Col: 120},
+			resize:   &unix.Winsize{Row: 50, Col: 100},
+			wantErr:  false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "50 100")
+			},
+		},
+		{
+			name:     "tty without a local terminal",
+			args:     []string{"nginx-default", "-t", "--", "tty"},
+			terminal: false,
+			wantErr:  false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "/dev/pts/")
+				assert.NotContains(t, output, "not a tty")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Run with a pipe, or with a pseudo-terminal of the given size like a user's
+			var r, w *os.File
+			if tt.terminal {
+				r, w = openPTY(t)
+				if err := unix.IoctlSetWinsize(int(w.Fd()), unix.TIOCSWINSZ, &tt.size); err != nil {
+					t.Fatalf("failed to set terminal size: %v", err)
+				}
+				os.Stdin = w
+			} else {
+				r, w, _ = os.Pipe()
+				stdinR, stdinW, _ := os.Pipe()
+				stdinW.Close()
+				defer stdinR.Close()
+				os.Stdin = stdinR
+			}
+			os.Stdout = w
+
+			var buf bytes.Buffer
+			copied := make(chan struct{})
+			go func() {
+				// Reading the terminal fails once it is closed
+				io.Copy(&buf, r)
+				close(copied)
+			}()
+
+			// Resize the terminal while the command runs, as a window manager would
+			if tt.resize != nil {
+				timer := time.AfterFunc(time.Second, func() {
+					unix.IoctlSetWinsize(int(w.Fd()), unix.TIOCSWINSZ, tt.resize)
+					syscall.Kill(os.Getpid(), syscall.SIGWINCH)
+				})
+				defer timer.Stop()
+			}
+
+			// Create fresh command for each test
+			cmd := getExecCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// The terminal is out of raw mode again
+			if tt.terminal {
+				termios, err := unix.IoctlGetTermios(int(w.Fd()), unix.TCGETS)
+				if assert.NoError(t, err) {
+					assert.NotZero(t, termios.Lflag&unix.ECHO, "terminal was not restored")
+				}
+			}
+
+			// Read output
+			w.Close()
+			<-copied
+			r.Close()
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+// +build !windows
+
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchTerminalSize reports the initial terminal size and every change signalled by SIGWINCH
+func watchTerminalSize(ctx context.Context, fd int, report func(fd int)) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGWINCH)
+	defer signal.Stop(sigChan)
+
+	report(fd)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigChan:
+			report(fd)
+		}
+	}
+}
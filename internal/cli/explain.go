@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8stool/internal/k8s/apis"
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// explainWidth is the column descriptions are wrapped at
+const explainWidth = 80
+
+func getExplainCmd() *cobra.Command {
+	var apiVersion string
+	var recursive bool
+
+	cmd := &cobra.Command{
+		Use:   "explain TYPE[.FIELD.PATH]",
+		Short: "Show the documentation of a resource type or field",
+		Long: `Show the documentation of a resource type or one of its fields, read from the OpenAPI
+schema published by the API server. Custom resources are documented when their definition
+includes a schema.
+
+Resource types can be given by name, singular name or short name. Fields are addressed
+with a dot-separated path; list and map fields are descended into transparently.
+
+Examples:
+  # Document the Deployment type
+  k8stool explain deployment
+
+  # Document a field
+  k8stool explain deploy.spec.template.spec.containers.resources
+
+  # List all nested fields of the pod spec
+  k8stool explain pod.spec --recursive
+
+  # Document a type in a specific API version
+  k8stool explain hpa --api-version autoscaling/v2`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			explanation, err := client.Explain(context.Background(), k8s.ExplainOptions{
+				Path:       args[0],
+				APIVersion: apiVersion,
+				Recursive:  recursive,
+			})
+			if err != nil {
+				return err
+			}
+
+			printExplanation(explanation, recursive)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiVersion, "api-version", "", "API version of the resource type, e.g. autoscaling/v2")
+	cmd.Flags().BoolVar(&recursive, "recursive", false, "List the fields of all nested objects")
+
+	return cmd
+}
+
+func printExplanation(e *apis.Explanation, recursive bool) {
+	fmt.Printf("%s    %s\n", utils.Bold("KIND:"), e.Kind)
+	fmt.Printf("%s %s\n", utils.Bold("VERSION:"), e.APIVersion)
+	if e.FieldPath != "" {
+		fmt.Printf("\n%s   %s %s\n", utils.Bold("FIELD:"), e.FieldPath[strings.LastIndex(e.FieldPath, ".")+1:], e.Type)
+	}
+
+	fmt.Printf("\n%s\n", utils.Bold("DESCRIPTION:"))
+	description := e.Description
+	if description == "" {
+		description = "<empty>"
+	}
+	fmt.Println(wrapText(description, "    ", explainWidth))
+
+	if len(e.Fields) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%s\n", utils.Bold("FIELDS:"))
+	for _, f := range e.Fields {
+		indent := strings.Repeat("  ", f.Depth+1)
+		required := ""
+		if f.Required {
+			required = " " + utils.Red("-required-")
+		}
+		fmt.Printf("%s%s %s%s\n", indent, utils.Bold(f.Name), f.Type, required)
+		if !recursive {
+			fmt.Println(wrapText(f.Description, indent+"  ", explainWidth))
+			fmt.Println()
+		}
+	}
+}
+
+// wrapText wraps text at width, prefixing every line with indent; paragraphs are kept
+func wrapText(text, indent string, width int) string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := indent
+		for _, word := range strings.Fields(paragraph) {
+			if len(line) > len(indent) && len(line)+1+len(word) > width {
+				lines = append(lines, line)
+				line = indent
+			}
+			if len(line) > len(indent) {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIResourcesAndExplainCommands_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	tests := []struct {
+		name     string
+		newCmd   func() *cobra.Command
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "list resources",
+			newCmd:  getAPIResourcesCmd,
+			args:    []string{},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "deployments")
+				assert.Contains(t, output, "deploy")
+				assert.Contains(t, output, "apps/v1")
+			},
+		},
+		{
+			name:    "list cluster-scoped core resources",
+			newCmd:  getAPIResourcesCmd,
+			args:    []string{"--api-group", "core", "--namespaced=false"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "namespaces")
+				assert.NotContains(t, output, "configmaps")
+			},
+		},
+		{
+			name:    "explain field",
+			newCmd:  getExplainCmd,
+			args:    []string{"deploy.spec.replicas"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Deployment")
+				assert.Contains(t, output, "<integer>")
+			},
+		},
+		{
+			name:     "explain unknown field",
+			newCmd:   getExplainCmd,
+			args:     []string{"pod.spec.nonexistent"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := tt.newCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
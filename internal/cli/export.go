@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+func getExportCmd() *cobra.Command {
+	var namespace string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export TYPE NAME",
+		Short: "Export a live resource as a clean manifest",
+		Long: `Print a live resource as a manifest that is ready to commit to version control.
+
+Status, managed fields, owner references and other fields populated by the API server or
+controllers are removed, as are annotations written by kubectl and controllers. Allocated values
+that would not carry over to another cluster, such as service cluster IPs, bound volume names of
+persistent volume claims and generated job selectors, are removed as well.
+
+Any resource type known to the cluster can be exported, including custom resources.
+
+Examples:
+  # Export a deployment as YAML
+  k8stool export deploy web > web.yaml
+
+  # Export a configmap from another namespace as JSON
+  k8stool export configmap app-config -n staging -o json
+
+  # Export a custom resource
+  k8stool export certificates.cert-manager.io web-tls`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "yaml" && output != "json" {
+				return errs.Validationf("unsupported output format %q, use yaml or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			obj, err := client.GetResource(context.Background(), args[0], namespace, args[1])
+			if err != nil {
+				return err
+			}
+			clean := resources.Clean(obj)
+
+			if output == "json" {
+				return printJSON(clean.Object)
+			}
+
+			data, err := yaml.Marshal(clean.Object)
+			if err != nil {
+				return fmt.Errorf("failed to encode object: %w", err)
+			}
+			_, err = os.Stdout.Write(data)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&output, "output", "o", "yaml", "Output format: yaml or json")
+	markQueryable(cmd)
+
+	return cmd
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "export deployment as yaml",
+			args:    []string{"deploy", "nginx-default-deploy", "--namespace", "default"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "kind: Deployment")
+				assert.Contains(t, output, "name: nginx-default-deploy")
+				assert.NotContains(t, output, "resourceVersion")
+				assert.NotContains(t, output, "managedFields")
+				assert.NotContains(t, output, "status:")
+			},
+		},
+		{
+			name:    "export deployment as json",
+			args:    []string{"deploy", "nginx-default-deploy", "--namespace", "default", "-o", "json"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `"kind": "Deployment"`)
+				assert.NotContains(t, output, `"uid"`)
+			},
+		},
+		{
+			name:     "export unsupported output",
+			args:     []string{"deploy", "nginx-default-deploy", "-o", "xml"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+		{
+			name:     "export missing resource",
+			args:     []string{"deploy", "nonexistent-deploy"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := getExportCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
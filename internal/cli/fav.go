@@ -0,0 +1,305 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/favorites"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getFavCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "fav",
+		Aliases: []string{"favorites"},
+		Short:   "Keep favorite namespaces and workloads and check their health",
+		Long: `Mark namespaces and workloads as favorites and show the health of just those, as a personal
+mini-dashboard.
+
+Favorites are kept per kubeconfig context in favorites.json under the user configuration
+directory (~/.config/k8stool on Linux).`,
+	}
+
+	cmd.AddCommand(getFavAddCmd())
+	cmd.AddCommand(getFavRemoveCmd())
+	cmd.AddCommand(getFavListCmd())
+	cmd.AddCommand(getFavStatusCmd())
+
+	return cmd
+}
+
+// favoriteScope returns the current context and the namespace favorites are given in
+func favoriteScope(namespace string) (string, string, error) {
+	client, err := k8s.NewClient()
+	if err != nil {
+		return "", "", err
+	}
+	current, err := client.GetCurrentContext()
+	if err != nil {
+		return "", "", err
+	}
+	if namespace == "" {
+		namespace = client.GetCurrentNamespace()
+	}
+	return current.Name, namespace, nil
+}
+
+// updateFavorites parses favorites in the current context and applies change to each of them
+// in the favorites file
+func updateFavorites(refs []string, namespace string, change func(favs []favorites.Favorite, fav favorites.Favorite) ([]favorites.Favorite, bool), done, unchanged string) error {
+	contextName, namespace, err := favoriteScope(namespace)
+	if err != nil {
+		return err
+	}
+	path, err := favorites.DefaultPath()
+	if err != nil {
+		return err
+	}
+	favs, err := favorites.Load(path)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		fav, err := favorites.Parse(ref, contextName, namespace)
+		if err != nil {
+			return err
+		}
+		var changed bool
+		if favs, changed = change(favs, fav); changed {
+			fmt.Printf("%s %s\n", fav.Ref(), done)
+		} else {
+			fmt.Printf("%s %s\n", fav.Ref(), unchanged)
+		}
+	}
+	return favorites.Save(path, favs)
+}
+
+func getFavAddCmd() *cobra.Command {
+	var namespace string
+
+	cmd := &cobra.Command{
+		Use:   "add TYPE/NAME...",
+		Short: "Add namespaces or workloads to the favorites",
+		Long: `Add namespaces or workloads of the current context to the favorites. Types are ns,
+deploy, sts and ds; workloads are taken from the namespace given with -n, or the current one.
+
+Examples:
+  # Follow a deployment of the prod namespace
+  k8stool fav add deploy/web -n prod
+
+  # Follow a whole namespace and a statefulset
+  k8stool fav add ns/payments sts/postgres -n payments`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateFavorites(args, namespace, favorites.Add, "added to favorites", "is already a favorite")
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the workloads")
+
+	return cmd
+}
+
+func getFavRemoveCmd() *cobra.Command {
+	var namespace string
+
+	cmd := &cobra.Command{
+		Use:     "remove TYPE/NAME...",
+		Aliases: []string{"rm"},
+		Short:   "Remove namespaces or workloads from the favorites",
+		Long: `Remove namespaces or workloads of the current context from the favorites.
+
+Examples:
+  # Stop following a deployment
+  k8stool fav remove deploy/web -n prod`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateFavorites(args, namespace, favorites.Remove, "removed from favorites", "is not a favorite")
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the workloads")
+
+	return cmd
+}
+
+func getFavListCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the favorites of every context",
+		Long: `List the favorites of every context without contacting the clusters.
+
+Examples:
+  # List favorites
+  k8stool fav list`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			path, err := favorites.DefaultPath()
+			if err != nil {
+				return err
+			}
+			favs, err := favorites.Load(path)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(favs)
+			}
+			if len(favs) == 0 {
+				fmt.Println("No favorites, add some with k8stool fav add")
+				return nil
+			}
+			w := newTable(os.Stdout)
+			defer w.Flush()
+			fmt.Fprintln(w, "CONTEXT\tNAMESPACE\tKIND\tNAME")
+			for _, f := range favs {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.Context, orDash(f.Namespace), f.Kind, f.Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+
+	return cmd
+}
+
+func getFavStatusCmd() *cobra.Command {
+	var since time.Duration
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the health of the favorites of the current context",
+		Long: `Show a compact health view of the favorites of the current context: ready replicas,
+container restarts, recent warning events and live CPU and memory usage.
+
+For a namespace the replicas are its pods, not counting completed ones, and every warning of
+the namespace is counted. For a workload the warnings of the workload, its pods and, for
+deployments, its ReplicaSets are counted. Usage comes from metrics-server and is left out when
+it is not installed.
+
+A favorite is Healthy when all replicas are ready, Degraded when some are, Unavailable when
+none are, and NotFound when it no longer exists.
+
+Examples:
+  # Check on the favorites
+  k8stool fav status
+
+  # Count warnings of the last 10 minutes only
+  k8stool fav status --since 10m`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			path, err := favorites.DefaultPath()
+			if err != nil {
+				return err
+			}
+			all, err := favorites.Load(path)
+			if err != nil {
+				return err
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			current, err := client.GetCurrentContext()
+			if err != nil {
+				return err
+			}
+			favs := favorites.InContext(all, current.Name)
+			if len(favs) == 0 && output == "table" {
+				fmt.Printf("No favorites in context %s, add some with k8stool fav add\n", current.Name)
+				return nil
+			}
+
+			statuses, err := client.FavoritesStatus(context.Background(), favs, k8s.FavoriteStatusOptions{Since: since})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(statuses)
+			}
+			return printFavoriteStatuses(statuses)
+		},
+	}
+
+	cmd.Flags().DurationVar(&since, "since", time.Hour, "Count warning events seen within this duration")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printFavoriteStatuses(statuses []k8s.FavoriteStatus) error {
+	metricsAvailable := false
+	for _, s := range statuses {
+		metricsAvailable = metricsAvailable || s.MetricsAvailable
+	}
+
+	w := newTable(os.Stdout)
+	defer w.Flush()
+
+	header := []string{"NAMESPACE", "KIND", "NAME", "READY", "RESTARTS", "WARNINGS"}
+	if metricsAvailable {
+		header = append(header, "CPU", "MEMORY")
+	}
+	fmt.Fprintln(w, strings.Join(append(header, "HEALTH"), "\t"))
+
+	for _, s := range statuses {
+		namespace := s.Namespace
+		if s.Kind == favorites.KindNamespace {
+			namespace = s.Name
+		}
+		row := []string{namespace, s.Kind, s.Name}
+		if s.Error != "" || s.Health == favorites.NotFound {
+			row = append(row, "-", "-", "-")
+		} else {
+			row = append(row, fmt.Sprintf("%d/%d", s.Ready, s.Desired), fmt.Sprintf("%d", s.Restarts), fmt.Sprintf("%d", s.Warnings))
+		}
+		if metricsAvailable {
+			if s.MetricsAvailable {
+				row = append(row, utils.FormatMilliCPU(s.CPUUsage), utils.FormatBytes(s.MemoryUsage))
+			} else {
+				row = append(row, "-", "-")
+			}
+		}
+		fmt.Fprintln(w, strings.Join(append(row, formatFavoriteHealth(s)), "\t"))
+	}
+	return nil
+}
+
+// formatFavoriteHealth colors the health of a favorite; it is the last column so the escape
+// codes do not skew the alignment
+func formatFavoriteHealth(s k8s.FavoriteStatus) string {
+	if s.Error != "" {
+		return utils.Red("Error: " + s.Error)
+	}
+	switch s.Health {
+	case favorites.Healthy:
+		if s.Warnings > 0 {
+			return utils.Yellow(s.Health + " (warnings)")
+		}
+		return utils.Green(s.Health)
+	case favorites.Degraded:
+		return utils.Yellow(s.Health)
+	}
+	return utils.Red(s.Health)
+}
@@ -0,0 +1,327 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// imageWorkloadKinds maps the accepted resource type names to workload kinds with images
+var imageWorkloadKinds = map[string]string{
+	"deploy":       "deployment",
+	"deployment":   "deployment",
+	"deployments":  "deployment",
+	"sts":          "statefulset",
+	"statefulset":  "statefulset",
+	"statefulsets": "statefulset",
+	"ds":           "daemonset",
+	"daemonset":    "daemonset",
+	"daemonsets":   "daemonset",
+}
+
+func getImageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "image",
+		Aliases: []string{"images"},
+		Short:   "Inspect the container images of workloads",
+		Long:    "Compare the container images of workloads with the tags in their registries, and pin mutable tags to digests.",
+	}
+
+	cmd.AddCommand(getImageTagsCmd())
+	cmd.AddCommand(getImagePinningCmd())
+
+	return cmd
+}
+
+func getImageTagsCmd() *cobra.Command {
+	var namespace string
+	var container string
+	var limit int
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "tags (TYPE/NAME | NAME)",
+		Short: "List registry tags newer than the deployed image",
+		Long: `Query the registry of each image of a deployment, statefulset or daemonset and list the
+tags newer than the deployed one, to tell whether there is a newer build.
+
+A tag is newer when it is a higher version of the same variant: for 1.25.3-alpine only tags
+like 1.27.0-alpine are considered. When the deployed tag is not a version, such as latest,
+the highest version tags are listed instead. The deployed tag is also resolved to its current
+digest; when it differs from what the pods run, the tag was pushed again since they started.
+
+Docker Hub, GHCR, ECR and any registry implementing the distribution API are supported.
+Credentials are taken from the workload's image pull secrets, the local docker config and
+its credential helpers, the AWS CLI for ECR and GITHUB_TOKEN for GHCR. Public images need
+none.
+
+Examples:
+  # Is there a newer build of the api deployment?
+  k8stool image tags deploy/api
+
+  # Check one container of a statefulset, showing at most 5 tags
+  k8stool image tags sts/postgres -c postgres --limit 5`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			kind, name := "deployment", args[0]
+			if resourceType, resourceName, found := strings.Cut(args[0], "/"); found {
+				var ok bool
+				if kind, ok = imageWorkloadKinds[strings.ToLower(resourceType)]; !ok {
+					return errs.Validationf("unsupported resource type %q: must be a deployment, statefulset or daemonset", resourceType)
+				}
+				name = resourceName
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			report, err := client.ImageTags(context.Background(), k8s.ImageTagOptions{
+				Namespace: namespace,
+				Kind:      kind,
+				Name:      name,
+				Container: container,
+				Limit:     limit,
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(report)
+			}
+			printImageTags(report)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&container, "container", "c", "", "Only look up the image of this container")
+	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum number of tags to show per container; 0 shows all")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printImageTags(report *k8s.ImageTagReport) {
+	for i, c := range report.Containers {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s\n", utils.Bold("Container "+c.Container+":"), c.Image)
+		if c.Error != "" {
+			fmt.Printf("  %s %s\n", utils.Red("Error:"), c.Error)
+			continue
+		}
+
+		w := newTable(os.Stdout)
+		fmt.Fprintf(w, "  Registry:\t%s/%s (%d tags)\n", c.Reference.Registry, c.Reference.Repository, c.TotalTags)
+		if c.RegistryDigest != "" {
+			fmt.Fprintf(w, "  Tag digest:\t%s\n", c.RegistryDigest)
+		}
+		if len(c.RunningDigests) > 0 {
+			fmt.Fprintf(w, "  Running:\t%s\n", strings.Join(c.RunningDigests, ", "))
+		}
+		w.Flush()
+
+		if c.TagMoved {
+			fmt.Printf("  %s tag %s was pushed again since the pods started; restart them to pick up the new build\n",
+				utils.Yellow("Newer build:"), c.Reference.Tag)
+		}
+		switch {
+		case c.Comparable && len(c.Newer) == 0:
+			fmt.Printf("  %s no newer version than %s\n", utils.Green("Up to date:"), c.Reference.Tag)
+		case c.Comparable:
+			fmt.Printf("  %s %s\n", utils.Yellow("Newer versions:"), strings.Join(c.Newer, ", "))
+		case len(c.Highest) > 0:
+			deployed := c.Reference.Tag
+			if deployed == "" {
+				deployed = c.Reference.Digest
+			}
+			fmt.Printf("  %s is not a version; highest version tags: %s\n", deployed, strings.Join(c.Highest, ", "))
+		default:
+			fmt.Println("  No version tags found")
+		}
+	}
+}
+
+// imageKindShortNames are the short resource type names workloads are shown with
+var imageKindShortNames = map[string]string{
+	"deployment":  "deploy",
+	"statefulset": "sts",
+	"daemonset":   "ds",
+}
+
+func getImagePinningCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var fix bool
+	var yes bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:     "pinning",
+		Aliases: []string{"pin"},
+		Short:   "Find mutable image tags and pin them to the running digests",
+		Long: `Find deployments, statefulsets and daemonsets whose images use a mutable tag: no tag,
+latest, or a floating tag like stable, main or nightly. Such a tag can be pushed again at any
+time, so a rollback or a rescheduled pod may silently pull a different build than the one
+running now.
+
+For every such container the digest its pods run is looked up, and a strategic merge patch is
+generated that pins the image to it. The patches are printed as kubectl commands; --fix applies
+them after a confirmation. A container is left alone when no running pod reports its digest or
+when the pods run several digests, since pinning one would roll the others to it.
+
+Pinning changes the pod template, so the workload rolls out again with the same build.
+
+Examples:
+  # Check the workloads of the current namespace
+  k8stool image pinning
+
+  # Pin every mutable image in the cluster to its running digest
+  k8stool image pinning -A --fix`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			if fix && output != "table" {
+				return errs.Validationf("--fix only supports the table output")
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if namespace == "" && !allNamespaces {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			ctx := context.Background()
+			findings, err := client.ImagePinning(ctx, k8s.ImagePinOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(findings)
+			}
+			if len(findings) == 0 {
+				fmt.Println("No workloads use mutable image tags")
+				return nil
+			}
+			printImagePinning(findings)
+
+			var fixable []k8s.ImagePinFinding
+			for _, f := range findings {
+				if f.Patch != "" {
+					fixable = append(fixable, f)
+				}
+			}
+			if len(fixable) == 0 {
+				return nil
+			}
+
+			if !fix {
+				fmt.Println()
+				fmt.Println(utils.Bold("Patches:"))
+				for _, f := range fixable {
+					fmt.Printf("kubectl patch %s %s -n %s --type strategic -p '%s'\n", f.Kind, f.Name, f.Namespace, f.Patch)
+				}
+				fmt.Println()
+				fmt.Println("Run with --fix to apply them.")
+				return nil
+			}
+
+			// Only --fix changes the cluster, so the command is not marked as mutating
+			if err := confirmProtectedContext(cmd.CommandPath()); err != nil {
+				return err
+			}
+			if !yes {
+				ok, err := confirm(fmt.Sprintf("Pin the images of %d workloads to their running digests", len(fixable)))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("aborted")
+				}
+			}
+
+			failed := 0
+			for _, f := range fixable {
+				name := fmt.Sprintf("%s/%s", imageKindShortNames[f.Kind], f.Name)
+				if err := client.PinImages(ctx, f); err != nil {
+					fmt.Println(utils.Red(fmt.Sprintf("✗ %s: %v", name, err)))
+					failed++
+					continue
+				}
+				fmt.Printf("✓ %s pinned\n", name)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d workloads could not be pinned", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Check workloads in all namespaces")
+	cmd.Flags().BoolVar(&fix, "fix", false, "Apply the patches pinning images to their running digests")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printImagePinning(findings []k8s.ImagePinFinding) {
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tCONTAINER\tIMAGE\tREASON\tPIN")
+	for _, f := range findings {
+		for _, c := range f.Containers {
+			pin := utils.Yellow(c.Problem)
+			if c.Pinned != "" {
+				pin = utils.Green(shortDigest(c.RunningDigests[0]))
+			}
+			fmt.Fprintf(w, "%s\t%s/%s\t%s\t%s\t%s\t%s\n",
+				f.Namespace,
+				imageKindShortNames[f.Kind],
+				f.Name,
+				c.Container,
+				c.Image,
+				c.Reason,
+				pin,
+			)
+		}
+	}
+	w.Flush()
+}
+
+// shortDigest abbreviates a digest to its algorithm and first 12 hex characters
+func shortDigest(digest string) string {
+	algorithm, hex, found := strings.Cut(digest, ":")
+	if !found || len(hex) <= 12 {
+		return digest
+	}
+	return algorithm + ":" + hex[:12]
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	k8s "k8stool/internal/k8s/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImpersonationFlags_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and the impersonation state and restore them after tests
+	oldStdout := os.Stdout
+	defer func() {
+		os.Stdout = oldStdout
+		asUser, asGroups = "", nil
+		k8s.SetImpersonation("", nil)
+	}()
+
+	// A namespace template with a single ConfigMap
+	templateDir := t.TempDir()
+	configMap := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: team\ndata:\n  team: \"{{ .Name }}\"\n"
+	if err := os.WriteFile(filepath.Join(templateDir, "configmap.yaml"), []byte(configMap), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	// The impersonated user has no RBAC bindings, so every request made as it is forbidden
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "get pods as another user",
+			args:    []string{"get", "pods", "-n", "default", "--as", "k8stool-nobody"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `User "k8stool-nobody" cannot list resource "pods"`)
+			},
+		},
+		{
+			name:    "list namespaces as another user",
+			args:    []string{"ns", "list", "--as", "k8stool-nobody"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `User "k8stool-nobody" cannot list resource "namespaces"`)
+			},
+		},
+		{
+			name:    "bootstrap a namespace as another user",
+			args:    []string{"ns", "bootstrap", "impersonation-test", "--template", templateDir, "--as", "k8stool-nobody"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `User "k8stool-nobody" cannot`)
+			},
+		},
+		{
+			name:    "group without user",
+			args:    []string{"ns", "list", "--as-group", "system:masters"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "--as-group requires --as")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Run through the root command so the persistent --as flags are parsed
+			cmd := rootCmd
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+			asUser, asGroups = "", nil
+			k8s.SetImpersonation("", nil)
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -2,12 +2,18 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"strings"
 	"testing"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/resources"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestContextCommands_Integration(t *testing.T) {
@@ -96,3 +102,31 @@ func TestContextCommands_Integration(t *testing.T) {
 		})
 	}
 }
+
+// createTestObjects applies the objects of a manifest in a namespace and deletes them when
+// the test ends
+func createTestObjects(t *testing.T, manifest, namespace string) {
+	objs, err := resources.Decode([]byte(manifest))
+	if err != nil {
+		t.Fatalf("failed to decode objects: %v", err)
+	}
+	client, err := k8s.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Delete(context.Background(), objs, k8s.DeleteOptions{Namespace: namespace, IgnoreNotFound: true, Wait: true, Timeout: time.Minute})
+	})
+	if err := applyTestObjects(client, objs, namespace); err != nil {
+		t.Fatalf("failed to create objects: %v", err)
+	}
+}
+
+// applyTestObjects applies objects, failing when any of them is not applied
+func applyTestObjects(client *k8s.Client, objs []*unstructured.Unstructured, namespace string) error {
+	results, err := client.Apply(context.Background(), objs, k8s.ApplyOptions{Namespace: namespace})
+	if err != nil {
+		return err
+	}
+	return resultsError(results)
+}
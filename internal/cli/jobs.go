@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func getJobsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "jobs",
+		Aliases: []string{"job"},
+		Short:   "Inspect the attempts of Jobs and read their logs",
+		Long: `Inspect the pods a Job created, failed retries included, and read their logs in order of
+attempt.`,
+	}
+
+	cmd.AddCommand(getJobsAttemptsCmd())
+	cmd.AddCommand(getJobsLogsCmd())
+
+	return cmd
+}
+
+func getJobsAttemptsCmd() *cobra.Command {
+	var namespace string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "attempts NAME",
+		Short: "List the pods of a Job in order of attempt",
+		Long: `List the pods a Job created, failed retries included, numbered in order of creation. For
+indexed jobs attempts are numbered per completion index.
+
+Examples:
+  # See how often a migration job was retried
+  k8stool jobs attempts db-migrate -n prod`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			attempts, err := client.JobAttempts(context.Background(), namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(attempts)
+			}
+			if len(attempts) == 0 {
+				fmt.Printf("Job %s has no pods\n", args[0])
+				return nil
+			}
+			w := newTable(os.Stdout)
+			defer w.Flush()
+			fmt.Fprintln(w, "ATTEMPT\tPOD\tNODE\tRESTARTS\tAGE\tPHASE")
+			for _, a := range attempts {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", formatAttempt(a), a.Pod, orDash(a.Node), a.Restarts, formatSince(a.Created), utils.ColorizeStatus(a.Phase))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func getJobsLogsCmd() *cobra.Command {
+	var namespace string
+	var container string
+	var allContainers bool
+	var follow bool
+	var tail int64
+	var timestamps bool
+	var noPrefix bool
+
+	cmd := &cobra.Command{
+		Use:   "logs NAME",
+		Short: "Print the logs of every attempt of a Job",
+		Long: `Print the logs of every pod a Job created, failed retries included, in order of attempt.
+Each line is prefixed with its attempt number, and with its completion index for indexed jobs,
+so a retry that failed differently than the first attempt is easy to spot.
+
+With -f running attempts are streamed and new retries are picked up as they start, until the
+Job completes or fails. With restartPolicy OnFailure retries restart the container within the
+same pod; the kubelet only keeps the logs of the run before the current one, and those are
+printed first.
+
+Examples:
+  # Why did the migration fail on every retry?
+  k8stool jobs logs db-migrate -n prod
+
+  # Follow a running job through its retries
+  k8stool jobs logs nightly-report -f
+
+  # Logs of every container, the last 100 lines each
+  k8stool jobs logs etl --all-containers --tail 100`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			var tailLines *int64
+			if tail >= 0 {
+				tailLines = &tail
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			// Followed logs never end, so they are not paged
+			if !follow {
+				defer startPager()()
+			}
+			stdout := os.Stdout
+
+			return client.JobLogs(ctx, namespace, args[0], k8s.JobLogOptions{
+				Container:     container,
+				AllContainers: allContainers,
+				Follow:        follow,
+				TailLines:     tailLines,
+				Timestamps:    timestamps,
+				WriterFor: func(attempt k8s.JobAttempt, c string) io.Writer {
+					var out io.Writer = stdout
+					if !noPrefix {
+						prefix := "[" + formatAttempt(attempt)
+						if allContainers {
+							prefix += "/" + c
+						}
+						prefix += "]"
+						attr := prefixColors[(attempt.Number-1)%len(prefixColors)]
+						out = &prefixWriter{out: out, prefix: []byte(color.New(attr).Sprint(prefix) + " ")}
+					}
+					if timestamps {
+						out = &timestampWriter{out: out}
+					}
+					return out
+				},
+				OnAttempt: func(attempt k8s.JobAttempt) {
+					fmt.Fprintln(stdout, utils.Bold(fmt.Sprintf("==> %s: pod %s (%s)", formatAttempt(attempt), attempt.Pod, attempt.Phase)))
+				},
+				OnError: func(attempt k8s.JobAttempt, c string, err error) {
+					fmt.Fprintf(os.Stderr, "Warning: %s, container %s: %v\n", formatAttempt(attempt), c, err)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&container, "container", "c", "", "Print the logs of this container")
+	cmd.Flags().BoolVarP(&allContainers, "all-containers", "a", false, "Get logs from all containers, init containers included")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Follow running attempts and new retries until the job finishes")
+	cmd.Flags().Int64VarP(&tail, "tail", "t", -1, "Lines of recent log file to display per container")
+	cmd.Flags().BoolVar(&timestamps, "timestamps", false, "Prefix each line with its timestamp")
+	cmd.Flags().BoolVar(&noPrefix, "no-prefix", false, "Do not prefix lines with their attempt")
+
+	return cmd
+}
+
+// formatAttempt names an attempt of a Job, with its completion index for indexed jobs
+func formatAttempt(a k8s.JobAttempt) string {
+	if a.Index != nil {
+		return fmt.Sprintf("index %d attempt %d", *a.Index, a.Number)
+	}
+	return fmt.Sprintf("attempt %d", a.Number)
+}
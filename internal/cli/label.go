@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func getLabelCmd() *cobra.Command {
+	return newMetadataCmd(resources.Labels, "label", `Add, change or remove labels on resources.
+
+Changes are given as KEY=VALUE to set a label and KEY- to remove it. Resources are selected by
+name or with a label selector. Changing a label that already has a different value requires
+--overwrite. Objects matched by a selector are listed first and only changed once their
+number is typed back, or with --yes.
+
+Examples:
+  # Label a pod
+  k8stool label pods web-1 tier=frontend
+
+  # Change an existing label and remove another
+  k8stool label deploy web tier=backend legacy- --overwrite
+
+  # Label every pod matching a selector without confirmation
+  k8stool label pods -l app=web canary=true --yes`)
+}
+
+func getAnnotateCmd() *cobra.Command {
+	return newMetadataCmd(resources.Annotations, "annotate", `Add, change or remove annotations on resources.
+
+Changes are given as KEY=VALUE to set an annotation and KEY- to remove it. Resources are selected
+by name or with a label selector. Changing an annotation that already has a different value
+requires --overwrite. Objects matched by a selector are listed first and only changed once
+their number is typed back, or with --yes.
+
+Examples:
+  # Annotate a deployment
+  k8stool annotate deploy web team=payments
+
+  # Record a change cause on every matching deployment
+  k8stool annotate deploy -l app=web kubernetes.io/change-cause="bump to v2" --overwrite
+
+  # Remove an annotation
+  k8stool annotate svc web legacy-`)
+}
+
+// newMetadataCmd builds the label and annotate commands, which only differ in the field they change
+func newMetadataCmd(field resources.MetadataField, use, long string) *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var selector string
+	var yes bool
+	var opts resources.MetadataOptions
+
+	cmd := &cobra.Command{
+		Use:   use + " TYPE (NAME... | -l SELECTOR) KEY=VALUE... KEY-...",
+		Short: fmt.Sprintf("Add, change or remove %s on resources", field),
+		Long:  long,
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names, set, remove, err := parseMetadataArgs(field, args[1:])
+			if err != nil {
+				return err
+			}
+			if len(set) == 0 && len(remove) == 0 {
+				return errs.Validationf("specify at least one KEY=VALUE or KEY- change")
+			}
+			if (len(names) == 0) == (selector == "") {
+				return errs.Validationf("specify either resource names or a selector with -l")
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			ctx := context.Background()
+			var objs []*unstructured.Unstructured
+			if selector != "" {
+				objs, err = client.ListResources(ctx, args[0], resources.ListOptions{
+					Namespace:     namespace,
+					AllNamespaces: allNamespaces,
+					Selector:      selector,
+				})
+			} else {
+				objs, _, err = getNamedResources(ctx, client, args[0], namespace, names, false)
+			}
+			if err != nil {
+				return err
+			}
+			if len(objs) == 0 {
+				fmt.Println("No resources found")
+				return nil
+			}
+
+			if selector != "" && !yes && !opts.DryRun {
+				ok, err := confirmSelection(strings.ToUpper(use[:1])+use[1:], objs)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("aborted")
+				}
+			}
+
+			opts.Field = field
+			opts.Set = set
+			opts.Remove = remove
+			results, err := client.SetMetadata(ctx, objs, opts)
+			printMetadataResults(results, use, opts.DryRun)
+			if err != nil {
+				return err
+			}
+			return resultsError(results)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Match the selector across all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().BoolVar(&opts.Overwrite, "overwrite", false, fmt.Sprintf("Allow changing %s that already have a different value", field))
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Submit a server-side dry run without persisting changes")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", resources.DefaultConcurrency, "Number of objects changed at a time")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt for selectors")
+	markMutating(cmd)
+
+	return cmd
+}
+
+// parseMetadataArgs splits arguments into resource names, keys to set and keys to remove
+func parseMetadataArgs(field resources.MetadataField, args []string) (names []string, set map[string]string, remove []string, err error) {
+	set = make(map[string]string)
+	for _, arg := range args {
+		switch {
+		case strings.Contains(arg, "="):
+			key, value, _ := strings.Cut(arg, "=")
+			if err := validateMetadata(field, key, value); err != nil {
+				return nil, nil, nil, err
+			}
+			set[key] = value
+		case strings.HasSuffix(arg, "-"):
+			key := strings.TrimSuffix(arg, "-")
+			if problems := validation.IsQualifiedName(key); len(problems) > 0 {
+				return nil, nil, nil, errs.Validationf("invalid key %q: %s", key, strings.Join(problems, "; "))
+			}
+			remove = append(remove, key)
+		default:
+			if len(set) > 0 || len(remove) > 0 {
+				return nil, nil, nil, errs.Validationf("resource names must come before changes, got %q", arg)
+			}
+			names = append(names, arg)
+		}
+	}
+
+	for _, key := range remove {
+		if _, ok := set[key]; ok {
+			return nil, nil, nil, errs.Validationf("key %q is both set and removed", key)
+		}
+	}
+	return names, set, remove, nil
+}
+
+func validateMetadata(field resources.MetadataField, key, value string) error {
+	if problems := validation.IsQualifiedName(key); len(problems) > 0 {
+		return errs.Validationf("invalid key %q: %s", key, strings.Join(problems, "; "))
+	}
+	// Annotation values are free-form; label values are restricted
+	if field == resources.Labels {
+		if problems := validation.IsValidLabelValue(value); len(problems) > 0 {
+			return errs.Validationf("invalid label value %q: %s", value, strings.Join(problems, "; "))
+		}
+	}
+	return nil
+}
+
+func printMetadataResults(results []resources.Result, verb string, dryRun bool) {
+	suffix := ""
+	if dryRun {
+		suffix = " (dry run)"
+	}
+	past := strings.TrimSuffix(verb, "e") + "ed"
+	for _, r := range results {
+		ref := r.Ref()
+		if r.Namespace != "" {
+			ref = r.Namespace + "/" + ref
+		}
+		switch r.Action {
+		case resources.Failed:
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", utils.Red("error"), ref, r.Error)
+		case resources.Unchanged:
+			fmt.Printf("%s not %s%s\n", ref, past, suffix)
+		default:
+			fmt.Printf("%s %s%s\n", ref, utils.Green(past), suffix)
+		}
+	}
+}
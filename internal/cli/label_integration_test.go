@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelCommands_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	tests := []struct {
+		name     string
+		cmd      func() *cobra.Command
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "label pod",
+			cmd:     getLabelCmd,
+			args:    []string{"pods", "nginx-default", "k8stool-test=true"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "pod/nginx-default labeled")
+			},
+		},
+		{
+			name:    "label pod again",
+			cmd:     getLabelCmd,
+			args:    []string{"pods", "nginx-default", "k8stool-test=true"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "pod/nginx-default not labeled")
+			},
+		},
+		{
+			name:     "change label without overwrite",
+			cmd:      getLabelCmd,
+			args:     []string{"pods", "nginx-default", "k8stool-test=false"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+		{
+			name:    "annotate by selector",
+			cmd:     getAnnotateCmd,
+			args:    []string{"pods", "-l", "k8stool-test=true", "k8stool/note=integration", "--yes"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "pod/nginx-default annotated")
+			},
+		},
+		{
+			name:    "remove label",
+			cmd:     getLabelCmd,
+			args:    []string{"pods", "nginx-default", "k8stool-test-"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "pod/nginx-default labeled")
+			},
+		},
+		{
+			name:     "invalid label value",
+			cmd:      getLabelCmd,
+			args:     []string{"pods", "nginx-default", "k8stool-test=not valid"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := tt.cmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
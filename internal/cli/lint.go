@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/lint"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check workload configuration for common mistakes",
+		Long:  "Check the configuration of live workloads for common mistakes.",
+	}
+
+	cmd.AddCommand(getLintProbesCmd())
+
+	return cmd
+}
+
+func getLintProbesCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var selector string
+
+	cmd := &cobra.Command{
+		Use:   "probes",
+		Short: "Check liveness, readiness and startup probes",
+		Long: `Check the health probes of deployments, statefulsets, daemonsets and bare pods.
+
+Flags containers without liveness or readiness probes, liveness and readiness probes that
+check the same endpoint, overly aggressive periods and thresholds, and probes pointing at
+ports the container does not expose.
+
+Examples:
+  # Lint probes in the current namespace
+  k8stool lint probes
+
+  # Lint probes of matching workloads across all namespaces
+  k8stool lint probes -A -l team=payments`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			findings, err := client.LintProbes(context.Background(), k8s.LintOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+				Selector:      selector,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(findings) == 0 {
+				fmt.Println("No probe issues found")
+				return nil
+			}
+			printLintFindings(findings)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Lint across all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
+
+	return cmd
+}
+
+func printLintFindings(findings []lint.Finding) {
+	w := newTable(os.Stdout)
+
+	var errors, warnings int
+	fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tCONTAINER\tPROBE\tSEVERITY\tRULE\tMESSAGE")
+	for _, f := range findings {
+		probe := f.Probe
+		if probe == "" {
+			probe = "-"
+		}
+		severity := utils.Yellow(string(f.Severity))
+		if f.Severity == lint.Error {
+			severity = utils.Red(string(f.Severity))
+			errors++
+		} else {
+			warnings++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", f.Namespace, f.Workload, f.Container, probe, severity, f.Rule, f.Message)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d errors, %d warnings\n", errors, warnings)
+}
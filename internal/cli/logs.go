@@ -1,12 +1,14 @@
 package cli
 
 import (
-	"fmt"
+	"io"
 	"os"
 	"strings"
+	"text/template"
 	"time"
 
 	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
 
 	"github.com/spf13/cobra"
 )
@@ -20,11 +22,20 @@ func getLogsCmd() *cobra.Command {
 	var since string
 	var sinceTime string
 	var allContainers bool
+	var timestamps bool
+	var noInteractive bool
+	var prefixTemplate string
+	var highlight []string
+	var initContainers bool
 
 	cmd := &cobra.Command{
 		Use:   "logs (pod|deployment)/(name) or (pod|deployment) [name]",
 		Short: "View logs from containers",
 		Long: `View logs from containers in pods or deployments.
+
+If a pod has multiple containers and -c is not given, you are asked to pick one when running in a
+terminal. Use --no-interactive to fail instead, as scripts should.
+
 Examples:
   # Get logs from a pod
   k8stool logs pod/nginx-pod
@@ -34,7 +45,16 @@ Examples:
   k8stool logs deployment/nginx
   k8stool logs deployment nginx
   k8stool logs deploy/nginx
-  k8stool logs deploy nginx`,
+  k8stool logs deploy nginx
+
+  # Find out why a pod is stuck initializing
+  k8stool logs pod/nginx-pod --init-containers
+
+  # Follow logs, highlighting error signatures without hiding other lines
+  k8stool logs deploy/nginx -f --highlight 'error|panic,timeout'
+
+  # Shorter line prefixes for deployment logs
+  k8stool logs deploy/nginx --prefix-template '{{.PodShort}}/{{.Container}}'`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := k8s.NewClient()
@@ -53,7 +73,7 @@ Examples:
 				// Handle slash format: pod/nginx-pod
 				parts := strings.SplitN(args[0], "/", 2)
 				if len(parts) != 2 {
-					return fmt.Errorf("invalid resource format. Use 'pod/name' or 'deployment/name' or 'pod name' or 'deployment name'")
+					return errs.Validationf("invalid resource format. Use 'pod/name' or 'deployment/name' or 'pod name' or 'deployment name'")
 				}
 				resourceType = parts[0]
 				name = parts[1]
@@ -63,6 +83,23 @@ Examples:
 				name = args[1]
 			}
 
+			// Lines of several pods or containers are told apart by a prefix, which is opt-in for
+			// a single container
+			var prefix *template.Template
+			isPod := resourceType == "pod" || resourceType == "po"
+			multiple := !isPod || allContainers || initContainers
+			if prefixTemplate != "" && (multiple || cmd.Flags().Changed("prefix-template")) {
+				prefix, err = parseLogPrefix(prefixTemplate)
+				if err != nil {
+					return err
+				}
+			}
+
+			highlights, err := parseHighlights(highlight)
+			if err != nil {
+				return err
+			}
+
 			// Parse time filters
 			var sinceSeconds *int64
 			var startTime *time.Time
@@ -70,7 +107,7 @@ Examples:
 			if since != "" {
 				duration, err := time.ParseDuration(since)
 				if err != nil {
-					return fmt.Errorf("invalid duration: %v", err)
+					return errs.Validationf("invalid duration: %v", err)
 				}
 				seconds := int64(duration.Seconds())
 				sinceSeconds = &seconds
@@ -79,7 +116,7 @@ Examples:
 			if sinceTime != "" {
 				t, err := time.Parse(time.RFC3339, sinceTime)
 				if err != nil {
-					return fmt.Errorf("invalid time format: %v", err)
+					return errs.Validationf("invalid time format: %v", err)
 				}
 				startTime = &t
 			}
@@ -90,29 +127,74 @@ Examples:
 				tailLines = &tail
 			}
 
+			// Let the user pick the container of a multi-container pod before paging starts.
+			// Lookup errors are left to the log request, which reports them.
+			if isPod && container == "" && !allContainers && !initContainers {
+				if pod, err := client.PodService.Get(namespace, name); err == nil {
+					container, err = pickContainer(pod, noInteractive)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			// Followed logs never end, so they are not paged
+			if !follow {
+				defer startPager()()
+			}
+
+			// Log lines are prefixed with the API server's RFC3339 timestamps, shown in --time-format.
+			// Matches are highlighted and timestamps rewritten before the pod prefix is added.
+			stdout := os.Stdout
+			writerFor := func(pod, container string) io.Writer {
+				var out io.Writer = stdout
+				if prefix != nil {
+					out = &prefixWriter{out: out, prefix: []byte(logPrefix(prefix, namespace, pod, container))}
+				}
+				if timestamps {
+					out = &timestampWriter{out: out}
+				}
+				if len(highlights) > 0 {
+					out = &highlightWriter{out: out, patterns: highlights}
+				}
+				return out
+			}
+			var writerForContainer func(pod, container string) io.Writer
+			if prefix != nil {
+				writerForContainer = writerFor
+			}
+			out := writerFor(name, container)
+
 			switch resourceType {
 			case "pod", "po":
 				return client.GetPodLogs(namespace, name, container, k8s.LogOptions{
-					Follow:       follow,
-					Previous:     previous,
-					TailLines:    tailLines,
-					Writer:       os.Stdout,
-					SinceTime:    startTime,
-					SinceSeconds: sinceSeconds,
+					Follow:         follow,
+					Previous:       previous,
+					TailLines:      tailLines,
+					Writer:         out,
+					WriterFor:      writerForContainer,
+					SinceTime:      startTime,
+					SinceSeconds:   sinceSeconds,
+					Timestamps:     timestamps,
+					AllContainers:  allContainers,
+					InitContainers: initContainers,
 				})
 			case "deployment", "deploy":
 				return client.GetDeploymentLogs(namespace, name, k8s.LogOptions{
-					Follow:        follow,
-					Previous:      previous,
-					TailLines:     tailLines,
-					Writer:        os.Stdout,
-					SinceTime:     startTime,
-					SinceSeconds:  sinceSeconds,
-					Container:     container,
-					AllContainers: allContainers,
+					Follow:         follow,
+					Previous:       previous,
+					TailLines:      tailLines,
+					Writer:         out,
+					WriterFor:      writerForContainer,
+					SinceTime:      startTime,
+					SinceSeconds:   sinceSeconds,
+					Container:      container,
+					AllContainers:  allContainers,
+					InitContainers: initContainers,
+					Timestamps:     timestamps,
 				})
 			default:
-				return fmt.Errorf("unsupported resource type: %s", resourceType)
+				return errs.Validationf("unsupported resource type: %s", resourceType)
 			}
 		},
 	}
@@ -124,7 +206,12 @@ Examples:
 	cmd.Flags().StringVarP(&container, "container", "c", "", "Print the logs of this container")
 	cmd.Flags().StringVar(&since, "since", "", "Show logs since duration (e.g. 1h, 5m, 30s)")
 	cmd.Flags().StringVar(&sinceTime, "since-time", "", "Show logs since specific time (RFC3339 format)")
-	cmd.Flags().BoolVarP(&allContainers, "all-containers", "a", false, "Get logs from all containers")
+	cmd.Flags().BoolVarP(&allContainers, "all-containers", "a", false, "Get logs from all containers, init containers included")
+	cmd.Flags().BoolVar(&initContainers, "init-containers", false, "Get logs from init containers")
+	cmd.Flags().BoolVar(&timestamps, "timestamps", false, "Prefix each line with its timestamp")
+	cmd.Flags().StringVar(&prefixTemplate, "prefix-template", defaultLogPrefix, "Go template for the prefix of each line when logs of several pods are shown; fields: .Pod, .PodShort, .Container, .Namespace (empty disables)")
+	cmd.Flags().StringSliceVar(&highlight, "highlight", nil, "Color matches of these regular expressions, comma separated or repeated")
+	cmd.Flags().BoolVar(&noInteractive, "no-interactive", false, "Never prompt for a container; fail if -c is needed")
 
 	return cmd
 }
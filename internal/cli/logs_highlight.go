@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"sort"
+
+	"k8stool/internal/k8s/errs"
+
+	"github.com/fatih/color"
+)
+
+// highlightColors are the colors of --highlight patterns, in the order the patterns are given
+var highlightColors = []*color.Color{
+	color.New(color.FgHiRed, color.Bold),
+	color.New(color.FgHiYellow, color.Bold),
+	color.New(color.FgHiMagenta, color.Bold),
+	color.New(color.FgHiCyan, color.Bold),
+	color.New(color.FgHiGreen, color.Bold),
+}
+
+// parseHighlights compiles the --highlight patterns
+func parseHighlights(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if p == "" {
+			continue
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, errs.Validationf("invalid highlight pattern %q: %v", p, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// highlightWriter colors the matches of patterns in the lines written through it. Every line is
+// still printed. Where matches of different patterns overlap, the one starting first wins.
+type highlightWriter struct {
+	out      io.Writer
+	patterns []*regexp.Regexp
+}
+
+func (w *highlightWriter) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	for _, line := range bytes.SplitAfter(p, []byte("\n")) {
+		w.highlight(&buf, line)
+	}
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// highlight writes line to buf with the matches colored
+func (w *highlightWriter) highlight(buf *bytes.Buffer, line []byte) {
+	type match struct{ start, end, pattern int }
+	var matches []match
+	for i, re := range w.patterns {
+		for _, loc := range re.FindAllIndex(line, -1) {
+			if loc[1] > loc[0] {
+				matches = append(matches, match{loc[0], loc[1], i})
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].start != matches[j].start {
+			return matches[i].start < matches[j].start
+		}
+		return matches[i].pattern < matches[j].pattern
+	})
+
+	pos := 0
+	for _, m := range matches {
+		if m.start < pos {
+			continue
+		}
+		buf.Write(line[pos:m.start])
+		buf.WriteString(highlightColors[m.pattern%len(highlightColors)].Sprint(string(line[m.start:m.end])))
+		pos = m.end
+	}
+	buf.Write(line[pos:])
+}
@@ -40,6 +40,7 @@ func TestLogsCommands_Integration(t *testing.T) {
 			wantErr: false,
 			validate: func(t *testing.T, output string) {
 				assert.Contains(t, output, "nginx")
+				assert.Contains(t, output, "[pod/nginx-default-deploy-")
 			},
 		},
 		{
@@ -98,6 +99,47 @@ func TestLogsCommands_Integration(t *testing.T) {
 				assert.Contains(t, output, "failed to get logs: no previous terminated state found for container")
 			},
 		},
+		{
+			name:    "get deployment logs with prefix template",
+			args:    []string{"deployment", "nginx-default-deploy", "--prefix-template", "{{.Container}}|"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.NotContains(t, output, "[pod/")
+				assert.Contains(t, output, "|")
+			},
+		},
+		{
+			name:    "get logs with invalid prefix template",
+			args:    []string{"deployment", "nginx-default-deploy", "--prefix-template", "{{.Unknown}}"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "invalid prefix template")
+			},
+		},
+		{
+			name:    "get logs with highlight",
+			args:    []string{"pod", "nginx-default", "--highlight", "nginx,worker"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "nginx")
+			},
+		},
+		{
+			name:    "get logs with invalid highlight pattern",
+			args:    []string{"pod", "nginx-default", "--highlight", "("},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "invalid highlight pattern \"(\"")
+			},
+		},
+		{
+			name:    "get init container logs of pod without init containers",
+			args:    []string{"pod", "nginx-default", "--init-containers"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "pod nginx-default has no init containers")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"hash/fnv"
+	"io"
+	"strings"
+	"text/template"
+
+	"k8stool/internal/k8s/errs"
+
+	"github.com/fatih/color"
+)
+
+// defaultLogPrefix is the prefix put before lines of logs combined from several pods
+const defaultLogPrefix = "[pod/{{.Pod}}/{{.Container}}]"
+
+// logPrefixData holds the fields available to --prefix-template
+type logPrefixData struct {
+	Namespace string
+	Pod       string
+	PodShort  string
+	Container string
+}
+
+// prefixColors are the colors prefixes are drawn in, picked by hashing the pod name
+var prefixColors = []color.Attribute{
+	color.FgCyan, color.FgGreen, color.FgYellow, color.FgBlue, color.FgMagenta,
+	color.FgHiCyan, color.FgHiGreen, color.FgHiYellow, color.FgHiBlue, color.FgHiMagenta,
+}
+
+// parseLogPrefix parses a --prefix-template value
+func parseLogPrefix(text string) (*template.Template, error) {
+	tmpl, err := template.New("prefix").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errs.Validationf("invalid prefix template: %v", err)
+	}
+	// Catch unknown fields before any logs are read
+	if err := tmpl.Execute(io.Discard, logPrefixData{}); err != nil {
+		return nil, errs.Validationf("invalid prefix template: %v", err)
+	}
+	return tmpl, nil
+}
+
+// logPrefix renders the prefix of a pod's container, colored by pod so lines of the same pod
+// share a color
+func logPrefix(tmpl *template.Template, namespace, pod, container string) string {
+	var buf bytes.Buffer
+	tmpl.Execute(&buf, logPrefixData{
+		Namespace: namespace,
+		Pod:       pod,
+		PodShort:  shortPodName(pod),
+		Container: container,
+	})
+	if buf.Len() == 0 {
+		return ""
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(pod))
+	return color.New(prefixColors[h.Sum32()%uint32(len(prefixColors))]).Sprint(buf.String()) + " "
+}
+
+// shortPodName returns the part of a generated pod name that tells replicas apart, such as
+// "x2kzq" for "nginx-7d9f8b6c4-x2kzq" or "0" for "web-0"
+func shortPodName(pod string) string {
+	if i := strings.LastIndex(pod, "-"); i >= 0 && i < len(pod)-1 {
+		return pod[i+1:]
+	}
+	return pod
+}
+
+// prefixWriter puts a prefix in front of every line written through it
+type prefixWriter struct {
+	out     io.Writer
+	prefix  []byte
+	midLine bool
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	for _, line := range bytes.SplitAfter(p, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if !w.midLine {
+			buf.Write(w.prefix)
+		}
+		buf.Write(line)
+		w.midLine = line[len(line)-1] != '\n'
+	}
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
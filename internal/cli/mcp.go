@@ -0,0 +1,410 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"syscall"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/events"
+	"k8stool/internal/k8s/logs"
+	"k8stool/internal/k8s/pods"
+	"k8stool/internal/mcp"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// maxMCPLogLines bounds how many log lines a single tool call may return
+const maxMCPLogLines = 5000
+
+// sensitiveEnvPattern matches environment variable names whose values are redacted
+var sensitiveEnvPattern = regexp.MustCompile(`(?i)(pass|secret|token|key|credential|auth)`)
+
+func getMCPCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Model Context Protocol server for AI clients",
+		Long:  "Expose k8stool capabilities to external AI clients (IDE assistants, chat clients) over the Model Context Protocol.",
+	}
+
+	cmd.AddCommand(getMCPServeCmd())
+
+	return cmd
+}
+
+func getMCPServeCmd() *cobra.Command {
+	var readOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run an MCP server on stdin/stdout",
+		Long: `Run a Model Context Protocol server that communicates over stdin/stdout.
+
+The server exposes tools to list pods, read logs, describe resources, list events,
+read metrics and scale deployments, using the credentials of the current kubeconfig context.
+
+Examples:
+  # Run the server (normally launched by the MCP client)
+  k8stool mcp serve
+
+  # Only expose read-only tools
+  k8stool mcp serve --read-only`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			server := mcp.NewServer(mcp.ServerInfo{
+				Name:    "k8stool",
+				Version: Version,
+			})
+
+			for _, tool := range mcpTools(client, readOnly) {
+				if err := server.Register(tool); err != nil {
+					return err
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return server.Serve(ctx, os.Stdin, os.Stdout)
+		},
+	}
+
+	cmd.Flags().BoolVar(&readOnly, "read-only", false, "Only expose tools that do not modify the cluster")
+
+	return cmd
+}
+
+// mcpTools returns the tools exposed by the MCP server
+func mcpTools(client *k8s.Client, readOnly bool) []mcp.Tool {
+	namespaceProp := mcp.Property{Type: "string", Description: "Namespace (defaults to the current context namespace)"}
+
+	tools := []mcp.Tool{
+		{
+			Name:        "list_pods",
+			Description: "List pods with their status, readiness, restarts and node",
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"namespace":      namespaceProp,
+					"all_namespaces": {Type: "boolean", Description: "List pods across all namespaces"},
+					"selector":       {Type: "string", Description: "Label selector to filter on"},
+				},
+			},
+			Handler: func(ctx context.Context, args mcp.Arguments) (string, error) {
+				namespace, err := mcpNamespace(client, args)
+				if err != nil {
+					return "", err
+				}
+				allNamespaces, err := args.Bool("all_namespaces", false)
+				if err != nil {
+					return "", err
+				}
+				selector, err := args.String("selector", "")
+				if err != nil {
+					return "", err
+				}
+
+				podList, err := client.PodService.List(namespace, allNamespaces, selector, "")
+				if err != nil {
+					return "", err
+				}
+				return mcpJSON(podList)
+			},
+		},
+		{
+			Name:        "get_logs",
+			Description: "Get recent logs of a pod container",
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"pod":       {Type: "string", Description: "Pod name"},
+					"namespace": namespaceProp,
+					"container": {Type: "string", Description: "Container name (defaults to the first container)"},
+					"tail":      {Type: "integer", Description: fmt.Sprintf("Number of lines to return (max %d)", maxMCPLogLines)},
+					"previous":  {Type: "boolean", Description: "Return logs of the previous container instance"},
+				},
+				Required: []string{"pod"},
+			},
+			Handler: func(ctx context.Context, args mcp.Arguments) (string, error) {
+				namespace, err := mcpNamespace(client, args)
+				if err != nil {
+					return "", err
+				}
+				pod, err := mcpName(args, "pod")
+				if err != nil {
+					return "", err
+				}
+				container, err := args.String("container", "")
+				if err != nil {
+					return "", err
+				}
+				tail, err := args.Int("tail", 100)
+				if err != nil {
+					return "", err
+				}
+				if tail <= 0 || tail > maxMCPLogLines {
+					return "", fmt.Errorf("tail must be between 1 and %d", maxMCPLogLines)
+				}
+				previous, err := args.Bool("previous", false)
+				if err != nil {
+					return "", err
+				}
+
+				result, err := client.LogService.GetLogs(ctx, namespace, pod, &logs.LogOptions{
+					Container: container,
+					Previous:  previous,
+					TailLines: &tail,
+				})
+				if err != nil {
+					return "", err
+				}
+				return result.Logs, nil
+			},
+		},
+		{
+			Name:        "describe",
+			Description: "Describe a pod or deployment in detail",
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"type":      {Type: "string", Description: "Resource type (pod or deployment)"},
+					"name":      {Type: "string", Description: "Resource name"},
+					"namespace": namespaceProp,
+				},
+				Required: []string{"type", "name"},
+			},
+			Handler: func(ctx context.Context, args mcp.Arguments) (string, error) {
+				namespace, err := mcpNamespace(client, args)
+				if err != nil {
+					return "", err
+				}
+				name, err := mcpName(args, "name")
+				if err != nil {
+					return "", err
+				}
+				resourceType, err := args.String("type", "")
+				if err != nil {
+					return "", err
+				}
+				if actualType, ok := resourceTypeAliases[strings.ToLower(resourceType)]; ok {
+					resourceType = actualType
+				}
+
+				switch resourceType {
+				case "pod":
+					details, err := client.PodService.Describe(namespace, name)
+					if err != nil {
+						return "", err
+					}
+					redactPodDetails(details)
+					return mcpJSON(details)
+				case "deployment":
+					details, err := client.DeploymentService.Describe(namespace, name)
+					if err != nil {
+						return "", err
+					}
+					for i := range details.Environment {
+						if sensitiveEnvPattern.MatchString(details.Environment[i].Name) && details.Environment[i].Value != "" {
+							details.Environment[i].Value = "<redacted>"
+						}
+					}
+					return mcpJSON(details)
+				default:
+					return "", fmt.Errorf("unsupported resource type: %s", resourceType)
+				}
+			},
+		},
+		{
+			Name:        "list_events",
+			Description: "List events, optionally filtered to warnings or a single resource",
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"namespace":     namespaceProp,
+					"warnings_only": {Type: "boolean", Description: "Only return warning events"},
+					"resource_kind": {Type: "string", Description: "Involved object kind, e.g. Pod"},
+					"resource_name": {Type: "string", Description: "Involved object name"},
+				},
+			},
+			Handler: func(ctx context.Context, args mcp.Arguments) (string, error) {
+				namespace, err := mcpNamespace(client, args)
+				if err != nil {
+					return "", err
+				}
+				warningsOnly, err := args.Bool("warnings_only", false)
+				if err != nil {
+					return "", err
+				}
+				kind, err := args.String("resource_kind", "")
+				if err != nil {
+					return "", err
+				}
+				name, err := args.String("resource_name", "")
+				if err != nil {
+					return "", err
+				}
+
+				filter := &events.EventFilter{SortBy: events.SortByTime, Limit: 200}
+				if warningsOnly {
+					filter.Types = []events.EventType{events.Warning}
+				}
+				if kind != "" {
+					filter.ResourceKinds = []string{kind}
+				}
+				if name != "" {
+					filter.ResourceNames = []string{name}
+				}
+
+				eventList, err := client.EventService.List(ctx, namespace, filter)
+				if err != nil {
+					return "", err
+				}
+				return mcpJSON(eventList.Items)
+			},
+		},
+		{
+			Name:        "get_metrics",
+			Description: "Get CPU and memory usage for pods or nodes (requires metrics-server)",
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"type":      {Type: "string", Description: "pods or nodes"},
+					"namespace": namespaceProp,
+				},
+				Required: []string{"type"},
+			},
+			Handler: func(ctx context.Context, args mcp.Arguments) (string, error) {
+				resourceType, err := args.String("type", "")
+				if err != nil {
+					return "", err
+				}
+
+				switch resourceType {
+				case "pods", "pod", "po":
+					namespace, err := mcpNamespace(client, args)
+					if err != nil {
+						return "", err
+					}
+					podMetrics, err := client.MetricsService.ListPodMetrics(namespace)
+					if err != nil {
+						return "", err
+					}
+					return mcpJSON(podMetrics)
+				case "nodes", "node", "no":
+					nodeMetrics, err := client.MetricsService.ListNodeMetrics()
+					if err != nil {
+						return "", err
+					}
+					return mcpJSON(nodeMetrics)
+				default:
+					return "", fmt.Errorf("unsupported metrics type: %s", resourceType)
+				}
+			},
+		},
+	}
+
+	if !readOnly {
+		tools = append(tools, mcp.Tool{
+			Name:        "scale_deployment",
+			Description: "Scale a deployment to the given number of replicas",
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"name":      {Type: "string", Description: "Deployment name"},
+					"namespace": namespaceProp,
+					"replicas":  {Type: "integer", Description: "Desired replica count"},
+				},
+				Required: []string{"name", "replicas"},
+			},
+			Handler: func(ctx context.Context, args mcp.Arguments) (string, error) {
+				namespace, err := mcpNamespace(client, args)
+				if err != nil {
+					return "", err
+				}
+				name, err := mcpName(args, "name")
+				if err != nil {
+					return "", err
+				}
+				replicas, err := args.Int("replicas", -1)
+				if err != nil {
+					return "", err
+				}
+				if replicas < 0 || replicas > 1000 {
+					return "", fmt.Errorf("replicas must be between 0 and 1000")
+				}
+				// Nobody can type the name of a protected context over MCP, so it is not changed
+				protected, err := protectedContext()
+				if err != nil {
+					return "", err
+				}
+				if protected != "" {
+					return "", fmt.Errorf("context %q is protected: scale the deployment with k8stool from a terminal", protected)
+				}
+
+				if err := client.DeploymentService.Scale(namespace, name, int32(replicas)); err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("deployment %s/%s scaled to %d replicas", namespace, name, replicas), nil
+			},
+		})
+	}
+
+	return tools
+}
+
+// mcpNamespace returns the validated namespace argument, defaulting to the current namespace
+func mcpNamespace(client *k8s.Client, args mcp.Arguments) (string, error) {
+	namespace, err := args.String("namespace", "")
+	if err != nil {
+		return "", err
+	}
+	if namespace == "" {
+		return client.GetCurrentNamespace(), nil
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, "; "))
+	}
+	return namespace, nil
+}
+
+// mcpName returns a validated resource name argument
+func mcpName(args mcp.Arguments, key string) (string, error) {
+	name, err := args.String(key, "")
+	if err != nil {
+		return "", err
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid %s %q: %s", key, name, strings.Join(errs, "; "))
+	}
+	return name, nil
+}
+
+// redactPodDetails hides environment values that look like credentials
+func redactPodDetails(details *pods.PodDetails) {
+	for i := range details.Containers {
+		for j := range details.Containers[i].Env {
+			env := &details.Containers[i].Env[j]
+			if sensitiveEnvPattern.MatchString(env.Name) && env.Value != "" {
+				env.Value = "<redacted>"
+			}
+		}
+	}
+}
+
+func mcpJSON(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode result: %w", err)
+	}
+	return string(data), nil
+}
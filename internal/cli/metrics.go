@@ -3,10 +3,12 @@ package cli
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
+	"sort"
 
 	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
 	"k8stool/internal/k8s/metrics"
+	"k8stool/pkg/utils"
 
 	"github.com/spf13/cobra"
 )
@@ -19,10 +21,27 @@ func getMetricsCmd() *cobra.Command {
 	var reverse bool
 
 	cmd := &cobra.Command{
-		Use:     "metrics (pods|nodes|<pod-name>)",
+		Use:     "metrics (pods|containers|nodes|<pod-name>)",
 		Aliases: []string{"top"},
-		Short:   "Show metrics for pods or nodes",
-		Args:    cobra.ExactArgs(1),
+		Short:   "Show metrics for pods, containers or nodes",
+		Long: `Show CPU and memory usage from the metrics server for pods, containers or nodes.
+
+The containers view lists every container with its usage as a share of its request and limit,
+since per-pod totals hide the one sidecar that uses all the CPU.
+
+Examples:
+  # Show pod metrics in the current namespace
+  k8stool top pods
+
+  # Show the containers using the most CPU across all namespaces
+  k8stool top containers -A --sort cpu
+
+  # Show node metrics
+  k8stool top nodes
+
+  # Show the request rate, errors and latency of a deployment from Prometheus
+  k8stool metrics http deploy/web`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := k8s.NewClient()
 			if err != nil {
@@ -60,6 +79,18 @@ func getMetricsCmd() *cobra.Command {
 
 				return printPodMetricsList(podMetrics)
 
+			case "containers", "container":
+				podMetrics, err := client.MetricsService.ListPodMetrics(namespace)
+				if err != nil {
+					return err
+				}
+
+				rows, err := containerMetrics(podMetrics, sortBy, reverse)
+				if err != nil {
+					return err
+				}
+				return printContainerMetrics(rows)
+
 			case "nodes", "node", "no":
 				// List all node metrics
 				nodeMetrics, err := client.MetricsService.ListNodeMetrics()
@@ -86,11 +117,13 @@ func getMetricsCmd() *cobra.Command {
 	cmd.Flags().StringVar(&sortBy, "sort", "", "Sort by (name, cpu, memory, age)")
 	cmd.Flags().BoolVar(&reverse, "reverse", false, "Reverse sort order")
 
+	cmd.AddCommand(getMetricsHTTPCmd())
+
 	return cmd
 }
 
 func printPodMetrics(metrics *metrics.PodMetrics) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := newTable(os.Stdout)
 	defer w.Flush()
 
 	fmt.Fprintln(w, "NAMESPACE\tPOD\tCPU(cores)\tCPU%\tMEMORY(bytes)\tMEMORY%")
@@ -106,7 +139,7 @@ func printPodMetrics(metrics *metrics.PodMetrics) error {
 }
 
 func printPodMetricsList(metrics []metrics.PodMetrics) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := newTable(os.Stdout)
 	defer w.Flush()
 
 	fmt.Fprintln(w, "NAMESPACE\tPOD\tCPU(cores)\tCPU%\tMEMORY(bytes)\tMEMORY%")
@@ -123,8 +156,91 @@ func printPodMetricsList(metrics []metrics.PodMetrics) error {
 	return nil
 }
 
+// containerMetric is a single container of a pod metrics sample
+type containerMetric struct {
+	namespace string
+	pod       string
+	container string
+	metrics.ResourceMetrics
+}
+
+// containerMetrics flattens pod metrics to containers, sorted by name, cpu or memory
+func containerMetrics(podMetrics []metrics.PodMetrics, sortBy string, reverse bool) ([]containerMetric, error) {
+	var rows []containerMetric
+	for _, pm := range podMetrics {
+		for name, m := range pm.Containers {
+			rows = append(rows, containerMetric{namespace: pm.Namespace, pod: pm.Name, container: name, ResourceMetrics: m})
+		}
+	}
+
+	var less func(a, b containerMetric) bool
+	switch metrics.MetricsSortOption(sortBy) {
+	case "", metrics.SortByName:
+		less = func(a, b containerMetric) bool {
+			if a.namespace+"/"+a.pod != b.namespace+"/"+b.pod {
+				return a.namespace+"/"+a.pod < b.namespace+"/"+b.pod
+			}
+			return a.container < b.container
+		}
+	case metrics.SortByCPU:
+		less = func(a, b containerMetric) bool { return a.CPU.UsageNanoCores > b.CPU.UsageNanoCores }
+	case metrics.SortByMemory:
+		less = func(a, b containerMetric) bool { return a.Memory.UsageBytes > b.Memory.UsageBytes }
+	default:
+		return nil, errs.Validationf("invalid sort %q for containers: must be name, cpu or memory", sortBy)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if reverse {
+			return less(rows[j], rows[i])
+		}
+		return less(rows[i], rows[j])
+	})
+
+	return rows, nil
+}
+
+func printContainerMetrics(rows []containerMetric) error {
+	w := newTable(os.Stdout)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tCPU\tCPU/REQ\tCPU/LIM\tMEMORY\tMEM/REQ\tMEM/LIM")
+	for _, r := range rows {
+		cpuMilli := r.CPU.UsageNanoCores / 1e6
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.namespace,
+			r.pod,
+			r.container,
+			utils.FormatMilliCPU(cpuMilli),
+			utilization(cpuMilli, r.CPU.RequestMilliCores),
+			utilization(cpuMilli, r.CPU.LimitMilliCores),
+			utils.FormatBytes(r.Memory.UsageBytes),
+			utilization(r.Memory.UsageBytes, r.Memory.RequestBytes),
+			utilization(r.Memory.UsageBytes, r.Memory.LimitBytes),
+		)
+	}
+
+	return nil
+}
+
+// utilization renders usage as a share of a request or limit, colored when it runs high
+func utilization(usage, bound int64) string {
+	if bound == 0 {
+		return utils.Yellow("<none>")
+	}
+	p := float64(usage) * 100 / float64(bound)
+	cell := fmt.Sprintf("%.0f%%", p)
+	switch {
+	case p >= 90:
+		return utils.Red(cell)
+	case p >= 75:
+		return utils.Yellow(cell)
+	default:
+		return utils.Green(cell)
+	}
+}
+
 func printNodeMetricsList(metrics []metrics.NodeMetrics) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := newTable(os.Stdout)
 	defer w.Flush()
 
 	fmt.Fprintln(w, "NODE\tCPU(cores)\tCPU%\tMEMORY(bytes)\tMEMORY%\tPODS")
@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"text/tabwriter"
 
 	k8s "k8stool/internal/k8s/client"
 	"k8stool/internal/k8s/context"
+	"k8stool/internal/k8s/errs"
 	"k8stool/pkg/utils"
 
 	"github.com/manifoldco/promptui"
@@ -25,9 +25,15 @@ func getNamespaceCmd() *cobra.Command {
 		Args:    cobra.MaximumNArgs(1),
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Skip cluster connection for namespace commands
-			return nil
+			return prepareCommand(cmd)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if interactive || len(args) > 0 {
+				if err := checkNamespaceSwitch(); err != nil {
+					return err
+				}
+			}
+
 			// Initialize context service without cluster access
 			contextService, err := context.NewContextOnlyService()
 			if err != nil {
@@ -128,6 +134,7 @@ func getNamespaceCmd() *cobra.Command {
 	cmd.AddCommand(getCurrentNamespaceCmd())
 	cmd.AddCommand(listNamespacesCmd())
 	cmd.AddCommand(switchNamespaceCmd())
+	cmd.AddCommand(getNamespaceBootstrapCmd())
 
 	return cmd
 }
@@ -171,7 +178,7 @@ func listNamespacesCmd() *cobra.Command {
 				return fmt.Errorf("failed to list namespaces: %w", err)
 			}
 
-			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			w := newTable(os.Stdout)
 			fmt.Fprintln(w, "NAME\tSTATUS")
 			for _, ns := range namespaces {
 				fmt.Fprintf(w, "%s\t%s\n",
@@ -192,6 +199,10 @@ func switchNamespaceCmd() *cobra.Command {
 		Short: "Switch to a different namespace",
 		Long:  "Switch to a different Kubernetes namespace, either by name or interactively.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkNamespaceSwitch(); err != nil {
+				return err
+			}
+
 			contextService, err := context.NewContextOnlyService()
 			if err != nil {
 				return fmt.Errorf("failed to initialize context service: %w", err)
@@ -270,3 +281,12 @@ func switchNamespaceCmd() *cobra.Command {
 
 	return cmd
 }
+
+// checkNamespaceSwitch rejects switching namespaces with --from-snapshot, which would pick the
+// namespace of the kubeconfig context from those of the snapshot
+func checkNamespaceSwitch() error {
+	if fromSnapshot != "" {
+		return errs.Validationf("cannot switch namespaces with --from-snapshot")
+	}
+	return nil
+}
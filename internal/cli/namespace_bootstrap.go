@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	ns "k8stool/internal/k8s/namespace"
+	"k8stool/internal/k8s/resources"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+func getNamespaceBootstrapCmd() *cobra.Command {
+	var templateName string
+	var labels map[string]string
+	var values map[string]string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "bootstrap NAME --template TEMPLATE",
+		Short: "Create a namespace with its standard objects from a template",
+		Long: `Create a namespace together with the objects every namespace of its kind should have, such
+as a ResourceQuota, a LimitRange, a default NetworkPolicy and the RoleBindings of its team,
+from a template directory.
+
+A template is a directory of .yaml and .yml manifests, rendered in the order of their names
+as Go templates with:
+  {{ .Name }}            the name of the namespace
+  {{ .Labels.KEY }}      a label given with --label
+  {{ .Values.KEY }}      a value given with --set
+
+Using a label or value that was not given is an error; {{ index .Values "KEY" }} gives an
+empty string instead. Objects without a namespace are put in the new one. The template may
+contain the Namespace itself, to set annotations for instance; --label is added to it.
+
+A template given by name is looked up in templates/ under the user configuration directory
+(~/.config/k8stool/templates on Linux); one given with a / is a path. The namespace and the
+objects are created or updated with server-side apply, so bootstrapping an existing namespace
+again brings it in line with the template.
+
+Examples:
+  # Create the payments namespace from ~/.config/k8stool/templates/team-default
+  k8stool ns bootstrap payments --template team-default --label team=payments --set group=payments-devs
+
+  # Show the objects a template renders without creating them
+  k8stool ns bootstrap payments --template ./templates/team-default --set group=payments-devs --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if problems := validation.IsDNS1123Label(name); len(problems) > 0 {
+				return errs.Validationf("invalid namespace name %q: %s", name, strings.Join(problems, "; "))
+			}
+			for key, value := range labels {
+				if err := validateMetadata(resources.Labels, key, value); err != nil {
+					return err
+				}
+			}
+
+			dir, err := ns.ResolveTemplate(templateName)
+			if err != nil {
+				return err
+			}
+			objs, err := ns.Render(dir, ns.TemplateData{Name: name, Labels: labels, Values: values})
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				return printManifests(objs)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			results, err := client.Apply(context.Background(), objs, resources.ApplyOptions{Namespace: name})
+			printResults(results, false)
+			if err != nil {
+				return err
+			}
+			return resultsError(results)
+		},
+	}
+
+	cmd.Flags().StringVar(&templateName, "template", "", "Name or path of the template directory")
+	cmd.Flags().StringToStringVar(&labels, "label", nil, "Label of the namespace as KEY=VALUE (repeatable)")
+	cmd.Flags().StringToStringVar(&values, "set", nil, "Value of the template as KEY=VALUE (repeatable)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the rendered objects instead of creating them")
+	cmd.MarkFlagRequired("template")
+	markMutating(cmd)
+
+	return cmd
+}
+
+// printManifests prints objects as a multi-document YAML stream
+func printManifests(objs []*unstructured.Unstructured) error {
+	for i, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to encode object: %w", err)
+		}
+		if i > 0 {
+			fmt.Println("---")
+		}
+		if _, err := os.Stdout.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,6 +19,13 @@ func TestNamespaceCommands_Integration(t *testing.T) {
 	oldStdout := os.Stdout
 	defer func() { os.Stdout = oldStdout }()
 
+	// A namespace template with a quota parameterized by --set
+	templateDir := t.TempDir()
+	quota := "apiVersion: v1\nkind: ResourceQuota\nmetadata:\n  name: default\nspec:\n  hard:\n    pods: \"{{ .Values.pods }}\"\n"
+	if err := os.WriteFile(filepath.Join(templateDir, "quota.yaml"), []byte(quota), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
 	tests := []struct {
 		name     string
 		args     []string
@@ -76,6 +84,32 @@ func TestNamespaceCommands_Integration(t *testing.T) {
 				assert.Contains(t, output, "Error: namespaces \"nonexistent-namespace\" not found")
 			},
 		},
+		{
+			name:    "render a namespace template",
+			args:    []string{"bootstrap", "bootstrap-test", "--template", templateDir, "--label", "team=test", "--set", "pods=10", "--dry-run"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "kind: Namespace")
+				assert.Contains(t, output, "team: test")
+				assert.Contains(t, output, `pods: "10"`)
+			},
+		},
+		{
+			name:    "render a template without its values",
+			args:    []string{"bootstrap", "bootstrap-test", "--template", templateDir, "--dry-run"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `map has no entry for key "pods"`)
+			},
+		},
+		{
+			name:    "bootstrap from a missing template",
+			args:    []string{"bootstrap", "bootstrap-test", "--template", "nonexistent-template"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `template "nonexistent-template" not found`)
+			},
+		},
 	}
 
 	for _, tt := range tests {
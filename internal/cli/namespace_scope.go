@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	k8s "k8stool/internal/k8s/client"
+)
+
+// namespaceScope is the set of namespaces a list command queries
+type namespaceScope struct {
+	// allNamespaces queries every namespace at once instead of namespaces one by one
+	allNamespaces bool
+	namespaces    []string
+	exclude       map[string]bool
+}
+
+// resolveNamespaceScope turns the -n, -A and --exclude-namespace flags into a scope.
+// -n accepts a comma-separated list, and --exclude-namespace without -n implies all namespaces.
+func resolveNamespaceScope(client *k8s.Client, namespace string, allNamespaces bool, exclude []string) namespaceScope {
+	scope := namespaceScope{exclude: make(map[string]bool, len(exclude))}
+	for _, ns := range exclude {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			scope.exclude[ns] = true
+		}
+	}
+
+	if allNamespaces || (namespace == "" && len(scope.exclude) > 0) {
+		scope.allNamespaces = true
+		return scope
+	}
+
+	if namespace == "" {
+		namespace = client.GetCurrentNamespace()
+	}
+
+	seen := make(map[string]bool)
+	for _, ns := range strings.Split(namespace, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "" || seen[ns] || scope.exclude[ns] {
+			continue
+		}
+		seen[ns] = true
+		scope.namespaces = append(scope.namespaces, ns)
+	}
+	return scope
+}
+
+// all reports whether the scope covers every namespace
+func (s namespaceScope) all() bool {
+	return s.allNamespaces
+}
+
+// multiple reports whether results may come from more than one namespace
+func (s namespaceScope) multiple() bool {
+	return s.allNamespaces || len(s.namespaces) > 1
+}
+
+// includes reports whether a namespace is part of the scope
+func (s namespaceScope) includes(namespace string) bool {
+	if s.allNamespaces {
+		return !s.exclude[namespace]
+	}
+	for _, ns := range s.namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// listInScope runs list once per namespace of the scope concurrently and merges the results in
+// namespace order. For all namespaces list is called once with an empty namespace, and results
+// from excluded namespaces are dropped.
+func listInScope[T any](scope namespaceScope, namespaceOf func(T) string, list func(namespace string) ([]T, error)) ([]T, error) {
+	if scope.all() {
+		items, err := list("")
+		if err != nil {
+			return nil, err
+		}
+		var kept []T
+		for _, item := range items {
+			if !scope.exclude[namespaceOf(item)] {
+				kept = append(kept, item)
+			}
+		}
+		return kept, nil
+	}
+
+	results := make([][]T, len(scope.namespaces))
+	failures := make([]error, len(scope.namespaces))
+	var wg sync.WaitGroup
+	for i, ns := range scope.namespaces {
+		wg.Add(1)
+		go func(i int, ns string) {
+			defer wg.Done()
+			results[i], failures[i] = list(ns)
+		}(i, ns)
+	}
+	wg.Wait()
+
+	var merged []T
+	for i, err := range failures {
+		if err != nil {
+			if len(scope.namespaces) == 1 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("namespace %s: %w", scope.namespaces[i], err)
+		}
+		merged = append(merged, results[i]...)
+	}
+	return merged, nil
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/netcheck"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getNetTestCmd() *cobra.Command {
+	var opts netcheck.ConnectivityOptions
+
+	cmd := &cobra.Command{
+		Use:   "nettest",
+		Short: "Test network connectivity from a pod",
+		Long: `Test network connectivity from a pod to a service, pod or host and report where the path breaks.
+
+The service, its endpoints and the network policies on both ends are checked through the API.
+DNS resolution and a TCP connect are then run inside the source pod with exec. When the source
+image lacks a shell or network tools, --ephemeral runs the checks from an ephemeral debug container.
+
+Targets:
+  svc/NAME[.NAMESPACE]:PORT   a service port
+  pod/NAME:PORT               a pod in the source namespace
+  HOST:PORT                   any host name or address
+
+Examples:
+  # Test a service in the same namespace
+  k8stool nettest --from pod/frontend --to svc/backend:80
+
+  # Test a service in another namespace from a debug container
+  k8stool nettest --from frontend --to svc/postgres.db:5432 --ephemeral
+
+  # Test an external host
+  k8stool nettest --from frontend --to example.com:443`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if opts.Namespace == "" {
+				opts.Namespace = client.GetCurrentNamespace()
+			}
+
+			// Only --ephemeral changes the cluster, adding a container to the source pod, so
+			// the command is not marked as mutating
+			if opts.Ephemeral {
+				if err := confirmProtectedContext(cmd.CommandPath()); err != nil {
+					return err
+				}
+			}
+
+			report, err := client.TestConnectivity(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+
+			printConnectivityReport(report)
+			if report.BrokenAt != "" {
+				return fmt.Errorf("connection from %s to %s failed at %s", report.Source, report.Target, report.BrokenAt)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "Namespace of the source pod")
+	cmd.Flags().StringVar(&opts.From, "from", "", "Source pod (NAME or pod/NAME)")
+	cmd.Flags().StringVar(&opts.To, "to", "", "Target (svc/NAME:PORT, pod/NAME:PORT or HOST:PORT)")
+	cmd.Flags().StringVarP(&opts.Container, "container", "c", "", "Source container (defaults to the first container)")
+	cmd.Flags().BoolVar(&opts.Ephemeral, "ephemeral", false, "Run the checks from an ephemeral debug container")
+	cmd.Flags().StringVar(&opts.Image, "image", netcheck.DefaultImage, "Image of the ephemeral debug container")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 5*time.Second, "Timeout of each in-pod check")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func printConnectivityReport(report *netcheck.ConnectivityReport) {
+	fmt.Printf("From %s to %s\n\n", utils.Bold(report.Source), utils.Bold(report.Target))
+
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "STEP\tSTATUS\tDETAILS")
+	for _, step := range report.Steps {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", step.Name, colorizeStepStatus(step.Status), step.Message)
+	}
+	w.Flush()
+
+	if report.BrokenAt == "" {
+		fmt.Printf("\n%s\n", utils.Green("Connection succeeded"))
+	} else {
+		fmt.Printf("\n%s %s\n", utils.Red("Path breaks at:"), report.BrokenAt)
+	}
+}
+
+func colorizeStepStatus(status netcheck.StepStatus) string {
+	switch status {
+	case netcheck.Pass:
+		return utils.StatusOK(string(status))
+	case netcheck.Warn, netcheck.Skip:
+		return utils.StatusWarn(string(status))
+	case netcheck.Fail:
+		return utils.StatusFail(string(status))
+	default:
+		return string(status)
+	}
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetTestCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "connect to a pod",
+			args:    []string{"--from", "nginx-default", "--to", "pod/nginx-default:80", "-n", "default"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "From default/nginx-default to pod/nginx-default:80")
+				assert.Contains(t, output, "STEP")
+				assert.Regexp(t, `pod\s+PASS\s+pod nginx-default has IP`, output)
+				assert.Regexp(t, `network-policy\s+PASS\s+no network policy restricts this path`, output)
+				assert.Regexp(t, `tcp\s+PASS\s+connected to \S+:80`, output)
+				assert.NotContains(t, output, "dns")
+				assert.Contains(t, output, "Connection succeeded")
+			},
+		},
+		{
+			name:    "connect to a non-existent service",
+			args:    []string{"--from", "pod/nginx-default", "--to", "svc/k8stool-missing:80", "-n", "default"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Regexp(t, `service\s+FAIL\s+`, output)
+				assert.Contains(t, output, "Path breaks at: service")
+				assert.Contains(t, output, "connection from default/nginx-default to svc/k8stool-missing:80 failed at service")
+			},
+		},
+		{
+			name:    "unsupported target type",
+			args:    []string{"--from", "nginx-default", "--to", "deploy/nginx-default-deploy:80", "-n", "default"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `unsupported target type "deploy"`)
+			},
+		},
+		{
+			name:    "non-existent source pod",
+			args:    []string{"--from", "non-existent-pod", "--to", "example.com:443", "-n", "default"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "failed to get source pod")
+			},
+		},
+		{
+			name:    "without target",
+			args:    []string{"--from", "nginx-default"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `required flag(s) "to" not set`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getNetTestCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
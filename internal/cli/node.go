@@ -0,0 +1,431 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/nodes"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getNodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "node",
+		Aliases: []string{"nodes", "no"},
+		Short:   "Manage cluster nodes",
+		Long:    "List nodes with their cloud instance metadata, cordon, uncordon and drain cluster nodes for maintenance, report how much of each node is allocated, read node logs and open a shell on a node.",
+	}
+
+	cmd.AddCommand(getNodeListCmd())
+	cmd.AddCommand(getNodeCordonCmd())
+	cmd.AddCommand(getNodeUncordonCmd())
+	cmd.AddCommand(getNodeDrainCmd())
+	cmd.AddCommand(getNodeAllocationCmd())
+	cmd.AddCommand(getNodeShellCmd())
+	cmd.AddCommand(getNodeLogsCmd())
+
+	return cmd
+}
+
+func getNodeCordonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cordon NAME",
+		Short: "Mark a node as unschedulable",
+		Long: `Mark a node as unschedulable. Pods already running on the node are not affected.
+
+Examples:
+  # Stop new pods from being scheduled on a node
+  k8stool node cordon worker-1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if err := client.CordonNode(context.Background(), args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Node %s cordoned\n", utils.Bold(args[0]))
+			return nil
+		},
+	}
+	markMutating(cmd)
+
+	return cmd
+}
+
+func getNodeUncordonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uncordon NAME",
+		Short: "Mark a node as schedulable",
+		Long: `Mark a node as schedulable again after maintenance.
+
+Examples:
+  # Allow pods to be scheduled on a node again
+  k8stool node uncordon worker-1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if err := client.UncordonNode(context.Background(), args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Node %s uncordoned\n", utils.Bold(args[0]))
+			return nil
+		},
+	}
+	markMutating(cmd)
+
+	return cmd
+}
+
+func getNodeDrainCmd() *cobra.Command {
+	var opts nodes.DrainOptions
+	var dryRun bool
+	var simulate bool
+
+	cmd := &cobra.Command{
+		Use:   "drain NAME",
+		Short: "Cordon a node and evict its pods",
+		Long: `Cordon a node and evict its pods through the Eviction API.
+
+Evictions respect PodDisruptionBudgets: an eviction refused by a budget is retried until
+the timeout expires. Mirror pods are always skipped. DaemonSet pods, pods without a
+controller and pods using emptyDir volumes stop the drain unless the matching flag is set.
+
+With --simulate nothing is changed. Every disruption budget covering the pods to evict is
+compared with the disruptions it allows, and every workload with pods on the node is checked
+for ready pods left on other nodes. A budget allowing no disruptions would hang the drain, and
+a workload whose ready pods all run on the node, with no budget pacing their eviction, would
+go down; either makes the command exit non-zero.
+
+Examples:
+  # Show which pods would be evicted
+  k8stool node drain worker-1 --ignore-daemonsets --dry-run
+
+  # Check whether a drain would break availability before attempting it
+  k8stool drain --simulate worker-1 --ignore-daemonsets
+
+  # Drain a node, skipping DaemonSet pods
+  k8stool node drain worker-1 --ignore-daemonsets
+
+  # Drain a node, discarding emptyDir data and waiting at most 10 minutes
+  k8stool node drain worker-1 --ignore-daemonsets --delete-emptydir-data --timeout 10m`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			if simulate {
+				sim, err := client.SimulateDrain(ctx, args[0], opts)
+				if err != nil {
+					return err
+				}
+				printDrainPlan(sim.Plan)
+				printDrainSimulation(sim)
+				if violations := sim.Violations(); violations > 0 {
+					return fmt.Errorf("draining node %s would violate availability of %d budgets or workloads", args[0], violations)
+				}
+				return nil
+			}
+
+			if dryRun {
+				plan, err := client.PlanDrain(ctx, args[0], opts)
+				if err != nil {
+					return err
+				}
+				printDrainPlan(plan)
+				return nil
+			}
+
+			fmt.Printf("Draining node %s\n", utils.Bold(args[0]))
+			opts.OnProgress = printDrainEvent
+			plan, err := client.DrainNode(ctx, args[0], opts)
+			if plan != nil && len(plan.Blocking) > 0 {
+				printDrainPlan(plan)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Node %s drained\n", utils.Bold(args[0]))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.IgnoreDaemonSets, "ignore-daemonsets", false, "Skip DaemonSet-managed pods")
+	cmd.Flags().BoolVar(&opts.DeleteEmptyDirData, "delete-emptydir-data", false, "Evict pods using emptyDir volumes, discarding their data")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Evict pods not managed by a controller")
+	cmd.Flags().Int64Var(&opts.GracePeriodSeconds, "grace-period", -1, "Seconds given to each pod to terminate; -1 uses the pod's own")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 5*time.Minute, "Maximum time to wait for the drain; 0 waits forever")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list the pods that would be evicted")
+	cmd.Flags().BoolVar(&simulate, "simulate", false, "Report whether evicting the pods would violate disruption budgets or workload availability, without draining")
+	markMutating(cmd)
+
+	return cmd
+}
+
+func printDrainPlan(plan *nodes.DrainPlan) {
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTROLLER\tACTION\tDETAILS")
+	for _, p := range plan.Evict {
+		details := ""
+		if len(p.PDBs) > 0 {
+			details = fmt.Sprintf("pdb: %v", p.PDBs)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.Namespace, p.Name, controllerOrNone(p.Controller), utils.Green("evict"), details)
+	}
+	for _, p := range plan.Skip {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.Pod.Namespace, p.Pod.Name, controllerOrNone(p.Pod.Controller), utils.Yellow("skip"), p.Reason)
+	}
+	for _, p := range plan.Blocking {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.Pod.Namespace, p.Pod.Name, controllerOrNone(p.Pod.Controller), utils.Red("blocked"), p.Reason)
+	}
+	w.Flush()
+
+	for _, warning := range plan.Warnings {
+		fmt.Printf("%s %s\n", utils.Yellow("Warning:"), warning)
+	}
+	fmt.Printf("\n%d to evict, %d skipped, %d blocking\n", len(plan.Evict), len(plan.Skip), len(plan.Blocking))
+}
+
+func printDrainSimulation(sim *nodes.DrainSimulation) {
+	if len(sim.Budgets) > 0 {
+		fmt.Printf("\n%s\n", utils.Bold("Disruption budgets:"))
+		w := newTable(os.Stdout)
+		fmt.Fprintln(w, "NAMESPACE\tPDB\tPOLICY\tHEALTHY\tALLOWED\tEVICTED\tVERDICT\tDETAILS")
+		for _, b := range sim.Budgets {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%d\t%d\t%s\t%s\n", b.Namespace, b.Name, orDash(b.Policy),
+				b.CurrentHealthy, b.DesiredHealthy, b.DisruptionsAllowed, b.Evicted, colorizeVerdict(b.Verdict), b.Message)
+		}
+		w.Flush()
+	}
+
+	if len(sim.Workloads) > 0 {
+		fmt.Printf("\n%s\n", utils.Bold("Workloads:"))
+		w := newTable(os.Stdout)
+		fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tREADY\tEVICTED\tREADY AFTER\tVERDICT\tDETAILS")
+		for _, wl := range sim.Workloads {
+			fmt.Fprintf(w, "%s\t%s/%s\t%d\t%d\t%d\t%s\t%s\n", wl.Namespace, wl.Kind, wl.Name,
+				wl.Ready, wl.Evicted, wl.ReadyAfter, colorizeVerdict(wl.Verdict), wl.Message)
+		}
+		w.Flush()
+	}
+
+	if violations := sim.Violations(); violations > 0 {
+		fmt.Printf("\n%s draining node %s would violate availability of %d budgets or workloads\n",
+			utils.Red("Unsafe:"), sim.Plan.Node, violations)
+		return
+	}
+	if len(sim.Plan.Blocking) > 0 {
+		fmt.Printf("\n%s %d pods stop the drain before any eviction unless the flags shown are set\n",
+			utils.Yellow("Blocked:"), len(sim.Plan.Blocking))
+		return
+	}
+	fmt.Printf("\n%s draining node %s keeps every workload available\n", utils.Green("Safe:"), sim.Plan.Node)
+}
+
+// colorizeVerdict colors safe verdicts green, paced ones yellow and violations red
+func colorizeVerdict(v nodes.Verdict) string {
+	switch v {
+	case nodes.VerdictOK:
+		return utils.StatusOK(string(v))
+	case nodes.VerdictWaits:
+		return utils.StatusWarn(string(v))
+	}
+	return utils.StatusFail(string(v))
+}
+
+func printDrainEvent(e nodes.DrainEvent) {
+	pod := e.Pod.Namespace + "/" + e.Pod.Name
+	switch e.Status {
+	case nodes.Evicting:
+		fmt.Printf("  evicting pod %s\n", pod)
+	case nodes.BlockedByPDB:
+		fmt.Printf("  %s pod %s: %s, retrying\n", utils.Yellow("waiting"), pod, e.Message)
+	case nodes.Evicted:
+		fmt.Printf("  %s pod %s\n", utils.Green("evicted"), pod)
+	case nodes.Failed:
+		fmt.Printf("  %s pod %s: %s\n", utils.Red("failed"), pod, e.Message)
+	}
+}
+
+func controllerOrNone(controller string) string {
+	if controller == "" {
+		return "<none>"
+	}
+	return controller
+}
+
+func getNodeAllocationCmd() *cobra.Command {
+	var selector string
+	var top int
+	var sortBy string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:     "allocation [NAME]",
+		Aliases: []string{"alloc"},
+		Short:   "Show requested versus allocatable resources per node",
+		Long: `Show how much of each node's allocatable CPU and memory is claimed by the requests and
+limits of the pods scheduled on it, the pod count against the node's pod limit, and the pods
+claiming the largest share.
+
+This is what the scheduler sees: a node whose requests are close to allocatable accepts no
+more pods even if actual usage is low. Limits above 100% mean the node is overcommitted.
+
+Examples:
+  # Show all nodes
+  k8stool node allocation
+
+  # Show the fullest nodes by CPU requests first
+  k8stool node allocation --sort cpu
+
+  # Show one node with its ten largest pods
+  k8stool node allocation worker-1 --top 10`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			opts := k8s.NodeAllocationOptions{Selector: selector, Top: top}
+			if len(args) == 1 {
+				opts.Name = args[0]
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			allocations, err := client.NodeAllocation(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+
+			if err := sortAllocations(allocations, sortBy); err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(allocations)
+			}
+
+			printAllocations(allocations)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter nodes on")
+	cmd.Flags().IntVar(&top, "top", 3, "Number of largest pods to show per node")
+	cmd.Flags().StringVar(&sortBy, "sort", "name", "Sort nodes by name, cpu, memory or pods")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+// sortAllocations orders nodes by name or by the highest share of a resource first
+func sortAllocations(allocations []nodes.Allocation, sortBy string) error {
+	var share func(a nodes.Allocation) float64
+	switch sortBy {
+	case "name":
+		return nil
+	case "cpu":
+		share = func(a nodes.Allocation) float64 { return percent(a.CPURequests, a.CPUAllocatable) }
+	case "memory":
+		share = func(a nodes.Allocation) float64 { return percent(a.MemoryRequests, a.MemoryAllocatable) }
+	case "pods":
+		share = func(a nodes.Allocation) float64 { return percent(a.Pods, a.MaxPods) }
+	default:
+		return errs.Validationf("invalid sort %q: must be name, cpu, memory or pods", sortBy)
+	}
+	sort.SliceStable(allocations, func(i, j int) bool {
+		return share(allocations[i]) > share(allocations[j])
+	})
+	return nil
+}
+
+func printAllocations(allocations []nodes.Allocation) {
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "NODE\tSTATUS\tCPU REQUESTS\tCPU LIMITS\tMEMORY REQUESTS\tMEMORY LIMITS\tPODS")
+	for _, a := range allocations {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			a.Node,
+			nodeStatus(a),
+			allocationCell(utils.FormatMilliCPU(a.CPURequests), a.CPURequests, a.CPUAllocatable),
+			allocationCell(utils.FormatMilliCPU(a.CPULimits), a.CPULimits, a.CPUAllocatable),
+			allocationCell(utils.FormatBytes(a.MemoryRequests), a.MemoryRequests, a.MemoryAllocatable),
+			allocationCell(utils.FormatBytes(a.MemoryLimits), a.MemoryLimits, a.MemoryAllocatable),
+			allocationCell(fmt.Sprintf("%d/%d", a.Pods, a.MaxPods), a.Pods, a.MaxPods),
+		)
+	}
+	w.Flush()
+
+	for _, a := range allocations {
+		if len(a.TopPods) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s\n", utils.Bold("Top pods on "+a.Node+":"))
+		w := newTable(os.Stdout)
+		fmt.Fprintln(w, "  NAMESPACE\tPOD\tCPU REQUEST\tMEMORY REQUEST")
+		for _, p := range a.TopPods {
+			fmt.Fprintf(w, "  %s\t%s\t%s (%.0f%%)\t%s (%.0f%%)\n",
+				p.Namespace,
+				p.Name,
+				utils.FormatMilliCPU(p.CPURequest), percent(p.CPURequest, a.CPUAllocatable),
+				utils.FormatBytes(p.MemoryRequest), percent(p.MemoryRequest, a.MemoryAllocatable),
+			)
+		}
+		w.Flush()
+	}
+}
+
+func nodeStatus(a nodes.Allocation) string {
+	status := "Ready"
+	if !a.Ready {
+		status = "NotReady"
+	}
+	if a.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
+
+// allocationCell renders a value with its share of the total, colored by how full the node is
+func allocationCell(value string, used, total int64) string {
+	p := percent(used, total)
+	cell := fmt.Sprintf("%s (%.0f%%)", value, p)
+	switch {
+	case p >= 90:
+		return utils.Red(cell)
+	case p >= 75:
+		return utils.Yellow(cell)
+	default:
+		return utils.Green(cell)
+	}
+}
+
+func percent(used, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(used) * 100 / float64(total)
+}
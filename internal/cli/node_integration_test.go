@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeCommands_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	rootCmd := getNodeCmd()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "list all nodes",
+			args:    []string{"list"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "INSTANCE TYPE")
+				assert.Contains(t, output, "NODE GROUP")
+			},
+		},
+		{
+			name:    "list nodes as json",
+			args:    []string{"list", "-o", "json"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `"cloud"`)
+			},
+		},
+		{
+			name:    "allocation of all nodes",
+			args:    []string{"allocation", "--sort", "name", "-o", "table"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "CPU REQUESTS")
+				assert.Contains(t, output, "Top pods on")
+			},
+		},
+		{
+			name:    "allocation as json",
+			args:    []string{"allocation", "--sort", "cpu", "-o", "json"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `"cpuAllocatable"`)
+			},
+		},
+		{
+			name:     "allocation with invalid sort",
+			args:     []string{"allocation", "--sort", "invalid", "-o", "table"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+		{
+			name:     "logs of non-existent node",
+			args:     []string{"logs", "non-existent"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+		{
+			name:     "logs with path and query",
+			args:     []string{"logs", "non-existent", "--path", "kubelet.log", "--query", "kubelet"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+		{
+			name:     "shell on non-existent node",
+			args:     []string{"shell", "non-existent", "--", "true"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+		{
+			name:     "allocation of non-existent node",
+			args:     []string{"allocation", "non-existent", "--sort", "name", "-o", "table"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+		{
+			name:     "simulate drain of non-existent node",
+			args:     []string{"drain", "non-existent-node", "--simulate"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := rootCmd
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/nodes"
+
+	"github.com/spf13/cobra"
+)
+
+func getNodeListCmd() *cobra.Command {
+	var selector string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List nodes with their cloud instance type, zone and node group",
+		Long: `List nodes with their status, roles and kubelet version, along with the cloud metadata
+read from their labels: the instance type, the availability zone, whether the instance is
+on-demand or spot (preemptible on GKE), and the node group it belongs to.
+
+Node groups are read from the labels of EKS managed node groups, eksctl, Karpenter, GKE node
+pools, AKS agent pools and kOps instance groups. Columns the labels do not reveal show "-".
+
+Examples:
+  # List all nodes
+  k8stool node list
+
+  # List the spot nodes of an EKS cluster
+  k8stool node list -l eks.amazonaws.com/capacityType=SPOT
+
+  # List the nodes of one zone as JSON
+  k8stool node list -l topology.kubernetes.io/zone=us-east-1a -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			nodeList, err := client.ListNodes(context.Background(), k8s.NodeListOptions{Selector: selector})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(nodeList)
+			}
+
+			printNodes(nodeList)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter nodes on")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printNodes(nodeList []nodes.Node) {
+	if len(nodeList) == 0 {
+		fmt.Println("No nodes found")
+		return
+	}
+
+	w := newTable(os.Stdout)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "NAME\tSTATUS\tROLES\tINSTANCE TYPE\tZONE\tCAPACITY\tNODE GROUP\tVERSION\tAGE")
+	for _, n := range nodeList {
+		roles := "<none>"
+		if len(n.Roles) > 0 {
+			roles = strings.Join(n.Roles, ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			n.Name,
+			formatNodeStatus(n),
+			roles,
+			orDash(n.Cloud.InstanceType),
+			orDash(n.Cloud.Zone),
+			orDash(n.Cloud.Capacity),
+			orDash(n.Cloud.NodeGroup),
+			n.Version,
+			formatAge(time.Since(n.Created)),
+		)
+	}
+}
+
+// formatNodeStatus renders readiness and cordoning the way kubectl get nodes does
+func formatNodeStatus(n nodes.Node) string {
+	status := "NotReady"
+	if n.Ready {
+		status = "Ready"
+	}
+	if n.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
+
+// orDash renders an empty value as "-"
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getNodeLogsCmd() *cobra.Command {
+	var opts k8s.NodeLogOptions
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "logs NAME",
+		Short: "Read node logs through the kubelet",
+		Long: `Read the logs of a node through the API server's node proxy, without SSH access.
+
+Without flags the files of the node's /var/log are listed. --path prints one of them, such as
+kubelet.log or a directory entry like pods/. --query reads the logs of a service, such as
+kubelet or containerd, from the journal; this needs the NodeLogQuery feature gate and
+enableSystemLogQuery in the kubelet configuration.
+
+Reading node logs requires the get permission on nodes/proxy.
+
+Examples:
+  # List the log files of a node
+  k8stool node logs worker-1
+
+  # Show the last 100 lines of the kubelet log file
+  k8stool node logs worker-1 --path kubelet.log --tail 100
+
+  # Query the kubelet's journal for errors of the last hour
+  k8stool node logs worker-1 --query kubelet --since 1h --pattern "E[0-9]{4}"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if since != "" {
+				if opts.Query == "" {
+					return errs.Validationf("--since can only be used with --query")
+				}
+				duration, err := time.ParseDuration(since)
+				if err != nil {
+					return errs.Validationf("invalid --since %q: %v", since, err)
+				}
+				t := time.Now().Add(-duration)
+				opts.SinceTime = &t
+			}
+			var pattern *regexp.Regexp
+			if opts.Pattern != "" {
+				var err error
+				if pattern, err = regexp.Compile(opts.Pattern); err != nil {
+					return errs.Validationf("invalid --pattern: %v", err)
+				}
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			if opts.Query == "" && (opts.Path == "" || strings.HasSuffix(opts.Path, "/")) {
+				files, err := client.ListNodeLogs(ctx, args[0], opts.Path)
+				if err != nil {
+					return err
+				}
+				printNodeLogFiles(args[0], opts.Path, files)
+				return nil
+			}
+
+			stream, err := client.NodeLogs(ctx, args[0], opts)
+			if err != nil {
+				return err
+			}
+			defer stream.Close()
+
+			if opts.Query != "" {
+				// The kubelet applies the tail and pattern of a query itself
+				_, err = io.Copy(os.Stdout, stream)
+				return err
+			}
+			return printNodeLogFile(stream, opts.TailLines, pattern)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Path, "path", "", "Log file or directory relative to /var/log, such as kubelet.log")
+	cmd.Flags().StringVar(&opts.Query, "query", "", "Service to query the logs of, such as kubelet (needs the NodeLogQuery feature gate)")
+	cmd.Flags().Int64Var(&opts.TailLines, "tail", 0, "Show only the last lines; 0 shows all")
+	cmd.Flags().StringVar(&opts.Pattern, "pattern", "", "Show only lines matching a regular expression")
+	cmd.Flags().StringVar(&since, "since", "", "With --query, show only lines logged within a duration (e.g. 1h, 5m)")
+
+	return cmd
+}
+
+// printNodeLogFiles lists the entries of a node log directory
+func printNodeLogFiles(node, dir string, files []string) {
+	if len(files) == 0 {
+		fmt.Printf("No log files found in /var/log/%s on node %s\n", strings.TrimPrefix(dir, "/"), node)
+		return
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "/") {
+			fmt.Println(utils.Bold(file))
+			continue
+		}
+		fmt.Println(file)
+	}
+	fmt.Printf("\nRead a file with: k8stool node logs %s --path %s<file>\n", node, dir)
+}
+
+// printNodeLogFile copies a log file to stdout, keeping only matching lines and, with a tail,
+// only the last of them
+func printNodeLogFile(stream io.Reader, tail int64, pattern *regexp.Regexp) error {
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	// A ring of the last lines, so large files are not held in memory
+	var ring []string
+	next := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		if pattern != nil && !pattern.MatchString(line) {
+			continue
+		}
+		switch {
+		case tail <= 0:
+			fmt.Println(line)
+		case int64(len(ring)) < tail:
+			ring = append(ring, line)
+		default:
+			ring[next] = line
+			next = (next + 1) % len(ring)
+		}
+	}
+	for i := range ring {
+		fmt.Println(ring[(next+i)%len(ring)])
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read node log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/nodes"
+	"k8stool/internal/k8s/pods"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// hostShell starts bash on the host when it has one, and sh otherwise
+const hostShell = "if command -v bash >/dev/null 2>&1; then exec bash -l; fi; exec sh -l"
+
+func getNodeShellCmd() *cobra.Command {
+	var namespace string
+	var opts nodes.ShellOptions
+
+	cmd := &cobra.Command{
+		Use:   "shell NAME [-- COMMAND [args...]]",
+		Short: "Open a root shell on a node",
+		Long: `Open a root shell on a node through a privileged debug pod.
+
+The pod is pinned to the node, tolerates every taint and shares the host's PID, network and
+IPC namespaces. The shell enters the host's namespaces with nsenter, so it sees the node as
+if logged in over SSH: systemctl, journalctl and crictl work as usual. The node's root
+filesystem is also mounted at /host in the pod.
+
+The pod is deleted when the shell exits or k8stool is interrupted. The namespace must allow
+privileged pods; kube-system usually does when the current namespace does not.
+
+Examples:
+  # Open a shell on a node
+  k8stool node shell worker-1
+
+  # Read the kubelet logs of a node
+  k8stool node shell worker-1 -- journalctl -u kubelet --since "10 min ago"
+
+  # Use kube-system and an image from a private registry
+  k8stool node shell worker-1 -n kube-system --image registry.example.com/busybox:1.36`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			opts.Namespace = namespace
+			if opts.Namespace == "" {
+				opts.Namespace = client.GetCurrentNamespace()
+			}
+
+			command := []string{"sh", "-c", hostShell}
+			if len(args) > 1 {
+				command = args[1:]
+			}
+			return runNodeShell(client, args[0], opts, command)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace to create the debug pod in (defaults to the current namespace)")
+	cmd.Flags().StringVar(&opts.Image, "image", nodes.DefaultShellImage, "Image of the debug pod; it must provide nsenter")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 2*time.Minute, "Maximum time to wait for the debug pod to start")
+	markMutating(cmd)
+
+	return cmd
+}
+
+// runNodeShell starts the debug pod, runs the command in the host's namespaces and deletes
+// the pod again, also when interrupted
+func runNodeShell(client *k8s.Client, node string, opts nodes.ShellOptions, command []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer stop()
+
+	fmt.Fprintf(os.Stderr, "Starting debug pod on node %s in namespace %s...\n", utils.Bold(node), opts.Namespace)
+	shell, err := client.StartNodeShell(ctx, node, opts)
+	if shell != nil {
+		var once sync.Once
+		cleanup := func() {
+			once.Do(func() {
+				cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				if err := client.StopNodeShell(cleanupCtx, shell); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+					return
+				}
+				fmt.Fprintf(os.Stderr, "Deleted debug pod %s/%s\n", shell.Namespace, shell.Name)
+			})
+		}
+		defer cleanup()
+		// Deleting the pod on a signal also ends a running session
+		go func() {
+			<-ctx.Done()
+			cleanup()
+		}()
+	}
+	if err != nil {
+		return err
+	}
+
+	execOpts := pods.ExecOptions{
+		Command: append([]string{"nsenter", "--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "--"}, command...),
+		Stdin:   os.Stdin,
+		Stdout:  os.Stdout,
+		Stderr:  os.Stderr,
+	}
+
+	stdinFd, stdoutFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if term.IsTerminal(stdinFd) && term.IsTerminal(stdoutFd) {
+		// A TTY merges stderr into stdout
+		execOpts.TTY = true
+		execOpts.Stderr = nil
+
+		state, err := term.MakeRaw(stdinFd)
+		if err != nil {
+			return fmt.Errorf("failed to put terminal into raw mode: %w", err)
+		}
+		defer term.Restore(stdinFd, state)
+		execOpts.SizeQueue = newTerminalSizeQueue(ctx, stdoutFd)
+	}
+
+	return client.PodService.Exec(shell.Namespace, shell.Name, "shell", execOpts)
+}
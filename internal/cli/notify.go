@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// notifier delivers alerts as desktop notifications and/or webhook posts
+type notifier struct {
+	desktop bool
+	webhook string
+	client  *http.Client
+}
+
+func newNotifier(desktop bool, webhook string) *notifier {
+	return &notifier{
+		desktop: desktop,
+		webhook: webhook,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// notify sends an alert to every configured target; payload is posted to the webhook as JSON
+// next to a text field, so Slack and Mattermost incoming webhooks render it as is
+func (n *notifier) notify(ctx context.Context, title, message string, payload map[string]interface{}) error {
+	var errs []string
+	if n.desktop {
+		if err := desktopNotify(ctx, title, message); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if n.webhook != "" {
+		body := map[string]interface{}{"text": title + ": " + message}
+		for k, v := range payload {
+			body[k] = v
+		}
+		if err := n.post(ctx, body); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to send notification: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (n *notifier) post(ctx context.Context, body map[string]interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhook, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// desktopNotify shows a notification with notify-send on Linux and osascript on macOS
+func desktopNotify(ctx context.Context, title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+		script := fmt.Sprintf(`display notification "%s" with title "%s"`, quote.Replace(message), quote.Replace(title))
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	case "windows":
+		return fmt.Errorf("desktop notifications are not supported on windows, use --webhook instead")
+	default:
+		cmd = exec.CommandContext(ctx, "notify-send", "--app-name=k8stool", title, message)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %v %s", cmd.Path, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/oom"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getOOMReportCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var since time.Duration
+	var threshold int32
+
+	cmd := &cobra.Command{
+		Use:   "oomreport",
+		Short: "Report OOM kills and restart bursts grouped by workload",
+		Long: `Scan container statuses and events for OOMKilled terminations and restart bursts,
+grouped by the workload owning the pods, so memory-starved services are spotted early.
+
+A container is reported when it was OOM killed within the window, or when the kubelet
+reported at least --restart-threshold back-off restarts for it within the window.
+
+Examples:
+  # Report for the current namespace over the last 24 hours
+  k8stool oomreport
+
+  # Report across all namespaces over the last hour
+  k8stool oomreport -A --since 1h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			report, err := client.OOMReport(context.Background(), k8s.OOMOptions{
+				Namespace:        namespace,
+				AllNamespaces:    allNamespaces,
+				Since:            since,
+				RestartThreshold: threshold,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(report.Workloads) == 0 {
+				fmt.Printf("No OOM kills or restart bursts in the last %s\n", utils.FormatDuration(report.Since))
+				return nil
+			}
+			return printOOMReport(report)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Scan all namespaces")
+	cmd.Flags().DurationVar(&since, "since", 24*time.Hour, "Only consider terminations and events within this window")
+	cmd.Flags().Int32Var(&threshold, "restart-threshold", 3, "Back-off restarts within the window that count as a burst")
+
+	return cmd
+}
+
+func printOOMReport(report *oom.Report) error {
+	w := newTable(os.Stdout)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tPOD\tCONTAINER\tOOM\tBACKOFFS\tRESTARTS\tLAST TERMINATION\tMEM LIMIT")
+	for _, wl := range report.Workloads {
+		fmt.Fprintf(w, "%s\t%s\t\t\t%s\t%d\t%d\t\t\n",
+			wl.Namespace,
+			utils.Bold(wl.Workload),
+			colorizeCount(wl.OOMKills),
+			wl.BackOffs,
+			wl.Restarts,
+		)
+		for _, c := range wl.Containers {
+			oomKilled := "-"
+			if c.OOMKilled {
+				oomKilled = utils.Red("yes")
+			}
+			last := "-"
+			if c.LastTermination != "" {
+				last = fmt.Sprintf("%s (%s)", c.LastTermination, formatAgo(c.LastTerminated))
+			}
+			limit := c.MemoryLimit
+			if limit == "" {
+				limit = "<none>"
+			}
+			fmt.Fprintf(w, "\t\t%s\t%s\t%s\t%d\t%d\t%s\t%s\n",
+				c.Pod,
+				c.Container,
+				oomKilled,
+				c.BackOffs,
+				c.Restarts,
+				last,
+				limit,
+			)
+		}
+	}
+
+	return nil
+}
+
+func colorizeCount(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	return utils.Red(n)
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// oomPod buffers an endless line in memory until it runs past its memory limit
+const oomPod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: k8stool-oom
+spec:
+  restartPolicy: Never
+  containers:
+  - name: hog
+    image: nginx:alpine
+    command: ["sh", "-c", "head -c 512m /dev/zero | tail"]
+    resources:
+      limits:
+        memory: 16Mi
+`
+
+func TestOOMReportCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	waitForOOMKill(t)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "report OOM kill in namespace",
+			args:    []string{"-n", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "WORKLOAD")
+				assert.Contains(t, output, "LAST TERMINATION")
+				assert.Contains(t, output, "MEM LIMIT")
+				assert.Regexp(t, `integration-test\s+Pod/k8stool-oom\s+1\s+0\s+0`, output)
+				assert.Regexp(t, `k8stool-oom\s+hog\s+yes\s+0\s+0\s+OOMKilled \(.+ ago\)\s+16Mi`, output)
+				assert.NotContains(t, output, "nginx")
+			},
+		},
+		{
+			name:    "report across all namespaces",
+			args:    []string{"-A"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Pod/k8stool-oom")
+			},
+		},
+		{
+			name:    "namespace without OOM kills",
+			args:    []string{"-n", "default", "--since", "1h"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Equal(t, "No OOM kills or restart bursts in the last 1h\n", output)
+			},
+		},
+		{
+			name:     "invalid window",
+			args:     []string{"--since", "today"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getOOMReportCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+// waitForOOMKill creates a pod in the integration-test namespace that is OOM killed and waits
+// until its termination is reported
+func waitForOOMKill(t *testing.T) {
+	createTestObjects(t, oomPod, "integration-test")
+
+	client, err := k8s.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Minute)
+	for {
+		report, err := client.OOMReport(context.Background(), k8s.OOMOptions{Namespace: "integration-test"})
+		if err != nil {
+			t.Fatalf("failed to get OOM report: %v", err)
+		}
+		if len(report.Workloads) > 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pod k8stool-oom was not OOM killed")
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
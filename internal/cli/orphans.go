@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/orphans"
+
+	"github.com/spf13/cobra"
+)
+
+func getOrphansCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var kinds []string
+
+	cmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "Find orphaned and unused resources",
+		Long: `Find resources that appear to be unused, to help clean up drift.
+
+Checks:
+  - configmaps: ConfigMaps not referenced by any pod
+  - secrets: Secrets not referenced by any pod, service account or ingress
+  - services: Services with no endpoints
+  - pvcs: PersistentVolumeClaims not mounted by any pod
+  - replicasets: ReplicaSets of old deployment revisions scaled to zero
+
+Results are hints, not proof: resources may be used by things k8stool cannot see,
+such as CronJobs that have not run yet or external consumers.
+
+Examples:
+  # Find orphans in the current namespace
+  k8stool orphans
+
+  # Only look for unused ConfigMaps and Secrets across all namespaces
+  k8stool orphans -A --kind configmaps,secrets`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			opts := k8s.OrphanOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+			}
+			for _, kind := range kinds {
+				opts.Kinds = append(opts.Kinds, orphans.Kind(kind))
+			}
+
+			found, err := client.FindOrphans(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+
+			if len(found) == 0 {
+				fmt.Println("No orphaned resources found")
+				return nil
+			}
+			return printOrphans(found)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Search across all namespaces")
+	cmd.Flags().StringSliceVar(&kinds, "kind", nil, "Kinds to check (configmaps, secrets, services, pvcs, replicasets)")
+
+	return cmd
+}
+
+func printOrphans(found []orphans.Orphan) error {
+	w := newTable(os.Stdout)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tAGE\tREASON")
+	for _, o := range found {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			o.Kind,
+			o.Namespace,
+			o.Name,
+			formatAge(o.Age),
+			o.Reason,
+		)
+	}
+
+	return nil
+}
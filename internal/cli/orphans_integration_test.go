@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// orphanObjects are a ConfigMap no pod uses and a Service whose selector matches no pods
+const orphanObjects = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: k8stool-orphan-config
+data:
+  key: value
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: k8stool-orphan-svc
+spec:
+  selector:
+    app: k8stool-nothing
+  ports:
+  - port: 80
+`
+
+func TestOrphansCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	createTestObjects(t, orphanObjects, "integration-test")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "find orphans in namespace",
+			args:    []string{"-n", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "KIND")
+				assert.Contains(t, output, "REASON")
+				assert.Regexp(t, `configmaps\s+integration-test\s+k8stool-orphan-config\s+\S+\s+not referenced by any pod`, output)
+				assert.Regexp(t, `services\s+integration-test\s+k8stool-orphan-svc\s+\S+\s+selector matches no pods`, output)
+				assert.NotContains(t, output, "kube-root-ca.crt")
+			},
+		},
+		{
+			name:    "find orphans of one kind",
+			args:    []string{"-n", "integration-test", "--kind", "services"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "k8stool-orphan-svc")
+				assert.NotContains(t, output, "k8stool-orphan-config")
+			},
+		},
+		{
+			name:    "current replicasets are not orphans",
+			args:    []string{"-n", "integration-test", "--kind", "replicasets"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Equal(t, "No orphaned resources found\n", output)
+			},
+		},
+		{
+			name:    "unsupported kind",
+			args:    []string{"-n", "integration-test", "--kind", "deployments"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "unsupported kind: deployments")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getOrphansCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// noPager disables paging of long output
+var noPager bool
+
+// startPager pipes everything written to stdout through the user's pager when stdout is a
+// terminal. The returned function closes the pager and waits for the user to quit it.
+//
+// The pager is taken from K8STOOL_PAGER or PAGER and defaults to less. Like git, LESS is set
+// to FRX when unset, so short output is printed directly and colors are preserved.
+func startPager() func() {
+	noop := func() {}
+	if noPager || !isatty.IsTerminal(os.Stdout.Fd()) {
+		return noop
+	}
+
+	pager, ok := os.LookupEnv("K8STOOL_PAGER")
+	if !ok {
+		if pager, ok = os.LookupEnv("PAGER"); !ok {
+			pager = "less"
+		}
+	}
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		return noop
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+	if _, ok := os.LookupEnv("LV"); !ok {
+		cmd.Env = append(cmd.Env, "LV=-c")
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return noop
+	}
+	cmd.Stdin = r
+	if err := cmd.Start(); err != nil {
+		r.Close()
+		w.Close()
+		return noop
+	}
+	r.Close()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	return func() {
+		os.Stdout = stdout
+		w.Close()
+		cmd.Wait()
+	}
+}
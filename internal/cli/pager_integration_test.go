@@ -0,0 +1,165 @@
+//go:build linux
+// +build linux
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestPager_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() {
+		os.Stdout = oldStdout
+		noPager = false
+	}()
+
+	// A pager that keeps a copy of what it pages
+	paged := filepath.Join(t.TempDir(), "paged")
+	t.Setenv("K8STOOL_PAGER", "tee "+paged)
+
+	tests := []struct {
+		name     string
+		args     []string
+		terminal bool
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:     "describe on a terminal is paged",
+			args:     []string{"describe", "pod", "nginx-default", "-n", "default"},
+			terminal: true,
+			wantErr:  false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "nginx-default")
+				assert.Contains(t, readPaged(t, paged), "nginx-default")
+			},
+		},
+		{
+			name:     "logs on a terminal are paged",
+			args:     []string{"logs", "pod", "nginx-default", "-n", "default"},
+			terminal: true,
+			wantErr:  false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, readPaged(t, paged), "nginx")
+			},
+		},
+		{
+			name:     "describe on a terminal without pager",
+			args:     []string{"describe", "pod", "nginx-default", "-n", "default", "--no-pager"},
+			terminal: true,
+			wantErr:  false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "nginx-default")
+				assert.NoFileExists(t, paged)
+			},
+		},
+		{
+			name:     "describe into a pipe is not paged",
+			args:     []string{"describe", "pod", "nginx-default", "-n", "default"},
+			terminal: false,
+			wantErr:  false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "nginx-default")
+				assert.NoFileExists(t, paged)
+			},
+		},
+		{
+			name:     "events into a pipe are not paged",
+			args:     []string{"events", "-n", "default"},
+			terminal: false,
+			wantErr:  false,
+			validate: func(t *testing.T, output string) {
+				assert.NoFileExists(t, paged)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(paged)
+
+			// Capture output through a pipe, or through a pseudo-terminal like a user's
+			r, w, _ := os.Pipe()
+			if tt.terminal {
+				r, w = openPTY(t)
+			}
+			os.Stdout = w
+
+			var buf bytes.Buffer
+			copied := make(chan struct{})
+			go func() {
+				// Reading the terminal fails once it is closed
+				io.Copy(&buf, r)
+				close(copied)
+			}()
+
+			// Run through the root command so the persistent --no-pager flag is parsed
+			cmd := rootCmd
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+			noPager = false
+
+			// Read output
+			w.Close()
+			<-copied
+			r.Close()
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+// openPTY returns the master and slave ends of a new pseudo-terminal
+func openPTY(t *testing.T) (*os.File, *os.File) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("no pseudo-terminal available: %v", err)
+	}
+	if err := unix.IoctlSetPointerInt(int(master.Fd()), unix.TIOCSPTLCK, 0); err != nil {
+		t.Fatalf("failed to unlock pseudo-terminal: %v", err)
+	}
+	n, err := unix.IoctlGetUint32(int(master.Fd()), unix.TIOCGPTN)
+	if err != nil {
+		t.Fatalf("failed to get pseudo-terminal number: %v", err)
+	}
+	slave, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Fatalf("failed to open pseudo-terminal: %v", err)
+	}
+	return master, slave
+}
+
+// readPaged returns what the pager was given
+func readPaged(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("output was not paged: %v", err)
+	}
+	return string(data)
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/pods"
+
+	"github.com/manifoldco/promptui"
+	"github.com/mattn/go-isatty"
+)
+
+// canPrompt reports whether the user can answer an interactive prompt
+func canPrompt() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// pick lets the user choose one of items and returns its index
+func pick(label string, items []string) (int, error) {
+	prompt := promptui.Select{
+		Label: label,
+		Items: items,
+		Size:  10,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}",
+			Active:   "▸ {{ . | cyan }}",
+			Inactive: "  {{ . }}",
+			Selected: "✔ {{ . | green }}",
+		},
+	}
+	idx, _, err := prompt.Run()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user input: %w", err)
+	}
+	return idx, nil
+}
+
+// pickContainer returns the only container of a pod, or lets the user choose one when the pod
+// has several and a prompt is possible. Otherwise the error lists the containers for -c.
+func pickContainer(pod *pods.Pod, noInteractive bool) (string, error) {
+	names := make([]string, 0, len(pod.Containers))
+	for _, c := range pod.Containers {
+		names = append(names, c.Name)
+	}
+
+	switch {
+	case len(names) == 0:
+		return "", fmt.Errorf("pod %q has no containers", pod.Name)
+	case len(names) == 1:
+		return names[0], nil
+	case noInteractive || !canPrompt():
+		return "", errs.Validationf("pod %q has multiple containers, use -c to choose one of: %s", pod.Name, strings.Join(names, ", "))
+	}
+
+	idx, err := pick(fmt.Sprintf("Select container in pod %s", pod.Name), names)
+	if err != nil {
+		return "", err
+	}
+	return names[idx], nil
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// pluginBinary is the name kubectl finds the k8stool plugin by on PATH, so
+	// kubectl k8stool runs it. krew installs the binary under this name.
+	pluginBinary = "kubectl-k8stool"
+	// pluginCompletionBinary is the name kubectl runs to complete the arguments of the plugin,
+	// with the words typed after kubectl k8stool
+	pluginCompletionBinary = "kubectl_complete-k8stool"
+)
+
+// adaptToPlugin adapts the root command to the name the binary was run by. As a kubectl plugin
+// help shows kubectl k8stool; as the completion helper of the plugin the arguments are
+// completed.
+func adaptToPlugin(argv0 string, args []string) {
+	switch strings.TrimSuffix(filepath.Base(argv0), ".exe") {
+	case pluginBinary:
+		rootCmd.Annotations = map[string]string{cobra.CommandDisplayNameAnnotation: "kubectl k8stool"}
+	case pluginCompletionBinary:
+		rootCmd.Annotations = map[string]string{cobra.CommandDisplayNameAnnotation: "kubectl k8stool"}
+		rootCmd.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+	}
+}
+
+// applyKubeconfig makes --kubeconfig take precedence over KUBECONFIG, as with kubectl. Every
+// kubeconfig is loaded with the default loading rules, which read KUBECONFIG.
+func applyKubeconfig() {
+	if kubeconfig != "" {
+		os.Setenv("KUBECONFIG", kubeconfig)
+	}
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestKubectlPlugin_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and the plugin state and restore them after tests
+	oldStdout := os.Stdout
+	defer func() {
+		os.Stdout = oldStdout
+		rootCmd.Annotations = nil
+		kubeconfig, namespace = "", ""
+	}()
+
+	// --kubeconfig sets KUBECONFIG, which is restored after tests
+	t.Setenv("KUBECONFIG", os.Getenv("KUBECONFIG"))
+	kubeconfigPath := clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+
+	// Start without cached completions
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	tests := []struct {
+		name          string
+		argv0         string
+		args          []string
+		kubeconfigEnv string
+		wantErr       bool
+		validate      func(t *testing.T, output string)
+	}{
+		{
+			name:    "help as a kubectl plugin",
+			argv0:   "/usr/local/bin/kubectl-k8stool",
+			args:    []string{"help"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "  kubectl k8stool [command]\n")
+			},
+		},
+		{
+			name:    "command help as a kubectl plugin",
+			argv0:   "/usr/local/bin/kubectl-k8stool",
+			args:    []string{"help", "get", "pods"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "  kubectl k8stool get pods [flags]\n")
+			},
+		},
+		{
+			name:    "help as a kubectl plugin on Windows",
+			argv0:   "kubectl-k8stool.exe",
+			args:    []string{"help"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "  kubectl k8stool [command]\n")
+			},
+		},
+		{
+			name:    "help under its own name",
+			argv0:   "/usr/local/bin/k8stool",
+			args:    []string{"help"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "  k8stool [command]\n")
+				assert.NotContains(t, output, "kubectl k8stool [command]")
+			},
+		},
+		{
+			name:    "list pods as a kubectl plugin",
+			argv0:   "/usr/local/bin/kubectl-k8stool",
+			args:    []string{"get", "pods", "-n", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "nginx")
+			},
+		},
+		{
+			name:    "complete as the completion helper of the plugin",
+			argv0:   "/usr/local/bin/kubectl_complete-k8stool",
+			args:    []string{"namespace", "switch", ""},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "integration-test\n")
+				assert.Contains(t, output, ":4\n")
+			},
+		},
+		{
+			name:          "kubeconfig flag takes precedence over KUBECONFIG",
+			argv0:         "/usr/local/bin/kubectl-k8stool",
+			args:          []string{"--kubeconfig", kubeconfigPath, "get", "pods", "-n", "integration-test"},
+			kubeconfigEnv: "/nonexistent/kubeconfig",
+			wantErr:       false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "nginx")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.kubeconfigEnv != "" {
+				t.Setenv("KUBECONFIG", tt.kubeconfigEnv)
+			}
+
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Run through the root command, adapted to the name the binary was run by
+			cmd := rootCmd
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+			adaptToPlugin(tt.argv0, tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+			rootCmd.Annotations = nil
+			kubeconfig, namespace = "", ""
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
-	"text/tabwriter"
+	"strings"
+	"sync/atomic"
 
 	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
 	"k8stool/internal/k8s/pods"
 	"k8stool/pkg/utils"
 
@@ -20,78 +24,217 @@ func getPodsCmd() *cobra.Command {
 	var reverse bool
 	var showMetrics bool
 	var namespace string
+	var excludeNamespaces []string
+	var output string
+	var watch bool
+	var showMesh bool
+	var showDigests bool
+	var columns []string
+	var clusters contextFlags
+	var interactive bool
 
 	cmd := &cobra.Command{
 		Use:     "pods",
 		Aliases: []string{"pod", "po"},
 		Short:   "Get pods",
+		Long: `List pods with their readiness, restarts, IP, node, age and status.
+
+With --watch the table is kept up to date until Ctrl+C. On a terminal rows that changed are
+highlighted for a few seconds: new pods in green, deleted pods in red, and pods whose status,
+readiness or restart count changed in yellow, so the churn of a rollout is easy to follow.
+When the output is not a terminal a row is printed for every change instead.
+
+With --mesh a MESH column shows the Istio or Linkerd sidecar of each pod. A pod that its
+namespace or its own labels enroll in a mesh but that has no sidecar is shown as missing in
+red; such pods silently bypass the mesh, usually because they started before injection was
+enabled.
+
+With --show-digests a DIGEST column shows the image digest each container runs, from the
+container statuses. Digests that differ between the replicas of a workload are yellow and
+listed after the table, which catches rollouts that did not finish and tags pushed again that
+only some pods pulled.
+
+--columns picks the columns shown and --sort restarts lists the most restarted pods first.
+Both are handy as defaults in the config file.
+
+With -i the pods are shown in a picker instead. Choosing one opens a menu to describe it,
+read its logs, open a shell in it, list its events or forward one of its ports, and comes
+back to the menu afterwards; Back returns to the pods, read again, and Quit or Ctrl+C ends.
+
+Examples:
+  # List pods in the current namespace
+  k8stool pods
+
+  # Most restarted pods first, without the IP column
+  k8stool pods --sort restarts --columns name,ready,restarts,node,age,status
+
+  # Follow the pods of an app during a deploy
+  k8stool pods -l app=web -w
+
+  # Find pods missing their mesh sidecar
+  k8stool pods -A --mesh
+
+  # Check that every replica of the web app runs the same build
+  k8stool pods -l app=web --show-digests
+
+  # Pick a pod of the web app and look into it
+  k8stool pods -l app=web -i`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := k8s.NewClient()
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			if watch && (output != "table" || showMetrics || showMesh || showDigests) {
+				return errs.Validationf("--watch only supports the table output without --metrics, --mesh or --show-digests")
+			}
+			for i, column := range columns {
+				columns[i] = strings.ToLower(column)
+				if !slices.Contains(podColumns, columns[i]) {
+					return errs.Validationf("invalid column %q: must be one of %s", column, strings.Join(podColumns, ", "))
+				}
+			}
+
+			contexts, err := clusters.resolve()
 			if err != nil {
 				return err
 			}
+			if watch && contexts != nil {
+				return errs.Validationf("--watch cannot be used with --contexts or --all-contexts")
+			}
+			if interactive {
+				if watch {
+					return errs.Validationf("--interactive cannot be used with --watch")
+				}
+				if err := checkBrowse(output, contexts); err != nil {
+					return err
+				}
+			}
 
-			// Get namespace from flag or current context
-			if !allNamespaces && namespace == "" {
-				currentCtx, err := client.ContextService.GetCurrent()
-				if err != nil {
-					return fmt.Errorf("failed to get current context: %v", err)
+			// List pods in every selected namespace
+			var multipleNamespaces atomic.Bool
+			list := func(client *k8s.Client) ([]pods.Pod, error) {
+				scope := resolveNamespaceScope(client, namespace, allNamespaces, excludeNamespaces)
+				if scope.multiple() {
+					multipleNamespaces.Store(true)
 				}
-				namespace = currentCtx.Namespace
+				podList, err := listInScope(scope, func(p pods.Pod) string { return p.Namespace }, func(ns string) ([]pods.Pod, error) {
+					return client.PodService.List(ns, ns == "", selector, "")
+				})
+				if err != nil || !showMesh {
+					return podList, err
+				}
+				return podList, client.PodService.ResolveMesh(context.Background(), podList)
 			}
 
-			// List pods using the service
-			podList, err := client.PodService.List(namespace, allNamespaces, selector, "")
+			var podList []pods.Pod
+			if contexts != nil {
+				podList, err = listInContexts(contexts, func(p *pods.Pod, name string) { p.Context = name }, list)
+			} else {
+				client, clientErr := k8s.NewClient()
+				if clientErr != nil {
+					return clientErr
+				}
+				if watch {
+					scope := resolveNamespaceScope(client, namespace, allNamespaces, excludeNamespaces)
+					return watchPods(client, scope, selector, sortBy, reverse)
+				}
+				if interactive {
+					return endBrowse(browsePods(client, func() ([]pods.Pod, error) {
+						podList, err := list(client)
+						if err != nil {
+							return nil, err
+						}
+						return podList, sortPods(podList, sortBy, reverse)
+					}, false))
+				}
+				podList, err = list(client)
+			}
 			if err != nil {
 				return err
 			}
 
-			// Sort pods if requested
-			if sortBy != "" {
-				switch sortBy {
-				case "name":
-					sort.Slice(podList, func(i, j int) bool {
-						if reverse {
-							return podList[i].Name > podList[j].Name
-						}
-						return podList[i].Name < podList[j].Name
-					})
-				case "status":
-					sort.Slice(podList, func(i, j int) bool {
-						if reverse {
-							return podList[i].Status > podList[j].Status
-						}
-						return podList[i].Status < podList[j].Status
-					})
-				case "age":
-					sort.Slice(podList, func(i, j int) bool {
-						if reverse {
-							return podList[i].Age < podList[j].Age
-						}
-						return podList[i].Age > podList[j].Age
-					})
-				default:
-					return fmt.Errorf("invalid sort key: %s", sortBy)
-				}
+			if err := sortPods(podList, sortBy, reverse); err != nil {
+				return err
 			}
 
-			// Pass allNamespaces flag to ensure namespace column is shown when -A is used
-			return printPods(podList, showMetrics, allNamespaces)
+			if output == "json" {
+				return printJSON(podList)
+			}
+
+			// Show the namespace column whenever several namespaces were queried
+			var drift []digestDrift
+			if showDigests {
+				drift = findDigestDrift(podList)
+			}
+			if err := printPods(podList, showMetrics, showMesh, showDigests, multipleNamespaces.Load(), columns, drift); err != nil {
+				return err
+			}
+			printDigestDrift(drift)
+			return nil
 		},
 	}
 
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List pods in all namespaces")
-	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace to list pods from")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace to list pods from (comma-separated for several)")
+	cmd.Flags().StringSliceVar(&excludeNamespaces, "exclude-namespace", nil, "Namespaces to leave out; implies all namespaces when -n is not set")
 	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
-	cmd.Flags().StringVar(&sortBy, "sort", "", "Sort pods by key (name, status, age)")
+	cmd.Flags().StringVar(&sortBy, "sort", "", "Sort pods by key (name, status, age, restarts)")
 	cmd.Flags().BoolVar(&reverse, "reverse", false, "Reverse sort order")
 	cmd.Flags().BoolVar(&showMetrics, "metrics", false, "Show pod metrics")
+	cmd.Flags().BoolVar(&showMesh, "mesh", false, "Show the service mesh sidecar of each pod and flag missing ones")
+	cmd.Flags().BoolVar(&showDigests, "show-digests", false, "Show the image digests the containers run and flag replicas running different ones")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "Columns to show, e.g. name,ready,status; the namespace column is shown when needed")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep the table up to date and highlight changes")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Pick a pod and describe it, read its logs, exec into it, list its events or port-forward to it")
+	clusters.register(cmd)
+	markQueryable(cmd)
 
 	return cmd
 }
 
-func printPods(pods []pods.Pod, showMetrics bool, allNamespaces bool) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+// podColumns are the columns of the pod table --columns picks from
+var podColumns = []string{"name", "ready", "restarts", "ip", "node", "cpu", "memory", "mesh", "digest", "age", "status"}
+
+// sortPods sorts pods by name, status, age or restarts, most first; an empty key keeps the order
+func sortPods(podList []pods.Pod, sortBy string, reverse bool) error {
+	switch sortBy {
+	case "":
+	case "name":
+		sort.Slice(podList, func(i, j int) bool {
+			if reverse {
+				return podList[i].Name > podList[j].Name
+			}
+			return podList[i].Name < podList[j].Name
+		})
+	case "status":
+		sort.Slice(podList, func(i, j int) bool {
+			if reverse {
+				return podList[i].Status > podList[j].Status
+			}
+			return podList[i].Status < podList[j].Status
+		})
+	case "age":
+		sort.Slice(podList, func(i, j int) bool {
+			if reverse {
+				return podList[i].Age < podList[j].Age
+			}
+			return podList[i].Age > podList[j].Age
+		})
+	case "restarts":
+		sort.SliceStable(podList, func(i, j int) bool {
+			if reverse {
+				return podList[i].Restarts < podList[j].Restarts
+			}
+			return podList[i].Restarts > podList[j].Restarts
+		})
+	default:
+		return errs.Validationf("invalid sort key: %s", sortBy)
+	}
+	return nil
+}
+
+func printPods(pods []pods.Pod, showMetrics, showMesh, showDigests, allNamespaces bool, columns []string, drift []digestDrift) error {
+	w := newTable(os.Stdout)
 	defer w.Flush()
 
 	// Check if we need to show namespace column by checking if pods are from different namespaces
@@ -107,66 +250,93 @@ func printPods(pods []pods.Pod, showMetrics bool, allNamespaces bool) error {
 		}
 	}
 
+	// Pods read from several contexts are told apart by a context column
+	showContext := false
+	for _, pod := range pods {
+		if pod.Context != "" {
+			showContext = true
+			break
+		}
+	}
+
 	// Print header based on what columns we're showing
+	var header []string
+	if showContext {
+		header = append(header, "CONTEXT")
+	}
 	if showNamespace {
-		if showMetrics {
-			fmt.Fprintln(w, "NAMESPACE\tNAME\tREADY\tRESTARTS\tIP\tNODE\tCPU\tMEMORY\tAGE\tSTATUS")
-		} else {
-			fmt.Fprintln(w, "NAMESPACE\tNAME\tREADY\tRESTARTS\tIP\tNODE\tAGE\tSTATUS")
-		}
-	} else {
-		if showMetrics {
-			fmt.Fprintln(w, "NAME\tREADY\tRESTARTS\tIP\tNODE\tCPU\tMEMORY\tAGE\tSTATUS")
-		} else {
-			fmt.Fprintln(w, "NAME\tREADY\tRESTARTS\tIP\tNODE\tAGE\tSTATUS")
-		}
+		header = append(header, "NAMESPACE")
+	}
+	header = append(header, "NAME", "READY", "RESTARTS", "IP", "NODE")
+	if showMetrics {
+		header = append(header, "CPU", "MEMORY")
+	}
+	if showMesh {
+		header = append(header, "MESH")
+	}
+	if showDigests {
+		header = append(header, "DIGEST")
 	}
+	header = append(header, "AGE", "STATUS")
 
-	for _, pod := range pods {
-		ready := pod.Ready
-		age := utils.FormatDuration(pod.Age)
-		restartCount := fmt.Sprintf("%d", pod.Restarts)
+	// Keep the columns asked for; the context and namespace columns tell the pods of
+	// clusters and namespaces apart
+	keep := make([]bool, len(header))
+	for i, name := range header {
+		keep[i] = len(columns) == 0 || name == "CONTEXT" || name == "NAMESPACE" || slices.Contains(columns, strings.ToLower(name))
+	}
+	fmt.Fprintln(w, strings.Join(pickColumns(header, keep), "\t"))
 
+	drifted := driftedContainers(drift)
+	for _, pod := range pods {
+		var row []string
+		if showContext {
+			row = append(row, pod.Context)
+		}
 		if showNamespace {
-			if showMetrics && pod.Metrics != nil {
-				cpu := "<none>"
-				mem := "<none>"
-				if pod.Metrics != nil {
-					cpu = pod.Metrics.CPU
-					mem = pod.Metrics.Memory
-				}
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-					pod.Namespace, pod.Name, ready,
-					restartCount, pod.IP, pod.Node,
-					cpu, mem, age,
-					utils.ColorizeStatus(pod.Status))
-			} else {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-					pod.Namespace, pod.Name, ready,
-					restartCount, pod.IP, pod.Node,
-					age, utils.ColorizeStatus(pod.Status))
-			}
-		} else {
-			if showMetrics && pod.Metrics != nil {
-				cpu := "<none>"
-				mem := "<none>"
-				if pod.Metrics != nil {
-					cpu = pod.Metrics.CPU
-					mem = pod.Metrics.Memory
-				}
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-					pod.Name, ready,
-					restartCount, pod.IP, pod.Node,
-					cpu, mem, age,
-					utils.ColorizeStatus(pod.Status))
-			} else {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-					pod.Name, ready,
-					restartCount, pod.IP, pod.Node,
-					age, utils.ColorizeStatus(pod.Status))
+			row = append(row, pod.Namespace)
+		}
+		row = append(row, pod.Name, pod.Ready, fmt.Sprintf("%d", pod.Restarts), pod.IP, pod.Node)
+		if showMetrics {
+			cpu := "<none>"
+			mem := "<none>"
+			if pod.Metrics != nil {
+				cpu = pod.Metrics.CPU
+				mem = pod.Metrics.Memory
 			}
+			row = append(row, cpu, mem)
 		}
+		if showMesh {
+			row = append(row, formatMesh(pod.Mesh))
+		}
+		if showDigests {
+			row = append(row, formatDigests(pod, drifted[podKey(pod)]))
+		}
+		row = append(row, formatAge(pod.Age), utils.ColorizeStatus(pod.Status))
+		fmt.Fprintln(w, strings.Join(pickColumns(row, keep), "\t"))
 	}
 
 	return nil
 }
+
+// formatMesh renders the sidecar of a pod, flagging a sidecar its mesh failed to inject
+func formatMesh(mesh pods.MeshStatus) string {
+	switch {
+	case mesh.Missing():
+		return utils.Red("missing (" + mesh.Expected + ")")
+	case mesh.Sidecar != "":
+		return mesh.Sidecar
+	}
+	return "-"
+}
+
+// pickColumns returns the cells of a row whose column is kept
+func pickColumns(cells []string, keep []bool) []string {
+	picked := make([]string, 0, len(cells))
+	for i, cell := range cells {
+		if keep[i] {
+			picked = append(picked, cell)
+		}
+	}
+	return picked
+}
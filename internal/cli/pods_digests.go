@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/images"
+	"k8stool/internal/k8s/pods"
+	"k8stool/pkg/utils"
+)
+
+// digestDrift is a container of a workload whose replicas run different images
+type digestDrift struct {
+	context   string
+	namespace string
+	workload  string
+	container string
+	// pods are the names of the pods running each digest
+	pods map[string][]string
+}
+
+// name returns the workload of the drift as [CONTEXT:]NAMESPACE/KIND/NAME
+func (d digestDrift) name() string {
+	name := d.namespace + "/" + d.workload
+	if d.context != "" {
+		name = d.context + ":" + name
+	}
+	return name
+}
+
+// podKey identifies a pod across namespaces and contexts
+func podKey(pod pods.Pod) string {
+	return pod.Context + "/" + pod.Namespace + "/" + pod.Name
+}
+
+// runningDigest returns the digest a container runs. Images built on the node, as with kind
+// load, have no repository digest, so their image ID tells the builds apart instead.
+func runningDigest(c pods.ContainerInfo) string {
+	if digest := images.ImageDigest(c.ImageID); digest != "" {
+		return digest
+	}
+	return c.ImageID
+}
+
+// podWorkload returns the workload a pod belongs to, resolving ReplicaSets to their
+// Deployment so the pods of a rollout in progress are compared with each other
+func podWorkload(p pods.Pod) string {
+	kind, name := p.Controller, p.ControllerName
+	if kind == "" {
+		return ""
+	}
+	if kind == "ReplicaSet" {
+		if hash := p.Labels["pod-template-hash"]; hash != "" && strings.HasSuffix(name, "-"+hash) {
+			kind, name = "Deployment", strings.TrimSuffix(name, "-"+hash)
+		}
+	}
+	return strings.ToLower(kind) + "/" + name
+}
+
+// findDigestDrift finds the containers whose replicas run different digests: an unfinished
+// rollout, or a tag pushed again that only some pods pulled. Pods without a controller and
+// containers that did not start yet are not compared.
+func findDigestDrift(podList []pods.Pod) []digestDrift {
+	type key struct{ context, namespace, workload, container string }
+	running := make(map[key]map[string][]string)
+	for _, pod := range podList {
+		workload := podWorkload(pod)
+		if workload == "" {
+			continue
+		}
+		for _, c := range pod.Containers {
+			digest := runningDigest(c)
+			if digest == "" {
+				continue
+			}
+			k := key{pod.Context, pod.Namespace, workload, c.Name}
+			if running[k] == nil {
+				running[k] = make(map[string][]string)
+			}
+			running[k][digest] = append(running[k][digest], pod.Name)
+		}
+	}
+
+	var drift []digestDrift
+	for k, digests := range running {
+		if len(digests) > 1 {
+			drift = append(drift, digestDrift{k.context, k.namespace, k.workload, k.container, digests})
+		}
+	}
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].name() != drift[j].name() {
+			return drift[i].name() < drift[j].name()
+		}
+		return drift[i].container < drift[j].container
+	})
+	return drift
+}
+
+// driftedContainers returns the containers of the drift by podKey
+func driftedContainers(drift []digestDrift) map[string]map[string]bool {
+	drifted := make(map[string]map[string]bool)
+	for _, d := range drift {
+		for _, podNames := range d.pods {
+			for _, name := range podNames {
+				key := podKey(pods.Pod{Context: d.context, Namespace: d.namespace, Name: name})
+				if drifted[key] == nil {
+					drifted[key] = make(map[string]bool)
+				}
+				drifted[key][d.container] = true
+			}
+		}
+	}
+	return drifted
+}
+
+// formatDigests renders the digests of the containers of a pod, prefixed with the container
+// name when there are several. Digests differing across replicas are yellow.
+func formatDigests(pod pods.Pod, drifted map[string]bool) string {
+	var cells []string
+	for _, c := range pod.Containers {
+		cell := "<none>"
+		if digest := runningDigest(c); digest != "" {
+			cell = images.ShortDigest(digest)
+		}
+		if len(pod.Containers) > 1 {
+			cell = c.Name + "@" + cell
+		}
+		if drifted[c.Name] {
+			cell = utils.Yellow(cell)
+		}
+		cells = append(cells, cell)
+	}
+	if len(cells) == 0 {
+		return "<none>"
+	}
+	return strings.Join(cells, ",")
+}
+
+// printDigestDrift warns about the containers whose replicas run different digests, with the
+// pods running each
+func printDigestDrift(drift []digestDrift) {
+	for _, d := range drift {
+		fmt.Fprintln(os.Stderr, utils.Yellow(fmt.Sprintf("Warning: %s runs %d digests of container %s:", d.name(), len(d.pods), d.container)))
+		digests := make([]string, 0, len(d.pods))
+		for digest := range d.pods {
+			digests = append(digests, digest)
+		}
+		sort.Slice(digests, func(i, j int) bool {
+			if len(d.pods[digests[i]]) != len(d.pods[digests[j]]) {
+				return len(d.pods[digests[i]]) > len(d.pods[digests[j]])
+			}
+			return digests[i] < digests[j]
+		})
+		for _, digest := range digests {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", images.ShortDigest(digest), strings.Join(d.pods[digest], ", "))
+		}
+	}
+}
@@ -35,6 +35,14 @@ func TestPodsCommands_Integration(t *testing.T) {
 				assert.Contains(t, output, "STATUS")
 			},
 		},
+		{
+			name:    "list pods with mesh sidecars",
+			args:    []string{"--mesh"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "MESH")
+			},
+		},
 		{
 			name:    "list pods with namespace",
 			args:    []string{"-n", "kube-system"},
@@ -55,6 +63,16 @@ func TestPodsCommands_Integration(t *testing.T) {
 				assert.Contains(t, output, "STATUS")
 			},
 		},
+		{
+			name:    "list pods in several namespaces",
+			args:    []string{"-n", "default,kube-system"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "NAMESPACE")
+				assert.Contains(t, output, "nginx-default")
+				assert.Contains(t, output, "coredns")
+			},
+		},
 		{
 			name:    "list pods with all namespaces",
 			args:    []string{"--all-namespaces"},
@@ -66,6 +84,31 @@ func TestPodsCommands_Integration(t *testing.T) {
 				assert.Contains(t, output, "kube-system")
 			},
 		},
+		{
+			name:    "list pods excluding a namespace",
+			args:    []string{"--all-namespaces", "--exclude-namespace", "kube-system"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "nginx-default")
+				assert.NotContains(t, output, "coredns")
+			},
+		},
+		{
+			name:    "list pods in an unknown context",
+			args:    []string{"--contexts", "nonexistent-context"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "not found in the kubeconfig")
+			},
+		},
+		{
+			name:    "browse pods with json output",
+			args:    []string{"-i", "-o", "json"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "--interactive cannot be used with -o json")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/pods"
+	"k8stool/pkg/utils"
+
+	"github.com/mattn/go-isatty"
+)
+
+// podWatchHighlight is how long a changed row stays highlighted, and a deleted pod stays listed
+const podWatchHighlight = 3 * time.Second
+
+// podChange is how a pod changed during a watch
+type podChange int
+
+const (
+	podUnchanged podChange = iota
+	podAdded
+	podUpdated
+	podDeleted
+)
+
+// watchedPodRow is a pod of the watch table with its latest change
+type watchedPodRow struct {
+	pod       pods.Pod
+	change    podChange
+	changedAt time.Time
+}
+
+// podWatchTable holds the pods of a watch, keyed by namespace/name
+type podWatchTable struct {
+	rows map[string]*watchedPodRow
+}
+
+// apply records a watch event and reports whether the table changed
+func (t *podWatchTable) apply(event k8s.PodWatchEvent, now time.Time) bool {
+	key := event.Pod.Namespace + "/" + event.Pod.Name
+	row, exists := t.rows[key]
+
+	switch {
+	case event.Type == "DELETED":
+		if !exists {
+			return false
+		}
+		row.change, row.changedAt = podDeleted, now
+	case event.Initial:
+		t.rows[key] = &watchedPodRow{pod: event.Pod}
+	case !exists || row.change == podDeleted:
+		t.rows[key] = &watchedPodRow{pod: event.Pod, change: podAdded, changedAt: now}
+	default:
+		// Only status transitions are highlighted, not every update of the object
+		old := row.pod
+		row.pod = event.Pod
+		if old.Status == event.Pod.Status && old.Ready == event.Pod.Ready && old.Restarts == event.Pod.Restarts {
+			return false
+		}
+		if row.change != podAdded || now.Sub(row.changedAt) >= podWatchHighlight {
+			row.change = podUpdated
+		}
+		row.changedAt = now
+	}
+	return true
+}
+
+// expire drops deleted pods and clears highlights older than podWatchHighlight, reporting
+// whether anything changed
+func (t *podWatchTable) expire(now time.Time) bool {
+	changed := false
+	for key, row := range t.rows {
+		if row.change == podUnchanged || now.Sub(row.changedAt) < podWatchHighlight {
+			continue
+		}
+		if row.change == podDeleted {
+			delete(t.rows, key)
+		} else {
+			row.change = podUnchanged
+		}
+		changed = true
+	}
+	return changed
+}
+
+// render writes the table with changed rows highlighted: new pods green, deleted pods red
+// and status transitions yellow
+func (t *podWatchTable) render(out io.Writer, showNamespace bool, sortBy string, reverse bool) error {
+	rows := make([]*watchedPodRow, 0, len(t.rows))
+	podList := make([]pods.Pod, 0, len(t.rows))
+	for _, row := range t.rows {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].pod.Namespace != rows[j].pod.Namespace {
+			return rows[i].pod.Namespace < rows[j].pod.Namespace
+		}
+		return rows[i].pod.Name < rows[j].pod.Name
+	})
+	for _, row := range rows {
+		podList = append(podList, row.pod)
+	}
+	if err := sortPods(podList, sortBy, reverse); err != nil {
+		return err
+	}
+	byKey := make(map[string]*watchedPodRow, len(rows))
+	for _, row := range rows {
+		byKey[row.pod.Namespace+"/"+row.pod.Name] = row
+	}
+
+	var buf bytes.Buffer
+	w := newTable(&buf)
+	if showNamespace {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintln(w, "NAME\tREADY\tRESTARTS\tIP\tNODE\tAGE\tSTATUS")
+	for _, pod := range podList {
+		status := pod.Status
+		if byKey[pod.Namespace+"/"+pod.Name].change == podDeleted {
+			status = "Deleted"
+		}
+		if showNamespace {
+			fmt.Fprintf(w, "%s\t", pod.Namespace)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n", pod.Name, pod.Ready, pod.Restarts, pod.IP, pod.Node, formatAge(pod.Age), status)
+	}
+	w.Flush()
+
+	// Color whole lines after alignment, since escape codes would skew the column widths
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	fmt.Fprintln(out, lines[0])
+	for i, pod := range podList {
+		line := lines[i+1]
+		switch byKey[pod.Namespace+"/"+pod.Name].change {
+		case podAdded:
+			line = utils.Green(line)
+		case podUpdated:
+			line = utils.Yellow(line)
+		case podDeleted:
+			line = utils.Red(line)
+		default:
+			// Unchanged rows keep the usual status colors
+			line = strings.TrimSuffix(line, pod.Status) + utils.ColorizeStatus(pod.Status)
+		}
+		fmt.Fprintln(out, line)
+	}
+	return nil
+}
+
+// watchPods redraws the pod table on every change until interrupted. On a terminal the table is
+// redrawn in place; otherwise each change is printed as a row, as kubectl get -w does.
+func watchPods(client *k8s.Client, scope namespaceScope, selector, sortBy string, reverse bool) error {
+	if err := sortPods(nil, sortBy, reverse); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	events, err := watchPodsInScope(ctx, client, scope, selector)
+	if err != nil {
+		return err
+	}
+
+	showNamespace := scope.multiple()
+	if !isatty.IsTerminal(os.Stdout.Fd()) {
+		return streamPods(events, showNamespace)
+	}
+
+	table := &podWatchTable{rows: make(map[string]*watchedPodRow)}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	dirty := true
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if table.apply(event, time.Now()) {
+				dirty = true
+			}
+			continue
+		case <-ticker.C:
+			if table.expire(time.Now()) {
+				dirty = true
+			}
+		}
+		if !dirty {
+			continue
+		}
+		dirty = false
+
+		// Move home and clear the screen before drawing the table again
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("Watching pods, updated %s (Ctrl+C to stop)\n\n", time.Now().Format("15:04:05"))
+		if err := table.render(os.Stdout, showNamespace, sortBy, reverse); err != nil {
+			return err
+		}
+	}
+}
+
+// watchPodsInScope watches the pods of every namespace of the scope and merges their events.
+// Explicit namespaces get a watch each, so no cluster-wide access is needed; for all namespaces
+// one watch is opened and pods from excluded namespaces are dropped.
+func watchPodsInScope(ctx context.Context, client *k8s.Client, scope namespaceScope, selector string) (<-chan k8s.PodWatchEvent, error) {
+	namespaces := scope.namespaces
+	if scope.all() {
+		namespaces = []string{""}
+	}
+
+	merged := make(chan k8s.PodWatchEvent, 100)
+	var wg sync.WaitGroup
+	for _, ns := range namespaces {
+		events, err := client.PodService.Watch(ctx, k8s.PodWatchOptions{Namespace: ns, AllNamespaces: ns == "", Selector: selector})
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range events {
+				if !scope.exclude[event.Pod.Namespace] {
+					merged <- event
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+
+	return merged, nil
+}
+
+// streamPods prints a row for every pod change, for output that is not a terminal
+func streamPods(events <-chan k8s.PodWatchEvent, showNamespace bool) error {
+	w := newTable(os.Stdout)
+	if showNamespace {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintln(w, "NAME\tREADY\tRESTARTS\tIP\tNODE\tAGE\tSTATUS")
+	for event := range events {
+		pod := event.Pod
+		status := pod.Status
+		if event.Type == "DELETED" {
+			status = "Deleted"
+		}
+		if showNamespace {
+			fmt.Fprintf(w, "%s\t", pod.Namespace)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n", pod.Name, pod.Ready, pod.Restarts, pod.IP, pod.Node, formatAge(pod.Age), status)
+		// Flush every row so changes show up as they happen
+		w.Flush()
+	}
+	return nil
+}
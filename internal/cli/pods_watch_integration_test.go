@@ -0,0 +1,193 @@
+//go:build linux
+// +build linux
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"testing"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/resources"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+// watchedPod is created and deleted while the pods are watched
+const watchedPod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: k8stool-watched
+  labels:
+    app: k8stool-watched
+spec:
+  terminationGracePeriodSeconds: 1
+  containers:
+  - name: nginx
+    image: nginx:alpine
+`
+
+func TestPodsWatch_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and colors and restore them after tests
+	oldStdout, oldNoColor := os.Stdout, color.NoColor
+	defer func() { os.Stdout, color.NoColor = oldStdout, oldNoColor }()
+	color.NoColor = false
+
+	// Keep an interrupt meant for a watch from stopping the tests
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	tests := []struct {
+		name       string
+		args       []string
+		terminal   bool
+		changePods bool
+		wantErr    bool
+		validate   func(t *testing.T, output string)
+	}{
+		{
+			name:       "watch on a terminal highlights changes",
+			args:       []string{"-n", "integration-test", "-l", "app=k8stool-watched", "--watch"},
+			terminal:   true,
+			changePods: true,
+			wantErr:    false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "\033[H\033[2J")
+				assert.Contains(t, output, "(Ctrl+C to stop)")
+				assert.Regexp(t, `NAME\s+READY\s+RESTARTS\s+IP\s+NODE\s+AGE\s+STATUS`, output)
+				assert.Regexp(t, "\033\\[32mk8stool-watched\\s", output)
+				assert.Regexp(t, "\033\\[31mk8stool-watched\\s[^\n]*Deleted", output)
+			},
+		},
+		{
+			name:       "watch into a pipe prints a row per change",
+			args:       []string{"-n", "integration-test", "-l", "app=k8stool-watched", "--watch"},
+			terminal:   false,
+			changePods: true,
+			wantErr:    false,
+			validate: func(t *testing.T, output string) {
+				assert.NotContains(t, output, "\033[H\033[2J")
+				assert.Regexp(t, `NAME\s+READY\s+RESTARTS\s+IP\s+NODE\s+AGE\s+STATUS`, output)
+				assert.Regexp(t, `k8stool-watched\s+0/1\s+0\s+.*Pending`, output)
+				assert.Regexp(t, `k8stool-watched\s+.*Deleted`, output)
+			},
+		},
+		{
+			name:       "watch several namespaces into a pipe",
+			args:       []string{"-n", "integration-test,default", "-l", "app=k8stool-watched", "--watch"},
+			terminal:   false,
+			changePods: true,
+			wantErr:    false,
+			validate: func(t *testing.T, output string) {
+				assert.Regexp(t, `NAMESPACE\s+NAME\s+READY`, output)
+				assert.Regexp(t, `integration-test\s+k8stool-watched\s+.*Deleted`, output)
+			},
+		},
+		{
+			name:     "watch with json output",
+			args:     []string{"-n", "integration-test", "--watch", "-o", "json"},
+			terminal: false,
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "--watch only supports the table output")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Capture output through a pipe, or through a pseudo-terminal like a user's
+			r, w, _ := os.Pipe()
+			if tt.terminal {
+				r, w = openPTY(t)
+			}
+			os.Stdout = w
+
+			var buf bytes.Buffer
+			copied := make(chan struct{})
+			go func() {
+				// Reading the terminal fails once it is closed
+				io.Copy(&buf, r)
+				close(copied)
+			}()
+
+			changed := make(chan struct{})
+			if tt.changePods {
+				go func() {
+					cyclePod(t, watchedPod, "integration-test")
+					close(changed)
+				}()
+			} else {
+				close(changed)
+			}
+
+			// Create fresh command for each test
+			cmd := getPodsCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+			<-changed
+
+			// Read output
+			w.Close()
+			<-copied
+			r.Close()
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+// cyclePod creates the pods of a manifest once a watch has started, deletes them again and
+// then stops the watch
+func cyclePod(t *testing.T, manifest, namespace string) {
+	defer interruptWatch()
+
+	objs, err := resources.Decode([]byte(manifest))
+	if err != nil {
+		t.Errorf("failed to decode objects: %v", err)
+		return
+	}
+	client, err := k8s.NewClient()
+	if err != nil {
+		t.Errorf("failed to create client: %v", err)
+		return
+	}
+
+	time.Sleep(2 * time.Second)
+	if err := applyTestObjects(client, objs, namespace); err != nil {
+		t.Errorf("failed to create objects: %v", err)
+		return
+	}
+	// Leave the pods time to start, so the watch sees them change
+	time.Sleep(5 * time.Second)
+	results, err := client.Delete(context.Background(), objs, k8s.DeleteOptions{Namespace: namespace, IgnoreNotFound: true, Wait: true, Timeout: time.Minute})
+	if err == nil {
+		err = resultsError(results)
+	}
+	if err != nil {
+		t.Errorf("failed to delete objects: %v", err)
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
 	"k8stool/internal/k8s/portforward"
 
 	"github.com/manifoldco/promptui"
@@ -20,11 +22,17 @@ func getPortForwardCmd() *cobra.Command {
 	var address string
 	var interactive bool
 	var protocol string
+	var ordinal int
+	var idleTimeout time.Duration
+	var keepAlive time.Duration
 
 	cmd := &cobra.Command{
-		Use:   "port-forward (pod|deployment) NAME [LOCAL_PORT:]REMOTE_PORT [...[LOCAL_PORT_N:]REMOTE_PORT_N]",
-		Short: "Forward local ports to a pod or deployment",
-		Long: `Forward one or more local ports to a pod or deployment.
+		Use:   "port-forward (pod|deployment|statefulset) NAME [LOCAL_PORT:]REMOTE_PORT [...[LOCAL_PORT_N:]REMOTE_PORT_N]",
+		Short: "Forward local ports to a pod, deployment or statefulset",
+		Long: `Forward one or more local ports to a pod, deployment or statefulset.
+
+For a statefulset the replica is chosen with --ordinal, or the lowest ready ordinal is used.
+
 Examples:
   # Forward local port 8080 to pod port 80
   k8stool port-forward pod nginx 8080:80
@@ -32,6 +40,9 @@ Examples:
   # Forward local port 8080 to deployment port 80
   k8stool port-forward deployment nginx 8080:80
 
+  # Forward to the primary replica of a statefulset
+  k8stool port-forward statefulset postgres 5432 --ordinal 0
+
   # Forward multiple ports
   k8stool port-forward pod nginx 8080:80 9090:90
 
@@ -41,8 +52,14 @@ Examples:
   # Forward using UDP protocol
   k8stool port-forward pod nginx 8080:80 --protocol=udp
 
+  # Close the tunnel after 30 minutes without traffic
+  k8stool port-forward pod nginx 8080:80 --idle-timeout 30m
+
   # Interactive mode
-  k8stool port-forward -i`,
+  k8stool port-forward -i
+
+  # List running port forwards with their traffic
+  k8stool port-forward list`,
 		Aliases: []string{"pf"},
 		Args:    cobra.MinimumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -62,7 +79,7 @@ Examples:
 
 			// Handle interactive mode
 			if interactive {
-				return handleInteractivePortForward(client, namespace, address, protocol)
+				return handleInteractivePortForward(client, namespace, address, protocol, idleTimeout, keepAlive)
 			}
 
 			// Original non-interactive logic continues here
@@ -74,9 +91,13 @@ Examples:
 			name := args[1]
 			ports := args[2:]
 
+			if cmd.Flags().Changed("ordinal") && !isStatefulSetType(resourceType) {
+				return errs.Validationf("--ordinal can only be used with a statefulset")
+			}
+
 			// If no ports specified, return error
 			if len(ports) == 0 {
-				return fmt.Errorf("at least one port mapping is required")
+				return errs.Validationf("at least one port mapping is required")
 			}
 
 			// Parse port mappings
@@ -84,7 +105,7 @@ Examples:
 			for _, port := range ports {
 				parts := strings.Split(port, ":")
 				if len(parts) > 2 {
-					return fmt.Errorf("invalid port mapping: %s", port)
+					return errs.Validationf("invalid port mapping: %s", port)
 				}
 
 				var localPort, remotePort string
@@ -100,11 +121,11 @@ Examples:
 				// Convert string ports to uint16
 				localPortNum, err := strconv.ParseUint(localPort, 10, 16)
 				if err != nil {
-					return fmt.Errorf("invalid local port: %s", localPort)
+					return errs.Validationf("invalid local port: %s", localPort)
 				}
 				remotePortNum, err := strconv.ParseUint(remotePort, 10, 16)
 				if err != nil {
-					return fmt.Errorf("invalid remote port: %s", remotePort)
+					return errs.Validationf("invalid remote port: %s", remotePort)
 				}
 
 				portMappings = append(portMappings, portforward.PortMapping{
@@ -145,6 +166,8 @@ Examples:
 					Out:    os.Stdout,
 					ErrOut: os.Stderr,
 				},
+				IdleTimeout: idleTimeout,
+				KeepAlive:   keepAliveOption(keepAlive),
 			}
 
 			var result *portforward.PortForwardResult
@@ -153,8 +176,16 @@ Examples:
 				result, err = client.PortForwardService.ForwardPodPort(namespace, name, opts)
 			case "deployment", "deploy":
 				result, err = client.PortForwardService.ForwardServicePort(namespace, name, opts)
+			case "statefulset", "sts":
+				var podName string
+				podName, err = client.PodService.StatefulSetPod(namespace, name, ordinal)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Forwarding to replica %s\n", podName)
+				result, err = client.PortForwardService.ForwardPodPort(namespace, podName, opts)
 			default:
-				return fmt.Errorf("unsupported resource type: %s", resourceType)
+				return errs.Validationf("unsupported resource type: %s", resourceType)
 			}
 
 			if err != nil {
@@ -174,13 +205,8 @@ Examples:
 				fmt.Printf("  %s:%d -> %d\n", port.Address, port.Local, port.Remote)
 			}
 
-			// Wait for stop signal
-			<-stopChan
-
-			// Stop port forwarding
-			if err := client.PortForwardService.StopForwarding(result); err != nil {
-				fmt.Printf("Error stopping port forward: %v\n", err)
-			}
+			// Track traffic until the stop signal
+			trackPortForward(client, namespace, resourceType+"/"+name, result, stopChan)
 
 			return nil
 		},
@@ -190,11 +216,31 @@ Examples:
 	cmd.Flags().StringVar(&address, "address", "localhost", "Local address to bind to")
 	cmd.Flags().StringVar(&protocol, "protocol", string(portforward.TCP), "Protocol to use (tcp or udp)")
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive mode")
+	cmd.Flags().IntVar(&ordinal, "ordinal", -1, "StatefulSet replica to forward to; -1 picks the lowest ready ordinal")
+
+	cmd.Flags().DurationVar(&idleTimeout, "idle-timeout", 0, "Close the port forward after this long without traffic, e.g. 30m (0 disables)")
+	cmd.Flags().DurationVar(&keepAlive, "keepalive", portforward.DefaultKeepAlive, "Interval of keepalive pings to the API server (0 disables)")
+
+	cmd.AddCommand(getPortForwardListCmd())
 
 	return cmd
 }
 
-func handleInteractivePortForward(client *k8s.Client, namespace, address, protocol string) error {
+// keepAliveOption converts the --keepalive flag, where 0 disables pings, to the service option,
+// where 0 selects the default
+func keepAliveOption(keepAlive time.Duration) time.Duration {
+	if keepAlive <= 0 {
+		return -1
+	}
+	return keepAlive
+}
+
+// isStatefulSetType reports whether a port-forward resource type names a statefulset
+func isStatefulSetType(resourceType string) bool {
+	return resourceType == "statefulset" || resourceType == "sts"
+}
+
+func handleInteractivePortForward(client *k8s.Client, namespace, address, protocol string, idleTimeout, keepAlive time.Duration) error {
 	// First, let the user choose between pod and deployment
 	resourceTypes := []string{"pod", "deployment"}
 	resourcePrompt := promptui.Select{
@@ -242,24 +288,10 @@ func handleInteractivePortForward(client *k8s.Client, namespace, address, protoc
 			return err
 		}
 
-		selectedPod := podList[idx]
-		resourceName = selectedPod.Name
-
-		// Get pod details to access container information
-		podDetails, err := client.PodService.Get(namespace, selectedPod.Name)
-		if err != nil {
+		resourceName = podList[idx].Name
+		if containerPorts, err = podPortChoices(client, namespace, resourceName); err != nil {
 			return err
 		}
-
-		// Get container ports
-		for _, container := range podDetails.Containers {
-			for _, port := range container.Ports {
-				if port.ContainerPort > 0 {
-					containerPorts = append(containerPorts,
-						fmt.Sprintf("%d:%d (%s/%s)", port.ContainerPort, port.ContainerPort, container.Name, port.Protocol))
-				}
-			}
-		}
 	} else {
 		// Get list of deployments
 		deploymentList, err := client.DeploymentService.List(namespace, false, "")
@@ -284,24 +316,53 @@ func handleInteractivePortForward(client *k8s.Client, namespace, address, protoc
 			return err
 		}
 
-		selectedDeployment := deploymentList[idx]
-		resourceName = selectedDeployment.Name
-
-		// Get deployment details to access container information
-		deploymentDetails, err := client.DeploymentService.Describe(namespace, selectedDeployment.Name)
-		if err != nil {
+		resourceName = deploymentList[idx].Name
+		if containerPorts, err = deploymentPortChoices(client, namespace, resourceName); err != nil {
 			return err
 		}
+	}
 
-		// Get container ports
-		for _, container := range deploymentDetails.Containers {
-			for _, port := range container.Ports {
-				containerPorts = append(containerPorts,
-					fmt.Sprintf("%d:%d (%s)", port.ContainerPort, port.ContainerPort, container.Name))
+	return forwardChosenPort(client, namespace, resourceType, resourceName, containerPorts, address, protocol, idleTimeout, keepAlive)
+}
+
+// podPortChoices lists the container ports of a pod as choices of the interactive port forward
+func podPortChoices(client *k8s.Client, namespace, name string) ([]string, error) {
+	pod, err := client.PodService.Get(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	var choices []string
+	for _, container := range pod.Containers {
+		for _, port := range container.Ports {
+			if port.ContainerPort > 0 {
+				choices = append(choices,
+					fmt.Sprintf("%d:%d (%s/%s)", port.ContainerPort, port.ContainerPort, container.Name, port.Protocol))
 			}
 		}
 	}
+	return choices, nil
+}
+
+// deploymentPortChoices lists the container ports of a deployment as choices of the
+// interactive port forward
+func deploymentPortChoices(client *k8s.Client, namespace, name string) ([]string, error) {
+	details, err := client.DeploymentService.Describe(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	var choices []string
+	for _, container := range details.Containers {
+		for _, port := range container.Ports {
+			choices = append(choices,
+				fmt.Sprintf("%d:%d (%s)", port.ContainerPort, port.ContainerPort, container.Name))
+		}
+	}
+	return choices, nil
+}
 
+// forwardChosenPort lets the user pick one of containerPorts and a local port, and forwards it
+// to the pod or deployment until Ctrl+C
+func forwardChosenPort(client *k8s.Client, namespace, resourceType, resourceName string, containerPorts []string, address, protocol string, idleTimeout, keepAlive time.Duration) error {
 	if len(containerPorts) == 0 {
 		return fmt.Errorf("no ports exposed by %s %s", resourceType, resourceName)
 	}
@@ -326,12 +387,12 @@ func handleInteractivePortForward(client *k8s.Client, namespace, address, protoc
 	// Parse selected port mapping
 	parts := strings.Split(strings.Split(portMapping, " ")[0], ":")
 	if len(parts) != 2 {
-		return fmt.Errorf("invalid port mapping format")
+		return errs.Validationf("invalid port mapping format")
 	}
 
 	remotePort, err := strconv.ParseUint(parts[1], 10, 16)
 	if err != nil {
-		return fmt.Errorf("invalid remote port: %v", err)
+		return errs.Validationf("invalid remote port: %v", err)
 	}
 
 	// Ask if user wants to specify a local port
@@ -350,7 +411,7 @@ func handleInteractivePortForward(client *k8s.Client, namespace, address, protoc
 			Validate: func(input string) error {
 				port, err := strconv.ParseUint(input, 10, 16)
 				if err != nil {
-					return fmt.Errorf("invalid port number")
+					return errs.Validationf("invalid port number")
 				}
 				if port < 1 || port > 65535 {
 					return fmt.Errorf("port must be between 1 and 65535")
@@ -366,7 +427,7 @@ func handleInteractivePortForward(client *k8s.Client, namespace, address, protoc
 
 		localPort, err = strconv.ParseUint(localPortStr, 10, 16)
 		if err != nil {
-			return fmt.Errorf("invalid local port: %v", err)
+			return errs.Validationf("invalid local port: %v", err)
 		}
 	} else {
 		localPort = remotePort
@@ -412,6 +473,8 @@ func handleInteractivePortForward(client *k8s.Client, namespace, address, protoc
 			Out:    os.Stdout,
 			ErrOut: os.Stderr,
 		},
+		IdleTimeout: idleTimeout,
+		KeepAlive:   keepAliveOption(keepAlive),
 	}
 
 	var result *portforward.PortForwardResult
@@ -438,13 +501,8 @@ func handleInteractivePortForward(client *k8s.Client, namespace, address, protoc
 		fmt.Printf("  %s:%d -> %d\n", port.Address, port.Local, port.Remote)
 	}
 
-	// Wait for stop signal
-	<-stopChan
-
-	// Stop port forwarding
-	if err := client.PortForwardService.StopForwarding(result); err != nil {
-		fmt.Printf("Error stopping port forward: %v\n", err)
-	}
+	// Track traffic until the stop signal
+	trackPortForward(client, namespace, resourceType+"/"+resourceName, result, stopChan)
 
 	return nil
 }
@@ -60,6 +60,26 @@ func TestPortForwardCommands_Integration(t *testing.T) {
 				assert.Contains(t, output, "Error: invalid local port: invalid-port")
 			},
 		},
+		{
+			name:    "port-forward closes after idle timeout",
+			args:    []string{"pod", "nginx-default", "8083:80", "--idle-timeout", "2s"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "No traffic within the idle timeout, closing port forward")
+				assert.Contains(t, output, "localhost:8083 -> 80: 0 connections")
+			},
+		},
+		{
+			name:    "list running port forwards",
+			args:    []string{"list"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				// The forward started by the first case is still running
+				assert.Contains(t, output, "CONNECTIONS")
+				assert.Contains(t, output, "pod/nginx-default")
+				assert.Contains(t, output, "localhost:8080")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/portforward"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// sessionUpdateInterval is how often a running port forward refreshes its session file.
+// Files not refreshed for sessionStaleAfter belong to a process that exited without cleaning up.
+const (
+	sessionUpdateInterval = 2 * time.Second
+	sessionStaleAfter     = 5 * sessionUpdateInterval
+)
+
+// forwardSession describes a running port forward, shared with "port-forward list" through
+// a file in the user's cache directory
+type forwardSession struct {
+	PID       int                  `json:"pid"`
+	Context   string               `json:"context,omitempty"`
+	Namespace string               `json:"namespace"`
+	Resource  string               `json:"resource"`
+	Started   time.Time            `json:"started"`
+	Updated   time.Time            `json:"updated"`
+	Ports     []forwardSessionPort `json:"ports"`
+}
+
+// forwardSessionPort is a forwarded port with its traffic counters
+type forwardSessionPort struct {
+	Address string `json:"address"`
+	Local   uint16 `json:"local"`
+	Remote  uint16 `json:"remote"`
+	portforward.ForwardStats
+}
+
+// forwardSessionDir returns the directory holding the session files
+func forwardSessionDir() (string, error) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find cache directory: %w", err)
+	}
+	return filepath.Join(cache, "k8stool", "port-forwards"), nil
+}
+
+// path returns the session file of the process
+func (s *forwardSession) path() (string, error) {
+	dir, err := forwardSessionDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("%d.json", s.PID)), nil
+}
+
+// update refreshes the traffic counters of the session
+func (s *forwardSession) update(ports []portforward.ForwardedPort) {
+	s.Updated = time.Now()
+	s.Ports = s.Ports[:0]
+	for _, port := range ports {
+		s.Ports = append(s.Ports, forwardSessionPort{
+			Address:      port.Address,
+			Local:        port.Local,
+			Remote:       port.Remote,
+			ForwardStats: port.Stats,
+		})
+	}
+}
+
+// save writes the session file, replacing it atomically so readers never see a partial file
+func (s *forwardSession) save() error {
+	path, err := s.path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// remove deletes the session file
+func (s *forwardSession) remove() {
+	if path, err := s.path(); err == nil {
+		os.Remove(path)
+	}
+}
+
+// listForwardSessions returns the running port forwards, removing files left behind by
+// processes that no longer run
+func listForwardSessions() ([]forwardSession, error) {
+	dir, err := forwardSessionDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read session directory: %w", err)
+	}
+
+	var sessions []forwardSession
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var session forwardSession
+		if err := json.Unmarshal(data, &session); err != nil || time.Since(session.Updated) > sessionStaleAfter {
+			os.Remove(path)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Started.Before(sessions[j].Started)
+	})
+	return sessions, nil
+}
+
+// trackPortForward publishes the session and its traffic until stopChan is closed or the idle
+// timeout expires, then stops the forward and prints the traffic it carried
+func trackPortForward(client *k8s.Client, namespace, resource string, result *portforward.PortForwardResult, stopChan <-chan struct{}) {
+	session := &forwardSession{
+		PID:       os.Getpid(),
+		Namespace: namespace,
+		Resource:  resource,
+		Started:   time.Now(),
+	}
+	if currentCtx, err := client.ContextService.GetCurrent(); err == nil {
+		session.Context = currentCtx.Name
+	}
+	defer session.remove()
+
+	ticker := time.NewTicker(sessionUpdateInterval)
+	defer ticker.Stop()
+	for running := true; running; {
+		session.update(client.PortForwardService.GetStats(result))
+		if err := session.save(); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+
+		select {
+		case <-stopChan:
+			running = false
+		case <-result.Idle:
+			fmt.Println("\nNo traffic within the idle timeout, closing port forward")
+			running = false
+		case <-ticker.C:
+		}
+	}
+
+	// Stop port forwarding
+	ports := client.PortForwardService.GetStats(result)
+	if err := client.PortForwardService.StopForwarding(result); err != nil {
+		fmt.Printf("Error stopping port forward: %v\n", err)
+	}
+
+	fmt.Println("Traffic:")
+	for _, port := range ports {
+		fmt.Printf("  %s:%d -> %d: %d connections, %s in, %s out\n", port.Address, port.Local, port.Remote,
+			port.Stats.TotalConnections, utils.FormatBytes(port.Stats.BytesIn), utils.FormatBytes(port.Stats.BytesOut))
+	}
+}
+
+func getPortForwardListCmd() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List running port forwards and their traffic",
+		Long: `List the port forwards started by k8stool on this machine, with the connections and bytes
+each forwarded port has carried. Use it to confirm whether a tunnel is actually in use.
+
+Examples:
+  # List running port forwards
+  k8stool port-forward list
+
+  # Output as JSON
+  k8stool pf list -o json`,
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Sessions are read from local files, no cluster connection needed
+			return prepareCommand(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != "table" && outputFormat != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", outputFormat)
+			}
+
+			sessions, err := listForwardSessions()
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == "json" {
+				if sessions == nil {
+					sessions = []forwardSession{}
+				}
+				return printJSON(sessions)
+			}
+
+			if len(sessions) == 0 {
+				fmt.Println("No port forwards running")
+				return nil
+			}
+
+			w := newTable(os.Stdout)
+			fmt.Fprintln(w, "PID\tNAMESPACE\tRESOURCE\tLOCAL\tREMOTE\tACTIVE\tCONNECTIONS\tIN\tOUT\tAGE")
+			for _, session := range sessions {
+				for _, port := range session.Ports {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s:%d\t%d\t%d\t%d\t%s\t%s\t%s\n",
+						session.PID,
+						session.Namespace,
+						session.Resource,
+						port.Address, port.Local,
+						port.Remote,
+						port.ActiveConnections,
+						port.TotalConnections,
+						utils.FormatBytes(port.BytesIn),
+						utils.FormatBytes(port.BytesOut),
+						formatSince(session.Started),
+					)
+				}
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table|json)")
+	markQueryable(cmd)
+
+	return cmd
+}
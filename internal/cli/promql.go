@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// prometheusFlags are the flags choosing the Prometheus a command queries, overriding the
+// prometheus section of the config file
+type prometheusFlags struct {
+	url     string
+	service string
+}
+
+func (f *prometheusFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.url, "prometheus-url", "", "Base URL of the Prometheus HTTP API")
+	cmd.Flags().StringVar(&f.service, "prometheus-service", "", "Prometheus service as NAMESPACE/NAME[:PORT], reached through the API server")
+}
+
+// source returns the Prometheus to query: the flags when given, the config file otherwise
+func (f *prometheusFlags) source() (k8s.PrometheusSource, error) {
+	if f.url != "" && f.service != "" {
+		return k8s.PrometheusSource{}, errs.Validationf("--prometheus-url and --prometheus-service cannot be used together")
+	}
+	if f.url != "" || f.service != "" {
+		return k8s.PrometheusSource{URL: f.url, Service: f.service}, nil
+	}
+	source := userConfig.Prometheus
+	if source.IsZero() {
+		return source, errs.Validationf("no Prometheus configured: set prometheus.url or prometheus.service in %s, or pass --prometheus-url or --prometheus-service", userConfigPath)
+	}
+	if source.URL != "" && source.Service != "" {
+		return source, errs.Validationf("invalid prometheus section in %s: set either url or service", userConfigPath)
+	}
+	return source, nil
+}
+
+func getPromQLCmd() *cobra.Command {
+	var prom prometheusFlags
+	var at string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "promql EXPR",
+		Short: "Run a Prometheus instant query",
+		Long: `Run a PromQL instant query against the Prometheus configured in the config file, or given
+with --prometheus-url or --prometheus-service.
+
+A service is reached through the API server proxy, so Prometheus running in the cluster can be
+queried without a port forward. Range vectors show the last value of each series.
+
+Examples:
+  # Pods restarting the most over the last hour
+  k8stool promql 'topk(5, increase(kube_pod_container_status_restarts_total[1h]))'
+
+  # Query an in-cluster Prometheus without configuring it
+  k8stool promql 'up == 0' --prometheus-service monitoring/prometheus-operated
+
+  # Evaluate a query at a past time, as JSON
+  k8stool promql 'sum(rate(http_requests_total[5m]))' --time 2025-01-20T03:00:00Z -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			source, err := prom.source()
+			if err != nil {
+				return err
+			}
+			opts := k8s.PromQueryOptions{Source: source, Expr: args[0]}
+			if at != "" {
+				if opts.Time, err = time.Parse(time.RFC3339, at); err != nil {
+					return errs.Validationf("invalid time %q: must be RFC3339, such as 2025-01-20T03:00:00Z", at)
+				}
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			result, err := client.PromQuery(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(result)
+			}
+			for _, warning := range result.Warnings {
+				fmt.Fprintln(os.Stderr, utils.Yellow("Warning: "+warning))
+			}
+			printPromResult(result)
+			return nil
+		},
+	}
+
+	prom.register(cmd)
+	cmd.Flags().StringVar(&at, "time", "", "Evaluate the query at this time (RFC3339) instead of now")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+// printPromResult prints the series of a query result with their values
+func printPromResult(result *k8s.PromQueryResult) {
+	if len(result.Samples) == 0 {
+		fmt.Println("No data")
+		return
+	}
+	if result.ResultType == "scalar" || result.ResultType == "string" {
+		fmt.Println(result.Samples[0].Value)
+		return
+	}
+
+	w := newTable(os.Stdout)
+	defer w.Flush()
+	fmt.Fprintln(w, "METRIC\tVALUE")
+	for _, sample := range result.Samples {
+		fmt.Fprintf(w, "%s\t%s\n", formatMetric(sample.Metric), sample.Value)
+	}
+}
+
+// formatMetric formats the labels of a series the way Prometheus does, metric name first
+func formatMetric(labels map[string]string) string {
+	var pairs []string
+	for name, value := range labels {
+		if name != "__name__" {
+			pairs = append(pairs, fmt.Sprintf("%s=%q", name, value))
+		}
+	}
+	sort.Strings(pairs)
+	return labels["__name__"] + "{" + strings.Join(pairs, ", ") + "}"
+}
+
+func getMetricsHTTPCmd() *cobra.Command {
+	var namespace string
+	var prom prometheusFlags
+	var window string
+	var profile string
+	var showQueries bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "http (deploy|sts|ds)/NAME",
+		Short: "Show the request rate, error rate and latency of a workload",
+		Long: `Show the request rate, share of 5xx responses and p50, p95 and p99 latency of a workload,
+queried from Prometheus.
+
+The metrics are taken from the istio sidecars (istio_requests_total) when the workload is in
+the mesh, and otherwise from http_requests_total and http_request_duration_seconds as exported
+by the Prometheus client libraries, selected by the namespace and pod labels. Use --profile to
+pick one, and --show-queries to see the PromQL behind each value.
+
+Examples:
+  # Request rate, errors and latency of the web deployment over the last 5 minutes
+  k8stool metrics http deploy/web -n shop
+
+  # Over the last hour, with the queries used
+  k8stool metrics http deploy/web --window 1h --show-queries
+
+  # Read the client library metrics even though the workload is in the mesh
+  k8stool metrics http sts/api --profile http`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			resourceType, name, found := strings.Cut(args[0], "/")
+			kind := imageWorkloadKinds[strings.ToLower(resourceType)]
+			if !found || kind == "" || name == "" {
+				return errs.Validationf("invalid workload %q: must be deploy/NAME, sts/NAME or ds/NAME", args[0])
+			}
+			source, err := prom.source()
+			if err != nil {
+				return err
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			panel, err := client.WorkloadHTTPPanel(context.Background(), k8s.HTTPPanelOptions{
+				Source:    source,
+				Namespace: namespace,
+				Kind:      kind,
+				Name:      name,
+				Window:    window,
+				Profile:   k8s.PrometheusProfile(profile),
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(panel)
+			}
+			printHTTPPanel(panel, showQueries)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	prom.register(cmd)
+	cmd.Flags().StringVar(&window, "window", "5m", "Range rates and latencies are computed over")
+	cmd.Flags().StringVar(&profile, "profile", "auto", "Metrics to read: auto, istio or http")
+	cmd.Flags().BoolVar(&showQueries, "show-queries", false, "Show the PromQL query of each value")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+// printHTTPPanel prints the values of a panel, with its queries when asked to
+func printHTTPPanel(panel *k8s.HTTPPanel, showQueries bool) {
+	hasData := false
+	for _, row := range panel.Rows {
+		if row.Value != nil {
+			hasData = true
+		}
+	}
+	if !hasData {
+		fmt.Printf("No HTTP metrics found for %s/%s over the last %s\n", panel.Kind, panel.Name, panel.Window)
+		fmt.Println("Expected istio_requests_total or http_requests_total series for its pods; use promql to look for the metrics it exports.")
+		return
+	}
+
+	fmt.Printf("%s/%s in %s, %s metrics over %s\n\n", panel.Kind, panel.Name, panel.Namespace, panel.Profile, panel.Window)
+	w := newTable(os.Stdout)
+	defer w.Flush()
+	if showQueries {
+		fmt.Fprintln(w, "METRIC\tVALUE\tQUERY")
+	} else {
+		fmt.Fprintln(w, "METRIC\tVALUE")
+	}
+	for _, row := range panel.Rows {
+		value := "no data"
+		if row.Value != nil {
+			value = formatPanelValue(*row.Value, row.Unit)
+		}
+		if showQueries {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", row.Name, value, row.Expr)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\n", row.Name, value)
+		}
+	}
+}
+
+// formatPanelValue formats a panel value in its unit
+func formatPanelValue(value float64, unit string) string {
+	switch unit {
+	case "ratio":
+		return fmt.Sprintf("%.2f%%", value*100)
+	case "seconds":
+		if value < 1 {
+			return fmt.Sprintf("%.0fms", value*1000)
+		}
+		return fmt.Sprintf("%.2fs", value)
+	}
+	return fmt.Sprintf("%.2f %s", value, unit)
+}
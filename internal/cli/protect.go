@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"k8stool/internal/k8s/context"
+	"k8stool/internal/k8s/errs"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// mutatingAnnotation marks the commands that change the cluster, which ask for the name of a
+// protected context before running against it
+const mutatingAnnotation = "k8stool_mutating"
+
+// confirmContext is the --confirm-context flag, naming the protected context a command may
+// change without the prompt, for scripts
+var confirmContext string
+
+// protectedBanner renders the banner shown before changing a protected context
+var protectedBanner = color.New(color.FgHiWhite, color.BgRed, color.Bold).SprintFunc()
+
+// markMutating makes the command confirm the context before changing a protected one
+func markMutating(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[mutatingAnnotation] = "true"
+}
+
+// guardMutating confirms the context before a command marked as mutating runs. Dry runs and
+// simulations change nothing, so they are not confirmed.
+func guardMutating(cmd *cobra.Command) error {
+	if cmd.Annotations[mutatingAnnotation] == "" {
+		return nil
+	}
+	for _, name := range []string{"dry-run", "simulate"} {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Value.String() == "true" {
+			return nil
+		}
+	}
+	return confirmProtectedContext(cmd.CommandPath())
+}
+
+// protectedContext returns the current context when the config file lists it as protected,
+// and an empty name otherwise
+func protectedContext() (string, error) {
+	if len(userConfig.ProtectedContexts) == 0 || fromSnapshot != "" {
+		return "", nil
+	}
+	contextService, err := context.NewContextOnlyService()
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize context service: %w", err)
+	}
+	current, err := contextService.GetCurrent()
+	if err != nil {
+		return "", err
+	}
+	if slices.Contains(userConfig.ProtectedContexts, current.Name) {
+		return current.Name, nil
+	}
+	return "", nil
+}
+
+// confirmProtectedContext shows a banner when the current context is protected and has the
+// user type its name before the command changes it, so a change meant for another cluster is
+// caught. --confirm-context gives the name up front.
+func confirmProtectedContext(command string) error {
+	name, err := protectedContext()
+	if err != nil || name == "" {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, protectedBanner(" PROTECTED CONTEXT "+name+" "))
+	fmt.Fprintf(os.Stderr, "%s changes the cluster of a protected context\n", command)
+
+	if confirmContext != "" {
+		if confirmContext != name {
+			return errs.Validationf("--confirm-context %q does not match the current context %q", confirmContext, name)
+		}
+		return nil
+	}
+
+	prompt := promptui.Prompt{
+		Label: fmt.Sprintf("Type %s to continue", name),
+	}
+	answer, err := prompt.Run()
+	if err != nil {
+		if errors.Is(err, promptui.ErrInterrupt) {
+			return fmt.Errorf("aborted")
+		}
+		return fmt.Errorf("confirmation failed (use --confirm-context %s to skip it): %w", name, err)
+	}
+	if strings.TrimSpace(answer) != name {
+		return fmt.Errorf("aborted: %q is not the name of the context", strings.TrimSpace(answer))
+	}
+	return nil
+}
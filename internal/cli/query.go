@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8stool/internal/k8s/errs"
+
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+)
+
+// queryableAnnotation marks the output flag of commands whose JSON output accepts --query
+const queryableAnnotation = "k8stool_queryable"
+
+// query is the jq expression given with --query, compiled by prepareQuery
+var (
+	query     string
+	queryCode *gojq.Code
+)
+
+// markQueryable lets --query be used with the command; it switches -o to json unless set explicitly
+func markQueryable(cmd *cobra.Command) {
+	cmd.Flags().SetAnnotation("output", queryableAnnotation, []string{"true"})
+}
+
+// prepareQuery compiles --query and switches the command to JSON output
+func prepareQuery(cmd *cobra.Command) error {
+	if query == "" {
+		return nil
+	}
+
+	output := cmd.Flags().Lookup("output")
+	if output == nil || output.Annotations[queryableAnnotation] == nil {
+		return errs.Validationf("--query is not supported by %q: it has no JSON output", cmd.CommandPath())
+	}
+	if !output.Changed {
+		if err := output.Value.Set("json"); err != nil {
+			return err
+		}
+	} else if output.Value.String() != "json" {
+		return errs.Validationf("--query requires -o json")
+	}
+
+	parsed, err := gojq.Parse(query)
+	if err != nil {
+		return errs.Validationf("invalid query: %v", err)
+	}
+	queryCode, err = gojq.Compile(parsed)
+	if err != nil {
+		return errs.Validationf("invalid query: %v", err)
+	}
+	return nil
+}
+
+// printJSON writes v as indented JSON, or each result of --query applied to it.
+// String results are printed without quotes, like jq -r.
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	if queryCode == nil {
+		fmt.Println(string(data))
+		return nil
+	}
+
+	// gojq works on plain maps and slices, so round-trip through JSON
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return fmt.Errorf("failed to decode output: %w", err)
+	}
+
+	iter := queryCode.Run(input)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if err, ok := result.(error); ok {
+			if err, ok := err.(*gojq.HaltError); ok && err.Value() == nil {
+				return nil
+			}
+			return errs.Validationf("query failed: %v", err)
+		}
+		if s, ok := result.(string); ok {
+			fmt.Println(s)
+			continue
+		}
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode query result: %w", err)
+		}
+		fmt.Println(string(out))
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryFlag_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and the query state and restore them after tests
+	oldStdout := os.Stdout
+	defer func() {
+		os.Stdout = oldStdout
+		query = ""
+		queryCode = nil
+	}()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "query switches to json output",
+			args:    []string{"tree", "deploy/nginx-deploy", "-n", "integration-test", "--query", ".kind"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Equal(t, "Deployment\n", output)
+			},
+		},
+		{
+			name:    "query with projection",
+			args:    []string{"get", "pods", "-n", "default", "--query", `.[] | select(.Name == "nginx-default") | {name: .Name}`},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `"name": "nginx-default"`)
+			},
+		},
+		{
+			name:     "query with table output",
+			args:     []string{"tree", "deploy/nginx-deploy", "-n", "integration-test", "-o", "table", "--query", ".kind"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+		{
+			name:     "invalid query",
+			args:     []string{"tree", "deploy/nginx-deploy", "-n", "integration-test", "--query", ".["},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Run through the root command so the persistent --query flag is parsed
+			cmd := rootCmd
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+			query = ""
+			queryCode = nil
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getQuotaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quota",
+		Short: "Plan against resource quotas",
+		Long:  "Plan scaling against the resource quotas of a namespace.",
+	}
+
+	cmd.AddCommand(getQuotaHeadroomCmd())
+
+	return cmd
+}
+
+func getQuotaHeadroomCmd() *cobra.Command {
+	var namespace string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "headroom DEPLOYMENT",
+		Short: "Show how many more replicas of a deployment fit under the quotas",
+		Long: `Show how many more replicas of a deployment fit under the resource quotas of its namespace
+before new pods are rejected, so a scale-up can be checked before it is made.
+
+Each pod of the deployment is counted as the quota admission does: requests and limits are
+taken from the pod template with the defaults of the namespace's limit ranges applied, init
+containers and pod overhead included. For every quota resource a pod consumes the remaining
+amount (hard minus used) is divided by the per-pod amount; the smallest result is the
+headroom. Quotas whose scopes or priority class selector do not match the pods are skipped.
+
+A rolling update creates up to maxSurge extra pods, which need room under the quota too, so
+the surge is subtracted in the advice.
+
+Examples:
+  # Headroom of a deployment
+  k8stool quota headroom checkout -n shop
+
+  # As JSON
+  k8stool quota headroom checkout -n shop -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			headroom, err := client.QuotaHeadroom(context.Background(), namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(headroom)
+			}
+			return printQuotaHeadroom(headroom)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printQuotaHeadroom(h *k8s.QuotaHeadroom) error {
+	fmt.Printf("Deployment %s/%s: %d replicas\n", h.Namespace, h.Deployment, h.Replicas)
+
+	if len(h.PerReplica) > 0 {
+		names := make([]string, 0, len(h.PerReplica))
+		for name := range h.PerReplica {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		parts := make([]string, len(names))
+		for i, name := range names {
+			parts[i] = name + "=" + h.PerReplica[name]
+		}
+		fmt.Printf("Per replica: %s\n", strings.Join(parts, ", "))
+	}
+
+	if len(h.Resources) > 0 {
+		fmt.Println()
+		w := newTable(os.Stdout)
+		fmt.Fprintln(w, "QUOTA\tRESOURCE\tUSED\tHARD\tPER REPLICA\tFITS")
+		for _, r := range h.Resources {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n", r.Quota, r.Resource, r.Used, r.Hard, r.PerReplica, r.Fits)
+		}
+		w.Flush()
+	}
+
+	fmt.Println()
+	switch {
+	case len(h.Issues) > 0:
+		for _, issue := range h.Issues {
+			fmt.Println(utils.Red("✗ " + issue))
+		}
+		fmt.Println("No more replicas can be created until the pods set the missing resources.")
+	case !h.Limited:
+		fmt.Println("No resource quota limits the pods of this deployment.")
+	case h.Additional == 0:
+		fmt.Println(utils.Red(fmt.Sprintf("No more replicas fit: %s is used up.", h.LimitedBy)))
+	default:
+		fmt.Printf("%d more replicas fit (up to %d), limited by %s.\n", h.Additional, int64(h.Replicas)+h.Additional, h.LimitedBy)
+	}
+
+	if h.Limited && len(h.Issues) == 0 && h.MaxSurge > 0 {
+		safe := max(h.Additional-int64(h.MaxSurge), 0)
+		fmt.Println(utils.Yellow(fmt.Sprintf("Rolling updates create up to %d extra pods; to keep them unblocked add at most %d replicas (up to %d).",
+			h.MaxSurge, safe, int64(h.Replicas)+safe)))
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/recommend"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getRecommendCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var selector string
+	var samples int
+	var interval time.Duration
+	var headroom int
+	var onlyChanges bool
+
+	cmd := &cobra.Command{
+		Use:     "recommend",
+		Aliases: []string{"rightsize"},
+		Short:   "Suggest right-sized resource requests and limits",
+		Long: `Compare observed usage from the metrics server against container requests and limits
+and suggest adjusted values per workload container.
+
+Usage is sampled one or more times and the peak is used. Suggested requests are the peak
+usage plus the configured headroom. Memory limits are raised when usage runs close to them.
+
+Requires metrics-server to be installed in the cluster.
+
+Examples:
+  # Recommendations for the current namespace
+  k8stool recommend
+
+  # Sample usage 10 times, 30 seconds apart, across all namespaces
+  k8stool recommend -A --samples 10 --interval 30s
+
+  # Only show containers whose configuration should change
+  k8stool recommend -n my-namespace --changes-only`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			if samples > 1 {
+				fmt.Fprintf(os.Stderr, "Collecting %d samples over %s...\n", samples, time.Duration(samples-1)*interval)
+			}
+
+			report, err := client.Recommend(ctx, k8s.RecommendOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+				Selector:      selector,
+				Samples:       samples,
+				Interval:      interval,
+				Headroom:      float64(headroom) / 100,
+			})
+			if err != nil {
+				return err
+			}
+
+			return printRecommendations(report, onlyChanges)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Recommend across all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().IntVar(&samples, "samples", 1, "Number of usage samples to take; the peak is used")
+	cmd.Flags().DurationVar(&interval, "interval", 30*time.Second, "Time between usage samples")
+	cmd.Flags().IntVar(&headroom, "headroom", 20, "Percentage added on top of peak usage")
+	cmd.Flags().BoolVar(&onlyChanges, "changes-only", false, "Only show containers whose configuration should change")
+
+	return cmd
+}
+
+func printRecommendations(report *recommend.Report, onlyChanges bool) error {
+	w := newTable(os.Stdout)
+
+	fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tCONTAINER\tPODS\tCPU USE\tCPU REQ\tSUGGESTED\tMEM USE\tMEM REQ/LIM\tSUGGESTED\tVERDICT")
+	for _, r := range report.Recommendations {
+		if onlyChanges && r.CPU.Verdict == recommend.OK && r.Memory.Verdict == recommend.OK {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Namespace,
+			r.Workload,
+			r.Container,
+			r.Pods,
+			utils.FormatMilliCPU(r.CPU.Usage),
+			formatRequest(r.CPU.Request, utils.FormatMilliCPU),
+			utils.FormatMilliCPU(r.CPU.SuggestedRequest),
+			utils.FormatBytes(r.Memory.Usage),
+			formatRequest(r.Memory.Request, utils.FormatBytes)+"/"+formatRequest(r.Memory.Limit, utils.FormatBytes),
+			utils.FormatBytes(r.Memory.SuggestedRequest)+"/"+formatRequest(r.Memory.SuggestedLimit, utils.FormatBytes),
+			formatVerdicts(r),
+		)
+	}
+	w.Flush()
+
+	s := report.Summary
+	fmt.Printf("\nCPU requested: %s, suggested: %s\n", utils.FormatMilliCPU(s.CPURequested), utils.FormatMilliCPU(s.CPUSuggested))
+	fmt.Printf("Memory requested: %s, suggested: %s\n", utils.FormatBytes(s.MemoryRequested), utils.FormatBytes(s.MemorySuggested))
+	fmt.Printf("%s over-provisioned, %s under-provisioned, %s missing requests\n",
+		utils.Yellow(s.Over), utils.Red(s.Under), utils.Yellow(s.Missing))
+
+	return nil
+}
+
+func formatRequest(v int64, format func(int64) string) string {
+	if v == 0 {
+		return "<none>"
+	}
+	return format(v)
+}
+
+func formatVerdicts(r recommend.Recommendation) string {
+	colorize := func(v recommend.Verdict) string {
+		switch v {
+		case recommend.OK:
+			return utils.Green(string(v))
+		case recommend.UnderProvisioned:
+			return utils.Red(string(v))
+		default:
+			return utils.Yellow(string(v))
+		}
+	}
+	if r.CPU.Verdict == r.Memory.Verdict {
+		return colorize(r.CPU.Verdict)
+	}
+	return fmt.Sprintf("cpu:%s mem:%s", colorize(r.CPU.Verdict), colorize(r.Memory.Verdict))
+}
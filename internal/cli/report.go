@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var reportFuncs = map[string]any{
+	"cpu":    utils.FormatMilliCPU,
+	"memory": utils.FormatBytes,
+	"join":   strings.Join,
+	"time":   func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+	"ago": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return formatSince(t)
+	},
+	"percent": func(p int) string {
+		if p < 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%d%%", p)
+	},
+	"status": func(ready, unschedulable bool) string {
+		status := "NotReady"
+		if ready {
+			status = "Ready"
+		}
+		if unschedulable {
+			status += ",SchedulingDisabled"
+		}
+		return status
+	},
+	// cell escapes text for a markdown table cell
+	"cell": func(s string) string {
+		return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+	},
+}
+
+const markdownReport = `# Cluster report: {{.Context}}
+
+Generated {{time .GeneratedAt}}{{if .ServerVersion}}, Kubernetes {{.ServerVersion}}{{end}}.
+
+## Nodes
+
+| Name | Roles | Status | Version | CPU | Memory | Pods |
+|------|-------|--------|---------|-----|--------|------|
+{{range .Nodes}}| {{.Name}} | {{.Roles}} | {{status .Ready .Unschedulable}} | {{.KubeletVersion}} | {{cpu .CPUAllocatable}} | {{memory .MemoryAllocatable}} | {{.Pods}}/{{.PodsAllocatable}} |
+{{end}}
+## Workloads
+
+| Namespace | Deployments | StatefulSets | DaemonSets | CronJobs | Jobs | Pods (running) |
+|-----------|-------------|--------------|------------|----------|------|----------------|
+{{range .Namespaces}}| {{.Namespace}} | {{.Deployments}} | {{.StatefulSets}} | {{.DaemonSets}} | {{.CronJobs}} | {{.Jobs}} | {{.Pods}} ({{.RunningPods}}) |
+{{end}}
+## Images
+
+| Image | Version | Pods | Namespaces |
+|-------|---------|------|------------|
+{{range .Images}}| {{.Repository}} | {{cell .Version}} | {{.Pods}} | {{join .Namespaces ", "}} |
+{{end}}
+## Quota usage
+{{if .Quotas}}
+| Namespace | Quota | Resource | Used | Hard | Usage |
+|-----------|-------|----------|------|------|-------|
+{{range .Quotas}}| {{.Namespace}} | {{.Quota}} | {{.Resource}} | {{.Used}} | {{.Hard}} | {{percent .Percent}} |
+{{end}}{{else}}
+No resource quotas.
+{{end}}
+## Warning events
+{{if .Warnings}}
+| Namespace | Reason | Kind | Count | Last seen | Latest message |
+|-----------|--------|------|-------|-----------|----------------|
+{{range .Warnings}}| {{.Namespace}} | {{.Reason}} | {{.Kind}} | {{.Count}} | {{ago .LastSeen}} | {{cell .Message}} |
+{{end}}{{else}}
+No warning events.
+{{end}}`
+
+const htmlReport = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Cluster report: {{.Context}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #d0d7de; padding: 4px 10px; text-align: left; }
+th { background: #f6f8fa; }
+.bad { color: #cf222e; }
+</style>
+</head>
+<body>
+<h1>Cluster report: {{.Context}}</h1>
+<p>Generated {{time .GeneratedAt}}{{if .ServerVersion}}, Kubernetes {{.ServerVersion}}{{end}}.</p>
+
+<h2>Nodes</h2>
+<table>
+<tr><th>Name</th><th>Roles</th><th>Status</th><th>Version</th><th>CPU</th><th>Memory</th><th>Pods</th></tr>
+{{range .Nodes}}<tr><td>{{.Name}}</td><td>{{.Roles}}</td><td{{if not .Ready}} class="bad"{{end}}>{{status .Ready .Unschedulable}}</td><td>{{.KubeletVersion}}</td><td>{{cpu .CPUAllocatable}}</td><td>{{memory .MemoryAllocatable}}</td><td>{{.Pods}}/{{.PodsAllocatable}}</td></tr>
+{{end}}</table>
+
+<h2>Workloads</h2>
+<table>
+<tr><th>Namespace</th><th>Deployments</th><th>StatefulSets</th><th>DaemonSets</th><th>CronJobs</th><th>Jobs</th><th>Pods (running)</th></tr>
+{{range .Namespaces}}<tr><td>{{.Namespace}}</td><td>{{.Deployments}}</td><td>{{.StatefulSets}}</td><td>{{.DaemonSets}}</td><td>{{.CronJobs}}</td><td>{{.Jobs}}</td><td>{{.Pods}} ({{.RunningPods}})</td></tr>
+{{end}}</table>
+
+<h2>Images</h2>
+<table>
+<tr><th>Image</th><th>Version</th><th>Pods</th><th>Namespaces</th></tr>
+{{range .Images}}<tr><td>{{.Repository}}</td><td>{{.Version}}</td><td>{{.Pods}}</td><td>{{join .Namespaces ", "}}</td></tr>
+{{end}}</table>
+
+<h2>Quota usage</h2>
+{{if .Quotas}}<table>
+<tr><th>Namespace</th><th>Quota</th><th>Resource</th><th>Used</th><th>Hard</th><th>Usage</th></tr>
+{{range .Quotas}}<tr><td>{{.Namespace}}</td><td>{{.Quota}}</td><td>{{.Resource}}</td><td>{{.Used}}</td><td>{{.Hard}}</td><td{{if ge .Percent 90}} class="bad"{{end}}>{{percent .Percent}}</td></tr>
+{{end}}</table>
+{{else}}<p>No resource quotas.</p>
+{{end}}
+<h2>Warning events</h2>
+{{if .Warnings}}<table>
+<tr><th>Namespace</th><th>Reason</th><th>Kind</th><th>Count</th><th>Last seen</th><th>Latest message</th></tr>
+{{range .Warnings}}<tr><td>{{.Namespace}}</td><td>{{.Reason}}</td><td>{{.Kind}}</td><td>{{.Count}}</td><td>{{ago .LastSeen}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>
+{{else}}<p>No warning events.</p>
+{{end}}</body>
+</html>
+`
+
+var (
+	markdownReportTemplate = template.Must(template.New("report").Funcs(reportFuncs).Parse(markdownReport))
+	htmlReportTemplate     = htmltemplate.Must(htmltemplate.New("report").Funcs(reportFuncs).Parse(htmlReport))
+)
+
+func getReportCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Generate a cluster inventory report",
+		Long: `Generate a shareable snapshot of the cluster as Markdown or HTML, e.g. for handovers and audits.
+
+The report contains node capacity and status, workloads per namespace, container images and
+their versions, resource quota usage and a summary of warning events. Nodes are always
+reported for the whole cluster; the other sections cover the selected namespaces.
+
+Examples:
+  # Markdown report of the current namespace
+  k8stool report
+
+  # HTML report of the whole cluster
+  k8stool report -A --format html > report.html`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "markdown" && format != "html" {
+				return errs.Validationf("unsupported format %q, use markdown or html", format)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			inventory, err := client.Inventory(context.Background(), k8s.ReportOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+			})
+			if err != nil {
+				return err
+			}
+			if current, err := client.GetCurrentContext(); err == nil {
+				inventory.Context = current.Name
+			}
+
+			if format == "html" {
+				return htmlReportTemplate.Execute(os.Stdout, inventory)
+			}
+			return markdownReportTemplate.Execute(os.Stdout, inventory)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Report on all namespaces")
+	cmd.Flags().StringVar(&format, "format", "markdown", "Report format: markdown or html")
+
+	return cmd
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func getRestartCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var selector string
+	var yes bool
+	var opts resources.RestartOptions
+
+	cmd := &cobra.Command{
+		Use:   "restart TYPE (NAME... | -l SELECTOR)",
+		Short: "Restart workloads or pods by name or by selector",
+		Long: `Restart deployments, statefulsets, daemonsets or pods.
+
+Workloads roll out new pods the way kubectl rollout restart does, respecting their update
+strategy. Pods are deleted so their controller replaces them; pods without a controller are
+refused since they would not come back.
+
+With a selector the matching objects are listed first and the restart only goes ahead once
+their number is typed back, or with --yes. Objects are restarted --concurrency at a time.
+
+Examples:
+  # Restart a deployment
+  k8stool restart deploy web
+
+  # Restart every pod of an app, replacing up to 10 at a time
+  k8stool restart pods -l app=web --concurrency 10
+
+  # Restart matching deployments in all namespaces without confirmation
+  k8stool restart deploy -l team=payments -A --yes`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 1) == (selector == "") {
+				return errs.Validationf("specify either resource names or a selector with -l")
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			var objs []*unstructured.Unstructured
+			if selector != "" {
+				objs, err = client.ListResources(ctx, args[0], resources.ListOptions{
+					Namespace:     namespace,
+					AllNamespaces: allNamespaces,
+					Selector:      selector,
+				})
+			} else {
+				objs, _, err = getNamedResources(ctx, client, args[0], namespace, args[1:], false)
+			}
+			if err != nil {
+				return err
+			}
+			if len(objs) == 0 {
+				fmt.Println("No resources found")
+				return nil
+			}
+
+			if selector != "" && !yes && !opts.DryRun {
+				ok, err := confirmSelection("Restart", objs)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("aborted")
+				}
+			}
+
+			results, err := client.Restart(ctx, objs, opts)
+			printResults(results, opts.DryRun)
+			if err != nil {
+				return err
+			}
+			return resultsError(results)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Match the selector across all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", resources.DefaultConcurrency, "Number of objects restarted at a time")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Submit a server-side dry run without restarting anything")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	markMutating(cmd)
+
+	return cmd
+}
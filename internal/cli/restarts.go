@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/restarts"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// restartTimeLayout is how the samples of the restart history are timestamped
+const restartTimeLayout = "2006-01-02 15:04"
+
+func getRestartsCmd() *cobra.Command {
+	var namespace string
+	var since time.Duration
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "restarts TYPE/NAME",
+		Short: "Show how many times the containers of a workload restarted recently",
+		Long: `Show the container restarts of a deployment, statefulset or daemonset over the last --since,
+instead of the lifetime counter of its pods, to tell a crash loop going on now from one that
+settled days ago.
+
+The restart counters are recorded on every run in restarts.json under the user cache
+directory (~/.cache/k8stool on Linux), and the restarts are the increase of the counters
+since the last sample before --since. The first run only records the counters; run it again
+later, or from cron for a finer history. Samples are kept for 30 days.
+
+Restarts of pods deleted since the last sample they were in are not counted, and pods
+created since the previous sample count all their restarts.
+
+Examples:
+  # Restarts of the web deployment over the last 24 hours
+  k8stool restarts deploy/web
+
+  # Over the last hour, for a statefulset in another namespace
+  k8stool restarts sts/postgres -n db --since 1h`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			resourceType, name, found := strings.Cut(args[0], "/")
+			kind := imageWorkloadKinds[strings.ToLower(resourceType)]
+			if !found || kind == "" || name == "" {
+				return errs.Validationf("invalid workload %q: must be deploy/NAME, sts/NAME or ds/NAME", args[0])
+			}
+			if since <= 0 {
+				return errs.Validationf("invalid --since %s: must be positive", since)
+			}
+			if fromSnapshot != "" {
+				return errs.Validationf("cannot record restarts from a snapshot")
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+			current, err := client.GetCurrentContext()
+			if err != nil {
+				return err
+			}
+
+			sample, err := client.SampleRestarts(context.Background(), k8s.RestartSampleOptions{
+				Namespace: namespace,
+				Kind:      kind,
+				Name:      name,
+			})
+			if err != nil {
+				return err
+			}
+
+			path, err := restarts.DefaultPath()
+			if err != nil {
+				return err
+			}
+			history, err := restarts.Load(path)
+			if err != nil {
+				return err
+			}
+			key := restarts.Key(current.Name, namespace, kind, name)
+			history.Record(key, *sample)
+			if err := restarts.Save(path, history); err != nil {
+				return err
+			}
+
+			report := restarts.NewReport(history[key], sample.Time.Add(-since))
+			report.Context, report.Namespace, report.Kind, report.Name = current.Name, namespace, kind, name
+
+			if output == "json" {
+				return printJSON(report)
+			}
+			printRestartReport(report, since)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().DurationVar(&since, "since", 24*time.Hour, "Period to count restarts over")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+// printRestartReport prints the restarts between samples, with consecutive samples without
+// restarts merged into one row, and the containers that restarted
+func printRestartReport(report *restarts.Report, since time.Duration) {
+	workload := fmt.Sprintf("%s/%s in %s", report.Kind, report.Name, report.Namespace)
+	if report.Samples < 2 {
+		fmt.Printf("First sample of %s recorded: %d restarts over the lifetime of its pods.\n", workload, report.Lifetime)
+		fmt.Println("Run this command again later to see the restarts since now.")
+		return
+	}
+
+	fmt.Printf("Restarts of %s over the last %s: %s (lifetime counters of its pods: %d)\n",
+		workload, utils.FormatDuration(since), formatRestarts(report.Restarts), report.Lifetime)
+	if report.From.After(report.Since) {
+		fmt.Printf("The history only goes back to %s, %s\n", formatTimestamp(report.From, restartTimeLayout), formatAgo(report.From))
+	}
+	fmt.Println()
+
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "FROM\tUNTIL\tRESTARTS")
+	for i := 0; i < len(report.Intervals); i++ {
+		interval := report.Intervals[i]
+		for interval.Restarts == 0 && i+1 < len(report.Intervals) && report.Intervals[i+1].Restarts == 0 {
+			i++
+			interval.Until = report.Intervals[i].Until
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n",
+			formatTimestamp(interval.From, restartTimeLayout),
+			formatTimestamp(interval.Until, restartTimeLayout),
+			formatRestarts(interval.Restarts))
+	}
+	w.Flush()
+
+	var restarted []restarts.ContainerDelta
+	for _, c := range report.Containers {
+		if c.Restarts > 0 {
+			restarted = append(restarted, c)
+		}
+	}
+	if len(restarted) == 0 {
+		return
+	}
+	fmt.Println()
+	w = newTable(os.Stdout)
+	defer w.Flush()
+	fmt.Fprintln(w, "POD\tCONTAINER\tRESTARTS\tLIFETIME")
+	for _, c := range restarted {
+		pod := c.Pod
+		if c.Gone {
+			pod += " (gone)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", pod, c.Container, formatRestarts(c.Restarts), c.Lifetime)
+	}
+}
+
+// formatRestarts shows a count of restarts, yellow when there were any
+func formatRestarts(count int32) string {
+	if count > 0 {
+		return utils.Yellow(fmt.Sprintf("%d", count))
+	}
+	return "0"
+}
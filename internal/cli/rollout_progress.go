@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/pkg/utils"
+)
+
+// rolloutBarWidth is the number of cells of the rollout progress bar
+const rolloutBarWidth = 30
+
+// rolloutProgress draws a rollout as a progress bar redrawn in place on a terminal
+type rolloutProgress struct {
+	out   io.Writer
+	drawn bool
+}
+
+// update redraws the bar for the status
+func (p *rolloutProgress) update(status k8s.RolloutStatus) {
+	fmt.Fprint(p.out, "\r\033[K"+renderRolloutBar(status, time.Now()))
+	p.drawn = true
+}
+
+// println prints a line above the bar, which is drawn again on the next update
+func (p *rolloutProgress) println(line string) {
+	if p.drawn {
+		fmt.Fprint(p.out, "\r\033[K")
+		p.drawn = false
+	}
+	fmt.Fprintln(p.out, line)
+}
+
+// done leaves the last drawn bar on screen
+func (p *rolloutProgress) done() {
+	if p.drawn {
+		fmt.Fprintln(p.out)
+		p.drawn = false
+	}
+}
+
+// renderRolloutBar renders the updated and available replicas of a rollout with its ETA. Available
+// cells are filled, updated but not yet available cells are shaded.
+func renderRolloutBar(status k8s.RolloutStatus, now time.Time) string {
+	replicas := max(status.Replicas, 1)
+	updated := min(status.UpdatedReplicas, replicas)
+	available := min(status.AvailableReplicas, updated)
+
+	availableCells := int(available) * rolloutBarWidth / int(replicas)
+	updatedCells := int(updated)*rolloutBarWidth/int(replicas) - availableCells
+	bar := utils.Green(strings.Repeat("█", availableCells)) +
+		utils.Yellow(strings.Repeat("▒", updatedCells)) +
+		strings.Repeat("░", rolloutBarWidth-availableCells-updatedCells)
+
+	eta := "ETA --"
+	if remaining, ok := rolloutETA(status, now); ok {
+		eta = "ETA " + utils.FormatDuration(remaining)
+	}
+	return fmt.Sprintf("[%s] %d/%d updated, %d/%d available, %s", bar, status.UpdatedReplicas, status.Replicas,
+		available, status.Replicas, eta)
+}
+
+// rolloutETA estimates the time left in a rollout from the pace at which the pods of the new
+// revision became ready so far. There is no estimate until the first of them is ready.
+func rolloutETA(status k8s.RolloutStatus, now time.Time) (time.Duration, bool) {
+	remaining := int(status.Replicas) - len(status.ReadyTimes)
+	if remaining <= 0 {
+		return 0, true
+	}
+	if len(status.ReadyTimes) == 0 || status.StartedAt.IsZero() {
+		return 0, false
+	}
+
+	last := status.ReadyTimes[len(status.ReadyTimes)-1]
+	perPod := last.Sub(status.StartedAt) / time.Duration(len(status.ReadyTimes))
+	eta := perPod*time.Duration(remaining) - now.Sub(last)
+	if eta < 0 {
+		eta = 0
+	}
+	return eta.Round(time.Second), true
+}
@@ -2,12 +2,14 @@ package cli
 
 import (
 	"fmt"
-	"path/filepath"
+	"os"
 
+	"k8stool/internal/config"
 	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
 
 	"github.com/spf13/cobra"
-	"k8s.io/client-go/util/homedir"
 )
 
 // Version information
@@ -22,6 +24,16 @@ var (
 	kubeconfig string
 	namespace  string
 	verbose    bool
+	asUser     string
+	asGroups   []string
+
+	// fromSnapshot runs commands against a snapshot file instead of the cluster
+	fromSnapshot string
+
+	// userConfig is the user configuration file, read before every command
+	userConfig = &config.Config{}
+	// userConfigPath is where userConfig was read from
+	userConfigPath string
 )
 
 var rootCmd = &cobra.Command{
@@ -30,25 +42,87 @@ var rootCmd = &cobra.Command{
 	Long: `A CLI tool that helps you interact with Kubernetes clusters,
 allowing you to view pods, logs, deployments, and more.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Shell completion requests must work without a reachable cluster
+		if cmd.Name() == cobra.ShellCompRequestCmd {
+			return nil
+		}
+		if err := prepareCommand(cmd); err != nil {
+			return err
+		}
 		return initializeClient()
 	},
 }
 
+// prepareCommand does the setup every command needs before it runs: it reads the config file
+// and applies the impersonation, snapshot, time format, query and protected context flags.
+// Commands overriding PersistentPreRunE to skip connecting to the cluster must call it.
+func prepareCommand(cmd *cobra.Command) error {
+	if err := loadConfig(cmd); err != nil {
+		return err
+	}
+	if len(asGroups) > 0 && asUser == "" {
+		return errs.Validationf("--as-group requires --as")
+	}
+	k8s.SetImpersonation(asUser, asGroups)
+	if fromSnapshot != "" {
+		if asUser != "" {
+			return errs.Validationf("--as cannot be used with --from-snapshot")
+		}
+		k8s.SetSnapshot(fromSnapshot)
+	}
+	if err := validateTimeFormat(); err != nil {
+		return err
+	}
+	if err := prepareQuery(cmd); err != nil {
+		return err
+	}
+	return guardMutating(cmd)
+}
+
+// loadConfig reads the user configuration file, applies its theme and sets the defaults it
+// has for the flags of cmd
+func loadConfig(cmd *cobra.Command) error {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	if err := utils.ApplyTheme(cfg.Theme); err != nil {
+		return errs.New(errs.Validation, "invalid theme in %s: %v", path, err)
+	}
+	userConfig, userConfigPath = cfg, path
+	return applyDefaults(cmd)
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
+	adaptToPlugin(os.Args[0], os.Args[1:])
 	return rootCmd.Execute()
 }
 
 func init() {
 	// Initialize flags
-	if home := homedir.HomeDir(); home != "" {
-		rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", filepath.Join(home, ".kube", "config"), "path to the kubeconfig file")
-	} else {
-		rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "path to the kubeconfig file")
-	}
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "path to the kubeconfig file, instead of $KUBECONFIG or ~/.kube/config")
+	cobra.OnInitialize(applyKubeconfig)
 
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "the namespace to use")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&asUser, "as", "", "user to impersonate for the operation")
+	rootCmd.PersistentFlags().StringSliceVar(&asGroups, "as-group", nil, "group to impersonate for the operation, can be repeated")
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "do not pipe long output through a pager")
+	rootCmd.PersistentFlags().BoolVar(&utcTimes, "utc", false, "show absolute times in UTC instead of local time")
+	rootCmd.PersistentFlags().StringVar(&timeFormat, "time-format", "", "how to show ages and timestamps: relative, iso or unix")
+	rootCmd.PersistentFlags().StringVar(&fromSnapshot, "from-snapshot", "", "read from a snapshot file created with snapshot create instead of the cluster")
+	rootCmd.PersistentFlags().StringVar(&query, "query", "", "jq expression applied to the JSON output of the command")
+	rootCmd.PersistentFlags().StringVar(&confirmContext, "confirm-context", "", "name of the protected context a command may change without asking")
+
+	// Invalid flags exit with the validation exit code
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return errs.Wrap(errs.Validation, err)
+	})
 
 	// Add commands to root
 	rootCmd.AddCommand(getCmd())
@@ -60,6 +134,77 @@ func init() {
 	rootCmd.AddCommand(versionCmd())
 	rootCmd.AddCommand(getNamespaceCmd())
 	rootCmd.AddCommand(getMetricsCmd())
+	rootCmd.AddCommand(getMCPCmd())
+	rootCmd.AddCommand(getDoctorCmd())
+	rootCmd.AddCommand(getTroubleshootCmd())
+	rootCmd.AddCommand(getRecommendCmd())
+	rootCmd.AddCommand(getOrphansCmd())
+	rootCmd.AddCommand(getWhyPendingCmd())
+	rootCmd.AddCommand(getOOMReportCmd())
+	rootCmd.AddCommand(getNodeCmd())
+	rootCmd.AddCommand(getCertsCmd())
+	rootCmd.AddCommand(getLintCmd())
+	rootCmd.AddCommand(getNetTestCmd())
+	rootCmd.AddCommand(getDNSCheckCmd())
+	rootCmd.AddCommand(getApplyCmd())
+	rootCmd.AddCommand(getDeleteCmd())
+	rootCmd.AddCommand(getRestartCmd())
+	rootCmd.AddCommand(getEvictCmd())
+	rootCmd.AddCommand(getEditCmd())
+	rootCmd.AddCommand(getLabelCmd())
+	rootCmd.AddCommand(getAnnotateCmd())
+	rootCmd.AddCommand(getValidateCmd())
+	rootCmd.AddCommand(getDiffCmd())
+	rootCmd.AddCommand(getExportCmd())
+	rootCmd.AddCommand(getBackupCmd())
+	rootCmd.AddCommand(getRestoreCmd())
+	rootCmd.AddCommand(getCompareCmd())
+	rootCmd.AddCommand(getReportCmd())
+	rootCmd.AddCommand(getCanICmd())
+	rootCmd.AddCommand(getWhoCanCmd())
+	rootCmd.AddCommand(getAuditCmd())
+	rootCmd.AddCommand(getScanCmd())
+	rootCmd.AddCommand(getWatchCmd())
+	rootCmd.AddCommand(getArgoCmd())
+	rootCmd.AddCommand(getDeprecationsCmd())
+	rootCmd.AddCommand(getAPIResourcesCmd())
+	rootCmd.AddCommand(getExplainCmd())
+	rootCmd.AddCommand(getTreeCmd())
+	rootCmd.AddCommand(getSvcCmd())
+	rootCmd.AddCommand(getCleanupCmd())
+	rootCmd.AddCommand(getServeCmd())
+	rootCmd.AddCommand(getTimelineCmd())
+	rootCmd.AddCommand(getDeployCmd())
+	rootCmd.AddCommand(getUsageCmd())
+	rootCmd.AddCommand(getCostCmd())
+	rootCmd.AddCommand(getQuotaCmd())
+	rootCmd.AddCommand(getWebhooksCmd())
+	rootCmd.AddCommand(getImageCmd())
+	rootCmd.AddCommand(getSnapshotCmd())
+	rootCmd.AddCommand(getFavCmd())
+	rootCmd.AddCommand(getCronJobsCmd())
+	rootCmd.AddCommand(getJobsCmd())
+	rootCmd.AddCommand(getSetCmd())
+	rootCmd.AddCommand(getNodeDrainCmd())
+	rootCmd.AddCommand(getPromQLCmd())
+	rootCmd.AddCommand(getEventsGroupCmd())
+	rootCmd.AddCommand(getRestartsCmd())
+
+	registerCompletions(rootCmd)
+	wrapArgsErrors(rootCmd)
+}
+
+// wrapArgsErrors makes invalid arguments exit with the validation exit code, like invalid
+// flags, for cmd and all its subcommands. It must run after every command is added.
+func wrapArgsErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			return errs.Wrap(errs.Validation, validate(cmd, args))
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		wrapArgsErrors(sub)
+	}
 }
 
 // getCmd returns the get command
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/scan"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getScanCmd() *cobra.Command {
+	var namespace string
+	var output string
+	var failOn string
+	var list bool
+	var trivy string
+
+	cmd := &cobra.Command{
+		Use:   "scan TYPE/NAME",
+		Short: "Scan the images of a workload for vulnerabilities",
+		Long: `Scan every unique image of a workload with trivy and summarize the vulnerabilities
+found per container.
+
+trivy must be installed; see https://trivy.dev. Images are pulled by trivy itself, so private
+registries need credentials configured for trivy rather than the cluster.
+
+With --fail-on the command exits with an error when any container has a vulnerability of
+that severity or worse, or when an image could not be scanned, so it can gate CI pipelines.
+
+Examples:
+  # Summarize vulnerabilities of a deployment
+  k8stool scan deploy/web
+
+  # List the critical and high CVEs of a statefulset
+  k8stool scan sts/db -n prod --list
+
+  # Fail a pipeline on critical vulnerabilities
+  k8stool scan deploy/web -o json --fail-on critical`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			var threshold scan.Severity
+			if failOn != "" {
+				var err error
+				if threshold, err = scan.ParseSeverity(failOn); err != nil {
+					return err
+				}
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			report, err := client.ScanWorkload(ctx, k8s.ScanOptions{
+				Namespace: namespace,
+				Workload:  args[0],
+				Trivy:     trivy,
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				if err := printJSON(report); err != nil {
+					return err
+				}
+			} else {
+				printScanReport(report, list)
+			}
+
+			if threshold == "" {
+				return nil
+			}
+			var failed, errored int
+			for _, c := range report.Containers {
+				if c.Error != "" {
+					errored++
+				} else if c.AtLeast(threshold) > 0 {
+					failed++
+				}
+			}
+			if errored > 0 {
+				return fmt.Errorf("%d containers could not be scanned", errored)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d containers have %s or worse vulnerabilities", failed, threshold)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
+	markQueryable(cmd)
+	cmd.Flags().StringVar(&failOn, "fail-on", "", "Exit with an error when a vulnerability reaches this severity")
+	cmd.Flags().BoolVar(&list, "list", false, "List the critical and high vulnerabilities of each image")
+	cmd.Flags().StringVar(&trivy, "trivy", "trivy", "Path to the trivy binary")
+
+	return cmd
+}
+
+func printScanReport(report *scan.Report, list bool) {
+	fmt.Printf("Workload: %s/%s\n\n", report.Namespace, report.Workload)
+
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "CONTAINER\tIMAGE\tCRITICAL\tHIGH\tMEDIUM\tLOW\tUNKNOWN")
+	for _, c := range report.Containers {
+		if c.Error != "" {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", c.Container, c.Image, utils.Red(c.Error))
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%d\n",
+			c.Container,
+			c.Image,
+			scanCount(c.Counts[scan.Critical]),
+			scanCount(c.Counts[scan.High]),
+			c.Counts[scan.Medium],
+			c.Counts[scan.Low],
+			c.Counts[scan.Unknown],
+		)
+	}
+	w.Flush()
+
+	if !list {
+		return
+	}
+
+	printed := make(map[string]bool)
+	for _, c := range report.Containers {
+		if printed[c.Image] || c.AtLeast(scan.High) == 0 {
+			continue
+		}
+		printed[c.Image] = true
+
+		fmt.Printf("\n%s\n", utils.Bold(c.Image))
+		w := newTable(os.Stdout)
+		fmt.Fprintln(w, "SEVERITY\tID\tPACKAGE\tINSTALLED\tFIXED\tTITLE")
+		for _, v := range c.Vulnerabilities {
+			if v.Severity.Rank() < scan.High.Rank() {
+				continue
+			}
+			fixed := v.FixedVersion
+			if fixed == "" {
+				fixed = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				utils.Red(string(v.Severity)), v.ID, v.Package, v.InstalledVersion, fixed, utils.TruncateString(v.Title, 60))
+		}
+		w.Flush()
+	}
+}
+
+// scanCount highlights non-zero counts of serious vulnerabilities
+func scanCount(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	return utils.Red(n)
+}
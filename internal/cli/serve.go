@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/events"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func getServeCmd() *cobra.Command {
+	var listen string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve read-only cluster data as JSON over HTTP",
+		Long: `Run an HTTP server that exposes read-only endpoints for pods, deployments, events,
+metrics and describe as JSON, using the credentials of the current kubeconfig context.
+
+Endpoints:
+  GET /healthz
+  GET /api/v1/pods?namespace=NS&allNamespaces=true&selector=SEL&status=STATUS
+  GET /api/v1/deployments?namespace=NS&allNamespaces=true&selector=SEL
+  GET /api/v1/events?namespace=NS&warnings=true&kind=KIND&name=NAME&limit=N
+  GET /api/v1/metrics/pods?namespace=NS
+  GET /api/v1/metrics/nodes
+  GET /api/v1/describe/TYPE/NAME?namespace=NS
+
+The namespace defaults to the current context namespace. Errors are returned as
+{"error": "..."} with a matching HTTP status code.
+
+The server has no authentication of its own and every request runs with your
+credentials, so it listens on localhost unless told otherwise. Requests must be
+addressed to localhost, the host it listens on, or an IP address when listening on
+all interfaces, so web pages cannot reach it through a rebound domain name.
+
+Examples:
+  # Serve on localhost:8099
+  k8stool serve
+
+  # Listen on all interfaces
+  k8stool serve --listen :8099
+
+  # Query the server
+  curl localhost:8099/api/v1/pods?namespace=default`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			server := &http.Server{
+				Addr:              listen,
+				Handler:           serveHandler(client, listen),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				server.Shutdown(shutdownCtx)
+			}()
+
+			fmt.Fprintf(os.Stderr, "Serving on %s (Ctrl+C to stop)\n", listen)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("failed to serve: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&listen, "listen", "localhost:8099", "Address to listen on")
+
+	return cmd
+}
+
+// serveHandler returns the HTTP handler with all read-only endpoints for a server listening on listen
+func serveHandler(client *k8s.Client, listen string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		serveJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	mux.HandleFunc("GET /api/v1/pods", func(w http.ResponseWriter, r *http.Request) {
+		namespace, allNamespaces, err := serveNamespace(client, r)
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		q := r.URL.Query()
+		podList, err := client.PodService.List(namespace, allNamespaces, q.Get("selector"), q.Get("status"))
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		serveJSON(w, http.StatusOK, podList)
+	})
+
+	mux.HandleFunc("GET /api/v1/deployments", func(w http.ResponseWriter, r *http.Request) {
+		namespace, allNamespaces, err := serveNamespace(client, r)
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		deploymentList, err := client.DeploymentService.List(namespace, allNamespaces, r.URL.Query().Get("selector"))
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		serveJSON(w, http.StatusOK, deploymentList)
+	})
+
+	mux.HandleFunc("GET /api/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		namespace, allNamespaces, err := serveNamespace(client, r)
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		if allNamespaces {
+			namespace = ""
+		}
+		q := r.URL.Query()
+
+		filter := &events.EventFilter{SortBy: events.SortByTime, Limit: 500}
+		if q.Get("warnings") == "true" {
+			filter.Types = []events.EventType{events.Warning}
+		}
+		if kind := q.Get("kind"); kind != "" {
+			filter.ResourceKinds = []string{kind}
+		}
+		if name := q.Get("name"); name != "" {
+			filter.ResourceNames = []string{name}
+		}
+		if limit := q.Get("limit"); limit != "" {
+			n, err := strconv.Atoi(limit)
+			if err != nil || n <= 0 {
+				serveError(w, errs.Validationf("invalid limit %q", limit))
+				return
+			}
+			filter.Limit = n
+		}
+
+		eventList, err := client.EventService.List(r.Context(), namespace, filter)
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		serveJSON(w, http.StatusOK, eventList.Items)
+	})
+
+	mux.HandleFunc("GET /api/v1/metrics/pods", func(w http.ResponseWriter, r *http.Request) {
+		namespace, allNamespaces, err := serveNamespace(client, r)
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		if allNamespaces {
+			namespace = ""
+		}
+		podMetrics, err := client.MetricsService.ListPodMetrics(namespace)
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		serveJSON(w, http.StatusOK, podMetrics)
+	})
+
+	mux.HandleFunc("GET /api/v1/metrics/nodes", func(w http.ResponseWriter, r *http.Request) {
+		nodeMetrics, err := client.MetricsService.ListNodeMetrics()
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		serveJSON(w, http.StatusOK, nodeMetrics)
+	})
+
+	mux.HandleFunc("GET /api/v1/describe/{type}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		namespace, _, err := serveNamespace(client, r)
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		resourceType := strings.ToLower(r.PathValue("type"))
+		if actualType, ok := resourceTypeAliases[resourceType]; ok {
+			resourceType = actualType
+		}
+		name := r.PathValue("name")
+		if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+			serveError(w, errs.Validationf("invalid name %q: %s", name, strings.Join(msgs, "; ")))
+			return
+		}
+
+		description, err := client.DescribeResource(r.Context(), k8s.ResourceType(resourceType), namespace, name)
+		if err != nil {
+			serveError(w, err)
+			return
+		}
+		serveJSON(w, http.StatusOK, description)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !serveHostAllowed(r.Host, listen) {
+			serveError(w, errs.New(errs.Forbidden, "host %q is not allowed", r.Host))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// serveHostAllowed reports whether a request addressed to host may be served. A web page can
+// rebind a domain name of its own to a local address, so only localhost and the host the server
+// listens on are accepted, or any IP address when it listens on all interfaces.
+func serveHostAllowed(host, listen string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	listenHost, _, _ := net.SplitHostPort(listen)
+
+	switch {
+	case strings.EqualFold(host, "localhost"), host == "127.0.0.1", host == "::1":
+		return true
+	case listenHost != "" && strings.EqualFold(host, listenHost):
+		return true
+	}
+	return net.ParseIP(host) != nil && (listenHost == "" || net.ParseIP(listenHost).IsUnspecified())
+}
+
+// serveNamespace returns the validated namespace query parameter, defaulting to the current namespace
+func serveNamespace(client *k8s.Client, r *http.Request) (string, bool, error) {
+	q := r.URL.Query()
+	allNamespaces := q.Get("allNamespaces") == "true"
+
+	namespace := q.Get("namespace")
+	if namespace == "" {
+		return client.GetCurrentNamespace(), allNamespaces, nil
+	}
+	if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+		return "", false, errs.Validationf("invalid namespace %q: %s", namespace, strings.Join(msgs, "; "))
+	}
+	return namespace, allNamespaces, nil
+}
+
+// serveError writes an error with the HTTP status matching its category
+func serveError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch errs.CategoryOf(err) {
+	case errs.NotFound:
+		status = http.StatusNotFound
+	case errs.Forbidden:
+		status = http.StatusForbidden
+	case errs.Timeout:
+		status = http.StatusGatewayTimeout
+	case errs.Conflict:
+		status = http.StatusConflict
+	case errs.Validation:
+		status = http.StatusBadRequest
+	}
+	serveJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func serveJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error": %q}`, err.Error()), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(data, '\n'))
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	k8s "k8stool/internal/k8s/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServe_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	client, err := k8s.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	server := httptest.NewServer(serveHandler(client, "localhost:8099"))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		host       string
+		wantStatus int
+		validate   func(t *testing.T, body string)
+	}{
+		{
+			name:       "health check",
+			path:       "/healthz",
+			wantStatus: http.StatusOK,
+			validate: func(t *testing.T, body string) {
+				assert.Contains(t, body, `"status": "ok"`)
+			},
+		},
+		{
+			name:       "list pods in namespace",
+			path:       "/api/v1/pods?namespace=default",
+			wantStatus: http.StatusOK,
+			validate: func(t *testing.T, body string) {
+				assert.Contains(t, body, "nginx-default")
+			},
+		},
+		{
+			name:       "list deployments in namespace",
+			path:       "/api/v1/deployments?namespace=integration-test",
+			wantStatus: http.StatusOK,
+			validate: func(t *testing.T, body string) {
+				assert.Contains(t, body, "nginx-deploy")
+			},
+		},
+		{
+			name:       "describe pod",
+			path:       "/api/v1/describe/pod/nginx-default?namespace=default",
+			wantStatus: http.StatusOK,
+			validate: func(t *testing.T, body string) {
+				assert.Contains(t, body, `"name": "nginx-default"`)
+			},
+		},
+		{
+			name:       "describe non-existent pod",
+			path:       "/api/v1/describe/pod/non-existent?namespace=default",
+			wantStatus: http.StatusNotFound,
+			validate: func(t *testing.T, body string) {
+				assert.Contains(t, body, `"error"`)
+			},
+		},
+		{
+			name:       "invalid namespace",
+			path:       "/api/v1/pods?namespace=Invalid_NS",
+			wantStatus: http.StatusBadRequest,
+			validate:   func(t *testing.T, body string) {},
+		},
+		{
+			name:       "localhost host",
+			path:       "/healthz",
+			host:       "localhost:8099",
+			wantStatus: http.StatusOK,
+			validate:   func(t *testing.T, body string) {},
+		},
+		{
+			name:       "rebound domain name",
+			path:       "/api/v1/pods?namespace=default",
+			host:       "attacker.example.com:8099",
+			wantStatus: http.StatusForbidden,
+			validate: func(t *testing.T, body string) {
+				assert.Contains(t, body, `host \"attacker.example.com:8099\" is not allowed`)
+				assert.NotContains(t, body, "nginx-default")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			resp, err := server.Client().Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("failed to read response: %v", err)
+			}
+
+			t.Logf("Response body:\n%s", body)
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			tt.validate(t, string(body))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeHostAllowed(t *testing.T) {
+	tests := []struct {
+		host   string
+		listen string
+		want   bool
+	}{
+		{host: "localhost:8099", listen: "localhost:8099", want: true},
+		{host: "127.0.0.1:8099", listen: "localhost:8099", want: true},
+		{host: "[::1]:8099", listen: "localhost:8099", want: true},
+		{host: "LOCALHOST.", listen: "localhost:8099", want: true},
+		{host: "attacker.example.com:8099", listen: "localhost:8099", want: false},
+		{host: "192.168.1.10:8099", listen: "localhost:8099", want: false},
+		{host: "dashboard.internal:8099", listen: "dashboard.internal:8099", want: true},
+		{host: "attacker.example.com:8099", listen: "dashboard.internal:8099", want: false},
+		{host: "192.168.1.10:8099", listen: ":8099", want: true},
+		{host: "192.168.1.10:8099", listen: "0.0.0.0:8099", want: true},
+		{host: "attacker.example.com:8099", listen: ":8099", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host+" on "+tt.listen, func(t *testing.T) {
+			assert.Equal(t, tt.want, serveHostAllowed(tt.host, tt.listen))
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// defaultDiffWidth is the width of side-by-side diffs when the output is not a terminal
+const defaultDiffWidth = 160
+
+func getSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Update fields of workloads",
+		Long:  "Update fields of workloads, showing the change and asking for confirmation first.",
+	}
+
+	cmd.AddCommand(getSetImageCmd())
+
+	return cmd
+}
+
+func getSetImageCmd() *cobra.Command {
+	var namespace string
+	var dryRun bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "image (deploy/NAME | NAME) [CONTAINER=]IMAGE",
+		Short: "Set the image of a deployment container",
+		Long: `Set the image of a container of a deployment.
+
+The pod template before and after the change is shown side by side, and the change is only
+saved once confirmed, or with --yes. The container may only be left out when the deployment
+has a single container, so the images of several containers are never overwritten by mistake.
+The save fails if the deployment changed after the diff was shown.
+
+Examples:
+  # Set the image of the only container of the web deployment
+  k8stool set image web nginx:1.27.3
+
+  # Set the image of the proxy container, without confirmation
+  k8stool set image deploy/web proxy=envoyproxy/envoy:v1.32.1 --yes
+
+  # Only show the change
+  k8stool set image deploy/web web=nginx:1.27.3 --dry-run`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if resourceType, resourceName, found := strings.Cut(name, "/"); found {
+				if imageWorkloadKinds[strings.ToLower(resourceType)] != "deployment" {
+					return errs.Validationf("unsupported resource type %q: must be a deployment", resourceType)
+				}
+				name = resourceName
+			}
+			opts := k8s.DeploymentOptions{Image: args[1]}
+			if container, image, found := strings.Cut(args[1], "="); found {
+				opts.Container, opts.Image = container, image
+			}
+			if opts.Image == "" {
+				return errs.Validationf("image must not be empty")
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			change, err := client.PreviewDeploymentUpdate(namespace, name, opts)
+			if err != nil {
+				return err
+			}
+			if !change.Changed() {
+				fmt.Printf("deployment/%s unchanged\n", name)
+				return nil
+			}
+
+			ok, err := confirmTemplateChange(fmt.Sprintf("deployment/%s", name), change.Before, change.After, dryRun || yes)
+			if err != nil {
+				return err
+			}
+			if dryRun {
+				return nil
+			}
+			if !ok {
+				return fmt.Errorf("aborted")
+			}
+
+			opts.ResourceVersion = change.ResourceVersion
+			if err := client.UpdateDeployment(namespace, name, opts); err != nil {
+				return err
+			}
+			fmt.Printf("deployment/%s image updated\n", name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only show the change")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	markMutating(cmd)
+
+	return cmd
+}
+
+// confirmTemplateChange shows the pod template of a workload before and after a change side by
+// side and asks whether to roll it out, unless skip is set
+func confirmTemplateChange(ref, before, after string, skip bool) (bool, error) {
+	fmt.Print(utils.SideBySideDiff(before, after, "current pod template", "new pod template", terminalWidth()))
+	if skip {
+		return true, nil
+	}
+	return confirm(fmt.Sprintf("Roll out the new pod template of %s", ref))
+}
+
+// terminalWidth returns the width of the terminal, or defaultDiffWidth when stdout is not one
+func terminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	return defaultDiffWidth
+}
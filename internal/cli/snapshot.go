@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/snapshot"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Capture cluster state into a file for offline analysis",
+		Long: `Capture the resources, events and metrics of a cluster into a snapshot file, and inspect
+snapshots.
+
+Any read command runs against a snapshot with --from-snapshot FILE instead of the cluster, so
+the state of a cluster during an incident can be analysed afterwards, or shared with people who
+have no access to the cluster. Commands that change the cluster, watch it, or need logs, exec
+or port forwarding fail against a snapshot.`,
+	}
+
+	cmd.AddCommand(getSnapshotCreateCmd())
+	cmd.AddCommand(getSnapshotInfoCmd())
+
+	return cmd
+}
+
+func getSnapshotCreateCmd() *cobra.Command {
+	var opts k8s.SnapshotOptions
+
+	cmd := &cobra.Command{
+		Use:   "create FILE",
+		Short: "Capture the state of the cluster into a snapshot file",
+		Long: `Capture every object the current user can list, including events and metrics when the
+metrics server is installed, into a gzipped snapshot file. Resources that cannot be listed, for
+instance because access is forbidden, are skipped and reported.
+
+Secret values are removed, so snapshots can be shared; the keys of each secret are kept.
+Container logs are not captured.
+
+Examples:
+  # Capture the whole cluster
+  k8stool snapshot create cluster.k8snap
+
+  # Capture two namespaces and the cluster-scoped resources
+  k8stool snapshot create shop.k8snap -n shop,payments
+
+  # Look at the pods as they were, later or on another machine
+  k8stool pods -A --from-snapshot cluster.k8snap`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromSnapshot != "" {
+				return errs.Validationf("cannot create a snapshot from a snapshot")
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			snap, err := client.CreateSnapshot(ctx, opts)
+			if err != nil {
+				return err
+			}
+			if current, err := client.GetCurrentContext(); err == nil {
+				snap.Context = current.Name
+				snap.Cluster = current.Cluster
+			}
+			snap.Namespace = client.GetCurrentNamespace()
+			if len(opts.Namespaces) == 1 {
+				snap.Namespace = opts.Namespaces[0]
+			}
+
+			size, err := writeSnapshotFile(args[0], snap)
+			if err != nil {
+				return err
+			}
+
+			paths := make([]string, 0, len(snap.Skipped))
+			for p := range snap.Skipped {
+				paths = append(paths, p)
+			}
+			sort.Strings(paths)
+			for _, p := range paths {
+				fmt.Fprintf(os.Stderr, "%s skipped %s: %s\n", utils.Yellow("warning"), p, snap.Skipped[p])
+			}
+
+			objects := 0
+			for _, list := range snap.Resources {
+				objects += len(list.Items)
+			}
+			fmt.Printf("Captured %d objects of %d resource types into %s (%s)\n",
+				objects, len(snap.Resources), utils.Bold(args[0]), utils.FormatBytes(size))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.Namespaces, "namespace", "n", nil, "Namespaces to capture (comma-separated); all when not set")
+
+	return cmd
+}
+
+func getSnapshotInfoCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "info FILE",
+		Short: "Show what a snapshot contains",
+		Long: `Show when and from which cluster a snapshot was taken, and how many objects of each kind
+it holds.
+
+Examples:
+  # Summarize a snapshot
+  k8stool snapshot info cluster.k8snap`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			snap, err := snapshot.Load(args[0])
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				// The objects themselves are left out; they are read with --from-snapshot
+				resources := make([]map[string]interface{}, 0, len(snap.Resources))
+				for _, list := range snap.Resources {
+					resources = append(resources, map[string]interface{}{
+						"apiVersion": list.APIVersion,
+						"kind":       list.Kind,
+						"count":      len(list.Items),
+					})
+				}
+				return printJSON(map[string]interface{}{
+					"version":    snap.Version,
+					"created":    snap.Created,
+					"context":    snap.Context,
+					"cluster":    snap.Cluster,
+					"namespace":  snap.Namespace,
+					"namespaces": snap.Namespaces,
+					"resources":  resources,
+					"skipped":    snap.Skipped,
+				})
+			}
+
+			printSnapshotInfo(os.Stdout, snap)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+// writeSnapshotFile writes the snapshot next to its destination first, so a failed capture
+// never replaces an existing snapshot, and returns its size
+func writeSnapshotFile(path string, snap *k8s.Snapshot) (int64, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), ".k8stool-snapshot-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if err := snapshot.Write(f, snap); err != nil {
+		f.Close()
+		return 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return 0, fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return 0, fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	return info.Size(), nil
+}
+
+func printSnapshotInfo(out io.Writer, snap *k8s.Snapshot) {
+	w := newTable(out)
+	fmt.Fprintf(w, "Created:\t%s (%s)\n", formatTimestamp(snap.Created, "2006-01-02 15:04:05 MST"), formatAgo(snap.Created))
+	fmt.Fprintf(w, "Context:\t%s\n", snap.Context)
+	if snap.Cluster != "" {
+		fmt.Fprintf(w, "Cluster:\t%s\n", snap.Cluster)
+	}
+	if len(snap.Namespaces) > 0 {
+		fmt.Fprintf(w, "Namespaces:\t%s\n", strings.Join(snap.Namespaces, ", "))
+	} else {
+		fmt.Fprintf(w, "Namespaces:\tall\n")
+	}
+	w.Flush()
+
+	counts := make(map[string]int)
+	for _, list := range snap.Resources {
+		if len(list.Items) > 0 {
+			counts[list.Kind] += len(list.Items)
+		}
+	}
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	fmt.Fprintln(out)
+	w = newTable(out)
+	fmt.Fprintln(w, "KIND\tCOUNT")
+	for _, kind := range kinds {
+		fmt.Fprintf(w, "%s\t%d\n", kind, counts[kind])
+	}
+	w.Flush()
+
+	if len(snap.Skipped) > 0 {
+		fmt.Fprintf(out, "\n%d resource types could not be captured; see snapshot info -o json\n", len(snap.Skipped))
+	}
+}
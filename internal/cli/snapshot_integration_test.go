@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	k8s "k8stool/internal/k8s/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	file := filepath.Join(t.TempDir(), "cluster.k8snap")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "create snapshot of a namespace",
+			args:    []string{"create", file, "-n", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Captured")
+			},
+		},
+		{
+			name:    "snapshot info",
+			args:    []string{"info", file},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "KIND")
+				assert.Contains(t, output, "Pod")
+			},
+		},
+		{
+			name:    "snapshot info as json",
+			args:    []string{"info", file, "-o", "json"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `"namespaces"`)
+			},
+		},
+		{
+			name:     "info of a file that is not a snapshot",
+			args:     []string{"info", "snapshot_integration_test.go"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := getSnapshotCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+func TestSnapshotFlag_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and the snapshot state and restore them after tests
+	oldStdout := os.Stdout
+	defer func() {
+		os.Stdout = oldStdout
+		fromSnapshot, asUser = "", ""
+		k8s.SetSnapshot("")
+		k8s.SetImpersonation("", nil)
+	}()
+
+	missing := filepath.Join(t.TempDir(), "missing.k8snap")
+
+	// The namespace commands skip the cluster connection of the root command, and must still
+	// read from the snapshot
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "list namespaces from a missing snapshot",
+			args:    []string{"ns", "list", "--from-snapshot", missing},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "failed to open snapshot")
+			},
+		},
+		{
+			name:    "switch namespace from a snapshot",
+			args:    []string{"ns", "switch", "default", "--from-snapshot", missing},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "cannot switch namespaces with --from-snapshot")
+			},
+		},
+		{
+			name:    "impersonate against a snapshot",
+			args:    []string{"ns", "list", "--from-snapshot", missing, "--as", "k8stool-nobody"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "--as cannot be used with --from-snapshot")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Run through the root command so the persistent --from-snapshot flag is parsed
+			cmd := rootCmd
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+			fromSnapshot, asUser = "", ""
+			k8s.SetSnapshot("")
+			k8s.SetImpersonation("", nil)
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
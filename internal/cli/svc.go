@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/services"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getSvcCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "svc",
+		Aliases: []string{"service", "services"},
+		Short:   "Inspect services",
+		Long:    "Inspect Kubernetes services and the pods behind them.",
+	}
+
+	cmd.AddCommand(getSvcHealthCmd())
+
+	return cmd
+}
+
+func getSvcHealthCmd() *cobra.Command {
+	var namespace string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "health NAME",
+		Short: "Show the backend pods of a service and whether they receive traffic",
+		Long: `Show each pod behind a service with its readiness, whether it is listed as a ready
+endpoint, its restarts and its last failed probe.
+
+Pods matching the selector but missing from the endpoints, pods listed in the endpoints but
+not ready, selectors matching no pods and named target ports the pods do not declare are all
+reported, so a service returning errors can be diagnosed in one command.
+
+Examples:
+  # Check a service in the current namespace
+  k8stool svc health my-app
+
+  # Check a service in another namespace as JSON
+  k8stool svc health my-app -n my-namespace -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			health, err := client.ServiceHealth(context.Background(), namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(health)
+			}
+
+			printServiceHealth(health)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printServiceHealth(h *services.Health) {
+	fmt.Printf("%s %s/%s (%s %s, ports %s)\n\n",
+		utils.Bold("Service"), h.Namespace, h.Name, h.Type, orNone(h.ClusterIP), orNone(strings.Join(h.Ports, ", ")))
+
+	if len(h.Backends) > 0 {
+		w := newTable(os.Stdout)
+		fmt.Fprintln(w, "POD\tNODE\tSTATUS\tENDPOINT\tRESTARTS\tLAST PROBE FAILURE")
+		for _, b := range h.Backends {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+				b.Pod,
+				orNone(b.Node),
+				backendStatus(b),
+				endpointStatus(b),
+				b.Restarts,
+				probeFailure(b.LastProbeFailure),
+			)
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	for _, warning := range h.Warnings {
+		fmt.Printf("%s %s\n", utils.Yellow("Warning:"), warning)
+	}
+
+	serving := h.Serving()
+	summary := fmt.Sprintf("%d/%d backends serving", serving, len(h.Backends))
+	switch {
+	case serving == 0:
+		fmt.Println(utils.Red(summary))
+	case serving < len(h.Backends):
+		fmt.Println(utils.Yellow(summary))
+	default:
+		fmt.Println(utils.Green(summary))
+	}
+}
+
+func backendStatus(b services.Backend) string {
+	switch {
+	case b.Terminating:
+		return utils.Yellow("Terminating")
+	case b.Ready:
+		return utils.Green("Ready")
+	case b.Phase == "Running":
+		return utils.Yellow("NotReady")
+	default:
+		return utils.Red(b.Phase)
+	}
+}
+
+func endpointStatus(b services.Backend) string {
+	switch {
+	case b.Serving():
+		return utils.Green("serving")
+	case b.InEndpoints:
+		return utils.Yellow("not ready")
+	case !b.Selected:
+		return utils.Red("not selected")
+	default:
+		return utils.Red("missing")
+	}
+}
+
+func probeFailure(f *services.ProbeFailure) string {
+	if f == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%s ago (x%d): %s",
+		formatSince(f.Time), f.Count, utils.TruncateString(f.Message, 80))
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"io"
+	"text/tabwriter"
+
+	"k8stool/pkg/utils"
+)
+
+// tableWriter aligns tab-separated cells into columns like tabwriter, cutting cells longer
+// than the theme's column width limit with an ellipsis. The first line is the header and is
+// never cut. Color escape sequences are kept and do not count toward the width.
+type tableWriter struct {
+	tw       *tabwriter.Writer
+	maxWidth int
+
+	// body is set once the header line was written
+	body bool
+	// width is the number of visible characters written for the current cell
+	width int
+	// held is the last character allowed in a full cell, written once the cell turns out to
+	// end there, and heldTail the escape sequences that followed it
+	held, heldTail []byte
+	// cut is set once the current cell was cut, dropping the rest of its text
+	cut bool
+	// escape is set inside an escape sequence
+	escape bool
+}
+
+// newTable returns a writer for a table with columns two spaces apart
+func newTable(out io.Writer) *tableWriter {
+	return &tableWriter{
+		tw:       tabwriter.NewWriter(out, 0, 0, 2, ' ', 0),
+		maxWidth: utils.CurrentTheme().MaxColumnWidth,
+	}
+}
+
+func (t *tableWriter) Write(p []byte) (int, error) {
+	if t.maxWidth <= 0 {
+		return t.tw.Write(p)
+	}
+
+	out := make([]byte, 0, len(p))
+	for _, b := range p {
+		switch {
+		case !t.body:
+			out = append(out, b)
+			t.body = b == '\n'
+		case b == '\t' || b == '\n':
+			out = append(append(out, t.held...), t.heldTail...)
+			out = append(out, b)
+			t.width, t.held, t.heldTail, t.cut = 0, nil, nil, false
+		case t.escape || b == 0x1b:
+			// Escape sequences end with a letter, such as the m of color codes
+			t.escape = b == 0x1b || b < 0x40 || b > 0x7e || b == '['
+			if t.held != nil {
+				t.heldTail = append(t.heldTail, b)
+			} else {
+				out = append(out, b)
+			}
+		case t.cut:
+		case b&0xc0 == 0x80:
+			// Continuation bytes belong to the character before them
+			if t.held != nil {
+				t.held = append(t.held, b)
+			} else {
+				out = append(out, b)
+			}
+		case t.width < t.maxWidth-1:
+			out = append(out, b)
+			t.width++
+		case t.held == nil:
+			t.held = []byte{b}
+			t.width++
+		default:
+			// The cell goes on past the limit
+			out = append(append(out, "…"...), t.heldTail...)
+			t.held, t.heldTail, t.cut = nil, nil, true
+		}
+	}
+	if _, err := t.tw.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes the aligned table
+func (t *tableWriter) Flush() error {
+	if t.held != nil {
+		if _, err := t.tw.Write(append(t.held, t.heldTail...)); err != nil {
+			return err
+		}
+		t.held, t.heldTail = nil, nil
+	}
+	return t.tw.Flush()
+}
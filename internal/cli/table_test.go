@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxWidth int
+		writes   []string
+		want     string
+	}{
+		{
+			name:     "without a limit",
+			maxWidth: 0,
+			writes:   []string{"NAME\tSTATUS\n", "nginx-deployment\tRunning\n"},
+			want:     "NAME              STATUS\nnginx-deployment  Running\n",
+		},
+		{
+			name:     "cells past the limit are cut",
+			maxWidth: 6,
+			writes:   []string{"NAME\tSTATUS\n", "nginx-deployment\tRunning\n", "redis\tFailed\n"},
+			want:     "NAME    STATUS\nnginx…  Runni…\nredis   Failed\n",
+		},
+		{
+			name:     "header cells are not cut",
+			maxWidth: 6,
+			writes:   []string{"NAMESPACE\tRESTARTS\n", "default\t3\n"},
+			want:     "NAMESPACE  RESTARTS\ndefau…     3\n",
+		},
+		{
+			name:     "header written in pieces",
+			maxWidth: 4,
+			writes:   []string{"NAMESPACE\t", "STATUS\n", "kube-system\tRunning\n"},
+			want:     "NAMESPACE  STATUS\nkub…       Run…\n",
+		},
+		{
+			name:     "color codes do not count",
+			maxWidth: 6,
+			writes:   []string{"NAME\tSTATUS\n", "web\t\x1b[32mRunning\x1b[0m\n", "db\t\x1b[31mFailed\x1b[0m\n"},
+			want:     "NAME  STATUS\nweb   \x1b[32mRunni…\x1b[0m\ndb    \x1b[31mFailed\x1b[0m\n",
+		},
+		{
+			name:     "multibyte characters",
+			maxWidth: 3,
+			writes:   []string{"STATUS\tNAME\n", "✓✓✓✓\tweb\n"},
+			want:     "STATUS  NAME\n✓✓…     web\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := &tableWriter{tw: tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0), maxWidth: tt.maxWidth}
+			for _, p := range tt.writes {
+				fmt.Fprint(w, p)
+			}
+			assert.NoError(t, w.Flush())
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+)
+
+// Time display flags shared by every command
+var (
+	utcTimes   bool
+	timeFormat string
+)
+
+// Supported --time-format values
+const (
+	timeFormatRelative = "relative"
+	timeFormatISO      = "iso"
+	timeFormatUnix     = "unix"
+)
+
+// validateTimeFormat checks the --time-format flag
+func validateTimeFormat() error {
+	switch timeFormat {
+	case "", timeFormatRelative, timeFormatISO, timeFormatUnix:
+		return nil
+	default:
+		return errs.Validationf("invalid time format %q: must be relative, iso or unix", timeFormat)
+	}
+}
+
+// formatAge renders the age of an object, relative unless another time format is requested
+func formatAge(age time.Duration) string {
+	switch timeFormat {
+	case "", timeFormatRelative:
+		return utils.FormatDuration(age)
+	default:
+		return formatTimestamp(time.Now().Add(-age), time.RFC3339)
+	}
+}
+
+// formatSince renders how long ago t was, relative unless another time format is requested
+func formatSince(t time.Time) string {
+	return formatAge(time.Since(t))
+}
+
+// formatAgo renders a past time as "5m ago", or as an absolute time for the iso and unix formats
+func formatAgo(t time.Time) string {
+	switch timeFormat {
+	case "", timeFormatRelative:
+		return utils.FormatDuration(time.Since(t)) + " ago"
+	default:
+		return formatTimestamp(t, time.RFC3339)
+	}
+}
+
+// formatTimestamp renders an absolute time. Without --time-format the command's own layout is
+// used, in local time or UTC with --utc.
+func formatTimestamp(t time.Time, layout string) string {
+	if utcTimes {
+		t = t.UTC()
+	} else {
+		t = t.Local()
+	}
+
+	switch timeFormat {
+	case timeFormatRelative:
+		return utils.FormatDuration(time.Since(t)) + " ago"
+	case timeFormatISO:
+		return t.Format(time.RFC3339)
+	case timeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.Format(layout)
+	}
+}
+
+// maxTimestampLen bounds how much of a log line is held back looking for its timestamp
+const maxTimestampLen = len(time.RFC3339Nano)
+
+// timestampWriter rewrites the RFC3339 timestamp the API server puts in front of each log line
+// when timestamps are requested. Writes may split lines anywhere, so the start of a line is held
+// back until the space after its timestamp arrives.
+type timestampWriter struct {
+	out io.Writer
+	// head is the start of the current line, before its first space
+	head []byte
+	// midLine is set once the timestamp of the current line was written
+	midLine bool
+}
+
+func (w *timestampWriter) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	for rest := p; len(rest) > 0; {
+		if w.midLine {
+			i := bytes.IndexByte(rest, '\n')
+			if i < 0 {
+				buf.Write(rest)
+				break
+			}
+			buf.Write(rest[:i+1])
+			rest = rest[i+1:]
+			w.midLine = false
+			continue
+		}
+
+		i := bytes.IndexAny(rest, " \n")
+		if i < 0 {
+			w.head = append(w.head, rest...)
+			if len(w.head) > maxTimestampLen {
+				// Too long for a timestamp: the line has none
+				buf.Write(w.head)
+				w.head = w.head[:0]
+				w.midLine = true
+			}
+			break
+		}
+		w.head = append(w.head, rest[:i]...)
+		t, err := time.Parse(time.RFC3339Nano, string(w.head))
+		if rest[i] == ' ' && err == nil {
+			buf.WriteString(formatTimestamp(t, time.RFC3339Nano))
+		} else {
+			buf.Write(w.head)
+		}
+		buf.WriteByte(rest[i])
+		w.midLine = rest[i] == ' '
+		w.head = w.head[:0]
+		rest = rest[i+1:]
+	}
+	if buf.Len() > 0 {
+		if _, err := w.out.Write(buf.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampWriter(t *testing.T) {
+	utcTimes, timeFormat = true, timeFormatUnix
+	defer func() { utcTimes, timeFormat = false, "" }()
+
+	tests := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{
+			name:   "whole line",
+			writes: []string{"2024-05-02T09:00:00.5Z started\n"},
+			want:   "1714640400 started\n",
+		},
+		{
+			name:   "several lines in one write",
+			writes: []string{"2024-05-02T09:00:00Z one\n2024-05-02T09:00:01Z two\n"},
+			want:   "1714640400 one\n1714640401 two\n",
+		},
+		{
+			name:   "timestamp split across writes",
+			writes: []string{"2024-05-02T09:", "00:00Z", " one\n"},
+			want:   "1714640400 one\n",
+		},
+		{
+			name:   "line split after its timestamp",
+			writes: []string{"2024-05-02T09:00:00Z one ", "2024-05-02T09:00:01Z\n2024-05-02T09:00:01Z two\n"},
+			want:   "1714640400 one 2024-05-02T09:00:01Z\n1714640401 two\n",
+		},
+		{
+			name:   "line without timestamp",
+			writes: []string{"plain", " line\n", "another\n"},
+			want:   "plain line\nanother\n",
+		},
+		{
+			name:   "long line without spaces",
+			writes: []string{"0123456789012345678901234567890123456789", "2024-05-02T09:00:00Z x\n"},
+			want:   "01234567890123456789012345678901234567892024-05-02T09:00:00Z x\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w := &timestampWriter{out: &out}
+			for _, p := range tt.writes {
+				n, err := w.Write([]byte(p))
+				assert.NoError(t, err)
+				assert.Equal(t, len(p), n)
+			}
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// timelineKinds maps the resource types accepted by timeline to their kind
+var timelineKinds = map[string]string{
+	"pod":          "Pod",
+	"po":           "Pod",
+	"pods":         "Pod",
+	"deployment":   "Deployment",
+	"deploy":       "Deployment",
+	"deployments":  "Deployment",
+	"replicaset":   "ReplicaSet",
+	"rs":           "ReplicaSet",
+	"statefulset":  "StatefulSet",
+	"statefulsets": "StatefulSet",
+	"sts":          "StatefulSet",
+	"daemonset":    "DaemonSet",
+	"ds":           "DaemonSet",
+	"job":          "Job",
+	"jobs":         "Job",
+	"cronjob":      "CronJob",
+	"cj":           "CronJob",
+	"service":      "Service",
+	"svc":          "Service",
+	"pvc":          "PersistentVolumeClaim",
+}
+
+func getTimelineCmd() *cobra.Command {
+	var namespace string
+	var output string
+	var logs bool
+	var logLines int64
+	var since time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "timeline TYPE/NAME",
+		Short: "Show the history of a resource as one chronological timeline",
+		Long: `Show the events of a resource together with the transitions recorded in its status, in the
+order they happened. For a pod this includes condition changes and container starts and
+terminations, so a crash loop or a failed probe can be followed step by step. With --logs the
+error lines of the container logs are merged in, including those of the previous run of
+restarted containers.
+
+A deployment shows its condition changes and the events of its ReplicaSets, which report pods
+that could not be created. Other resources show their events.
+
+Examples:
+  # Reconstruct what happened to a pod
+  k8stool timeline pod/my-app-7c9f8d5b6d-zr5mw
+
+  # Include error lines from the container logs
+  k8stool timeline pod/my-app-7c9f8d5b6d-zr5mw --logs
+
+  # Show the last hour of a deployment as JSON
+  k8stool timeline deploy/my-app --since 1h -o json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			kind, name, err := parseTimelineTarget(args)
+			if err != nil {
+				return err
+			}
+			if logs && kind != "Pod" {
+				return errs.Validationf("--logs can only be used with a pod")
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			timeline, err := client.Timeline(context.Background(), k8s.TimelineOptions{
+				Namespace: namespace,
+				Kind:      kind,
+				Name:      name,
+				Logs:      logs,
+				LogLines:  logLines,
+				Since:     since,
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(timeline)
+			}
+
+			if len(timeline.Entries) == 0 {
+				fmt.Printf("No history found for %s/%s\n", timeline.Kind, timeline.Name)
+				return nil
+			}
+
+			defer startPager()()
+			return printTimeline(timeline.Entries)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVar(&logs, "logs", false, "Include error lines from the container logs of a pod")
+	cmd.Flags().Int64Var(&logLines, "log-lines", 1000, "Number of recent log lines scanned per container")
+	cmd.Flags().DurationVar(&since, "since", 0, "Show only entries newer than a relative duration")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+// parseTimelineTarget returns the kind and name of a TYPE/NAME or TYPE NAME target
+func parseTimelineTarget(args []string) (string, string, error) {
+	resourceType, name := args[0], ""
+	if len(args) == 2 {
+		name = args[1]
+	} else {
+		var found bool
+		resourceType, name, found = strings.Cut(args[0], "/")
+		if !found {
+			return "", "", errs.Validationf("resource must be given as TYPE/NAME, e.g. pod/my-pod")
+		}
+	}
+	if name == "" {
+		return "", "", errs.Validationf("resource name is required")
+	}
+
+	kind, ok := timelineKinds[strings.ToLower(resourceType)]
+	if !ok {
+		return "", "", errs.Validationf("unsupported resource type %q", resourceType)
+	}
+	return kind, name, nil
+}
+
+func printTimeline(entries []k8s.TimelineEntry) error {
+	w := newTable(os.Stdout)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "TIME\tSOURCE\tTYPE\tOBJECT\tREASON\tMESSAGE")
+	for _, e := range entries {
+		entryType := e.Type
+		if entryType == "" {
+			entryType = "Normal"
+		}
+		message := e.Message
+		if e.Count > 1 {
+			message += fmt.Sprintf(" (x%d)", e.Count)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			formatTimestamp(e.Time, "2006-01-02 15:04:05"),
+			e.Source,
+			utils.ColorizeEventType(entryType),
+			e.Object,
+			e.Reason,
+			message)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/tree"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getTreeCmd() *cobra.Command {
+	var namespace string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "tree TYPE/NAME",
+		Short: "Show the ownership tree of a resource",
+		Long: `Show a resource and, recursively, the objects it owns, with a status marker per object.
+
+Ownership follows owner references: Deployments own ReplicaSets which own Pods, StatefulSets
+and DaemonSets own Pods and ControllerRevisions, CronJobs own Jobs which own Pods. Services
+are shown with their EndpointSlices and their Endpoints, and the Endpoints with the pods
+they route to.
+
+Examples:
+  # Show a deployment with its ReplicaSets and pods
+  k8stool tree deploy/my-app
+
+  # Show which pods a service routes to
+  k8stool tree svc/my-app -n my-namespace
+
+  # Show the jobs and pods of a cronjob as JSON
+  k8stool tree cronjob/nightly -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			root, err := client.Tree(context.Background(), namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(root)
+			}
+
+			printTree(root)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printTree(root *tree.Node) {
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "NAME\tAGE\tSTATUS")
+
+	var walk func(node *tree.Node, prefix, branch string)
+	walk = func(node *tree.Node, prefix, branch string) {
+		fmt.Fprintf(w, "%s%s%s/%s\t%s\t%s %s\n",
+			prefix,
+			branch,
+			node.Kind,
+			node.Name,
+			formatAge(node.Age),
+			healthMarker(node.Health),
+			node.Status,
+		)
+
+		// Children line up under their parent's name
+		switch branch {
+		case "├─ ":
+			prefix += "│  "
+		case "└─ ":
+			prefix += "   "
+		}
+		for i, child := range node.Children {
+			if i == len(node.Children)-1 {
+				walk(child, prefix, "└─ ")
+			} else {
+				walk(child, prefix, "├─ ")
+			}
+		}
+	}
+	walk(root, "", "")
+
+	w.Flush()
+}
+
+func healthMarker(health tree.Health) string {
+	switch health {
+	case tree.Healthy:
+		return utils.Green("✓")
+	case tree.Progressing:
+		return utils.Yellow("…")
+	case tree.Degraded:
+		return utils.Red("✗")
+	default:
+		return "?"
+	}
+}
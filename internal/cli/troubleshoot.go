@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/troubleshoot"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getTroubleshootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "troubleshoot",
+		Aliases: []string{"ts"},
+		Short:   "Collect troubleshooting reports for resources",
+		Long:    "Collect everything needed to troubleshoot a resource into a single report.",
+	}
+
+	cmd.AddCommand(getTroubleshootPodCmd())
+
+	return cmd
+}
+
+func getTroubleshootPodCmd() *cobra.Command {
+	var namespace string
+	var eventLimit int
+	var tail int64
+	var markdown bool
+
+	cmd := &cobra.Command{
+		Use:   "pod NAME",
+		Short: "Collect a troubleshooting report for a pod",
+		Long: `Collect a troubleshooting report for a pod.
+
+The report combines the pod description, its most recent warning events,
+current and previous log tails of every container, probe configuration and
+the rollout status of the owning workload, annotated with likely causes.
+
+Examples:
+  # Troubleshoot a pod in the current namespace
+  k8stool troubleshoot pod my-pod
+
+  # Include more events and log lines
+  k8stool troubleshoot pod my-pod --events 20 --tail 200
+
+  # Produce a markdown report to paste into an issue
+  k8stool troubleshoot pod my-pod -n my-namespace --markdown > report.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			ns := namespace
+			if ns == "" {
+				ns = client.GetCurrentNamespace()
+			}
+
+			report, err := client.TroubleshootPod(context.Background(), ns, args[0], k8s.TroubleshootOptions{
+				EventLimit: eventLimit,
+				TailLines:  tail,
+			})
+			if err != nil {
+				return err
+			}
+
+			if markdown {
+				return writeTroubleshootMarkdown(os.Stdout, report)
+			}
+			return writeTroubleshootReport(os.Stdout, report)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the pod")
+	cmd.Flags().IntVar(&eventLimit, "events", 10, "Number of recent warning events to include")
+	cmd.Flags().Int64Var(&tail, "tail", 50, "Number of log lines to include per container instance")
+	cmd.Flags().BoolVar(&markdown, "markdown", false, "Render the report as markdown")
+
+	return cmd
+}
+
+func writeTroubleshootReport(out io.Writer, report *troubleshoot.PodReport) error {
+	section := func(title string) {
+		fmt.Fprintf(out, "\n%s\n%s\n", utils.Bold(title), strings.Repeat("=", len(title)))
+	}
+
+	section("Findings")
+	if len(report.Findings) == 0 {
+		fmt.Fprintln(out, utils.Green("No obvious problems found"))
+	}
+	for _, f := range report.Findings {
+		fmt.Fprintf(out, "%s %s\n", utils.Yellow("!"), f)
+	}
+
+	section("Owner")
+	if report.Owner == nil {
+		fmt.Fprintln(out, "<none>")
+	} else {
+		rollout := utils.Yellow(report.Owner.Rollout)
+		if report.Owner.Complete {
+			rollout = utils.Green(report.Owner.Rollout)
+		}
+		fmt.Fprintf(out, "%s/%s: %s\n", report.Owner.Kind, report.Owner.Name, rollout)
+		fmt.Fprintf(out, "Desired: %d  Updated: %d  Ready: %d  Available: %d\n",
+			report.Owner.Desired, report.Owner.Updated, report.Owner.Ready, report.Owner.Available)
+	}
+
+	section("Warning Events")
+	if len(report.WarningEvents) == 0 {
+		fmt.Fprintln(out, "<none>")
+	}
+	for _, e := range report.WarningEvents {
+		fmt.Fprintf(out, "%s\t%s (x%d)\t%s\n", formatAgo(e.LastSeen), utils.Red(e.Reason), e.Count, e.Message)
+	}
+
+	section("Logs")
+	for _, l := range report.Logs {
+		writeContainerLogs(out, l, func(title, logs string) {
+			fmt.Fprintf(out, "--- %s ---\n%s", title, logs)
+			if !strings.HasSuffix(logs, "\n") {
+				fmt.Fprintln(out)
+			}
+		})
+	}
+
+	section("Describe")
+	return writePodDetails(out, report.Pod)
+}
+
+func writeTroubleshootMarkdown(out io.Writer, report *troubleshoot.PodReport) error {
+	fmt.Fprintf(out, "# Troubleshooting report: pod %s/%s\n", report.Pod.Namespace, report.Pod.Name)
+
+	fmt.Fprintf(out, "\n## Findings\n\n")
+	if len(report.Findings) == 0 {
+		fmt.Fprintln(out, "No obvious problems found.")
+	}
+	for _, f := range report.Findings {
+		fmt.Fprintf(out, "- %s\n", f)
+	}
+
+	fmt.Fprintf(out, "\n## Owner\n\n")
+	if report.Owner == nil {
+		fmt.Fprintln(out, "The pod has no controlling owner.")
+	} else {
+		fmt.Fprintf(out, "**%s/%s**: %s\n\n", report.Owner.Kind, report.Owner.Name, report.Owner.Rollout)
+		fmt.Fprintln(out, "| Desired | Updated | Ready | Available |")
+		fmt.Fprintln(out, "|---|---|---|---|")
+		fmt.Fprintf(out, "| %d | %d | %d | %d |\n", report.Owner.Desired, report.Owner.Updated, report.Owner.Ready, report.Owner.Available)
+	}
+
+	fmt.Fprintf(out, "\n## Warning Events\n\n")
+	if len(report.WarningEvents) == 0 {
+		fmt.Fprintln(out, "No warning events.")
+	} else {
+		fmt.Fprintln(out, "| Last Seen | Reason | Count | Message |")
+		fmt.Fprintln(out, "|---|---|---|---|")
+		for _, e := range report.WarningEvents {
+			fmt.Fprintf(out, "| %s | %s | %d | %s |\n",
+				formatAgo(e.LastSeen), e.Reason, e.Count, strings.ReplaceAll(e.Message, "|", "\\|"))
+		}
+	}
+
+	fmt.Fprintf(out, "\n## Logs\n")
+	for _, l := range report.Logs {
+		writeContainerLogs(out, l, func(title, logs string) {
+			fmt.Fprintf(out, "\n### %s\n\n```text\n%s", title, logs)
+			if !strings.HasSuffix(logs, "\n") {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintln(out, "```")
+		})
+	}
+
+	fmt.Fprintf(out, "\n## Describe\n\n```text\n")
+	if err := writePodDetails(out, report.Pod); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "```")
+	return nil
+}
+
+// writeContainerLogs emits the log tails of a container through the given block writer
+func writeContainerLogs(out io.Writer, l troubleshoot.ContainerLogs, block func(title, logs string)) {
+	if l.Error != "" {
+		fmt.Fprintf(out, "%s: %s\n", l.Container, l.Error)
+	}
+	if l.Previous != "" {
+		block(fmt.Sprintf("%s (previous)", l.Container), l.Previous)
+	}
+	if l.Current != "" {
+		block(fmt.Sprintf("%s (current)", l.Container), l.Current)
+	} else if l.Error == "" {
+		block(fmt.Sprintf("%s (current)", l.Container), "<no logs>\n")
+	}
+}
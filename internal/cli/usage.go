@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getUsageCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var selector string
+	var groupBy string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show resource requests, limits and usage per team or namespace",
+		Long: `Sum the CPU and memory requests, limits and live usage of running pods per group, to see
+what each team or namespace consumes for chargeback and capacity discussions.
+
+Pods are grouped by namespace, or with --group-by label:KEY by the value of a pod label. Pods
+without the label are counted under <none>. Live usage comes from metrics-server and is left
+out when it is not installed.
+
+Examples:
+  # Consumption per team across the cluster
+  k8stool usage -A --group-by label:team
+
+  # Consumption per namespace
+  k8stool usage -A
+
+  # Consumption per app in one namespace as JSON
+  k8stool usage -n shop --group-by label:app.kubernetes.io/name -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			report, err := client.Usage(context.Background(), k8s.UsageOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+				Selector:      selector,
+				GroupBy:       groupBy,
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(report)
+			}
+
+			if !report.MetricsAvailable {
+				fmt.Fprintln(os.Stderr, "Warning: metrics API unavailable, usage is not shown")
+			}
+			return printUsage(report)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Include pods in all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().StringVar(&groupBy, "group-by", "namespace", "Group pods by namespace or by a label with label:KEY")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printUsage(report *k8s.UsageReport) error {
+	w := newTable(os.Stdout)
+	defer w.Flush()
+
+	header := strings.ToUpper(strings.TrimPrefix(report.GroupBy, "label:"))
+	fmt.Fprintf(w, "%s\tPODS\tCPU REQ\tCPU LIM\tCPU USE\tMEM REQ\tMEM LIM\tMEM USE\n", header)
+
+	row := func(g k8s.UsageGroup) {
+		cpuUsage, memoryUsage := "-", "-"
+		if report.MetricsAvailable {
+			cpuUsage, memoryUsage = utils.FormatMilliCPU(g.CPUUsage), utils.FormatBytes(g.MemoryUsage)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			g.Name,
+			g.Pods,
+			formatRequest(g.CPURequests, utils.FormatMilliCPU),
+			formatRequest(g.CPULimits, utils.FormatMilliCPU),
+			cpuUsage,
+			formatRequest(g.MemoryRequests, utils.FormatBytes),
+			formatRequest(g.MemoryLimits, utils.FormatBytes),
+			memoryUsage,
+		)
+	}
+	for _, g := range report.Groups {
+		row(g)
+	}
+	if len(report.Groups) > 1 {
+		row(report.Total)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/resources"
+	"k8stool/internal/k8s/validate"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getValidateCmd() *cobra.Command {
+	var namespace string
+	var files []string
+	var recursive bool
+
+	cmd := &cobra.Command{
+		Use:   "validate -f FILENAME",
+		Short: "Validate manifests against the cluster with a server-side dry run",
+		Long: `Validate manifest files, directories or URLs by submitting every document to the cluster
+as a server-side dry run. Nothing is persisted.
+
+Each document is reported separately with:
+  - schema errors, including unknown and duplicate fields
+  - rejections by admission webhooks and validating admission policies
+  - deprecated or no longer served apiVersions and other API server warnings
+
+Documents whose namespace or custom resource definition is created by the same manifests are
+reported as warnings, since they cannot be checked until those exist. The command fails if any
+document would be rejected.
+
+Examples:
+  # Validate a directory of manifests
+  k8stool validate -f manifests/
+
+  # Validate a directory tree, defaulting the namespace
+  k8stool validate -f manifests/ -R -n staging
+
+  # Validate rendered output in CI
+  helm template my-app ./chart | k8stool validate -f -`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			docs, err := resources.LoadDocuments(files, recursive)
+			if err != nil {
+				return err
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			results, err := client.Validate(ctx, docs, k8s.ValidateOptions{Namespace: namespace})
+			printValidationResults(results)
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, r := range results {
+				if r.Failed() {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d documents failed validation", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace for objects that do not set one")
+	cmd.Flags().StringSliceVarP(&files, "filename", "f", nil, "File, directory or URL to validate (repeatable, - for stdin)")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, "Process directories recursively")
+	cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+func printValidationResults(results []validate.Result) {
+	w := newTable(os.Stdout)
+
+	valid, warned := 0, 0
+	fmt.Fprintln(w, "DOCUMENT\tOBJECT\tRESULT\tMESSAGE")
+	for _, r := range results {
+		document := fmt.Sprintf("%s#%d", r.Source, r.Index)
+		object := strings.ToLower(r.Kind) + "/" + r.Name
+		if r.Namespace != "" {
+			object = r.Namespace + "/" + object
+		}
+
+		if len(r.Issues) == 0 {
+			valid++
+			fmt.Fprintf(w, "%s\t%s\t%s\t\n", document, object, utils.Green("valid"))
+			continue
+		}
+		if !r.Failed() {
+			warned++
+		}
+		for i, issue := range r.Issues {
+			if i > 0 {
+				document, object = "", ""
+			}
+			result := utils.Yellow(string(issue.Type))
+			if issue.Failed() {
+				result = utils.Red(string(issue.Type))
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", document, object, result, issue.Message)
+		}
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d documents: %s valid, %s with warnings, %s failed\n",
+		len(results), utils.Green(valid), utils.Yellow(warned), utils.Red(len(results)-valid-warned))
+}
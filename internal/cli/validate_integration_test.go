@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	dir := t.TempDir()
+	manifests := map[string]string{
+		"valid.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: k8stool-validate-test
+data:
+  key: value
+`,
+		"invalid.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: k8stool-validate-test
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: k8stool-validate-test
+unknownField: value
+`,
+	}
+	for name, content := range manifests {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write manifest: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "validate valid manifest",
+			args:    []string{"-f", filepath.Join(dir, "valid.yaml"), "--namespace", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "integration-test/configmap/k8stool-validate-test")
+				assert.Contains(t, output, "valid")
+				assert.Contains(t, output, "1 documents: 1 valid, 0 with warnings, 0 failed")
+			},
+		},
+		{
+			name:    "validate unknown field",
+			args:    []string{"-f", filepath.Join(dir, "invalid.yaml"), "--namespace", "integration-test"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "invalid.yaml#2")
+				assert.Contains(t, output, "schema")
+				assert.Contains(t, output, "unknownField")
+			},
+		},
+		{
+			name:     "validate missing file",
+			args:     []string{"-f", "nonexistent.yaml"},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create command
+			cmd := getValidateCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/pods"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getWatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch resources, alert on problems and stream changes",
+		Long: `Watch resources through the watch API. Pods can be watched for problem states; pods,
+deployments, nodes and events can be streamed as text, NDJSON or server-sent events.`,
+	}
+
+	cmd.AddCommand(getWatchPodsCmd())
+	cmd.AddCommand(getWatchResourceCmd("deployments", []string{"deployment", "deploy"}, `  # Follow the rollouts of a namespace
+  k8stool watch deployments -n prod
+
+  # Feed deployment changes of all namespaces to another program
+  k8stool watch deployments -A -o ndjson | jq -c 'select(.type == "MODIFIED")'`))
+	cmd.AddCommand(getWatchResourceCmd("nodes", []string{"node", "no"}, `  # Print node changes as they happen
+  k8stool watch nodes
+
+  # Stream node changes as server-sent events
+  k8stool watch nodes -o sse`))
+	cmd.AddCommand(getWatchResourceCmd("events", []string{"event", "ev"}, `  # Follow the events of a namespace
+  k8stool watch events -n prod
+
+  # Keep a log of every event of the cluster
+  k8stool watch events -A -o ndjson >> events.ndjson`))
+
+	return cmd
+}
+
+func getWatchPodsCmd() *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var selector string
+	var desktop bool
+	var webhook string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:     "pods",
+		Aliases: []string{"pod", "po"},
+		Short:   "Alert when pods crash loop, fail or become not ready",
+		Long: `Watch pods and print an alert whenever a matching pod enters CrashLoopBackOff, fails,
+or stops being ready after having been ready. Pods that are still starting are not reported
+as not ready.
+
+With --notify each alert is also shown as a desktop notification (notify-send on Linux,
+osascript on macOS), and with --webhook it is posted as JSON to a URL. The payload has a
+"text" field, so Slack and Mattermost incoming webhooks can be used directly.
+
+Pods already in a problem state when the watch starts are reported once. Press Ctrl+C to stop.
+
+With -o ndjson or -o sse every change of the pods is streamed instead of alerts, one JSON
+object per line or one server-sent event per change, like the other watch subcommands.
+
+Examples:
+  # Watch an app's pods during a deploy and get desktop notifications
+  k8stool watch pods -l app=web --notify
+
+  # Post alerts for a namespace to a Slack channel
+  k8stool watch pods -n prod --webhook https://hooks.slack.com/services/T000/B000/XXXX
+
+  # Print alerts for all namespaces
+  k8stool watch pods -A
+
+  # Stream every pod change as JSON lines
+  k8stool watch pods -l app=web -o ndjson`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "ndjson" && output != "sse" {
+				return errs.Validationf("invalid output format %q: must be ndjson or sse", output)
+			}
+			if output != "" && (desktop || webhook != "") {
+				return errs.Validationf("--notify and --webhook cannot be used with -o")
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			if output != "" {
+				return streamChanges(ctx, client, k8s.WatchOptions{
+					Resource:      "pods",
+					Namespace:     namespace,
+					AllNamespaces: allNamespaces,
+					Selector:      selector,
+				}, output)
+			}
+
+			events, err := client.WatchPods(ctx, k8s.PodWatchOptions{
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+				Selector:      selector,
+			})
+			if err != nil {
+				return err
+			}
+
+			scope := "namespace " + namespace
+			if allNamespaces {
+				scope = "all namespaces"
+			}
+			if selector != "" {
+				scope += " matching " + selector
+			}
+			fmt.Fprintf(os.Stderr, "Watching pods in %s (Ctrl+C to stop)\n", scope)
+
+			n := newNotifier(desktop, webhook)
+			for event := range events {
+				for _, alert := range event.Alerts {
+					printPodAlert(event.Pod, alert)
+					if !desktop && webhook == "" {
+						continue
+					}
+					title := fmt.Sprintf("%s %s/%s", alert.Type, event.Pod.Namespace, event.Pod.Name)
+					err := n.notify(ctx, title, alert.Message, map[string]interface{}{
+						"namespace": event.Pod.Namespace,
+						"pod":       event.Pod.Name,
+						"alert":     alert.Type,
+						"message":   alert.Message,
+					})
+					if err != nil && ctx.Err() == nil {
+						fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Watch pods in all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().BoolVar(&desktop, "notify", false, "Show a desktop notification for each alert")
+	cmd.Flags().StringVar(&webhook, "webhook", "", "URL to post each alert to as JSON")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Stream every change instead of alerts (ndjson or sse)")
+
+	return cmd
+}
+
+func printPodAlert(pod pods.Pod, alert pods.Alert) {
+	label := utils.Red(string(alert.Type))
+	if alert.Type == pods.AlertNotReady {
+		label = utils.Yellow(string(alert.Type))
+	}
+	fmt.Printf("%s  %s/%s  %s  %s\n", time.Now().Format("15:04:05"), pod.Namespace, pod.Name, label, alert.Message)
+}
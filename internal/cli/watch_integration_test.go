@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failedPod exits with an error right away, so it is reported as failed
+const failedPod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: k8stool-watch-failed
+  labels:
+    app: k8stool-watch
+spec:
+  restartPolicy: Never
+  containers:
+  - name: fail
+    image: nginx:alpine
+    command: ["sh", "-c", "exit 1"]
+`
+
+func TestWatchPodsCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	createTestObjects(t, failedPod, "integration-test")
+
+	// The webhook receives the alerts and stops the watch after the first one, like Ctrl+C
+	posted := make(chan string, 16)
+	var once sync.Once
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		posted <- string(body)
+		once.Do(interruptWatch)
+	}))
+	defer webhook.Close()
+
+	// Stop the watch anyway when no alert arrives
+	timeout := time.AfterFunc(2*time.Minute, func() { once.Do(interruptWatch) })
+	defer timeout.Stop()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "alert on a failed pod",
+			args:    []string{"pods", "-n", "integration-test", "-l", "app=k8stool-watch", "--webhook", webhook.URL},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Regexp(t, `\d{2}:\d{2}:\d{2}  integration-test/k8stool-watch-failed  Failed  pod failed`, output)
+				select {
+				case body := <-posted:
+					assert.Contains(t, body, `"text":"Failed integration-test/k8stool-watch-failed: pod failed`)
+					assert.Contains(t, body, `"pod":"k8stool-watch-failed"`)
+					assert.Contains(t, body, `"alert":"Failed"`)
+				default:
+					t.Error("no alert was posted to the webhook")
+				}
+			},
+		},
+		{
+			name:    "invalid output format",
+			args:    []string{"pods", "-o", "yaml"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `invalid output format "yaml": must be ndjson or sse`)
+			},
+		},
+		{
+			name:    "webhook with streamed output",
+			args:    []string{"pods", "-o", "ndjson", "--webhook", webhook.URL},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "--notify and --webhook cannot be used with -o")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getWatchCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+// interruptWatch stops a running watch the way Ctrl+C does
+func interruptWatch() {
+	// Give the watch a moment to print what it received
+	time.Sleep(time.Second)
+	if p, err := os.FindProcess(os.Getpid()); err == nil {
+		p.Signal(os.Interrupt)
+	}
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/deployments"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/events"
+	"k8stool/internal/k8s/nodes"
+	"k8stool/internal/k8s/pods"
+
+	"github.com/spf13/cobra"
+)
+
+// getWatchResourceCmd returns the watch subcommand that streams the changes of a resource
+func getWatchResourceCmd(resource string, aliases []string, example string) *cobra.Command {
+	var namespace string
+	var allNamespaces bool
+	var selector string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:     resource,
+		Aliases: aliases,
+		Short:   "Stream changes of " + resource,
+		Long: fmt.Sprintf(`Watch %[1]s and print every change as it happens, starting with the %[1]s that already exist.
+
+With -o ndjson each change is written as one JSON object per line, and with -o sse as a
+server-sent event, so other programs can consume live cluster changes through a pipe. Each
+object has the change type (ADDED, MODIFIED or DELETED), the kind, whether it was listed when
+the watch started, and the object as k8stool shows it elsewhere. Press Ctrl+C to stop.
+
+Examples:
+%[2]s`, resource, example),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "text" && output != "ndjson" && output != "sse" {
+				return errs.Validationf("invalid output format %q: must be text, ndjson or sse", output)
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+			if !allNamespaces && namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			return streamChanges(ctx, client, k8s.WatchOptions{
+				Resource:      resource,
+				Namespace:     namespace,
+				AllNamespaces: allNamespaces,
+				Selector:      selector,
+			}, output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Watch "+resource+" in all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format (text, ndjson or sse)")
+
+	return cmd
+}
+
+// streamChanges prints every change of a watch until it ends, as text, NDJSON or server-sent events
+func streamChanges(ctx context.Context, client *k8s.Client, opts k8s.WatchOptions, output string) error {
+	changes, err := client.WatchResources(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for change := range changes {
+		switch output {
+		case "ndjson":
+			if err := enc.Encode(change); err != nil {
+				return err
+			}
+		case "sse":
+			data, err := json.Marshal(change)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(os.Stdout, "event: %s\ndata: %s\n\n", change.Type, data); err != nil {
+				return err
+			}
+		default:
+			name, detail := describeChange(change.Object)
+			fmt.Printf("%s  %-8s  %-10s  %s  %s\n", time.Now().Format("15:04:05"), change.Type, change.Kind, name, detail)
+		}
+	}
+	return nil
+}
+
+// describeChange returns the name of a watched object and a short summary of its state
+func describeChange(obj interface{}) (string, string) {
+	switch o := obj.(type) {
+	case pods.Pod:
+		return o.Namespace + "/" + o.Name, fmt.Sprintf("%s, ready %s, %d restarts", o.Status, o.Ready, o.Restarts)
+	case deployments.Deployment:
+		return o.Namespace + "/" + o.Name, fmt.Sprintf("%d/%d ready, %d up-to-date", o.ReadyReplicas, o.Replicas, o.UpdatedReplicas)
+	case nodes.Node:
+		return o.Name, formatNodeStatus(o)
+	case *events.Event:
+		return o.Namespace + "/" + o.Name, fmt.Sprintf("%s %s/%s: %s", o.Reason, o.ResourceKind, o.ResourceName, o.Message)
+	}
+	return "", ""
+}
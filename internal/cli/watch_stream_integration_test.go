@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// streamedChange is a change as a consumer of -o ndjson or -o sse decodes it
+type streamedChange struct {
+	Type    string                 `json:"type"`
+	Kind    string                 `json:"kind"`
+	Initial bool                   `json:"initial"`
+	Object  map[string]interface{} `json:"object"`
+}
+
+func TestWatchStream_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	// Keep an interrupt meant for a watch from stopping the tests
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	tests := []struct {
+		name     string
+		args     []string
+		stream   bool
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "stream pods as ndjson",
+			args:    []string{"pods", "-n", "integration-test", "-o", "ndjson"},
+			stream:  true,
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				changes := decodeNDJSON(t, output)
+				if assert.NotEmpty(t, changes) {
+					assert.Equal(t, "ADDED", changes[0].Type)
+					assert.Equal(t, "Pod", changes[0].Kind)
+					assert.True(t, changes[0].Initial)
+				}
+				assert.True(t, hasStreamedObject(changes, "Pod", "Name", "nginx"), "pod nginx was not streamed")
+			},
+		},
+		{
+			name:    "stream deployments as ndjson",
+			args:    []string{"deployments", "-n", "integration-test", "-o", "ndjson"},
+			stream:  true,
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				changes := decodeNDJSON(t, output)
+				assert.True(t, hasStreamedObject(changes, "Deployment", "Name", "nginx-deploy"), "deployment nginx-deploy was not streamed")
+				assert.False(t, hasStreamedObject(changes, "Deployment", "Name", "nginx-default-deploy"), "deployment of another namespace was streamed")
+			},
+		},
+		{
+			name:    "stream nodes as server-sent events",
+			args:    []string{"nodes", "-o", "sse"},
+			stream:  true,
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.True(t, strings.HasPrefix(output, "event: ADDED\ndata: {"), "output is not a server-sent event")
+				assert.Contains(t, output, `"kind":"Node"`)
+				assert.Contains(t, output, `"ready":true`)
+			},
+		},
+		{
+			name:    "stream deployments as text",
+			args:    []string{"deploy", "-n", "integration-test"},
+			stream:  true,
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Regexp(t, `\d{2}:\d{2}:\d{2}  ADDED     Deployment  integration-test/nginx-deploy  1/1 ready, 1 up-to-date`, output)
+			},
+		},
+		{
+			name:    "stream events of all namespaces",
+			args:    []string{"events", "-A", "-o", "ndjson"},
+			stream:  true,
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				for _, change := range decodeNDJSON(t, output) {
+					assert.Equal(t, "Event", change.Kind)
+				}
+			},
+		},
+		{
+			name:    "invalid output format",
+			args:    []string{"nodes", "-o", "yaml"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `invalid output format "yaml": must be text, ndjson or sse`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			var buf bytes.Buffer
+			copied := make(chan struct{})
+			go func() {
+				io.Copy(&buf, r)
+				close(copied)
+			}()
+
+			// Stop the stream once the existing objects were written
+			stopped := make(chan struct{})
+			go func() {
+				defer close(stopped)
+				if tt.stream {
+					time.Sleep(2 * time.Second)
+					interruptWatch()
+				}
+			}()
+
+			// Create fresh command for each test
+			cmd := getWatchCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+			<-stopped
+
+			// Read output
+			w.Close()
+			<-copied
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
+
+// decodeNDJSON decodes every line of the output as a change
+func decodeNDJSON(t *testing.T, output string) []streamedChange {
+	var changes []streamedChange
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var change streamedChange
+		if err := json.Unmarshal(scanner.Bytes(), &change); err != nil {
+			t.Errorf("line is not a JSON object: %v\n%s", err, scanner.Text())
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// hasStreamedObject reports whether a change of kind has an object with the field set to value
+func hasStreamedObject(changes []streamedChange, kind, field, value string) bool {
+	for _, change := range changes {
+		if change.Kind == kind && change.Object[field] == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/webhooks"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getWebhooksCmd() *cobra.Command {
+	var opts k8s.WebhookListOptions
+	var output string
+
+	cmd := &cobra.Command{
+		Use:     "webhooks",
+		Aliases: []string{"webhook"},
+		Short:   "List admission webhooks and check their health",
+		Long: `List the mutating and validating admission webhooks of the cluster with their failure
+policy, timeout, namespace selector and target, and check that the service each one calls
+exists and has ready endpoints.
+
+A webhook that fails closed (failurePolicy Fail) and has no ready endpoints rejects every
+request it intercepts. A slow webhook slows down every such request, up to its timeout.
+
+With --probe each webhook is sent dry-run admission reviews through the API server's service
+proxy and the round trip is measured. Webhooks that may have side effects, or that are called
+by URL, are not probed. Probing requires the create permission on services/proxy.
+
+Examples:
+  # List all admission webhooks
+  k8stool webhooks
+
+  # Measure the latency of the validating webhooks
+  k8stool webhooks --type validating --probe --count 5`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errs.Validationf("invalid output format %q: must be table or json", output)
+			}
+			if opts.Type != "" && opts.Type != webhooks.Mutating && opts.Type != webhooks.Validating {
+				return errs.Validationf("invalid type %q: must be mutating or validating", opts.Type)
+			}
+			if opts.Count < 1 {
+				return errs.Validationf("--count must be at least 1")
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			list, err := client.ListWebhooks(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				return printJSON(list)
+			}
+
+			if len(list) == 0 {
+				fmt.Println("No admission webhooks found")
+				return nil
+			}
+			printWebhooks(list, opts.Probe)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Type, "type", "", "Only list mutating or validating webhooks")
+	cmd.Flags().BoolVar(&opts.Probe, "probe", false, "Measure the round trip of each webhook with dry-run admission reviews")
+	cmd.Flags().IntVar(&opts.Count, "count", 3, "Number of probes sent to each webhook")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format (table or json)")
+	markQueryable(cmd)
+
+	return cmd
+}
+
+func printWebhooks(list []k8s.Webhook, probe bool) {
+	w := newTable(os.Stdout)
+	fmt.Fprint(w, "TYPE\tCONFIGURATION\tWEBHOOK\tPOLICY\tTIMEOUT\tNAMESPACES\tTARGET\tHEALTH")
+	if probe {
+		fmt.Fprint(w, "\tLATENCY")
+	}
+	fmt.Fprintln(w)
+
+	for _, wh := range list {
+		namespaces := wh.NamespaceSelector
+		if namespaces == "" {
+			namespaces = "all"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%ds\t%s\t%s\t%s",
+			wh.Type, wh.Configuration, wh.Name, wh.FailurePolicy, wh.TimeoutSeconds, namespaces, wh.Target, formatWebhookHealth(wh))
+		if probe {
+			fmt.Fprintf(w, "\t%s", formatWebhookLatency(wh))
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+
+	var warnings []string
+	for _, wh := range list {
+		name := wh.Configuration + "/" + wh.Name
+		switch wh.Health {
+		case webhooks.ServiceNotFound, webhooks.NoReadyEndpoints:
+			if wh.FailurePolicy == "Fail" {
+				warnings = append(warnings, fmt.Sprintf("%s fails closed and %s: matching requests are rejected", name, wh.Details))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s is ignored on failure and %s: matching requests skip it", name, wh.Details))
+			}
+		}
+		if p := wh.Probe; p != nil && p.Count > 0 && p.Average > time.Duration(wh.TimeoutSeconds)*time.Second/2 {
+			warnings = append(warnings, fmt.Sprintf("%s takes %s on average, more than half of its %ds timeout", name, p.Average.Round(time.Millisecond), wh.TimeoutSeconds))
+		}
+		if p := wh.Probe; p != nil && p.Error != "" {
+			warnings = append(warnings, fmt.Sprintf("%s probe failed: %s", name, p.Error))
+		}
+	}
+	if len(warnings) > 0 {
+		fmt.Println()
+		for _, warning := range warnings {
+			fmt.Printf("%s %s\n", utils.Yellow("Warning:"), warning)
+		}
+	}
+}
+
+// formatWebhookHealth colors the health of a webhook's target; an unreachable target is red
+// when the webhook fails closed
+func formatWebhookHealth(wh k8s.Webhook) string {
+	switch wh.Health {
+	case webhooks.Healthy:
+		return utils.Green(wh.Health)
+	case webhooks.ServiceNotFound, webhooks.NoReadyEndpoints:
+		if wh.FailurePolicy == "Fail" {
+			return utils.Red(wh.Health)
+		}
+		return utils.Yellow(wh.Health)
+	}
+	return wh.Health
+}
+
+// formatWebhookLatency renders the average and maximum round trip of the probes, or why the
+// webhook was not probed
+func formatWebhookLatency(wh k8s.Webhook) string {
+	p := wh.Probe
+	switch {
+	case p == nil:
+		return "-"
+	case p.Skipped != "":
+		return "skipped: " + p.Skipped
+	case p.Count == 0:
+		return utils.Red("failed")
+	}
+	latency := fmt.Sprintf("%s (max %s)", p.Average.Round(time.Millisecond), p.Max.Round(time.Millisecond))
+	timeout := time.Duration(wh.TimeoutSeconds) * time.Second
+	switch {
+	case p.Average > timeout/2:
+		return utils.Red(latency)
+	case p.Average > timeout/10:
+		return utils.Yellow(latency)
+	}
+	return latency
+}
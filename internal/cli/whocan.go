@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	k8s "k8stool/internal/k8s/client"
+
+	"github.com/spf13/cobra"
+)
+
+func getWhoCanCmd() *cobra.Command {
+	var opts k8s.AccessOptions
+
+	cmd := &cobra.Command{
+		Use:   "who-can VERB RESOURCE [NAME]",
+		Short: "List the subjects allowed to perform an action",
+		Long: `List every user, group and service account that RBAC allows to perform an action.
+
+ClusterRoleBindings and the RoleBindings of the namespace are walked, and each subject whose
+bound role has a rule matching the verb, resource and optional name is reported together with
+the binding and role granting it. Wildcard verbs, groups and resources are taken into account.
+
+Access granted outside RBAC, e.g. by webhook authorizers or to the system:masters group by the
+API server itself, is not listed.
+
+Examples:
+  # Who can delete pods in the prod namespace
+  k8stool who-can delete pods -n prod
+
+  # Who can read a specific secret
+  k8stool who-can get secret db-credentials -n prod
+
+  # Who can exec into pods in any namespace
+  k8stool who-can create pods/exec -A
+
+  # Who can read the metrics endpoint
+  k8stool who-can get /metrics`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Verb, opts.Resource = args[0], args[1]
+			if len(args) == 3 {
+				opts.Name = args[2]
+			}
+
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if !opts.AllNamespaces && opts.Namespace == "" {
+				opts.Namespace = client.GetCurrentNamespace()
+			}
+
+			grants, err := client.WhoCan(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+
+			if len(grants) == 0 {
+				fmt.Println("No subjects found")
+				return nil
+			}
+
+			w := newTable(os.Stdout)
+			fmt.Fprintln(w, "KIND\tSUBJECT\tBINDING\tROLE")
+			for _, g := range grants {
+				subject := g.Subject
+				if g.SubjectNamespace != "" {
+					subject = g.SubjectNamespace + "/" + subject
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", g.SubjectKind, subject, g.Binding, g.Role)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&opts.AllNamespaces, "all-namespaces", "A", false, "Include role bindings of all namespaces")
+
+	return cmd
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	k8s "k8stool/internal/k8s/client"
+	"k8stool/internal/k8s/scheduling"
+	"k8stool/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func getWhyPendingCmd() *cobra.Command {
+	var namespace string
+
+	cmd := &cobra.Command{
+		Use:   "why-pending POD",
+		Short: "Explain why a pod cannot be scheduled",
+		Long: `Explain why a pod is stuck in Pending.
+
+The pod's resource requests, nodeSelector, required node affinity and tolerations are compared
+against every node's allocatable resources, labels, taints and readiness, reporting exactly which
+constraint excludes which node. FailedScheduling events from the scheduler are shown alongside.
+
+Examples:
+  # Analyze a pending pod in the current namespace
+  k8stool why-pending my-pod
+
+  # Analyze a pending pod in a specific namespace
+  k8stool why-pending my-pod -n my-namespace`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := k8s.NewClient()
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				namespace = client.GetCurrentNamespace()
+			}
+
+			analysis, err := client.WhyPending(context.Background(), namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			return printSchedulingAnalysis(analysis)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the pod")
+
+	return cmd
+}
+
+func printSchedulingAnalysis(a *scheduling.Analysis) error {
+	fmt.Printf("Pod:      %s/%s\n", a.Namespace, a.Name)
+	fmt.Printf("Phase:    %s\n", utils.ColorizeStatus(a.Phase))
+	fmt.Printf("Requests: cpu %s, memory %s\n", utils.FormatMilliCPU(a.CPURequest), utils.FormatBytes(a.MemoryRequest))
+
+	if a.NodeName != "" {
+		fmt.Printf("\nThe pod is already scheduled to node %s.\n", utils.Bold(a.NodeName))
+		return nil
+	}
+
+	if len(a.SchedulerMessages) > 0 {
+		fmt.Printf("\n%s\n", utils.Bold("Scheduler messages:"))
+		for _, m := range a.SchedulerMessages {
+			fmt.Printf("  - %s\n", m)
+		}
+	}
+
+	if len(a.PodIssues) > 0 {
+		fmt.Printf("\n%s\n", utils.Bold("Pod issues:"))
+		for _, issue := range a.PodIssues {
+			fmt.Printf("  - %s\n", utils.Yellow(issue))
+		}
+	}
+
+	fmt.Printf("\n%s\n", utils.Bold("Nodes:"))
+	w := newTable(os.Stdout)
+	fmt.Fprintln(w, "NODE\tFITS\tREASONS")
+	fits := 0
+	for _, n := range a.Nodes {
+		if n.Fits() {
+			fits++
+			fmt.Fprintf(w, "%s\t%s\t-\n", n.Name, utils.Green("yes"))
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", n.Name, utils.Red("no"), strings.Join(n.Reasons, "; "))
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d of %d nodes fit the checked constraints\n", fits, len(a.Nodes))
+	if fits > 0 && len(a.PodIssues) == 0 {
+		fmt.Println("If the pod is still pending, check the scheduler messages above or wait for the next scheduling attempt.")
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pendingPod selects a node label no node has, so it is never scheduled
+const pendingPod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: k8stool-pending
+spec:
+  nodeSelector:
+    k8stool-test: missing
+  containers:
+  - name: nginx
+    image: nginx:alpine
+    resources:
+      requests:
+        cpu: 10m
+`
+
+func TestWhyPendingCommand_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Save original stdout and restore it after tests
+	oldStdout := os.Stdout
+	defer func() { os.Stdout = oldStdout }()
+
+	createTestObjects(t, pendingPod, "integration-test")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		validate func(t *testing.T, output string)
+	}{
+		{
+			name:    "pod no node matches",
+			args:    []string{"k8stool-pending", "-n", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Pod:      integration-test/k8stool-pending")
+				assert.Contains(t, output, "Phase:    Pending")
+				assert.Contains(t, output, "Requests: cpu 10m")
+				assert.Contains(t, output, "NODE")
+				assert.Contains(t, output, "FITS")
+				assert.Contains(t, output, "nodeSelector k8stool-test=missing does not match")
+				assert.Regexp(t, `0 of \d+ nodes fit the checked constraints`, output)
+			},
+		},
+		{
+			name:    "scheduled pod",
+			args:    []string{"nginx", "-n", "integration-test"},
+			wantErr: false,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "Pod:      integration-test/nginx")
+				assert.Contains(t, output, "The pod is already scheduled to node")
+				assert.NotContains(t, output, "Nodes:")
+			},
+		},
+		{
+			name:    "non-existent pod",
+			args:    []string{"non-existent-pod", "-n", "integration-test"},
+			wantErr: true,
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, "failed to get pod")
+			},
+		},
+		{
+			name:     "without pod name",
+			args:     []string{},
+			wantErr:  true,
+			validate: func(t *testing.T, output string) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a pipe to capture output
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Create fresh command for each test
+			cmd := getWhyPendingCmd()
+			cmd.SetOut(w)
+			cmd.SetErr(w)
+			cmd.SetArgs(tt.args)
+
+			// Execute command
+			execErr := cmd.Execute()
+
+			// Read output
+			w.Close()
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("failed to copy response: %v", err)
+			}
+			output := buf.String()
+
+			t.Logf("Command output:\n%s", output)
+
+			if tt.wantErr {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			tt.validate(t, output)
+		})
+	}
+}
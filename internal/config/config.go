@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/prometheus"
+	"k8stool/pkg/utils"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the user configuration, read from config.yaml in the k8stool configuration
+// directory
+type Config struct {
+	// Theme configures how tables and statuses are rendered
+	Theme utils.Theme `json:"theme,omitempty"`
+
+	// Prometheus is where promql and metrics http send their queries
+	Prometheus prometheus.Source `json:"prometheus,omitempty"`
+
+	// Defaults are flag values applied to commands unless given on the command line, keyed
+	// by command, such as "get pods", then by flag name without dashes
+	Defaults map[string]map[string]interface{} `json:"defaults,omitempty"`
+
+	// ProtectedContexts are kubeconfig contexts whose name must be typed before a command
+	// changes their cluster
+	ProtectedContexts []string `json:"protectedContexts,omitempty"`
+}
+
+// DefaultPath returns the configuration file, under the user configuration directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the configuration directory: %w", err)
+	}
+	return filepath.Join(dir, "k8stool", "config.yaml"), nil
+}
+
+// Load reads the configuration file; a missing file is an empty configuration. Unknown fields
+// are rejected, so a misspelled setting is not silently ignored.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, errs.New(errs.Validation, "failed to read config from %s: %v", path, err)
+	}
+	return cfg, nil
+}
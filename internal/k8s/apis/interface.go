@@ -0,0 +1,29 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+)
+
+// Service defines the interface for API discovery and schema documentation
+type Service interface {
+	// Resources lists the resource types served by the cluster
+	Resources(ctx context.Context, opts ResourceOptions) ([]Resource, error)
+
+	// Explain documents a resource type or one of its fields from the OpenAPI schema
+	Explain(ctx context.Context, opts ExplainOptions) (*Explanation, error)
+}
+
+// NewAPIService creates a new API discovery service instance
+func NewAPIService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	discoveryClient := memory.NewMemCacheClient(clientset.Discovery())
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+	return newService(clientset, restmapper.NewShortcutExpander(mapper, discoveryClient, nil)), nil
+}
@@ -0,0 +1,328 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxRecursionDepth stops recursive explanations of self-referencing schemas such as JSONSchemaProps
+const maxRecursionDepth = 15
+
+type service struct {
+	clientset *kubernetes.Clientset
+	mapper    meta.RESTMapper
+}
+
+// newService creates a new API discovery service instance
+func newService(clientset *kubernetes.Clientset, mapper meta.RESTMapper) Service {
+	return &service{
+		clientset: clientset,
+		mapper:    mapper,
+	}
+}
+
+// Resources lists the resource types served by the cluster
+func (s *service) Resources(ctx context.Context, opts ResourceOptions) ([]Resource, error) {
+	lists, err := s.clientset.Discovery().ServerPreferredResources()
+	// Unavailable aggregated APIs fail discovery of their group only
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("failed to discover resources: %w", err)
+	}
+
+	group := opts.APIGroup
+	if group == "core" {
+		group = ""
+	}
+
+	var resources []Resource
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		if opts.APIGroup != "" && gv.Group != group {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") {
+				continue // Subresources
+			}
+			if opts.Namespaced != nil && r.Namespaced != *opts.Namespaced {
+				continue
+			}
+			if !slices.ContainsFunc(opts.Verbs, func(v string) bool { return !slices.Contains(r.Verbs, v) }) {
+				resources = append(resources, Resource{
+					Name:       r.Name,
+					ShortNames: r.ShortNames,
+					APIVersion: list.GroupVersion,
+					Namespaced: r.Namespaced,
+					Kind:       r.Kind,
+					Verbs:      r.Verbs,
+					Categories: r.Categories,
+				})
+			}
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		gi, gj := groupOf(resources[i].APIVersion), groupOf(resources[j].APIVersion)
+		if gi != gj {
+			return gi < gj
+		}
+		return resources[i].Name < resources[j].Name
+	})
+
+	return resources, nil
+}
+
+func groupOf(apiVersion string) string {
+	gv, _ := schema.ParseGroupVersion(apiVersion)
+	return gv.Group
+}
+
+// schemaNode is the part of an OpenAPI v3 schema used for explanations
+type schemaNode struct {
+	Type                 string                 `json:"type"`
+	Format               string                 `json:"format"`
+	Description          string                 `json:"description"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	Items                *schemaNode            `json:"items"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Ref                  string                 `json:"$ref"`
+	AllOf                []*schemaNode          `json:"allOf"`
+	Required             []string               `json:"required"`
+	IntOrString          bool                   `json:"x-kubernetes-int-or-string"`
+	GroupVersionKinds    []struct {
+		Group   string `json:"group"`
+		Version string `json:"version"`
+		Kind    string `json:"kind"`
+	} `json:"x-kubernetes-group-version-kind"`
+}
+
+type openAPIDocument struct {
+	Components struct {
+		Schemas map[string]*schemaNode `json:"schemas"`
+	} `json:"components"`
+}
+
+// Explain documents a resource type or one of its fields from the OpenAPI schema
+func (s *service) Explain(ctx context.Context, opts ExplainOptions) (*Explanation, error) {
+	resource, fieldPath, _ := strings.Cut(opts.Path, ".")
+	var fields []string
+	if fieldPath != "" {
+		fields = strings.Split(fieldPath, ".")
+	}
+
+	gvr := schema.GroupVersionResource{Resource: strings.ToLower(resource)}
+	if opts.APIVersion != "" {
+		gv, err := schema.ParseGroupVersion(opts.APIVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api version %q: %w", opts.APIVersion, err)
+		}
+		gvr.Group, gvr.Version = gv.Group, gv.Version
+	}
+	gvk, err := s.mapper.KindFor(gvr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve resource %q: %w", resource, err)
+	}
+
+	doc, err := s.openAPIDocument(gvk.GroupVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	node := doc.kindSchema(gvk)
+	if node == nil {
+		return nil, errs.NotFoundf("no schema published for %s", gvk)
+	}
+
+	explanation := &Explanation{
+		Kind:       gvk.Kind,
+		APIVersion: gvk.GroupVersion().String(),
+		FieldPath:  strings.Join(fields, "."),
+	}
+
+	description := node.Description
+	for i, name := range fields {
+		object := doc.elementOf(node)
+		child, ok := object.Properties[name]
+		if !ok {
+			return nil, errs.Validationf("field %q does not exist in %s", strings.Join(fields[:i+1], "."), gvk.Kind)
+		}
+		node = child
+		description = doc.description(child)
+	}
+
+	explanation.Type = doc.typeName(node)
+	explanation.Description = description
+	explanation.Fields = doc.fields(doc.elementOf(node), 0, opts.Recursive, nil)
+
+	return explanation, nil
+}
+
+// openAPIDocument fetches the OpenAPI v3 document of a group version
+func (s *service) openAPIDocument(gv schema.GroupVersion) (*openAPIDocument, error) {
+	paths, err := s.clientset.Discovery().OpenAPIV3().Paths()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenAPI paths: %w", err)
+	}
+
+	path := "apis/" + gv.String()
+	if gv.Group == "" {
+		path = "api/" + gv.Version
+	}
+	client, ok := paths[path]
+	if !ok {
+		return nil, errs.NotFoundf("no OpenAPI schema published for %s", gv)
+	}
+
+	data, err := client.Schema("application/json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenAPI schema for %s: %w", gv, err)
+	}
+	doc := &openAPIDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI schema for %s: %w", gv, err)
+	}
+	return doc, nil
+}
+
+// kindSchema returns the top-level schema of a kind
+func (d *openAPIDocument) kindSchema(gvk schema.GroupVersionKind) *schemaNode {
+	for _, node := range d.Components.Schemas {
+		for _, k := range node.GroupVersionKinds {
+			if k.Group == gvk.Group && k.Version == gvk.Version && k.Kind == gvk.Kind {
+				return node
+			}
+		}
+	}
+	return nil
+}
+
+// resolve follows references, including references wrapped in allOf to carry a description
+func (d *openAPIDocument) resolve(node *schemaNode) *schemaNode {
+	for depth := 0; node != nil && depth < 10; depth++ {
+		switch {
+		case node.Ref != "":
+			node = d.Components.Schemas[strings.TrimPrefix(node.Ref, "#/components/schemas/")]
+		case len(node.AllOf) == 1:
+			node = node.AllOf[0]
+		default:
+			return node
+		}
+	}
+	return node
+}
+
+// elementOf returns the schema describing the fields of a node: the node itself, or the
+// elements of arrays and maps
+func (d *openAPIDocument) elementOf(node *schemaNode) *schemaNode {
+	node = d.resolve(node)
+	if node == nil {
+		return &schemaNode{}
+	}
+	if node.Type == "array" && node.Items != nil {
+		return d.elementOf(node.Items)
+	}
+	if additional := d.additionalProperties(node); additional != nil && len(node.Properties) == 0 {
+		return d.elementOf(additional)
+	}
+	return node
+}
+
+func (d *openAPIDocument) additionalProperties(node *schemaNode) *schemaNode {
+	if len(node.AdditionalProperties) == 0 {
+		return nil
+	}
+	var additional schemaNode
+	// additionalProperties may also be a boolean
+	if json.Unmarshal(node.AdditionalProperties, &additional) != nil {
+		return nil
+	}
+	return &additional
+}
+
+// description returns the description of a field, preferring the field's own over its type's
+func (d *openAPIDocument) description(node *schemaNode) string {
+	if node.Description != "" {
+		return node.Description
+	}
+	if resolved := d.resolve(node); resolved != nil {
+		return resolved.Description
+	}
+	return ""
+}
+
+// typeName renders the type of a node the way kubectl explain does, e.g. <[]Object>
+func (d *openAPIDocument) typeName(node *schemaNode) string {
+	return "<" + d.rawTypeName(node) + ">"
+}
+
+func (d *openAPIDocument) rawTypeName(node *schemaNode) string {
+	if node.IntOrString {
+		return "IntOrString"
+	}
+	resolved := d.resolve(node)
+	if resolved == nil {
+		return "Object"
+	}
+	if resolved.IntOrString {
+		return "IntOrString"
+	}
+	switch resolved.Type {
+	case "array":
+		if resolved.Items != nil {
+			return "[]" + d.rawTypeName(resolved.Items)
+		}
+		return "[]Object"
+	case "object", "":
+		if additional := d.additionalProperties(resolved); additional != nil && len(resolved.Properties) == 0 {
+			return "map[string]" + d.rawTypeName(additional)
+		}
+		return "Object"
+	default:
+		return resolved.Type
+	}
+}
+
+// fields lists the fields of an object, descending into nested objects when recursive
+func (d *openAPIDocument) fields(object *schemaNode, depth int, recursive bool, visiting []*schemaNode) []Field {
+	names := make([]string, 0, len(object.Properties))
+	for name := range object.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []Field
+	for _, name := range names {
+		child := object.Properties[name]
+		fields = append(fields, Field{
+			Name:        name,
+			Type:        d.typeName(child),
+			Description: d.description(child),
+			Required:    slices.Contains(object.Required, name),
+			Depth:       depth,
+		})
+
+		if !recursive || depth >= maxRecursionDepth {
+			continue
+		}
+		element := d.elementOf(child)
+		if len(element.Properties) == 0 || slices.Contains(visiting, element) {
+			continue
+		}
+		fields = append(fields, d.fields(element, depth+1, recursive, append(visiting, object))...)
+	}
+	return fields
+}
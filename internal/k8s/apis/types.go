@@ -0,0 +1,62 @@
+package apis
+
+// ResourceOptions filters the listed resource types
+type ResourceOptions struct {
+	// APIGroup limits the list to one group; use "core" for the legacy core group
+	APIGroup string
+
+	// Namespaced limits the list to namespaced or cluster-scoped types when set
+	Namespaced *bool
+
+	// Verbs limits the list to types supporting all of these verbs
+	Verbs []string
+}
+
+// Resource is a resource type served by the cluster, in its preferred version
+type Resource struct {
+	Name       string   `json:"name"`
+	ShortNames []string `json:"shortNames,omitempty"`
+	APIVersion string   `json:"apiVersion"`
+	Namespaced bool     `json:"namespaced"`
+	Kind       string   `json:"kind"`
+	Verbs      []string `json:"verbs"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// ExplainOptions selects what to document
+type ExplainOptions struct {
+	// Path is a resource type optionally followed by a field path, e.g. deploy.spec.template
+	Path string
+
+	// APIVersion selects a group/version other than the preferred one
+	APIVersion string
+
+	// Recursive lists the fields of all nested objects rather than only the direct fields
+	Recursive bool
+}
+
+// Explanation documents a resource type or field
+type Explanation struct {
+	Kind       string
+	APIVersion string
+
+	// FieldPath is the documented field, empty for the resource type itself
+	FieldPath string
+
+	Type        string
+	Description string
+
+	// Fields are the fields of the documented object, depth first when recursive
+	Fields []Field
+}
+
+// Field is a field of an object schema
+type Field struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+
+	// Depth is the nesting level below the documented object, starting at 0
+	Depth int
+}
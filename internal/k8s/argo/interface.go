@@ -0,0 +1,22 @@
+package argo
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/dynamic"
+)
+
+// Service defines the interface for reading ArgoCD state
+type Service interface {
+	// Applications lists ArgoCD Applications with their sync and health status
+	Applications(ctx context.Context, opts Options) ([]Application, error)
+}
+
+// NewArgoService creates a new ArgoCD service instance
+func NewArgoService(dynamicClient dynamic.Interface) (Service, error) {
+	if dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client is required")
+	}
+	return newService(dynamicClient), nil
+}
@@ -0,0 +1,131 @@
+package argo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// applications is the resource of ArgoCD Application CRs
+var applications = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+
+type service struct {
+	dynamicClient dynamic.Interface
+}
+
+// newService creates a new ArgoCD service instance
+func newService(dynamicClient dynamic.Interface) Service {
+	return &service{
+		dynamicClient: dynamicClient,
+	}
+}
+
+// Applications lists ArgoCD Applications with their sync and health status
+func (s *service) Applications(ctx context.Context, opts Options) ([]Application, error) {
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	list, err := s.dynamicClient.Resource(applications).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: opts.Selector})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errs.NotFoundf("ArgoCD is not installed: %s not found", applications.GroupResource())
+		}
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	apps := make([]Application, 0, len(list.Items))
+	for i := range list.Items {
+		apps = append(apps, toApplication(&list.Items[i]))
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		if apps[i].Namespace != apps[j].Namespace {
+			return apps[i].Namespace < apps[j].Namespace
+		}
+		return apps[i].Name < apps[j].Name
+	})
+
+	return apps, nil
+}
+
+func toApplication(obj *unstructured.Unstructured) Application {
+	str := func(fields ...string) string {
+		v, _, _ := unstructured.NestedString(obj.Object, fields...)
+		return v
+	}
+
+	app := Application{
+		Name:             obj.GetName(),
+		Namespace:        obj.GetNamespace(),
+		Project:          str("spec", "project"),
+		DestServer:       str("spec", "destination", "server"),
+		DestNamespace:    str("spec", "destination", "namespace"),
+		SyncStatus:       str("status", "sync", "status"),
+		Health:           str("status", "health", "status"),
+		HealthMessage:    str("status", "health", "message"),
+		Revision:         str("status", "sync", "revision"),
+		OperationPhase:   str("status", "operationState", "phase"),
+		OperationMessage: str("status", "operationState", "message"),
+	}
+	if app.DestServer == "" {
+		app.DestServer = str("spec", "destination", "name")
+	}
+	if app.SyncStatus == "" {
+		app.SyncStatus = "Unknown"
+	}
+	if app.Health == "" {
+		app.Health = "Unknown"
+	}
+
+	if source, ok, _ := unstructured.NestedMap(obj.Object, "spec", "source"); ok {
+		app.RepoURL, app.Path, app.TargetRevision = sourceFields(source)
+	} else if sources, ok, _ := unstructured.NestedSlice(obj.Object, "spec", "sources"); ok {
+		var repos, paths, targets []string
+		for _, s := range sources {
+			if m, ok := s.(map[string]interface{}); ok {
+				repo, path, target := sourceFields(m)
+				repos, paths, targets = append(repos, repo), append(paths, path), append(targets, target)
+			}
+		}
+		app.RepoURL, app.Path, app.TargetRevision = strings.Join(repos, ","), strings.Join(paths, ","), strings.Join(targets, ",")
+		if revisions, _, _ := unstructured.NestedStringSlice(obj.Object, "status", "sync", "revisions"); len(revisions) > 0 {
+			app.Revision = strings.Join(revisions, ",")
+		}
+	}
+
+	if finished := str("status", "operationState", "finishedAt"); finished != "" {
+		app.LastSynced, _ = time.Parse(time.RFC3339, finished)
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		if m, ok := c.(map[string]interface{}); ok {
+			app.Conditions = append(app.Conditions, fmt.Sprintf("%v: %v", m["type"], m["message"]))
+		}
+	}
+
+	return app
+}
+
+// sourceFields returns the repository, path or chart, and target revision of an application source
+func sourceFields(source map[string]interface{}) (repo, path, target string) {
+	repo, _, _ = unstructured.NestedString(source, "repoURL")
+	path, _, _ = unstructured.NestedString(source, "path")
+	if path == "" {
+		path, _, _ = unstructured.NestedString(source, "chart")
+	}
+	target, _, _ = unstructured.NestedString(source, "targetRevision")
+	return repo, path, target
+}
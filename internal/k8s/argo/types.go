@@ -0,0 +1,47 @@
+package argo
+
+import "time"
+
+// Options configures which Applications are listed
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+	Selector      string
+}
+
+// Application is the GitOps state of an ArgoCD Application
+type Application struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Project   string `json:"project"`
+
+	// RepoURL, Path and TargetRevision describe the source; multi-source
+	// applications list their sources separated by commas
+	RepoURL        string `json:"repoURL"`
+	Path           string `json:"path,omitempty"`
+	TargetRevision string `json:"targetRevision,omitempty"`
+
+	// DestServer and DestNamespace are where the application is deployed to
+	DestServer    string `json:"destServer"`
+	DestNamespace string `json:"destNamespace,omitempty"`
+
+	// SyncStatus is Synced, OutOfSync or Unknown
+	SyncStatus string `json:"syncStatus"`
+
+	// Health is Healthy, Progressing, Degraded, Suspended, Missing or Unknown
+	Health        string `json:"health"`
+	HealthMessage string `json:"healthMessage,omitempty"`
+
+	// Revision is the revision the application was last synced to
+	Revision string `json:"revision,omitempty"`
+
+	// LastSynced is when the last sync operation finished; zero if it never ran
+	LastSynced time.Time `json:"lastSynced,omitempty"`
+
+	// OperationPhase is the phase of the last sync operation, e.g. Succeeded or Failed
+	OperationPhase   string `json:"operationPhase,omitempty"`
+	OperationMessage string `json:"operationMessage,omitempty"`
+
+	// Conditions holds error and warning conditions reported by ArgoCD
+	Conditions []string `json:"conditions,omitempty"`
+}
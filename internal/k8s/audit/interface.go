@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for cluster audits
+type Service interface {
+	// Security checks the security posture of workload pods
+	Security(ctx context.Context, opts Options) ([]Finding, error)
+}
+
+// NewAuditService creates a new audit service instance
+func NewAuditService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
@@ -0,0 +1,218 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new audit service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// workload is a pod template together with the object that owns it
+type workload struct {
+	namespace string
+	name      string
+	spec      *corev1.PodSpec
+}
+
+// Security checks the security posture of workload pods
+func (s *service) Security(ctx context.Context, opts Options) ([]Finding, error) {
+	workloads, err := s.workloads(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, wl := range workloads {
+		for _, f := range auditPod(wl.spec) {
+			f.Namespace = wl.namespace
+			f.Workload = wl.name
+			findings = append(findings, f)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity.Rank() > b.Severity.Rank()
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Workload < b.Workload
+	})
+
+	return findings, nil
+}
+
+// workloads lists the pod templates of deployments, statefulsets, daemonsets, cronjobs and
+// jobs, and the specs of pods without a controller
+func (s *service) workloads(ctx context.Context, opts Options) ([]workload, error) {
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+	listOpts := metav1.ListOptions{LabelSelector: opts.Selector}
+
+	var workloads []workload
+
+	deployments, err := s.clientset.AppsV1().Deployments(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		workloads = append(workloads, workload{d.Namespace, "Deployment/" + d.Name, &d.Spec.Template.Spec})
+	}
+
+	statefulSets, err := s.clientset.AppsV1().StatefulSets(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for i := range statefulSets.Items {
+		st := &statefulSets.Items[i]
+		workloads = append(workloads, workload{st.Namespace, "StatefulSet/" + st.Name, &st.Spec.Template.Spec})
+	}
+
+	daemonSets, err := s.clientset.AppsV1().DaemonSets(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for i := range daemonSets.Items {
+		ds := &daemonSets.Items[i]
+		workloads = append(workloads, workload{ds.Namespace, "DaemonSet/" + ds.Name, &ds.Spec.Template.Spec})
+	}
+
+	cronJobs, err := s.clientset.BatchV1().CronJobs(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for i := range cronJobs.Items {
+		cj := &cronJobs.Items[i]
+		workloads = append(workloads, workload{cj.Namespace, "CronJob/" + cj.Name, &cj.Spec.JobTemplate.Spec.Template.Spec})
+	}
+
+	jobs, err := s.clientset.BatchV1().Jobs(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for i := range jobs.Items {
+		j := &jobs.Items[i]
+		if metav1.GetControllerOf(j) != nil {
+			continue // Audited through the owning cronjob
+		}
+		workloads = append(workloads, workload{j.Namespace, "Job/" + j.Name, &j.Spec.Template.Spec})
+	}
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if metav1.GetControllerOf(p) != nil {
+			continue // Audited through the owning workload
+		}
+		workloads = append(workloads, workload{p.Namespace, "Pod/" + p.Name, &p.Spec})
+	}
+
+	return workloads, nil
+}
+
+// auditPod returns the findings of a pod spec and its containers
+func auditPod(spec *corev1.PodSpec) []Finding {
+	var findings []Finding
+	add := func(container string, rule Rule, severity Severity, format string, args ...interface{}) {
+		findings = append(findings, Finding{
+			Container: container,
+			Rule:      rule,
+			Severity:  severity,
+			Message:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	if spec.HostNetwork {
+		add("", HostNetwork, High, "pod uses the node's network namespace and can reach host-only services")
+	}
+	if spec.HostPID {
+		add("", HostPID, High, "pod can see and signal every process on the node")
+	}
+	if spec.HostIPC {
+		add("", HostIPC, High, "pod shares the node's IPC namespace")
+	}
+	for _, v := range spec.Volumes {
+		if v.HostPath != nil {
+			add("", HostPath, High, "volume %s mounts %s from the node", v.Name, v.HostPath.Path)
+		}
+	}
+
+	containers := append([]corev1.Container{}, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for i := range containers {
+		c := &containers[i]
+		sc := c.SecurityContext
+
+		if sc != nil && sc.Privileged != nil && *sc.Privileged {
+			add(c.Name, Privileged, Critical, "container runs privileged with full access to the node's devices and kernel")
+		}
+
+		if sc == nil && spec.SecurityContext == nil {
+			add(c.Name, MissingSecurityContext, Medium, "no securityContext; the container runs with the image's defaults")
+		}
+
+		if uid := runAsUser(spec, c); uid != nil && *uid == 0 {
+			add(c.Name, RunAsRoot, High, "container runs as user 0")
+		} else if uid == nil && !runAsNonRoot(spec, c) {
+			add(c.Name, RunAsRoot, Medium, "container may run as root; set runAsNonRoot or a non-zero runAsUser")
+		}
+
+		if usesLatestTag(c.Image) {
+			add(c.Name, LatestTag, Medium, "image %s is not pinned to a version", c.Image)
+		}
+	}
+
+	return findings
+}
+
+// runAsUser returns the effective user of a container, where container settings override the pod's
+func runAsUser(spec *corev1.PodSpec, c *corev1.Container) *int64 {
+	if c.SecurityContext != nil && c.SecurityContext.RunAsUser != nil {
+		return c.SecurityContext.RunAsUser
+	}
+	if spec.SecurityContext != nil {
+		return spec.SecurityContext.RunAsUser
+	}
+	return nil
+}
+
+// runAsNonRoot reports whether the kubelet refuses to start the container as root
+func runAsNonRoot(spec *corev1.PodSpec, c *corev1.Container) bool {
+	if c.SecurityContext != nil && c.SecurityContext.RunAsNonRoot != nil {
+		return *c.SecurityContext.RunAsNonRoot
+	}
+	return spec.SecurityContext != nil && spec.SecurityContext.RunAsNonRoot != nil && *spec.SecurityContext.RunAsNonRoot
+}
+
+// usesLatestTag reports whether an image resolves to the latest tag; digests are always pinned
+func usesLatestTag(image string) bool {
+	if strings.Contains(image, "@") {
+		return false
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	i := strings.LastIndex(name, ":")
+	return i < 0 || name[i+1:] == "latest"
+}
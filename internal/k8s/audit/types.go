@@ -0,0 +1,82 @@
+package audit
+
+import "k8stool/internal/k8s/errs"
+
+// Severity ranks how serious a finding is
+type Severity string
+
+const (
+	// Low flags hardening that is missing but rarely exploitable on its own
+	Low Severity = "low"
+	// Medium flags configuration that weakens isolation or reproducibility
+	Medium Severity = "medium"
+	// High flags access to the host or running as root
+	High Severity = "high"
+	// Critical flags containers with full access to the node
+	Critical Severity = "critical"
+)
+
+// Severities lists all severities from least to most serious
+var Severities = []Severity{Low, Medium, High, Critical}
+
+// Rank orders severities, starting at 1 for Low; unknown severities rank 0
+func (s Severity) Rank() int {
+	for i, severity := range Severities {
+		if s == severity {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ParseSeverity converts a severity name into a Severity
+func ParseSeverity(name string) (Severity, error) {
+	if s := Severity(name); s.Rank() > 0 {
+		return s, nil
+	}
+	return "", errs.Validationf("invalid severity %q: must be one of low, medium, high, critical", name)
+}
+
+// Rule names the check that produced a finding
+type Rule string
+
+const (
+	// Privileged flags containers running in privileged mode
+	Privileged Rule = "privileged"
+	// HostNetwork flags pods sharing the node's network namespace
+	HostNetwork Rule = "host-network"
+	// HostPID flags pods sharing the node's process namespace
+	HostPID Rule = "host-pid"
+	// HostIPC flags pods sharing the node's IPC namespace
+	HostIPC Rule = "host-ipc"
+	// HostPath flags pods mounting directories of the node
+	HostPath Rule = "host-path"
+	// RunAsRoot flags containers that run, or may run, as root
+	RunAsRoot Rule = "run-as-root"
+	// MissingSecurityContext flags containers without any security context
+	MissingSecurityContext Rule = "missing-security-context"
+	// LatestTag flags images using the latest tag or no tag at all
+	LatestTag Rule = "latest-tag"
+)
+
+// Options configures which workloads are audited
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+	Selector      string
+}
+
+// Finding is a single audit result for a pod or container
+type Finding struct {
+	Namespace string `json:"namespace"`
+
+	// Workload is the kind/name of the audited object
+	Workload string `json:"workload"`
+
+	// Container is empty for pod level findings
+	Container string `json:"container,omitempty"`
+
+	Rule     Rule     `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
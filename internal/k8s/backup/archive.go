@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// WriteArchive writes objects as YAML files to a gzipped tarball, one file per object laid out
+// as NAMESPACE/KIND[.GROUP]/NAME.yaml
+func WriteArchive(w io.Writer, objs []*unstructured.Unstructured) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	now := time.Now()
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", obj.GetName(), err)
+		}
+		header := &tar.Header{
+			Name:    archivePath(obj),
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+func archivePath(obj *unstructured.Unstructured) string {
+	if obj.GetKind() == "Namespace" {
+		return path.Join(obj.GetName(), "namespace.yaml")
+	}
+	kind := strings.ToLower(obj.GetKind())
+	if group := obj.GroupVersionKind().Group; group != "" {
+		kind += "." + group
+	}
+	return path.Join(obj.GetNamespace(), kind, obj.GetName()+".yaml")
+}
+
+// ReadArchive reads every object from the YAML and JSON files of a gzipped tarball
+func ReadArchive(r io.Reader) ([]*unstructured.Unstructured, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	var objs []*unstructured.Unstructured
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		switch strings.ToLower(path.Ext(header.Name)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		decoded, err := resources.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		objs = append(objs, decoded...)
+	}
+
+	if len(objs) == 0 {
+		return nil, errs.Validationf("no objects found in archive")
+	}
+	return objs, nil
+}
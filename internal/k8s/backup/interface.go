@@ -0,0 +1,35 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"k8stool/internal/k8s/resources"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for namespace backups
+type Service interface {
+	// Backup returns the cleaned objects of a namespace, including the namespace itself
+	Backup(ctx context.Context, opts BackupOptions) ([]*unstructured.Unstructured, error)
+
+	// Restore applies backed up objects in dependency order, optionally into another namespace
+	Restore(ctx context.Context, objs []*unstructured.Unstructured, opts RestoreOptions) ([]resources.Result, error)
+}
+
+// NewBackupService creates a new backup service instance
+func NewBackupService(clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, resourceService resources.Service) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	if dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client is required")
+	}
+	if resourceService == nil {
+		return nil, fmt.Errorf("resource service is required")
+	}
+	return newService(clientset, dynamicClient, resourceService), nil
+}
@@ -0,0 +1,244 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// runtimeResources hold state recreated by controllers and are skipped unless explicitly included
+var runtimeResources = map[schema.GroupResource]bool{
+	{Resource: "events"}:                                    true,
+	{Group: "events.k8s.io", Resource: "events"}:            true,
+	{Resource: "endpoints"}:                                 true,
+	{Group: "discovery.k8s.io", Resource: "endpointslices"}: true,
+	{Group: "coordination.k8s.io", Resource: "leases"}:      true,
+	{Group: "apps", Resource: "controllerrevisions"}:        true,
+	{Group: "metrics.k8s.io", Resource: "pods"}:             true,
+}
+
+// installOrder is the order kinds are restored in, so that objects are created after what they
+// depend on. Kinds not listed, such as custom resources, are restored last.
+var installOrder = []string{
+	"Namespace",
+	"NetworkPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"PersistentVolumeClaim",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"Ingress",
+}
+
+type service struct {
+	clientset       *kubernetes.Clientset
+	dynamicClient   dynamic.Interface
+	resourceService resources.Service
+}
+
+// newService creates a new backup service instance
+func newService(clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, resourceService resources.Service) Service {
+	return &service{
+		clientset:       clientset,
+		dynamicClient:   dynamicClient,
+		resourceService: resourceService,
+	}
+}
+
+// Backup returns the cleaned objects of a namespace. Objects owned by a controller are left
+// out, since restoring their owner recreates them.
+func (s *service) Backup(ctx context.Context, opts BackupOptions) ([]*unstructured.Unstructured, error) {
+	namespace, err := s.dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}).
+		Get(ctx, opts.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace: %w", err)
+	}
+	objs := []*unstructured.Unstructured{resources.Clean(namespace)}
+
+	lists, err := s.clientset.Discovery().ServerPreferredNamespacedResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("failed to discover resource types: %w", err)
+	}
+
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") || !hasVerbs(r, "list", "get", "create") {
+				continue
+			}
+			if !selected(r, gv.Group, opts) {
+				continue
+			}
+
+			items, err := s.dynamicClient.Resource(gv.WithResource(r.Name)).Namespace(opts.Namespace).
+				List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s: %w", r.Name, err)
+			}
+
+			var found []*unstructured.Unstructured
+			for i := range items.Items {
+				item := &items.Items[i]
+				if metav1.GetControllerOfNoCopy(item) != nil || generated(item) {
+					continue
+				}
+				found = append(found, resources.Clean(item))
+			}
+			sort.Slice(found, func(i, j int) bool {
+				return found[i].GetName() < found[j].GetName()
+			})
+			objs = append(objs, found...)
+		}
+	}
+
+	return objs, nil
+}
+
+func hasVerbs(r metav1.APIResource, verbs ...string) bool {
+	for _, verb := range verbs {
+		if !slices.Contains(r.Verbs, verb) {
+			return false
+		}
+	}
+	return true
+}
+
+// selected applies the include and exclude lists, and skips runtime state unless it is included
+func selected(r metav1.APIResource, group string, opts BackupOptions) bool {
+	if matches(r, group, opts.Exclude) {
+		return false
+	}
+	if len(opts.Include) > 0 {
+		return matches(r, group, opts.Include)
+	}
+	return !runtimeResources[schema.GroupResource{Group: group, Resource: r.Name}]
+}
+
+// matches reports whether any of the names refers to the resource type
+func matches(r metav1.APIResource, group string, names []string) bool {
+	for _, name := range names {
+		name = strings.ToLower(name)
+		if name == strings.ToLower(r.Kind) || name == r.Name || name == r.SingularName ||
+			name == r.Name+"."+group || slices.Contains(r.ShortNames, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// generated reports whether an object is created automatically in every namespace
+func generated(obj *unstructured.Unstructured) bool {
+	switch obj.GetKind() {
+	case "ConfigMap":
+		return obj.GetName() == "kube-root-ca.crt"
+	case "ServiceAccount":
+		return obj.GetName() == "default"
+	case "Secret":
+		secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+		return secretType == "kubernetes.io/service-account-token"
+	}
+	return false
+}
+
+// Restore applies backed up objects in dependency order. Server-side apply takes ownership of
+// conflicting fields, so the backup wins over changes made since.
+func (s *service) Restore(ctx context.Context, objs []*unstructured.Unstructured, opts RestoreOptions) ([]resources.Result, error) {
+	source := sourceNamespace(objs)
+	target := source
+	if opts.Into != "" {
+		target = opts.Into
+	}
+	if target == "" {
+		return nil, errs.Validationf("backup does not contain any namespaced objects")
+	}
+
+	restored := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		obj = obj.DeepCopy()
+		if obj.GetKind() == "Namespace" {
+			obj.SetName(target)
+		} else {
+			obj.SetNamespace(target)
+		}
+		if obj.GetKind() == "RoleBinding" && source != target {
+			retargetSubjects(obj, source, target)
+		}
+		restored = append(restored, obj)
+	}
+
+	sort.SliceStable(restored, func(i, j int) bool {
+		return installRank(restored[i].GetKind()) < installRank(restored[j].GetKind())
+	})
+
+	return s.resourceService.Apply(ctx, restored, resources.ApplyOptions{
+		Namespace:    target,
+		FieldManager: opts.FieldManager,
+		Force:        true,
+		DryRun:       opts.DryRun,
+	})
+}
+
+// sourceNamespace returns the namespace a backup was taken from
+func sourceNamespace(objs []*unstructured.Unstructured) string {
+	for _, obj := range objs {
+		if obj.GetKind() == "Namespace" {
+			return obj.GetName()
+		}
+	}
+	for _, obj := range objs {
+		if obj.GetNamespace() != "" {
+			return obj.GetNamespace()
+		}
+	}
+	return ""
+}
+
+// retargetSubjects moves service account subjects of the backed up namespace to the target
+func retargetSubjects(obj *unstructured.Unstructured, source, target string) {
+	subjects, found, _ := unstructured.NestedSlice(obj.Object, "subjects")
+	if !found {
+		return
+	}
+	for _, subject := range subjects {
+		if m, ok := subject.(map[string]interface{}); ok && m["namespace"] == source {
+			m["namespace"] = target
+		}
+	}
+	_ = unstructured.SetNestedSlice(obj.Object, subjects, "subjects")
+}
+
+func installRank(kind string) int {
+	if i := slices.Index(installOrder, kind); i >= 0 {
+		return i
+	}
+	return len(installOrder)
+}
@@ -0,0 +1,26 @@
+package backup
+
+// BackupOptions selects what is backed up
+type BackupOptions struct {
+	Namespace string
+
+	// Include limits the backup to these resource types. Types are matched by kind, resource
+	// name, short name or resource.group. When empty, every type except events, endpoints,
+	// leases and other runtime state is backed up.
+	Include []string
+
+	// Exclude skips these resource types
+	Exclude []string
+}
+
+// RestoreOptions configures a restore
+type RestoreOptions struct {
+	// Into restores into this namespace instead of the backed up one
+	Into string
+
+	// FieldManager owns the restored fields; the resources default when empty
+	FieldManager string
+
+	// DryRun submits the requests without persisting them
+	DryRun bool
+}
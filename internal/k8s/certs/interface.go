@@ -0,0 +1,26 @@
+package certs
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Service defines the interface for certificate expiry checks
+type Service interface {
+	// Check inspects TLS secrets, webhook CA bundles and the API server certificate
+	Check(ctx context.Context, opts Options) (*Report, error)
+}
+
+// NewCertService creates a new certificate check service instance
+func NewCertService(clientset *kubernetes.Clientset, config *rest.Config) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	if config == nil {
+		return nil, fmt.Errorf("rest config is required")
+	}
+	return newService(clientset, config), nil
+}
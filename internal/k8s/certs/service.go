@@ -0,0 +1,206 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// dialTimeout bounds the TLS handshake with the API server
+const dialTimeout = 5 * time.Second
+
+type service struct {
+	clientset *kubernetes.Clientset
+	config    *rest.Config
+}
+
+// newService creates a new certificate check service instance
+func newService(clientset *kubernetes.Clientset, config *rest.Config) Service {
+	return &service{
+		clientset: clientset,
+		config:    config,
+	}
+}
+
+// Check inspects TLS secrets, webhook CA bundles and the API server certificate
+func (s *service) Check(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 30 * 24 * time.Hour
+	}
+
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	var certs []Certificate
+
+	secrets, err := s.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "type=" + string(corev1.SecretTypeTLS),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	for _, secret := range secrets.Items {
+		certs = append(certs, parseBundle(TLSSecret, secret.Namespace, secret.Name, secret.Data[corev1.TLSCertKey])...)
+	}
+
+	webhookCerts, err := s.webhookCertificates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	certs = append(certs, webhookCerts...)
+
+	certs = append(certs, s.apiServerCertificate())
+
+	now := time.Now()
+	for i := range certs {
+		c := &certs[i]
+		if c.Error != "" {
+			continue
+		}
+		c.Remaining = c.NotAfter.Sub(now)
+		c.Expired = c.Remaining <= 0
+		c.Expiring = c.Remaining < opts.Threshold
+	}
+
+	// Unreadable certificates sort first so they are not overlooked
+	sort.SliceStable(certs, func(i, j int) bool {
+		a, b := certs[i], certs[j]
+		if (a.Error != "") != (b.Error != "") {
+			return a.Error != ""
+		}
+		return a.NotAfter.Before(b.NotAfter)
+	})
+
+	return &Report{Threshold: opts.Threshold, Certificates: certs}, nil
+}
+
+// webhookCertificates returns the CA bundles of all validating and mutating webhooks
+func (s *service) webhookCertificates(ctx context.Context) ([]Certificate, error) {
+	var certs []Certificate
+
+	validating, err := s.clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validating webhook configurations: %w", err)
+	}
+	for _, cfg := range validating.Items {
+		for _, wh := range cfg.Webhooks {
+			certs = append(certs, webhookBundle(cfg.Name, wh.Name, wh.ClientConfig)...)
+		}
+	}
+
+	mutating, err := s.clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mutating webhook configurations: %w", err)
+	}
+	for _, cfg := range mutating.Items {
+		for _, wh := range cfg.Webhooks {
+			certs = append(certs, webhookBundle(cfg.Name, wh.Name, wh.ClientConfig)...)
+		}
+	}
+
+	return certs, nil
+}
+
+func webhookBundle(config, webhook string, clientConfig admissionregistrationv1.WebhookClientConfig) []Certificate {
+	// Webhooks without a bundle are verified against the API server's system roots
+	if len(clientConfig.CABundle) == 0 {
+		return nil
+	}
+	return parseBundle(Webhook, "", config+"/"+webhook, clientConfig.CABundle)
+}
+
+// apiServerCertificate reads the serving certificate of the API server with a TLS handshake
+func (s *service) apiServerCertificate() Certificate {
+	cert := Certificate{Source: APIServer, Name: s.config.Host}
+
+	u, err := url.Parse(s.config.Host)
+	if err != nil || u.Host == "" {
+		cert.Error = fmt.Sprintf("invalid API server address %q", s.config.Host)
+		return cert
+	}
+	if u.Scheme == "http" {
+		cert.Error = "API server is not served over TLS"
+		return cert
+	}
+	cert.Name = u.Host
+
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	serverName := s.config.TLSClientConfig.ServerName
+	if serverName == "" {
+		serverName = u.Hostname()
+	}
+
+	// Only the expiry is read, so the chain does not need to be trusted here
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", address, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		cert.Error = fmt.Sprintf("failed to connect: %v", err)
+		return cert
+	}
+	defer conn.Close()
+
+	peers := conn.ConnectionState().PeerCertificates
+	if len(peers) == 0 {
+		cert.Error = "no certificate presented"
+		return cert
+	}
+	fill(&cert, peers[0])
+	return cert
+}
+
+// parseBundle returns every certificate in a PEM bundle, or a single entry describing the error
+func parseBundle(source Source, namespace, name string, data []byte) []Certificate {
+	var certs []Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert := Certificate{Source: source, Namespace: namespace, Name: name}
+		parsed, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			cert.Error = fmt.Sprintf("failed to parse certificate: %v", err)
+		} else {
+			fill(&cert, parsed)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		certs = append(certs, Certificate{Source: source, Namespace: namespace, Name: name, Error: "no PEM certificate found"})
+	}
+	return certs
+}
+
+func fill(cert *Certificate, parsed *x509.Certificate) {
+	cert.Subject = parsed.Subject.CommonName
+	if cert.Subject == "" {
+		cert.Subject = parsed.Subject.String()
+	}
+	cert.Issuer = parsed.Issuer.CommonName
+	cert.NotAfter = parsed.NotAfter
+}
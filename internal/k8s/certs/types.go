@@ -0,0 +1,55 @@
+package certs
+
+import (
+	"time"
+)
+
+// Source identifies where a certificate was found
+type Source string
+
+const (
+	// TLSSecret is a certificate stored in a kubernetes.io/tls secret
+	TLSSecret Source = "secret"
+	// Webhook is a CA bundle of a validating or mutating webhook
+	Webhook Source = "webhook"
+	// APIServer is the serving certificate of the API server
+	APIServer Source = "apiserver"
+)
+
+// Options configures the check
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+
+	// Threshold marks certificates expiring within this window
+	Threshold time.Duration
+}
+
+// Certificate describes a single certificate and its expiry
+type Certificate struct {
+	Source    Source `json:"source"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the secret, webhook configuration/webhook or API server host
+	Name    string `json:"name"`
+	Subject string `json:"subject,omitempty"`
+	Issuer  string `json:"issuer,omitempty"`
+
+	NotAfter  time.Time     `json:"notAfter"`
+	Remaining time.Duration `json:"remaining"`
+
+	// Expired reports whether NotAfter has passed
+	Expired bool `json:"expired"`
+
+	// Expiring reports whether the certificate expires within the threshold
+	Expiring bool `json:"expiring"`
+
+	// Error is set when the certificate could not be read or parsed
+	Error string `json:"error,omitempty"`
+}
+
+// Report contains every certificate found, soonest expiry first
+type Report struct {
+	Threshold    time.Duration `json:"threshold"`
+	Certificates []Certificate `json:"certificates"`
+}
@@ -0,0 +1,27 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+
+	"k8stool/internal/k8s/resources"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for finding and removing finished workloads
+type Service interface {
+	// Find lists evicted pods, completed pods and completed jobs matching the options
+	Find(ctx context.Context, opts Options) ([]Candidate, error)
+
+	// Delete removes candidates, deleting the pods of jobs with them
+	Delete(ctx context.Context, candidates []Candidate, dryRun bool) ([]resources.Result, error)
+}
+
+// NewCleanupService creates a new cleanup service instance
+func NewCleanupService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
@@ -0,0 +1,203 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new cleanup service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// Find lists evicted pods, completed pods and completed jobs matching the options
+func (s *service) Find(ctx context.Context, opts Options) ([]Candidate, error) {
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+	cutoff := time.Now().Add(-opts.OlderThan)
+
+	var candidates []Candidate
+	for _, kind := range opts.Kinds {
+		var found []Candidate
+		var err error
+
+		switch kind {
+		case EvictedPods, CompletedPods:
+			found, err = s.findPods(ctx, namespace, kind)
+		case CompletedJobs:
+			found, err = s.findJobs(ctx, namespace)
+		default:
+			return nil, errs.Validationf("unsupported kind: %s", kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range found {
+			if !c.Finished.After(cutoff) {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return candidates, nil
+}
+
+func (s *service) findPods(ctx context.Context, namespace string, kind Kind) ([]Candidate, error) {
+	phase := corev1.PodFailed
+	if kind == CompletedPods {
+		phase = corev1.PodSucceeded
+	}
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase=" + string(phase),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var candidates []Candidate
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		reason := "completed"
+		switch kind {
+		case EvictedPods:
+			if pod.Status.Reason != "Evicted" {
+				continue
+			}
+			reason = pod.Status.Message
+		case CompletedPods:
+			// Job pods are removed together with their job
+			if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "Job" {
+				continue
+			}
+		}
+		candidates = append(candidates, Candidate{
+			Kind:      kind,
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Finished:  podFinished(pod),
+			Reason:    reason,
+		})
+	}
+	return candidates, nil
+}
+
+func (s *service) findJobs(ctx context.Context, namespace string) ([]Candidate, error) {
+	jobList, err := s.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	var candidates []Candidate
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		// The history of CronJob runs is pruned by the CronJob's history limits
+		if owner := metav1.GetControllerOf(job); owner != nil && owner.Kind == "CronJob" {
+			continue
+		}
+		if !jobComplete(job) {
+			continue
+		}
+		finished := job.CreationTimestamp.Time
+		if job.Status.CompletionTime != nil {
+			finished = job.Status.CompletionTime.Time
+		}
+		candidates = append(candidates, Candidate{
+			Kind:      CompletedJobs,
+			Namespace: job.Namespace,
+			Name:      job.Name,
+			Finished:  finished,
+			Reason:    fmt.Sprintf("%d succeeded", job.Status.Succeeded),
+		})
+	}
+	return candidates, nil
+}
+
+// Delete removes candidates, deleting the pods of jobs with them
+func (s *service) Delete(ctx context.Context, candidates []Candidate, dryRun bool) ([]resources.Result, error) {
+	propagation := metav1.DeletePropagationBackground
+	opts := metav1.DeleteOptions{PropagationPolicy: &propagation}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	results := make([]resources.Result, 0, len(candidates))
+	for _, c := range candidates {
+		result := resources.Result{Namespace: c.Namespace, Name: c.Name, Action: resources.Deleted}
+
+		var err error
+		switch c.Kind {
+		case EvictedPods, CompletedPods:
+			result.Kind = "Pod"
+			err = s.clientset.CoreV1().Pods(c.Namespace).Delete(ctx, c.Name, opts)
+		case CompletedJobs:
+			result.Kind, result.Group = "Job", "batch"
+			err = s.clientset.BatchV1().Jobs(c.Namespace).Delete(ctx, c.Name, opts)
+		default:
+			err = errs.Validationf("unsupported kind: %s", c.Kind)
+		}
+
+		switch {
+		case apierrors.IsNotFound(err):
+			result.Action = resources.NotFound
+		case err != nil:
+			if ctx.Err() != nil {
+				return results, ctx.Err()
+			}
+			result.Action = resources.Failed
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// podFinished returns when the last container of a pod terminated, or when the pod was created
+func podFinished(pod *corev1.Pod) time.Time {
+	finished := pod.CreationTimestamp.Time
+	for _, cs := range pod.Status.ContainerStatuses {
+		if t := cs.State.Terminated; t != nil && t.FinishedAt.After(finished) {
+			finished = t.FinishedAt.Time
+		}
+	}
+	return finished
+}
+
+func jobComplete(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobComplete && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
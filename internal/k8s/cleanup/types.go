@@ -0,0 +1,38 @@
+package cleanup
+
+import (
+	"time"
+)
+
+// Kind is a category of leftover object
+type Kind string
+
+const (
+	// EvictedPods are pods that failed because the kubelet evicted them
+	EvictedPods Kind = "evicted-pods"
+	// CompletedPods are succeeded pods that are not owned by a job
+	CompletedPods Kind = "completed-pods"
+	// CompletedJobs are successfully completed jobs that are not owned by a CronJob
+	CompletedJobs Kind = "completed-jobs"
+)
+
+// Options selects what to clean up
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+
+	// Kinds are the categories to look for
+	Kinds []Kind
+
+	// OlderThan only selects objects that finished at least this long ago
+	OlderThan time.Duration
+}
+
+// Candidate is an object that can be cleaned up
+type Candidate struct {
+	Kind      Kind      `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Finished  time.Time `json:"finished"`
+	Reason    string    `json:"reason"`
+}
@@ -3,20 +3,66 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"io"
+	"k8stool/internal/k8s/apis"
+	"k8stool/internal/k8s/argo"
+	"k8stool/internal/k8s/audit"
+	"k8stool/internal/k8s/backup"
+	"k8stool/internal/k8s/certs"
+	"k8stool/internal/k8s/cleanup"
+	"k8stool/internal/k8s/compare"
 	ctx "k8stool/internal/k8s/context"
+	"k8stool/internal/k8s/cost"
+	"k8stool/internal/k8s/cronjobs"
 	"k8stool/internal/k8s/deployments"
+	"k8stool/internal/k8s/deprecations"
 	desc "k8stool/internal/k8s/describe"
+	"k8stool/internal/k8s/doctor"
+	"k8stool/internal/k8s/errs"
 	"k8stool/internal/k8s/events"
 	ex "k8stool/internal/k8s/exec"
+	"k8stool/internal/k8s/favorites"
+	"k8stool/internal/k8s/images"
+	"k8stool/internal/k8s/jobs"
+	"k8stool/internal/k8s/lint"
 	"k8stool/internal/k8s/logs"
 	"k8stool/internal/k8s/metrics"
 	ns "k8stool/internal/k8s/namespace"
+	"k8stool/internal/k8s/netcheck"
+	"k8stool/internal/k8s/nodes"
+	"k8stool/internal/k8s/oom"
+	"k8stool/internal/k8s/orphans"
 	"k8stool/internal/k8s/pods"
 	pf "k8stool/internal/k8s/portforward"
-
+	"k8stool/internal/k8s/prometheus"
+	"k8stool/internal/k8s/quota"
+	"k8stool/internal/k8s/rbac"
+	"k8stool/internal/k8s/recommend"
+	"k8stool/internal/k8s/report"
+	"k8stool/internal/k8s/resources"
+	"k8stool/internal/k8s/restarts"
+	"k8stool/internal/k8s/scan"
+	"k8stool/internal/k8s/scheduling"
+	"k8stool/internal/k8s/services"
+	"k8stool/internal/k8s/snapshot"
+	"k8stool/internal/k8s/timeline"
+	"k8stool/internal/k8s/tree"
+	"k8stool/internal/k8s/troubleshoot"
+	"k8stool/internal/k8s/usage"
+	"k8stool/internal/k8s/validate"
+	"k8stool/internal/k8s/watcher"
+	"k8stool/internal/k8s/webhooks"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
@@ -29,12 +75,18 @@ type Volume = pods.Volume
 type VolumeMount = pods.VolumeMount
 type Event = pods.Event
 type ListOptions = pods.ListOptions
+type PodWatchOptions = pods.WatchOptions
+type PodWatchEvent = pods.WatchEvent
+type PodEvictOptions = pods.EvictOptions
 
 // Type aliases for deployments package
 type Deployment = deployments.Deployment
 type DeploymentDetails = deployments.DeploymentDetails
 type DeploymentMetrics = deployments.DeploymentMetrics
 type DeploymentOptions = deployments.DeploymentOptions
+type DeploymentTemplateChange = deployments.TemplateChange
+type RolloutOptions = deployments.RolloutOptions
+type RolloutStatus = deployments.RolloutStatus
 
 // Type aliases for events package
 type EventType = events.EventType
@@ -42,6 +94,9 @@ type EventList = events.EventList
 type EventFilter = events.EventFilter
 type EventSortOption = events.EventSortOption
 type EventOptions = events.EventOptions
+type EventStatsOptions = events.StatsOptions
+type EventStats = events.Stats
+type EventReasonStats = events.ReasonStats
 
 // Type aliases for namespace package
 type Namespace = ns.Namespace
@@ -92,6 +147,192 @@ type ResourceDescription = desc.ResourceDescription
 type ContainerDetails = desc.ContainerDetails
 type VolumeDetails = desc.VolumeDetails
 type ResourceRequirements = desc.ResourceRequirements
+type ConfigDataDetails = desc.ConfigDataDetails
+type NodeDetails = desc.NodeDetails
+
+// Type aliases for doctor package
+type DoctorReport = doctor.Report
+type DoctorOptions = doctor.Options
+type CheckResult = doctor.CheckResult
+
+// Type aliases for troubleshoot package
+type PodReport = troubleshoot.PodReport
+type TroubleshootOptions = troubleshoot.Options
+
+// Type aliases for recommend package
+type RecommendOptions = recommend.Options
+type RecommendReport = recommend.Report
+type Recommendation = recommend.Recommendation
+
+// Type aliases for orphans package
+type Orphan = orphans.Orphan
+type OrphanOptions = orphans.Options
+
+// Type aliases for scheduling package
+type SchedulingAnalysis = scheduling.Analysis
+type NodeFit = scheduling.NodeFit
+
+// Type aliases for oom package
+type OOMReport = oom.Report
+type OOMOptions = oom.Options
+
+// Type aliases for nodes package
+type DrainOptions = nodes.DrainOptions
+type DrainPlan = nodes.DrainPlan
+type DrainSimulation = nodes.DrainSimulation
+type DrainEvent = nodes.DrainEvent
+type NodeAllocationOptions = nodes.AllocationOptions
+type NodeAllocation = nodes.Allocation
+type NodeShellOptions = nodes.ShellOptions
+type NodeShellPod = nodes.ShellPod
+type NodeLogOptions = nodes.LogOptions
+type NodeListOptions = nodes.ListOptions
+type NodeInfo = nodes.Node
+type NodeCloudInfo = nodes.CloudInfo
+
+// Type aliases for certs package
+type CertReport = certs.Report
+type CertOptions = certs.Options
+
+// Type aliases for lint package
+type LintFinding = lint.Finding
+type LintOptions = lint.Options
+
+// Type aliases for audit package
+type AuditFinding = audit.Finding
+type AuditOptions = audit.Options
+
+// Type aliases for netcheck package
+type ConnectivityOptions = netcheck.ConnectivityOptions
+type ConnectivityReport = netcheck.ConnectivityReport
+type DNSOptions = netcheck.DNSOptions
+type DNSReport = netcheck.DNSReport
+
+// Type aliases for resources package
+type ApplyOptions = resources.ApplyOptions
+type ResourceResult = resources.Result
+type ResourceListOptions = resources.ListOptions
+type DeleteOptions = resources.DeleteOptions
+type MetadataOptions = resources.MetadataOptions
+type RestartOptions = resources.RestartOptions
+type Document = resources.Document
+type Comparison = resources.Comparison
+
+// Type aliases for backup package
+type BackupOptions = backup.BackupOptions
+type RestoreOptions = backup.RestoreOptions
+
+// Type aliases for compare package
+type NamespaceSnapshot = compare.Snapshot
+type Difference = compare.Difference
+
+// Type aliases for report package
+type ReportOptions = report.Options
+type Inventory = report.Inventory
+
+// Type aliases for rbac package
+type AccessOptions = rbac.AccessOptions
+type Access = rbac.Access
+type Grant = rbac.Grant
+
+// Type aliases for argo package
+type ArgoOptions = argo.Options
+type ArgoApplication = argo.Application
+
+// Type aliases for deprecations package
+type DeprecationOptions = deprecations.Options
+type DeprecationReport = deprecations.Report
+
+// Type aliases for apis package
+type APIResource = apis.Resource
+type APIResourceOptions = apis.ResourceOptions
+type ExplainOptions = apis.ExplainOptions
+type Explanation = apis.Explanation
+
+// Type aliases for scan package
+type ScanOptions = scan.Options
+type ScanReport = scan.Report
+
+// Type aliases for services package
+type ServiceHealth = services.Health
+
+// Type aliases for cleanup package
+type CleanupOptions = cleanup.Options
+type CleanupCandidate = cleanup.Candidate
+
+// Type aliases for timeline package
+type Timeline = timeline.Timeline
+type TimelineOptions = timeline.Options
+type TimelineEntry = timeline.Entry
+
+// Type aliases for cost package
+type CostOptions = cost.Options
+type CostReport = cost.Report
+type WorkloadCost = cost.WorkloadCost
+
+// Type aliases for usage package
+type UsageOptions = usage.Options
+type UsageReport = usage.Report
+type UsageGroup = usage.Group
+
+// Type aliases for quota package
+type QuotaHeadroom = quota.Headroom
+type QuotaResourceHeadroom = quota.ResourceHeadroom
+
+// Type aliases for webhooks package
+type WebhookListOptions = webhooks.ListOptions
+type Webhook = webhooks.Webhook
+type WebhookProbeResult = webhooks.ProbeResult
+
+// Type aliases for images package
+type ImageTagOptions = images.TagOptions
+type ImageTagReport = images.TagReport
+type ImagePinOptions = images.PinOptions
+type ImagePinFinding = images.PinFinding
+type ImageContainerTags = images.ContainerTags
+
+// Type aliases for snapshot package
+type Snapshot = snapshot.Snapshot
+type SnapshotOptions = snapshot.CreateOptions
+
+// Type aliases for watcher package
+type WatchOptions = watcher.Options
+type WatchEvent = watcher.Event
+
+// Type aliases for favorites package
+type Favorite = favorites.Favorite
+type FavoriteStatus = favorites.Status
+type FavoriteStatusOptions = favorites.StatusOptions
+
+// Type aliases for cronjobs package
+type CronJob = cronjobs.CronJob
+type CronJobRun = cronjobs.Run
+type CronJobListOptions = cronjobs.ListOptions
+type CronJobHistoryOptions = cronjobs.HistoryOptions
+
+// Type aliases for jobs package
+type JobAttempt = jobs.Attempt
+type JobLogOptions = jobs.LogOptions
+
+// Type aliases for prometheus package
+type PrometheusSource = prometheus.Source
+type PromQueryOptions = prometheus.QueryOptions
+type PromQueryResult = prometheus.QueryResult
+type PromSample = prometheus.Sample
+type PrometheusProfile = prometheus.Profile
+type HTTPPanelOptions = prometheus.PanelOptions
+type HTTPPanel = prometheus.HTTPPanel
+
+// Type aliases for restarts package
+type RestartSampleOptions = restarts.Options
+type RestartSample = restarts.Sample
+
+// Type aliases for tree package
+type TreeNode = tree.Node
+
+// Type aliases for validate package
+type ValidateOptions = validate.Options
+type ValidationResult = validate.Result
 
 type Client struct {
 	clientset          *kubernetes.Clientset
@@ -109,13 +350,136 @@ type Client struct {
 	ExecService        ex.ExecService
 	PortForwardService pf.Service
 	DescribeSvc        desc.DescribeService
+	DoctorService      doctor.Service
+	TroubleshootSvc    troubleshoot.Service
+	RecommendService   recommend.Service
+	OrphanService      orphans.Service
+	SchedulingService  scheduling.Service
+	OOMService         oom.Service
+	NodeService        nodes.Service
+	CertService        certs.Service
+	LintService        lint.Service
+	AuditService       audit.Service
+	NetCheckService    netcheck.Service
+	ResourceService    resources.Service
+	ValidateService    validate.Service
+	BackupService      backup.Service
+	CompareService     compare.Service
+	ReportService      report.Service
+	RBACService        rbac.Service
+	ScanService        scan.Service
+	ArgoService        argo.Service
+	DeprecationService deprecations.Service
+	APIService         apis.Service
+	TreeService        tree.Service
+	ServiceService     services.Service
+	CleanupService     cleanup.Service
+	TimelineService    timeline.Service
+	UsageService       usage.Service
+	CostService        cost.Service
+	QuotaService       quota.Service
+	WebhookService     webhooks.Service
+	ImageService       images.Service
+	SnapshotService    snapshot.Service
+	WatcherService     watcher.Service
+	FavoritesService   favorites.Service
+	CronJobService     cronjobs.Service
+	JobService         jobs.Service
+	PrometheusService  prometheus.Service
+	RestartService     restarts.Service
+}
+
+// impersonation is applied to every client created with NewClient or NewClientForContext after
+// SetImpersonation
+var impersonation struct {
+	user   string
+	groups []string
+}
+
+// SetImpersonation makes clients act as another user and groups, like kubectl --as and --as-group
+func SetImpersonation(user string, groups []string) {
+	impersonation.user = user
+	impersonation.groups = groups
+}
+
+// snapshotSource replaces the cluster with a snapshot for every client created after SetSnapshot.
+// The snapshot is served once per process from a read-only API server on the loopback interface.
+var snapshotSource struct {
+	path string
+	once sync.Once
+	snap *snapshot.Snapshot
+	url  string
+	err  error
+}
+
+// SetSnapshot makes clients read from a snapshot file instead of the cluster
+func SetSnapshot(path string) {
+	snapshotSource.path = path
+}
+
+// snapshotClientConfig returns a client config pointing at the server of the snapshot, with a
+// context named after the one the snapshot was taken from
+func snapshotClientConfig(contextName string) (clientcmd.ClientConfig, error) {
+	snapshotSource.once.Do(func() {
+		snap, err := snapshot.Load(snapshotSource.path)
+		if err != nil {
+			snapshotSource.err = err
+			return
+		}
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			snapshotSource.err = fmt.Errorf("failed to serve snapshot: %w", err)
+			return
+		}
+		go func() { _ = http.Serve(listener, snapshot.Handler(snap)) }()
+		snapshotSource.snap = snap
+		snapshotSource.url = "http://" + listener.Addr().String()
+	})
+	if snapshotSource.err != nil {
+		return nil, snapshotSource.err
+	}
+
+	snap := snapshotSource.snap
+	name := snap.Context
+	if name == "" {
+		name = "snapshot"
+	}
+	config := clientcmdapi.NewConfig()
+	config.Clusters[name] = &clientcmdapi.Cluster{Server: snapshotSource.url}
+	config.AuthInfos[name] = &clientcmdapi.AuthInfo{}
+	config.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name, Namespace: snap.Namespace}
+	config.CurrentContext = name
+	return clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{CurrentContext: contextName}), nil
 }
 
 func NewClient() (*Client, error) {
-	// Load kubeconfig
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-	configOverrides := &clientcmd.ConfigOverrides{}
-	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
+	return NewClientForContext("")
+}
+
+// NewClientForContext creates a client for a kubeconfig context, or the current context if empty.
+// The client impersonates the user and groups set with SetImpersonation.
+func NewClientForContext(contextName string) (*Client, error) {
+	return NewClientForContextWithOptions(contextName, impersonation.user, impersonation.groups)
+}
+
+// NewClientForContextWithOptions creates a client for a kubeconfig context that acts as user and
+// groups, like kubectl --as and --as-group, whatever was set with SetImpersonation. An empty user
+// and no groups impersonate nobody.
+func NewClientForContextWithOptions(contextName, user string, groups []string) (*Client, error) {
+	// Load kubeconfig, or serve the snapshot in its place
+	var kubeConfig clientcmd.ClientConfig
+	if snapshotSource.path != "" {
+		var err error
+		if kubeConfig, err = snapshotClientConfig(contextName); err != nil {
+			return nil, err
+		}
+	} else {
+		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+		configOverrides := &clientcmd.ConfigOverrides{CurrentContext: contextName}
+		configOverrides.AuthInfo.Impersonate = user
+		configOverrides.AuthInfo.ImpersonateGroups = groups
+		kubeConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
+	}
 
 	// Get config
 	config, err := kubeConfig.ClientConfig()
@@ -129,6 +493,12 @@ func NewClient() (*Client, error) {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
+	// Create dynamic client
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
 	// Create metrics client
 	metricsClient, err := metricsv1beta1.NewForConfig(config)
 	if err != nil {
@@ -216,6 +586,265 @@ func NewClient() (*Client, error) {
 	}
 	client.DescribeSvc = describeService
 
+	// Initialize doctor service
+	doctorService, err := doctor.NewDoctorService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create doctor service: %w", err)
+	}
+	client.DoctorService = doctorService
+
+	// Initialize troubleshoot service
+	troubleshootService, err := troubleshoot.NewTroubleshootService(clientset, client.PodService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create troubleshoot service: %w", err)
+	}
+	client.TroubleshootSvc = troubleshootService
+
+	// Initialize recommend service
+	recommendService, err := recommend.NewRecommendService(client.PodService, client.MetricsService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recommend service: %w", err)
+	}
+	client.RecommendService = recommendService
+
+	// Initialize orphan service
+	orphanService, err := orphans.NewOrphanService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create orphan service: %w", err)
+	}
+	client.OrphanService = orphanService
+
+	// Initialize scheduling service
+	schedulingService, err := scheduling.NewSchedulingService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scheduling service: %w", err)
+	}
+	client.SchedulingService = schedulingService
+
+	// Initialize oom service
+	oomService, err := oom.NewOOMService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oom service: %w", err)
+	}
+	client.OOMService = oomService
+
+	// Initialize node service
+	nodeService, err := nodes.NewNodeService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create node service: %w", err)
+	}
+	client.NodeService = nodeService
+
+	// Initialize cert service
+	certService, err := certs.NewCertService(clientset, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cert service: %w", err)
+	}
+	client.CertService = certService
+
+	// Initialize lint service
+	lintService, err := lint.NewLintService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lint service: %w", err)
+	}
+	client.LintService = lintService
+
+	// Initialize audit service
+	auditService, err := audit.NewAuditService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit service: %w", err)
+	}
+	client.AuditService = auditService
+
+	// Initialize netcheck service
+	netCheckService, err := netcheck.NewNetCheckService(clientset, execService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create netcheck service: %w", err)
+	}
+	client.NetCheckService = netCheckService
+
+	// Initialize resource service
+	resourceService, err := resources.NewResourceService(clientset, dynamicClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource service: %w", err)
+	}
+	client.ResourceService = resourceService
+
+	// Initialize validate service
+	validateService, err := validate.NewValidateService(clientset, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validate service: %w", err)
+	}
+	client.ValidateService = validateService
+
+	// Initialize backup service
+	backupService, err := backup.NewBackupService(clientset, dynamicClient, resourceService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup service: %w", err)
+	}
+	client.BackupService = backupService
+
+	// Initialize compare service
+	compareService, err := compare.NewCompareService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compare service: %w", err)
+	}
+	client.CompareService = compareService
+
+	// Initialize report service
+	reportService, err := report.NewReportService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report service: %w", err)
+	}
+	client.ReportService = reportService
+
+	// Initialize rbac service
+	rbacService, err := rbac.NewRBACService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rbac service: %w", err)
+	}
+	client.RBACService = rbacService
+
+	// Initialize scan service
+	scanService, err := scan.NewScanService(resourceService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scan service: %w", err)
+	}
+	client.ScanService = scanService
+
+	// Initialize argo service
+	argoService, err := argo.NewArgoService(dynamicClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create argo service: %w", err)
+	}
+	client.ArgoService = argoService
+
+	// Initialize deprecation service
+	deprecationService, err := deprecations.NewDeprecationService(clientset, dynamicClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deprecation service: %w", err)
+	}
+	client.DeprecationService = deprecationService
+
+	// Initialize API discovery service
+	apiService, err := apis.NewAPIService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API discovery service: %w", err)
+	}
+	client.APIService = apiService
+
+	// Initialize tree service
+	treeService, err := tree.NewTreeService(resourceService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tree service: %w", err)
+	}
+	client.TreeService = treeService
+
+	// Initialize service inspection service
+	serviceService, err := services.NewServiceService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service inspection service: %w", err)
+	}
+	client.ServiceService = serviceService
+
+	// Initialize cleanup service
+	cleanupService, err := cleanup.NewCleanupService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cleanup service: %w", err)
+	}
+	client.CleanupService = cleanupService
+
+	// Initialize timeline service
+	timelineService, err := timeline.NewTimelineService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create timeline service: %w", err)
+	}
+	client.TimelineService = timelineService
+
+	// Initialize usage service
+	usageService, err := usage.NewUsageService(clientset, client.MetricsService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create usage service: %w", err)
+	}
+	client.UsageService = usageService
+
+	// Initialize cost service
+	costService, err := cost.NewCostService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cost service: %w", err)
+	}
+	client.CostService = costService
+
+	// Initialize quota service
+	quotaService, err := quota.NewQuotaService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quota service: %w", err)
+	}
+	client.QuotaService = quotaService
+
+	// Initialize webhook service
+	webhookService, err := webhooks.NewWebhookService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook service: %w", err)
+	}
+	client.WebhookService = webhookService
+
+	// Initialize image service
+	imageService, err := images.NewImageService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image service: %w", err)
+	}
+	client.ImageService = imageService
+
+	// Initialize snapshot service
+	snapshotService, err := snapshot.NewSnapshotService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot service: %w", err)
+	}
+	client.SnapshotService = snapshotService
+
+	// Initialize watcher service
+	watcherService, err := watcher.NewWatcherService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watcher service: %w", err)
+	}
+	client.WatcherService = watcherService
+
+	// Initialize favorites service
+	favoritesService, err := favorites.NewFavoritesService(clientset, client.MetricsService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create favorites service: %w", err)
+	}
+	client.FavoritesService = favoritesService
+
+	// Initialize cronjob service
+	cronJobService, err := cronjobs.NewCronJobService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cronjob service: %w", err)
+	}
+	client.CronJobService = cronJobService
+
+	// Initialize job service
+	jobService, err := jobs.NewJobService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job service: %w", err)
+	}
+	client.JobService = jobService
+
+	// Initialize prometheus service
+	prometheusService, err := prometheus.NewPrometheusService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus service: %w", err)
+	}
+	client.PrometheusService = prometheusService
+
+	// Initialize restarts service
+	restartService, err := restarts.NewRestartsService(clientset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create restarts service: %w", err)
+	}
+	client.RestartService = restartService
+
 	return client, nil
 }
 
@@ -231,11 +860,25 @@ func (c *Client) AddPodMetrics(pods []Pod) error {
 	return c.PodService.AddMetrics(pods)
 }
 
+func (c *Client) WatchPods(ctx context.Context, opts PodWatchOptions) (<-chan PodWatchEvent, error) {
+	return c.PodService.Watch(ctx, opts)
+}
+
+func (c *Client) EvictPod(ctx context.Context, namespace, name string, opts PodEvictOptions) error {
+	return c.PodService.Evict(ctx, namespace, name, opts)
+}
+
 func (c *Client) GetPodLogs(namespace, name string, container string, opts logs.LogOptions) error {
 	// Set container if provided
 	if container != "" {
 		opts.Container = container
 	}
+	if opts.Container == "" && (opts.AllContainers || opts.InitContainers) {
+		return c.getPodContainersLogs(namespace, name, opts)
+	}
+	if opts.WriterFor != nil {
+		opts.Writer = opts.WriterFor(name, opts.Container)
+	}
 
 	// Get logs
 	result, err := c.LogService.GetLogs(context.Background(), namespace, name, &opts)
@@ -259,6 +902,37 @@ func (c *Client) GetPodLogs(namespace, name string, container string, opts logs.
 	return nil
 }
 
+// getPodContainersLogs writes the logs of the init containers of a pod, followed by those of
+// its other containers with AllContainers. A container that has not started yet is reported
+// and skipped, so the logs of a failed init container are shown even though the containers
+// after it never ran.
+func (c *Client) getPodContainersLogs(namespace, name string, opts logs.LogOptions) error {
+	pod, err := c.PodService.Get(namespace, name)
+	if err != nil {
+		return fmt.Errorf("failed to get logs: %w", err)
+	}
+
+	containers := pod.InitContainers
+	if opts.AllContainers {
+		containers = append(containers, pod.Containers...)
+	}
+	if len(containers) == 0 {
+		return errs.NotFoundf("pod %s has no init containers", name)
+	}
+
+	var failed int
+	for _, container := range containers {
+		if err := c.GetPodLogs(namespace, name, container.Name, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: container %s: %v\n", container.Name, err)
+			failed++
+		}
+	}
+	if failed == len(containers) {
+		return fmt.Errorf("failed to get logs of any container in pod %s", name)
+	}
+	return nil
+}
+
 func (c *Client) ExecInPod(namespace, podName, containerName string, opts ExecOptions) error {
 	result, err := c.ExecService.Exec(context.Background(), namespace, podName, &opts)
 	if err != nil {
@@ -298,10 +972,18 @@ func (c *Client) UpdateDeployment(namespace, name string, opts DeploymentOptions
 	return c.DeploymentService.Update(namespace, name, opts)
 }
 
+func (c *Client) PreviewDeploymentUpdate(namespace, name string, opts DeploymentOptions) (*DeploymentTemplateChange, error) {
+	return c.DeploymentService.PreviewUpdate(namespace, name, opts)
+}
+
 func (c *Client) AddDeploymentMetrics(deployments []Deployment) error {
 	return c.DeploymentService.AddMetrics(deployments)
 }
 
+func (c *Client) WatchRollout(ctx context.Context, namespace, name string, opts RolloutOptions) (*RolloutStatus, error) {
+	return c.DeploymentService.WatchRollout(ctx, namespace, name, opts)
+}
+
 // Event methods
 func (c *Client) ListEvents(ctx context.Context, namespace string, filter *EventFilter) (*EventList, error) {
 	return c.EventService.List(ctx, namespace, filter)
@@ -319,6 +1001,10 @@ func (c *Client) GetEvent(ctx context.Context, namespace, name string) (*events.
 	return c.EventService.Get(ctx, namespace, name)
 }
 
+func (c *Client) EventStats(ctx context.Context, opts EventStatsOptions) (*EventStats, error) {
+	return c.EventService.Stats(ctx, opts)
+}
+
 // Namespace methods
 func (c *Client) ListNamespaces() ([]Namespace, error) {
 	return c.NamespaceService.List()
@@ -458,6 +1144,307 @@ func (c *Client) DescribeNamespace(ctx context.Context, name string) (*ResourceD
 	return c.DescribeSvc.DescribeNamespace(ctx, name)
 }
 
+func (c *Client) DescribeConfigMap(ctx context.Context, namespace, name string) (*ResourceDescription, error) {
+	return c.DescribeSvc.DescribeConfigMap(ctx, namespace, name)
+}
+
+func (c *Client) DescribeSecret(ctx context.Context, namespace, name string) (*ResourceDescription, error) {
+	return c.DescribeSvc.DescribeSecret(ctx, namespace, name)
+}
+
+// Doctor methods
+func (c *Client) RunDoctor(ctx context.Context, opts DoctorOptions) (*DoctorReport, error) {
+	return c.DoctorService.Run(ctx, opts)
+}
+
+// Troubleshoot methods
+func (c *Client) TroubleshootPod(ctx context.Context, namespace, name string, opts TroubleshootOptions) (*PodReport, error) {
+	return c.TroubleshootSvc.Pod(ctx, namespace, name, opts)
+}
+
+// Recommend methods
+func (c *Client) Recommend(ctx context.Context, opts RecommendOptions) (*RecommendReport, error) {
+	return c.RecommendService.Recommend(ctx, opts)
+}
+
+// Orphan methods
+func (c *Client) FindOrphans(ctx context.Context, opts OrphanOptions) ([]Orphan, error) {
+	return c.OrphanService.Find(ctx, opts)
+}
+
+// Scheduling methods
+func (c *Client) WhyPending(ctx context.Context, namespace, name string) (*SchedulingAnalysis, error) {
+	return c.SchedulingService.WhyPending(ctx, namespace, name)
+}
+
+// OOM methods
+func (c *Client) OOMReport(ctx context.Context, opts OOMOptions) (*OOMReport, error) {
+	return c.OOMService.Report(ctx, opts)
+}
+
+// Node methods
+func (c *Client) ListNodes(ctx context.Context, opts NodeListOptions) ([]NodeInfo, error) {
+	return c.NodeService.List(ctx, opts)
+}
+
+func (c *Client) CordonNode(ctx context.Context, name string) error {
+	return c.NodeService.Cordon(ctx, name)
+}
+
+func (c *Client) UncordonNode(ctx context.Context, name string) error {
+	return c.NodeService.Uncordon(ctx, name)
+}
+
+func (c *Client) PlanDrain(ctx context.Context, name string, opts DrainOptions) (*DrainPlan, error) {
+	return c.NodeService.PlanDrain(ctx, name, opts)
+}
+
+func (c *Client) SimulateDrain(ctx context.Context, name string, opts DrainOptions) (*DrainSimulation, error) {
+	return c.NodeService.SimulateDrain(ctx, name, opts)
+}
+
+func (c *Client) DrainNode(ctx context.Context, name string, opts DrainOptions) (*DrainPlan, error) {
+	return c.NodeService.Drain(ctx, name, opts)
+}
+
+func (c *Client) NodeAllocation(ctx context.Context, opts NodeAllocationOptions) ([]NodeAllocation, error) {
+	return c.NodeService.Allocation(ctx, opts)
+}
+
+func (c *Client) ListNodeLogs(ctx context.Context, name, dir string) ([]string, error) {
+	return c.NodeService.ListLogs(ctx, name, dir)
+}
+
+func (c *Client) NodeLogs(ctx context.Context, name string, opts NodeLogOptions) (io.ReadCloser, error) {
+	return c.NodeService.Logs(ctx, name, opts)
+}
+
+func (c *Client) StartNodeShell(ctx context.Context, node string, opts NodeShellOptions) (*NodeShellPod, error) {
+	return c.NodeService.StartShell(ctx, node, opts)
+}
+
+func (c *Client) StopNodeShell(ctx context.Context, shell *NodeShellPod) error {
+	return c.NodeService.StopShell(ctx, shell)
+}
+
+// Cert methods
+func (c *Client) CheckCerts(ctx context.Context, opts CertOptions) (*CertReport, error) {
+	return c.CertService.Check(ctx, opts)
+}
+
+// Lint methods
+func (c *Client) LintProbes(ctx context.Context, opts LintOptions) ([]LintFinding, error) {
+	return c.LintService.Probes(ctx, opts)
+}
+
+// Audit methods
+func (c *Client) AuditSecurity(ctx context.Context, opts AuditOptions) ([]AuditFinding, error) {
+	return c.AuditService.Security(ctx, opts)
+}
+
+// NetCheck methods
+func (c *Client) TestConnectivity(ctx context.Context, opts ConnectivityOptions) (*ConnectivityReport, error) {
+	return c.NetCheckService.Connectivity(ctx, opts)
+}
+
+func (c *Client) CheckDNS(ctx context.Context, opts DNSOptions) (*DNSReport, error) {
+	return c.NetCheckService.DNS(ctx, opts)
+}
+
+// Resource methods
+func (c *Client) Apply(ctx context.Context, objs []*unstructured.Unstructured, opts ApplyOptions) ([]ResourceResult, error) {
+	return c.ResourceService.Apply(ctx, objs, opts)
+}
+
+func (c *Client) Diff(ctx context.Context, objs []*unstructured.Unstructured, opts ApplyOptions) ([]Comparison, error) {
+	return c.ResourceService.Diff(ctx, objs, opts)
+}
+
+func (c *Client) GetResource(ctx context.Context, resourceType, namespace, name string) (*unstructured.Unstructured, error) {
+	return c.ResourceService.Get(ctx, resourceType, namespace, name)
+}
+
+func (c *Client) ListResources(ctx context.Context, resourceType string, opts ResourceListOptions) ([]*unstructured.Unstructured, error) {
+	return c.ResourceService.List(ctx, resourceType, opts)
+}
+
+func (c *Client) UpdateResource(ctx context.Context, obj *unstructured.Unstructured, dryRun bool) (*unstructured.Unstructured, error) {
+	return c.ResourceService.Update(ctx, obj, dryRun)
+}
+
+func (c *Client) SetMetadata(ctx context.Context, objs []*unstructured.Unstructured, opts MetadataOptions) ([]ResourceResult, error) {
+	return c.ResourceService.SetMetadata(ctx, objs, opts)
+}
+
+func (c *Client) Delete(ctx context.Context, objs []*unstructured.Unstructured, opts DeleteOptions) ([]ResourceResult, error) {
+	return c.ResourceService.Delete(ctx, objs, opts)
+}
+
+func (c *Client) Restart(ctx context.Context, objs []*unstructured.Unstructured, opts RestartOptions) ([]ResourceResult, error) {
+	return c.ResourceService.Restart(ctx, objs, opts)
+}
+
+// Backup methods
+func (c *Client) BackupNamespace(ctx context.Context, opts BackupOptions) ([]*unstructured.Unstructured, error) {
+	return c.BackupService.Backup(ctx, opts)
+}
+
+func (c *Client) Restore(ctx context.Context, objs []*unstructured.Unstructured, opts RestoreOptions) ([]ResourceResult, error) {
+	return c.BackupService.Restore(ctx, objs, opts)
+}
+
+// Compare methods
+func (c *Client) SnapshotNamespace(ctx context.Context, namespace string) (*NamespaceSnapshot, error) {
+	return c.CompareService.Snapshot(ctx, namespace)
+}
+
+// RBAC methods
+func (c *Client) CanI(ctx context.Context, opts AccessOptions) (*Access, error) {
+	return c.RBACService.CanI(ctx, opts)
+}
+
+func (c *Client) WhoCan(ctx context.Context, opts AccessOptions) ([]Grant, error) {
+	return c.RBACService.WhoCan(ctx, opts)
+}
+
+// Scan methods
+func (c *Client) ScanWorkload(ctx context.Context, opts ScanOptions) (*ScanReport, error) {
+	return c.ScanService.Scan(ctx, opts)
+}
+
+// Argo methods
+func (c *Client) ArgoApplications(ctx context.Context, opts ArgoOptions) ([]ArgoApplication, error) {
+	return c.ArgoService.Applications(ctx, opts)
+}
+
+// Deprecation methods
+func (c *Client) ScanDeprecations(ctx context.Context, opts DeprecationOptions) (*DeprecationReport, error) {
+	return c.DeprecationService.Scan(ctx, opts)
+}
+
+// API discovery methods
+func (c *Client) APIResources(ctx context.Context, opts APIResourceOptions) ([]APIResource, error) {
+	return c.APIService.Resources(ctx, opts)
+}
+
+func (c *Client) Explain(ctx context.Context, opts ExplainOptions) (*Explanation, error) {
+	return c.APIService.Explain(ctx, opts)
+}
+
+// Tree methods
+func (c *Client) Tree(ctx context.Context, namespace, ref string) (*TreeNode, error) {
+	return c.TreeService.Tree(ctx, namespace, ref)
+}
+
+// Service methods
+func (c *Client) ServiceHealth(ctx context.Context, namespace, name string) (*ServiceHealth, error) {
+	return c.ServiceService.Health(ctx, namespace, name)
+}
+
+// Cleanup methods
+func (c *Client) FindCleanup(ctx context.Context, opts CleanupOptions) ([]CleanupCandidate, error) {
+	return c.CleanupService.Find(ctx, opts)
+}
+
+func (c *Client) Cleanup(ctx context.Context, candidates []CleanupCandidate, dryRun bool) ([]ResourceResult, error) {
+	return c.CleanupService.Delete(ctx, candidates, dryRun)
+}
+
+// Timeline methods
+func (c *Client) Timeline(ctx context.Context, opts TimelineOptions) (*Timeline, error) {
+	return c.TimelineService.Build(ctx, opts)
+}
+
+// Usage methods
+func (c *Client) Usage(ctx context.Context, opts UsageOptions) (*UsageReport, error) {
+	return c.UsageService.Report(ctx, opts)
+}
+
+// Cost methods
+func (c *Client) WorkloadCosts(ctx context.Context, opts CostOptions) (*CostReport, error) {
+	return c.CostService.Workloads(ctx, opts)
+}
+
+// Quota methods
+func (c *Client) QuotaHeadroom(ctx context.Context, namespace, deployment string) (*QuotaHeadroom, error) {
+	return c.QuotaService.Headroom(ctx, namespace, deployment)
+}
+
+// Webhook methods
+func (c *Client) ListWebhooks(ctx context.Context, opts WebhookListOptions) ([]Webhook, error) {
+	return c.WebhookService.List(ctx, opts)
+}
+
+// Image methods
+func (c *Client) ImageTags(ctx context.Context, opts ImageTagOptions) (*ImageTagReport, error) {
+	return c.ImageService.Tags(ctx, opts)
+}
+
+func (c *Client) ImagePinning(ctx context.Context, opts ImagePinOptions) ([]ImagePinFinding, error) {
+	return c.ImageService.Pinning(ctx, opts)
+}
+
+func (c *Client) PinImages(ctx context.Context, finding ImagePinFinding) error {
+	return c.ImageService.Pin(ctx, finding)
+}
+
+// Snapshot methods
+func (c *Client) CreateSnapshot(ctx context.Context, opts SnapshotOptions) (*Snapshot, error) {
+	return c.SnapshotService.Create(ctx, opts)
+}
+
+// Watch methods
+func (c *Client) WatchResources(ctx context.Context, opts WatchOptions) (<-chan WatchEvent, error) {
+	return c.WatcherService.Watch(ctx, opts)
+}
+
+// Favorites methods
+func (c *Client) FavoritesStatus(ctx context.Context, favs []Favorite, opts FavoriteStatusOptions) ([]FavoriteStatus, error) {
+	return c.FavoritesService.Status(ctx, favs, opts)
+}
+
+// CronJob methods
+func (c *Client) ListCronJobs(ctx context.Context, opts CronJobListOptions) ([]CronJob, error) {
+	return c.CronJobService.List(ctx, opts)
+}
+
+func (c *Client) CronJobHistory(ctx context.Context, namespace, name string, opts CronJobHistoryOptions) (*CronJob, error) {
+	return c.CronJobService.History(ctx, namespace, name, opts)
+}
+
+// Job methods
+func (c *Client) JobAttempts(ctx context.Context, namespace, name string) ([]JobAttempt, error) {
+	return c.JobService.Attempts(ctx, namespace, name)
+}
+
+func (c *Client) JobLogs(ctx context.Context, namespace, name string, opts JobLogOptions) error {
+	return c.JobService.Logs(ctx, namespace, name, opts)
+}
+
+// Prometheus methods
+func (c *Client) PromQuery(ctx context.Context, opts PromQueryOptions) (*PromQueryResult, error) {
+	return c.PrometheusService.Query(ctx, opts)
+}
+
+func (c *Client) WorkloadHTTPPanel(ctx context.Context, opts HTTPPanelOptions) (*HTTPPanel, error) {
+	return c.PrometheusService.HTTPPanel(ctx, opts)
+}
+
+// Restarts methods
+func (c *Client) SampleRestarts(ctx context.Context, opts RestartSampleOptions) (*RestartSample, error) {
+	return c.RestartService.Sample(ctx, opts)
+}
+
+// Report methods
+func (c *Client) Inventory(ctx context.Context, opts ReportOptions) (*Inventory, error) {
+	return c.ReportService.Inventory(ctx, opts)
+}
+
+// Validate methods
+func (c *Client) Validate(ctx context.Context, docs []Document, opts ValidateOptions) ([]ValidationResult, error) {
+	return c.ValidateService.Validate(ctx, docs, opts)
+}
+
 // ListPods returns a list of pods based on the given options
 func (c *Client) ListPods(opts *ListOptions) ([]Pod, error) {
 	return c.PodService.List(opts.Namespace, opts.AllNamespaces, opts.LabelSelector, "")
@@ -487,7 +1474,7 @@ func (c *Client) GetDeploymentLogs(namespace, name string, opts LogOptions) erro
 	}
 
 	if len(pods) == 0 {
-		return fmt.Errorf("no pods found for deployment %s", name)
+		return errs.NotFoundf("no pods found for deployment %s", name)
 	}
 
 	// Get logs from each pod
@@ -501,13 +1488,11 @@ func (c *Client) GetDeploymentLogs(namespace, name string, opts LogOptions) erro
 			continue
 		}
 
-		// If all containers requested, get logs for each container
-		if opts.AllContainers {
-			for _, container := range pod.Containers {
-				err = c.GetPodLogs(namespace, pod.Name, container.Name, opts)
-				if err != nil {
-					return fmt.Errorf("failed to get logs for container %s in pod %s: %w", container.Name, pod.Name, err)
-				}
+		// If all containers or init containers requested, get logs for each of them
+		if opts.AllContainers || opts.InitContainers {
+			err = c.GetPodLogs(namespace, pod.Name, "", opts)
+			if err != nil {
+				return fmt.Errorf("failed to get logs for pod %s: %w", pod.Name, err)
 			}
 			continue
 		}
@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKubeconfig is a kubeconfig for a cluster that is never contacted
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:1
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+    namespace: team
+current-context: test
+users:
+- name: test
+  user:
+    token: secret
+`
+
+func TestNewClientForContextWithOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(testKubeconfig), 0o600))
+	t.Setenv("KUBECONFIG", path)
+
+	SetImpersonation("cli-user", []string{"cli-group"})
+	defer SetImpersonation("", nil)
+
+	// Clients built concurrently each keep their own identity
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			user, group := fmt.Sprintf("user-%d", i), fmt.Sprintf("group-%d", i)
+			client, err := NewClientForContextWithOptions("", user, []string{group})
+			if assert.NoError(t, err) {
+				assert.Equal(t, user, client.config.Impersonate.UserName)
+				assert.Equal(t, []string{group}, client.config.Impersonate.Groups)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Without a user nobody is impersonated, whatever SetImpersonation set
+	client, err := NewClientForContextWithOptions("", "", nil)
+	require.NoError(t, err)
+	assert.Empty(t, client.config.Impersonate.UserName)
+
+	// The impersonation set with SetImpersonation is left alone
+	client, err = NewClientForContext("")
+	require.NoError(t, err)
+	assert.Equal(t, "cli-user", client.config.Impersonate.UserName)
+	assert.Equal(t, []string{"cli-group"}, client.config.Impersonate.Groups)
+}
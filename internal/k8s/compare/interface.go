@@ -0,0 +1,22 @@
+package compare
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for collecting namespace snapshots to compare
+type Service interface {
+	// Snapshot collects the deployments, configmaps and services of a namespace
+	Snapshot(ctx context.Context, namespace string) (*Snapshot, error)
+}
+
+// NewCompareService creates a new compare service instance
+func NewCompareService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
@@ -0,0 +1,207 @@
+package compare
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// present is the value shown for an object that exists on one side only
+const present = "<present>"
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new compare service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// Snapshot collects the deployments, configmaps and services of a namespace
+func (s *service) Snapshot(ctx context.Context, namespace string) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Namespace:   namespace,
+		Deployments: make(map[string]*appsv1.Deployment),
+		ConfigMaps:  make(map[string]*corev1.ConfigMap),
+		Services:    make(map[string]*corev1.Service),
+	}
+
+	deployments, err := s.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		snapshot.Deployments[deployments.Items[i].Name] = &deployments.Items[i]
+	}
+
+	configMaps, err := s.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps: %w", err)
+	}
+	for i := range configMaps.Items {
+		// The root CA bundle differs per cluster by design
+		if configMaps.Items[i].Name == "kube-root-ca.crt" {
+			continue
+		}
+		snapshot.ConfigMaps[configMaps.Items[i].Name] = &configMaps.Items[i]
+	}
+
+	services, err := s.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	for i := range services.Items {
+		snapshot.Services[services.Items[i].Name] = &services.Items[i]
+	}
+
+	return snapshot, nil
+}
+
+// Namespaces returns the differences between two snapshots, ordered by kind and name
+func Namespaces(left, right *Snapshot) []Difference {
+	var diffs []Difference
+	diffs = append(diffs, compareObjects("Deployment", left.Deployments, right.Deployments, deploymentFields)...)
+	diffs = append(diffs, compareObjects("ConfigMap", left.ConfigMaps, right.ConfigMaps, configMapFields)...)
+	diffs = append(diffs, compareObjects("Service", left.Services, right.Services, serviceFields)...)
+	return diffs
+}
+
+// compareObjects compares two sets of objects by the fields extracted from each
+func compareObjects[T any](kind string, left, right map[string]T, fields func(T) map[string]string) []Difference {
+	var diffs []Difference
+	for _, name := range unionKeys(left, right) {
+		l, inLeft := left[name]
+		r, inRight := right[name]
+		switch {
+		case !inRight:
+			diffs = append(diffs, Difference{Kind: kind, Name: name, Left: present})
+		case !inLeft:
+			diffs = append(diffs, Difference{Kind: kind, Name: name, Right: present})
+		default:
+			lf, rf := fields(l), fields(r)
+			for _, field := range unionKeys(lf, rf) {
+				if lf[field] != rf[field] {
+					diffs = append(diffs, Difference{Kind: kind, Name: name, Field: field, Left: lf[field], Right: rf[field]})
+				}
+			}
+		}
+	}
+	return diffs
+}
+
+func deploymentFields(d *appsv1.Deployment) map[string]string {
+	fields := make(map[string]string)
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	fields["replicas"] = fmt.Sprint(replicas)
+
+	spec := d.Spec.Template.Spec
+	for _, c := range spec.InitContainers {
+		addContainerFields(fields, "init container "+c.Name, c)
+	}
+	for _, c := range spec.Containers {
+		addContainerFields(fields, "container "+c.Name, c)
+	}
+	return fields
+}
+
+func addContainerFields(fields map[string]string, prefix string, c corev1.Container) {
+	fields[prefix+" image"] = c.Image
+	for _, env := range c.Env {
+		fields[prefix+" env "+env.Name] = envValue(env)
+	}
+	for _, from := range c.EnvFrom {
+		switch {
+		case from.ConfigMapRef != nil:
+			fields[prefix+" envFrom configmap "+from.ConfigMapRef.Name] = from.Prefix + "*"
+		case from.SecretRef != nil:
+			fields[prefix+" envFrom secret "+from.SecretRef.Name] = from.Prefix + "*"
+		}
+	}
+	for name, q := range c.Resources.Requests {
+		fields[prefix+" requests "+string(name)] = q.String()
+	}
+	for name, q := range c.Resources.Limits {
+		fields[prefix+" limits "+string(name)] = q.String()
+	}
+}
+
+// envValue renders an environment variable's value or its source
+func envValue(env corev1.EnvVar) string {
+	from := env.ValueFrom
+	switch {
+	case from == nil:
+		if env.Value == "" {
+			return `""`
+		}
+		return env.Value
+	case from.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("configmap %s/%s", from.ConfigMapKeyRef.Name, from.ConfigMapKeyRef.Key)
+	case from.SecretKeyRef != nil:
+		return fmt.Sprintf("secret %s/%s", from.SecretKeyRef.Name, from.SecretKeyRef.Key)
+	case from.FieldRef != nil:
+		return "field " + from.FieldRef.FieldPath
+	case from.ResourceFieldRef != nil:
+		return "resource " + from.ResourceFieldRef.Resource
+	}
+	return ""
+}
+
+func configMapFields(cm *corev1.ConfigMap) map[string]string {
+	fields := make(map[string]string, len(cm.Data)+len(cm.BinaryData))
+	for key, value := range cm.Data {
+		if value == "" {
+			value = `""`
+		}
+		fields["data "+key] = value
+	}
+	for key, value := range cm.BinaryData {
+		fields["binaryData "+key] = fmt.Sprintf("<%d bytes>", len(value))
+	}
+	return fields
+}
+
+func serviceFields(svc *corev1.Service) map[string]string {
+	fields := map[string]string{"type": string(svc.Spec.Type)}
+	if len(svc.Spec.Selector) > 0 {
+		selector := make([]string, 0, len(svc.Spec.Selector))
+		for k, v := range svc.Spec.Selector {
+			selector = append(selector, k+"="+v)
+		}
+		sort.Strings(selector)
+		fields["selector"] = strings.Join(selector, ",")
+	}
+	for _, p := range svc.Spec.Ports {
+		name := p.Name
+		if name == "" {
+			name = fmt.Sprint(p.Port)
+		}
+		fields["port "+name] = fmt.Sprintf("%d/%s -> %s", p.Port, p.Protocol, p.TargetPort.String())
+	}
+	return fields
+}
+
+func unionKeys[T any](a, b map[string]T) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
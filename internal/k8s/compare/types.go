@@ -0,0 +1,28 @@
+package compare
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Snapshot holds the compared objects of a namespace, keyed by name
+type Snapshot struct {
+	Namespace   string
+	Deployments map[string]*appsv1.Deployment
+	ConfigMaps  map[string]*corev1.ConfigMap
+	Services    map[string]*corev1.Service
+}
+
+// Difference is a single difference between two snapshots
+type Difference struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+
+	// Field is the compared attribute, e.g. replicas or container app image. It is empty when
+	// the object only exists on one side.
+	Field string `json:"field,omitempty"`
+
+	// Left and Right are the values on either side; empty means absent
+	Left  string `json:"left"`
+	Right string `json:"right"`
+}
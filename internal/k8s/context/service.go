@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sort"
 
+	"k8stool/internal/k8s/errs"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -73,7 +75,7 @@ func (s *service) GetCurrent() (*Context, error) {
 	currentContext := rawConfig.CurrentContext
 	ctx, exists := rawConfig.Contexts[currentContext]
 	if !exists {
-		return nil, fmt.Errorf("current context %q not found", currentContext)
+		return nil, errs.NotFoundf("current context %q not found", currentContext)
 	}
 
 	context := &Context{
@@ -96,7 +98,7 @@ func (s *service) SwitchContext(name string) error {
 	}
 
 	if _, exists := config.Contexts[name]; !exists {
-		return fmt.Errorf("context %q not found", name)
+		return errs.NotFoundf("context %q not found", name)
 	}
 
 	config.CurrentContext = name
@@ -123,7 +125,7 @@ func (s *service) SetNamespace(namespace string) error {
 
 	ctx, exists := config.Contexts[currentContext]
 	if !exists {
-		return fmt.Errorf("current context %q not found", currentContext)
+		return errs.NotFoundf("current context %q not found", currentContext)
 	}
 
 	ctx.Namespace = namespace
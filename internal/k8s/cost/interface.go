@@ -0,0 +1,22 @@
+package cost
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for workload cost estimation
+type Service interface {
+	// Workloads joins the cost OpenCost allocated to each workload with its replicas
+	Workloads(ctx context.Context, opts Options) (*Report, error)
+}
+
+// NewCostService creates a new cost service instance
+func NewCostService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
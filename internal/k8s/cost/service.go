@@ -0,0 +1,296 @@
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// allocationPath is the OpenCost allocation API, also served by Kubecost under /model
+const allocationPath = "/allocation/compute"
+
+type service struct {
+	clientset  *kubernetes.Clientset
+	httpClient *http.Client
+}
+
+// newService creates a new cost service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset:  clientset,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// allocation is a cost allocation of the OpenCost API
+type allocation struct {
+	Properties struct {
+		Namespace      string `json:"namespace"`
+		ControllerKind string `json:"controllerKind"`
+		Controller     string `json:"controller"`
+	} `json:"properties"`
+	CPUCoreRequestAverage float64 `json:"cpuCoreRequestAverage"`
+	CPUCoreUsageAverage   float64 `json:"cpuCoreUsageAverage"`
+	RAMByteRequestAverage float64 `json:"ramByteRequestAverage"`
+	RAMByteUsageAverage   float64 `json:"ramByteUsageAverage"`
+	CPUCost               float64 `json:"cpuCost"`
+	RAMCost               float64 `json:"ramCost"`
+	PVCost                float64 `json:"pvCost"`
+	NetworkCost           float64 `json:"networkCost"`
+	TotalCost             float64 `json:"totalCost"`
+}
+
+// allocationResponse is the response of the OpenCost allocation API
+type allocationResponse struct {
+	Code    int                     `json:"code"`
+	Message string                  `json:"message"`
+	Data    []map[string]allocation `json:"data"`
+}
+
+// kindNames maps the lower case controller kinds of OpenCost to Kubernetes kinds
+var kindNames = map[string]string{
+	"deployment":  "Deployment",
+	"statefulset": "StatefulSet",
+	"daemonset":   "DaemonSet",
+	"replicaset":  "ReplicaSet",
+	"job":         "Job",
+	"cronjob":     "CronJob",
+	"pod":         "Pod",
+}
+
+type workloadKey struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+// Workloads joins the cost OpenCost allocated to each workload with its replicas
+func (s *service) Workloads(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Window == "" {
+		opts.Window = "7d"
+	}
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	allocations, err := s.allocations(ctx, opts, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads, err := s.listWorkloads(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[workloadKey]int, len(workloads))
+	for i, w := range workloads {
+		index[workloadKey{w.Namespace, strings.ToLower(w.Kind), w.Name}] = i
+	}
+
+	// Allocations of the same controller are summed, OpenCost may split them by cluster
+	for _, a := range allocations {
+		p := a.Properties
+		if p.Controller == "" || (namespace != "" && p.Namespace != namespace) {
+			continue // Idle and unallocated costs, or outside the namespace
+		}
+		key := workloadKey{p.Namespace, strings.ToLower(p.ControllerKind), p.Controller}
+		i, ok := index[key]
+		if !ok {
+			workloads = append(workloads, WorkloadCost{Namespace: p.Namespace, Kind: kindName(p.ControllerKind), Name: p.Controller})
+			i = len(workloads) - 1
+			index[key] = i
+		}
+		w := &workloads[i]
+		w.HasCost = true
+		w.CPURequest += a.CPUCoreRequestAverage
+		w.CPUUsage += a.CPUCoreUsageAverage
+		w.MemoryRequest += a.RAMByteRequestAverage
+		w.MemoryUsage += a.RAMByteUsageAverage
+		w.CPUCost += a.CPUCost
+		w.MemoryCost += a.RAMCost
+		w.StorageCost += a.PVCost
+		w.NetworkCost += a.NetworkCost
+		w.TotalCost += a.TotalCost
+	}
+
+	report := &Report{Window: opts.Window, Workloads: workloads}
+	for _, w := range workloads {
+		report.TotalCost += w.TotalCost
+	}
+	sort.SliceStable(report.Workloads, func(i, j int) bool {
+		a, b := report.Workloads[i], report.Workloads[j]
+		if a.TotalCost != b.TotalCost {
+			return a.TotalCost > b.TotalCost
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return report, nil
+}
+
+// allocations queries the OpenCost allocation API, aggregated per controller
+func (s *service) allocations(ctx context.Context, opts Options, namespace string) ([]allocation, error) {
+	params := map[string]string{
+		"window":     opts.Window,
+		"aggregate":  "namespace,controllerKind,controller",
+		"accumulate": "true",
+	}
+	if namespace != "" {
+		// filterNamespaces is understood by older releases, filter by current ones
+		params["filterNamespaces"] = namespace
+		params["filter"] = fmt.Sprintf("namespace:%q", namespace)
+	}
+
+	var data []byte
+	var err error
+	if opts.URL != "" {
+		data, err = s.getURL(ctx, opts.URL, params)
+	} else {
+		data, err = s.getProxy(ctx, opts.Service, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var resp allocationResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenCost response: %w", err)
+	}
+	if resp.Code != 0 && resp.Code != http.StatusOK {
+		return nil, fmt.Errorf("OpenCost returned error %d: %s", resp.Code, resp.Message)
+	}
+
+	var allocations []allocation
+	for _, set := range resp.Data {
+		for _, a := range set {
+			allocations = append(allocations, a)
+		}
+	}
+	return allocations, nil
+}
+
+// kindName returns the Kubernetes kind of an OpenCost controller kind
+func kindName(kind string) string {
+	if name, ok := kindNames[strings.ToLower(kind)]; ok {
+		return name
+	}
+	return kind
+}
+
+// getURL queries the allocation API directly
+func (s *service) getURL(ctx context.Context, baseURL string, params map[string]string) ([]byte, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/") + allocationPath)
+	if err != nil {
+		return nil, errs.Validationf("invalid OpenCost URL %q: %v", baseURL, err)
+	}
+	query := u.Query()
+	for k, v := range params {
+		query.Set(k, v)
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenCost request: %w", err)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OpenCost: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenCost response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenCost returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+// getProxy queries the allocation API of an in-cluster service through the API server proxy
+func (s *service) getProxy(ctx context.Context, target string, params map[string]string) ([]byte, error) {
+	if target == "" {
+		target = DefaultService
+	}
+	namespace, rest, ok := strings.Cut(target, "/")
+	name, port, _ := strings.Cut(rest, ":")
+	if !ok || namespace == "" || name == "" {
+		return nil, errs.Validationf("invalid OpenCost service %q: must be namespace/name[:port]", target)
+	}
+
+	data, err := s.clientset.CoreV1().Services(namespace).ProxyGet("http", name, port, allocationPath, params).DoRaw(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errs.NotFoundf("OpenCost service %s not found: install OpenCost or set its location with --service or --url", target)
+		}
+		return nil, fmt.Errorf("failed to query OpenCost through service %s: %w", target, err)
+	}
+	return data, nil
+}
+
+// listWorkloads returns the deployments, statefulsets and daemonsets with their replicas
+func (s *service) listWorkloads(ctx context.Context, namespace string) ([]WorkloadCost, error) {
+	var workloads []WorkloadCost
+
+	deployments, err := s.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		workloads = append(workloads, WorkloadCost{
+			Namespace:     d.Namespace,
+			Kind:          "Deployment",
+			Name:          d.Name,
+			Replicas:      d.Status.Replicas,
+			ReadyReplicas: d.Status.ReadyReplicas,
+		})
+	}
+
+	statefulSets, err := s.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, sts := range statefulSets.Items {
+		workloads = append(workloads, WorkloadCost{
+			Namespace:     sts.Namespace,
+			Kind:          "StatefulSet",
+			Name:          sts.Name,
+			Replicas:      sts.Status.Replicas,
+			ReadyReplicas: sts.Status.ReadyReplicas,
+		})
+	}
+
+	daemonSets, err := s.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, ds := range daemonSets.Items {
+		workloads = append(workloads, WorkloadCost{
+			Namespace:     ds.Namespace,
+			Kind:          "DaemonSet",
+			Name:          ds.Name,
+			Replicas:      ds.Status.DesiredNumberScheduled,
+			ReadyReplicas: ds.Status.NumberReady,
+		})
+	}
+
+	return workloads, nil
+}
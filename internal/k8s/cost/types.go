@@ -0,0 +1,55 @@
+package cost
+
+// DefaultService is the OpenCost service queried through the API server proxy
+const DefaultService = "opencost/opencost:9003"
+
+// Options configures where costs are read from and for which workloads
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+
+	// URL is the base URL of the OpenCost or Kubecost API, e.g. http://localhost:9003. When
+	// empty, Service is queried through the API server proxy.
+	URL string
+
+	// Service is the OpenCost service as namespace/name:port, DefaultService when empty
+	Service string
+
+	// Window is the OpenCost time window, e.g. 24h, 7d or month
+	Window string
+}
+
+// WorkloadCost is the cost of a workload over the window. Resource values are averages over
+// the window, CPU in cores and memory in bytes. Costs are in the currency OpenCost is
+// configured with.
+type WorkloadCost struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+
+	// Replicas and ReadyReplicas are the current replicas of deployments, statefulsets and
+	// daemonsets; they are zero for other workloads OpenCost reports on, such as jobs
+	Replicas      int32 `json:"replicas"`
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	CPURequest    float64 `json:"cpuRequest"`
+	CPUUsage      float64 `json:"cpuUsage"`
+	MemoryRequest float64 `json:"memoryRequest"`
+	MemoryUsage   float64 `json:"memoryUsage"`
+
+	CPUCost     float64 `json:"cpuCost"`
+	MemoryCost  float64 `json:"memoryCost"`
+	StorageCost float64 `json:"storageCost"`
+	NetworkCost float64 `json:"networkCost"`
+	TotalCost   float64 `json:"totalCost"`
+
+	// HasCost is false for workloads OpenCost has no data for, such as ones created after the window
+	HasCost bool `json:"hasCost"`
+}
+
+// Report is the cost of the workloads in scope, most expensive first
+type Report struct {
+	Window    string         `json:"window"`
+	Workloads []WorkloadCost `json:"workloads"`
+	TotalCost float64        `json:"totalCost"`
+}
@@ -0,0 +1,25 @@
+package cronjobs
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for inspecting cronjobs and their runs
+type Service interface {
+	// List returns cronjobs with their latest and next runs, sorted by namespace and name
+	List(ctx context.Context, opts ListOptions) ([]CronJob, error)
+
+	// History returns a cronjob with its most recent runs
+	History(ctx context.Context, namespace, name string, opts HistoryOptions) (*CronJob, error)
+}
+
+// NewCronJobService creates a new cronjob service instance
+func NewCronJobService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
@@ -0,0 +1,235 @@
+package cronjobs
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+)
+
+// Schedule is a parsed cron schedule in the five field format the CronJob controller accepts:
+// minute, hour, day of month, month and day of week
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record a day field given as * or ?, with a step of 1 if any. When
+	// neither day field is, a day matches if either field does.
+	domStar, dowStar bool
+
+	// Location is the time zone the schedule runs in
+	Location *time.Location
+}
+
+// field is the range and names of a schedule field
+type field struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minutes = field{0, 59, nil}
+	hours   = field{0, 23, nil}
+	doms    = field{1, 31, nil}
+	months  = field{1, 12, map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day 7 is Sunday as well as 0
+	dows = field{0, 7, map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// descriptors are the predefined schedules
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses the schedule of a CronJob. The time zone is the timeZone field of the
+// CronJob, or a CRON_TZ= or TZ= prefix of the schedule; without either the schedule runs in
+// defaultLocation, the time zone of kube-controller-manager.
+func ParseSchedule(spec, timeZone string, defaultLocation *time.Location) (*Schedule, error) {
+	loc := defaultLocation
+	if tz, rest, ok := cutTimeZone(spec); ok {
+		timeZone, spec = tz, rest
+	}
+	if timeZone != "" {
+		l, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, errs.Validationf("unknown time zone %q", timeZone)
+		}
+		loc = l
+	}
+
+	spec = strings.TrimSpace(spec)
+	if d, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = d
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errs.Validationf("invalid schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	s := &Schedule{Location: loc}
+	var err error
+	if s.minute, err = parseField(fields[0], minutes); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hours); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], doms); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], months); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dows); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = isStar(fields[2])
+	s.dowStar = isStar(fields[4])
+	return s, nil
+}
+
+// cutTimeZone splits a CRON_TZ= or TZ= prefix off a schedule
+func cutTimeZone(spec string) (string, string, bool) {
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(spec), prefix); ok {
+			tz, rest, _ := strings.Cut(rest, " ")
+			return tz, rest, true
+		}
+	}
+	return "", spec, false
+}
+
+// parseField parses a comma-separated list of values, ranges and steps into a bit set
+func parseField(expr string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n <= 0 {
+				return 0, errs.Validationf("invalid step in %q", part)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?":
+			lo, hi = f.min, f.max
+		case strings.Contains(rangeExpr, "-"):
+			a, b, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if lo, err = parseValue(a, f); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(b, f); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if lo, err = parseValue(rangeExpr, f); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if hasStep {
+				// A value with a step, like 5/15, runs from the value to the end of the range
+				hi = f.max
+			}
+		}
+		if lo > hi {
+			return 0, errs.Validationf("invalid range %q: %d is after %d", part, lo, hi)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// isStar reports whether a day field counts as * for the rule combining the day fields. As in
+// the cron library of the CronJob controller, a part of * or ? counts unless its step is
+// larger than 1, so */1 does and */2 does not.
+func isStar(expr string) bool {
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		if rangeExpr != "*" && rangeExpr != "?" {
+			continue
+		}
+		if step, err := strconv.Atoi(stepExpr); !hasStep || (err == nil && step == 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseValue parses a number or a month or weekday name within the range of a field
+func parseValue(s string, f field) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errs.Validationf("invalid value %q: must be between %d and %d", s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t the schedule runs, in the location of the schedule.
+// The zero time is returned when it never runs, e.g. on February 30. Daylight saving changes
+// are handled as by the CronJob controller: runs in the hour skipped in spring do not happen,
+// and runs in the hour repeated in autumn happen twice.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.In(s.Location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.Location)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.Location)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.Location)
+			if !next.After(t) {
+				// The next hour was skipped by a daylight saving change
+				next = t.Add(time.Hour).Truncate(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of t matches the day of month and day of week fields
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,234 @@
+package cronjobs
+
+import (
+	"testing"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// Monday 15 January 2024
+	monday := time.Date(2024, 1, 15, 10, 7, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule string
+		timeZone string
+		from     time.Time
+		want     time.Time
+	}{
+		{
+			name:     "every minute",
+			schedule: "* * * * *",
+			from:     monday,
+			want:     time.Date(2024, 1, 15, 10, 8, 0, 0, time.UTC),
+		},
+		{
+			name:     "step over the whole range",
+			schedule: "*/15 * * * *",
+			from:     monday,
+			want:     time.Date(2024, 1, 15, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:     "step from a value",
+			schedule: "5/20 * * * *",
+			from:     monday,
+			want:     time.Date(2024, 1, 15, 10, 25, 0, 0, time.UTC),
+		},
+		{
+			name:     "range with a step",
+			schedule: "0 9-17/4 * * *",
+			from:     monday,
+			want:     time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "list of values",
+			schedule: "0,45 10 * * *",
+			from:     monday,
+			want:     time.Date(2024, 1, 15, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekday names",
+			schedule: "0 0 * * mon-fri",
+			from:     monday,
+			want:     time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "month names",
+			schedule: "0 12 1 jan,jul *",
+			from:     monday,
+			want:     time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "sunday as 7",
+			schedule: "0 0 * * 7",
+			from:     monday,
+			want:     time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "hourly",
+			schedule: "@hourly",
+			from:     monday,
+			want:     time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekly",
+			schedule: "@weekly",
+			from:     monday,
+			want:     time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "monthly",
+			schedule: "@monthly",
+			from:     monday,
+			want:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "yearly",
+			schedule: "@yearly",
+			from:     monday,
+			want:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month or day of week",
+			schedule: "0 0 13 * 5",
+			from:     monday,
+			want:     time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month with any day of week",
+			schedule: "0 0 13 * *",
+			from:     monday,
+			want:     time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of week with any day of month",
+			schedule: "0 0 ? * 5",
+			from:     monday,
+			want:     time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of week step of 1 counts as any",
+			schedule: "0 0 13 * */1",
+			from:     monday,
+			want:     time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month step of 1 counts as any",
+			schedule: "0 0 */1 * 5",
+			from:     monday,
+			want:     time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month step of 2 does not count as any",
+			schedule: "0 0 */2 * 5",
+			from:     monday,
+			want:     time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "leap day",
+			schedule: "0 0 29 2 *",
+			from:     monday,
+			want:     time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "never",
+			schedule: "0 0 30 2 *",
+			from:     monday,
+			want:     time.Time{},
+		},
+		{
+			name:     "time zone field",
+			schedule: "0 9 * * *",
+			timeZone: "America/New_York",
+			from:     monday,
+			want:     time.Date(2024, 1, 15, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:     "time zone prefix",
+			schedule: "CRON_TZ=Europe/Berlin 0 9 * * *",
+			from:     monday,
+			want:     time.Date(2024, 1, 16, 9, 0, 0, 0, berlin),
+		},
+		{
+			// Clocks go from 02:00 to 03:00 on 10 March 2024, so the run of that day does not
+			// happen, as with the CronJob controller
+			name:     "run in the hour skipped in spring",
+			schedule: "30 2 * * *",
+			timeZone: "America/New_York",
+			from:     time.Date(2024, 3, 9, 3, 0, 0, 0, newYork),
+			want:     time.Date(2024, 3, 11, 2, 30, 0, 0, newYork),
+		},
+		{
+			name:     "run after the hour skipped in spring",
+			schedule: "30 3 * * *",
+			timeZone: "America/New_York",
+			from:     time.Date(2024, 3, 10, 0, 0, 0, 0, newYork),
+			want:     time.Date(2024, 3, 10, 3, 30, 0, 0, newYork),
+		},
+		{
+			name:     "hourly across the hour skipped in spring",
+			schedule: "0 * * * *",
+			timeZone: "America/New_York",
+			from:     time.Date(2024, 3, 10, 1, 30, 0, 0, newYork),
+			want:     time.Date(2024, 3, 10, 3, 0, 0, 0, newYork),
+		},
+		{
+			// Clocks go from 02:00 back to 01:00 on 3 November 2024, so 01:30 comes twice
+			name:     "run in the hour repeated in autumn",
+			schedule: "30 1 * * *",
+			timeZone: "America/New_York",
+			from:     time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC),
+			want:     time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchedule(tt.schedule, tt.timeZone, time.UTC)
+			require.NoError(t, err)
+
+			got := s.Next(tt.from)
+			if tt.want.IsZero() {
+				assert.True(t, got.IsZero(), "got %s", got)
+				return
+			}
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		timeZone string
+	}{
+		{name: "too few fields", schedule: "* * * *"},
+		{name: "too many fields", schedule: "* * * * * *"},
+		{name: "minute out of range", schedule: "60 * * * *"},
+		{name: "day of month out of range", schedule: "0 0 0 * *"},
+		{name: "zero step", schedule: "*/0 * * * *"},
+		{name: "reversed range", schedule: "0 5-1 * * *"},
+		{name: "unknown weekday", schedule: "0 0 * * foo"},
+		{name: "unknown descriptor", schedule: "@fortnightly"},
+		{name: "unknown time zone", schedule: "0 0 * * *", timeZone: "Mars/Olympus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSchedule(tt.schedule, tt.timeZone, time.UTC)
+			require.Error(t, err)
+			assert.Equal(t, errs.Validation, errs.CategoryOf(err))
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package cronjobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultHistoryLimit is the number of runs shown when HistoryOptions.Limit is zero
+	DefaultHistoryLimit = 5
+
+	// missedGrace is how late a run may start before it is reported as missed
+	missedGrace = 2 * time.Minute
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new cronjob service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{clientset: clientset}
+}
+
+// List returns cronjobs with their latest and next runs
+func (s *service) List(ctx context.Context, opts ListOptions) ([]CronJob, error) {
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+	list, err := s.clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	runs, err := s.runsByOwner(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]CronJob, 0, len(list.Items))
+	for i := range list.Items {
+		cj := fromCronJob(&list.Items[i], now)
+		if r := runs[list.Items[i].UID]; len(r) > 0 {
+			cj.LastRun = &r[0]
+		}
+		result = append(result, cj)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// History returns a cronjob with its most recent runs
+func (s *service) History(ctx context.Context, namespace, name string, opts HistoryOptions) (*CronJob, error) {
+	obj, err := s.clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, errs.NotFoundf("cronjob %q not found in namespace %q", name, namespace)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cronjob: %w", err)
+	}
+	runs, err := s.runsByOwner(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	cj := fromCronJob(obj, time.Now())
+	cj.Runs = runs[obj.UID]
+	if len(cj.Runs) > 0 {
+		cj.LastRun = &cj.Runs[0]
+	}
+	if len(cj.Runs) > limit {
+		cj.Runs = cj.Runs[:limit]
+	}
+	return &cj, nil
+}
+
+// runsByOwner returns the runs of the jobs of a namespace by the UID of their cronjob, newest first
+func (s *service) runsByOwner(ctx context.Context, namespace string) (map[types.UID][]Run, error) {
+	jobs, err := s.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	now := time.Now()
+	runs := make(map[types.UID][]Run)
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		ref := metav1.GetControllerOf(job)
+		if ref == nil || ref.Kind != "CronJob" {
+			continue
+		}
+		runs[ref.UID] = append(runs[ref.UID], fromJob(job, now))
+	}
+	for _, r := range runs {
+		sort.Slice(r, func(i, j int) bool { return r[i].Created.After(r[j].Created) })
+	}
+	return runs, nil
+}
+
+// fromCronJob converts a cronjob and computes its next and missed runs
+func fromCronJob(obj *batchv1.CronJob, now time.Time) CronJob {
+	cj := CronJob{
+		Name:      obj.Name,
+		Namespace: obj.Namespace,
+		Schedule:  obj.Spec.Schedule,
+		Suspended: obj.Spec.Suspend != nil && *obj.Spec.Suspend,
+		Active:    len(obj.Status.Active),
+	}
+	if t := obj.Status.LastScheduleTime; t != nil {
+		cj.LastSchedule = &t.Time
+	}
+	if t := obj.Status.LastSuccessfulTime; t != nil {
+		cj.LastSuccessful = &t.Time
+	}
+
+	timeZone := ""
+	if obj.Spec.TimeZone != nil {
+		timeZone = *obj.Spec.TimeZone
+	}
+	// kube-controller-manager runs in UTC unless configured otherwise
+	sched, err := ParseSchedule(obj.Spec.Schedule, timeZone, time.UTC)
+	if err != nil {
+		cj.ScheduleError = err.Error()
+		return cj
+	}
+	cj.TimeZone = sched.Location.String()
+	if cj.Suspended {
+		return cj
+	}
+
+	if next := sched.Next(now); !next.IsZero() {
+		cj.NextRun = &next
+	}
+
+	// A run is missed when the first one due after the last schedule did not start in time
+	base := obj.CreationTimestamp.Time
+	if cj.LastSchedule != nil {
+		base = *cj.LastSchedule
+	}
+	grace := missedGrace
+	if d := obj.Spec.StartingDeadlineSeconds; d != nil && time.Duration(*d)*time.Second > grace {
+		grace = time.Duration(*d) * time.Second
+	}
+	if due := sched.Next(base); !due.IsZero() && now.Sub(due) > grace {
+		cj.MissedRun = &due
+	}
+	return cj
+}
+
+// fromJob converts a job created by a cronjob into a run
+func fromJob(job *batchv1.Job, now time.Time) Run {
+	run := Run{
+		Name:     job.Name,
+		Result:   Running,
+		Created:  job.CreationTimestamp.Time,
+		Attempts: job.Status.Succeeded + job.Status.Failed + job.Status.Active,
+	}
+	if job.Status.StartTime != nil {
+		run.Start = &job.Status.StartTime.Time
+	}
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			run.Result = Succeeded
+			finished := c.LastTransitionTime.Time
+			run.Finished = &finished
+		case batchv1.JobFailed:
+			run.Result, run.Reason = Failed, c.Reason
+			finished := c.LastTransitionTime.Time
+			run.Finished = &finished
+		}
+	}
+	if job.Status.CompletionTime != nil {
+		run.Finished = &job.Status.CompletionTime.Time
+	}
+
+	if run.Start != nil {
+		end := now
+		if run.Finished != nil {
+			end = *run.Finished
+		}
+		run.Duration = end.Sub(*run.Start)
+	}
+	return run
+}
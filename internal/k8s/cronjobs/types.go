@@ -0,0 +1,69 @@
+package cronjobs
+
+import "time"
+
+// Results of a run
+const (
+	Succeeded = "Succeeded"
+	Failed    = "Failed"
+	Running   = "Running"
+)
+
+// ListOptions selects the cronjobs to list
+type ListOptions struct {
+	Namespace     string
+	AllNamespaces bool
+}
+
+// HistoryOptions configures the run history of a cronjob
+type HistoryOptions struct {
+	// Limit is the number of most recent runs to return
+	Limit int
+}
+
+// CronJob is a cronjob with its latest run and its next scheduled run
+type CronJob struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Schedule  string `json:"schedule"`
+
+	// TimeZone is the time zone the schedule runs in
+	TimeZone  string `json:"timeZone"`
+	Suspended bool   `json:"suspended"`
+	Active    int    `json:"active"`
+
+	LastSchedule   *time.Time `json:"lastSchedule,omitempty"`
+	LastSuccessful *time.Time `json:"lastSuccessful,omitempty"`
+
+	// NextRun is unset when the cronjob is suspended or its schedule never runs
+	NextRun *time.Time `json:"nextRun,omitempty"`
+
+	// MissedRun is set when a run that was due after the last schedule did not start
+	MissedRun *time.Time `json:"missedRun,omitempty"`
+
+	// LastRun is the most recent run still kept by the cluster
+	LastRun *Run `json:"lastRun,omitempty"`
+
+	// Runs are the most recent runs, newest first; only set for the history of a cronjob
+	Runs []Run `json:"runs,omitempty"`
+
+	// ScheduleError is set when the schedule cannot be parsed
+	ScheduleError string `json:"scheduleError,omitempty"`
+}
+
+// Run is a Job created by a cronjob
+type Run struct {
+	Name   string `json:"name"`
+	Result string `json:"result"`
+
+	// Reason explains a failure, e.g. BackoffLimitExceeded
+	Reason string `json:"reason,omitempty"`
+
+	Created  time.Time     `json:"created"`
+	Start    *time.Time    `json:"start,omitempty"`
+	Finished *time.Time    `json:"finished,omitempty"`
+	Duration time.Duration `json:"duration"`
+
+	// Attempts is the number of pods that ran
+	Attempts int32 `json:"attempts"`
+}
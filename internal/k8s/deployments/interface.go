@@ -1,6 +1,7 @@
 package deployments
 
 import (
+	"context"
 	"fmt"
 
 	"k8s.io/client-go/kubernetes"
@@ -28,8 +29,14 @@ type Service interface {
 	// Update updates a deployment's configuration
 	Update(namespace, name string, opts DeploymentOptions) error
 
+	// PreviewUpdate returns how an update would change the pod template of a deployment
+	PreviewUpdate(namespace, name string, opts DeploymentOptions) (*TemplateChange, error)
+
 	// AddMetrics adds metrics information to a list of deployments
 	AddMetrics(deployments []Deployment) error
+
+	// WatchRollout follows the rollout of a deployment until it completes, fails or times out
+	WatchRollout(ctx context.Context, namespace, name string, opts RolloutOptions) (*RolloutStatus, error)
 }
 
 // NewDeploymentService creates a new deployment service instance
@@ -0,0 +1,205 @@
+package deployments
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// rolloutPollInterval is how often the rollout status is checked
+const rolloutPollInterval = 2 * time.Second
+
+// revisionAnnotation holds the rollout revision of a deployment and of its ReplicaSets
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// failingReasons are container waiting reasons a rollout does not recover from by itself
+var failingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+}
+
+// WatchRollout follows the rollout of a deployment until it completes, fails or times out
+func (s *service) WatchRollout(ctx context.Context, namespace, name string, opts RolloutOptions) (*RolloutStatus, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	for {
+		status, err := s.rolloutStatus(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		if opts.OnProgress != nil {
+			opts.OnProgress(*status)
+		}
+
+		switch {
+		case status.Complete:
+			return status, nil
+		case status.DeadlineExceeded:
+			return status, fmt.Errorf("deployment %q exceeded its progress deadline", name)
+		case opts.FailOnCrashLoop && len(status.FailingPods) > 0:
+			pod := status.FailingPods[0]
+			return status, fmt.Errorf("rollout of deployment %q failed: pod %s container %s is in %s", name, pod.Name, pod.Container, pod.Reason)
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return status, errs.Timeoutf("timed out waiting for deployment %q rollout to finish", name)
+			}
+			return status, ctx.Err()
+		case <-time.After(rolloutPollInterval):
+		}
+	}
+}
+
+// rolloutStatus returns the current rollout status with the pods of the new ReplicaSet
+func (s *service) rolloutStatus(ctx context.Context, namespace, name string) (*RolloutStatus, error) {
+	d, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errs.NotFoundf("deployment %q not found in namespace %q", name, namespace)
+		}
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	status := &RolloutStatus{
+		Name:              d.Name,
+		Namespace:         d.Namespace,
+		Revision:          d.Annotations[revisionAnnotation],
+		Replicas:          replicas,
+		UpdatedReplicas:   d.Status.UpdatedReplicas,
+		ReadyReplicas:     d.Status.ReadyReplicas,
+		AvailableReplicas: d.Status.AvailableReplicas,
+	}
+
+	// The same checks as kubectl rollout status
+	switch {
+	case d.Generation > d.Status.ObservedGeneration:
+		status.Message = "Waiting for deployment spec update to be observed..."
+		return status, nil
+	case progressDeadlineExceeded(d):
+		status.DeadlineExceeded = true
+		status.Message = fmt.Sprintf("deployment %q exceeded its progress deadline", d.Name)
+		return status, nil
+	case d.Status.UpdatedReplicas < replicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...",
+			d.Name, d.Status.UpdatedReplicas, replicas)
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...",
+			d.Name, d.Status.Replicas-d.Status.UpdatedReplicas)
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...",
+			d.Name, d.Status.AvailableReplicas, d.Status.UpdatedReplicas)
+	default:
+		status.Complete = true
+		status.Message = fmt.Sprintf("deployment %q successfully rolled out", d.Name)
+		return status, nil
+	}
+
+	if err := s.inspectNewPods(ctx, d, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// progressDeadlineExceeded reports whether the deployment controller gave up on the rollout
+func progressDeadlineExceeded(d *appsv1.Deployment) bool {
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing {
+			return c.Reason == "ProgressDeadlineExceeded"
+		}
+	}
+	return false
+}
+
+// newReplicaSet returns the ReplicaSet of the current revision, nil if it does not exist yet
+func (s *service) newReplicaSet(ctx context.Context, d *appsv1.Deployment) (*appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment selector: %w", err)
+	}
+	list, err := s.clientset.AppsV1().ReplicaSets(d.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+
+	revision := d.Annotations[revisionAnnotation]
+	for i := range list.Items {
+		rs := &list.Items[i]
+		if ref := metav1.GetControllerOf(rs); ref == nil || ref.UID != d.UID {
+			continue
+		}
+		if rs.Annotations[revisionAnnotation] == revision {
+			return rs, nil
+		}
+	}
+	return nil, nil
+}
+
+// inspectNewPods records when the pods of the new ReplicaSet became ready and which of them
+// have containers waiting for a reason listed in failingReasons
+func (s *service) inspectNewPods(ctx context.Context, d *appsv1.Deployment, status *RolloutStatus) error {
+	rs, err := s.newReplicaSet(ctx, d)
+	if err != nil || rs == nil {
+		return err
+	}
+	status.StartedAt = rs.CreationTimestamp.Time
+
+	selector, err := metav1.LabelSelectorAsSelector(rs.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid replicaset selector: %w", err)
+	}
+	list, err := s.clientset.CoreV1().Pods(d.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	for _, pod := range list.Items {
+		if ref := metav1.GetControllerOf(&pod); ref == nil || ref.UID != rs.UID {
+			continue
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				status.ReadyTimes = append(status.ReadyTimes, c.LastTransitionTime.Time)
+			}
+		}
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			if cs.State.Waiting == nil || !failingReasons[cs.State.Waiting.Reason] {
+				continue
+			}
+			status.FailingPods = append(status.FailingPods, FailingPod{
+				Name:      pod.Name,
+				Container: cs.Name,
+				Reason:    cs.State.Waiting.Reason,
+				Message:   cs.State.Waiting.Message,
+				Restarts:  cs.RestartCount,
+			})
+			break
+		}
+	}
+
+	sort.Slice(status.ReadyTimes, func(i, j int) bool {
+		return status.ReadyTimes[i].Before(status.ReadyTimes[j])
+	})
+	return nil
+}
@@ -3,13 +3,18 @@ package deployments
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"k8stool/internal/k8s/errs"
+
 	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned"
+	"sigs.k8s.io/yaml"
 )
 
 type service struct {
@@ -45,19 +50,8 @@ func (s *service) List(namespace string, allNamespaces bool, selector string) ([
 		return nil, fmt.Errorf("failed to list deployments: %w", err)
 	}
 
-	for _, d := range deployList.Items {
-		deployment := Deployment{
-			Name:              d.Name,
-			Namespace:         d.Namespace,
-			Replicas:          *d.Spec.Replicas,
-			ReadyReplicas:     d.Status.ReadyReplicas,
-			UpdatedReplicas:   d.Status.UpdatedReplicas,
-			AvailableReplicas: d.Status.AvailableReplicas,
-			Age:               time.Since(d.CreationTimestamp.Time),
-			Status:            getDeploymentStatus(d),
-			Selector:          d.Spec.Selector.MatchLabels,
-		}
-		deployments = append(deployments, deployment)
+	for i := range deployList.Items {
+		deployments = append(deployments, FromAppsDeployment(&deployList.Items[i]))
 	}
 
 	return deployments, nil
@@ -109,6 +103,9 @@ func (s *service) Describe(namespace, name string) (*DeploymentDetails, error) {
 		// Pod template details
 		TemplateLabels:      d.Spec.Template.Labels,
 		TemplateAnnotations: d.Spec.Template.Annotations,
+
+		Affinity:                  d.Spec.Template.Spec.Affinity,
+		TopologySpreadConstraints: d.Spec.Template.Spec.TopologySpreadConstraints,
 	}
 
 	// Add rolling update strategy if available
@@ -312,25 +309,87 @@ func (s *service) Update(namespace, name string, opts DeploymentOptions) error {
 		return fmt.Errorf("failed to get deployment: %w", err)
 	}
 
-	if opts.Replicas != nil {
-		deployment.Spec.Replicas = opts.Replicas
+	if err := applyOptions(deployment, opts); err != nil {
+		return err
 	}
-
-	if opts.Image != "" {
-		// Update image for all containers
-		for i := range deployment.Spec.Template.Spec.Containers {
-			deployment.Spec.Template.Spec.Containers[i].Image = opts.Image
-		}
+	if opts.ResourceVersion != "" {
+		// The server rejects the update when the deployment changed since this version
+		deployment.ResourceVersion = opts.ResourceVersion
 	}
 
 	_, err = s.clientset.AppsV1().Deployments(namespace).Update(context.Background(), deployment, metav1.UpdateOptions{})
 	if err != nil {
+		if apierrors.IsConflict(err) && opts.ResourceVersion != "" {
+			return errs.New(errs.Conflict, "deployment %q changed since the update was previewed, review it again: %w", name, err)
+		}
 		return fmt.Errorf("failed to update deployment: %w", err)
 	}
 
 	return nil
 }
 
+// PreviewUpdate returns how an update would change the pod template of a deployment
+func (s *service) PreviewUpdate(namespace, name string, opts DeploymentOptions) (*TemplateChange, error) {
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errs.NotFoundf("deployment %q not found in namespace %q", name, namespace)
+		}
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	before, err := yaml.Marshal(deployment.Spec.Template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pod template: %w", err)
+	}
+	if err := applyOptions(deployment, opts); err != nil {
+		return nil, err
+	}
+	after, err := yaml.Marshal(deployment.Spec.Template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pod template: %w", err)
+	}
+
+	return &TemplateChange{
+		Namespace:       namespace,
+		Name:            name,
+		ResourceVersion: deployment.ResourceVersion,
+		Before:          string(before),
+		After:           string(after),
+	}, nil
+}
+
+// applyOptions sets the replicas and image of an update on a deployment
+func applyOptions(deployment *appsv1.Deployment, opts DeploymentOptions) error {
+	if opts.Replicas != nil {
+		deployment.Spec.Replicas = opts.Replicas
+	}
+
+	if opts.Image == "" {
+		return nil
+	}
+	containers := deployment.Spec.Template.Spec.Containers
+	if opts.Container == "" {
+		if len(containers) != 1 {
+			names := make([]string, 0, len(containers))
+			for _, c := range containers {
+				names = append(names, c.Name)
+			}
+			return errs.Validationf("deployment %q has %d containers (%s), choose the one whose image is set",
+				deployment.Name, len(containers), strings.Join(names, ", "))
+		}
+		containers[0].Image = opts.Image
+		return nil
+	}
+	for i := range containers {
+		if containers[i].Name == opts.Container {
+			containers[i].Image = opts.Image
+			return nil
+		}
+	}
+	return errs.NotFoundf("container %q not found in deployment %q", opts.Container, deployment.Name)
+}
+
 // AddMetrics adds metrics information to a list of deployments
 func (s *service) AddMetrics(deployments []Deployment) error {
 	for i := range deployments {
@@ -345,6 +404,21 @@ func (s *service) AddMetrics(deployments []Deployment) error {
 
 // Helper functions
 
+// FromAppsDeployment converts an apps deployment to a Deployment
+func FromAppsDeployment(d *appsv1.Deployment) Deployment {
+	return Deployment{
+		Name:              d.Name,
+		Namespace:         d.Namespace,
+		Replicas:          *d.Spec.Replicas,
+		ReadyReplicas:     d.Status.ReadyReplicas,
+		UpdatedReplicas:   d.Status.UpdatedReplicas,
+		AvailableReplicas: d.Status.AvailableReplicas,
+		Age:               time.Since(d.CreationTimestamp.Time),
+		Status:            getDeploymentStatus(*d),
+		Selector:          d.Spec.Selector.MatchLabels,
+	}
+}
+
 func getDeploymentStatus(d appsv1.Deployment) string {
 	if d.Generation <= d.Status.ObservedGeneration {
 		if d.Spec.Replicas != nil && d.Status.UpdatedReplicas < *d.Spec.Replicas {
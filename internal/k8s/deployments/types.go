@@ -2,10 +2,16 @@ package deployments
 
 import (
 	"time"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 // Deployment represents a Kubernetes deployment with essential information
 type Deployment struct {
+	// Context is the kubeconfig context the deployment was read from, when several were
+	// queried
+	Context string `json:",omitempty"`
+
 	Name              string
 	Namespace         string
 	Replicas          int32
@@ -37,6 +43,10 @@ type DeploymentDetails struct {
 	TemplateLabels      map[string]string
 	TemplateAnnotations map[string]string
 
+	// Scheduling constraints of the pod template
+	Affinity                  *corev1.Affinity
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+
 	// Rolling update strategy
 	RollingUpdateStrategy *RollingUpdateStrategy
 
@@ -70,6 +80,32 @@ type DeploymentMetrics struct {
 type DeploymentOptions struct {
 	Replicas *int32
 	Image    string
+
+	// Container is the container whose image is set. It may be empty when the deployment has a
+	// single container; the images of several containers are never overwritten at once.
+	Container string
+
+	// ResourceVersion makes the update fail when the deployment changed since this version,
+	// such as since its change was previewed
+	ResourceVersion string
+}
+
+// TemplateChange is the pod template of a deployment before and after an update
+type TemplateChange struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// ResourceVersion is the version of the deployment the change was computed from
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Before and After are the pod template as YAML
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Changed reports whether the update changes the pod template, which rolls out new pods
+func (c *TemplateChange) Changed() bool {
+	return c.Before != c.After
 }
 
 type RollingUpdateStrategy struct {
@@ -139,3 +175,54 @@ type EnvVar struct {
 	Value     string
 	ValueFrom string // e.g. "configmap key" or "secret key"
 }
+
+// RolloutOptions configures how a rollout is followed
+type RolloutOptions struct {
+	// Timeout bounds how long to wait for the rollout; zero waits until the progress deadline
+	Timeout time.Duration
+
+	// FailOnCrashLoop stops as soon as a pod of the new ReplicaSet crash loops or cannot pull
+	// its image, instead of waiting for the progress deadline
+	FailOnCrashLoop bool
+
+	// OnProgress is called with the status after every poll
+	OnProgress func(RolloutStatus)
+}
+
+// RolloutStatus is the progress of a deployment rollout
+type RolloutStatus struct {
+	Name              string
+	Namespace         string
+	Revision          string
+	Replicas          int32
+	UpdatedReplicas   int32
+	ReadyReplicas     int32
+	AvailableReplicas int32
+
+	// Message describes what the rollout is waiting for, as kubectl rollout status does
+	Message string
+
+	// Complete is set once all replicas are updated and available
+	Complete bool
+
+	// DeadlineExceeded is set when the deployment reports it made no progress within its deadline
+	DeadlineExceeded bool
+
+	// FailingPods lists pods of the new ReplicaSet that crash loop or cannot pull their image
+	FailingPods []FailingPod
+
+	// StartedAt is when the ReplicaSet of the current revision was created, zero before it exists
+	StartedAt time.Time
+
+	// ReadyTimes are the times the ready pods of the new ReplicaSet became ready, oldest first
+	ReadyTimes []time.Time
+}
+
+// FailingPod is a pod of a rollout stuck in a state it will not recover from by itself
+type FailingPod struct {
+	Name      string
+	Container string
+	Reason    string
+	Message   string
+	Restarts  int32
+}
@@ -0,0 +1,63 @@
+package deprecations
+
+// deprecatedAPIs lists the built-in API versions deprecated or removed since Kubernetes 1.16,
+// from https://kubernetes.io/docs/reference/using-api/deprecation-guide/
+var deprecatedAPIs = []DeprecatedAPI{
+	// Removed in 1.16
+	{"extensions/v1beta1", "Deployment", "deployments", "apps/v1", "1.9", "1.16"},
+	{"extensions/v1beta1", "DaemonSet", "daemonsets", "apps/v1", "1.9", "1.16"},
+	{"extensions/v1beta1", "ReplicaSet", "replicasets", "apps/v1", "1.9", "1.16"},
+	{"extensions/v1beta1", "NetworkPolicy", "networkpolicies", "networking.k8s.io/v1", "1.9", "1.16"},
+	{"extensions/v1beta1", "PodSecurityPolicy", "podsecuritypolicies", "policy/v1beta1", "1.10", "1.16"},
+	{"apps/v1beta1", "Deployment", "deployments", "apps/v1", "1.9", "1.16"},
+	{"apps/v1beta1", "StatefulSet", "statefulsets", "apps/v1", "1.9", "1.16"},
+	{"apps/v1beta2", "Deployment", "deployments", "apps/v1", "1.9", "1.16"},
+	{"apps/v1beta2", "StatefulSet", "statefulsets", "apps/v1", "1.9", "1.16"},
+	{"apps/v1beta2", "DaemonSet", "daemonsets", "apps/v1", "1.9", "1.16"},
+	{"apps/v1beta2", "ReplicaSet", "replicasets", "apps/v1", "1.9", "1.16"},
+
+	// Removed in 1.22
+	{"extensions/v1beta1", "Ingress", "ingresses", "networking.k8s.io/v1", "1.14", "1.22"},
+	{"networking.k8s.io/v1beta1", "Ingress", "ingresses", "networking.k8s.io/v1", "1.19", "1.22"},
+	{"networking.k8s.io/v1beta1", "IngressClass", "ingressclasses", "networking.k8s.io/v1", "1.19", "1.22"},
+	{"apiextensions.k8s.io/v1beta1", "CustomResourceDefinition", "customresourcedefinitions", "apiextensions.k8s.io/v1", "1.16", "1.22"},
+	{"admissionregistration.k8s.io/v1beta1", "MutatingWebhookConfiguration", "mutatingwebhookconfigurations", "admissionregistration.k8s.io/v1", "1.16", "1.22"},
+	{"admissionregistration.k8s.io/v1beta1", "ValidatingWebhookConfiguration", "validatingwebhookconfigurations", "admissionregistration.k8s.io/v1", "1.16", "1.22"},
+	{"apiregistration.k8s.io/v1beta1", "APIService", "apiservices", "apiregistration.k8s.io/v1", "1.19", "1.22"},
+	{"certificates.k8s.io/v1beta1", "CertificateSigningRequest", "certificatesigningrequests", "certificates.k8s.io/v1", "1.19", "1.22"},
+	{"coordination.k8s.io/v1beta1", "Lease", "leases", "coordination.k8s.io/v1", "1.19", "1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRole", "clusterroles", "rbac.authorization.k8s.io/v1", "1.17", "1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRoleBinding", "clusterrolebindings", "rbac.authorization.k8s.io/v1", "1.17", "1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "Role", "roles", "rbac.authorization.k8s.io/v1", "1.17", "1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "RoleBinding", "rolebindings", "rbac.authorization.k8s.io/v1", "1.17", "1.22"},
+	{"scheduling.k8s.io/v1beta1", "PriorityClass", "priorityclasses", "scheduling.k8s.io/v1", "1.14", "1.22"},
+	{"storage.k8s.io/v1beta1", "CSIDriver", "csidrivers", "storage.k8s.io/v1", "1.19", "1.22"},
+	{"storage.k8s.io/v1beta1", "CSINode", "csinodes", "storage.k8s.io/v1", "1.17", "1.22"},
+	{"storage.k8s.io/v1beta1", "StorageClass", "storageclasses", "storage.k8s.io/v1", "1.19", "1.22"},
+	{"storage.k8s.io/v1beta1", "VolumeAttachment", "volumeattachments", "storage.k8s.io/v1", "1.19", "1.22"},
+
+	// Removed in 1.25
+	{"batch/v1beta1", "CronJob", "cronjobs", "batch/v1", "1.21", "1.25"},
+	{"discovery.k8s.io/v1beta1", "EndpointSlice", "endpointslices", "discovery.k8s.io/v1", "1.21", "1.25"},
+	{"events.k8s.io/v1beta1", "Event", "events", "events.k8s.io/v1", "1.19", "1.25"},
+	{"autoscaling/v2beta1", "HorizontalPodAutoscaler", "horizontalpodautoscalers", "autoscaling/v2", "1.22", "1.25"},
+	{"policy/v1beta1", "PodDisruptionBudget", "poddisruptionbudgets", "policy/v1", "1.21", "1.25"},
+	{"policy/v1beta1", "PodSecurityPolicy", "podsecuritypolicies", "", "1.21", "1.25"},
+	{"node.k8s.io/v1beta1", "RuntimeClass", "runtimeclasses", "node.k8s.io/v1", "1.20", "1.25"},
+
+	// Removed in 1.26
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "FlowSchema", "flowschemas", "flowcontrol.apiserver.k8s.io/v1", "1.23", "1.26"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "PriorityLevelConfiguration", "prioritylevelconfigurations", "flowcontrol.apiserver.k8s.io/v1", "1.23", "1.26"},
+	{"autoscaling/v2beta2", "HorizontalPodAutoscaler", "horizontalpodautoscalers", "autoscaling/v2", "1.23", "1.26"},
+
+	// Removed in 1.27
+	{"storage.k8s.io/v1beta1", "CSIStorageCapacity", "csistoragecapacities", "storage.k8s.io/v1", "1.24", "1.27"},
+
+	// Removed in 1.29
+	{"flowcontrol.apiserver.k8s.io/v1beta2", "FlowSchema", "flowschemas", "flowcontrol.apiserver.k8s.io/v1", "1.26", "1.29"},
+	{"flowcontrol.apiserver.k8s.io/v1beta2", "PriorityLevelConfiguration", "prioritylevelconfigurations", "flowcontrol.apiserver.k8s.io/v1", "1.26", "1.29"},
+
+	// Removed in 1.32
+	{"flowcontrol.apiserver.k8s.io/v1beta3", "FlowSchema", "flowschemas", "flowcontrol.apiserver.k8s.io/v1", "1.29", "1.32"},
+	{"flowcontrol.apiserver.k8s.io/v1beta3", "PriorityLevelConfiguration", "prioritylevelconfigurations", "flowcontrol.apiserver.k8s.io/v1", "1.29", "1.32"},
+}
@@ -0,0 +1,27 @@
+package deprecations
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for deprecated API detection
+type Service interface {
+	// Scan reports live objects and manifests using API versions deprecated or removed in
+	// the target version
+	Scan(ctx context.Context, opts Options) (*Report, error)
+}
+
+// NewDeprecationService creates a new deprecation service instance
+func NewDeprecationService(clientset *kubernetes.Clientset, dynamicClient dynamic.Interface) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	if dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client is required")
+	}
+	return newService(clientset, dynamicClient), nil
+}
@@ -0,0 +1,213 @@
+package deprecations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// lastAppliedAnnotation holds the manifest last applied with client-side kubectl apply
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+type service struct {
+	clientset     *kubernetes.Clientset
+	dynamicClient dynamic.Interface
+}
+
+// newService creates a new deprecation service instance
+func newService(clientset *kubernetes.Clientset, dynamicClient dynamic.Interface) Service {
+	return &service{
+		clientset:     clientset,
+		dynamicClient: dynamicClient,
+	}
+}
+
+// Scan reports live objects and manifests using API versions deprecated or removed in the target version
+func (s *service) Scan(ctx context.Context, opts Options) (*Report, error) {
+	report := &Report{}
+
+	if info, err := s.clientset.Discovery().ServerVersion(); err == nil {
+		report.ServerVersion = info.GitVersion
+	} else if opts.TargetVersion == "" {
+		return nil, fmt.Errorf("failed to get server version: %w", err)
+	}
+
+	target, err := targetVersion(opts.TargetVersion, report.ServerVersion)
+	if err != nil {
+		return nil, err
+	}
+	report.TargetVersion = fmt.Sprintf("%d.%d", target.Major(), target.Minor())
+
+	// Only APIs already deprecated in the target version are of interest
+	var apis []DeprecatedAPI
+	for _, api := range deprecatedAPIs {
+		if !target.LessThan(version.MustParseGeneric(api.DeprecatedIn)) {
+			apis = append(apis, api)
+		}
+	}
+
+	for _, doc := range opts.Documents {
+		obj := doc.Object
+		if api, ok := lookup(apis, obj.GetAPIVersion(), obj.GetKind()); ok {
+			report.Findings = append(report.Findings, newFinding(api, target, fmt.Sprintf("%s#%d", doc.Source, doc.Index), obj))
+		}
+	}
+
+	if opts.Live {
+		findings, warnings, err := s.scanLive(ctx, apis, target)
+		if err != nil {
+			return nil, err
+		}
+		report.Findings = append(report.Findings, findings...)
+		report.Warnings = warnings
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Removed != b.Removed {
+			return a.Removed
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Source < b.Source
+	})
+
+	return report, nil
+}
+
+// scanLive finds live objects whose field managers or last applied manifest used a deprecated
+// API version. The API server converts objects to every served version, so the version an object
+// is read with says nothing about the version its owners send.
+func (s *service) scanLive(ctx context.Context, apis []DeprecatedAPI, target *version.Version) ([]Finding, []string, error) {
+	// Objects of a kind are read once through the replacement version, falling back to the
+	// deprecated versions on clusters too old to serve the replacement
+	type kindKey struct{ group, resource string }
+	candidates := make(map[kindKey][]string)
+	var order []kindKey
+	for _, api := range apis {
+		current := api.Replacement
+		if current == "" {
+			current = api.APIVersion
+		}
+		gv, _ := schema.ParseGroupVersion(current)
+		key := kindKey{gv.Group, api.Resource}
+		if _, ok := candidates[key]; !ok {
+			order = append(order, key)
+			if api.Replacement != "" {
+				candidates[key] = []string{api.Replacement}
+			}
+		}
+		candidates[key] = append(candidates[key], api.APIVersion)
+	}
+
+	var findings []Finding
+	var warnings []string
+	seen := make(map[string]bool)
+	for _, key := range order {
+		var list *unstructured.UnstructuredList
+		var err error
+		for _, apiVersion := range candidates[key] {
+			gv, _ := schema.ParseGroupVersion(apiVersion)
+			list, err = s.dynamicClient.Resource(gv.WithResource(key.resource)).List(ctx, metav1.ListOptions{})
+			if !apierrors.IsNotFound(err) {
+				break
+			}
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+			continue // Not served by this cluster at all
+		case apierrors.IsForbidden(err):
+			warnings = append(warnings, fmt.Sprintf("not allowed to list %s", schema.GroupResource{Group: key.group, Resource: key.resource}))
+			continue
+		case err != nil:
+			return nil, nil, fmt.Errorf("failed to list %s: %w", key.resource, err)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			id := string(obj.GetUID())
+			for source, apiVersion := range usedVersions(obj) {
+				api, ok := lookup(apis, apiVersion, obj.GetKind())
+				if !ok || seen[id+"/"+source] {
+					continue
+				}
+				seen[id+"/"+source] = true
+				findings = append(findings, newFinding(api, target, source, obj))
+			}
+		}
+	}
+
+	return findings, warnings, nil
+}
+
+// usedVersions returns the API versions clients used to write an object, keyed by how they are known
+func usedVersions(obj *unstructured.Unstructured) map[string]string {
+	used := make(map[string]string)
+	for _, entry := range obj.GetManagedFields() {
+		used["manager "+entry.Manager] = entry.APIVersion
+	}
+	if lastApplied := obj.GetAnnotations()[lastAppliedAnnotation]; lastApplied != "" {
+		var manifest struct {
+			APIVersion string `json:"apiVersion"`
+		}
+		if json.Unmarshal([]byte(lastApplied), &manifest) == nil && manifest.APIVersion != "" {
+			used["last-applied-configuration"] = manifest.APIVersion
+		}
+	}
+	return used
+}
+
+// targetVersion parses the target version, defaulting to the minor version after the server's
+func targetVersion(target, serverVersion string) (*version.Version, error) {
+	if target != "" {
+		v, err := version.ParseGeneric(target)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target version %q: %w", target, err)
+		}
+		return v, nil
+	}
+	v, err := version.ParseGeneric(serverVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse server version %q: %w", serverVersion, err)
+	}
+	return version.MajorMinor(v.Major(), v.Minor()+1), nil
+}
+
+func lookup(apis []DeprecatedAPI, apiVersion, kind string) (DeprecatedAPI, bool) {
+	for _, api := range apis {
+		if api.APIVersion == apiVersion && api.Kind == kind {
+			return api, true
+		}
+	}
+	return DeprecatedAPI{}, false
+}
+
+func newFinding(api DeprecatedAPI, target *version.Version, source string, obj *unstructured.Unstructured) Finding {
+	return Finding{
+		Source:       source,
+		APIVersion:   api.APIVersion,
+		Kind:         api.Kind,
+		Namespace:    obj.GetNamespace(),
+		Name:         obj.GetName(),
+		Replacement:  api.Replacement,
+		DeprecatedIn: api.DeprecatedIn,
+		RemovedIn:    api.RemovedIn,
+		Removed:      !target.LessThan(version.MustParseGeneric(api.RemovedIn)),
+	}
+}
@@ -0,0 +1,61 @@
+package deprecations
+
+import "k8stool/internal/k8s/resources"
+
+// DeprecatedAPI is a built-in API version of a kind that is deprecated and scheduled for removal
+type DeprecatedAPI struct {
+	APIVersion string
+	Kind       string
+
+	// Resource is the plural resource name of the kind
+	Resource string
+
+	// Replacement is the API version to migrate to; empty when the kind has no replacement
+	Replacement string
+
+	DeprecatedIn string
+	RemovedIn    string
+}
+
+// Options configures a deprecation scan
+type Options struct {
+	// TargetVersion is the Kubernetes version to check against, e.g. 1.32; it defaults to
+	// the minor version after the server's
+	TargetVersion string
+
+	// Live scans the objects in the cluster for the API versions their managers used
+	Live bool
+
+	// Documents are manifests to scan in addition to the live objects
+	Documents []resources.Document
+}
+
+// Report holds the deprecated API usage found by a scan
+type Report struct {
+	ServerVersion string    `json:"serverVersion,omitempty"`
+	TargetVersion string    `json:"targetVersion"`
+	Findings      []Finding `json:"findings"`
+
+	// Warnings lists resources that could not be scanned, e.g. for lack of permissions
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Finding is an object using a deprecated API version
+type Finding struct {
+	// Source is the manifest (file#document) or, for live objects, the field manager or the
+	// last-applied-configuration annotation that used the API version
+	Source string `json:"source"`
+
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+
+	Replacement  string `json:"replacement,omitempty"`
+	DeprecatedIn string `json:"deprecatedIn"`
+	RemovedIn    string `json:"removedIn"`
+
+	// Removed reports whether the API version is gone in the target version, rather than
+	// only deprecated
+	Removed bool `json:"removed"`
+}
@@ -0,0 +1,271 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DescribeConfigMap returns the keys of a ConfigMap and the workloads using it
+func (s *service) DescribeConfigMap(ctx context.Context, namespace, name string) (*ResourceDescription, error) {
+	cm, err := s.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap: %w", err)
+	}
+
+	details := &ConfigDataDetails{
+		Immutable: cm.Immutable != nil && *cm.Immutable,
+		Keys:      []DataKey{},
+	}
+	for key, value := range cm.Data {
+		details.Keys = append(details.Keys, DataKey{Name: key, Size: len(value)})
+	}
+	for key, value := range cm.BinaryData {
+		details.Keys = append(details.Keys, DataKey{Name: key, Size: len(value), Binary: true})
+	}
+	sort.Slice(details.Keys, func(i, j int) bool {
+		return details.Keys[i].Name < details.Keys[j].Name
+	})
+	details.LastModified, details.LastModifiedBy = lastModified(cm.ObjectMeta)
+
+	details.UsedBy, err = s.findReferences(ctx, namespace, func(spec *corev1.PodSpec) []Reference {
+		return configMapReferences(spec, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResourceDescription{
+		Type:              ConfigMap,
+		Name:              cm.Name,
+		Namespace:         cm.Namespace,
+		CreationTimestamp: cm.CreationTimestamp.Time,
+		Labels:            cm.Labels,
+		Annotations:       cm.Annotations,
+		Status:            usageStatus(details.UsedBy),
+		Details:           details,
+	}, nil
+}
+
+// DescribeSecret returns the keys of a Secret and the workloads using it, without its values
+func (s *service) DescribeSecret(ctx context.Context, namespace, name string) (*ResourceDescription, error) {
+	secret, err := s.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+
+	details := &ConfigDataDetails{
+		Type:      string(secret.Type),
+		Immutable: secret.Immutable != nil && *secret.Immutable,
+		Keys:      []DataKey{},
+	}
+	for key, value := range secret.Data {
+		details.Keys = append(details.Keys, DataKey{Name: key, Size: len(value)})
+	}
+	sort.Slice(details.Keys, func(i, j int) bool {
+		return details.Keys[i].Name < details.Keys[j].Name
+	})
+	details.LastModified, details.LastModifiedBy = lastModified(secret.ObjectMeta)
+
+	details.UsedBy, err = s.findReferences(ctx, namespace, func(spec *corev1.PodSpec) []Reference {
+		return secretReferences(spec, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The last-applied annotation of kubectl apply holds the secret data in clear text
+	annotations := make(map[string]string, len(secret.Annotations))
+	for k, v := range secret.Annotations {
+		if k == corev1.LastAppliedConfigAnnotation {
+			v = "(hidden)"
+		}
+		annotations[k] = v
+	}
+
+	return &ResourceDescription{
+		Type:              Secret,
+		Name:              secret.Name,
+		Namespace:         secret.Namespace,
+		CreationTimestamp: secret.CreationTimestamp.Time,
+		Labels:            secret.Labels,
+		Annotations:       annotations,
+		Status:            usageStatus(details.UsedBy),
+		Details:           details,
+	}, nil
+}
+
+// findReferences returns the references found by match in the pod templates of the workloads
+// in a namespace. Pods created by a controller are covered by their controller's template.
+func (s *service) findReferences(ctx context.Context, namespace string, match func(spec *corev1.PodSpec) []Reference) ([]Reference, error) {
+	refs := []Reference{}
+	add := func(kind, name string, spec *corev1.PodSpec) {
+		for _, ref := range match(spec) {
+			ref.Kind, ref.Name = kind, name
+			refs = append(refs, ref)
+		}
+	}
+
+	deployments, err := s.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		add("Deployment", d.Name, &d.Spec.Template.Spec)
+	}
+
+	statefulSets, err := s.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, sts := range statefulSets.Items {
+		add("StatefulSet", sts.Name, &sts.Spec.Template.Spec)
+	}
+
+	daemonSets, err := s.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, ds := range daemonSets.Items {
+		add("DaemonSet", ds.Name, &ds.Spec.Template.Spec)
+	}
+
+	cronJobs, err := s.clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for _, cj := range cronJobs.Items {
+		add("CronJob", cj.Name, &cj.Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	jobs, err := s.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, job := range jobs.Items {
+		if metav1.GetControllerOf(&job) == nil {
+			add("Job", job.Name, &job.Spec.Template.Spec)
+		}
+	}
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if metav1.GetControllerOf(&pod) == nil {
+			add("Pod", pod.Name, &pod.Spec)
+		}
+	}
+
+	return refs, nil
+}
+
+// configMapReferences returns the uses of a ConfigMap in a pod spec
+func configMapReferences(spec *corev1.PodSpec, name string) []Reference {
+	var refs []Reference
+	for _, c := range podContainers(spec) {
+		var keys []string
+		for _, env := range c.Env {
+			if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil && env.ValueFrom.ConfigMapKeyRef.Name == name {
+				keys = append(keys, env.ValueFrom.ConfigMapKeyRef.Key)
+			}
+		}
+		if len(keys) > 0 {
+			refs = append(refs, Reference{Container: c.Name, Via: "env", Keys: keys})
+		}
+		for _, from := range c.EnvFrom {
+			if from.ConfigMapRef != nil && from.ConfigMapRef.Name == name {
+				refs = append(refs, Reference{Container: c.Name, Via: "envFrom"})
+			}
+		}
+	}
+	for _, v := range spec.Volumes {
+		switch {
+		case v.ConfigMap != nil && v.ConfigMap.Name == name:
+			refs = append(refs, Reference{Via: "volume"})
+		case v.Projected != nil:
+			for _, source := range v.Projected.Sources {
+				if source.ConfigMap != nil && source.ConfigMap.Name == name {
+					refs = append(refs, Reference{Via: "projected volume"})
+					break
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// secretReferences returns the uses of a Secret in a pod spec
+func secretReferences(spec *corev1.PodSpec, name string) []Reference {
+	var refs []Reference
+	for _, c := range podContainers(spec) {
+		var keys []string
+		for _, env := range c.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == name {
+				keys = append(keys, env.ValueFrom.SecretKeyRef.Key)
+			}
+		}
+		if len(keys) > 0 {
+			refs = append(refs, Reference{Container: c.Name, Via: "env", Keys: keys})
+		}
+		for _, from := range c.EnvFrom {
+			if from.SecretRef != nil && from.SecretRef.Name == name {
+				refs = append(refs, Reference{Container: c.Name, Via: "envFrom"})
+			}
+		}
+	}
+	for _, v := range spec.Volumes {
+		switch {
+		case v.Secret != nil && v.Secret.SecretName == name:
+			refs = append(refs, Reference{Via: "volume"})
+		case v.Projected != nil:
+			for _, source := range v.Projected.Sources {
+				if source.Secret != nil && source.Secret.Name == name {
+					refs = append(refs, Reference{Via: "projected volume"})
+					break
+				}
+			}
+		}
+	}
+	for _, pullSecret := range spec.ImagePullSecrets {
+		if pullSecret.Name == name {
+			refs = append(refs, Reference{Via: "imagePullSecrets"})
+		}
+	}
+	return refs
+}
+
+// podContainers returns the init and regular containers of a pod spec
+func podContainers(spec *corev1.PodSpec) []corev1.Container {
+	return append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+}
+
+// lastModified returns the time and field manager of the latest entry in the managed fields.
+// Objects written before server-side field tracking only have their creation time.
+func lastModified(meta metav1.ObjectMeta) (time.Time, string) {
+	var latest time.Time
+	var manager string
+	for _, entry := range meta.ManagedFields {
+		if entry.Time != nil && !entry.Time.Time.Before(latest) {
+			latest = entry.Time.Time
+			manager = entry.Manager
+		}
+	}
+	if latest.IsZero() {
+		return meta.CreationTimestamp.Time, ""
+	}
+	return latest, manager
+}
+
+// usageStatus summarizes whether an object is used
+func usageStatus(refs []Reference) string {
+	if len(refs) == 0 {
+		return "Unused"
+	}
+	return "In use"
+}
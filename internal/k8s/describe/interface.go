@@ -21,6 +21,12 @@ type DescribeService interface {
 	// DescribeNamespace returns a detailed description of a namespace
 	DescribeNamespace(ctx context.Context, name string) (*ResourceDescription, error)
 
+	// DescribeConfigMap returns the keys of a ConfigMap and the workloads using it
+	DescribeConfigMap(ctx context.Context, namespace, name string) (*ResourceDescription, error)
+
+	// DescribeSecret returns the keys of a Secret and the workloads using it, without its values
+	DescribeSecret(ctx context.Context, namespace, name string) (*ResourceDescription, error)
+
 	// Describe returns a detailed description of any supported resource
 	Describe(ctx context.Context, resourceType ResourceType, namespace, name string) (*ResourceDescription, error)
 }
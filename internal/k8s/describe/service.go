@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/nodes"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,10 +34,11 @@ func (s *service) DescribePod(ctx context.Context, namespace, name string) (*Res
 	}
 
 	details := &PodDetails{
-		Phase:      pod.Status.Phase,
-		Conditions: pod.Status.Conditions,
-		Node:       pod.Spec.NodeName,
-		IP:         pod.Status.PodIP,
+		Phase:          pod.Status.Phase,
+		Conditions:     pod.Status.Conditions,
+		ReadinessGates: pod.Spec.ReadinessGates,
+		Node:           pod.Spec.NodeName,
+		IP:             pod.Status.PodIP,
 	}
 
 	// Get container details
@@ -229,16 +233,10 @@ func (s *service) DescribeNode(ctx context.Context, name string) (*ResourceDescr
 		return nil, fmt.Errorf("failed to get node: %w", err)
 	}
 
-	details := struct {
-		Addresses   []NodeAddress    `json:"addresses"`
-		Capacity    ResourceList     `json:"capacity"`
-		Allocatable ResourceList     `json:"allocatable"`
-		Conditions  []NodeCondition  `json:"conditions"`
-		Info        NodeSystemInfo   `json:"info"`
-		Images      []ContainerImage `json:"images"`
-	}{
+	details := &NodeDetails{
 		Capacity:    make(ResourceList),
 		Allocatable: make(ResourceList),
+		Cloud:       nodes.CloudInfoFor(node),
 	}
 
 	for _, addr := range node.Status.Addresses {
@@ -343,8 +341,12 @@ func (s *service) Describe(ctx context.Context, resourceType ResourceType, names
 		return s.DescribeNode(ctx, name)
 	case Namespace:
 		return s.DescribeNamespace(ctx, name)
+	case ConfigMap:
+		return s.DescribeConfigMap(ctx, namespace, name)
+	case Secret:
+		return s.DescribeSecret(ctx, namespace, name)
 	default:
-		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+		return nil, errs.Validationf("unsupported resource type: %s", resourceType)
 	}
 }
 
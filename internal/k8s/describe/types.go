@@ -3,6 +3,8 @@ package describe
 import (
 	"time"
 
+	"k8stool/internal/k8s/nodes"
+
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -20,6 +22,10 @@ const (
 	Node ResourceType = "node"
 	// Namespace resource type
 	Namespace ResourceType = "namespace"
+	// ConfigMap resource type
+	ConfigMap ResourceType = "configmap"
+	// Secret resource type
+	Secret ResourceType = "secret"
 )
 
 // ResourceDescription contains detailed information about a Kubernetes resource
@@ -84,6 +90,9 @@ type PodDetails struct {
 	// Conditions are the current pod conditions
 	Conditions []corev1.PodCondition `json:"conditions"`
 
+	// ReadinessGates are the extra conditions that must be true for the pod to be ready
+	ReadinessGates []corev1.PodReadinessGate `json:"readinessGates,omitempty"`
+
 	// Node is the name of the node running the pod
 	Node string `json:"node"`
 
@@ -162,3 +171,67 @@ type VolumeDetails struct {
 	// MountPath is where the volume is mounted
 	MountPath string `json:"mountPath,omitempty"`
 }
+
+// DataKey is a key of a ConfigMap or Secret
+type DataKey struct {
+	// Name is the key
+	Name string `json:"name"`
+
+	// Size is the size of the value in bytes
+	Size int `json:"size"`
+
+	// Binary is set for keys stored in binaryData
+	Binary bool `json:"binary,omitempty"`
+}
+
+// Reference is a workload using a ConfigMap or Secret
+type Reference struct {
+	// Kind is the workload kind, e.g. Deployment or Pod
+	Kind string `json:"kind"`
+
+	// Name is the workload name
+	Name string `json:"name"`
+
+	// Container is the container using the object, empty for volumes and image pull secrets
+	Container string `json:"container,omitempty"`
+
+	// Via is how the object is used: envFrom, env, volume, projected volume or imagePullSecrets
+	Via string `json:"via"`
+
+	// Keys are the keys used by env references, empty when all keys are used
+	Keys []string `json:"keys,omitempty"`
+}
+
+// ConfigDataDetails contains the details of a ConfigMap or Secret
+type ConfigDataDetails struct {
+	// Type is the secret type, empty for ConfigMaps
+	Type string `json:"type,omitempty"`
+
+	// Immutable is set when the data cannot be changed
+	Immutable bool `json:"immutable"`
+
+	// Keys are the data keys with the size of their values, sorted by name
+	Keys []DataKey `json:"keys"`
+
+	// UsedBy lists the workloads in the namespace that reference the object
+	UsedBy []Reference `json:"usedBy"`
+
+	// LastModified is the latest update recorded in the managed fields
+	LastModified time.Time `json:"lastModified,omitempty"`
+
+	// LastModifiedBy is the field manager of the latest update, e.g. kubectl-client-side-apply
+	LastModifiedBy string `json:"lastModifiedBy,omitempty"`
+}
+
+// NodeDetails contains the details of a node
+type NodeDetails struct {
+	Addresses   []NodeAddress    `json:"addresses"`
+	Capacity    ResourceList     `json:"capacity"`
+	Allocatable ResourceList     `json:"allocatable"`
+	Conditions  []NodeCondition  `json:"conditions"`
+	Info        NodeSystemInfo   `json:"info"`
+	Images      []ContainerImage `json:"images"`
+
+	// Cloud is the instance metadata read from the cloud provider labels
+	Cloud nodes.CloudInfo `json:"cloud"`
+}
@@ -0,0 +1,22 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for cluster health checks
+type Service interface {
+	// Run executes all checks and returns the report
+	Run(ctx context.Context, opts Options) (*Report, error)
+}
+
+// NewDoctorService creates a new doctor service instance
+func NewDoctorService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
@@ -0,0 +1,446 @@
+package doctor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/images"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// pendingThreshold is how long a pod may stay pending before it is reported
+const pendingThreshold = 5 * time.Minute
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new doctor service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+type check struct {
+	name string
+	run  func(ctx context.Context, opts Options) CheckResult
+}
+
+// Run executes all checks and returns the report
+func (s *service) Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.CertExpiryDays <= 0 {
+		opts.CertExpiryDays = 30
+	}
+
+	// API reachability gates everything else
+	api := s.checkAPI(ctx)
+	report := &Report{Results: []CheckResult{api}}
+	if api.Status == Fail {
+		return report, nil
+	}
+
+	checks := []check{
+		{name: "metrics-server", run: s.checkMetricsServer},
+		{name: "nodes", run: s.checkNodes},
+		{name: "pods", run: s.checkPods},
+		{name: "webhooks", run: s.checkWebhooks},
+		{name: "certificates", run: s.checkCertificates},
+		{name: "deprecated-apis", run: s.checkDeprecatedAPIs},
+		{name: "image-pinning", run: s.checkImagePinning},
+	}
+
+	skip := make(map[string]bool)
+	for _, name := range opts.Skip {
+		skip[name] = true
+	}
+
+	for _, c := range checks {
+		if skip[c.name] {
+			continue
+		}
+		result := c.run(ctx, opts)
+		result.Name = c.name
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
+
+func (s *service) checkAPI(ctx context.Context) CheckResult {
+	version, err := s.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return CheckResult{
+			Name:    "api-server",
+			Status:  Fail,
+			Message: fmt.Sprintf("API server unreachable: %v", err),
+			Hint:    "Check the kubeconfig server address, network access and credentials",
+		}
+	}
+	return CheckResult{
+		Name:    "api-server",
+		Status:  Pass,
+		Message: fmt.Sprintf("reachable, version %s", version.GitVersion),
+	}
+}
+
+func (s *service) checkMetricsServer(ctx context.Context, opts Options) CheckResult {
+	if _, err := s.clientset.Discovery().ServerResourcesForGroupVersion("metrics.k8s.io/v1beta1"); err != nil {
+		return CheckResult{
+			Status:  Warn,
+			Message: "metrics.k8s.io API is not available",
+			Hint:    "Install metrics-server to enable --metrics and the metrics command",
+		}
+	}
+	return CheckResult{Status: Pass, Message: "metrics.k8s.io API is available"}
+}
+
+func (s *service) checkNodes(ctx context.Context, opts Options) CheckResult {
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return CheckResult{Status: Warn, Message: fmt.Sprintf("failed to list nodes: %v", err)}
+	}
+
+	var notReady, pressure []string
+	for _, node := range nodes.Items {
+		for _, c := range node.Status.Conditions {
+			switch c.Type {
+			case corev1.NodeReady:
+				if c.Status != corev1.ConditionTrue {
+					notReady = append(notReady, fmt.Sprintf("%s: NotReady (%s)", node.Name, c.Reason))
+				}
+			case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure, corev1.NodeNetworkUnavailable:
+				if c.Status == corev1.ConditionTrue {
+					pressure = append(pressure, fmt.Sprintf("%s: %s", node.Name, c.Type))
+				}
+			}
+		}
+		if node.Spec.Unschedulable {
+			pressure = append(pressure, fmt.Sprintf("%s: cordoned", node.Name))
+		}
+	}
+
+	switch {
+	case len(notReady) > 0:
+		return CheckResult{
+			Status:  Fail,
+			Message: fmt.Sprintf("%d of %d nodes not ready", len(notReady), len(nodes.Items)),
+			Details: append(notReady, pressure...),
+			Hint:    "Inspect the node with 'k8stool describe node NAME' and check kubelet health",
+		}
+	case len(pressure) > 0:
+		return CheckResult{
+			Status:  Warn,
+			Message: fmt.Sprintf("%d node conditions need attention", len(pressure)),
+			Details: pressure,
+			Hint:    "Free resources on the affected nodes or uncordon them when maintenance is done",
+		}
+	}
+	return CheckResult{Status: Pass, Message: fmt.Sprintf("all %d nodes ready", len(nodes.Items))}
+}
+
+func (s *service) checkPods(ctx context.Context, opts Options) CheckResult {
+	podList, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return CheckResult{Status: Warn, Message: fmt.Sprintf("failed to list pods: %v", err)}
+	}
+
+	var crashing, pending []string
+	for _, pod := range podList.Items {
+		name := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+
+		if pod.Status.Phase == corev1.PodPending && time.Since(pod.CreationTimestamp.Time) > pendingThreshold {
+			pending = append(pending, fmt.Sprintf("%s: pending for %s", name, time.Since(pod.CreationTimestamp.Time).Round(time.Minute)))
+		}
+
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting == nil {
+				continue
+			}
+			switch cs.State.Waiting.Reason {
+			case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError":
+				crashing = append(crashing, fmt.Sprintf("%s (%s): %s", name, cs.Name, cs.State.Waiting.Reason))
+			}
+		}
+	}
+
+	switch {
+	case len(crashing) > 0:
+		return CheckResult{
+			Status:  Fail,
+			Message: fmt.Sprintf("%d containers failing, %d pods pending", len(crashing), len(pending)),
+			Details: append(crashing, pending...),
+			Hint:    "Check 'k8stool logs pod/NAME --previous' and the pod events",
+		}
+	case len(pending) > 0:
+		return CheckResult{
+			Status:  Warn,
+			Message: fmt.Sprintf("%d pods pending for more than %s", len(pending), pendingThreshold),
+			Details: pending,
+			Hint:    "Check FailedScheduling events for resource or affinity constraints",
+		}
+	}
+	return CheckResult{Status: Pass, Message: fmt.Sprintf("%d pods checked, none failing", len(podList.Items))}
+}
+
+func (s *service) checkWebhooks(ctx context.Context, opts Options) CheckResult {
+	type webhookRef struct {
+		name          string
+		failurePolicy *admissionregistrationv1.FailurePolicyType
+		service       *admissionregistrationv1.ServiceReference
+	}
+
+	var refs []webhookRef
+
+	validating, err := s.clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return CheckResult{Status: Warn, Message: fmt.Sprintf("failed to list validating webhooks: %v", err)}
+	}
+	for _, cfg := range validating.Items {
+		for _, wh := range cfg.Webhooks {
+			refs = append(refs, webhookRef{name: cfg.Name + "/" + wh.Name, failurePolicy: wh.FailurePolicy, service: wh.ClientConfig.Service})
+		}
+	}
+
+	mutating, err := s.clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return CheckResult{Status: Warn, Message: fmt.Sprintf("failed to list mutating webhooks: %v", err)}
+	}
+	for _, cfg := range mutating.Items {
+		for _, wh := range cfg.Webhooks {
+			refs = append(refs, webhookRef{name: cfg.Name + "/" + wh.Name, failurePolicy: wh.FailurePolicy, service: wh.ClientConfig.Service})
+		}
+	}
+
+	var failing, degraded []string
+	for _, ref := range refs {
+		if ref.service == nil {
+			continue
+		}
+
+		endpoints, err := s.clientset.CoreV1().Endpoints(ref.service.Namespace).Get(ctx, ref.service.Name, metav1.GetOptions{})
+		ready := 0
+		if err == nil {
+			for _, subset := range endpoints.Subsets {
+				ready += len(subset.Addresses)
+			}
+		}
+		if ready > 0 {
+			continue
+		}
+
+		msg := fmt.Sprintf("%s: service %s/%s has no ready endpoints", ref.name, ref.service.Namespace, ref.service.Name)
+		if ref.failurePolicy == nil || *ref.failurePolicy == admissionregistrationv1.Fail {
+			failing = append(failing, msg)
+		} else {
+			degraded = append(degraded, msg)
+		}
+	}
+
+	switch {
+	case len(failing) > 0:
+		return CheckResult{
+			Status:  Fail,
+			Message: fmt.Sprintf("%d webhooks with failurePolicy=Fail have no backend", len(failing)),
+			Details: append(failing, degraded...),
+			Hint:    "Restore the webhook service or remove the stale webhook configuration; API writes matching it will be rejected",
+		}
+	case len(degraded) > 0:
+		return CheckResult{
+			Status:  Warn,
+			Message: fmt.Sprintf("%d webhooks have no backend", len(degraded)),
+			Details: degraded,
+			Hint:    "The webhooks are ignored on failure but add latency to every matching request",
+		}
+	}
+	return CheckResult{Status: Pass, Message: fmt.Sprintf("%d webhooks checked", len(refs))}
+}
+
+func (s *service) checkCertificates(ctx context.Context, opts Options) CheckResult {
+	secrets, err := s.clientset.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		FieldSelector: "type=" + string(corev1.SecretTypeTLS),
+	})
+	if err != nil {
+		return CheckResult{Status: Warn, Message: fmt.Sprintf("failed to list TLS secrets: %v", err)}
+	}
+
+	threshold := time.Duration(opts.CertExpiryDays) * 24 * time.Hour
+	var expired, expiring []string
+	for _, secret := range secrets.Items {
+		notAfter, err := earliestExpiry(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			continue
+		}
+		name := fmt.Sprintf("%s/%s", secret.Namespace, secret.Name)
+		remaining := time.Until(notAfter)
+		switch {
+		case remaining <= 0:
+			expired = append(expired, fmt.Sprintf("%s: expired %s", name, notAfter.Format(time.RFC3339)))
+		case remaining < threshold:
+			expiring = append(expiring, fmt.Sprintf("%s: expires in %d days", name, int(remaining.Hours()/24)))
+		}
+	}
+
+	switch {
+	case len(expired) > 0:
+		return CheckResult{
+			Status:  Fail,
+			Message: fmt.Sprintf("%d certificates expired", len(expired)),
+			Details: append(expired, expiring...),
+			Hint:    "Renew the certificates (or check cert-manager Certificate resources)",
+		}
+	case len(expiring) > 0:
+		return CheckResult{
+			Status:  Warn,
+			Message: fmt.Sprintf("%d certificates expire within %d days", len(expiring), opts.CertExpiryDays),
+			Details: expiring,
+			Hint:    "Schedule renewal before expiry",
+		}
+	}
+	return CheckResult{Status: Pass, Message: fmt.Sprintf("%d TLS secrets checked", len(secrets.Items))}
+}
+
+// deprecatedAPIMetric matches the apiserver metric that records requests to deprecated APIs
+var deprecatedAPIMetric = regexp.MustCompile(`^apiserver_requested_deprecated_apis\{(.*)\}\s+1`)
+
+func (s *service) checkDeprecatedAPIs(ctx context.Context, opts Options) CheckResult {
+	data, err := s.clientset.CoreV1().RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+	if err != nil {
+		return CheckResult{
+			Status:  Warn,
+			Message: "unable to read API server metrics",
+			Hint:    "Deprecated API usage requires get access to the /metrics non-resource URL",
+		}
+	}
+
+	var found []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		m := deprecatedAPIMetric.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		labels := parseMetricLabels(m[1])
+		gv := labels["version"]
+		if labels["group"] != "" {
+			gv = labels["group"] + "/" + labels["version"]
+		}
+		entry := fmt.Sprintf("%s %s", gv, labels["resource"])
+		if labels["removed_release"] != "" {
+			entry += fmt.Sprintf(" (removed in %s)", labels["removed_release"])
+		}
+		found = append(found, entry)
+	}
+
+	if len(found) > 0 {
+		sort.Strings(found)
+		return CheckResult{
+			Status:  Warn,
+			Message: fmt.Sprintf("%d deprecated APIs requested since API server start", len(found)),
+			Details: found,
+			Hint:    "Update manifests and clients to the replacement API versions before upgrading",
+		}
+	}
+	return CheckResult{Status: Pass, Message: "no deprecated API requests recorded"}
+}
+
+func (s *service) checkImagePinning(ctx context.Context, opts Options) CheckResult {
+	apps := s.clientset.AppsV1()
+	templates := make(map[string]corev1.PodTemplateSpec)
+	deployments, err := apps.Deployments("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return CheckResult{Status: Warn, Message: fmt.Sprintf("failed to list deployments: %v", err)}
+	}
+	for _, d := range deployments.Items {
+		templates[fmt.Sprintf("%s/deploy/%s", d.Namespace, d.Name)] = d.Spec.Template
+	}
+	statefulSets, err := apps.StatefulSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return CheckResult{Status: Warn, Message: fmt.Sprintf("failed to list statefulsets: %v", err)}
+	}
+	for _, sts := range statefulSets.Items {
+		templates[fmt.Sprintf("%s/sts/%s", sts.Namespace, sts.Name)] = sts.Spec.Template
+	}
+	daemonSets, err := apps.DaemonSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return CheckResult{Status: Warn, Message: fmt.Sprintf("failed to list daemonsets: %v", err)}
+	}
+	for _, ds := range daemonSets.Items {
+		templates[fmt.Sprintf("%s/ds/%s", ds.Namespace, ds.Name)] = ds.Spec.Template
+	}
+
+	var found []string
+	workloads := make(map[string]bool)
+	for name, template := range templates {
+		containers := append(append([]corev1.Container{}, template.Spec.InitContainers...), template.Spec.Containers...)
+		for _, c := range containers {
+			if reason, mutable := images.MutableTag(c.Image); mutable {
+				found = append(found, fmt.Sprintf("%s (%s): %s, %s", name, c.Name, c.Image, reason))
+				workloads[name] = true
+			}
+		}
+	}
+
+	if len(found) > 0 {
+		sort.Strings(found)
+		return CheckResult{
+			Status:  Warn,
+			Message: fmt.Sprintf("%d containers in %d workloads use mutable image tags", len(found), len(workloads)),
+			Details: found,
+			Hint:    "A rollback or rescheduled pod may pull a different build; pin them with 'k8stool image pinning -A --fix'",
+		}
+	}
+	return CheckResult{Status: Pass, Message: fmt.Sprintf("%d workloads checked, all images pinned to a version or digest", len(templates))}
+}
+
+// earliestExpiry returns the earliest NotAfter of the certificates in a PEM bundle
+func earliestExpiry(data []byte) (time.Time, error) {
+	var earliest time.Time
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if earliest.IsZero() || cert.NotAfter.Before(earliest) {
+			earliest = cert.NotAfter
+		}
+	}
+	if earliest.IsZero() {
+		return earliest, fmt.Errorf("no certificates found")
+	}
+	return earliest, nil
+}
+
+// parseMetricLabels parses a Prometheus label set like a="1",b="2"
+func parseMetricLabels(s string) map[string]string {
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		labels[strings.TrimSpace(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return labels
+}
@@ -0,0 +1,56 @@
+package doctor
+
+// Status represents the outcome of a check
+type Status string
+
+const (
+	// Pass means the check found no problems
+	Pass Status = "PASS"
+	// Warn means the check found something worth looking at
+	Warn Status = "WARN"
+	// Fail means the check found a problem that needs fixing
+	Fail Status = "FAIL"
+)
+
+// CheckResult contains the outcome of a single check
+type CheckResult struct {
+	// Name is the check name
+	Name string `json:"name"`
+
+	// Status is the check outcome
+	Status Status `json:"status"`
+
+	// Message summarizes what was found
+	Message string `json:"message"`
+
+	// Details lists the individual offending items
+	Details []string `json:"details,omitempty"`
+
+	// Hint suggests how to remediate a warning or failure
+	Hint string `json:"hint,omitempty"`
+}
+
+// Report contains the results of all checks
+type Report struct {
+	Results []CheckResult `json:"results"`
+}
+
+// Options configures which checks run and their thresholds
+type Options struct {
+	// CertExpiryDays is the threshold below which certificates are reported
+	CertExpiryDays int
+
+	// Skip lists check names that should not run
+	Skip []string
+}
+
+// Count returns the number of results with the given status
+func (r *Report) Count(status Status) int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
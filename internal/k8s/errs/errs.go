@@ -0,0 +1,124 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Category is the cause of a failure
+type Category string
+
+const (
+	// Unknown is any failure without a more specific category
+	Unknown Category = "Unknown"
+	// NotFound means a requested object does not exist
+	NotFound Category = "NotFound"
+	// Forbidden means the caller is not authenticated or not allowed to perform the action
+	Forbidden Category = "Forbidden"
+	// Timeout means an operation did not finish in time
+	Timeout Category = "Timeout"
+	// Conflict means the object already exists or changed concurrently
+	Conflict Category = "Conflict"
+	// Validation means the input or an object is invalid
+	Validation Category = "Validation"
+)
+
+// ExitCode returns the process exit code for the category
+func (c Category) ExitCode() int {
+	switch c {
+	case NotFound:
+		return 3
+	case Forbidden:
+		return 4
+	case Timeout:
+		return 5
+	case Conflict:
+		return 6
+	case Validation:
+		return 7
+	default:
+		return 1
+	}
+}
+
+// Error is an error with a category
+type Error struct {
+	Category Category
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns an error of the category with a formatted message; %w is supported
+func New(category Category, format string, args ...interface{}) error {
+	return &Error{Category: category, Err: fmt.Errorf(format, args...)}
+}
+
+// Wrap assigns a category to an error, returning nil for a nil error
+func Wrap(category Category, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Category: category, Err: err}
+}
+
+// NotFoundf returns a NotFound error with a formatted message
+func NotFoundf(format string, args ...interface{}) error {
+	return New(NotFound, format, args...)
+}
+
+// Validationf returns a Validation error with a formatted message
+func Validationf(format string, args ...interface{}) error {
+	return New(Validation, format, args...)
+}
+
+// Timeoutf returns a Timeout error with a formatted message
+func Timeoutf(format string, args ...interface{}) error {
+	return New(Timeout, format, args...)
+}
+
+// CategoryOf returns the category of the first categorized error in the chain. Errors of the
+// API server and context deadlines are categorized from their type, so services only need to
+// wrap them with %w.
+func CategoryOf(err error) Category {
+	if err == nil {
+		return Unknown
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Category
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		return NotFound
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return Forbidden
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return Timeout
+	case apierrors.IsConflict(err), apierrors.IsAlreadyExists(err):
+		return Conflict
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return Validation
+	default:
+		return Unknown
+	}
+}
+
+// ExitCode returns the process exit code for an error: 0 for nil, 1 for uncategorized errors
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return CategoryOf(err).ExitCode()
+}
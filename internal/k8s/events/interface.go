@@ -17,4 +17,8 @@ type EventService interface {
 
 	// Get returns a specific event by name
 	Get(ctx context.Context, namespace, name string) (*Event, error)
+
+	// Stats counts the events of each reason and kind of object over a window and the window
+	// before it
+	Stats(ctx context.Context, opts StatsOptions) (*Stats, error)
 }
@@ -75,28 +75,7 @@ func (s *service) List(ctx context.Context, namespace string, filter *EventFilte
 	}
 
 	if filter != nil {
-		// Apply sorting
-		switch filter.SortBy {
-		case SortByTime:
-			sort.Slice(result.Items, func(i, j int) bool {
-				return result.Items[i].LastTimestamp.After(result.Items[j].LastTimestamp)
-			})
-		case SortByCount:
-			sort.Slice(result.Items, func(i, j int) bool {
-				return result.Items[i].Count > result.Items[j].Count
-			})
-		case SortByType:
-			sort.Slice(result.Items, func(i, j int) bool {
-				return string(result.Items[i].Type) < string(result.Items[j].Type)
-			})
-		case SortByResource:
-			sort.Slice(result.Items, func(i, j int) bool {
-				if result.Items[i].ResourceKind == result.Items[j].ResourceKind {
-					return result.Items[i].ResourceName < result.Items[j].ResourceName
-				}
-				return result.Items[i].ResourceKind < result.Items[j].ResourceKind
-			})
-		}
+		Sort(result.Items, filter.SortBy)
 
 		// Apply limit
 		if filter.Limit > 0 && len(result.Items) > filter.Limit {
@@ -107,6 +86,31 @@ func (s *service) List(ctx context.Context, namespace string, filter *EventFilte
 	return result, nil
 }
 
+// Sort orders events by the given option; unknown options leave the order unchanged
+func Sort(items []Event, sortBy EventSortOption) {
+	switch sortBy {
+	case SortByTime:
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].LastTimestamp.After(items[j].LastTimestamp)
+		})
+	case SortByCount:
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].Count > items[j].Count
+		})
+	case SortByType:
+		sort.Slice(items, func(i, j int) bool {
+			return string(items[i].Type) < string(items[j].Type)
+		})
+	case SortByResource:
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].ResourceKind == items[j].ResourceKind {
+				return items[i].ResourceName < items[j].ResourceName
+			}
+			return items[i].ResourceKind < items[j].ResourceKind
+		})
+	}
+}
+
 // ListForObject returns events related to a specific resource
 func (s *service) ListForObject(ctx context.Context, namespace, kind, name string) (*EventList, error) {
 	return s.List(ctx, namespace, &EventFilter{
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// statsKey groups the events of a reason about one kind of object
+type statsKey struct {
+	reason string
+	kind   string
+}
+
+// Stats counts the events of each reason and kind over the window and the window before it.
+// An event seen several times stands for occurrences spread between its first and last
+// timestamps, so the part of them falling in each window is counted.
+func (s *service) Stats(ctx context.Context, opts StatsOptions) (*Stats, error) {
+	if opts.Window <= 0 {
+		return nil, errs.Validationf("invalid window %s: must be positive", opts.Window)
+	}
+
+	listOpts := metav1.ListOptions{}
+	if opts.WarningsOnly {
+		listOpts.FieldSelector = "type=" + string(Warning)
+	}
+	list, err := s.clientset.CoreV1().Events(opts.Namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	until := time.Now()
+	since := until.Add(-opts.Window)
+	previousSince := since.Add(-opts.Window)
+
+	counts := make(map[statsKey]*ReasonStats)
+	objects := make(map[statsKey]map[string]bool)
+	for i := range list.Items {
+		e := &list.Items[i]
+		first, last, count := occurrences(e)
+		current := spread(first, last, count, since, until)
+		previous := spread(first, last, count, previousSince, since)
+		if current == 0 && previous == 0 {
+			continue
+		}
+
+		key := statsKey{reason: e.Reason, kind: e.InvolvedObject.Kind}
+		stats := counts[key]
+		if stats == nil {
+			stats = &ReasonStats{Reason: e.Reason, Kind: e.InvolvedObject.Kind, Type: Normal}
+			counts[key] = stats
+			objects[key] = make(map[string]bool)
+		}
+		if e.Type == string(Warning) {
+			stats.Type = Warning
+		}
+		stats.Count += current
+		stats.Previous += previous
+		if current > 0 {
+			objects[key][e.InvolvedObject.Namespace+"/"+e.InvolvedObject.Name] = true
+		}
+	}
+
+	result := &Stats{Namespace: opts.Namespace, Since: since, Until: until, Reasons: []ReasonStats{}}
+	for key, stats := range counts {
+		stats.Objects = len(objects[key])
+		result.Reasons = append(result.Reasons, *stats)
+	}
+	sort.Slice(result.Reasons, func(i, j int) bool {
+		a, b := result.Reasons[i], result.Reasons[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Previous != b.Previous {
+			return a.Previous > b.Previous
+		}
+		if a.Reason != b.Reason {
+			return a.Reason < b.Reason
+		}
+		return a.Kind < b.Kind
+	})
+	return result, nil
+}
+
+// occurrences returns when an event was first and last seen and how often. Events recorded
+// through the events.k8s.io API set the event time and series instead of the timestamps.
+func occurrences(e *corev1.Event) (time.Time, time.Time, int) {
+	first, last := e.FirstTimestamp.Time, e.LastTimestamp.Time
+	count := int(e.Count)
+	if last.IsZero() {
+		last = e.EventTime.Time
+		if e.Series != nil {
+			last = e.Series.LastObservedTime.Time
+			count = int(e.Series.Count)
+		}
+	}
+	if last.IsZero() {
+		last = e.CreationTimestamp.Time
+	}
+	if first.IsZero() || first.After(last) {
+		first = e.EventTime.Time
+	}
+	if first.IsZero() || first.After(last) {
+		first = last
+	}
+	if count < 1 {
+		count = 1
+	}
+	return first, last, count
+}
+
+// spread returns how many of count occurrences, spread evenly from first to last, fall in
+// [from, to). An event overlapping the window counts at least once.
+func spread(first, last time.Time, count int, from, to time.Time) int {
+	if !last.Equal(first) {
+		start, end := first, last
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			return 0
+		}
+		share := float64(count) * float64(end.Sub(start)) / float64(last.Sub(first))
+		return max(1, int(math.Round(share)))
+	}
+	if !last.Before(from) && last.Before(to) {
+		return count
+	}
+	return 0
+}
@@ -20,6 +20,9 @@ const (
 
 // Event represents a Kubernetes event with additional metadata
 type Event struct {
+	// Context is the kubeconfig context the event was read from, when several were queried
+	Context string `json:"context,omitempty"`
+
 	// Type is the event type (Normal, Warning, Error)
 	Type EventType `json:"type"`
 
@@ -120,6 +123,68 @@ type EventOptions struct {
 	BufferSize int `json:"bufferSize,omitempty"`
 }
 
+// StatsOptions configures the event statistics
+type StatsOptions struct {
+	// Namespace is the namespace to count events in; empty counts every namespace
+	Namespace string `json:"namespace,omitempty"`
+
+	// Window is the period counted, compared with the same period before it
+	Window time.Duration `json:"window"`
+
+	// WarningsOnly counts only warning events
+	WarningsOnly bool `json:"warningsOnly,omitempty"`
+}
+
+// Stats are the event counts of a window per reason and kind of object
+type Stats struct {
+	// Namespace is the namespace counted; empty for every namespace
+	Namespace string `json:"namespace,omitempty"`
+
+	// Since is the start of the window
+	Since time.Time `json:"since"`
+
+	// Until is the end of the window
+	Until time.Time `json:"until"`
+
+	// Reasons are the counts, most frequent first
+	Reasons []ReasonStats `json:"reasons"`
+}
+
+// ReasonStats counts the events of one reason about one kind of object
+type ReasonStats struct {
+	// Reason is the reason of the events, such as FailedMount
+	Reason string `json:"reason"`
+
+	// Kind is the kind of the objects the events are about
+	Kind string `json:"kind"`
+
+	// Type is Warning when any of the events is a warning, Normal otherwise
+	Type EventType `json:"type"`
+
+	// Count is the number of times the events occurred in the window
+	Count int `json:"count"`
+
+	// Previous is the number of times they occurred in the window before
+	Previous int `json:"previous"`
+
+	// Objects is the number of objects the events of the window are about
+	Objects int `json:"objects"`
+}
+
+// Trend compares the count of the window with the window before: up, down, same, or new
+// when there were none before
+func (r ReasonStats) Trend() string {
+	switch {
+	case r.Previous == 0 && r.Count > 0:
+		return "new"
+	case r.Count > r.Previous:
+		return "up"
+	case r.Count < r.Previous:
+		return "down"
+	}
+	return "same"
+}
+
 // FromCoreEvent converts a core event to an Event
 func FromCoreEvent(e *corev1.Event) *Event {
 	return &Event{
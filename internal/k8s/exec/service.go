@@ -2,6 +2,7 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
@@ -10,6 +11,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
 type service struct {
@@ -74,8 +76,13 @@ func (s *service) Exec(ctx context.Context, namespace, pod string, opts *ExecOpt
 	})
 
 	if err != nil {
+		exitCode := -1
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitStatus()
+		}
 		return &ExecResult{
-			ExitCode: -1,
+			ExitCode: exitCode,
 			Error:    err.Error(),
 		}, nil
 	}
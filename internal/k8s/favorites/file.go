@@ -0,0 +1,124 @@
+package favorites
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+)
+
+// kinds maps the names a favorite can be given with to its kind
+var kinds = map[string]string{
+	"ns": KindNamespace, "namespace": KindNamespace, "namespaces": KindNamespace,
+	"deploy": KindDeployment, "deployment": KindDeployment, "deployments": KindDeployment,
+	"sts": KindStatefulSet, "statefulset": KindStatefulSet, "statefulsets": KindStatefulSet,
+	"ds": KindDaemonSet, "daemonset": KindDaemonSet, "daemonsets": KindDaemonSet,
+}
+
+// Parse parses a favorite given as TYPE/NAME, such as deploy/web or ns/prod. Workloads are
+// in namespace; namespaces ignore it.
+func Parse(ref, contextName, namespace string) (Favorite, error) {
+	kind, name, ok := strings.Cut(ref, "/")
+	if !ok || name == "" {
+		return Favorite{}, errs.Validationf("invalid favorite %q: must be TYPE/NAME, e.g. deploy/web or ns/prod", ref)
+	}
+	fav := Favorite{Context: contextName, Kind: kinds[strings.ToLower(kind)], Name: name}
+	switch fav.Kind {
+	case "":
+		return Favorite{}, errs.Validationf("invalid favorite %q: type must be ns, deploy, sts or ds", ref)
+	case KindNamespace:
+	default:
+		fav.Namespace = namespace
+	}
+	return fav, nil
+}
+
+// Ref returns the TYPE/NAME form of a favorite, with its namespace for workloads
+func (f Favorite) Ref() string {
+	short := map[string]string{KindNamespace: "ns", KindDeployment: "deploy", KindStatefulSet: "sts", KindDaemonSet: "ds"}[f.Kind]
+	if f.Namespace == "" {
+		return short + "/" + f.Name
+	}
+	return f.Namespace + "/" + short + "/" + f.Name
+}
+
+// DefaultPath returns the file favorites are kept in, under the user configuration directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the configuration directory: %w", err)
+	}
+	return filepath.Join(dir, "k8stool", "favorites.json"), nil
+}
+
+// Load reads the favorites file; a missing file has no favorites
+func Load(path string) ([]Favorite, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read favorites: %w", err)
+	}
+	var favs []Favorite
+	if err := json.Unmarshal(data, &favs); err != nil {
+		return nil, errs.New(errs.Validation, "failed to read favorites from %s: %v", path, err)
+	}
+	return favs, nil
+}
+
+// Save writes the favorites file, replacing it atomically
+func Save(path string, favs []Favorite) error {
+	data, err := json.MarshalIndent(favs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to write favorites: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to write favorites: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write favorites: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write favorites: %w", err)
+	}
+	return nil
+}
+
+// Add appends a favorite unless it is already there, reporting whether it was added
+func Add(favs []Favorite, fav Favorite) ([]Favorite, bool) {
+	for _, f := range favs {
+		if f == fav {
+			return favs, false
+		}
+	}
+	return append(favs, fav), true
+}
+
+// Remove removes a favorite, reporting whether it was there
+func Remove(favs []Favorite, fav Favorite) ([]Favorite, bool) {
+	for i, f := range favs {
+		if f == fav {
+			return append(favs[:i:i], favs[i+1:]...), true
+		}
+	}
+	return favs, false
+}
+
+// InContext returns the favorites of a context
+func InContext(favs []Favorite, contextName string) []Favorite {
+	var result []Favorite
+	for _, f := range favs {
+		if f.Context == contextName {
+			result = append(result, f)
+		}
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package favorites
+
+import (
+	"context"
+	"fmt"
+
+	"k8stool/internal/k8s/metrics"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for checking the health of favorites
+type Service interface {
+	// Status checks the health of favorites, in the order given. A favorite that cannot be
+	// checked has its Error set instead of failing the others.
+	Status(ctx context.Context, favs []Favorite, opts StatusOptions) ([]Status, error)
+}
+
+// NewFavoritesService creates a new favorites service instance
+func NewFavoritesService(clientset *kubernetes.Clientset, metricsService metrics.Service) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	if metricsService == nil {
+		return nil, fmt.Errorf("metrics service is required")
+	}
+	return newService(clientset, metricsService), nil
+}
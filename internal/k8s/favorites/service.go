@@ -0,0 +1,218 @@
+package favorites
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8stool/internal/k8s/metrics"
+	"k8stool/internal/k8s/pods"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultSince is how far back warning events are counted when StatusOptions.Since is zero
+const defaultSince = time.Hour
+
+type service struct {
+	clientset      *kubernetes.Clientset
+	metricsService metrics.Service
+}
+
+// newService creates a new favorites service instance
+func newService(clientset *kubernetes.Clientset, metricsService metrics.Service) Service {
+	return &service{
+		clientset:      clientset,
+		metricsService: metricsService,
+	}
+}
+
+// namespaceData is what the favorites of a namespace are checked against, fetched once per
+// namespace
+type namespaceData struct {
+	warnings []corev1.Event
+	usage    map[string]metrics.ResourceMetrics
+}
+
+// Status checks the health of favorites
+func (s *service) Status(ctx context.Context, favs []Favorite, opts StatusOptions) ([]Status, error) {
+	since := opts.Since
+	if since <= 0 {
+		since = defaultSince
+	}
+	cutoff := time.Now().Add(-since)
+
+	cache := make(map[string]*namespaceData)
+	statuses := make([]Status, 0, len(favs))
+	for _, fav := range favs {
+		status := Status{Favorite: fav}
+		if err := s.check(ctx, &status, cache, cutoff); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			status.Error = err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// check fills in the health of one favorite
+func (s *service) check(ctx context.Context, status *Status, cache map[string]*namespaceData, cutoff time.Time) error {
+	namespace := status.Namespace
+	if status.Kind == KindNamespace {
+		namespace = status.Name
+	}
+
+	// The workload and the objects its events can be about
+	var selector labels.Selector
+	owned := map[string]bool{}
+	var err error
+	switch status.Kind {
+	case KindNamespace:
+		_, err = s.clientset.CoreV1().Namespaces().Get(ctx, status.Name, metav1.GetOptions{})
+		selector = labels.Everything()
+	case KindDeployment:
+		d, getErr := s.clientset.AppsV1().Deployments(namespace).Get(ctx, status.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			status.Desired, status.Ready = replicas(d.Spec.Replicas), d.Status.ReadyReplicas
+			if selector, err = metav1.LabelSelectorAsSelector(d.Spec.Selector); err == nil {
+				err = s.addReplicaSets(ctx, namespace, d.UID, selector, owned)
+			}
+		}
+	case KindStatefulSet:
+		sts, getErr := s.clientset.AppsV1().StatefulSets(namespace).Get(ctx, status.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			status.Desired, status.Ready = replicas(sts.Spec.Replicas), sts.Status.ReadyReplicas
+			selector, err = metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+		}
+	case KindDaemonSet:
+		ds, getErr := s.clientset.AppsV1().DaemonSets(namespace).Get(ctx, status.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			status.Desired, status.Ready = ds.Status.DesiredNumberScheduled, ds.Status.NumberReady
+			selector, err = metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+		}
+	default:
+		return fmt.Errorf("unsupported kind %q", status.Kind)
+	}
+	if apierrors.IsNotFound(err) {
+		status.Health = NotFound
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	owned[status.Kind+"/"+status.Name] = true
+
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		owned["Pod/"+pod.Name] = true
+		for _, cs := range pod.Status.ContainerStatuses {
+			status.Restarts += cs.RestartCount
+		}
+		if status.Kind == KindNamespace {
+			status.Desired++
+			if pods.IsReady(&pod) {
+				status.Ready++
+			}
+		}
+	}
+
+	data, err := s.namespaceData(ctx, namespace, cache)
+	if err != nil {
+		return err
+	}
+	for _, e := range data.warnings {
+		if (status.Kind == KindNamespace || owned[e.InvolvedObject.Kind+"/"+e.InvolvedObject.Name]) && !lastSeen(e).Before(cutoff) {
+			status.Warnings++
+		}
+	}
+	if data.usage != nil {
+		status.MetricsAvailable = true
+		for name, usage := range data.usage {
+			if owned["Pod/"+name] {
+				status.CPUUsage += usage.CPU.UsageNanoCores / 1e6
+				status.MemoryUsage += usage.Memory.UsageBytes
+			}
+		}
+	}
+
+	switch {
+	case status.Desired == 0 || status.Ready >= status.Desired:
+		status.Health = Healthy
+	case status.Ready == 0:
+		status.Health = Unavailable
+	default:
+		status.Health = Degraded
+	}
+	return nil
+}
+
+// addReplicaSets adds the ReplicaSets of a deployment to owned, since rollout warnings are
+// reported on them
+func (s *service) addReplicaSets(ctx context.Context, namespace string, uid types.UID, selector labels.Selector, owned map[string]bool) error {
+	rsList, err := s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	for _, rs := range rsList.Items {
+		if ref := metav1.GetControllerOf(&rs); ref != nil && ref.UID == uid {
+			owned["ReplicaSet/"+rs.Name] = true
+		}
+	}
+	return nil
+}
+
+// namespaceData returns the warning events and pod usage of a namespace
+func (s *service) namespaceData(ctx context.Context, namespace string, cache map[string]*namespaceData) (*namespaceData, error) {
+	if data, ok := cache[namespace]; ok {
+		return data, nil
+	}
+	events, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "type=" + corev1.EventTypeWarning})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	data := &namespaceData{warnings: events.Items}
+
+	// Usage is best effort, the rest of the health is still useful without metrics-server
+	if podMetrics, err := s.metricsService.ListPodMetrics(namespace); err == nil {
+		data.usage = make(map[string]metrics.ResourceMetrics, len(podMetrics))
+		for _, pm := range podMetrics {
+			data.usage[pm.Name] = pm.TotalResources
+		}
+	}
+	cache[namespace] = data
+	return data, nil
+}
+
+// replicas returns the desired replicas of a workload, which default to one
+func replicas(n *int32) int32 {
+	if n == nil {
+		return 1
+	}
+	return *n
+}
+
+// lastSeen returns when an event last happened
+func lastSeen(e corev1.Event) time.Time {
+	switch {
+	case e.Series != nil:
+		return e.Series.LastObservedTime.Time
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
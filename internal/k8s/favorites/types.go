@@ -0,0 +1,58 @@
+package favorites
+
+import "time"
+
+// Kinds of favorites
+const (
+	KindNamespace   = "Namespace"
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+)
+
+// Health of a favorite
+const (
+	Healthy     = "Healthy"
+	Degraded    = "Degraded"
+	Unavailable = "Unavailable"
+	NotFound    = "NotFound"
+)
+
+// Favorite is a namespace or workload followed by the user in a kubeconfig context
+type Favorite struct {
+	Context string `json:"context"`
+	Kind    string `json:"kind"`
+
+	// Namespace is empty for namespaces
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// StatusOptions configures the health check of favorites
+type StatusOptions struct {
+	// Since is how far back warning events are counted
+	Since time.Duration
+}
+
+// Status is the health of a favorite. For a namespace the replicas are its pods, not counting
+// completed ones. CPU usage is in millicores and memory usage in bytes.
+type Status struct {
+	Favorite
+
+	Health   string `json:"health"`
+	Desired  int32  `json:"desired"`
+	Ready    int32  `json:"ready"`
+	Restarts int32  `json:"restarts"`
+
+	// Warnings is the number of warning events of the favorite and its pods within Since
+	Warnings int `json:"warnings"`
+
+	CPUUsage    int64 `json:"cpuUsage"`
+	MemoryUsage int64 `json:"memoryUsage"`
+
+	// MetricsAvailable is false when the metrics API could not be queried; usage is then zero
+	MetricsAvailable bool `json:"metricsAvailable"`
+
+	// Error is set when the favorite could not be checked
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,170 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// credential is a username and password for a registry
+type credential struct {
+	username string
+	password string
+}
+
+// dockerConfig is the credential part of a docker config.json or a dockerconfigjson secret
+type dockerConfig struct {
+	Auths       map[string]dockerAuth `json:"auths"`
+	CredHelpers map[string]string     `json:"credHelpers"`
+	CredsStore  string                `json:"credsStore"`
+}
+
+// dockerAuth is the entry of a registry in a docker config
+type dockerAuth struct {
+	Auth     string `json:"auth"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ecrHost matches the registry hosts of Amazon ECR, capturing the region
+var ecrHost = regexp.MustCompile(`^\d+\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com$`)
+
+// credentialsFor finds credentials for a registry, trying in order the pull secrets of the
+// workload, the local docker config and its credential helpers, the AWS CLI for ECR and a
+// GitHub token for GHCR. Nil means anonymous access.
+func credentialsFor(ctx context.Context, registry string, pullSecrets []dockerConfig) *credential {
+	for _, config := range pullSecrets {
+		if cred := config.lookup(registry); cred != nil {
+			return cred
+		}
+	}
+
+	local := localDockerConfig()
+	if cred := local.lookup(registry); cred != nil {
+		return cred
+	}
+	helper := local.CredHelpers[registry]
+	if helper == "" {
+		helper = local.CredsStore
+	}
+	if helper != "" {
+		if cred := credentialHelper(ctx, helper, registry); cred != nil {
+			return cred
+		}
+	}
+
+	if match := ecrHost.FindStringSubmatch(registry); match != nil {
+		out, err := exec.CommandContext(ctx, "aws", "ecr", "get-login-password", "--region", match[1]).Output()
+		if err == nil {
+			return &credential{username: "AWS", password: strings.TrimSpace(string(out))}
+		}
+	}
+	if registry == "ghcr.io" {
+		for _, env := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+			if token := os.Getenv(env); token != "" {
+				return &credential{username: "k8stool", password: token}
+			}
+		}
+	}
+	return nil
+}
+
+// lookup returns the credentials stored for a registry in the auths of a docker config
+func (c dockerConfig) lookup(registry string) *credential {
+	for server, auth := range c.Auths {
+		if normalizeServer(server) != registry {
+			continue
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				continue
+			}
+			if username, password, ok := strings.Cut(string(decoded), ":"); ok {
+				return &credential{username: username, password: password}
+			}
+		}
+		if auth.Username != "" {
+			return &credential{username: auth.Username, password: auth.Password}
+		}
+	}
+	return nil
+}
+
+// normalizeServer reduces a docker config server key, which may be a URL, to a registry host
+func normalizeServer(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	server, _, _ = strings.Cut(server, "/")
+	switch server {
+	case "index.docker.io", "registry-1.docker.io":
+		return dockerHub
+	}
+	return server
+}
+
+// localDockerConfig reads the docker config of the user, from $DOCKER_CONFIG or ~/.docker
+func localDockerConfig() dockerConfig {
+	var config dockerConfig
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return config
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "config.json")); err == nil {
+		_ = json.Unmarshal(data, &config)
+	}
+	return config
+}
+
+// credentialHelper asks a docker credential helper, such as ecr-login or gcloud, for the
+// credentials of a registry
+func credentialHelper(ctx context.Context, helper, registry string) *credential {
+	server := registry
+	if registry == dockerHub {
+		server = "https://index.docker.io/v1/"
+	}
+	cmd := exec.CommandContext(ctx, "docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	var result struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(out), &result); err != nil || result.Secret == "" {
+		return nil
+	}
+	return &credential{username: result.Username, password: result.Secret}
+}
+
+// parsePullSecret reads the docker config of an image pull secret
+func parsePullSecret(secret *corev1.Secret) (dockerConfig, bool) {
+	var config dockerConfig
+	switch secret.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return config, false
+		}
+	case corev1.SecretTypeDockercfg:
+		// The legacy format is the auths map on its own
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &config.Auths); err != nil {
+			return config, false
+		}
+	default:
+		return config, false
+	}
+	return config, true
+}
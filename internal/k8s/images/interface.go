@@ -0,0 +1,29 @@
+package images
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for container image lookups
+type Service interface {
+	// Tags compares the images of a workload with the tags in their registries
+	Tags(ctx context.Context, opts TagOptions) (*TagReport, error)
+
+	// Pinning finds workloads whose images use mutable tags, with patches pinning them to
+	// the digests their pods run
+	Pinning(ctx context.Context, opts PinOptions) ([]PinFinding, error)
+
+	// Pin applies the patch of a finding to its workload
+	Pin(ctx context.Context, finding PinFinding) error
+}
+
+// NewImageService creates a new image service instance
+func NewImageService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
@@ -0,0 +1,217 @@
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fieldManager is the field manager recorded for pinning patches
+const fieldManager = "k8stool"
+
+// floatingTags are tags conventionally moved to every new build
+var floatingTags = map[string]bool{
+	"stable":  true,
+	"main":    true,
+	"master":  true,
+	"edge":    true,
+	"nightly": true,
+	"dev":     true,
+	"develop": true,
+	"canary":  true,
+}
+
+// MutableTag reports whether an image is referenced by a tag that is routinely pushed again,
+// and why. Images pinned to a digest are never mutable, whatever their tag.
+func MutableTag(image string) (string, bool) {
+	ref, err := ParseReference(image)
+	if err != nil || ref.Digest != "" {
+		return "", false
+	}
+	name := image
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	switch {
+	case !strings.Contains(name, ":"):
+		return "no tag, defaults to latest", true
+	case ref.Tag == "latest":
+		return "latest tag", true
+	case floatingTags[strings.ToLower(ref.Tag)]:
+		return fmt.Sprintf("floating tag %s", ref.Tag), true
+	}
+	return "", false
+}
+
+// workload is a workload with the pod template its images are read from
+type workload struct {
+	kind      string
+	namespace string
+	name      string
+	template  corev1.PodTemplateSpec
+	selector  *metav1.LabelSelector
+}
+
+// Pinning finds workloads whose images use mutable tags, with patches pinning them to the
+// digests their pods run
+func (s *service) Pinning(ctx context.Context, opts PinOptions) ([]PinFinding, error) {
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+	workloads, err := s.listWorkloads(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []PinFinding
+	for _, w := range workloads {
+		if finding, ok := s.pinFinding(ctx, w); ok {
+			findings = append(findings, finding)
+		}
+	}
+	return findings, nil
+}
+
+// listWorkloads lists the deployments, statefulsets and daemonsets of a namespace, or of all
+// namespaces when it is empty
+func (s *service) listWorkloads(ctx context.Context, namespace string) ([]workload, error) {
+	apps := s.clientset.AppsV1()
+	var workloads []workload
+
+	deployments, err := apps.Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		workloads = append(workloads, workload{"deployment", d.Namespace, d.Name, d.Spec.Template, d.Spec.Selector})
+	}
+
+	statefulSets, err := apps.StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, sts := range statefulSets.Items {
+		workloads = append(workloads, workload{"statefulset", sts.Namespace, sts.Name, sts.Spec.Template, sts.Spec.Selector})
+	}
+
+	daemonSets, err := apps.DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, ds := range daemonSets.Items {
+		workloads = append(workloads, workload{"daemonset", ds.Namespace, ds.Name, ds.Spec.Template, ds.Spec.Selector})
+	}
+
+	sort.SliceStable(workloads, func(i, j int) bool {
+		return workloads[i].namespace < workloads[j].namespace
+	})
+	return workloads, nil
+}
+
+// pinFinding checks the containers of a workload for mutable tags. The pods are only listed
+// when one is found, to look up the digests they run.
+func (s *service) pinFinding(ctx context.Context, w workload) (PinFinding, bool) {
+	finding := PinFinding{Namespace: w.namespace, Kind: w.kind, Name: w.name}
+	var running map[string][]string
+
+	patch := map[string][]map[string]string{}
+	for _, init := range []bool{true, false} {
+		containers, field := w.template.Spec.Containers, "containers"
+		if init {
+			containers, field = w.template.Spec.InitContainers, "initContainers"
+		}
+		for _, c := range containers {
+			reason, mutable := MutableTag(c.Image)
+			if !mutable {
+				continue
+			}
+			if running == nil {
+				running = s.runningDigests(ctx, w.namespace, w.selector)
+			}
+
+			image := MutableImage{
+				Container:      c.Name,
+				InitContainer:  init,
+				Image:          c.Image,
+				Reason:         reason,
+				RunningDigests: running[c.Name],
+			}
+			switch len(image.RunningDigests) {
+			case 0:
+				image.Problem = "no running pod reports its digest"
+			case 1:
+				image.Pinned = withDigest(c.Image, image.RunningDigests[0])
+				patch[field] = append(patch[field], map[string]string{"name": c.Name, "image": image.Pinned})
+			default:
+				// Pinning one of them would silently roll the other pods to it
+				image.Problem = fmt.Sprintf("pods run %d different digests", len(image.RunningDigests))
+			}
+			finding.Containers = append(finding.Containers, image)
+		}
+	}
+	if len(finding.Containers) == 0 {
+		return finding, false
+	}
+
+	if len(patch) > 0 {
+		data, err := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": patch,
+				},
+			},
+		})
+		if err == nil {
+			finding.Patch = string(data)
+		}
+	}
+	return finding, true
+}
+
+// withDigest replaces the tag of an image with a digest, keeping the name as written
+func withDigest(image, digest string) string {
+	name := image
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name + "@" + digest
+}
+
+// Pin applies the patch of a finding to its workload
+func (s *service) Pin(ctx context.Context, finding PinFinding) error {
+	if finding.Patch == "" {
+		return errs.Validationf("%s %q has no container that can be pinned", finding.Kind, finding.Name)
+	}
+
+	data := []byte(finding.Patch)
+	opts := metav1.PatchOptions{FieldManager: fieldManager}
+	apps := s.clientset.AppsV1()
+	var err error
+	switch finding.Kind {
+	case "deployment":
+		_, err = apps.Deployments(finding.Namespace).Patch(ctx, finding.Name, types.StrategicMergePatchType, data, opts)
+	case "statefulset":
+		_, err = apps.StatefulSets(finding.Namespace).Patch(ctx, finding.Name, types.StrategicMergePatchType, data, opts)
+	case "daemonset":
+		_, err = apps.DaemonSets(finding.Namespace).Patch(ctx, finding.Name, types.StrategicMergePatchType, data, opts)
+	default:
+		return errs.Validationf("unsupported kind %q: must be deployment, statefulset or daemonset", finding.Kind)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return errs.NotFoundf("%s %q not found in namespace %q", finding.Kind, finding.Name, finding.Namespace)
+		}
+		return fmt.Errorf("failed to patch %s %s: %w", finding.Kind, finding.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package images
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// dockerHub is the registry of image names without a registry host
+	dockerHub = "docker.io"
+	// dockerHubAPI is the host serving Docker Hub's registry API
+	dockerHubAPI = "registry-1.docker.io"
+)
+
+// Reference is a parsed image name
+type Reference struct {
+	// Registry is the registry host, docker.io for Docker Hub
+	Registry string `json:"registry"`
+	// Repository is the repository path, with library/ for official Docker Hub images
+	Repository string `json:"repository"`
+	// Tag is empty when the image is referenced by digest only
+	Tag    string `json:"tag,omitempty"`
+	Digest string `json:"digest,omitempty"`
+}
+
+// ParseReference parses an image name as the container runtime does: a first component
+// without a dot, colon or localhost is a Docker Hub repository, and a missing tag and digest
+// mean the latest tag.
+func ParseReference(image string) (Reference, error) {
+	var ref Reference
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.Digest = name[:i], name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+	}
+	if name == "" {
+		return ref, fmt.Errorf("invalid image reference %q", image)
+	}
+
+	ref.Registry = dockerHub
+	if first, rest, found := strings.Cut(name, "/"); found &&
+		(strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry, name = first, rest
+	}
+	if ref.Registry == "index.docker.io" {
+		ref.Registry = dockerHub
+	}
+	if ref.Registry == dockerHub && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	ref.Repository = name
+
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+	return ref, nil
+}
+
+// ImageDigest returns the repository digest of the image ID a container status reports, such
+// as docker.io/library/nginx@sha256:..., or an empty string when the ID has none
+func ImageDigest(imageID string) string {
+	_, digest, _ := strings.Cut(imageID, "@")
+	return digest
+}
+
+// ShortDigest abbreviates a digest to the first 12 characters of its hash, as docker does
+func ShortDigest(digest string) string {
+	_, hash, found := strings.Cut(digest, ":")
+	if !found {
+		hash = digest
+	}
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	return hash
+}
+
+// apiHost returns the host serving the registry API
+func (r Reference) apiHost() string {
+	if r.Registry == dockerHub {
+		return dockerHubAPI
+	}
+	return r.Registry
+}
+
+// String returns the image name with the registry and tag spelled out
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
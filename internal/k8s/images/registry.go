@@ -0,0 +1,200 @@
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+)
+
+// maxTagPages bounds how many pages of a tag list are fetched
+const maxTagPages = 50
+
+// manifestTypes are the manifest media types accepted when resolving a tag to its digest,
+// multi-platform indexes first so the digest matches the one the runtime pulled
+var manifestTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var (
+	// challengeParam matches the parameters of a WWW-Authenticate challenge
+	challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+	// nextLink matches the next page of a paginated registry response
+	nextLink = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+)
+
+// registryClient talks to the distribution API of one repository
+type registryClient struct {
+	httpClient *http.Client
+	ref        Reference
+	cred       *credential
+	token      string
+}
+
+// baseURL returns the API root of the registry; local registries are reached over plain HTTP
+func (c *registryClient) baseURL() string {
+	host := c.ref.apiHost()
+	if strings.HasPrefix(host, "localhost") || strings.HasPrefix(host, "127.0.0.1") {
+		return "http://" + host + "/v2/"
+	}
+	return "https://" + host + "/v2/"
+}
+
+// Tags lists all tags of the repository, following pagination
+func (c *registryClient) Tags(ctx context.Context) ([]string, error) {
+	next := c.baseURL() + c.ref.Repository + "/tags/list?n=1000"
+	var tags []string
+	for page := 0; next != "" && page < maxTagPages; page++ {
+		resp, err := c.do(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode tag list: %w", err)
+		}
+		tags = append(tags, list.Tags...)
+
+		next = ""
+		if match := nextLink.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+			link, err := url.Parse(match[1])
+			if err == nil {
+				next = resp.Request.URL.ResolveReference(link).String()
+			}
+		}
+	}
+	return tags, nil
+}
+
+// Digest resolves a tag to the digest of its manifest
+func (c *registryClient) Digest(ctx context.Context, tag string) (string, error) {
+	resp, err := c.do(ctx, http.MethodHead, c.baseURL()+c.ref.Repository+"/manifests/"+tag, manifestTypes)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("Docker-Content-Digest"), nil
+}
+
+// do sends a request, authenticating once when the registry answers with a challenge
+func (c *registryClient) do(ctx context.Context, method, rawURL string, accept []string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, mediaType := range accept {
+			req.Header.Add("Accept", mediaType)
+		}
+		switch {
+		case c.token != "":
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		case c.cred != nil && attempt > 0:
+			req.SetBasicAuth(c.cred.username, c.cred.password)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reach registry %s: %w", c.ref.Registry, err)
+		}
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+			if err := c.authenticate(ctx, challenge); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.StatusCode >= 300 {
+			resp.Body.Close()
+			return nil, c.statusError(resp.StatusCode)
+		}
+		return resp, nil
+	}
+}
+
+// authenticate answers a WWW-Authenticate challenge: a bearer challenge is exchanged for a
+// token, with the credentials when there are any; a basic challenge uses the credentials
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		if c.cred == nil {
+			return errs.New(errs.Forbidden, "registry %s requires credentials; log in with docker login", c.ref.Registry)
+		}
+		return nil
+	}
+
+	values := make(map[string]string)
+	for _, match := range challengeParam.FindAllStringSubmatch(params, -1) {
+		values[strings.ToLower(match[1])] = match[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || values["realm"] == "" {
+		return fmt.Errorf("registry %s sent an invalid authentication challenge", c.ref.Registry)
+	}
+	query := realm.Query()
+	if service := values["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", "repository:"+c.ref.Repository+":pull")
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if c.cred != nil {
+		req.SetBasicAuth(c.cred.username, c.cred.password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get a token for registry %s: %w", c.ref.Registry, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return c.statusError(resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode token of registry %s: %w", c.ref.Registry, err)
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	return nil
+}
+
+// statusError categorizes an error status of the registry
+func (c *registryClient) statusError(status int) error {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		hint := "log in with docker login or add an image pull secret"
+		if c.cred != nil {
+			hint = "the credentials found were refused"
+		}
+		return errs.New(errs.Forbidden, "access to %s/%s denied: %s", c.ref.Registry, c.ref.Repository, hint)
+	case http.StatusNotFound:
+		return errs.NotFoundf("repository %s/%s not found", c.ref.Registry, c.ref.Repository)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("registry %s rate limit reached, try again later", c.ref.Registry)
+	}
+	return fmt.Errorf("registry %s answered with status %d", c.ref.Registry, status)
+}
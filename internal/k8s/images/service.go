@@ -0,0 +1,209 @@
+package images
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type service struct {
+	clientset  *kubernetes.Clientset
+	httpClient *http.Client
+}
+
+// newService creates a new image service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset:  clientset,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Tags compares the images of a workload with the tags in their registries
+func (s *service) Tags(ctx context.Context, opts TagOptions) (*TagReport, error) {
+	template, selector, err := s.podTemplate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := append(append([]corev1.Container{}, template.Spec.InitContainers...), template.Spec.Containers...)
+	if opts.Container != "" {
+		var selected []corev1.Container
+		for _, c := range containers {
+			if c.Name == opts.Container {
+				selected = append(selected, c)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, errs.NotFoundf("container %q not found in %s %q", opts.Container, opts.Kind, opts.Name)
+		}
+		containers = selected
+	}
+
+	running := s.runningDigests(ctx, opts.Namespace, selector)
+	pullSecrets := s.pullSecrets(ctx, opts.Namespace, template)
+
+	report := &TagReport{Namespace: opts.Namespace, Kind: opts.Kind, Name: opts.Name}
+	for _, c := range containers {
+		report.Containers = append(report.Containers, s.containerTags(ctx, c, running[c.Name], pullSecrets, opts.Limit))
+	}
+	return report, nil
+}
+
+// podTemplate returns the pod template and selector of a deployment, statefulset or daemonset
+func (s *service) podTemplate(ctx context.Context, opts TagOptions) (*corev1.PodTemplateSpec, *metav1.LabelSelector, error) {
+	var template corev1.PodTemplateSpec
+	var selector *metav1.LabelSelector
+	var err error
+
+	apps := s.clientset.AppsV1()
+	switch opts.Kind {
+	case "deployment":
+		d, getErr := apps.Deployments(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+		err = getErr
+		if err == nil {
+			template, selector = d.Spec.Template, d.Spec.Selector
+		}
+	case "statefulset":
+		sts, getErr := apps.StatefulSets(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+		err = getErr
+		if err == nil {
+			template, selector = sts.Spec.Template, sts.Spec.Selector
+		}
+	case "daemonset":
+		ds, getErr := apps.DaemonSets(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+		err = getErr
+		if err == nil {
+			template, selector = ds.Spec.Template, ds.Spec.Selector
+		}
+	default:
+		return nil, nil, errs.Validationf("unsupported kind %q: must be deployment, statefulset or daemonset", opts.Kind)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, errs.NotFoundf("%s %q not found in namespace %q", opts.Kind, opts.Name, opts.Namespace)
+		}
+		return nil, nil, fmt.Errorf("failed to get %s: %w", opts.Kind, err)
+	}
+	return &template, selector, nil
+}
+
+// runningDigests returns the image digests the pods of a workload run, per container
+func (s *service) runningDigests(ctx context.Context, namespace string, selector *metav1.LabelSelector) map[string][]string {
+	digests := make(map[string][]string)
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return digests
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return digests
+	}
+
+	seen := make(map[string]bool)
+	for _, pod := range pods.Items {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			digest := ImageDigest(status.ImageID)
+			if digest == "" || seen[status.Name+digest] {
+				continue
+			}
+			seen[status.Name+digest] = true
+			digests[status.Name] = append(digests[status.Name], digest)
+		}
+	}
+	for name := range digests {
+		sort.Strings(digests[name])
+	}
+	return digests
+}
+
+// pullSecrets reads the image pull secrets of a pod template and of its service account.
+// Secrets that cannot be read are skipped, since local credentials may still work.
+func (s *service) pullSecrets(ctx context.Context, namespace string, template *corev1.PodTemplateSpec) []dockerConfig {
+	refs := template.Spec.ImagePullSecrets
+	serviceAccount := template.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+	if sa, err := s.clientset.CoreV1().ServiceAccounts(namespace).Get(ctx, serviceAccount, metav1.GetOptions{}); err == nil {
+		refs = append(refs, sa.ImagePullSecrets...)
+	}
+
+	var configs []dockerConfig
+	for _, ref := range refs {
+		secret, err := s.clientset.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		if config, ok := parsePullSecret(secret); ok {
+			configs = append(configs, config)
+		}
+	}
+	return configs
+}
+
+// containerTags queries the registry of a container's image for its tags and the current
+// digest of the deployed tag. Registry failures are reported on the container, so the other
+// containers are still looked up.
+func (s *service) containerTags(ctx context.Context, c corev1.Container, running []string, pullSecrets []dockerConfig, limit int) ContainerTags {
+	result := ContainerTags{Container: c.Name, Image: c.Image, RunningDigests: running}
+	ref, err := ParseReference(c.Image)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Reference = ref
+
+	client := &registryClient{
+		httpClient: s.httpClient,
+		ref:        ref,
+		cred:       credentialsFor(ctx, ref.Registry, pullSecrets),
+	}
+
+	tags, err := client.Tags(ctx)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.TotalTags = len(tags)
+
+	if ref.Tag != "" {
+		result.Newer, result.Comparable = newerTags(ref.Tag, tags)
+		if digest, err := client.Digest(ctx, ref.Tag); err == nil && digest != "" {
+			result.RegistryDigest = digest
+			result.TagMoved = len(running) > 0 && ref.Digest == ""
+			for _, d := range running {
+				if d == digest {
+					result.TagMoved = false
+				}
+			}
+		}
+	}
+	if !result.Comparable {
+		result.Highest = highestTags(tags)
+	}
+
+	if limit > 0 {
+		result.Newer = truncate(result.Newer, limit)
+		result.Highest = truncate(result.Highest, limit)
+	}
+	return result
+}
+
+// truncate keeps the first n values
+func truncate(values []string, n int) []string {
+	if len(values) > n {
+		return values[:n]
+	}
+	return values
+}
@@ -0,0 +1,94 @@
+package images
+
+// TagOptions selects the workload whose images are looked up
+type TagOptions struct {
+	Namespace string
+
+	// Kind is deployment, statefulset or daemonset
+	Kind string
+	Name string
+
+	// Container limits the lookup to one container; empty looks up all of them
+	Container string
+
+	// Limit is the maximum number of tags reported per container; 0 reports all
+	Limit int
+}
+
+// TagReport is the registry tags of the images of a workload
+type TagReport struct {
+	Namespace  string          `json:"namespace"`
+	Kind       string          `json:"kind"`
+	Name       string          `json:"name"`
+	Containers []ContainerTags `json:"containers"`
+}
+
+// ContainerTags compares the image of a container with the tags in its registry
+type ContainerTags struct {
+	Container string    `json:"container"`
+	Image     string    `json:"image"`
+	Reference Reference `json:"reference"`
+
+	// RunningDigests are the digests the workload's pods run for the container
+	RunningDigests []string `json:"runningDigests,omitempty"`
+
+	// RegistryDigest is the digest the deployed tag currently points to
+	RegistryDigest string `json:"registryDigest,omitempty"`
+
+	// TagMoved is set when the deployed tag now points to a build the pods do not run
+	TagMoved bool `json:"tagMoved"`
+
+	// Comparable is set when the deployed tag is a version that newer tags are compared with
+	Comparable bool `json:"comparable"`
+
+	// Newer are the tags of the same variant newer than the deployed one, newest first
+	Newer []string `json:"newer,omitempty"`
+
+	// Highest are the highest version tags, reported when the deployed tag is not a version
+	Highest []string `json:"highest,omitempty"`
+
+	// TotalTags is the number of tags in the repository
+	TotalTags int `json:"totalTags"`
+
+	// Error is why the registry could not be queried
+	Error string `json:"error,omitempty"`
+}
+
+// PinOptions selects the workloads checked for mutable image tags
+type PinOptions struct {
+	Namespace     string
+	AllNamespaces bool
+}
+
+// PinFinding is a workload whose containers use mutable image tags
+type PinFinding struct {
+	Namespace string `json:"namespace"`
+
+	// Kind is deployment, statefulset or daemonset
+	Kind       string         `json:"kind"`
+	Name       string         `json:"name"`
+	Containers []MutableImage `json:"containers"`
+
+	// Patch is a strategic merge patch pinning every container that runs a single digest;
+	// empty when none can be pinned
+	Patch string `json:"patch,omitempty"`
+}
+
+// MutableImage is a container image referenced by a tag that can be pushed again
+type MutableImage struct {
+	Container     string `json:"container"`
+	InitContainer bool   `json:"initContainer,omitempty"`
+	Image         string `json:"image"`
+
+	// Reason is why the tag is considered mutable
+	Reason string `json:"reason"`
+
+	// RunningDigests are the digests the workload's pods run for the container
+	RunningDigests []string `json:"runningDigests,omitempty"`
+
+	// Pinned is the image pinned to the running digest
+	Pinned string `json:"pinned,omitempty"`
+
+	// Problem is why the image cannot be pinned automatically
+	Problem string `json:"problem,omitempty"`
+}
@@ -0,0 +1,105 @@
+package images
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// versionTag matches tags made of dot-separated numbers, with an optional v prefix and a
+// suffix naming a variant, such as v1.2.3 or 1.25.3-alpine
+var versionTag = regexp.MustCompile(`^(v?)(\d+(?:\.\d+)*)(.*)$`)
+
+// version is a tag parsed as a version
+type version struct {
+	tag    string
+	prefix string
+	parts  []int
+	suffix string
+}
+
+// parseVersion parses a tag as a version, reporting false for tags such as latest or a commit
+func parseVersion(tag string) (version, bool) {
+	match := versionTag.FindStringSubmatch(tag)
+	if match == nil {
+		return version{}, false
+	}
+	v := version{tag: tag, prefix: match[1], suffix: match[3]}
+	for _, part := range strings.Split(match[2], ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return version{}, false
+		}
+		v.parts = append(v.parts, n)
+	}
+	// A long number, such as a date or build number, is not a version component
+	if len(v.parts) == 1 && len(match[2]) >= 6 {
+		return version{}, false
+	}
+	return v, true
+}
+
+// sameShape reports whether two versions are of the same variant and precision, so 1.25.3
+// is compared with 1.27.0 but not with 1.27 or 1.27.0-alpine
+func (v version) sameShape(o version) bool {
+	return v.prefix == o.prefix && v.suffix == o.suffix && len(v.parts) == len(o.parts)
+}
+
+// less reports whether v is an older version than o of the same shape
+func (v version) less(o version) bool {
+	for i := range v.parts {
+		if v.parts[i] != o.parts[i] {
+			return v.parts[i] < o.parts[i]
+		}
+	}
+	return false
+}
+
+// newerTags returns the tags of the same shape as current that are newer, newest first
+func newerTags(current string, tags []string) ([]string, bool) {
+	cur, ok := parseVersion(current)
+	if !ok {
+		return nil, false
+	}
+	var newer []version
+	for _, tag := range tags {
+		if v, ok := parseVersion(tag); ok && v.sameShape(cur) && cur.less(v) {
+			newer = append(newer, v)
+		}
+	}
+	return sortedTags(newer), true
+}
+
+// highestTags returns the version tags without a variant suffix, highest first, for images
+// deployed with a tag that is not a version
+func highestTags(tags []string) []string {
+	var versions []version
+	for _, tag := range tags {
+		if v, ok := parseVersion(tag); ok && v.suffix == "" {
+			versions = append(versions, v)
+		}
+	}
+	return sortedTags(versions)
+}
+
+// sortedTags sorts versions newest first, more precise versions before shorter ones
+func sortedTags(versions []version) []string {
+	sort.Slice(versions, func(i, j int) bool {
+		a, b := versions[i], versions[j]
+		for k := 0; k < len(a.parts) && k < len(b.parts); k++ {
+			if a.parts[k] != b.parts[k] {
+				return a.parts[k] > b.parts[k]
+			}
+		}
+		if len(a.parts) != len(b.parts) {
+			return len(a.parts) > len(b.parts)
+		}
+		return a.tag < b.tag
+	})
+	tags := make([]string, 0, len(versions))
+	for _, v := range versions {
+		tags = append(tags, v.tag)
+	}
+	return tags
+}
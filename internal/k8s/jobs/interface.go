@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for inspecting Jobs and their attempts
+type Service interface {
+	// Attempts returns the pods of a Job, failed retries included, in order of creation
+	Attempts(ctx context.Context, namespace, name string) ([]Attempt, error)
+
+	// Logs writes the logs of every attempt of a Job in order, following running attempts and
+	// new retries with opts.Follow
+	Logs(ctx context.Context, namespace, name string, opts LogOptions) error
+}
+
+// NewJobService creates a new job service instance
+func NewJobService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
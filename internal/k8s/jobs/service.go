@@ -0,0 +1,301 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// pollInterval is how often a followed Job is checked for new attempts
+	pollInterval = 2 * time.Second
+
+	// completionIndexAnnotation holds the completion index of a pod of an indexed job
+	completionIndexAnnotation = "batch.kubernetes.io/job-completion-index"
+
+	// defaultContainerAnnotation names the container kubectl reads by default
+	defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new job service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{clientset: clientset}
+}
+
+// Attempts returns the pods of a Job in order of creation
+func (s *service) Attempts(ctx context.Context, namespace, name string) ([]Attempt, error) {
+	job, err := s.getJob(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	attempts, _, err := s.attempts(ctx, job)
+	return attempts, err
+}
+
+// Logs writes the logs of every attempt of a Job. Finished attempts are written whole; with
+// Follow running attempts are streamed concurrently, and the Job is polled for retries until
+// it completes or fails.
+func (s *service) Logs(ctx context.Context, namespace, name string, opts LogOptions) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	written := make(map[types.UID]bool)
+	for {
+		job, err := s.getJob(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
+		finished := isFinished(job)
+
+		attempts, pods, err := s.attempts(ctx, job)
+		if err != nil {
+			return err
+		}
+		if len(attempts) == 0 && !opts.Follow {
+			return errs.NotFoundf("job %s has no pods", name)
+		}
+
+		for i, attempt := range attempts {
+			pod := pods[i]
+			if written[pod.UID] {
+				continue
+			}
+			follow := false
+			switch pod.Status.Phase {
+			case corev1.PodPending:
+				// Wait for the attempt to start, unless nothing will start it anymore
+				if opts.Follow && !finished {
+					continue
+				}
+			case corev1.PodRunning:
+				follow = opts.Follow
+			}
+			written[pod.UID] = true
+			if opts.OnAttempt != nil {
+				opts.OnAttempt(attempt)
+			}
+			if follow {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					s.writeAttempt(ctx, pod, attempt, opts, true)
+				}()
+				continue
+			}
+			s.writeAttempt(ctx, pod, attempt, opts, false)
+		}
+
+		if !opts.Follow || finished {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// writeAttempt writes the logs of the containers of an attempt, which are streamed
+// concurrently when following
+func (s *service) writeAttempt(ctx context.Context, pod *corev1.Pod, attempt Attempt, opts LogOptions, follow bool) {
+	var wg sync.WaitGroup
+	for _, container := range containers(pod, opts) {
+		if !follow {
+			s.writeContainer(ctx, pod, attempt, container, opts, false)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.writeContainer(ctx, pod, attempt, container, opts, true)
+		}()
+	}
+	wg.Wait()
+}
+
+// writeContainer writes the logs of a container, preceded by the logs of its previous run when
+// it was restarted in place
+func (s *service) writeContainer(ctx context.Context, pod *corev1.Pod, attempt Attempt, container string, opts LogOptions, follow bool) {
+	out := opts.WriterFor(attempt, container)
+	restarts := restartCount(pod, container)
+	if restarts > 0 {
+		if err := s.copyLogs(ctx, pod, container, opts, true, false, out); err != nil && opts.OnError != nil {
+			opts.OnError(attempt, container, err)
+		}
+	}
+	if err := s.copyLogs(ctx, pod, container, opts, false, follow, out); err != nil {
+		if opts.OnError != nil && ctx.Err() == nil {
+			opts.OnError(attempt, container, err)
+		}
+		return
+	}
+
+	// With restartPolicy OnFailure a retry restarts the container in the same pod after a
+	// back-off, which ends the stream; follow each new run until the pod stops running
+	for follow {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+		current, err := s.clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil || current.Status.Phase != corev1.PodRunning {
+			return
+		}
+		if n := restartCount(current, container); n != restarts && containerStarted(current, container) {
+			restarts = n
+			if err := s.copyLogs(ctx, current, container, opts, false, true, out); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// containerStarted reports whether a container of a pod is running or has run, so it has logs
+func containerStarted(pod *corev1.Pod, container string) bool {
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if cs.Name == container {
+			return cs.State.Running != nil || cs.State.Terminated != nil
+		}
+	}
+	return false
+}
+
+// restartCount returns how often a container of a pod restarted
+func restartCount(pod *corev1.Pod, container string) int32 {
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if cs.Name == container {
+			return cs.RestartCount
+		}
+	}
+	return 0
+}
+
+// copyLogs copies the logs of a container to out
+func (s *service) copyLogs(ctx context.Context, pod *corev1.Pod, container string, opts LogOptions, previous, follow bool, out io.Writer) error {
+	stream, err := s.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     follow,
+		Previous:   previous,
+		TailLines:  opts.TailLines,
+		Timestamps: opts.Timestamps,
+	}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get logs: %w", err)
+	}
+	defer stream.Close()
+	if _, err := io.Copy(out, stream); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read logs: %w", err)
+	}
+	return nil
+}
+
+// containers returns the containers of a pod to read
+func containers(pod *corev1.Pod, opts LogOptions) []string {
+	if opts.Container != "" {
+		return []string{opts.Container}
+	}
+	if opts.AllContainers {
+		var names []string
+		for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+	if name := pod.Annotations[defaultContainerAnnotation]; name != "" {
+		return []string{name}
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return []string{pod.Spec.Containers[0].Name}
+	}
+	return nil
+}
+
+func (s *service) getJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	job, err := s.clientset.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, errs.NotFoundf("job %q not found in namespace %q", name, namespace)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return job, nil
+}
+
+// attempts returns the pods controlled by a Job as attempts, oldest first
+func (s *service) attempts(ctx context.Context, job *batchv1.Job) ([]Attempt, []*corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid selector of job %s: %w", job.Name, err)
+	}
+	list, err := s.clientset.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var pods []*corev1.Pod
+	for i := range list.Items {
+		if ref := metav1.GetControllerOf(&list.Items[i]); ref != nil && ref.UID == job.UID {
+			pods = append(pods, &list.Items[i])
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		a, b := pods[i].CreationTimestamp, pods[j].CreationTimestamp
+		if !a.Equal(&b) {
+			return a.Before(&b)
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	// Attempts are counted per completion index; a non-indexed job has a single count
+	counts := make(map[int]int)
+	attempts := make([]Attempt, 0, len(pods))
+	for _, pod := range pods {
+		attempt := Attempt{
+			Pod:     pod.Name,
+			Phase:   string(pod.Status.Phase),
+			Node:    pod.Spec.NodeName,
+			Created: pod.CreationTimestamp.Time,
+		}
+		key := -1
+		if index, err := strconv.Atoi(pod.Annotations[completionIndexAnnotation]); err == nil {
+			attempt.Index = &index
+			key = index
+		}
+		counts[key]++
+		attempt.Number = counts[key]
+		for _, cs := range pod.Status.ContainerStatuses {
+			attempt.Restarts += cs.RestartCount
+		}
+		attempts = append(attempts, attempt)
+	}
+	return attempts, pods, nil
+}
+
+// isFinished reports whether a Job completed or failed
+func isFinished(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
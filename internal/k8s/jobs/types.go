@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"io"
+	"time"
+)
+
+// Attempt is a pod a Job created to run its workload
+type Attempt struct {
+	// Number counts the attempts from 1 in order of creation, per completion index for
+	// indexed jobs
+	Number int `json:"number"`
+
+	// Index is the completion index of an indexed job
+	Index *int `json:"index,omitempty"`
+
+	Pod     string    `json:"pod"`
+	Phase   string    `json:"phase"`
+	Node    string    `json:"node,omitempty"`
+	Created time.Time `json:"created"`
+
+	// Restarts counts container restarts within the pod, which is how restartPolicy OnFailure
+	// retries
+	Restarts int32 `json:"restarts"`
+}
+
+// LogOptions configures the logs of the attempts of a Job
+type LogOptions struct {
+	// Container is the container to read; the default container of each pod when empty
+	Container string
+
+	// AllContainers reads every container of each pod, init containers included
+	AllContainers bool
+
+	// Follow streams running attempts and waits for retries until the Job finishes
+	Follow bool
+
+	TailLines  *int64
+	Timestamps bool
+
+	// WriterFor returns the writer for the logs of a container of an attempt
+	WriterFor func(attempt Attempt, container string) io.Writer
+
+	// OnAttempt, when set, is called before the logs of an attempt are written
+	OnAttempt func(Attempt)
+
+	// OnError, when set, is called for a container whose logs cannot be read, e.g. because it
+	// never started; the other containers and attempts are still read
+	OnError func(attempt Attempt, container string, err error)
+}
@@ -0,0 +1,22 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for configuration linters
+type Service interface {
+	// Probes checks the health probes of workload containers
+	Probes(ctx context.Context, opts Options) ([]Finding, error)
+}
+
+// NewLintService creates a new lint service instance
+func NewLintService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
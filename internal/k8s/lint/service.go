@@ -0,0 +1,239 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// minPeriodSeconds is the shortest probe period not considered aggressive
+	minPeriodSeconds = 5
+	// minStartupSeconds is the least time a container should get before liveness failures restart it
+	minStartupSeconds = 10
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new lint service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// workload is a pod template together with the object that owns it
+type workload struct {
+	namespace string
+	name      string
+	spec      *corev1.PodSpec
+}
+
+// Probes checks the health probes of workload containers
+func (s *service) Probes(ctx context.Context, opts Options) ([]Finding, error) {
+	workloads, err := s.workloads(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, wl := range workloads {
+		for _, c := range wl.spec.Containers {
+			for _, f := range lintContainer(&c) {
+				f.Namespace = wl.namespace
+				f.Workload = wl.name
+				f.Container = c.Name
+				findings = append(findings, f)
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity == Error
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Workload < b.Workload
+	})
+
+	return findings, nil
+}
+
+// workloads lists the pod templates of deployments, statefulsets and daemonsets,
+// and the specs of pods without a controller
+func (s *service) workloads(ctx context.Context, opts Options) ([]workload, error) {
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+	listOpts := metav1.ListOptions{LabelSelector: opts.Selector}
+
+	var workloads []workload
+
+	deployments, err := s.clientset.AppsV1().Deployments(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		workloads = append(workloads, workload{d.Namespace, "Deployment/" + d.Name, &d.Spec.Template.Spec})
+	}
+
+	statefulSets, err := s.clientset.AppsV1().StatefulSets(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for i := range statefulSets.Items {
+		st := &statefulSets.Items[i]
+		workloads = append(workloads, workload{st.Namespace, "StatefulSet/" + st.Name, &st.Spec.Template.Spec})
+	}
+
+	daemonSets, err := s.clientset.AppsV1().DaemonSets(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for i := range daemonSets.Items {
+		ds := &daemonSets.Items[i]
+		workloads = append(workloads, workload{ds.Namespace, "DaemonSet/" + ds.Name, &ds.Spec.Template.Spec})
+	}
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if metav1.GetControllerOf(p) != nil {
+			continue // Linted through the owning workload
+		}
+		workloads = append(workloads, workload{p.Namespace, "Pod/" + p.Name, &p.Spec})
+	}
+
+	return workloads, nil
+}
+
+// lintContainer returns the probe findings of a single container
+func lintContainer(c *corev1.Container) []Finding {
+	var findings []Finding
+	add := func(probe string, rule Rule, severity Severity, format string, args ...interface{}) {
+		findings = append(findings, Finding{
+			Probe:    probe,
+			Rule:     rule,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	if c.LivenessProbe == nil {
+		add("", MissingLiveness, Warning, "no liveness probe; a hung process is never restarted")
+	}
+	// Containers that expose no ports are usually workers that never receive traffic
+	if c.ReadinessProbe == nil && len(c.Ports) > 0 {
+		add("", MissingReadiness, Warning, "no readiness probe; traffic is sent before the container is ready")
+	}
+
+	if c.LivenessProbe != nil && c.ReadinessProbe != nil &&
+		reflect.DeepEqual(c.LivenessProbe.ProbeHandler, c.ReadinessProbe.ProbeHandler) &&
+		c.LivenessProbe.FailureThreshold <= c.ReadinessProbe.FailureThreshold {
+		add("liveness", IdenticalProbes, Warning, "liveness and readiness probes check the same endpoint; an overloaded container is restarted instead of taken out of rotation")
+	}
+
+	probes := []struct {
+		name  string
+		probe *corev1.Probe
+	}{
+		{"liveness", c.LivenessProbe},
+		{"readiness", c.ReadinessProbe},
+		{"startup", c.StartupProbe},
+	}
+	for _, p := range probes {
+		if p.probe == nil {
+			continue
+		}
+
+		if p.probe.PeriodSeconds > 0 && p.probe.PeriodSeconds < minPeriodSeconds {
+			add(p.name, AggressiveProbe, Warning, "probing every %ds adds load and amplifies transient failures", p.probe.PeriodSeconds)
+		}
+		if p.name == "liveness" && p.probe.FailureThreshold == 1 {
+			add(p.name, AggressiveProbe, Warning, "a single failed check restarts the container")
+		}
+		if p.name == "liveness" && c.StartupProbe == nil {
+			if budget := startupBudget(p.probe); budget < minStartupSeconds {
+				add(p.name, AggressiveProbe, Warning, "the container is restarted unless healthy within %ds of starting; add a startup probe or initialDelaySeconds", budget)
+			}
+		}
+
+		if port, ok := probePort(p.probe); ok {
+			if message := checkPort(c, port); message != "" {
+				severity := Warning
+				if port.Type == intstr.String {
+					severity = Error
+				}
+				add(p.name, UnknownPort, severity, "%s", message)
+			}
+		}
+	}
+
+	return findings
+}
+
+// startupBudget returns the seconds a container has before failed probes count against it,
+// applying the API defaults for unset fields
+func startupBudget(p *corev1.Probe) int32 {
+	period := p.PeriodSeconds
+	if period == 0 {
+		period = 10
+	}
+	failures := p.FailureThreshold
+	if failures == 0 {
+		failures = 3
+	}
+	return p.InitialDelaySeconds + period*failures
+}
+
+// probePort returns the port a network probe targets
+func probePort(p *corev1.Probe) (intstr.IntOrString, bool) {
+	switch {
+	case p.HTTPGet != nil:
+		return p.HTTPGet.Port, true
+	case p.TCPSocket != nil:
+		return p.TCPSocket.Port, true
+	case p.GRPC != nil:
+		return intstr.FromInt32(p.GRPC.Port), true
+	}
+	return intstr.IntOrString{}, false
+}
+
+// checkPort describes why a probe port does not match the container, or returns an empty string
+func checkPort(c *corev1.Container, port intstr.IntOrString) string {
+	if port.Type == intstr.String {
+		for _, p := range c.Ports {
+			if p.Name == port.StrVal {
+				return ""
+			}
+		}
+		return fmt.Sprintf("probe uses named port %q which the container does not define", port.StrVal)
+	}
+
+	// Declared ports are informational, so only containers that list ports can be checked
+	if len(c.Ports) == 0 {
+		return ""
+	}
+	for _, p := range c.Ports {
+		if p.ContainerPort == port.IntVal {
+			return ""
+		}
+	}
+	return fmt.Sprintf("probe targets port %d which is not among the container ports", port.IntVal)
+}
@@ -0,0 +1,50 @@
+package lint
+
+// Severity ranks how serious a finding is
+type Severity string
+
+const (
+	// Warning flags configuration that is risky but may be intended
+	Warning Severity = "warning"
+	// Error flags configuration that is almost certainly broken
+	Error Severity = "error"
+)
+
+// Rule names the check that produced a finding
+type Rule string
+
+const (
+	// MissingLiveness flags containers without a liveness probe
+	MissingLiveness Rule = "missing-liveness"
+	// MissingReadiness flags serving containers without a readiness probe
+	MissingReadiness Rule = "missing-readiness"
+	// IdenticalProbes flags liveness and readiness probes that check the same thing
+	IdenticalProbes Rule = "identical-probes"
+	// AggressiveProbe flags thresholds likely to restart or unready healthy containers
+	AggressiveProbe Rule = "aggressive-probe"
+	// UnknownPort flags probes pointing at ports the container does not expose
+	UnknownPort Rule = "unknown-port"
+)
+
+// Options configures which workloads are linted
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+	Selector      string
+}
+
+// Finding is a single lint result for a container
+type Finding struct {
+	Namespace string `json:"namespace"`
+
+	// Workload is the kind/name of the linted object
+	Workload  string `json:"workload"`
+	Container string `json:"container"`
+
+	// Probe is liveness, readiness or startup; empty for container level findings
+	Probe string `json:"probe,omitempty"`
+
+	Rule     Rule     `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 
+	"k8stool/internal/k8s/errs"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -45,14 +47,14 @@ func (s *service) GetLogs(ctx context.Context, namespace, pod string, opts *LogO
 	// If container is specified, verify it exists in the pod
 	if opts.Container != "" {
 		containerExists := false
-		for _, container := range podObj.Spec.Containers {
+		for _, container := range append(podObj.Spec.InitContainers, podObj.Spec.Containers...) {
 			if container.Name == opts.Container {
 				containerExists = true
 				break
 			}
 		}
 		if !containerExists {
-			return nil, fmt.Errorf("container %s not found in pod %s", opts.Container, pod)
+			return nil, errs.NotFoundf("container %s not found in pod %s", opts.Container, pod)
 		}
 	}
 
@@ -64,7 +66,7 @@ func (s *service) GetLogs(ctx context.Context, namespace, pod string, opts *LogO
 		}
 
 		var containerStatus *corev1.ContainerStatus
-		for _, status := range podObj.Status.ContainerStatuses {
+		for _, status := range append(podObj.Status.InitContainerStatuses, podObj.Status.ContainerStatuses...) {
 			if status.Name == containerName {
 				containerStatus = &status
 				break
@@ -72,12 +74,12 @@ func (s *service) GetLogs(ctx context.Context, namespace, pod string, opts *LogO
 		}
 
 		if containerStatus == nil {
-			return nil, fmt.Errorf("container status not found for %s", containerName)
+			return nil, errs.NotFoundf("container status not found for %s", containerName)
 		}
 
 		// Check if the container has any terminated states
 		if containerStatus.LastTerminationState.Terminated == nil && containerStatus.State.Terminated == nil {
-			return nil, fmt.Errorf("no previous terminated state found for container %s", containerName)
+			return nil, errs.NotFoundf("no previous terminated state found for container %s", containerName)
 		}
 	}
 
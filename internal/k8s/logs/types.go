@@ -35,8 +35,16 @@ type LogOptions struct {
 	// Writer specifies where to write the logs
 	Writer io.Writer `json:"-"`
 
-	// AllContainers indicates whether to get logs from all containers in the pod
+	// WriterFor, when set, returns the writer for the logs of one container and overrides
+	// Writer. It lets output combined from several pods tell their lines apart.
+	WriterFor func(pod, container string) io.Writer `json:"-"`
+
+	// AllContainers indicates whether to get logs from all containers in the pod,
+	// init containers included
 	AllContainers bool `json:"allContainers,omitempty"`
+
+	// InitContainers indicates whether to get logs from the init containers of the pod
+	InitContainers bool `json:"initContainers,omitempty"`
 }
 
 // LogResult contains the result of a log request
@@ -67,7 +67,7 @@ func (s *service) ListPodMetrics(namespace string) ([]PodMetrics, error) {
 
 	var metrics []PodMetrics
 	for _, podMetrics := range podMetricsList.Items {
-		pod, err := s.clientset.CoreV1().Pods(namespace).Get(context.Background(), podMetrics.Name, metav1.GetOptions{})
+		pod, err := s.clientset.CoreV1().Pods(podMetrics.Namespace).Get(context.Background(), podMetrics.Name, metav1.GetOptions{})
 		if err != nil {
 			continue // Skip pods that can't be found
 		}
@@ -0,0 +1,134 @@
+package namespace
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// TemplateDir returns the directory named templates are looked up in, under the user
+// configuration directory
+func TemplateDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the configuration directory: %w", err)
+	}
+	return filepath.Join(dir, "k8stool", "templates"), nil
+}
+
+// ResolveTemplate returns the directory of a template given by name, or by path when it
+// contains a path separator
+func ResolveTemplate(template string) (string, error) {
+	dir := template
+	if !strings.ContainsRune(template, '/') && !strings.ContainsRune(template, filepath.Separator) {
+		templates, err := TemplateDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(templates, template)
+	}
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return "", errs.NotFoundf("template %q not found: %s does not exist", template, dir)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read template %q: %w", template, err)
+	}
+	if !info.IsDir() {
+		return "", errs.Validationf("invalid template %q: %s is not a directory", template, dir)
+	}
+	return dir, nil
+}
+
+// Render renders the .yaml and .yml files of a template directory, in the order of their
+// names, as Go templates. The namespace comes first: the Namespace object of the template when
+// it has one, or a new one, with data.Labels added. Namespaced objects are put in the
+// namespace and may not name another one.
+func Render(dir string, data TemplateData) ([]*unstructured.Unstructured, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, entry.Name())
+		}
+	}
+	if len(files) == 0 {
+		return nil, errs.Validationf("invalid template %s: no .yaml or .yml files", dir)
+	}
+	sort.Strings(files)
+
+	var ns *unstructured.Unstructured
+	var objs []*unstructured.Unstructured
+	for _, file := range files {
+		rendered, err := renderFile(filepath.Join(dir, file), data)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range rendered {
+			switch {
+			case obj.GetKind() == "Namespace" && obj.GroupVersionKind().Group == "":
+				if obj.GetName() != data.Name || ns != nil {
+					return nil, errs.Validationf("invalid template %s: %s has a Namespace other than %s", dir, file, data.Name)
+				}
+				ns = obj
+			case obj.GetNamespace() != "" && obj.GetNamespace() != data.Name:
+				return nil, errs.Validationf("invalid template %s: %s puts %s/%s in namespace %s", dir, file, obj.GetKind(), obj.GetName(), obj.GetNamespace())
+			default:
+				objs = append(objs, obj)
+			}
+		}
+	}
+
+	if ns == nil {
+		ns = &unstructured.Unstructured{}
+		ns.SetAPIVersion("v1")
+		ns.SetKind("Namespace")
+		ns.SetName(data.Name)
+	}
+	if len(data.Labels) > 0 {
+		labels := ns.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		for key, value := range data.Labels {
+			labels[key] = value
+		}
+		ns.SetLabels(labels)
+	}
+	return append([]*unstructured.Unstructured{ns}, objs...), nil
+}
+
+// renderFile renders one file of a template. A value the template uses but was not given is
+// an error, so a typo does not create objects with empty fields.
+func renderFile(path string, data TemplateData) ([]*unstructured.Unstructured, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, errs.Validationf("invalid template %s: %v", path, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, errs.Validationf("failed to render %s: %v", path, err)
+	}
+	objs, err := resources.Decode(buf.Bytes())
+	if err != nil {
+		return nil, errs.Validationf("failed to render %s: %v", path, err)
+	}
+	return objs, nil
+}
@@ -54,3 +54,13 @@ const (
 	// SortByStatus sorts namespaces by status
 	SortByStatus NamespaceSortOption = "status"
 )
+
+// TemplateData is what the manifests of a namespace template are rendered with
+type TemplateData struct {
+	// Name is the name of the namespace
+	Name string
+	// Labels are the labels of the namespace
+	Labels map[string]string
+	// Values are the parameters given for the template
+	Values map[string]string
+}
@@ -0,0 +1,209 @@
+package netcheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+	ex "k8stool/internal/k8s/exec"
+	"k8stool/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	// dnsNamespace and dnsService locate the cluster DNS; CoreDNS keeps the kube-dns name
+	dnsNamespace = "kube-system"
+	dnsService   = "kube-dns"
+
+	// testPodLifetime bounds how long the test pod runs if it is not cleaned up
+	testPodLifetime = "300"
+)
+
+var (
+	digStatus    = regexp.MustCompile(`status: ([A-Z]+)`)
+	digQueryTime = regexp.MustCompile(`Query time: (\d+) msec`)
+)
+
+// DNS resolves a set of names against every cluster DNS backend from a test pod
+func (s *service) DNS(ctx context.Context, opts DNSOptions) (*DNSReport, error) {
+	if len(opts.Names) == 0 {
+		opts.Names = DefaultDNSNames
+	}
+	if opts.Image == "" {
+		opts.Image = DefaultImage
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Second
+	}
+
+	backends, err := s.dnsBackends(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := s.startTestPod(ctx, opts.Namespace, opts.Image)
+	if pod != nil {
+		defer s.deleteTestPod(pod)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DNSReport{Backends: backends}
+	for _, backend := range backends {
+		for _, name := range opts.Names {
+			report.Results = append(report.Results, s.query(ctx, pod, backend, name, opts.Timeout))
+		}
+	}
+	return report, nil
+}
+
+// dnsBackends returns the cluster DNS service IP followed by every CoreDNS pod
+func (s *service) dnsBackends(ctx context.Context) ([]DNSBackend, error) {
+	svc, err := s.clientset.CoreV1().Services(dnsNamespace).Get(ctx, dnsService, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster DNS service: %w", err)
+	}
+	backends := []DNSBackend{{Name: "service/" + dnsService, Address: svc.Spec.ClusterIP}}
+
+	endpoints, err := s.clientset.CoreV1().Endpoints(dnsNamespace).Get(ctx, dnsService, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster DNS endpoints: %w", err)
+	}
+	seen := make(map[string]bool)
+	for _, subset := range endpoints.Subsets {
+		for _, addr := range subset.Addresses {
+			if seen[addr.IP] {
+				continue // Listed once per port
+			}
+			seen[addr.IP] = true
+			name := addr.IP
+			if addr.TargetRef != nil {
+				name = addr.TargetRef.Name
+			}
+			backends = append(backends, DNSBackend{Name: name, Address: addr.IP})
+		}
+	}
+	return backends, nil
+}
+
+// startTestPod creates a pod that sleeps until it is deleted and waits for it to run
+func (s *service) startTestPod(ctx context.Context, namespace, image string) (*corev1.Pod, error) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "k8stool-dnscheck-" + utilrand.String(5),
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "k8stool"},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:            "dnscheck",
+				Image:           image,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command:         []string{"sleep", testPodLifetime},
+			}},
+		},
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create test pod: %w", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	for {
+		current, err := s.clientset.CoreV1().Pods(namespace).Get(waitCtx, pod.Name, metav1.GetOptions{})
+		if err == nil {
+			switch current.Status.Phase {
+			case corev1.PodRunning:
+				return current, nil
+			case corev1.PodFailed, corev1.PodSucceeded:
+				return current, fmt.Errorf("test pod exited: %s", current.Status.Phase)
+			}
+		}
+		select {
+		case <-waitCtx.Done():
+			return pod, errs.Timeoutf("timed out waiting for test pod %s to start", pod.Name)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// deleteTestPod removes the test pod, even when the caller's context was cancelled
+func (s *service) deleteTestPod(pod *corev1.Pod) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	grace := int64(0)
+	s.clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &grace})
+}
+
+// query resolves a name against one backend with dig
+func (s *service) query(ctx context.Context, pod *corev1.Pod, backend DNSBackend, name string, timeout time.Duration) DNSResult {
+	result := DNSResult{Backend: backend.Name, Name: name}
+
+	seconds := strconv.Itoa(max(1, int(timeout.Seconds())))
+	var out bytes.Buffer
+	execResult, err := s.execService.Exec(ctx, pod.Namespace, pod.Name, &ex.ExecOptions{
+		// +search applies the pod's search domains, as an application resolving the name would
+		Command: []string{"dig", "@" + backend.Address, name, "+search", "+tries=1", "+time=" + seconds},
+		Streams: &ex.IOStreams{Out: &out, ErrOut: &out},
+	})
+	if err != nil {
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		return result
+	}
+
+	output := out.String()
+	if match := digStatus.FindStringSubmatch(output); match != nil {
+		result.Status = match[1]
+	}
+	if match := digQueryTime.FindStringSubmatch(output); match != nil {
+		ms, _ := strconv.Atoi(match[1])
+		result.Latency = time.Duration(ms) * time.Millisecond
+	}
+	result.Answer = firstAnswer(output)
+
+	switch {
+	case strings.Contains(output, "timed out") || strings.Contains(output, "no servers could be reached"):
+		result.Status = "TIMEOUT"
+	case execResult.ExitCode != 0 && result.Status == "":
+		result.Status = "ERROR"
+		result.Error = utils.LastLine(output)
+		if result.Error == "" {
+			result.Error = execResult.Error
+		}
+	}
+	return result
+}
+
+// firstAnswer returns the first A or AAAA record in the answer section of dig output
+func firstAnswer(output string) string {
+	inAnswer := false
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, ";; ANSWER SECTION") {
+			inAnswer = true
+			continue
+		}
+		if !inAnswer {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			return ""
+		}
+		if len(fields) >= 5 && (fields[3] == "A" || fields[3] == "AAAA") {
+			return fields[4]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package netcheck
+
+import (
+	"context"
+	"fmt"
+
+	ex "k8stool/internal/k8s/exec"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for in-cluster network checks
+type Service interface {
+	// Connectivity checks the path from a pod to a service, pod or host
+	Connectivity(ctx context.Context, opts ConnectivityOptions) (*ConnectivityReport, error)
+
+	// DNS resolves a set of names against every cluster DNS backend from a test pod
+	DNS(ctx context.Context, opts DNSOptions) (*DNSReport, error)
+}
+
+// NewNetCheckService creates a new network check service instance
+func NewNetCheckService(clientset *kubernetes.Clientset, execService ex.ExecService) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	if execService == nil {
+		return nil, fmt.Errorf("exec service is required")
+	}
+	return newService(clientset, execService), nil
+}
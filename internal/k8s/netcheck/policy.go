@@ -0,0 +1,218 @@
+package netcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// endpoint is one side of a connection as seen by network policies
+type endpoint struct {
+	pod             *corev1.Pod
+	namespaceLabels labels.Set
+	ip              string
+}
+
+// policyStep evaluates the egress policies of the source and the ingress policies of the target
+func (s *service) policyStep(ctx context.Context, source *corev1.Pod, dst *target) Step {
+	src := endpoint{pod: source, namespaceLabels: s.namespaceLabels(ctx, source.Namespace), ip: source.Status.PodIP}
+
+	var to *endpoint
+	if dst.pod != nil {
+		to = &endpoint{pod: dst.pod, namespaceLabels: s.namespaceLabels(ctx, dst.pod.Namespace), ip: dst.pod.Status.PodIP}
+	}
+
+	var notes []string
+
+	egress, err := s.policies(ctx, source.Namespace, source, networkingv1.PolicyTypeEgress)
+	if err != nil {
+		return Step{Name: "network-policy", Status: Skip, Message: err.Error()}
+	}
+	if len(egress) > 0 {
+		if to == nil {
+			notes = append(notes, fmt.Sprintf("egress of the source is restricted by %s; the target is outside the cluster view", policyNames(egress)))
+		} else if allowedBy := allowingPolicies(egress, to, dst.pod, dst.podPort, true); len(allowedBy) == 0 {
+			return Step{Name: "network-policy", Status: Fail,
+				Message: fmt.Sprintf("egress from %s is denied; no rule in %s allows it", source.Name, policyNames(egress))}
+		}
+	}
+
+	if to != nil {
+		ingress, err := s.policies(ctx, to.pod.Namespace, to.pod, networkingv1.PolicyTypeIngress)
+		if err != nil {
+			return Step{Name: "network-policy", Status: Skip, Message: err.Error()}
+		}
+		if len(ingress) > 0 {
+			if allowedBy := allowingPolicies(ingress, &src, dst.pod, dst.podPort, false); len(allowedBy) == 0 {
+				return Step{Name: "network-policy", Status: Fail,
+					Message: fmt.Sprintf("ingress to %s is denied; no rule in %s allows it", to.pod.Name, policyNames(ingress))}
+			}
+			notes = append(notes, fmt.Sprintf("ingress allowed by %s", policyNames(ingress)))
+		}
+	}
+
+	if len(notes) == 0 {
+		return Step{Name: "network-policy", Status: Pass, Message: "no network policy restricts this path"}
+	}
+	if to == nil {
+		return Step{Name: "network-policy", Status: Warn, Message: strings.Join(notes, "; ")}
+	}
+	return Step{Name: "network-policy", Status: Pass, Message: strings.Join(notes, "; ")}
+}
+
+// namespaceLabels returns the labels of a namespace, falling back to the name label when it cannot be read
+func (s *service) namespaceLabels(ctx context.Context, name string) labels.Set {
+	ns, err := s.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return labels.Set{corev1.LabelMetadataName: name}
+	}
+	return labels.Set(ns.Labels)
+}
+
+// policies returns the network policies of the given type that select the pod
+func (s *service) policies(ctx context.Context, namespace string, pod *corev1.Pod, policyType networkingv1.PolicyType) ([]networkingv1.NetworkPolicy, error) {
+	list, err := s.clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network policies: %w", err)
+	}
+
+	var selected []networkingv1.NetworkPolicy
+	for _, policy := range list.Items {
+		if !hasPolicyType(&policy, policyType) {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		selected = append(selected, policy)
+	}
+	return selected, nil
+}
+
+// hasPolicyType applies the API defaults: Ingress always, Egress only when egress rules exist
+func hasPolicyType(policy *networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return policyType == networkingv1.PolicyTypeIngress || len(policy.Spec.Egress) > 0
+	}
+	for _, t := range policy.Spec.PolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// allowingPolicies returns the names of policies with a rule admitting the peer; ports are
+// always those of the target pod
+func allowingPolicies(policies []networkingv1.NetworkPolicy, peer *endpoint, target *corev1.Pod, port int32, egress bool) []string {
+	var names []string
+	for _, policy := range policies {
+		if egress {
+			for _, rule := range policy.Spec.Egress {
+				if peersMatch(rule.To, policy.Namespace, peer) && portsMatch(rule.Ports, target, port) {
+					names = append(names, policy.Name)
+					break
+				}
+			}
+			continue
+		}
+		for _, rule := range policy.Spec.Ingress {
+			if peersMatch(rule.From, policy.Namespace, peer) && portsMatch(rule.Ports, target, port) {
+				names = append(names, policy.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// peersMatch reports whether any peer selects the endpoint; no peers means all
+func peersMatch(peers []networkingv1.NetworkPolicyPeer, policyNamespace string, e *endpoint) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			if ipBlockContains(peer.IPBlock, e.ip) {
+				return true
+			}
+			continue
+		}
+
+		if peer.NamespaceSelector == nil {
+			if e.pod.Namespace != policyNamespace {
+				continue
+			}
+		} else {
+			selector, err := metav1.LabelSelectorAsSelector(peer.NamespaceSelector)
+			if err != nil || !selector.Matches(e.namespaceLabels) {
+				continue
+			}
+		}
+
+		if peer.PodSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(peer.PodSelector)
+			if err != nil || !selector.Matches(labels.Set(e.pod.Labels)) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func ipBlockContains(block *networkingv1.IPBlock, ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	_, cidr, err := net.ParseCIDR(block.CIDR)
+	if err != nil || !cidr.Contains(addr) {
+		return false
+	}
+	for _, except := range block.Except {
+		if _, excluded, err := net.ParseCIDR(except); err == nil && excluded.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// portsMatch reports whether any port entry admits the TCP port of the target pod
+func portsMatch(ports []networkingv1.NetworkPolicyPort, target *corev1.Pod, port int32) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		if p.Protocol != nil && *p.Protocol != corev1.ProtocolTCP {
+			continue
+		}
+		// An unknown target port cannot be ruled out
+		if p.Port == nil || port == 0 {
+			return true
+		}
+		resolved, ok := resolvePort(target, *p.Port)
+		if !ok {
+			continue
+		}
+		if resolved == port || (p.EndPort != nil && port >= resolved && port <= *p.EndPort) {
+			return true
+		}
+	}
+	return false
+}
+
+func policyNames(policies []networkingv1.NetworkPolicy) string {
+	names := make([]string, len(policies))
+	for i, p := range policies {
+		names[i] = p.Name
+	}
+	return strings.Join(names, ", ")
+}
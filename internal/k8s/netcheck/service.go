@@ -0,0 +1,367 @@
+package netcheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+	ex "k8stool/internal/k8s/exec"
+	"k8stool/internal/k8s/pods"
+	"k8stool/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// resolveScript resolves $0 with whichever resolver the image ships
+	resolveScript = `if command -v getent >/dev/null 2>&1; then getent hosts "$0"; elif command -v nslookup >/dev/null 2>&1; then nslookup "$0"; else exit 127; fi`
+
+	// connectScript opens a TCP connection to $0:$1 with a timeout of $2 seconds
+	connectScript = `if command -v nc >/dev/null 2>&1; then nc -z -w "$2" "$0" "$1"; elif command -v bash >/dev/null 2>&1; then timeout "$2" bash -c 'echo > /dev/tcp/$0/$1' "$0" "$1"; else exit 127; fi`
+
+	// exitCommandNotFound is the shell exit code for a missing command
+	exitCommandNotFound = 127
+
+	// debugContainerLifetime bounds how long an ephemeral debug container keeps running
+	debugContainerLifetime = "600"
+)
+
+type service struct {
+	clientset   *kubernetes.Clientset
+	execService ex.ExecService
+}
+
+// newService creates a new network check service instance
+func newService(clientset *kubernetes.Clientset, execService ex.ExecService) Service {
+	return &service{
+		clientset:   clientset,
+		execService: execService,
+	}
+}
+
+// target is the resolved destination of a connectivity test
+type target struct {
+	// host is the name or address connected to from the source pod
+	host string
+	port int32
+
+	// pod is a backend pod, used to evaluate ingress network policies
+	pod *corev1.Pod
+
+	// podPort is the port on the backend pod that the connection lands on
+	podPort int32
+}
+
+// Connectivity checks the path from a pod to a service, pod or host
+func (s *service) Connectivity(ctx context.Context, opts ConnectivityOptions) (*ConnectivityReport, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.Image == "" {
+		opts.Image = DefaultImage
+	}
+
+	source, err := s.clientset.CoreV1().Pods(opts.Namespace).Get(ctx, strings.TrimPrefix(opts.From, "pod/"), metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source pod: %w", err)
+	}
+	if source.Status.Phase != corev1.PodRunning {
+		return nil, fmt.Errorf("source pod %s is %s, not Running", source.Name, source.Status.Phase)
+	}
+
+	report := &ConnectivityReport{
+		Source: source.Namespace + "/" + source.Name,
+		Target: opts.To,
+	}
+
+	kind, name, port, err := parseTarget(opts.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var dst *target
+	switch kind {
+	case "svc":
+		dst, err = s.serviceTarget(ctx, report, source.Namespace, name, port)
+	case "pod":
+		dst, err = s.podTarget(ctx, report, source.Namespace, name, port)
+	default:
+		dst = &target{host: name, port: port}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if dst != nil {
+		report.Steps = append(report.Steps, s.policyStep(ctx, source, dst))
+
+		container := opts.Container
+		if opts.Ephemeral {
+			if container, err = s.attachDebugContainer(ctx, source, opts.Image); err != nil {
+				return nil, err
+			}
+		}
+
+		timeout := strconv.Itoa(max(1, int(opts.Timeout.Seconds())))
+		if kind != "pod" {
+			report.Steps = append(report.Steps, s.execStep(ctx, source, container, "dns", opts.Timeout,
+				fmt.Sprintf("%s resolves", dst.host), resolveScript, dst.host))
+		}
+		report.Steps = append(report.Steps, s.execStep(ctx, source, container, "tcp", 2*opts.Timeout,
+			fmt.Sprintf("connected to %s:%d", dst.host, dst.port), connectScript, dst.host, strconv.Itoa(int(dst.port)), timeout))
+	}
+
+	for _, step := range report.Steps {
+		if step.Status == Fail {
+			report.BrokenAt = step.Name
+			break
+		}
+	}
+
+	return report, nil
+}
+
+// parseTarget splits KIND/NAME:PORT or HOST:PORT
+func parseTarget(to string) (kind, name string, port int32, err error) {
+	kind = "host"
+	rest := to
+	if prefix, value, ok := strings.Cut(to, "/"); ok {
+		switch prefix {
+		case "svc", "service":
+			kind = "svc"
+		case "pod", "po":
+			kind = "pod"
+		default:
+			return "", "", 0, errs.Validationf("unsupported target type %q, use svc/NAME:PORT, pod/NAME:PORT or HOST:PORT", prefix)
+		}
+		rest = value
+	}
+
+	host, portStr, err := net.SplitHostPort(rest)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid target %q: %w", to, err)
+	}
+	p, err := strconv.ParseInt(portStr, 10, 32)
+	if err != nil || p <= 0 || p > 65535 {
+		return "", "", 0, errs.Validationf("invalid port %q", portStr)
+	}
+	return kind, host, int32(p), nil
+}
+
+// serviceTarget checks the service and its endpoints, returning nil when the path already breaks
+func (s *service) serviceTarget(ctx context.Context, report *ConnectivityReport, namespace, name string, port int32) (*target, error) {
+	if svcName, svcNamespace, ok := strings.Cut(name, "."); ok {
+		name, namespace = svcName, svcNamespace
+	}
+
+	svc, err := s.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		report.Steps = append(report.Steps, Step{Name: "service", Status: Fail, Message: err.Error()})
+		return nil, nil
+	}
+
+	var svcPort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Port == port {
+			svcPort = &svc.Spec.Ports[i]
+		}
+	}
+	if svcPort == nil {
+		report.Steps = append(report.Steps, Step{Name: "service", Status: Fail,
+			Message: fmt.Sprintf("service %s/%s does not expose port %d", namespace, name, port)})
+		return nil, nil
+	}
+	report.Steps = append(report.Steps, Step{Name: "service", Status: Pass,
+		Message: fmt.Sprintf("service %s/%s exposes %d -> %s", namespace, name, port, svcPort.TargetPort.String())})
+
+	dst := &target{host: fmt.Sprintf("%s.%s.svc", name, namespace), port: port}
+
+	endpoints, err := s.clientset.CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		report.Steps = append(report.Steps, Step{Name: "endpoints", Status: Fail, Message: err.Error()})
+		return nil, nil
+	}
+
+	var ready, notReady int
+	var backend *corev1.EndpointAddress
+	for _, subset := range endpoints.Subsets {
+		if !subsetServesPort(subset, svcPort) {
+			continue
+		}
+		for i := range subset.Addresses {
+			ready++
+			if backend == nil {
+				backend = &subset.Addresses[i]
+				for _, p := range subset.Ports {
+					if p.Name == svcPort.Name {
+						dst.podPort = p.Port
+					}
+				}
+			}
+		}
+		notReady += len(subset.NotReadyAddresses)
+	}
+
+	switch {
+	case ready == 0 && notReady > 0:
+		report.Steps = append(report.Steps, Step{Name: "endpoints", Status: Fail,
+			Message: fmt.Sprintf("no ready endpoints, %d not ready; check the readiness of the backend pods", notReady)})
+		return nil, nil
+	case ready == 0:
+		message := "no endpoints"
+		if len(svc.Spec.Selector) > 0 {
+			message = "no endpoints; the selector matches no pods"
+		}
+		report.Steps = append(report.Steps, Step{Name: "endpoints", Status: Fail, Message: message})
+		return nil, nil
+	case notReady > 0:
+		report.Steps = append(report.Steps, Step{Name: "endpoints", Status: Warn,
+			Message: fmt.Sprintf("%d ready, %d not ready", ready, notReady)})
+	default:
+		report.Steps = append(report.Steps, Step{Name: "endpoints", Status: Pass,
+			Message: fmt.Sprintf("%d ready", ready)})
+	}
+
+	if backend.TargetRef != nil && backend.TargetRef.Kind == "Pod" {
+		pod, err := s.clientset.CoreV1().Pods(backend.TargetRef.Namespace).Get(ctx, backend.TargetRef.Name, metav1.GetOptions{})
+		if err == nil {
+			dst.pod = pod
+		}
+	}
+	return dst, nil
+}
+
+func subsetServesPort(subset corev1.EndpointSubset, svcPort *corev1.ServicePort) bool {
+	for _, p := range subset.Ports {
+		if p.Name == svcPort.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// podTarget checks the target pod, returning nil when the path already breaks
+func (s *service) podTarget(ctx context.Context, report *ConnectivityReport, namespace, name string, port int32) (*target, error) {
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		report.Steps = append(report.Steps, Step{Name: "pod", Status: Fail, Message: err.Error()})
+		return nil, nil
+	}
+	if pod.Status.PodIP == "" {
+		report.Steps = append(report.Steps, Step{Name: "pod", Status: Fail,
+			Message: fmt.Sprintf("pod %s has no IP (%s)", name, pod.Status.Phase)})
+		return nil, nil
+	}
+
+	status, message := Pass, fmt.Sprintf("pod %s has IP %s", name, pod.Status.PodIP)
+	if !pods.IsReady(pod) {
+		status, message = Warn, message+" but is not ready"
+	}
+	report.Steps = append(report.Steps, Step{Name: "pod", Status: status, Message: message})
+
+	return &target{host: pod.Status.PodIP, port: port, pod: pod, podPort: port}, nil
+}
+
+// execStep runs a check script in the source pod and turns its exit code into a step
+func (s *service) execStep(ctx context.Context, pod *corev1.Pod, container, name string, timeout time.Duration, success, script string, args ...string) Step {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var out bytes.Buffer
+	result, err := s.execService.Exec(ctx, pod.Namespace, pod.Name, &ex.ExecOptions{
+		Command:   append([]string{"sh", "-c", script}, args...),
+		Container: container,
+		Streams:   &ex.IOStreams{Out: &out, ErrOut: &out},
+	})
+	if err != nil {
+		return Step{Name: name, Status: Skip, Message: err.Error()}
+	}
+
+	output := strings.TrimSpace(out.String())
+	switch {
+	case result.ExitCode == 0:
+		if name == "dns" && output != "" {
+			success += ": " + strings.Fields(output)[0]
+		}
+		return Step{Name: name, Status: Pass, Message: success}
+	case result.ExitCode == exitCommandNotFound:
+		return Step{Name: name, Status: Skip, Message: "no suitable tool in the container; retry with --ephemeral"}
+	case ctx.Err() != nil:
+		return Step{Name: name, Status: Fail, Message: "timed out"}
+	case result.ExitCode < 0:
+		// The exec itself failed, for example because the image has no shell
+		return Step{Name: name, Status: Skip, Message: fmt.Sprintf("%s; retry with --ephemeral", result.Error)}
+	default:
+		if output == "" {
+			output = result.Error
+		}
+		return Step{Name: name, Status: Fail, Message: utils.LastLine(output)}
+	}
+}
+
+// attachDebugContainer adds an ephemeral debug container to the pod and waits for it to run
+func (s *service) attachDebugContainer(ctx context.Context, pod *corev1.Pod, image string) (string, error) {
+	name := "k8stool-debug-" + utilrand.String(5)
+	pod = pod.DeepCopy()
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:            name,
+			Image:           image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"sleep", debugContainerLifetime},
+		},
+	})
+
+	if _, err := s.clientset.CoreV1().Pods(pod.Namespace).UpdateEphemeralContainers(ctx, pod.Name, pod, metav1.UpdateOptions{}); err != nil {
+		return "", fmt.Errorf("failed to add ephemeral container: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	for {
+		current, err := s.clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get pod: %w", err)
+		}
+		for _, cs := range current.Status.EphemeralContainerStatuses {
+			if cs.Name != name {
+				continue
+			}
+			if cs.State.Running != nil {
+				return name, nil
+			}
+			if cs.State.Terminated != nil {
+				return "", fmt.Errorf("ephemeral container terminated: %s", cs.State.Terminated.Reason)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return "", errs.Timeoutf("timed out waiting for ephemeral container to start")
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// resolvePort returns the numeric container port a named or numeric port refers to
+func resolvePort(pod *corev1.Pod, port intstr.IntOrString) (int32, bool) {
+	if port.Type == intstr.Int {
+		return port.IntVal, true
+	}
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.Name == port.StrVal {
+				return p.ContainerPort, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,115 @@
+package netcheck
+
+import (
+	"time"
+)
+
+// DefaultImage is the debug image used for ephemeral containers
+const DefaultImage = "nicolaka/netshoot"
+
+// StepStatus is the outcome of a single check along the path
+type StepStatus string
+
+const (
+	// Pass means the step succeeded
+	Pass StepStatus = "PASS"
+	// Warn means the step succeeded with caveats
+	Warn StepStatus = "WARN"
+	// Fail means the path breaks at this step
+	Fail StepStatus = "FAIL"
+	// Skip means the step did not apply or could not run
+	Skip StepStatus = "SKIP"
+)
+
+// Step is a single check along the network path
+type Step struct {
+	// Name is dns, service, endpoints, network-policy or tcp
+	Name    string     `json:"name"`
+	Status  StepStatus `json:"status"`
+	Message string     `json:"message"`
+}
+
+// ConnectivityOptions configures a connectivity test
+type ConnectivityOptions struct {
+	// Namespace is the namespace of the source pod
+	Namespace string
+
+	// From is the source pod, as NAME or pod/NAME
+	From string
+
+	// Container is the source container; defaults to the first container
+	Container string
+
+	// To is the target: svc/NAME[.NAMESPACE]:PORT, pod/NAME:PORT or HOST:PORT
+	To string
+
+	// Ephemeral runs the checks from an ephemeral debug container instead of exec
+	Ephemeral bool
+
+	// Image is the image of the ephemeral debug container
+	Image string
+
+	// Timeout bounds each in-pod check
+	Timeout time.Duration
+}
+
+// ConnectivityReport contains the result of each step, in path order
+type ConnectivityReport struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Steps  []Step `json:"steps"`
+
+	// BrokenAt is the name of the first failing step, empty if the path works
+	BrokenAt string `json:"brokenAt,omitempty"`
+}
+
+// DefaultDNSNames are resolved when no names are given: the API server service, a
+// cross-namespace service and an external domain
+var DefaultDNSNames = []string{"kubernetes.default", "kube-dns.kube-system.svc", "kubernetes.io"}
+
+// DNSOptions configures a DNS health check
+type DNSOptions struct {
+	// Namespace is where the short-lived test pod runs
+	Namespace string
+
+	// Names are the names to resolve; DefaultDNSNames when empty
+	Names []string
+
+	// Image is the test pod image; it must provide dig
+	Image string
+
+	// Timeout bounds each query
+	Timeout time.Duration
+}
+
+// DNSBackend is a DNS server that answered the queries
+type DNSBackend struct {
+	// Name is the CoreDNS pod name, or the service name for the cluster DNS service IP
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// DNSResult is the outcome of resolving one name against one backend
+type DNSResult struct {
+	Backend string `json:"backend"`
+	Name    string `json:"name"`
+
+	// Status is the DNS response code, e.g. NOERROR or NXDOMAIN, or TIMEOUT
+	Status  string        `json:"status"`
+	Latency time.Duration `json:"latency"`
+
+	// Answer is the first address returned
+	Answer string `json:"answer,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// OK reports whether the name resolved to at least one address
+func (r DNSResult) OK() bool {
+	return r.Status == "NOERROR" && r.Answer != ""
+}
+
+// DNSReport contains the result of every query, grouped by backend
+type DNSReport struct {
+	Backends []DNSBackend `json:"backends"`
+	Results  []DNSResult  `json:"results"`
+}
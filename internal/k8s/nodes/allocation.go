@@ -0,0 +1,114 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8stool/internal/k8s/pods"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Allocation sums the requests and limits of the pods scheduled on each node
+func (s *service) Allocation(ctx context.Context, opts AllocationOptions) ([]Allocation, error) {
+	var nodeList []corev1.Node
+	if opts.Name != "" {
+		node, err := s.clientset.CoreV1().Nodes().Get(ctx, opts.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node: %w", err)
+		}
+		nodeList = []corev1.Node{*node}
+	} else {
+		list, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: opts.Selector})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list nodes: %w", err)
+		}
+		nodeList = list.Items
+	}
+
+	// Only pods that still hold resources count against node allocatable
+	fieldSelector := "status.phase!=Succeeded,status.phase!=Failed"
+	if opts.Name != "" {
+		fieldSelector += ",spec.nodeName=" + opts.Name
+	}
+	podList, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: fieldSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	podsByNode := make(map[string][]*corev1.Pod)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Spec.NodeName != "" {
+			podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+		}
+	}
+
+	allocations := make([]Allocation, 0, len(nodeList))
+	for i := range nodeList {
+		allocations = append(allocations, allocate(&nodeList[i], podsByNode[nodeList[i].Name], opts.Top))
+	}
+
+	sort.Slice(allocations, func(i, j int) bool {
+		return allocations[i].Node < allocations[j].Node
+	})
+
+	return allocations, nil
+}
+
+func allocate(node *corev1.Node, nodePods []*corev1.Pod, top int) Allocation {
+	allocatable := node.Status.Allocatable
+	a := Allocation{
+		Node:              node.Name,
+		Unschedulable:     node.Spec.Unschedulable,
+		CPUAllocatable:    allocatable.Cpu().MilliValue(),
+		MemoryAllocatable: allocatable.Memory().Value(),
+		Pods:              int64(len(nodePods)),
+		MaxPods:           allocatable.Pods().Value(),
+	}
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			a.Ready = c.Status == corev1.ConditionTrue
+		}
+	}
+
+	var podAllocations []PodAllocation
+	for _, pod := range nodePods {
+		requests := pods.Requests(&pod.Spec)
+		limits := pods.Limits(&pod.Spec)
+
+		a.CPURequests += requests.Cpu().MilliValue()
+		a.MemoryRequests += requests.Memory().Value()
+		a.CPULimits += limits.Cpu().MilliValue()
+		a.MemoryLimits += limits.Memory().Value()
+
+		podAllocations = append(podAllocations, PodAllocation{
+			Namespace:     pod.Namespace,
+			Name:          pod.Name,
+			CPURequest:    requests.Cpu().MilliValue(),
+			MemoryRequest: requests.Memory().Value(),
+		})
+	}
+
+	// Pods are ranked by the larger of their CPU and memory share of the node
+	share := func(p PodAllocation) float64 {
+		return max(ratio(p.CPURequest, a.CPUAllocatable), ratio(p.MemoryRequest, a.MemoryAllocatable))
+	}
+	sort.SliceStable(podAllocations, func(i, j int) bool {
+		return share(podAllocations[i]) > share(podAllocations[j])
+	})
+	if len(podAllocations) > top {
+		podAllocations = podAllocations[:top]
+	}
+	a.TopPods = podAllocations
+
+	return a
+}
+
+func ratio(used, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(used) / float64(total)
+}
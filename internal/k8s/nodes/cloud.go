@@ -0,0 +1,102 @@
+package nodes
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Capacity types of cloud instances
+const (
+	OnDemand    = "on-demand"
+	Spot        = "spot"
+	Preemptible = "preemptible"
+)
+
+// nodeGroupLabels are the labels naming the node group of a node, most specific first:
+// EKS managed node groups, eksctl, Karpenter, GKE node pools, AKS agent pools and kOps
+var nodeGroupLabels = []string{
+	"eks.amazonaws.com/nodegroup",
+	"alpha.eksctl.io/nodegroup-name",
+	"karpenter.sh/nodepool",
+	"karpenter.sh/provisioner-name",
+	"cloud.google.com/gke-nodepool",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+	"kops.k8s.io/instancegroup",
+}
+
+// CloudInfoFor reads the cloud provider metadata of a node from its well-known labels and
+// provider ID. Fields the labels do not reveal are left empty.
+func CloudInfoFor(node *corev1.Node) CloudInfo {
+	labels := node.Labels
+	info := CloudInfo{
+		InstanceType: firstLabel(labels, corev1.LabelInstanceTypeStable, corev1.LabelInstanceType),
+		Region:       firstLabel(labels, corev1.LabelTopologyRegion, corev1.LabelFailureDomainBetaRegion),
+		Zone:         firstLabel(labels, corev1.LabelTopologyZone, corev1.LabelFailureDomainBetaZone),
+		NodeGroup:    firstLabel(labels, nodeGroupLabels...),
+	}
+
+	// The provider ID looks like aws:///us-east-1a/i-0abc or gce://project/zone/name
+	if scheme, rest, found := strings.Cut(node.Spec.ProviderID, "://"); found {
+		info.Provider = scheme
+		if i := strings.LastIndex(rest, "/"); i >= 0 {
+			info.InstanceID = rest[i+1:]
+		}
+	}
+	switch {
+	case info.Provider == "gce" || labels["cloud.google.com/gke-nodepool"] != "":
+		info.Provider = "gcp"
+	case info.Provider == "" && (labels["eks.amazonaws.com/nodegroup"] != "" || labels["karpenter.k8s.aws/instance-family"] != ""):
+		info.Provider = "aws"
+	case info.Provider == "" && labels["kubernetes.azure.com/cluster"] != "":
+		info.Provider = "azure"
+	}
+
+	info.Capacity = capacityType(info.Provider, labels)
+	return info
+}
+
+// capacityType tells spot and preemptible instances from on-demand ones. It is empty when
+// the labels do not say.
+func capacityType(provider string, labels map[string]string) string {
+	switch strings.ToLower(labels["eks.amazonaws.com/capacityType"]) {
+	case "spot":
+		return Spot
+	case "on_demand":
+		return OnDemand
+	}
+	switch strings.ToLower(firstLabel(labels, "karpenter.sh/capacity-type", "node.kubernetes.io/lifecycle")) {
+	case "spot":
+		return Spot
+	case "on-demand", "normal":
+		return OnDemand
+	}
+	if labels["cloud.google.com/gke-spot"] == "true" {
+		return Spot
+	}
+	if labels["cloud.google.com/gke-preemptible"] == "true" {
+		return Preemptible
+	}
+	if priority, ok := labels["kubernetes.azure.com/scalesetpriority"]; ok {
+		if priority == "spot" {
+			return Spot
+		}
+		return OnDemand
+	}
+	// GKE and AKS label every spot node, so an unlabeled node is on-demand
+	if provider == "gcp" || provider == "azure" {
+		return OnDemand
+	}
+	return ""
+}
+
+// firstLabel returns the value of the first of the labels that is set
+func firstLabel(labels map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := labels[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
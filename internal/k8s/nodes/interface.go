@@ -0,0 +1,54 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for node maintenance operations
+type Service interface {
+	// List returns the nodes with their status and cloud metadata
+	List(ctx context.Context, opts ListOptions) ([]Node, error)
+
+	// Cordon marks a node as unschedulable
+	Cordon(ctx context.Context, name string) error
+
+	// Uncordon marks a node as schedulable
+	Uncordon(ctx context.Context, name string) error
+
+	// PlanDrain lists the pods that would be evicted, skipped or would block a drain
+	PlanDrain(ctx context.Context, name string, opts DrainOptions) (*DrainPlan, error)
+
+	// SimulateDrain reports, per disruption budget and per workload, whether evicting the pods of
+	// a node would violate availability, without changing anything
+	SimulateDrain(ctx context.Context, name string, opts DrainOptions) (*DrainSimulation, error)
+
+	// Drain cordons a node and evicts its pods through the Eviction API
+	Drain(ctx context.Context, name string, opts DrainOptions) (*DrainPlan, error)
+
+	// Allocation sums the requests and limits of the pods scheduled on each node
+	Allocation(ctx context.Context, opts AllocationOptions) ([]Allocation, error)
+
+	// ListLogs lists the log files the kubelet serves from a directory of the node's /var/log
+	ListLogs(ctx context.Context, name, dir string) ([]string, error)
+
+	// Logs streams a node log file or the result of a node log query
+	Logs(ctx context.Context, name string, opts LogOptions) (io.ReadCloser, error)
+
+	// StartShell creates a privileged pod on a node for a host shell and waits for it to run
+	StartShell(ctx context.Context, node string, opts ShellOptions) (*ShellPod, error)
+
+	// StopShell deletes the pod of a node shell
+	StopShell(ctx context.Context, shell *ShellPod) error
+}
+
+// NewNodeService creates a new node service instance
+func NewNodeService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
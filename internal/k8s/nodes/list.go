@@ -0,0 +1,61 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nodeRolePrefix is the prefix of the labels naming the roles of a node
+const nodeRolePrefix = "node-role.kubernetes.io/"
+
+// List returns the nodes with their status and cloud metadata, sorted by name
+func (s *service) List(ctx context.Context, opts ListOptions) ([]Node, error) {
+	list, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: opts.Selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	nodes := make([]Node, 0, len(list.Items))
+	for i := range list.Items {
+		nodes = append(nodes, FromCoreNode(&list.Items[i]))
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes, nil
+}
+
+// FromCoreNode converts a core node to a Node with its cloud metadata
+func FromCoreNode(node *corev1.Node) Node {
+	n := Node{
+		Name:          node.Name,
+		Unschedulable: node.Spec.Unschedulable,
+		Version:       node.Status.NodeInfo.KubeletVersion,
+		Created:       node.CreationTimestamp.Time,
+		Cloud:         CloudInfoFor(node),
+	}
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			n.Ready = c.Status == corev1.ConditionTrue
+		}
+	}
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == corev1.NodeInternalIP {
+			n.InternalIP = addr.Address
+			break
+		}
+	}
+	for label := range node.Labels {
+		if role, found := strings.CutPrefix(label, nodeRolePrefix); found && role != "" {
+			n.Roles = append(n.Roles, role)
+		}
+	}
+	sort.Strings(n.Roles)
+	return n
+}
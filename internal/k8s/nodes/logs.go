@@ -0,0 +1,106 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// logLink matches the entries of the directory listing served by the kubelet for /var/log
+var logLink = regexp.MustCompile(`<a href="([^"]+)">`)
+
+// ListLogs returns the files and directories the kubelet serves from a directory of the
+// node's /var/log, the top level when dir is empty. Directories end with a slash.
+func (s *service) ListLogs(ctx context.Context, name, dir string) ([]string, error) {
+	dir = logPath(dir)
+	if dir != "" && !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+	data, err := s.logsRequest(name, dir).DoRaw(ctx)
+	if err != nil {
+		return nil, s.logsError(ctx, name, dir, err)
+	}
+
+	var files []string
+	for _, match := range logLink.FindAllStringSubmatch(string(data), -1) {
+		if file := match[1]; file != "../" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// Logs streams a log file of the node, or the output of a node log query for a service.
+// The caller must close the stream.
+func (s *service) Logs(ctx context.Context, name string, opts LogOptions) (io.ReadCloser, error) {
+	if opts.Path != "" && opts.Query != "" {
+		return nil, errs.Validationf("a log path and a query cannot be combined")
+	}
+	path := logPath(opts.Path)
+	if opts.Query == "" && (path == "" || strings.HasSuffix(path, "/")) {
+		return nil, errs.Validationf("a log file or a query is required")
+	}
+
+	req := s.logsRequest(name, path)
+	if opts.Query != "" {
+		// Node log queries read the journal, or the service's log file on Windows
+		req = req.Param("query", opts.Query)
+		if opts.TailLines > 0 {
+			req = req.Param("tailLines", strconv.FormatInt(opts.TailLines, 10))
+		}
+		if opts.Pattern != "" {
+			req = req.Param("pattern", opts.Pattern)
+		}
+		if opts.SinceTime != nil {
+			req = req.Param("sinceTime", opts.SinceTime.UTC().Format(metav1.RFC3339Micro))
+		}
+	}
+
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		return nil, s.logsError(ctx, name, opts.Path+opts.Query, err)
+	}
+	return stream, nil
+}
+
+// logPath makes a log path relative to /var/log, which the kubelet serves at its logs endpoint
+func logPath(path string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(path, "/var/log"), "/")
+}
+
+// logsRequest builds a request for the kubelet logs endpoint of a node through the API server
+// proxy. The endpoint lists directories, and answers queries, only with a trailing slash.
+func (s *service) logsRequest(name, path string) *rest.Request {
+	// AbsPath keeps a trailing slash only when given a single segment
+	return s.clientset.CoreV1().RESTClient().Get().
+		AbsPath("/api/v1/nodes/" + name + "/proxy/logs/" + path)
+}
+
+// logsError explains why the logs endpoint refused a request, telling a missing node from a
+// missing file and pointing at the permissions and feature gates the endpoint needs
+func (s *service) logsError(ctx context.Context, name, target string, err error) error {
+	switch {
+	case apierrors.IsNotFound(err):
+		if _, getErr := s.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{}); apierrors.IsNotFound(getErr) {
+			return errs.NotFoundf("node %q not found", name)
+		}
+		if target == "" {
+			return errs.NotFoundf("the kubelet of node %q does not serve logs; it may run with enableSystemLogHandler disabled", name)
+		}
+		return errs.NotFoundf("log %q not found on node %q", target, name)
+	case apierrors.IsForbidden(err):
+		return fmt.Errorf("reading node logs requires the get permission on nodes/proxy: %w", err)
+	case apierrors.IsBadRequest(err):
+		return errs.New(errs.Validation, "the kubelet of node %q rejected the log query; queries need the NodeLogQuery feature gate and enableSystemLogQuery: %w", name, err)
+	}
+	return fmt.Errorf("failed to read logs of node %q: %w", name, err)
+}
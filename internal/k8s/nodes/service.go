@@ -0,0 +1,261 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// mirrorPodAnnotation marks static pods mirrored from the kubelet
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+	// evictionRetryInterval is how long to wait before retrying an eviction refused by a PDB
+	evictionRetryInterval = 5 * time.Second
+	// deletionPollInterval is how often to check whether an evicted pod is gone
+	deletionPollInterval = 2 * time.Second
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new node service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// Cordon marks a node as unschedulable
+func (s *service) Cordon(ctx context.Context, name string) error {
+	return s.setUnschedulable(ctx, name, true)
+}
+
+// Uncordon marks a node as schedulable
+func (s *service) Uncordon(ctx context.Context, name string) error {
+	return s.setUnschedulable(ctx, name, false)
+}
+
+func (s *service) setUnschedulable(ctx context.Context, name string, unschedulable bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	if _, err := s.clientset.CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to update node %s: %w", name, err)
+	}
+	return nil
+}
+
+// PlanDrain lists the pods that would be evicted, skipped or would block a drain
+func (s *service) PlanDrain(ctx context.Context, name string, opts DrainOptions) (*DrainPlan, error) {
+	if _, err := s.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+
+	podList, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	pdbList, err := s.clientset.PolicyV1().PodDisruptionBudgets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+
+	plan := &DrainPlan{Node: name}
+	blockedBudgets := make(map[string]bool)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		ref := PodRef{Namespace: pod.Namespace, Name: pod.Name}
+		if owner := metav1.GetControllerOf(pod); owner != nil {
+			ref.Controller = owner.Kind + "/" + owner.Name
+		}
+
+		if skip, block := classify(pod, opts); skip != "" {
+			plan.Skip = append(plan.Skip, SkippedPod{Pod: ref, Reason: skip})
+			continue
+		} else if block != "" {
+			plan.Blocking = append(plan.Blocking, SkippedPod{Pod: ref, Reason: block})
+			continue
+		}
+
+		for j := range pdbList.Items {
+			pdb := &pdbList.Items[j]
+			if !coveredBy(pod, pdb) {
+				continue
+			}
+			ref.PDBs = append(ref.PDBs, pdb.Name)
+			if pdb.Status.DisruptionsAllowed == 0 {
+				blockedBudgets[pdb.Namespace+"/"+pdb.Name] = true
+			}
+		}
+		plan.Evict = append(plan.Evict, ref)
+	}
+
+	for budget := range blockedBudgets {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("pod disruption budget %s currently allows no disruptions; evictions will wait", budget))
+	}
+	sort.Strings(plan.Warnings)
+
+	return plan, nil
+}
+
+// classify returns why a pod is skipped or why it blocks the drain; both are empty if it can be evicted
+func classify(pod *corev1.Pod, opts DrainOptions) (skip, block string) {
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return "static pod managed by the kubelet", ""
+	}
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		// Finished pods hold no resources, but are still evicted to clear the node
+		return "", ""
+	}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner != nil && owner.Kind == "DaemonSet" {
+		if opts.IgnoreDaemonSets {
+			return "managed by DaemonSet", ""
+		}
+		return "", "managed by DaemonSet (use --ignore-daemonsets)"
+	}
+	if owner == nil && !opts.Force {
+		return "", "not managed by a controller (use --force)"
+	}
+	for _, v := range pod.Spec.Volumes {
+		if v.EmptyDir != nil && !opts.DeleteEmptyDirData {
+			return "", fmt.Sprintf("uses emptyDir volume %s (use --delete-emptydir-data)", v.Name)
+		}
+	}
+	return "", ""
+}
+
+// coveredBy reports whether a disruption budget selects the pod
+func coveredBy(pod *corev1.Pod, pdb *policyv1.PodDisruptionBudget) bool {
+	if pdb.Namespace != pod.Namespace || pdb.Spec.Selector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil || selector.Empty() {
+		return false
+	}
+	return selector.Matches(labels.Set(pod.Labels))
+}
+
+// Drain cordons a node and evicts its pods through the Eviction API
+func (s *service) Drain(ctx context.Context, name string, opts DrainOptions) (*DrainPlan, error) {
+	plan, err := s.PlanDrain(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Blocking) > 0 {
+		return plan, fmt.Errorf("cannot drain node %s: %d pods cannot be evicted", name, len(plan.Blocking))
+	}
+
+	if err := s.Cordon(ctx, name); err != nil {
+		return plan, err
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var mu sync.Mutex
+	progress := func(event DrainEvent) {
+		if opts.OnProgress == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		opts.OnProgress(event)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(plan.Evict))
+	for i, ref := range plan.Evict {
+		wg.Add(1)
+		go func(i int, ref PodRef) {
+			defer wg.Done()
+			if err := s.evict(ctx, ref, opts.GracePeriodSeconds, progress); err != nil {
+				errs[i] = err
+				progress(DrainEvent{Pod: ref, Status: Failed, Message: err.Error()})
+			}
+		}(i, ref)
+	}
+	wg.Wait()
+
+	var failed int
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return plan, fmt.Errorf("failed to evict %d of %d pods from node %s", failed, len(plan.Evict), name)
+	}
+	return plan, nil
+}
+
+// evict requests the eviction of a pod, retrying while a disruption budget refuses it,
+// and waits until the pod is gone
+func (s *service) evict(ctx context.Context, ref PodRef, gracePeriod int64, progress func(DrainEvent)) error {
+	pod, err := s.clientset.CoreV1().Pods(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		progress(DrainEvent{Pod: ref, Status: Evicted})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace},
+	}
+	if gracePeriod >= 0 {
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}
+	}
+
+	progress(DrainEvent{Pod: ref, Status: Evicting})
+	for {
+		err := s.clientset.CoreV1().Pods(ref.Namespace).EvictV1(ctx, eviction)
+		if err == nil || apierrors.IsNotFound(err) {
+			break
+		}
+		if !apierrors.IsTooManyRequests(err) {
+			return fmt.Errorf("failed to evict pod: %w", err)
+		}
+		progress(DrainEvent{Pod: ref, Status: BlockedByPDB, Message: err.Error()})
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for eviction: %w", ctx.Err())
+		case <-time.After(evictionRetryInterval):
+		}
+	}
+
+	for {
+		current, err := s.clientset.CoreV1().Pods(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		// A pod with the same name but a new UID was recreated by its StatefulSet
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			progress(DrainEvent{Pod: ref, Status: Evicted})
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get pod: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for pod deletion: %w", ctx.Err())
+		case <-time.After(deletionPollInterval):
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	// DefaultShellImage is used for node shells when no image is given
+	DefaultShellImage = "busybox:1.36"
+
+	// shellPodLifetime bounds how long a shell pod runs if it is never cleaned up
+	shellPodLifetime = "86400"
+
+	// shellHostRoot is where the node's root filesystem is mounted in the shell pod
+	shellHostRoot = "/host"
+)
+
+// StartShell creates a privileged pod pinned to a node, sharing the host's PID, network and
+// IPC namespaces and mounting its root filesystem, and waits for it to run. The pod tolerates
+// every taint so it also starts on cordoned, tainted or control plane nodes.
+func (s *service) StartShell(ctx context.Context, node string, opts ShellOptions) (*ShellPod, error) {
+	if _, err := s.clientset.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errs.NotFoundf("node %q not found", node)
+		}
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+	if opts.Image == "" {
+		opts.Image = DefaultShellImage
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Minute
+	}
+
+	privileged := true
+	hostPathType := corev1.HostPathDirectory
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "k8stool-node-shell-" + utilrand.String(5),
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "k8stool",
+				"app.kubernetes.io/component":  "node-shell",
+			},
+		},
+		Spec: corev1.PodSpec{
+			// Setting the node name bypasses the scheduler, so cordoned nodes are reachable too
+			NodeName:                      node,
+			HostPID:                       true,
+			HostNetwork:                   true,
+			HostIPC:                       true,
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			TerminationGracePeriodSeconds: new(int64),
+			Tolerations:                   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			Containers: []corev1.Container{{
+				Name:            "shell",
+				Image:           opts.Image,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command:         []string{"sleep", shellPodLifetime},
+				Stdin:           true,
+				TTY:             true,
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+				VolumeMounts:    []corev1.VolumeMount{{Name: "host-root", MountPath: shellHostRoot}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "host-root",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: "/", Type: &hostPathType},
+				},
+			}},
+		},
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(opts.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsForbidden(err) {
+			// Usually the Pod Security level of the namespace, which rejects privileged pods
+			return nil, fmt.Errorf("failed to create shell pod in namespace %s, which may not allow privileged pods: %w", opts.Namespace, err)
+		}
+		return nil, fmt.Errorf("failed to create shell pod: %w", err)
+	}
+	shell := &ShellPod{Namespace: pod.Namespace, Name: pod.Name, Node: node}
+
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	for {
+		current, err := s.clientset.CoreV1().Pods(pod.Namespace).Get(waitCtx, pod.Name, metav1.GetOptions{})
+		if err == nil {
+			switch current.Status.Phase {
+			case corev1.PodRunning:
+				return shell, nil
+			case corev1.PodFailed, corev1.PodSucceeded:
+				return shell, fmt.Errorf("shell pod exited: %s", current.Status.Phase)
+			}
+			if reason := waitingReason(current); reason != "" {
+				return shell, fmt.Errorf("shell pod cannot start: %s", reason)
+			}
+		}
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return shell, ctx.Err()
+			}
+			return shell, errs.Timeoutf("timed out waiting for shell pod %s to start", pod.Name)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// waitingReason returns why the shell container cannot start when it will not recover by
+// waiting, such as an image that cannot be pulled
+func waitingReason(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if w := status.State.Waiting; w != nil {
+			switch w.Reason {
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError", "CreateContainerError":
+				if w.Message != "" {
+					return w.Reason + ": " + w.Message
+				}
+				return w.Reason
+			}
+		}
+	}
+	return ""
+}
+
+// StopShell deletes a shell pod immediately. A pod that is already gone is not an error.
+func (s *service) StopShell(ctx context.Context, shell *ShellPod) error {
+	grace := int64(0)
+	err := s.clientset.CoreV1().Pods(shell.Namespace).Delete(ctx, shell.Name, metav1.DeleteOptions{GracePeriodSeconds: &grace})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete shell pod %s: %w", shell.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/pods"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SimulateDrain reports, per disruption budget and per workload, whether evicting the pods of a
+// node would violate availability, without changing anything
+func (s *service) SimulateDrain(ctx context.Context, name string, opts DrainOptions) (*DrainSimulation, error) {
+	plan, err := s.PlanDrain(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	sim := &DrainSimulation{Plan: plan}
+	if len(plan.Evict) == 0 {
+		return sim, nil
+	}
+
+	evicted := make(map[string]bool)
+	namespaces := make(map[string]bool)
+	for _, ref := range plan.Evict {
+		evicted[ref.Namespace+"/"+ref.Name] = true
+		namespaces[ref.Namespace] = true
+	}
+
+	for ns := range namespaces {
+		budgets, workloads, err := s.namespaceImpacts(ctx, ns, evicted)
+		if err != nil {
+			return nil, err
+		}
+		sim.Budgets = append(sim.Budgets, budgets...)
+		sim.Workloads = append(sim.Workloads, workloads...)
+	}
+	sort.Slice(sim.Budgets, func(i, j int) bool {
+		if sim.Budgets[i].Namespace != sim.Budgets[j].Namespace {
+			return sim.Budgets[i].Namespace < sim.Budgets[j].Namespace
+		}
+		return sim.Budgets[i].Name < sim.Budgets[j].Name
+	})
+	sort.Slice(sim.Workloads, func(i, j int) bool {
+		a, b := sim.Workloads[i], sim.Workloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return sim, nil
+}
+
+// budgetImpact judges a budget that would lose count pods
+func budgetImpact(pdb *policyv1.PodDisruptionBudget, count int) BudgetImpact {
+	impact := BudgetImpact{
+		Namespace:          pdb.Namespace,
+		Name:               pdb.Name,
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+		DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+		Evicted:            count,
+	}
+	switch {
+	case pdb.Spec.MinAvailable != nil:
+		impact.Policy = "minAvailable=" + pdb.Spec.MinAvailable.String()
+	case pdb.Spec.MaxUnavailable != nil:
+		impact.Policy = "maxUnavailable=" + pdb.Spec.MaxUnavailable.String()
+	}
+
+	allowed := int(pdb.Status.DisruptionsAllowed)
+	switch {
+	case allowed == 0:
+		impact.Verdict = VerdictBlocked
+		impact.Message = fmt.Sprintf("allows no disruptions with %d of %d desired pods healthy; the drain hangs until more pods are ready",
+			pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy)
+	case count > allowed:
+		impact.Verdict = VerdictWaits
+		impact.Message = fmt.Sprintf("%d pods to evict but %d disruptions allowed; the rest wait for replacements to become ready", count, allowed)
+	default:
+		impact.Verdict = VerdictOK
+		impact.Message = fmt.Sprintf("%d of %d allowed disruptions used", count, allowed)
+	}
+	return impact
+}
+
+// namespaceImpacts judges the disruption budgets of a namespace covering evicted pods, and
+// reports how many ready pods each workload with evicted pods keeps on other nodes. Pods are
+// grouped by their top-level controller.
+func (s *service) namespaceImpacts(ctx context.Context, namespace string, evicted map[string]bool) ([]BudgetImpact, []WorkloadImpact, error) {
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	rsList, err := s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	pdbList, err := s.clientset.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+	replicaSets := make(map[types.UID]*appsv1.ReplicaSet)
+	for i := range rsList.Items {
+		replicaSets[rsList.Items[i].UID] = &rsList.Items[i]
+	}
+
+	var budgets []BudgetImpact
+	verdicts := make(map[string]Verdict)
+	for i := range pdbList.Items {
+		pdb := &pdbList.Items[i]
+		count := 0
+		for j := range podList.Items {
+			pod := &podList.Items[j]
+			if evicted[pod.Namespace+"/"+pod.Name] && !podFinished(pod) && coveredBy(pod, pdb) {
+				count++
+			}
+		}
+		if count > 0 {
+			impact := budgetImpact(pdb, count)
+			budgets = append(budgets, impact)
+			verdicts[pdb.Name] = impact.Verdict
+		}
+	}
+
+	type tally struct {
+		impact       WorkloadImpact
+		readyEvicted int
+		pdbs         map[string]bool
+	}
+	workloads := make(map[string]*tally)
+	var order []string
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		kind, name := topController(pod, replicaSets)
+		key := kind + "/" + name
+		isEvicted := evicted[pod.Namespace+"/"+pod.Name] && !podFinished(pod)
+
+		t, ok := workloads[key]
+		if !ok {
+			t = &tally{impact: WorkloadImpact{Namespace: namespace, Kind: kind, Name: name}, pdbs: make(map[string]bool)}
+			workloads[key] = t
+			order = append(order, key)
+		}
+		ready := pods.IsReady(pod)
+		if ready {
+			t.impact.Ready++
+		}
+		if !isEvicted {
+			continue
+		}
+		t.impact.Evicted++
+		if ready {
+			t.readyEvicted++
+		}
+		for j := range pdbList.Items {
+			if coveredBy(pod, &pdbList.Items[j]) {
+				t.pdbs[pdbList.Items[j].Name] = true
+			}
+		}
+	}
+
+	var impacts []WorkloadImpact
+	for _, key := range order {
+		t := workloads[key]
+		if t.impact.Evicted == 0 {
+			continue
+		}
+		impact := t.impact
+		impact.ReadyAfter = impact.Ready - t.readyEvicted
+		for name := range t.pdbs {
+			impact.PDBs = append(impact.PDBs, name)
+		}
+		sort.Strings(impact.PDBs)
+		impact.Verdict, impact.Message = workloadVerdict(impact, t.readyEvicted, verdicts)
+		impacts = append(impacts, impact)
+	}
+	return budgets, impacts, nil
+}
+
+// workloadVerdict judges a workload from the budgets covering it and the ready pods it keeps.
+// A budget pacing the evictions keeps the workload available, so budgets are looked at first.
+func workloadVerdict(impact WorkloadImpact, readyEvicted int, budgets map[string]Verdict) (Verdict, string) {
+	var blocked, waits []string
+	for _, name := range impact.PDBs {
+		switch budgets[name] {
+		case VerdictBlocked:
+			blocked = append(blocked, name)
+		case VerdictWaits:
+			waits = append(waits, name)
+		}
+	}
+
+	switch {
+	case len(blocked) > 0:
+		return VerdictBlocked, fmt.Sprintf("evictions refused by pdb %s", strings.Join(blocked, ", "))
+	case len(waits) > 0:
+		return VerdictWaits, fmt.Sprintf("evictions paced by pdb %s", strings.Join(waits, ", "))
+	case impact.Kind == "Pod":
+		return VerdictOutage, "bare pod is not recreated once evicted"
+	case readyEvicted > 0 && impact.ReadyAfter == 0:
+		return VerdictOutage, fmt.Sprintf("all %d ready pods run on this node and no disruption budget protects them", readyEvicted)
+	}
+	return VerdictOK, fmt.Sprintf("%d ready pods remain on other nodes", impact.ReadyAfter)
+}
+
+// topController returns the kind and name of the controller at the top of a pod's ownership,
+// resolving ReplicaSets to their Deployment. Bare pods are their own workload.
+func topController(pod *corev1.Pod, replicaSets map[types.UID]*appsv1.ReplicaSet) (string, string) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "Pod", pod.Name
+	}
+	if owner.Kind == "ReplicaSet" {
+		if rs, ok := replicaSets[owner.UID]; ok {
+			if d := metav1.GetControllerOf(rs); d != nil && d.Kind == "Deployment" {
+				return d.Kind, d.Name
+			}
+		}
+	}
+	return owner.Kind, owner.Name
+}
+
+// podFinished reports whether a pod has completed, so evicting it costs no availability
+func podFinished(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
@@ -0,0 +1,282 @@
+package nodes
+
+import (
+	"time"
+)
+
+// DrainOptions configures which pods may be evicted and how
+type DrainOptions struct {
+	// IgnoreDaemonSets skips DaemonSet-managed pods instead of refusing to drain
+	IgnoreDaemonSets bool
+
+	// DeleteEmptyDirData allows evicting pods that use emptyDir volumes
+	DeleteEmptyDirData bool
+
+	// Force allows evicting pods that are not managed by a controller
+	Force bool
+
+	// GracePeriodSeconds overrides the pod termination grace period; negative uses the pod's own
+	GracePeriodSeconds int64
+
+	// Timeout bounds how long to wait for all evictions to complete; zero means no limit
+	Timeout time.Duration
+
+	// OnProgress is called for every eviction status change
+	OnProgress func(DrainEvent)
+}
+
+// PodRef identifies a pod on the node being drained
+type PodRef struct {
+	Namespace string
+	Name      string
+
+	// Controller is the kind/name of the controlling owner, empty for bare pods
+	Controller string
+
+	// PDBs lists the disruption budgets covering the pod
+	PDBs []string
+}
+
+// SkippedPod is a pod left alone by the drain
+type SkippedPod struct {
+	Pod    PodRef
+	Reason string
+}
+
+// DrainPlan describes what a drain will do
+type DrainPlan struct {
+	Node string
+
+	// Evict lists pods that will be evicted
+	Evict []PodRef
+
+	// Skip lists pods that are ignored, such as mirror and DaemonSet pods
+	Skip []SkippedPod
+
+	// Blocking lists pods that prevent the drain unless the matching option is set
+	Blocking []SkippedPod
+
+	// Warnings lists disruption budgets that currently allow no disruptions
+	Warnings []string
+}
+
+// Verdict is whether evicting a node's pods keeps a budget or workload available
+type Verdict string
+
+const (
+	// VerdictOK means the evictions keep the workload available
+	VerdictOK Verdict = "ok"
+	// VerdictWaits means a disruption budget lets the drain proceed only as replacement pods
+	// become ready elsewhere
+	VerdictWaits Verdict = "waits"
+	// VerdictBlocked means a disruption budget currently allows no disruptions, so the drain
+	// hangs until pods recover
+	VerdictBlocked Verdict = "blocked"
+	// VerdictOutage means every ready pod of the workload is evicted at once
+	VerdictOutage Verdict = "outage"
+)
+
+// DrainSimulation reports whether draining a node would violate availability
+type DrainSimulation struct {
+	Plan *DrainPlan `json:"plan"`
+
+	// Budgets are the disruption budgets covering the evicted pods
+	Budgets []BudgetImpact `json:"budgets"`
+
+	// Workloads are the controllers of the evicted pods
+	Workloads []WorkloadImpact `json:"workloads"`
+}
+
+// Violations returns the number of budgets and workloads whose availability the drain breaks
+func (s *DrainSimulation) Violations() int {
+	count := 0
+	for _, b := range s.Budgets {
+		if b.Verdict == VerdictBlocked {
+			count++
+		}
+	}
+	for _, w := range s.Workloads {
+		if w.Verdict == VerdictOutage {
+			count++
+		}
+	}
+	return count
+}
+
+// BudgetImpact is how a drain affects a pod disruption budget
+type BudgetImpact struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// Policy is the budget's minAvailable or maxUnavailable, such as minAvailable=2
+	Policy string `json:"policy"`
+
+	CurrentHealthy     int32 `json:"currentHealthy"`
+	DesiredHealthy     int32 `json:"desiredHealthy"`
+	DisruptionsAllowed int32 `json:"disruptionsAllowed"`
+
+	// Evicted is the number of the budget's pods on the node
+	Evicted int `json:"evicted"`
+
+	Verdict Verdict `json:"verdict"`
+	Message string  `json:"message"`
+}
+
+// WorkloadImpact is how a drain affects the availability of a workload
+type WorkloadImpact struct {
+	Namespace string `json:"namespace"`
+
+	// Kind is the kind of the top-level controller, such as Deployment, or Pod for bare pods
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+
+	// Ready is the number of ready pods of the workload across the cluster
+	Ready int `json:"ready"`
+
+	// Evicted is the number of the workload's pods on the node, ReadyAfter the ready pods left
+	// elsewhere once they are evicted
+	Evicted    int `json:"evicted"`
+	ReadyAfter int `json:"readyAfter"`
+
+	// PDBs lists the disruption budgets covering the workload's pods on the node
+	PDBs []string `json:"pdbs,omitempty"`
+
+	Verdict Verdict `json:"verdict"`
+	Message string  `json:"message"`
+}
+
+// DrainStatus is the state of a single pod eviction
+type DrainStatus string
+
+const (
+	// Evicting means the eviction request is being sent
+	Evicting DrainStatus = "evicting"
+	// BlockedByPDB means the eviction is being retried because a disruption budget refuses it
+	BlockedByPDB DrainStatus = "blocked by pdb"
+	// Evicted means the pod is gone
+	Evicted DrainStatus = "evicted"
+	// Failed means the pod could not be evicted
+	Failed DrainStatus = "failed"
+)
+
+// DrainEvent reports the progress of a single pod eviction
+type DrainEvent struct {
+	Pod     PodRef
+	Status  DrainStatus
+	Message string
+}
+
+// AllocationOptions selects the nodes to report on
+type AllocationOptions struct {
+	// Name limits the report to a single node
+	Name string
+
+	// Selector is a label selector for nodes
+	Selector string
+
+	// Top is the number of largest pods to report per node
+	Top int
+}
+
+// Allocation is the share of a node's allocatable resources claimed by its pods
+type Allocation struct {
+	Node          string `json:"node"`
+	Ready         bool   `json:"ready"`
+	Unschedulable bool   `json:"unschedulable"`
+
+	// CPU values are in millicores
+	CPUAllocatable int64 `json:"cpuAllocatable"`
+	CPURequests    int64 `json:"cpuRequests"`
+	CPULimits      int64 `json:"cpuLimits"`
+
+	// Memory values are in bytes
+	MemoryAllocatable int64 `json:"memoryAllocatable"`
+	MemoryRequests    int64 `json:"memoryRequests"`
+	MemoryLimits      int64 `json:"memoryLimits"`
+
+	Pods    int64 `json:"pods"`
+	MaxPods int64 `json:"maxPods"`
+
+	// TopPods are the pods claiming the largest share of the node, largest first
+	TopPods []PodAllocation `json:"topPods,omitempty"`
+}
+
+// PodAllocation is the resources requested by a single pod
+type PodAllocation struct {
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	CPURequest    int64  `json:"cpuRequest"`
+	MemoryRequest int64  `json:"memoryRequest"`
+}
+
+// ShellOptions configures the privileged pod started for a node shell
+type ShellOptions struct {
+	// Namespace the pod is created in
+	Namespace string
+
+	// Image of the pod; it must provide nsenter and a shell
+	Image string
+
+	// Timeout bounds how long to wait for the pod to start
+	Timeout time.Duration
+}
+
+// ShellPod identifies the pod of a node shell
+type ShellPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Node      string `json:"node"`
+}
+
+// LogOptions selects the node log to read
+type LogOptions struct {
+	// Path is a log file relative to the node's /var/log, such as kubelet.log
+	Path string
+
+	// Query is a service whose logs are read with a node log query, such as kubelet. It needs
+	// the NodeLogQuery feature gate.
+	Query string
+
+	// TailLines limits a query to its last lines
+	TailLines int64
+
+	// Pattern is a regular expression a query's lines must match
+	Pattern string
+
+	// SinceTime limits a query to lines logged after it
+	SinceTime *time.Time
+}
+
+// CloudInfo is the cloud provider metadata of a node
+type CloudInfo struct {
+	// Provider is the scheme of the node's provider ID, such as aws, gcp or azure
+	Provider     string `json:"provider,omitempty"`
+	InstanceID   string `json:"instanceID,omitempty"`
+	InstanceType string `json:"instanceType,omitempty"`
+	Region       string `json:"region,omitempty"`
+	Zone         string `json:"zone,omitempty"`
+
+	// Capacity is on-demand, spot or preemptible
+	Capacity string `json:"capacity,omitempty"`
+
+	// NodeGroup is the managed node group, node pool, Karpenter node pool or agent pool
+	NodeGroup string `json:"nodeGroup,omitempty"`
+}
+
+// ListOptions selects the nodes to list
+type ListOptions struct {
+	// Selector is a label selector for nodes
+	Selector string
+}
+
+// Node is a node with its status and cloud metadata
+type Node struct {
+	Name          string    `json:"name"`
+	Ready         bool      `json:"ready"`
+	Unschedulable bool      `json:"unschedulable"`
+	Roles         []string  `json:"roles,omitempty"`
+	Version       string    `json:"version"`
+	InternalIP    string    `json:"internalIP,omitempty"`
+	Created       time.Time `json:"created"`
+	Cloud         CloudInfo `json:"cloud"`
+}
@@ -0,0 +1,22 @@
+package oom
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for OOMKill and restart reporting
+type Service interface {
+	// Report scans container statuses and events for OOM kills and restart bursts
+	Report(ctx context.Context, opts Options) (*Report, error)
+}
+
+// NewOOMService creates a new OOM report service instance
+func NewOOMService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
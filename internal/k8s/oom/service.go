@@ -0,0 +1,174 @@
+package oom
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// containerFieldPath extracts the container name from an event field path like spec.containers{app}
+var containerFieldPath = regexp.MustCompile(`^spec\.(?:init)?[cC]ontainers\{(.+)\}$`)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new OOM report service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// Report scans container statuses and events for OOM kills and restart bursts
+func (s *service) Report(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Since <= 0 {
+		opts.Since = 24 * time.Hour
+	}
+	if opts.RestartThreshold <= 0 {
+		opts.RestartThreshold = 3
+	}
+	cutoff := time.Now().Add(-opts.Since)
+
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	backOffs, err := s.backOffs(ctx, namespace, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := make(map[string]*WorkloadReport)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+
+		limits := make(map[string]string)
+		for _, c := range pod.Spec.Containers {
+			if limit, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+				limits[c.Name] = limit.String()
+			}
+		}
+
+		for _, cs := range pod.Status.ContainerStatuses {
+			report := ContainerReport{
+				Pod:         pod.Name,
+				Container:   cs.Name,
+				Restarts:    cs.RestartCount,
+				BackOffs:    backOffs[pod.Namespace+"/"+pod.Name+"/"+cs.Name],
+				MemoryLimit: limits[cs.Name],
+			}
+
+			// The current state wins over the last state when the container is terminated right now
+			for _, terminated := range []*corev1.ContainerStateTerminated{cs.LastTerminationState.Terminated, cs.State.Terminated} {
+				if terminated == nil || terminated.FinishedAt.Time.Before(cutoff) {
+					continue
+				}
+				report.LastTermination = terminated.Reason
+				report.LastTerminated = terminated.FinishedAt.Time
+				if terminated.Reason == "OOMKilled" {
+					report.OOMKilled = true
+				}
+			}
+
+			if !report.OOMKilled && report.BackOffs < opts.RestartThreshold {
+				continue
+			}
+
+			key := pod.Namespace + "/" + workloadOf(pod)
+			wl, ok := workloads[key]
+			if !ok {
+				wl = &WorkloadReport{Namespace: pod.Namespace, Workload: workloadOf(pod)}
+				workloads[key] = wl
+			}
+			if report.OOMKilled {
+				wl.OOMKills++
+			}
+			wl.BackOffs += report.BackOffs
+			wl.Restarts += report.Restarts
+			wl.Containers = append(wl.Containers, report)
+		}
+	}
+
+	result := &Report{Since: opts.Since}
+	for _, wl := range workloads {
+		sort.Slice(wl.Containers, func(i, j int) bool {
+			if wl.Containers[i].Pod != wl.Containers[j].Pod {
+				return wl.Containers[i].Pod < wl.Containers[j].Pod
+			}
+			return wl.Containers[i].Container < wl.Containers[j].Container
+		})
+		result.Workloads = append(result.Workloads, *wl)
+	}
+
+	sort.Slice(result.Workloads, func(i, j int) bool {
+		a, b := result.Workloads[i], result.Workloads[j]
+		if a.OOMKills != b.OOMKills {
+			return a.OOMKills > b.OOMKills
+		}
+		if a.BackOffs != b.BackOffs {
+			return a.BackOffs > b.BackOffs
+		}
+		return a.Namespace+"/"+a.Workload < b.Namespace+"/"+b.Workload
+	})
+
+	return result, nil
+}
+
+// backOffs counts back-off restart events per namespace/pod/container since the cutoff
+func (s *service) backOffs(ctx context.Context, namespace string, cutoff time.Time) (map[string]int32, error) {
+	events, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "involvedObject.kind=Pod,reason=BackOff",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	counts := make(map[string]int32)
+	for _, e := range events.Items {
+		last := e.LastTimestamp.Time
+		if last.IsZero() {
+			last = e.EventTime.Time
+		}
+		if last.Before(cutoff) || !strings.Contains(e.Message, "restarting failed container") {
+			continue
+		}
+		match := containerFieldPath.FindStringSubmatch(e.InvolvedObject.FieldPath)
+		if match == nil {
+			continue
+		}
+		count := e.Count
+		if count == 0 {
+			count = 1
+		}
+		counts[e.InvolvedObject.Namespace+"/"+e.InvolvedObject.Name+"/"+match[1]] += count
+	}
+	return counts, nil
+}
+
+// workloadOf returns the top-level workload of a pod, resolving ReplicaSets to their Deployment
+func workloadOf(pod *corev1.Pod) string {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return "Pod/" + pod.Name
+	}
+	if ref.Kind == "ReplicaSet" {
+		if hash := pod.Labels["pod-template-hash"]; hash != "" && strings.HasSuffix(ref.Name, "-"+hash) {
+			return "Deployment/" + strings.TrimSuffix(ref.Name, "-"+hash)
+		}
+	}
+	return ref.Kind + "/" + ref.Name
+}
@@ -0,0 +1,55 @@
+package oom
+
+import (
+	"time"
+)
+
+// Options configures the scan
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+
+	// Since limits the report to terminations and events within this window
+	Since time.Duration
+
+	// RestartThreshold is the number of back-off restarts within the window that counts as a burst
+	RestartThreshold int32
+}
+
+// ContainerReport describes the OOM kills and restarts of a single container
+type ContainerReport struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+
+	// Restarts is the lifetime restart counter of the container
+	Restarts int32 `json:"restarts"`
+
+	// BackOffs is the number of back-off restarts reported by events within the window
+	BackOffs int32 `json:"backOffs"`
+
+	// OOMKilled reports whether the container was OOM killed within the window
+	OOMKilled bool `json:"oomKilled"`
+
+	// LastTermination is the reason of the last termination, e.g. OOMKilled or Error
+	LastTermination string    `json:"lastTermination,omitempty"`
+	LastTerminated  time.Time `json:"lastTerminated,omitempty"`
+
+	// MemoryLimit is the container memory limit, empty if unset
+	MemoryLimit string `json:"memoryLimit,omitempty"`
+}
+
+// WorkloadReport groups container reports by the workload owning the pods
+type WorkloadReport struct {
+	Namespace  string            `json:"namespace"`
+	Workload   string            `json:"workload"`
+	OOMKills   int               `json:"oomKills"`
+	BackOffs   int32             `json:"backOffs"`
+	Restarts   int32             `json:"restarts"`
+	Containers []ContainerReport `json:"containers"`
+}
+
+// Report contains the affected workloads, worst first
+type Report struct {
+	Since     time.Duration    `json:"since"`
+	Workloads []WorkloadReport `json:"workloads"`
+}
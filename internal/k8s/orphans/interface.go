@@ -0,0 +1,22 @@
+package orphans
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for detecting orphaned and unused resources
+type Service interface {
+	// Find returns resources that appear to be unused
+	Find(ctx context.Context, opts Options) ([]Orphan, error)
+}
+
+// NewOrphanService creates a new orphan detection service instance
+func NewOrphanService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
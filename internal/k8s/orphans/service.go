@@ -0,0 +1,322 @@
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// revisionAnnotation holds the rollout revision of deployments and their ReplicaSets
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new orphan detection service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// references records which namespaced objects are in use, keyed by namespace/name
+type references struct {
+	configMaps map[string]bool
+	secrets    map[string]bool
+	pvcs       map[string]bool
+}
+
+// Find returns resources that appear to be unused
+func (s *service) Find(ctx context.Context, opts Options) ([]Orphan, error) {
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = AllKinds
+	}
+
+	var refs *references
+	var orphans []Orphan
+	for _, kind := range kinds {
+		var found []Orphan
+		var err error
+
+		switch kind {
+		case ConfigMaps, Secrets, PVCs:
+			if refs == nil {
+				if refs, err = s.collectReferences(ctx, namespace); err != nil {
+					return nil, err
+				}
+			}
+			switch kind {
+			case ConfigMaps:
+				found, err = s.findConfigMaps(ctx, namespace, refs)
+			case Secrets:
+				found, err = s.findSecrets(ctx, namespace, refs)
+			case PVCs:
+				found, err = s.findPVCs(ctx, namespace, refs)
+			}
+		case Services:
+			found, err = s.findServices(ctx, namespace)
+		case ReplicaSets:
+			found, err = s.findReplicaSets(ctx, namespace)
+		default:
+			return nil, errs.Validationf("unsupported kind: %s", kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		sort.Slice(found, func(i, j int) bool {
+			if found[i].Namespace != found[j].Namespace {
+				return found[i].Namespace < found[j].Namespace
+			}
+			return found[i].Name < found[j].Name
+		})
+		orphans = append(orphans, found...)
+	}
+
+	return orphans, nil
+}
+
+// collectReferences gathers the ConfigMaps, Secrets and PVCs referenced by pods,
+// service accounts and ingresses
+func (s *service) collectReferences(ctx context.Context, namespace string) (*references, error) {
+	refs := &references{
+		configMaps: make(map[string]bool),
+		secrets:    make(map[string]bool),
+		pvcs:       make(map[string]bool),
+	}
+
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range podList.Items {
+		addPodReferences(refs, &pod)
+	}
+
+	saList, err := s.clientset.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	for _, sa := range saList.Items {
+		for _, ref := range sa.Secrets {
+			refs.secrets[key(sa.Namespace, ref.Name)] = true
+		}
+		for _, ref := range sa.ImagePullSecrets {
+			refs.secrets[key(sa.Namespace, ref.Name)] = true
+		}
+	}
+
+	ingressList, err := s.clientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+	for _, ing := range ingressList.Items {
+		for _, tls := range ing.Spec.TLS {
+			refs.secrets[key(ing.Namespace, tls.SecretName)] = true
+		}
+	}
+
+	return refs, nil
+}
+
+func addPodReferences(refs *references, pod *corev1.Pod) {
+	ns := pod.Namespace
+
+	for _, v := range pod.Spec.Volumes {
+		switch {
+		case v.ConfigMap != nil:
+			refs.configMaps[key(ns, v.ConfigMap.Name)] = true
+		case v.Secret != nil:
+			refs.secrets[key(ns, v.Secret.SecretName)] = true
+		case v.PersistentVolumeClaim != nil:
+			refs.pvcs[key(ns, v.PersistentVolumeClaim.ClaimName)] = true
+		case v.Projected != nil:
+			for _, src := range v.Projected.Sources {
+				if src.ConfigMap != nil {
+					refs.configMaps[key(ns, src.ConfigMap.Name)] = true
+				}
+				if src.Secret != nil {
+					refs.secrets[key(ns, src.Secret.Name)] = true
+				}
+			}
+		}
+	}
+
+	for _, ref := range pod.Spec.ImagePullSecrets {
+		refs.secrets[key(ns, ref.Name)] = true
+	}
+
+	containers := append([]corev1.Container{}, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+	for _, c := range containers {
+		for _, env := range c.EnvFrom {
+			if env.ConfigMapRef != nil {
+				refs.configMaps[key(ns, env.ConfigMapRef.Name)] = true
+			}
+			if env.SecretRef != nil {
+				refs.secrets[key(ns, env.SecretRef.Name)] = true
+			}
+		}
+		for _, env := range c.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				refs.configMaps[key(ns, env.ValueFrom.ConfigMapKeyRef.Name)] = true
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				refs.secrets[key(ns, env.ValueFrom.SecretKeyRef.Name)] = true
+			}
+		}
+	}
+}
+
+func (s *service) findConfigMaps(ctx context.Context, namespace string, refs *references) ([]Orphan, error) {
+	list, err := s.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps: %w", err)
+	}
+
+	var orphans []Orphan
+	for _, cm := range list.Items {
+		// The root CA bundle is published into every namespace by the control plane
+		if cm.Name == "kube-root-ca.crt" || refs.configMaps[key(cm.Namespace, cm.Name)] {
+			continue
+		}
+		orphans = append(orphans, newOrphan(ConfigMaps, cm.ObjectMeta, "not referenced by any pod"))
+	}
+	return orphans, nil
+}
+
+func (s *service) findSecrets(ctx context.Context, namespace string, refs *references) ([]Orphan, error) {
+	list, err := s.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	var orphans []Orphan
+	for _, secret := range list.Items {
+		// Token secrets and Helm release records are managed by their controllers
+		if secret.Type == corev1.SecretTypeServiceAccountToken || secret.Type == "helm.sh/release.v1" {
+			continue
+		}
+		if refs.secrets[key(secret.Namespace, secret.Name)] {
+			continue
+		}
+		orphans = append(orphans, newOrphan(Secrets, secret.ObjectMeta, "not referenced by any pod, service account or ingress"))
+	}
+	return orphans, nil
+}
+
+func (s *service) findPVCs(ctx context.Context, namespace string, refs *references) ([]Orphan, error) {
+	list, err := s.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistent volume claims: %w", err)
+	}
+
+	var orphans []Orphan
+	for _, pvc := range list.Items {
+		if refs.pvcs[key(pvc.Namespace, pvc.Name)] {
+			continue
+		}
+		orphans = append(orphans, newOrphan(PVCs, pvc.ObjectMeta, fmt.Sprintf("not mounted by any pod (%s)", pvc.Status.Phase)))
+	}
+	return orphans, nil
+}
+
+func (s *service) findServices(ctx context.Context, namespace string) ([]Orphan, error) {
+	list, err := s.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	endpointsList, err := s.clientset.CoreV1().Endpoints(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+	hasEndpoints := make(map[string]bool, len(endpointsList.Items))
+	for _, ep := range endpointsList.Items {
+		for _, subset := range ep.Subsets {
+			if len(subset.Addresses) > 0 || len(subset.NotReadyAddresses) > 0 {
+				hasEndpoints[key(ep.Namespace, ep.Name)] = true
+				break
+			}
+		}
+	}
+
+	var orphans []Orphan
+	for _, svc := range list.Items {
+		if svc.Spec.Type == corev1.ServiceTypeExternalName || hasEndpoints[key(svc.Namespace, svc.Name)] {
+			continue
+		}
+		reason := "no endpoints"
+		if len(svc.Spec.Selector) > 0 {
+			reason = "selector matches no pods"
+		}
+		orphans = append(orphans, newOrphan(Services, svc.ObjectMeta, reason))
+	}
+	return orphans, nil
+}
+
+func (s *service) findReplicaSets(ctx context.Context, namespace string) ([]Orphan, error) {
+	list, err := s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+
+	deployList, err := s.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	currentRevision := make(map[string]string, len(deployList.Items))
+	for _, d := range deployList.Items {
+		currentRevision[key(d.Namespace, d.Name)] = d.Annotations[revisionAnnotation]
+	}
+
+	var orphans []Orphan
+	for _, rs := range list.Items {
+		owner := metav1.GetControllerOf(&rs)
+		if owner == nil || owner.Kind != "Deployment" {
+			continue
+		}
+		if rs.Spec.Replicas == nil || *rs.Spec.Replicas != 0 || rs.Status.Replicas != 0 {
+			continue
+		}
+		// A deployment scaled to zero also scales its current ReplicaSet to zero
+		revision := rs.Annotations[revisionAnnotation]
+		if current, ok := currentRevision[key(rs.Namespace, owner.Name)]; !ok || current == revision {
+			continue
+		}
+		reason := fmt.Sprintf("revision %s of deployment %s scaled to zero", revision, owner.Name)
+		orphans = append(orphans, newOrphan(ReplicaSets, rs.ObjectMeta, reason))
+	}
+	return orphans, nil
+}
+
+func newOrphan(kind Kind, meta metav1.ObjectMeta, reason string) Orphan {
+	return Orphan{
+		Kind:      kind,
+		Namespace: meta.Namespace,
+		Name:      meta.Name,
+		Age:       time.Since(meta.CreationTimestamp.Time),
+		Reason:    reason,
+	}
+}
+
+func key(namespace, name string) string {
+	return namespace + "/" + name
+}
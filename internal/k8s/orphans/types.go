@@ -0,0 +1,42 @@
+package orphans
+
+import (
+	"time"
+)
+
+// Kind is a resource kind checked by the detector
+type Kind string
+
+const (
+	// ConfigMaps not referenced by any pod
+	ConfigMaps Kind = "configmaps"
+	// Secrets not referenced by any pod, service account or ingress
+	Secrets Kind = "secrets"
+	// Services without any ready or not-ready endpoints
+	Services Kind = "services"
+	// PVCs not mounted by any pod
+	PVCs Kind = "pvcs"
+	// ReplicaSets scaled to zero from old deployment revisions
+	ReplicaSets Kind = "replicasets"
+)
+
+// AllKinds lists every kind checked by default
+var AllKinds = []Kind{ConfigMaps, Secrets, Services, PVCs, ReplicaSets}
+
+// Options configures where and what to look for
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+
+	// Kinds limits the check to the given kinds; empty means all kinds
+	Kinds []Kind
+}
+
+// Orphan represents a resource that appears to be unused
+type Orphan struct {
+	Kind      Kind          `json:"kind"`
+	Namespace string        `json:"namespace"`
+	Name      string        `json:"name"`
+	Age       time.Duration `json:"age"`
+	Reason    string        `json:"reason"`
+}
@@ -0,0 +1,91 @@
+package pods
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// evictionPollInterval is how often an evicted pod is checked until it is gone
+const evictionPollInterval = 2 * time.Second
+
+// Evict requests the eviction of a pod through the Eviction API, which refuses it when a
+// PodDisruptionBudget would be violated
+func (s *service) Evict(ctx context.Context, namespace, name string, opts EvictOptions) error {
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return errs.NotFoundf("pod %q not found in namespace %q", name, namespace)
+		}
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	eviction := &policyv1.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Name: name, Namespace: namespace},
+		DeleteOptions: &metav1.DeleteOptions{},
+	}
+	if opts.GracePeriod >= 0 {
+		eviction.DeleteOptions.GracePeriodSeconds = &opts.GracePeriod
+	}
+	if opts.DryRun {
+		eviction.DeleteOptions.DryRun = []string{metav1.DryRunAll}
+	}
+
+	if err := s.clientset.CoreV1().Pods(namespace).EvictV1(ctx, eviction); err != nil {
+		if apierrors.IsTooManyRequests(err) {
+			return s.evictionBlocked(ctx, pod, err)
+		}
+		return fmt.Errorf("failed to evict pod: %w", err)
+	}
+
+	if !opts.Wait || opts.DryRun {
+		return nil
+	}
+	for {
+		current, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		// A pod with the same name but a new UID was recreated by its StatefulSet
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("failed to get pod: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return errs.Timeoutf("timed out waiting for pod %q to terminate", name)
+		case <-time.After(evictionPollInterval):
+		}
+	}
+}
+
+// evictionBlocked explains a refused eviction with the disruption budgets covering the pod
+func (s *service) evictionBlocked(ctx context.Context, pod *corev1.Pod, cause error) error {
+	budgets, err := s.clientset.PolicyV1().PodDisruptionBudgets(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errs.New(errs.Conflict, "cannot evict pod %q: %w", pod.Name, cause)
+	}
+
+	var reasons []string
+	for _, pdb := range budgets.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("PodDisruptionBudget %q allows %d disruptions (%d of %d pods healthy, %d required)",
+			pdb.Name, pdb.Status.DisruptionsAllowed, pdb.Status.CurrentHealthy, pdb.Status.ExpectedPods, pdb.Status.DesiredHealthy))
+	}
+	if len(reasons) == 0 {
+		return errs.New(errs.Conflict, "cannot evict pod %q: %w", pod.Name, cause)
+	}
+	return errs.New(errs.Conflict, "cannot evict pod %q: %s; wait for the other pods to become healthy or use delete to bypass the budget",
+		pod.Name, strings.Join(reasons, "; "))
+}
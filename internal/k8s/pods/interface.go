@@ -1,5 +1,7 @@
 package pods
 
+import "context"
+
 // Service defines the interface for pod operations
 type Service interface {
 	// List returns a list of pods based on the given filters
@@ -25,6 +27,20 @@ type Service interface {
 
 	// AddMetrics adds metrics information to a list of pods
 	AddMetrics(pods []Pod) error
+
+	// ResolveMesh sets which mesh should have injected a sidecar into each pod, from the
+	// injection settings of its namespace
+	ResolveMesh(ctx context.Context, pods []Pod) error
+
+	// StatefulSetPod returns the name of the StatefulSet replica with the given ordinal, or of the
+	// lowest ready ordinal when ordinal is negative
+	StatefulSetPod(namespace, statefulSet string, ordinal int) (string, error)
+
+	// Evict evicts a pod through the Eviction API, respecting PodDisruptionBudgets
+	Evict(ctx context.Context, namespace, name string, opts EvictOptions) error
+
+	// Watch streams pod changes, flagging pods that enter a problem state
+	Watch(ctx context.Context, opts WatchOptions) (<-chan WatchEvent, error)
 }
 
 // NewService creates a new pod service instance
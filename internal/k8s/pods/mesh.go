@@ -0,0 +1,160 @@
+package pods
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Service meshes whose sidecars are detected
+const (
+	MeshIstio   = "istio"
+	MeshLinkerd = "linkerd"
+)
+
+// meshProxies maps the container name of each mesh's proxy to the mesh
+var meshProxies = map[string]string{
+	"istio-proxy":   MeshIstio,
+	"linkerd-proxy": MeshLinkerd,
+}
+
+// Injection settings of a pod or namespace for one mesh
+const (
+	injectionUnset    = ""
+	injectionEnabled  = "enabled"
+	injectionDisabled = "disabled"
+)
+
+// meshInjection holds the sidecar injection settings of a pod or namespace
+type meshInjection struct {
+	istio   string
+	linkerd string
+}
+
+// podSidecar returns the mesh whose proxy runs in a pod, either as a regular container or as
+// a native sidecar init container
+func podSidecar(pod *corev1.Pod) string {
+	for _, c := range pod.Spec.Containers {
+		if mesh, ok := meshProxies[c.Name]; ok {
+			return mesh
+		}
+	}
+	for _, c := range pod.Spec.InitContainers {
+		if mesh, ok := meshProxies[c.Name]; ok {
+			return mesh
+		}
+	}
+	return ""
+}
+
+// podInjection reads the injection settings a pod sets for itself. Pods on the host network
+// are never injected.
+func podInjection(pod *corev1.Pod) meshInjection {
+	if pod.Spec.HostNetwork {
+		return meshInjection{istio: injectionDisabled, linkerd: injectionDisabled}
+	}
+
+	var injection meshInjection
+	// Istio honors the label and the older annotation, the label taking precedence
+	inject, ok := pod.Labels["sidecar.istio.io/inject"]
+	if !ok {
+		inject, ok = pod.Annotations["sidecar.istio.io/inject"]
+	}
+	switch {
+	case ok && inject == "true":
+		injection.istio = injectionEnabled
+	case ok && inject == "false":
+		injection.istio = injectionDisabled
+	case pod.Labels["istio.io/rev"] != "":
+		injection.istio = injectionEnabled
+	}
+	injection.linkerd = linkerdInjection(pod.Annotations)
+	return injection
+}
+
+// namespaceInjection reads the injection settings of a namespace
+func namespaceInjection(ns *corev1.Namespace) meshInjection {
+	var injection meshInjection
+	switch ns.Labels["istio-injection"] {
+	case "enabled":
+		injection.istio = injectionEnabled
+	case "disabled":
+		injection.istio = injectionDisabled
+	default:
+		if ns.Labels["istio.io/rev"] != "" {
+			injection.istio = injectionEnabled
+		}
+	}
+	injection.linkerd = linkerdInjection(ns.Annotations)
+	return injection
+}
+
+// linkerdInjection reads the linkerd.io/inject annotation
+func linkerdInjection(annotations map[string]string) string {
+	switch annotations["linkerd.io/inject"] {
+	case "enabled", "ingress":
+		return injectionEnabled
+	case "disabled":
+		return injectionDisabled
+	}
+	return injectionUnset
+}
+
+// expectedMesh returns the mesh that should have injected a sidecar into a pod, where the
+// pod's own setting overrides its namespace's
+func expectedMesh(pod, ns meshInjection) string {
+	resolve := func(podSetting, nsSetting string) bool {
+		if podSetting != injectionUnset {
+			return podSetting == injectionEnabled
+		}
+		return nsSetting == injectionEnabled
+	}
+	switch {
+	case resolve(pod.istio, ns.istio):
+		return MeshIstio
+	case resolve(pod.linkerd, ns.linkerd):
+		return MeshLinkerd
+	}
+	return ""
+}
+
+// newMeshStatus returns the sidecar state of a pod. Without its namespace only the pod's own
+// injection settings are considered.
+func newMeshStatus(pod *corev1.Pod, ns *corev1.Namespace) MeshStatus {
+	status := MeshStatus{Sidecar: podSidecar(pod), injection: podInjection(pod)}
+	if ns != nil {
+		status.Expected = expectedMesh(status.injection, namespaceInjection(ns))
+	} else {
+		status.Expected = expectedMesh(status.injection, meshInjection{})
+	}
+	return status
+}
+
+// ResolveMesh sets which mesh should have injected a sidecar into each pod, from the injection
+// settings of its namespace. Namespaces that cannot be read are treated as not injected.
+func (s *service) ResolveMesh(ctx context.Context, pods []Pod) error {
+	namespaces := make(map[string]*meshInjection)
+	for i := range pods {
+		name := pods[i].Namespace
+		injection, seen := namespaces[name]
+		if !seen {
+			ns, err := s.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+			switch {
+			case err == nil:
+				nsInjection := namespaceInjection(ns)
+				injection = &nsInjection
+			case apierrors.IsForbidden(err) || apierrors.IsNotFound(err):
+			default:
+				return fmt.Errorf("failed to get namespace %s: %w", name, err)
+			}
+			namespaces[name] = injection
+		}
+		if injection != nil {
+			pods[i].Mesh.Expected = expectedMesh(pods[i].Mesh.injection, *injection)
+		}
+	}
+	return nil
+}
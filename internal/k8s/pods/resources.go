@@ -0,0 +1,41 @@
+package pods
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Requests returns the effective requests of a pod spec, as the scheduler counts them
+func Requests(spec *corev1.PodSpec) corev1.ResourceList {
+	return effectiveResources(spec, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Requests })
+}
+
+// Limits returns the effective limits of a pod spec
+func Limits(spec *corev1.PodSpec) corev1.ResourceList {
+	return effectiveResources(spec, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Limits })
+}
+
+// effectiveResources returns the larger of the sum of the containers of a pod spec and its
+// largest init container, plus the pod overhead
+func effectiveResources(spec *corev1.PodSpec, field func(corev1.ResourceRequirements) corev1.ResourceList) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, c := range spec.Containers {
+		for name, q := range field(c.Resources) {
+			sum := total[name]
+			sum.Add(q)
+			total[name] = sum
+		}
+	}
+	for _, c := range spec.InitContainers {
+		for name, q := range field(c.Resources) {
+			if current, ok := total[name]; !ok || q.Cmp(current) > 0 {
+				total[name] = q.DeepCopy()
+			}
+		}
+	}
+	for name, q := range spec.Overhead {
+		sum := total[name]
+		sum.Add(q)
+		total[name] = sum
+	}
+	return total
+}
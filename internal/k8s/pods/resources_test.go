@@ -0,0 +1,77 @@
+package pods
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// resources returns requirements with the same requests and limits
+func resources(cpu, memory string) corev1.ResourceRequirements {
+	list := corev1.ResourceList{}
+	if cpu != "" {
+		list[corev1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[corev1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return corev1.ResourceRequirements{Requests: list, Limits: list}
+}
+
+func TestRequestsAndLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       corev1.PodSpec
+		wantCPU    string
+		wantMemory string
+	}{
+		{
+			name: "containers are summed",
+			spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Resources: resources("100m", "64Mi")},
+				{Resources: resources("250m", "128Mi")},
+			}},
+			wantCPU:    "350m",
+			wantMemory: "192Mi",
+		},
+		{
+			name: "larger init container wins",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Resources: resources("1", "32Mi")}},
+				Containers:     []corev1.Container{{Resources: resources("100m", "64Mi")}},
+			},
+			wantCPU:    "1",
+			wantMemory: "64Mi",
+		},
+		{
+			name: "init container without the resource",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Resources: resources("", "1Gi")}},
+				Containers:     []corev1.Container{{Resources: resources("100m", "")}},
+			},
+			wantCPU:    "100m",
+			wantMemory: "1Gi",
+		},
+		{
+			name: "overhead is added",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Resources: resources("100m", "64Mi")}},
+				Overhead:   resources("50m", "16Mi").Requests,
+			},
+			wantCPU:    "150m",
+			wantMemory: "80Mi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, list := range []corev1.ResourceList{Requests(&tt.spec), Limits(&tt.spec)} {
+				cpu, memory := list[corev1.ResourceCPU], list[corev1.ResourceMemory]
+				assert.Equal(t, 0, cpu.Cmp(resource.MustParse(tt.wantCPU)), "cpu %s", cpu.String())
+				assert.Equal(t, 0, memory.Cmp(resource.MustParse(tt.wantMemory)), "memory %s", memory.String())
+			}
+		})
+	}
+}
@@ -4,16 +4,24 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"k8stool/internal/k8s/errs"
+
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/remotecommand"
+	watchtools "k8s.io/client-go/tools/watch"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
@@ -77,8 +85,9 @@ func (s *service) List(namespace string, allNamespaces bool, selector string, st
 		// Add container information
 		for _, c := range p.Spec.Containers {
 			container := ContainerInfo{
-				Name:  c.Name,
-				Image: c.Image,
+				Name:    c.Name,
+				Image:   c.Image,
+				ImageID: getContainerImageID(&p, c.Name),
 			}
 
 			// Add container ports
@@ -94,6 +103,8 @@ func (s *service) List(namespace string, allNamespaces bool, selector string, st
 
 			pod.Containers = append(pod.Containers, container)
 		}
+		pod.InitContainers = initContainerInfo(p.Spec.InitContainers)
+		pod.Mesh = newMeshStatus(&p, nil)
 
 		pods = append(pods, pod)
 	}
@@ -140,6 +151,8 @@ func (s *service) Get(namespace, name string) (*Pod, error) {
 
 		pod.Containers = append(pod.Containers, container)
 	}
+	pod.InitContainers = initContainerInfo(p.Spec.InitContainers)
+	pod.Mesh = newMeshStatus(p, nil)
 
 	return pod, nil
 }
@@ -198,7 +211,18 @@ func (s *service) Describe(namespace, name string) (*PodDetails, error) {
 		Labels:         pod.Labels,
 		Annotations:    pod.Annotations,
 		NodeSelector:   pod.Spec.NodeSelector,
+
+		Affinity:                  pod.Spec.Affinity,
+		TopologySpreadConstraints: pod.Spec.TopologySpreadConstraints,
+	}
+
+	// The namespace decides about injection unless the pod overrides it; without access to
+	// the namespace only the sidecar itself is reported
+	ns, err := s.clientset.CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+	if err != nil {
+		ns = nil
 	}
+	details.Mesh = newMeshStatus(pod, ns)
 
 	// Add IPs
 	for _, ip := range pod.Status.PodIPs {
@@ -214,6 +238,7 @@ func (s *service) Describe(namespace, name string) (*PodDetails, error) {
 			ImageID:      getContainerImageID(pod, c.Name),
 			Ports:        make([]ContainerPort, 0),
 			State:        getContainerState(pod, c.Name),
+			LastState:    getContainerLastState(pod, c.Name),
 			Ready:        isContainerReady(pod, c.Name),
 			RestartCount: getContainerRestartCount(pod, c.Name),
 		}
@@ -241,27 +266,10 @@ func (s *service) Describe(namespace, name string) (*PodDetails, error) {
 			},
 		}
 
-		// Add readiness probe
-		if c.ReadinessProbe != nil {
-			probe := &Probe{
-				Delay:            time.Duration(c.ReadinessProbe.InitialDelaySeconds) * time.Second,
-				Timeout:          time.Duration(c.ReadinessProbe.TimeoutSeconds) * time.Second,
-				Period:           time.Duration(c.ReadinessProbe.PeriodSeconds) * time.Second,
-				SuccessThreshold: c.ReadinessProbe.SuccessThreshold,
-				FailureThreshold: c.ReadinessProbe.FailureThreshold,
-			}
-			if c.ReadinessProbe.TCPSocket != nil {
-				probe.Type = "tcp-socket"
-				probe.Port = c.ReadinessProbe.TCPSocket.Port.IntVal
-			} else if c.ReadinessProbe.HTTPGet != nil {
-				probe.Type = "http-get"
-				probe.Port = c.ReadinessProbe.HTTPGet.Port.IntVal
-				probe.Path = c.ReadinessProbe.HTTPGet.Path
-			} else if c.ReadinessProbe.Exec != nil {
-				probe.Type = "exec"
-			}
-			container.ReadinessProbe = probe
-		}
+		// Add probes
+		container.ReadinessProbe = convertProbe(c.ReadinessProbe)
+		container.LivenessProbe = convertProbe(c.LivenessProbe)
+		container.StartupProbe = convertProbe(c.StartupProbe)
 
 		// Add environment variables
 		for _, env := range c.EnvFrom {
@@ -316,12 +324,31 @@ func (s *service) Describe(namespace, name string) (*PodDetails, error) {
 	// Add conditions
 	for _, c := range pod.Status.Conditions {
 		condition := PodCondition{
-			Type:   string(c.Type),
-			Status: string(c.Status),
+			Type:               string(c.Type),
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime.Time,
+			Custom:             !standardPodConditions[c.Type],
 		}
 		details.Conditions = append(details.Conditions, condition)
 	}
 
+	// Add readiness gates with the state of their conditions
+	for _, gate := range pod.Spec.ReadinessGates {
+		readinessGate := ReadinessGate{ConditionType: string(gate.ConditionType)}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == gate.ConditionType {
+				readinessGate.Status = string(c.Status)
+				readinessGate.Reason = c.Reason
+				readinessGate.Message = c.Message
+				readinessGate.LastTransitionTime = c.LastTransitionTime.Time
+				break
+			}
+		}
+		details.ReadinessGates = append(details.ReadinessGates, readinessGate)
+	}
+
 	// Add volumes
 	for _, v := range pod.Spec.Volumes {
 		volume := VolumeInfo{
@@ -451,11 +478,71 @@ func (s *service) Exec(namespace, name, container string, opts ExecOptions) erro
 	}
 
 	return exec.StreamWithContext(context.Background(), remotecommand.StreamOptions{
-		Stdin:  opts.Stdin,
-		Stdout: opts.Stdout,
-		Stderr: opts.Stderr,
-		Tty:    opts.TTY,
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Stderr:            opts.Stderr,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: opts.SizeQueue,
+	})
+}
+
+// StatefulSetPod returns the name of the StatefulSet replica with the given ordinal, or of the
+// lowest ready ordinal when ordinal is negative
+func (s *service) StatefulSetPod(namespace, statefulSet string, ordinal int) (string, error) {
+	sts, err := s.clientset.AppsV1().StatefulSets(namespace).Get(context.Background(), statefulSet, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", errs.NotFoundf("statefulset %q not found in namespace %q", statefulSet, namespace)
+		}
+		return "", fmt.Errorf("failed to get statefulset: %w", err)
+	}
+
+	if ordinal >= 0 {
+		name := fmt.Sprintf("%s-%d", sts.Name, ordinal)
+		pod, err := s.clientset.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", errs.NotFoundf("statefulset %q has no replica with ordinal %d", statefulSet, ordinal)
+			}
+			return "", fmt.Errorf("failed to get pod: %w", err)
+		}
+		if !isOwnedBy(pod, sts.UID) {
+			return "", errs.NotFoundf("pod %q is not managed by statefulset %q", name, statefulSet)
+		}
+		return name, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid statefulset selector: %w", err)
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: selector.String(),
 	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// Pick by ordinal rather than list order, so the same replica is chosen every time
+	lowest := -1
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isOwnedBy(pod, sts.UID) || !IsReady(pod) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(pod.Name, sts.Name+"-"))
+		if err != nil || n < 0 {
+			continue
+		}
+		if lowest < 0 || n < lowest {
+			lowest = n
+		}
+	}
+	if lowest < 0 {
+		return "", errs.NotFoundf("statefulset %q has no ready replicas", statefulSet)
+	}
+
+	return fmt.Sprintf("%s-%d", sts.Name, lowest), nil
 }
 
 // AddMetrics adds metrics information to a list of pods
@@ -624,6 +711,50 @@ func getContainerState(pod *corev1.Pod, containerName string) ContainerState {
 	return ContainerState{}
 }
 
+// getContainerLastState returns the last termination state of a container, if any
+func getContainerLastState(pod *corev1.Pod, containerName string) ContainerState {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == containerName && cs.LastTerminationState.Terminated != nil {
+			return ContainerState{
+				Status:   "Terminated",
+				Started:  cs.LastTerminationState.Terminated.StartedAt.Time,
+				Reason:   cs.LastTerminationState.Terminated.Reason,
+				ExitCode: cs.LastTerminationState.Terminated.ExitCode,
+				Message:  cs.LastTerminationState.Terminated.Message,
+			}
+		}
+	}
+	return ContainerState{}
+}
+
+// convertProbe converts a container probe into its summary form
+func convertProbe(p *corev1.Probe) *Probe {
+	if p == nil {
+		return nil
+	}
+	probe := &Probe{
+		Delay:            time.Duration(p.InitialDelaySeconds) * time.Second,
+		Timeout:          time.Duration(p.TimeoutSeconds) * time.Second,
+		Period:           time.Duration(p.PeriodSeconds) * time.Second,
+		SuccessThreshold: p.SuccessThreshold,
+		FailureThreshold: p.FailureThreshold,
+	}
+	if p.TCPSocket != nil {
+		probe.Type = "tcp-socket"
+		probe.Port = p.TCPSocket.Port.IntVal
+	} else if p.HTTPGet != nil {
+		probe.Type = "http-get"
+		probe.Port = p.HTTPGet.Port.IntVal
+		probe.Path = p.HTTPGet.Path
+	} else if p.Exec != nil {
+		probe.Type = "exec"
+	} else if p.GRPC != nil {
+		probe.Type = "grpc"
+		probe.Port = p.GRPC.Port
+	}
+	return probe
+}
+
 func isContainerReady(pod *corev1.Pod, containerName string) bool {
 	for _, cs := range pod.Status.ContainerStatuses {
 		if cs.Name == containerName {
@@ -642,6 +773,45 @@ func getContainerRestartCount(pod *corev1.Pod, containerName string) int32 {
 	return 0
 }
 
+// initContainerInfo returns the names and images of init containers
+func initContainerInfo(containers []corev1.Container) []ContainerInfo {
+	var infos []ContainerInfo
+	for _, c := range containers {
+		infos = append(infos, ContainerInfo{Name: c.Name, Image: c.Image})
+	}
+	return infos
+}
+
+// isOwnedBy reports whether the pod is controlled by the object with the given UID
+func isOwnedBy(pod *corev1.Pod, uid types.UID) bool {
+	ref := metav1.GetControllerOf(pod)
+	return ref != nil && ref.UID == uid
+}
+
+// standardPodConditions are the conditions set by the kubelet and scheduler; any other
+// condition is set by a controller, usually for a readiness gate
+var standardPodConditions = map[corev1.PodConditionType]bool{
+	corev1.PodScheduled:              true,
+	corev1.PodInitialized:            true,
+	corev1.ContainersReady:           true,
+	corev1.PodReady:                  true,
+	corev1.PodReadyToStartContainers: true,
+	corev1.DisruptionTarget:          true,
+	// Set by the kubelet during in-place resizes on clusters newer than the client library
+	"PodResizePending":    true,
+	"PodResizeInProgress": true,
+}
+
+// IsReady reports whether the pod's Ready condition is true
+func IsReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func getControllerRef(pod *corev1.Pod) string {
 	if ref := metav1.GetControllerOf(pod); ref != nil {
 		return fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
@@ -673,3 +843,197 @@ func (s *service) getEvents(namespace, name string) ([]Event, error) {
 
 	return podEvents, nil
 }
+
+// podState is what Watch remembers of a pod to detect when it enters a problem state
+type podState struct {
+	ready  bool
+	alerts map[AlertType]bool
+}
+
+// Watch streams pod changes, flagging pods that enter a problem state
+func (s *service) Watch(ctx context.Context, opts WatchOptions) (<-chan WatchEvent, error) {
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+	pods := s.clientset.CoreV1().Pods(namespace)
+	listOptions := metav1.ListOptions{LabelSelector: opts.Selector}
+
+	podList, err := pods.List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	events := make(chan WatchEvent, 100)
+	go func() {
+		defer close(events)
+
+		states := make(map[string]*podState)
+		send := func(eventType watch.EventType, pod *corev1.Pod, initial bool) bool {
+			key := pod.Namespace + "/" + pod.Name
+			event := WatchEvent{Type: string(eventType), Pod: FromCorePod(pod), Initial: initial}
+			if eventType == watch.Deleted {
+				delete(states, key)
+			} else {
+				event.Alerts = newAlerts(states[key], pod)
+				states[key] = currentState(pod)
+			}
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for i := range podList.Items {
+			if !send(watch.Added, &podList.Items[i], true) {
+				return
+			}
+		}
+
+		resourceVersion := podList.ResourceVersion
+		for ctx.Err() == nil {
+			watcher, err := watchtools.NewRetryWatcher(resourceVersion, &cache.ListWatch{
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					options.LabelSelector = opts.Selector
+					return pods.Watch(ctx, options)
+				},
+			})
+			if err != nil {
+				return
+			}
+
+			expired := false
+			for !expired {
+				var event watch.Event
+				var ok bool
+				select {
+				case <-ctx.Done():
+					watcher.Stop()
+					return
+				case event, ok = <-watcher.ResultChan():
+				}
+				if !ok || event.Type == watch.Error {
+					// The resource version is too old to resume from, so start over from a fresh list
+					expired = true
+					continue
+				}
+				pod, isPod := event.Object.(*corev1.Pod)
+				if !isPod {
+					continue
+				}
+				resourceVersion = pod.ResourceVersion
+				if !send(event.Type, pod, false) {
+					watcher.Stop()
+					return
+				}
+			}
+			watcher.Stop()
+
+			podList, err := pods.List(ctx, listOptions)
+			if err != nil {
+				return
+			}
+			for i := range podList.Items {
+				if !send(watch.Modified, &podList.Items[i], false) {
+					return
+				}
+			}
+			resourceVersion = podList.ResourceVersion
+		}
+	}()
+
+	return events, nil
+}
+
+// FromCorePod converts a core pod to the summary reported by Watch
+func FromCorePod(p *corev1.Pod) Pod {
+	pod := Pod{
+		Name:      p.Name,
+		Namespace: p.Namespace,
+		Ready:     getPodReady(p.Status),
+		Status:    string(p.Status.Phase),
+		Restarts:  getPodRestarts(p.Status),
+		Age:       time.Since(p.CreationTimestamp.Time),
+		IP:        p.Status.PodIP,
+		Node:      p.Spec.NodeName,
+		Labels:    p.Labels,
+	}
+	if owner := metav1.GetControllerOf(p); owner != nil {
+		pod.Controller = owner.Kind
+		pod.ControllerName = owner.Name
+	}
+	return pod
+}
+
+// currentState returns whether a pod is ready and the problem states it is in
+func currentState(pod *corev1.Pod) *podState {
+	state := &podState{alerts: make(map[AlertType]bool)}
+	for _, alert := range podAlerts(pod) {
+		state.alerts[alert.Type] = true
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			state.ready = c.Status == corev1.ConditionTrue
+		}
+	}
+	return state
+}
+
+// newAlerts returns the problem states a pod is in that it was not in before
+func newAlerts(previous *podState, pod *corev1.Pod) []Alert {
+	var alerts []Alert
+	for _, alert := range podAlerts(pod) {
+		if previous != nil && previous.alerts[alert.Type] {
+			continue
+		}
+		// Pods are not ready while starting, so only losing readiness is a problem
+		if alert.Type == AlertNotReady && (previous == nil || !previous.ready) {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
+
+// podAlerts returns every problem state a pod is currently in
+func podAlerts(pod *corev1.Pod) []Alert {
+	var alerts []Alert
+
+	var crashing []string
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason == string(AlertCrashLoop) {
+			crashing = append(crashing, fmt.Sprintf("%s (%d restarts)", cs.Name, cs.RestartCount))
+		}
+	}
+	if len(crashing) > 0 {
+		alerts = append(alerts, Alert{AlertCrashLoop, "container " + strings.Join(crashing, ", ") + " is crash looping"})
+	}
+
+	switch {
+	case pod.Status.Phase == corev1.PodFailed:
+		message := "pod failed"
+		if pod.Status.Reason != "" {
+			message += ": " + pod.Status.Reason
+		}
+		if pod.Status.Message != "" {
+			message += ": " + pod.Status.Message
+		}
+		alerts = append(alerts, Alert{AlertFailed, message})
+	case pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil:
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status != corev1.ConditionTrue {
+				message := "pod is no longer ready"
+				if c.Message != "" {
+					message += ": " + c.Message
+				}
+				alerts = append(alerts, Alert{AlertNotReady, message})
+			}
+		}
+	}
+
+	return alerts
+}
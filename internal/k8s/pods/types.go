@@ -3,10 +3,16 @@ package pods
 import (
 	"io"
 	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
 )
 
 // Pod represents a Kubernetes pod with essential information
 type Pod struct {
+	// Context is the kubeconfig context the pod was read from, when several were queried
+	Context string `json:",omitempty"`
+
 	Name           string
 	Namespace      string
 	Ready          string
@@ -20,6 +26,27 @@ type Pod struct {
 	ControllerName string
 	Metrics        *PodMetrics
 	Containers     []ContainerInfo
+	InitContainers []ContainerInfo
+	Mesh           MeshStatus
+}
+
+// MeshStatus is the service mesh sidecar state of a pod
+type MeshStatus struct {
+	// Sidecar is the mesh whose proxy runs in the pod, empty when there is none
+	Sidecar string
+
+	// Expected is the mesh that should have injected a sidecar, from the injection settings
+	// of the pod and its namespace
+	Expected string
+
+	// injection is the pod's own injection settings, resolved against its namespace later
+	injection meshInjection
+}
+
+// Missing reports whether a mesh should have injected a sidecar that the pod lacks, which
+// leaves the pod outside the mesh
+func (m MeshStatus) Missing() bool {
+	return m.Expected != "" && m.Sidecar == ""
 }
 
 // PodDetails contains detailed information about a pod
@@ -48,12 +75,22 @@ type PodDetails struct {
 	// Pod conditions
 	Conditions []PodCondition
 
+	// Readiness gates
+	ReadinessGates []ReadinessGate
+
 	// Volume information
 	Volumes []VolumeInfo
 
 	// Tolerations
 	Tolerations []Toleration
 
+	// Service mesh sidecar
+	Mesh MeshStatus
+
+	// Scheduling constraints
+	Affinity                  *corev1.Affinity
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+
 	// Events
 	Events []Event
 }
@@ -66,11 +103,14 @@ type ContainerInfo struct {
 	ImageID        string
 	Ports          []ContainerPort
 	State          ContainerState
+	LastState      ContainerState
 	Ready          bool
 	RestartCount   int32
 	Resources      Resources
 	VolumeMounts   []VolumeMount
 	ReadinessProbe *Probe
+	LivenessProbe  *Probe
+	StartupProbe   *Probe
 	EnvFrom        []EnvFromSource
 	Env            []EnvVar
 }
@@ -154,6 +194,8 @@ type ExecOptions struct {
 	Stdin   io.Reader
 	Stdout  io.Writer
 	Stderr  io.Writer
+	// SizeQueue reports terminal size changes of TTY sessions
+	SizeQueue remotecommand.TerminalSizeQueue
 }
 
 // ListOptions configures how to list pods
@@ -173,7 +215,7 @@ type ContainerState struct {
 }
 
 type Probe struct {
-	Type             string // tcp-socket, http-get, exec
+	Type             string // tcp-socket, http-get, exec, grpc
 	Port             int32
 	Path             string
 	Delay            time.Duration
@@ -196,8 +238,27 @@ type EnvVar struct {
 }
 
 type PodCondition struct {
-	Type   string
-	Status string
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime time.Time
+
+	// Custom is set for conditions added by controllers, such as readiness gates of a
+	// load balancer or service mesh, rather than by the kubelet and scheduler
+	Custom bool
+}
+
+// ReadinessGate is a condition that must be true, in addition to the containers being ready,
+// for the pod to be ready
+type ReadinessGate struct {
+	ConditionType string
+
+	// Status is empty while no controller has reported the condition
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime time.Time
 }
 
 type VolumeInfo struct {
@@ -216,3 +277,53 @@ type Toleration struct {
 	Effect            string
 	TolerationSeconds *int64
 }
+
+// WatchOptions configures which pods are watched
+type WatchOptions struct {
+	Namespace     string
+	AllNamespaces bool
+	Selector      string
+}
+
+// EvictOptions configures the eviction of a pod
+type EvictOptions struct {
+	// GracePeriod overrides the termination grace period of the pod when not negative
+	GracePeriod int64
+
+	// DryRun asks the API server whether the eviction is allowed without evicting the pod
+	DryRun bool
+
+	// Wait waits until the pod is gone
+	Wait bool
+}
+
+// AlertType names a problem state a pod can enter
+type AlertType string
+
+const (
+	// AlertCrashLoop is raised when a container starts backing off after repeated crashes
+	AlertCrashLoop AlertType = "CrashLoopBackOff"
+	// AlertFailed is raised when a pod terminates in the Failed phase
+	AlertFailed AlertType = "Failed"
+	// AlertNotReady is raised when a running pod that was ready stops being ready
+	AlertNotReady AlertType = "NotReady"
+)
+
+// Alert is a problem state a pod entered
+type Alert struct {
+	Type    AlertType
+	Message string
+}
+
+// WatchEvent is a change of a watched pod
+type WatchEvent struct {
+	// Type is ADDED, MODIFIED or DELETED; pods present when the watch starts are ADDED
+	Type string
+	Pod  Pod
+
+	// Initial is set for the pods listed when the watch starts
+	Initial bool
+
+	// Alerts lists the problem states the pod entered with this change
+	Alerts []Alert
+}
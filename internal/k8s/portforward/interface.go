@@ -23,6 +23,9 @@ type Service interface {
 
 	// GetForwardedPorts returns a list of currently forwarded ports
 	GetForwardedPorts() []ForwardedPort
+
+	// GetStats returns the ports of a port forward with their current traffic counters
+	GetStats(result *PortForwardResult) []ForwardedPort
 }
 
 // NewPortForwardService creates a new port forward service instance
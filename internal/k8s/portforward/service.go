@@ -8,8 +8,12 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
+
+	"k8stool/internal/k8s/errs"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	spdystream "k8s.io/apimachinery/pkg/util/httpstream/spdy"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
@@ -20,6 +24,7 @@ type service struct {
 	clientset *kubernetes.Clientset
 	config    *rest.Config
 	forwards  map[string]*portforward.PortForwarder
+	counters  map[string]*trafficCounter
 	mu        sync.Mutex
 }
 
@@ -29,6 +34,7 @@ func newService(clientset *kubernetes.Clientset, config *rest.Config) Service {
 		clientset: clientset,
 		config:    config,
 		forwards:  make(map[string]*portforward.PortForwarder),
+		counters:  make(map[string]*trafficCounter),
 	}
 }
 
@@ -69,7 +75,7 @@ func (s *service) ForwardServicePort(namespace, service string, options PortForw
 	}
 
 	if len(pods.Items) == 0 {
-		return nil, fmt.Errorf("no pods found for service %s", service)
+		return nil, errs.NotFoundf("no pods found for service %s", service)
 	}
 
 	// Forward to the first available pod
@@ -92,6 +98,7 @@ func (s *service) StopForwarding(result *PortForwardResult) error {
 			forwarder.Close()
 			delete(s.forwards, key)
 		}
+		delete(s.counters, key)
 		if port.Listener != nil {
 			port.Listener.Close()
 		}
@@ -150,15 +157,45 @@ func (s *service) GetForwardedPorts() []ForwardedPort {
 	return ports
 }
 
+// GetStats returns the ports of a port forward with their current traffic counters
+func (s *service) GetStats(result *PortForwardResult) []ForwardedPort {
+	if result == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ports := make([]ForwardedPort, 0, len(result.Ports))
+	for _, port := range result.Ports {
+		if counter, ok := s.counters[fmt.Sprintf("%s:%d", port.Address, port.Local)]; ok {
+			port.Stats = counter.snapshot()
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
 // Helper functions
 
 func (s *service) forwardPorts(reqURL *url.URL, options PortForwardOptions) (*PortForwardResult, error) {
-	transport, upgrader, err := spdy.RoundTripperFor(s.config)
+	transport, upgrader, err := s.roundTripper(options.KeepAlive)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create round tripper: %w", err)
 	}
 
-	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", reqURL)
+	// Count traffic per remote port
+	counters := make(map[uint16]*trafficCounter)
+	for _, mapping := range options.Ports {
+		if _, ok := counters[mapping.Remote]; !ok {
+			counters[mapping.Remote] = &trafficCounter{}
+			counters[mapping.Remote].touch()
+		}
+	}
+	dialer := &countingDialer{
+		Dialer:   spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", reqURL),
+		counters: counters,
+	}
 
 	var ports []string
 	for _, mapping := range options.Ports {
@@ -182,6 +219,7 @@ func (s *service) forwardPorts(reqURL *url.URL, options PortForwardOptions) (*Po
 		key := fmt.Sprintf("%s:%d", mapping.Address, mapping.Local)
 		s.mu.Lock()
 		s.forwards[key] = fw
+		s.counters[key] = counters[mapping.Remote]
 		s.mu.Unlock()
 
 		forwardedPorts = append(forwardedPorts, ForwardedPort{
@@ -201,7 +239,47 @@ func (s *service) forwardPorts(reqURL *url.URL, options PortForwardOptions) (*Po
 
 	<-options.ReadyChannel
 
-	return &PortForwardResult{
+	result := &PortForwardResult{
 		Ports: forwardedPorts,
-	}, nil
+	}
+	if options.IdleTimeout > 0 {
+		idle := make(chan struct{})
+		result.Idle = idle
+		go watchIdle(counters, options.IdleTimeout, idle, options.StopChannel)
+	}
+
+	return result, nil
+}
+
+// roundTripper returns the SPDY round tripper for the API server, sending keepalive pings at
+// the given interval. It matches spdy.RoundTripperFor, which always pings every five seconds.
+func (s *service) roundTripper(keepAlive time.Duration) (http.RoundTripper, spdy.Upgrader, error) {
+	switch {
+	case keepAlive == 0:
+		keepAlive = DefaultKeepAlive
+	case keepAlive < 0:
+		keepAlive = 0
+	}
+
+	tlsConfig, err := rest.TLSConfigFor(s.config)
+	if err != nil {
+		return nil, nil, err
+	}
+	proxy := http.ProxyFromEnvironment
+	if s.config.Proxy != nil {
+		proxy = s.config.Proxy
+	}
+	upgrader, err := spdystream.NewRoundTripperWithConfig(spdystream.RoundTripperConfig{
+		TLS:        tlsConfig,
+		Proxier:    proxy,
+		PingPeriod: keepAlive,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapper, err := rest.HTTPWrappersForConfig(s.config, upgrader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return wrapper, upgrader, nil
 }
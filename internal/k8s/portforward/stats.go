@@ -0,0 +1,148 @@
+package portforward
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// trafficCounter accumulates the traffic of one forwarded port
+type trafficCounter struct {
+	bytesIn     atomic.Int64
+	bytesOut    atomic.Int64
+	active      atomic.Int64
+	connections atomic.Int64
+	lastActive  atomic.Int64
+}
+
+// touch records activity on the port
+func (c *trafficCounter) touch() {
+	c.lastActive.Store(time.Now().UnixNano())
+}
+
+// idleSince returns the time of the last activity on any of the counters
+func idleSince(counters map[uint16]*trafficCounter) time.Time {
+	var last int64
+	for _, c := range counters {
+		if t := c.lastActive.Load(); t > last {
+			last = t
+		}
+	}
+	return time.Unix(0, last)
+}
+
+// watchIdle closes idle once no counter has seen activity for timeout, or returns when stop is closed
+func watchIdle(counters map[uint16]*trafficCounter, timeout time.Duration, idle chan<- struct{}, stop <-chan struct{}) {
+	interval := timeout / 10
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if time.Since(idleSince(counters)) >= timeout {
+				close(idle)
+				return
+			}
+		}
+	}
+}
+
+// snapshot returns the current counter values
+func (c *trafficCounter) snapshot() ForwardStats {
+	return ForwardStats{
+		BytesIn:           c.bytesIn.Load(),
+		BytesOut:          c.bytesOut.Load(),
+		ActiveConnections: c.active.Load(),
+		TotalConnections:  c.connections.Load(),
+	}
+}
+
+// countingDialer wraps the SPDY dialer so every data stream the forwarder opens is counted
+type countingDialer struct {
+	httpstream.Dialer
+	counters map[uint16]*trafficCounter
+}
+
+func (d *countingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.Dialer.Dial(protocols...)
+	if err != nil {
+		return nil, protocol, err
+	}
+	return &countingConnection{Connection: conn, counters: d.counters}, protocol, nil
+}
+
+// countingConnection hands out counting data streams, keyed by remote port. Each local
+// connection gets its own data stream, created when it is accepted and removed when it closes.
+type countingConnection struct {
+	httpstream.Connection
+	counters map[uint16]*trafficCounter
+}
+
+func (c *countingConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	stream, err := c.Connection.CreateStream(headers)
+	if err != nil || headers.Get(corev1.StreamType) != corev1.StreamTypeData {
+		return stream, err
+	}
+	port, err := strconv.ParseUint(headers.Get(corev1.PortHeader), 10, 16)
+	if err != nil {
+		return stream, nil
+	}
+	counter, ok := c.counters[uint16(port)]
+	if !ok {
+		return stream, nil
+	}
+	counter.connections.Add(1)
+	counter.active.Add(1)
+	counter.touch()
+	return &countingStream{Stream: stream, counter: counter}, nil
+}
+
+func (c *countingConnection) RemoveStreams(streams ...httpstream.Stream) {
+	unwrapped := make([]httpstream.Stream, 0, len(streams))
+	for _, stream := range streams {
+		if cs, ok := stream.(*countingStream); ok {
+			if cs.removed.CompareAndSwap(false, true) {
+				cs.counter.active.Add(-1)
+			}
+			stream = cs.Stream
+		}
+		unwrapped = append(unwrapped, stream)
+	}
+	c.Connection.RemoveStreams(unwrapped...)
+}
+
+// countingStream counts the bytes of one forwarded connection. Reads come from the pod,
+// writes go to it.
+type countingStream struct {
+	httpstream.Stream
+	counter *trafficCounter
+	removed atomic.Bool
+}
+
+func (s *countingStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	if n > 0 {
+		s.counter.bytesIn.Add(int64(n))
+		s.counter.touch()
+	}
+	return n, err
+}
+
+func (s *countingStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	if n > 0 {
+		s.counter.bytesOut.Add(int64(n))
+		s.counter.touch()
+	}
+	return n, err
+}
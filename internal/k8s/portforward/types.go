@@ -3,8 +3,12 @@ package portforward
 import (
 	"io"
 	"net"
+	"time"
 )
 
+// DefaultKeepAlive is the interval of keepalive pings when PortForwardOptions.KeepAlive is zero
+const DefaultKeepAlive = 5 * time.Second
+
 // PortForwardOptions represents options for port forwarding
 type PortForwardOptions struct {
 	// Ports is a list of port mappings (local:remote)
@@ -18,6 +22,15 @@ type PortForwardOptions struct {
 
 	// Streams configures the standard streams
 	Streams Streams `json:"-"`
+
+	// IdleTimeout closes the Idle channel of the result once no data has moved for this
+	// long. Zero disables the timeout.
+	IdleTimeout time.Duration `json:"idleTimeout,omitempty"`
+
+	// KeepAlive is the interval of pings sent on the connection to the API server, which
+	// stops proxies from dropping it while idle. Zero uses DefaultKeepAlive, a negative
+	// value disables pings.
+	KeepAlive time.Duration `json:"keepAlive,omitempty"`
 }
 
 // PortMapping represents a port forwarding mapping
@@ -60,6 +73,24 @@ type ForwardedPort struct {
 
 	// Listener is the local port listener
 	Listener net.Listener
+
+	// Stats is the traffic seen on the port, filled in by GetStats
+	Stats ForwardStats
+}
+
+// ForwardStats holds the traffic counters of a forwarded port
+type ForwardStats struct {
+	// BytesIn is the number of bytes received from the pod
+	BytesIn int64 `json:"bytesIn"`
+
+	// BytesOut is the number of bytes sent to the pod
+	BytesOut int64 `json:"bytesOut"`
+
+	// ActiveConnections is the number of local connections currently open
+	ActiveConnections int64 `json:"activeConnections"`
+
+	// TotalConnections is the number of local connections accepted so far
+	TotalConnections int64 `json:"totalConnections"`
 }
 
 // PortForwardResult represents the result of a port forward operation
@@ -69,6 +100,9 @@ type PortForwardResult struct {
 
 	// Error is any error that occurred during port forwarding
 	Error error `json:"error,omitempty"`
+
+	// Idle is closed when the idle timeout of the port forward expires
+	Idle <-chan struct{} `json:"-"`
 }
 
 // PortForwardDirection represents the direction of port forwarding
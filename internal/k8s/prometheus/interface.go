@@ -0,0 +1,25 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for Prometheus queries
+type Service interface {
+	// Query runs an instant query
+	Query(ctx context.Context, opts QueryOptions) (*QueryResult, error)
+
+	// HTTPPanel queries the request rate, error rate and latency of a workload
+	HTTPPanel(ctx context.Context, opts PanelOptions) (*HTTPPanel, error)
+}
+
+// NewPrometheusService creates a new Prometheus service instance
+func NewPrometheusService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
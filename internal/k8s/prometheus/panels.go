@@ -0,0 +1,153 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+
+	"k8stool/internal/k8s/errs"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultWindow is the range rates are computed over when none is given
+const defaultWindow = "5m"
+
+// window matches a PromQL duration such as 30s, 5m or 1h30m
+var window = regexp.MustCompile(`^([0-9]+(ms|s|m|h|d|w|y))+$`)
+
+// podPatterns match the names of the pods of a workload after its name, so pods replaced
+// during the window are still counted. A deployment's pods carry the template hash and a
+// suffix, which keeps web from matching the pods of web-api.
+var podPatterns = map[string]string{
+	"deployment":  "-[a-z0-9]+-[a-z0-9]+",
+	"statefulset": "-[0-9]+",
+	"daemonset":   "-[a-z0-9]+",
+}
+
+// latencyQuantiles are the latency percentiles of a panel
+var latencyQuantiles = []struct {
+	name     string
+	quantile float64
+}{
+	{"latency p50", 0.5},
+	{"latency p95", 0.95},
+	{"latency p99", 0.99},
+}
+
+// HTTPPanel queries the request rate, error rate and latency of a workload. With the auto
+// profile the istio metrics are tried first, since sidecars report them for every workload
+// of the mesh, then the metrics of the Prometheus client libraries.
+func (s *service) HTTPPanel(ctx context.Context, opts PanelOptions) (*HTTPPanel, error) {
+	if opts.Window == "" {
+		opts.Window = defaultWindow
+	}
+	if !window.MatchString(opts.Window) {
+		return nil, errs.Validationf("invalid window %q: must be a duration such as 30s, 5m or 1h", opts.Window)
+	}
+	if opts.Profile == "" {
+		opts.Profile = ProfileAuto
+	}
+	profiles := []Profile{opts.Profile}
+	switch opts.Profile {
+	case ProfileAuto:
+		profiles = []Profile{ProfileIstio, ProfileHTTP}
+	case ProfileIstio, ProfileHTTP:
+	default:
+		return nil, errs.Validationf("invalid profile %q: must be auto, istio or http", opts.Profile)
+	}
+	if err := s.checkWorkload(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	var panel *HTTPPanel
+	for _, profile := range profiles {
+		panel = &HTTPPanel{
+			Namespace: opts.Namespace,
+			Kind:      opts.Kind,
+			Name:      opts.Name,
+			Window:    opts.Window,
+			Profile:   profile,
+			Rows:      panelRows(profile, opts),
+		}
+		hasData := false
+		for i := range panel.Rows {
+			result, err := s.Query(ctx, QueryOptions{Source: opts.Source, Expr: panel.Rows[i].Expr})
+			if err != nil {
+				return nil, fmt.Errorf("failed to query %s: %w", panel.Rows[i].Name, err)
+			}
+			// Quantiles and ratios of a workload without traffic are NaN, which is no data
+			if len(result.Samples) > 0 && !math.IsNaN(result.Samples[0].Float()) {
+				value := result.Samples[0].Float()
+				panel.Rows[i].Value = &value
+				hasData = true
+			}
+		}
+		if hasData {
+			break
+		}
+	}
+	return panel, nil
+}
+
+// checkWorkload checks that the workload of a panel exists, so a typo is not reported as a
+// workload without traffic
+func (s *service) checkWorkload(ctx context.Context, opts PanelOptions) error {
+	apps := s.clientset.AppsV1()
+	var err error
+	switch opts.Kind {
+	case "deployment":
+		_, err = apps.Deployments(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+	case "statefulset":
+		_, err = apps.StatefulSets(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+	case "daemonset":
+		_, err = apps.DaemonSets(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+	default:
+		return errs.Validationf("unsupported kind %q: must be deployment, statefulset or daemonset", opts.Kind)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return errs.NotFoundf("%s %q not found in namespace %q", opts.Kind, opts.Name, opts.Namespace)
+		}
+		return fmt.Errorf("failed to get %s: %w", opts.Kind, err)
+	}
+	return nil
+}
+
+// panelRows builds the queries of a panel. Label values are quoted with Go escaping, which
+// PromQL strings share.
+func panelRows(profile Profile, opts PanelOptions) []PanelRow {
+	var requests, errors, bucket, scale string
+	switch profile {
+	case ProfileIstio:
+		selector := fmt.Sprintf(`reporter="destination",destination_workload_namespace=%q,destination_workload=%q`, opts.Namespace, opts.Name)
+		requests = fmt.Sprintf(`istio_requests_total{%s}`, selector)
+		errors = fmt.Sprintf(`istio_requests_total{%s,response_code=~"5.."}`, selector)
+		bucket = fmt.Sprintf(`istio_request_duration_milliseconds_bucket{%s}`, selector)
+		scale = " / 1000"
+	default:
+		selector := fmt.Sprintf(`namespace=%q,pod=~%q`, opts.Namespace, regexp.QuoteMeta(opts.Name)+podPatterns[opts.Kind])
+		requests = fmt.Sprintf(`http_requests_total{%s}`, selector)
+		errors = fmt.Sprintf(`http_requests_total{%s,code=~"5.."}`, selector)
+		bucket = fmt.Sprintf(`http_request_duration_seconds_bucket{%s}`, selector)
+	}
+
+	rate := func(series string) string {
+		return fmt.Sprintf("sum(rate(%s[%s]))", series, opts.Window)
+	}
+	rows := []PanelRow{
+		{Name: "requests", Unit: "req/s", Expr: rate(requests)},
+		// Without errors the numerator has no series, so it falls back to 0
+		{Name: "errors", Unit: "ratio", Expr: fmt.Sprintf("(%s or vector(0)) / %s", rate(errors), rate(requests))},
+	}
+	for _, q := range latencyQuantiles {
+		rows = append(rows, PanelRow{
+			Name: q.name,
+			Unit: "seconds",
+			Expr: fmt.Sprintf("histogram_quantile(%g, sum by (le) (rate(%s[%s])))%s", q.quantile, bucket, opts.Window, scale),
+		})
+	}
+	return rows
+}
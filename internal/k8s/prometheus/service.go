@@ -0,0 +1,229 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// queryPath is the instant query endpoint of the Prometheus HTTP API
+	queryPath = "api/v1/query"
+	// defaultPort is the port of Prometheus services given without one
+	defaultPort = "9090"
+	// maxResponseSize bounds the query responses read, so a query selecting every series of a
+	// large cluster fails instead of exhausting memory
+	maxResponseSize = 64 << 20
+)
+
+type service struct {
+	clientset  *kubernetes.Clientset
+	httpClient *http.Client
+}
+
+// newService creates a new Prometheus service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset:  clientset,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Query runs an instant query
+func (s *service) Query(ctx context.Context, opts QueryOptions) (*QueryResult, error) {
+	if strings.TrimSpace(opts.Expr) == "" {
+		return nil, errs.Validationf("query must not be empty")
+	}
+	params := url.Values{"query": {opts.Expr}}
+	if !opts.Time.IsZero() {
+		params.Set("time", strconv.FormatFloat(float64(opts.Time.UnixMilli())/1000, 'f', 3, 64))
+	}
+
+	body, err := s.get(ctx, opts.Source, queryPath, params)
+	if err != nil {
+		return nil, err
+	}
+	return decodeQuery(opts.Expr, body)
+}
+
+// get calls an endpoint of the Prometheus HTTP API, directly or through the API server proxy.
+// Responses with an error status are returned when they carry an API error, which is more
+// telling than the status.
+func (s *service) get(ctx context.Context, source Source, path string, params url.Values) ([]byte, error) {
+	switch {
+	case source.URL != "":
+		base, err := url.Parse(source.URL)
+		if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+			return nil, errs.Validationf("invalid Prometheus URL %q: must be http(s)://HOST[:PORT][/PATH]", source.URL)
+		}
+		endpoint := base.JoinPath(path)
+		endpoint.RawQuery = params.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query Prometheus: %w", err)
+		}
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query Prometheus: %w", err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Prometheus response: %w", err)
+		}
+		if resp.StatusCode >= http.StatusMultipleChoices && !isAPIResponse(body) {
+			return nil, fmt.Errorf("failed to query Prometheus at %s: %s", source.URL, resp.Status)
+		}
+		return body, nil
+
+	case source.Service != "":
+		namespace, name, port, err := parseService(source.Service)
+		if err != nil {
+			return nil, err
+		}
+		proxyParams := make(map[string]string, len(params))
+		for key := range params {
+			proxyParams[key] = params.Get(key)
+		}
+		body, err := s.clientset.CoreV1().Services(namespace).ProxyGet("", name, port, path, proxyParams).DoRaw(ctx)
+		if err != nil && !isAPIResponse(body) {
+			if apierrors.IsNotFound(err) {
+				return nil, errs.NotFoundf("service %q not found in namespace %q", name, namespace)
+			}
+			return nil, fmt.Errorf("failed to query Prometheus through service %s: %w", source.Service, err)
+		}
+		return body, nil
+	}
+	return nil, errs.Validationf("no Prometheus configured: set a URL or a service")
+}
+
+// parseService splits a service given as NAMESPACE/NAME[:PORT]
+func parseService(ref string) (string, string, string, error) {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" {
+		return "", "", "", errs.Validationf("invalid Prometheus service %q: must be NAMESPACE/NAME[:PORT]", ref)
+	}
+	port := defaultPort
+	if n, p, found := strings.Cut(name, ":"); found {
+		if n == "" || p == "" {
+			return "", "", "", errs.Validationf("invalid Prometheus service %q: must be NAMESPACE/NAME[:PORT]", ref)
+		}
+		name, port = n, p
+	}
+	return namespace, name, port, nil
+}
+
+// apiResponse is the envelope of every Prometheus HTTP API response
+type apiResponse struct {
+	Status    string   `json:"status"`
+	ErrorType string   `json:"errorType"`
+	Error     string   `json:"error"`
+	Warnings  []string `json:"warnings"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// isAPIResponse reports whether a body is a Prometheus API response rather than, say, the
+// error page of a proxy
+func isAPIResponse(body []byte) bool {
+	var resp apiResponse
+	return json.Unmarshal(body, &resp) == nil && resp.Status != ""
+}
+
+// decodeQuery decodes the response of an instant query. Query errors are validation errors,
+// since they are almost always mistakes in the expression.
+func decodeQuery(expr string, body []byte) (*QueryResult, error) {
+	var resp apiResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode Prometheus response: %w", err)
+	}
+	if resp.Status != "success" {
+		if resp.ErrorType == "bad_data" {
+			return nil, errs.Validationf("invalid query: %s", resp.Error)
+		}
+		return nil, fmt.Errorf("query failed: %s: %s", resp.ErrorType, resp.Error)
+	}
+
+	result := &QueryResult{Expr: expr, ResultType: resp.Data.ResultType, Warnings: resp.Warnings}
+	var err error
+	switch resp.Data.ResultType {
+	case "vector":
+		var series []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		}
+		if err = json.Unmarshal(resp.Data.Result, &series); err == nil {
+			for _, ser := range series {
+				sample, pointErr := decodePoint(ser.Value)
+				if pointErr != nil {
+					err = pointErr
+					break
+				}
+				sample.Metric = ser.Metric
+				result.Samples = append(result.Samples, sample)
+			}
+		}
+	case "matrix":
+		var series []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][]interface{}   `json:"values"`
+		}
+		if err = json.Unmarshal(resp.Data.Result, &series); err == nil {
+			for _, ser := range series {
+				if len(ser.Values) == 0 {
+					continue
+				}
+				sample, pointErr := decodePoint(ser.Values[len(ser.Values)-1])
+				if pointErr != nil {
+					err = pointErr
+					break
+				}
+				sample.Metric = ser.Metric
+				result.Samples = append(result.Samples, sample)
+			}
+		}
+	case "scalar", "string":
+		var point []interface{}
+		if err = json.Unmarshal(resp.Data.Result, &point); err == nil {
+			var sample Sample
+			if sample, err = decodePoint(point); err == nil {
+				result.Samples = []Sample{sample}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", resp.Data.ResultType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Prometheus response: %w", err)
+	}
+	return result, nil
+}
+
+// decodePoint decodes a [timestamp, "value"] pair
+func decodePoint(point []interface{}) (Sample, error) {
+	if len(point) != 2 {
+		return Sample{}, fmt.Errorf("invalid sample %v", point)
+	}
+	ts, ok := point[0].(float64)
+	value, isText := point[1].(string)
+	if !ok || !isText {
+		return Sample{}, fmt.Errorf("invalid sample %v", point)
+	}
+	sec, frac := math.Modf(ts)
+	return Sample{Value: value, Timestamp: time.Unix(int64(sec), int64(frac*1e9)).UTC()}, nil
+}
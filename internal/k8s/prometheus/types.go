@@ -0,0 +1,113 @@
+package prometheus
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Source is where Prometheus is reached: its URL, or a service in the cluster reached through
+// the API server proxy, so no port forward is needed
+type Source struct {
+	// URL is the base URL of the Prometheus HTTP API, such as http://prometheus:9090
+	URL string `json:"url,omitempty"`
+
+	// Service is the Prometheus service as NAMESPACE/NAME or NAMESPACE/NAME:PORT; the port
+	// defaults to 9090
+	Service string `json:"service,omitempty"`
+}
+
+// IsZero reports whether no source is configured
+func (s Source) IsZero() bool {
+	return s.URL == "" && s.Service == ""
+}
+
+// QueryOptions configures an instant query
+type QueryOptions struct {
+	Source Source
+	Expr   string
+
+	// Time evaluates the query at a past time; zero evaluates it now
+	Time time.Time
+}
+
+// QueryResult is the result of an instant query
+type QueryResult struct {
+	Expr string `json:"expr"`
+
+	// ResultType is vector, scalar, matrix or string
+	ResultType string   `json:"resultType"`
+	Samples    []Sample `json:"samples"`
+
+	// Warnings are reported by Prometheus alongside the result
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Sample is one series of a query result with its value. Range vectors report the last value
+// of each series.
+type Sample struct {
+	Metric map[string]string `json:"metric,omitempty"`
+
+	// Value is the value as Prometheus reports it, such as 0.25, NaN or +Inf
+	Value     string    `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Float returns the value as a number, or NaN when it is not one
+func (s Sample) Float() float64 {
+	value, err := strconv.ParseFloat(s.Value, 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return value
+}
+
+// Profile names the metrics an HTTP panel is built from
+type Profile string
+
+const (
+	// ProfileAuto uses the first profile with data
+	ProfileAuto Profile = "auto"
+	// ProfileIstio uses the istio_requests_total metrics of the service mesh sidecars
+	ProfileIstio Profile = "istio"
+	// ProfileHTTP uses http_requests_total and http_request_duration_seconds, the metric
+	// names of the Prometheus client libraries
+	ProfileHTTP Profile = "http"
+)
+
+// PanelOptions selects the workload whose HTTP metrics are queried
+type PanelOptions struct {
+	Source    Source
+	Namespace string
+
+	// Kind is deployment, statefulset or daemonset
+	Kind string
+	Name string
+
+	// Window is the range rates are computed over, such as 5m
+	Window  string
+	Profile Profile
+}
+
+// HTTPPanel is the request rate, error rate and latency of a workload
+type HTTPPanel struct {
+	Namespace string  `json:"namespace"`
+	Kind      string  `json:"kind"`
+	Name      string  `json:"name"`
+	Window    string  `json:"window"`
+	Profile   Profile `json:"profile"`
+
+	Rows []PanelRow `json:"rows"`
+}
+
+// PanelRow is one query of a panel
+type PanelRow struct {
+	Name string `json:"name"`
+	Expr string `json:"expr"`
+
+	// Unit is req/s, ratio or seconds
+	Unit string `json:"unit"`
+
+	// Value is nil when the query returned no data
+	Value *float64 `json:"value"`
+}
@@ -0,0 +1,22 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for resource quota planning
+type Service interface {
+	// Headroom reports how many more replicas of a deployment fit under the quotas of its namespace
+	Headroom(ctx context.Context, namespace, deployment string) (*Headroom, error)
+}
+
+// NewQuotaService creates a new quota service instance
+func NewQuotaService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
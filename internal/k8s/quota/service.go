@@ -0,0 +1,268 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/pods"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new quota service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// Headroom reports how many more replicas of a deployment fit under the quotas of its namespace
+func (s *service) Headroom(ctx context.Context, namespace, name string) (*Headroom, error) {
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errs.NotFoundf("deployment %q not found in namespace %q", name, namespace)
+		}
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	quotas, err := s.clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+
+	limitRanges, err := s.clientset.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit ranges: %w", err)
+	}
+
+	// Quotas count pods as admitted, with the defaults of the limit ranges applied
+	spec := deployment.Spec.Template.Spec.DeepCopy()
+	applyLimitRanges(spec, limitRanges.Items)
+	requests := pods.Requests(spec)
+	limits := pods.Limits(spec)
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	headroom := &Headroom{
+		Namespace:  namespace,
+		Deployment: name,
+		Replicas:   replicas,
+		MaxSurge:   maxSurge(deployment, replicas),
+		PerReplica: map[string]string{},
+		Resources:  []ResourceHeadroom{},
+	}
+
+	for _, q := range quotas.Items {
+		if !scopesMatch(&q, spec, requests, limits) {
+			continue
+		}
+		for resourceName, hard := range q.Spec.Hard {
+			per, tracked := perPod(string(resourceName), requests, limits)
+			if !tracked {
+				continue
+			}
+			if per.IsZero() {
+				// Quotas on compute resources reject pods that do not set them
+				if strings.HasPrefix(string(resourceName), "requests.") || strings.HasPrefix(string(resourceName), "limits.") ||
+					resourceName == corev1.ResourceCPU || resourceName == corev1.ResourceMemory {
+					headroom.Issues = append(headroom.Issues, fmt.Sprintf("quota %s tracks %s but the pods do not set it, so they are rejected", q.Name, resourceName))
+				}
+				continue
+			}
+
+			used := q.Status.Used[resourceName]
+			available := hard.DeepCopy()
+			available.Sub(used)
+			fits := int64(0)
+			if available.Sign() > 0 {
+				fits = available.MilliValue() / per.MilliValue()
+			}
+
+			headroom.PerReplica[string(resourceName)] = per.String()
+			headroom.Resources = append(headroom.Resources, ResourceHeadroom{
+				Quota:      q.Name,
+				Resource:   string(resourceName),
+				Used:       used.String(),
+				Hard:       hard.String(),
+				PerReplica: per.String(),
+				Fits:       fits,
+			})
+		}
+	}
+
+	sort.Slice(headroom.Resources, func(i, j int) bool {
+		a, b := headroom.Resources[i], headroom.Resources[j]
+		if a.Fits != b.Fits {
+			return a.Fits < b.Fits
+		}
+		if a.Quota != b.Quota {
+			return a.Quota < b.Quota
+		}
+		return a.Resource < b.Resource
+	})
+	sort.Strings(headroom.Issues)
+
+	switch {
+	case len(headroom.Issues) > 0:
+		headroom.Limited = true
+	case len(headroom.Resources) > 0:
+		tightest := headroom.Resources[0]
+		headroom.Limited = true
+		headroom.Additional = tightest.Fits
+		headroom.LimitedBy = tightest.Quota + "/" + tightest.Resource
+	}
+
+	return headroom, nil
+}
+
+// perPod returns what one pod counts against a quota resource, and false when new pods do not
+// count against it at all, as for object counts other than pods
+func perPod(name string, requests, limits corev1.ResourceList) (resource.Quantity, bool) {
+	switch {
+	case name == string(corev1.ResourcePods) || name == "count/pods":
+		return *resource.NewQuantity(1, resource.DecimalSI), true
+	case name == string(corev1.ResourceCPU) || name == string(corev1.ResourceMemory) || name == string(corev1.ResourceEphemeralStorage):
+		return requests[corev1.ResourceName(name)], true
+	case strings.HasPrefix(name, "requests."):
+		return requests[corev1.ResourceName(strings.TrimPrefix(name, "requests."))], true
+	case strings.HasPrefix(name, "limits."):
+		return limits[corev1.ResourceName(strings.TrimPrefix(name, "limits."))], true
+	}
+	return resource.Quantity{}, false
+}
+
+// scopesMatch reports whether the pods of a spec are counted by a quota
+func scopesMatch(q *corev1.ResourceQuota, spec *corev1.PodSpec, requests, limits corev1.ResourceList) bool {
+	bestEffort := len(requests) == 0 && len(limits) == 0
+	for _, scope := range q.Spec.Scopes {
+		switch scope {
+		case corev1.ResourceQuotaScopeTerminating:
+			if spec.ActiveDeadlineSeconds == nil {
+				return false
+			}
+		case corev1.ResourceQuotaScopeNotTerminating:
+			if spec.ActiveDeadlineSeconds != nil {
+				return false
+			}
+		case corev1.ResourceQuotaScopeBestEffort:
+			if !bestEffort {
+				return false
+			}
+		case corev1.ResourceQuotaScopeNotBestEffort:
+			if bestEffort {
+				return false
+			}
+		}
+	}
+	if q.Spec.ScopeSelector == nil {
+		return true
+	}
+	for _, expr := range q.Spec.ScopeSelector.MatchExpressions {
+		if expr.ScopeName != corev1.ResourceQuotaScopePriorityClass {
+			continue
+		}
+		has := spec.PriorityClassName != ""
+		in := false
+		for _, v := range expr.Values {
+			if v == spec.PriorityClassName {
+				in = true
+			}
+		}
+		switch expr.Operator {
+		case corev1.ScopeSelectorOpIn:
+			if !has || !in {
+				return false
+			}
+		case corev1.ScopeSelectorOpNotIn:
+			if has && in {
+				return false
+			}
+		case corev1.ScopeSelectorOpExists:
+			if !has {
+				return false
+			}
+		case corev1.ScopeSelectorOpDoesNotExist:
+			if has {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// applyLimitRanges sets the default requests and limits of the container limit ranges on
+// containers that do not set them, as the LimitRanger admission plugin does. A request that is
+// still missing defaults to the limit.
+func applyLimitRanges(spec *corev1.PodSpec, limitRanges []corev1.LimitRange) {
+	apply := func(c *corev1.Container) {
+		for _, lr := range limitRanges {
+			for _, item := range lr.Spec.Limits {
+				if item.Type != corev1.LimitTypeContainer {
+					continue
+				}
+				for name, q := range item.Default {
+					if _, ok := c.Resources.Limits[name]; !ok {
+						if c.Resources.Limits == nil {
+							c.Resources.Limits = corev1.ResourceList{}
+						}
+						c.Resources.Limits[name] = q.DeepCopy()
+					}
+				}
+				for name, q := range item.DefaultRequest {
+					if _, ok := c.Resources.Requests[name]; !ok {
+						if c.Resources.Requests == nil {
+							c.Resources.Requests = corev1.ResourceList{}
+						}
+						c.Resources.Requests[name] = q.DeepCopy()
+					}
+				}
+			}
+		}
+		for name, q := range c.Resources.Limits {
+			if _, ok := c.Resources.Requests[name]; !ok {
+				if c.Resources.Requests == nil {
+					c.Resources.Requests = corev1.ResourceList{}
+				}
+				c.Resources.Requests[name] = q.DeepCopy()
+			}
+		}
+	}
+	for i := range spec.InitContainers {
+		apply(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		apply(&spec.Containers[i])
+	}
+}
+
+// maxSurge returns the number of extra pods a rolling update of the deployment creates
+func maxSurge(deployment *appsv1.Deployment, replicas int32) int32 {
+	if deployment.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return 0
+	}
+	surge := intstr.FromString("25%")
+	if ru := deployment.Spec.Strategy.RollingUpdate; ru != nil && ru.MaxSurge != nil {
+		surge = *ru.MaxSurge
+	}
+	value, err := intstr.GetScaledValueFromIntOrPercent(&surge, int(replicas), true)
+	if err != nil {
+		return 0
+	}
+	return int32(value)
+}
@@ -0,0 +1,43 @@
+package quota
+
+// Headroom is how many more replicas of a deployment fit under the resource quotas of its
+// namespace before new pods are rejected
+type Headroom struct {
+	Namespace  string `json:"namespace"`
+	Deployment string `json:"deployment"`
+	Replicas   int32  `json:"replicas"`
+
+	// MaxSurge is the number of pods a rolling update creates on top of the replicas
+	MaxSurge int32 `json:"maxSurge"`
+
+	// PerReplica is what one pod of the deployment counts against quotas, after LimitRange
+	// defaults, by quota resource name
+	PerReplica map[string]string `json:"perReplica"`
+
+	// Resources lists every quota resource a pod of the deployment consumes, tightest first
+	Resources []ResourceHeadroom `json:"resources"`
+
+	// Limited is false when no quota constrains the pods of the deployment
+	Limited bool `json:"limited"`
+
+	// Additional is the number of replicas that still fit when Limited
+	Additional int64 `json:"additional"`
+
+	// LimitedBy names the quota and resource that run out first, as quota/resource
+	LimitedBy string `json:"limitedBy,omitempty"`
+
+	// Issues lists reasons new pods are rejected regardless of the remaining quota
+	Issues []string `json:"issues,omitempty"`
+}
+
+// ResourceHeadroom is the room left for a quota resource
+type ResourceHeadroom struct {
+	Quota      string `json:"quota"`
+	Resource   string `json:"resource"`
+	Used       string `json:"used"`
+	Hard       string `json:"hard"`
+	PerReplica string `json:"perReplica"`
+
+	// Fits is the number of additional replicas the remaining amount allows
+	Fits int64 `json:"fits"`
+}
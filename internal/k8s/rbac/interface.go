@@ -0,0 +1,29 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+)
+
+// Service defines the interface for RBAC checks
+type Service interface {
+	// CanI checks whether the current user, or the given user and groups, may perform an action
+	CanI(ctx context.Context, opts AccessOptions) (*Access, error)
+
+	// WhoCan lists the subjects that RBAC bindings grant an action to
+	WhoCan(ctx context.Context, opts AccessOptions) ([]Grant, error)
+}
+
+// NewRBACService creates a new RBAC service instance
+func NewRBACService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	discoveryClient := memory.NewMemCacheClient(clientset.Discovery())
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+	return newService(clientset, restmapper.NewShortcutExpander(mapper, discoveryClient, nil)), nil
+}
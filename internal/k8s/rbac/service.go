@@ -0,0 +1,293 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+
+	// mapper also understands short names such as deploy or svc
+	mapper meta.RESTMapper
+}
+
+// newService creates a new RBAC service instance
+func newService(clientset *kubernetes.Clientset, mapper meta.RESTMapper) Service {
+	return &service{
+		clientset: clientset,
+		mapper:    mapper,
+	}
+}
+
+// CanI checks access with a SelfSubjectAccessReview, or a SubjectAccessReview for another user
+func (s *service) CanI(ctx context.Context, opts AccessOptions) (*Access, error) {
+	if opts.Verb == "" || opts.Resource == "" {
+		return nil, errs.Validationf("verb and resource are required")
+	}
+
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	access := &Access{Resource: opts.Resource}
+	var resourceAttributes *authorizationv1.ResourceAttributes
+	var nonResourceAttributes *authorizationv1.NonResourceAttributes
+	if strings.HasPrefix(opts.Resource, "/") {
+		nonResourceAttributes = &authorizationv1.NonResourceAttributes{Verb: opts.Verb, Path: opts.Resource}
+	} else {
+		gvr, subresource, err := s.resolve(opts.Resource)
+		if err != nil {
+			return nil, err
+		}
+		access.Resource = gvr.GroupResource().String()
+		if subresource != "" {
+			access.Resource += "/" + subresource
+		}
+		resourceAttributes = &authorizationv1.ResourceAttributes{
+			Namespace:   namespace,
+			Verb:        opts.Verb,
+			Group:       gvr.Group,
+			Resource:    gvr.Resource,
+			Subresource: subresource,
+			Name:        opts.Name,
+		}
+	}
+
+	var status authorizationv1.SubjectAccessReviewStatus
+	if opts.User == "" && len(opts.Groups) == 0 {
+		review, err := s.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes:    resourceAttributes,
+				NonResourceAttributes: nonResourceAttributes,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create self subject access review: %w", err)
+		}
+		status = review.Status
+	} else {
+		review, err := s.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes:    resourceAttributes,
+				NonResourceAttributes: nonResourceAttributes,
+				User:                  opts.User,
+				Groups:                opts.Groups,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create subject access review: %w", err)
+		}
+		status = review.Status
+	}
+
+	access.Allowed = status.Allowed && !status.Denied
+	access.Reason = status.Reason
+	if status.EvaluationError != "" {
+		access.Reason = strings.TrimSpace(access.Reason + " " + status.EvaluationError)
+	}
+	return access, nil
+}
+
+// resolve maps a resource argument such as po, pods/log or deployments.apps to its resource.
+// Unknown resources are passed through as given, since RBAC rules may name them anyway.
+func (s *service) resolve(resource string) (schema.GroupVersionResource, string, error) {
+	resource, subresource, _ := strings.Cut(strings.ToLower(resource), "/")
+	if resource == "*" {
+		return schema.GroupVersionResource{Resource: resource}, subresource, nil
+	}
+
+	fullySpecified, groupResource := schema.ParseResourceArg(resource)
+	if fullySpecified != nil {
+		if gvr, err := s.mapper.ResourceFor(*fullySpecified); err == nil {
+			return gvr, subresource, nil
+		}
+	}
+	gvr, err := s.mapper.ResourceFor(groupResource.WithVersion(""))
+	if meta.IsNoMatchError(err) {
+		return groupResource.WithVersion(""), subresource, nil
+	}
+	if err != nil {
+		return schema.GroupVersionResource{}, "", fmt.Errorf("failed to resolve resource %q: %w", resource, err)
+	}
+	return gvr, subresource, nil
+}
+
+// WhoCan walks RoleBindings and ClusterRoleBindings and returns every subject whose bound role
+// has a rule matching the action
+func (s *service) WhoCan(ctx context.Context, opts AccessOptions) ([]Grant, error) {
+	if opts.Verb == "" || opts.Resource == "" {
+		return nil, errs.Validationf("verb and resource are required")
+	}
+
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	var match func(rules []rbacv1.PolicyRule) bool
+	// Role bindings only grant namespaced resources, in their own namespace
+	namespaced := false
+	if strings.HasPrefix(opts.Resource, "/") {
+		match = func(rules []rbacv1.PolicyRule) bool {
+			return slices.ContainsFunc(rules, func(r rbacv1.PolicyRule) bool {
+				return matchesNonResource(r, opts.Verb, opts.Resource)
+			})
+		}
+	} else {
+		gvr, subresource, err := s.resolve(opts.Resource)
+		if err != nil {
+			return nil, err
+		}
+		namespaced = s.namespaced(gvr)
+		resource := gvr.Resource
+		if subresource != "" {
+			resource += "/" + subresource
+		}
+		match = func(rules []rbacv1.PolicyRule) bool {
+			return slices.ContainsFunc(rules, func(r rbacv1.PolicyRule) bool {
+				return matchesResource(r, opts.Verb, gvr.Group, resource, opts.Name)
+			})
+		}
+	}
+
+	clusterRoles, err := s.clientset.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster roles: %w", err)
+	}
+	clusterRoleRules := make(map[string][]rbacv1.PolicyRule, len(clusterRoles.Items))
+	for _, r := range clusterRoles.Items {
+		clusterRoleRules[r.Name] = r.Rules
+	}
+
+	var grants []Grant
+
+	clusterBindings, err := s.clientset.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster role bindings: %w", err)
+	}
+	for _, b := range clusterBindings.Items {
+		if b.RoleRef.Kind != "ClusterRole" || !match(clusterRoleRules[b.RoleRef.Name]) {
+			continue
+		}
+		grants = append(grants, grantsFor(b.Subjects, "ClusterRoleBinding "+b.Name, "ClusterRole "+b.RoleRef.Name, "")...)
+	}
+
+	if namespaced {
+		roles, err := s.clientset.RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", err)
+		}
+		roleRules := make(map[string][]rbacv1.PolicyRule, len(roles.Items))
+		for _, r := range roles.Items {
+			roleRules[r.Namespace+"/"+r.Name] = r.Rules
+		}
+
+		bindings, err := s.clientset.RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list role bindings: %w", err)
+		}
+		for _, b := range bindings.Items {
+			var rules []rbacv1.PolicyRule
+			switch b.RoleRef.Kind {
+			case "Role":
+				rules = roleRules[b.Namespace+"/"+b.RoleRef.Name]
+			case "ClusterRole":
+				rules = clusterRoleRules[b.RoleRef.Name]
+			}
+			if !match(rules) {
+				continue
+			}
+			binding := fmt.Sprintf("RoleBinding %s/%s", b.Namespace, b.Name)
+			grants = append(grants, grantsFor(b.Subjects, binding, b.RoleRef.Kind+" "+b.RoleRef.Name, b.Namespace)...)
+		}
+	}
+
+	sort.SliceStable(grants, func(i, j int) bool {
+		a, b := grants[i], grants[j]
+		if a.SubjectKind != b.SubjectKind {
+			return a.SubjectKind < b.SubjectKind
+		}
+		if a.SubjectNamespace != b.SubjectNamespace {
+			return a.SubjectNamespace < b.SubjectNamespace
+		}
+		return a.Subject < b.Subject
+	})
+	return grants, nil
+}
+
+// namespaced reports whether a resource is namespaced, assuming it is when it is unknown
+func (s *service) namespaced(gvr schema.GroupVersionResource) bool {
+	gvk, err := s.mapper.KindFor(gvr)
+	if err != nil {
+		return true
+	}
+	mapping, err := s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return true
+	}
+	return mapping.Scope.Name() == meta.RESTScopeNameNamespace
+}
+
+func grantsFor(subjects []rbacv1.Subject, binding, role, namespace string) []Grant {
+	grants := make([]Grant, 0, len(subjects))
+	for _, subject := range subjects {
+		grants = append(grants, Grant{
+			SubjectKind:      subject.Kind,
+			Subject:          subject.Name,
+			SubjectNamespace: subject.Namespace,
+			Binding:          binding,
+			Role:             role,
+			Namespace:        namespace,
+		})
+	}
+	return grants
+}
+
+// matchesResource reports whether a rule allows a verb on a resource, which may include a
+// subresource such as pods/log
+func matchesResource(rule rbacv1.PolicyRule, verb, group, resource, name string) bool {
+	if !matchesValue(rule.Verbs, verb) || !matchesValue(rule.APIGroups, group) {
+		return false
+	}
+	if len(rule.ResourceNames) > 0 && !slices.Contains(rule.ResourceNames, name) {
+		return false
+	}
+	if matchesValue(rule.Resources, resource) {
+		return true
+	}
+	// Rules may grant a subresource of every resource, e.g. */scale
+	if _, subresource, ok := strings.Cut(resource, "/"); ok {
+		return slices.Contains(rule.Resources, "*/"+subresource)
+	}
+	return false
+}
+
+func matchesNonResource(rule rbacv1.PolicyRule, verb, path string) bool {
+	if !matchesValue(rule.Verbs, verb) {
+		return false
+	}
+	for _, url := range rule.NonResourceURLs {
+		if url == "*" || url == path || (strings.HasSuffix(url, "*") && strings.HasPrefix(path, strings.TrimSuffix(url, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesValue(values []string, value string) bool {
+	return slices.Contains(values, rbacv1.VerbAll) || slices.Contains(values, value)
+}
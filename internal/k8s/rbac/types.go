@@ -0,0 +1,48 @@
+package rbac
+
+// AccessOptions describes the action to check
+type AccessOptions struct {
+	Verb string
+
+	// Resource is a resource type such as pods, deploy or deployments.apps, optionally with a
+	// subresource such as pods/log, or a non-resource URL such as /healthz
+	Resource string
+
+	// Name restricts the check to a single object
+	Name string
+
+	Namespace     string
+	AllNamespaces bool
+
+	// User and Groups check another subject instead of the current user; ignored by WhoCan
+	User   string
+	Groups []string
+}
+
+// Access is the outcome of an access check
+type Access struct {
+	Allowed bool
+
+	// Resource is the resolved resource, e.g. deployments.apps or pods/log
+	Resource string
+
+	// Reason explains the decision when the authorizer provides one
+	Reason string
+}
+
+// Grant is a subject granted an action by a binding
+type Grant struct {
+	// SubjectKind is User, Group or ServiceAccount
+	SubjectKind      string `json:"subjectKind"`
+	Subject          string `json:"subject"`
+	SubjectNamespace string `json:"subjectNamespace,omitempty"`
+
+	// Binding is the granting binding, e.g. RoleBinding prod/deployers or ClusterRoleBinding admins
+	Binding string `json:"binding"`
+
+	// Role is the bound role, e.g. Role deployer or ClusterRole edit
+	Role string `json:"role"`
+
+	// Namespace is where the grant applies, empty for cluster-wide grants
+	Namespace string `json:"namespace,omitempty"`
+}
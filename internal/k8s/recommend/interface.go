@@ -0,0 +1,26 @@
+package recommend
+
+import (
+	"context"
+	"fmt"
+
+	"k8stool/internal/k8s/metrics"
+	"k8stool/internal/k8s/pods"
+)
+
+// Service defines the interface for resource right-sizing recommendations
+type Service interface {
+	// Recommend compares observed usage against requests and limits and suggests adjusted values
+	Recommend(ctx context.Context, opts Options) (*Report, error)
+}
+
+// NewRecommendService creates a new recommend service instance
+func NewRecommendService(podService pods.Service, metricsService metrics.Service) (Service, error) {
+	if podService == nil {
+		return nil, fmt.Errorf("pod service is required")
+	}
+	if metricsService == nil {
+		return nil, fmt.Errorf("metrics service is required")
+	}
+	return newService(podService, metricsService), nil
+}
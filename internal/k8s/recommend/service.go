@@ -0,0 +1,234 @@
+package recommend
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/metrics"
+	"k8stool/internal/k8s/pods"
+)
+
+const (
+	// minCPURequest is the smallest CPU request suggested, in millicores
+	minCPURequest = 10
+	// minMemoryRequest is the smallest memory request suggested, in bytes
+	minMemoryRequest = 16 * 1024 * 1024
+	// cpuStep and memoryStep are the granularity suggestions are rounded up to
+	cpuStep    = 5
+	memoryStep = 1024 * 1024
+	// overProvisionedRatio flags requests this much larger than the suggestion
+	overProvisionedRatio = 1.5
+	// limitPressureRatio flags memory usage this close to the limit
+	limitPressureRatio = 0.9
+)
+
+type service struct {
+	podService     pods.Service
+	metricsService metrics.Service
+}
+
+// newService creates a new recommend service instance
+func newService(podService pods.Service, metricsService metrics.Service) Service {
+	return &service{
+		podService:     podService,
+		metricsService: metricsService,
+	}
+}
+
+type containerKey struct {
+	namespace string
+	workload  string
+	container string
+}
+
+type observation struct {
+	pods          map[string]bool
+	cpuUsage      int64
+	cpuRequest    int64
+	cpuLimit      int64
+	memoryUsage   int64
+	memoryRequest int64
+	memoryLimit   int64
+}
+
+// Recommend compares observed usage against requests and limits and suggests adjusted values
+func (s *service) Recommend(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Samples <= 0 {
+		opts.Samples = 1
+	}
+	if opts.Headroom < 0 {
+		return nil, errs.Validationf("headroom must not be negative")
+	}
+
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	podList, err := s.podService.List(namespace, opts.AllNamespaces, opts.Selector, "Running")
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := make(map[string]string, len(podList))
+	for _, p := range podList {
+		workloads[p.Namespace+"/"+p.Name] = workloadName(p)
+	}
+
+	observations := make(map[containerKey]*observation)
+	for i := 0; i < opts.Samples; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(opts.Interval):
+			}
+		}
+
+		podMetrics, err := s.metricsService.ListPodMetrics(namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pm := range podMetrics {
+			workload, ok := workloads[pm.Namespace+"/"+pm.Name]
+			if !ok {
+				continue // Filtered out by the selector or not running
+			}
+			for name, c := range pm.Containers {
+				key := containerKey{namespace: pm.Namespace, workload: workload, container: name}
+				obs, ok := observations[key]
+				if !ok {
+					obs = &observation{pods: make(map[string]bool)}
+					observations[key] = obs
+				}
+				obs.pods[pm.Name] = true
+				obs.cpuUsage = max(obs.cpuUsage, c.CPU.UsageNanoCores/1e6)
+				obs.cpuRequest = max(obs.cpuRequest, c.CPU.RequestMilliCores)
+				obs.cpuLimit = max(obs.cpuLimit, c.CPU.LimitMilliCores)
+				obs.memoryUsage = max(obs.memoryUsage, c.Memory.UsageBytes)
+				obs.memoryRequest = max(obs.memoryRequest, c.Memory.RequestBytes)
+				obs.memoryLimit = max(obs.memoryLimit, c.Memory.LimitBytes)
+			}
+		}
+	}
+
+	report := &Report{Samples: opts.Samples}
+	for key, obs := range observations {
+		rec := Recommendation{
+			Namespace: key.namespace,
+			Workload:  key.workload,
+			Container: key.container,
+			Pods:      len(obs.pods),
+			CPU:       recommendCPU(obs, opts.Headroom),
+			Memory:    recommendMemory(obs, opts.Headroom),
+		}
+		report.Recommendations = append(report.Recommendations, rec)
+
+		// Pods of the same workload share their configuration, so totals scale with the pod count
+		podCount := int64(rec.Pods)
+		report.Summary.CPURequested += rec.CPU.Request * podCount
+		report.Summary.CPUSuggested += rec.CPU.SuggestedRequest * podCount
+		report.Summary.MemoryRequested += rec.Memory.Request * podCount
+		report.Summary.MemorySuggested += rec.Memory.SuggestedRequest * podCount
+		for _, v := range []Verdict{rec.CPU.Verdict, rec.Memory.Verdict} {
+			switch v {
+			case OverProvisioned:
+				report.Summary.Over++
+			case UnderProvisioned:
+				report.Summary.Under++
+			case NoRequest:
+				report.Summary.Missing++
+			}
+		}
+	}
+
+	sort.Slice(report.Recommendations, func(i, j int) bool {
+		a, b := report.Recommendations[i], report.Recommendations[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Workload != b.Workload {
+			return a.Workload < b.Workload
+		}
+		return a.Container < b.Container
+	})
+
+	return report, nil
+}
+
+func recommendCPU(obs *observation, headroom float64) ResourceRecommendation {
+	rec := ResourceRecommendation{
+		Usage:   obs.cpuUsage,
+		Request: obs.cpuRequest,
+		Limit:   obs.cpuLimit,
+	}
+	rec.SuggestedRequest = suggest(obs.cpuUsage, headroom, cpuStep, minCPURequest)
+	rec.SuggestedLimit = obs.cpuLimit
+	if rec.SuggestedLimit > 0 && rec.SuggestedLimit < rec.SuggestedRequest {
+		rec.SuggestedLimit = rec.SuggestedRequest
+	}
+	rec.Verdict = verdict(rec)
+	return rec
+}
+
+func recommendMemory(obs *observation, headroom float64) ResourceRecommendation {
+	rec := ResourceRecommendation{
+		Usage:   obs.memoryUsage,
+		Request: obs.memoryRequest,
+		Limit:   obs.memoryLimit,
+	}
+	rec.SuggestedRequest = suggest(obs.memoryUsage, headroom, memoryStep, minMemoryRequest)
+	rec.SuggestedLimit = obs.memoryLimit
+	if rec.SuggestedLimit > 0 && float64(obs.memoryUsage) >= float64(rec.SuggestedLimit)*limitPressureRatio {
+		// Memory is not compressible, so running close to the limit risks an OOM kill
+		rec.SuggestedLimit = suggest(obs.memoryUsage, 2*headroom, memoryStep, minMemoryRequest)
+	}
+	if rec.SuggestedLimit > 0 && rec.SuggestedLimit < rec.SuggestedRequest {
+		rec.SuggestedLimit = rec.SuggestedRequest
+	}
+	rec.Verdict = verdict(rec)
+	if rec.Limit > 0 && rec.SuggestedLimit > rec.Limit {
+		rec.Verdict = UnderProvisioned
+	}
+	return rec
+}
+
+// suggest returns usage plus headroom, rounded up to step and never below floor
+func suggest(usage int64, headroom float64, step, floor int64) int64 {
+	v := int64(math.Ceil(float64(usage) * (1 + headroom)))
+	if rem := v % step; rem != 0 {
+		v += step - rem
+	}
+	return max(v, floor)
+}
+
+func verdict(rec ResourceRecommendation) Verdict {
+	switch {
+	case rec.Request == 0:
+		return NoRequest
+	case rec.Usage > rec.Request:
+		return UnderProvisioned
+	case float64(rec.Request) > float64(rec.SuggestedRequest)*overProvisionedRatio:
+		return OverProvisioned
+	default:
+		return OK
+	}
+}
+
+// workloadName returns the top-level workload of a pod, resolving ReplicaSets to their Deployment
+func workloadName(p pods.Pod) string {
+	switch {
+	case p.Controller == "":
+		return "Pod/" + p.Name
+	case p.Controller == "ReplicaSet":
+		if hash := p.Labels["pod-template-hash"]; hash != "" && strings.HasSuffix(p.ControllerName, "-"+hash) {
+			return "Deployment/" + strings.TrimSuffix(p.ControllerName, "-"+hash)
+		}
+	}
+	return p.Controller + "/" + p.ControllerName
+}
@@ -0,0 +1,74 @@
+package recommend
+
+import (
+	"time"
+)
+
+// Verdict classifies how well a resource request matches observed usage
+type Verdict string
+
+const (
+	// OK means the request is close to the suggested value
+	OK Verdict = "ok"
+	// OverProvisioned means the request is well above observed usage
+	OverProvisioned Verdict = "over-provisioned"
+	// UnderProvisioned means usage exceeds the request or is close to the limit
+	UnderProvisioned Verdict = "under-provisioned"
+	// NoRequest means the container does not request the resource at all
+	NoRequest Verdict = "no-request"
+)
+
+// Options configures how usage is sampled and suggestions are computed
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+	Selector      string
+
+	// Samples is the number of metrics samples taken; the peak usage is used
+	Samples int
+
+	// Interval is the time between samples
+	Interval time.Duration
+
+	// Headroom is the fraction added on top of peak usage, e.g. 0.2 for 20%
+	Headroom float64
+}
+
+// ResourceRecommendation compares usage of a single resource with its configuration.
+// CPU values are in millicores and memory values in bytes.
+type ResourceRecommendation struct {
+	Usage            int64   `json:"usage"`
+	Request          int64   `json:"request"`
+	Limit            int64   `json:"limit"`
+	SuggestedRequest int64   `json:"suggestedRequest"`
+	SuggestedLimit   int64   `json:"suggestedLimit"`
+	Verdict          Verdict `json:"verdict"`
+}
+
+// Recommendation holds the suggestions for one container of a workload
+type Recommendation struct {
+	Namespace string                 `json:"namespace"`
+	Workload  string                 `json:"workload"`
+	Container string                 `json:"container"`
+	Pods      int                    `json:"pods"`
+	CPU       ResourceRecommendation `json:"cpu"`
+	Memory    ResourceRecommendation `json:"memory"`
+}
+
+// Summary aggregates requested and suggested resources across all recommendations
+type Summary struct {
+	CPURequested    int64 `json:"cpuRequested"`
+	CPUSuggested    int64 `json:"cpuSuggested"`
+	MemoryRequested int64 `json:"memoryRequested"`
+	MemorySuggested int64 `json:"memorySuggested"`
+	Over            int   `json:"over"`
+	Under           int   `json:"under"`
+	Missing         int   `json:"missing"`
+}
+
+// Report contains all recommendations and their summary
+type Report struct {
+	Recommendations []Recommendation `json:"recommendations"`
+	Summary         Summary          `json:"summary"`
+	Samples         int              `json:"samples"`
+}
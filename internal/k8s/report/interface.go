@@ -0,0 +1,22 @@
+package report
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for cluster inventory reports
+type Service interface {
+	// Inventory collects nodes, workloads, images, quota usage and warning events
+	Inventory(ctx context.Context, opts Options) (*Inventory, error)
+}
+
+// NewReportService creates a new report service instance
+func NewReportService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
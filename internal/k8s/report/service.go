@@ -0,0 +1,321 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// roleLabelPrefix marks node roles, e.g. node-role.kubernetes.io/control-plane
+const roleLabelPrefix = "node-role.kubernetes.io/"
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new report service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// Inventory collects nodes, workloads, images, quota usage and warning events
+func (s *service) Inventory(ctx context.Context, opts Options) (*Inventory, error) {
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	inventory := &Inventory{GeneratedAt: time.Now()}
+	if version, err := s.clientset.Discovery().ServerVersion(); err == nil {
+		inventory.ServerVersion = version.GitVersion
+	}
+
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	if inventory.Nodes, err = s.nodes(ctx); err != nil {
+		return nil, err
+	}
+	if inventory.Namespaces, err = s.workloads(ctx, namespace, podList.Items); err != nil {
+		return nil, err
+	}
+	inventory.Images = images(podList.Items)
+	if inventory.Quotas, err = s.quotas(ctx, namespace); err != nil {
+		return nil, err
+	}
+	if inventory.Warnings, err = s.warnings(ctx, namespace); err != nil {
+		return nil, err
+	}
+
+	return inventory, nil
+}
+
+func (s *service) nodes(ctx context.Context) ([]Node, error) {
+	nodeList, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	// Pods are counted cluster-wide, independent of the reported namespaces
+	podList, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	podsPerNode := make(map[string]int)
+	for _, pod := range podList.Items {
+		podsPerNode[pod.Spec.NodeName]++
+	}
+
+	nodes := make([]Node, 0, len(nodeList.Items))
+	for _, n := range nodeList.Items {
+		var roles []string
+		for label := range n.Labels {
+			if role, ok := strings.CutPrefix(label, roleLabelPrefix); ok && role != "" {
+				roles = append(roles, role)
+			}
+		}
+		sort.Strings(roles)
+
+		node := Node{
+			Name:              n.Name,
+			Roles:             strings.Join(roles, ","),
+			Unschedulable:     n.Spec.Unschedulable,
+			KubeletVersion:    n.Status.NodeInfo.KubeletVersion,
+			OS:                n.Status.NodeInfo.OSImage,
+			CPUAllocatable:    n.Status.Allocatable.Cpu().MilliValue(),
+			MemoryAllocatable: n.Status.Allocatable.Memory().Value(),
+			PodsAllocatable:   n.Status.Allocatable.Pods().Value(),
+			Pods:              podsPerNode[n.Name],
+		}
+		for _, c := range n.Status.Conditions {
+			if c.Type == corev1.NodeReady {
+				node.Ready = c.Status == corev1.ConditionTrue
+			}
+		}
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes, nil
+}
+
+func (s *service) workloads(ctx context.Context, namespace string, pods []corev1.Pod) ([]NamespaceWorkloads, error) {
+	counts := make(map[string]*NamespaceWorkloads)
+	get := func(ns string) *NamespaceWorkloads {
+		if _, ok := counts[ns]; !ok {
+			counts[ns] = &NamespaceWorkloads{Namespace: ns}
+		}
+		return counts[ns]
+	}
+
+	deployments, err := s.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		get(d.Namespace).Deployments++
+	}
+
+	statefulSets, err := s.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, ss := range statefulSets.Items {
+		get(ss.Namespace).StatefulSets++
+	}
+
+	daemonSets, err := s.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, ds := range daemonSets.Items {
+		get(ds.Namespace).DaemonSets++
+	}
+
+	cronJobs, err := s.clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for _, cj := range cronJobs.Items {
+		get(cj.Namespace).CronJobs++
+	}
+
+	jobs, err := s.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, job := range jobs.Items {
+		get(job.Namespace).Jobs++
+	}
+
+	for _, pod := range pods {
+		ns := get(pod.Namespace)
+		ns.Pods++
+		if pod.Status.Phase == corev1.PodRunning {
+			ns.RunningPods++
+		}
+	}
+
+	result := make([]NamespaceWorkloads, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace < result[j].Namespace
+	})
+	return result, nil
+}
+
+// images groups the container images of pods by repository and version
+func images(pods []corev1.Pod) []Image {
+	type entry struct {
+		image      Image
+		namespaces map[string]bool
+	}
+	found := make(map[string]*entry)
+
+	for _, pod := range pods {
+		seen := make(map[string]bool)
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, c := range containers {
+			if seen[c.Image] {
+				continue
+			}
+			seen[c.Image] = true
+
+			e, ok := found[c.Image]
+			if !ok {
+				repository, version := splitImage(c.Image)
+				e = &entry{image: Image{Repository: repository, Version: version}, namespaces: make(map[string]bool)}
+				found[c.Image] = e
+			}
+			e.image.Pods++
+			e.namespaces[pod.Namespace] = true
+		}
+	}
+
+	result := make([]Image, 0, len(found))
+	for _, e := range found {
+		for ns := range e.namespaces {
+			e.image.Namespaces = append(e.image.Namespaces, ns)
+		}
+		sort.Strings(e.image.Namespaces)
+		result = append(result, e.image)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Repository != result[j].Repository {
+			return result[i].Repository < result[j].Repository
+		}
+		return result[i].Version < result[j].Version
+	})
+	return result
+}
+
+// splitImage splits an image reference into repository and tag or digest
+func splitImage(image string) (string, string) {
+	if repository, digest, ok := strings.Cut(image, "@"); ok {
+		return repository, digest
+	}
+	// A colon before the last slash belongs to a registry port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, "latest"
+}
+
+func (s *service) quotas(ctx context.Context, namespace string) ([]QuotaUsage, error) {
+	quotaList, err := s.clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+
+	var usage []QuotaUsage
+	for _, q := range quotaList.Items {
+		for name, hard := range q.Status.Hard {
+			used := q.Status.Used[name]
+			u := QuotaUsage{
+				Namespace: q.Namespace,
+				Quota:     q.Name,
+				Resource:  string(name),
+				Used:      used.String(),
+				Hard:      hard.String(),
+				Percent:   -1,
+			}
+			if hard.MilliValue() > 0 {
+				u.Percent = int(used.MilliValue() * 100 / hard.MilliValue())
+			}
+			usage = append(usage, u)
+		}
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		a, b := usage[i], usage[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Quota != b.Quota {
+			return a.Quota < b.Quota
+		}
+		return a.Resource < b.Resource
+	})
+	return usage, nil
+}
+
+func (s *service) warnings(ctx context.Context, namespace string) ([]WarningSummary, error) {
+	eventList, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "type=Warning",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	groups := make(map[string]*WarningSummary)
+	for _, e := range eventList.Items {
+		last := e.LastTimestamp.Time
+		if last.IsZero() {
+			last = e.EventTime.Time
+		}
+		count := e.Count
+		if count == 0 {
+			count = 1
+		}
+
+		key := e.Namespace + "/" + e.Reason + "/" + e.InvolvedObject.Kind
+		g, ok := groups[key]
+		if !ok {
+			g = &WarningSummary{Namespace: e.Namespace, Reason: e.Reason, Kind: e.InvolvedObject.Kind}
+			groups[key] = g
+		}
+		g.Count += count
+		if last.After(g.LastSeen) {
+			g.LastSeen = last
+			g.Message = e.Message
+		}
+	}
+
+	summaries := make([]WarningSummary, 0, len(groups))
+	for _, g := range groups {
+		summaries = append(summaries, *g)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return summaries[i].Namespace+summaries[i].Reason < summaries[j].Namespace+summaries[j].Reason
+	})
+	return summaries, nil
+}
@@ -0,0 +1,82 @@
+package report
+
+import "time"
+
+// Options configures which namespaces are reported on. Nodes are always reported.
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+}
+
+// Inventory is a snapshot of a cluster
+type Inventory struct {
+	// Context is the kubeconfig context the snapshot was taken from
+	Context       string
+	ServerVersion string
+	GeneratedAt   time.Time
+
+	Nodes      []Node
+	Namespaces []NamespaceWorkloads
+	Images     []Image
+	Quotas     []QuotaUsage
+	Warnings   []WarningSummary
+}
+
+// Node describes the capacity of a node
+type Node struct {
+	Name           string
+	Roles          string
+	Ready          bool
+	Unschedulable  bool
+	KubeletVersion string
+	OS             string
+
+	// CPU is in millicores and Memory in bytes
+	CPUAllocatable    int64
+	MemoryAllocatable int64
+	PodsAllocatable   int64
+	Pods              int
+}
+
+// NamespaceWorkloads counts the workloads of a namespace
+type NamespaceWorkloads struct {
+	Namespace    string
+	Deployments  int
+	StatefulSets int
+	DaemonSets   int
+	CronJobs     int
+	Jobs         int
+	Pods         int
+	RunningPods  int
+}
+
+// Image is a container image in use and where it runs
+type Image struct {
+	Repository string
+	// Version is the tag or digest, "latest" when neither is set
+	Version    string
+	Pods       int
+	Namespaces []string
+}
+
+// QuotaUsage is the usage of a single resource limited by a quota
+type QuotaUsage struct {
+	Namespace string
+	Quota     string
+	Resource  string
+	Used      string
+	Hard      string
+	// Percent is the used share of the hard limit, -1 if it cannot be computed
+	Percent int
+}
+
+// WarningSummary groups warning events by namespace, reason and object kind
+type WarningSummary struct {
+	Namespace string
+	Reason    string
+	Kind      string
+	Count     int32
+	LastSeen  time.Time
+	// Message is the message of the most recent event
+	Message string
+}
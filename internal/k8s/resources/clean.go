@@ -0,0 +1,85 @@
+package resources
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// serverFields are populated by the API server and controllers and never belong in a manifest
+var serverFields = [][]string{
+	{"status"},
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "deletionTimestamp"},
+	{"metadata", "deletionGracePeriodSeconds"},
+	{"metadata", "selfLink"},
+	{"metadata", "managedFields"},
+	{"metadata", "ownerReferences"},
+}
+
+// serverAnnotations are annotations written by kubectl and controllers rather than by users
+var serverAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+	"deprecated.daemonset.template.generation",
+	"pv.kubernetes.io/bind-completed",
+	"pv.kubernetes.io/bound-by-controller",
+	"volume.kubernetes.io/selected-node",
+	"volume.kubernetes.io/storage-provisioner",
+	"volume.beta.kubernetes.io/storage-provisioner",
+}
+
+// jobControllerLabels are set on jobs and their pod templates by the job controller
+var jobControllerLabels = []string{
+	"controller-uid",
+	"batch.kubernetes.io/controller-uid",
+}
+
+// Clean returns a copy of a live object without status and server-populated fields, so it can
+// be committed to version control and applied to another cluster or namespace
+func Clean(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	clean := obj.DeepCopy()
+	for _, field := range serverFields {
+		unstructured.RemoveNestedField(clean.Object, field...)
+	}
+
+	annotations := clean.GetAnnotations()
+	for _, key := range serverAnnotations {
+		delete(annotations, key)
+	}
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(clean.Object, "metadata", "annotations")
+	} else {
+		clean.SetAnnotations(annotations)
+	}
+
+	switch clean.GroupVersionKind().GroupKind().String() {
+	case "Namespace":
+		// The name label and the kubernetes finalizer are added back by the server
+		unstructured.RemoveNestedField(clean.Object, "metadata", "labels", "kubernetes.io/metadata.name")
+		if len(clean.GetLabels()) == 0 {
+			unstructured.RemoveNestedField(clean.Object, "metadata", "labels")
+		}
+		unstructured.RemoveNestedField(clean.Object, "spec")
+	case "Service":
+		// Cluster IPs are allocated; headless services keep their explicit None
+		if ip, _, _ := unstructured.NestedString(clean.Object, "spec", "clusterIP"); ip != "None" {
+			unstructured.RemoveNestedField(clean.Object, "spec", "clusterIP")
+			unstructured.RemoveNestedField(clean.Object, "spec", "clusterIPs")
+		}
+	case "PersistentVolumeClaim":
+		// The bound volume is chosen by the binder and may not exist elsewhere
+		unstructured.RemoveNestedField(clean.Object, "spec", "volumeName")
+	case "Job.batch":
+		// The generated selector matches the old job's UID and is rejected on create
+		if manual, _, _ := unstructured.NestedBool(clean.Object, "spec", "manualSelector"); !manual {
+			unstructured.RemoveNestedField(clean.Object, "spec", "selector")
+			for _, label := range jobControllerLabels {
+				unstructured.RemoveNestedField(clean.Object, "spec", "template", "metadata", "labels", label)
+			}
+		}
+	}
+
+	return clean
+}
@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+)
+
+// Service defines the interface for working with arbitrary resources
+type Service interface {
+	// Apply creates or updates objects with server-side apply
+	Apply(ctx context.Context, objs []*unstructured.Unstructured, opts ApplyOptions) ([]Result, error)
+
+	// Diff server-side applies objects as a dry run and returns the result next to the live objects
+	Diff(ctx context.Context, objs []*unstructured.Unstructured, opts ApplyOptions) ([]Comparison, error)
+
+	// Get returns a single object by resource type, e.g. deploy or deployments.apps, and name
+	Get(ctx context.Context, resourceType, namespace, name string) (*unstructured.Unstructured, error)
+
+	// List returns the objects of a resource type matching a label selector
+	List(ctx context.Context, resourceType string, opts ListOptions) ([]*unstructured.Unstructured, error)
+
+	// Update replaces an object, failing if it changed since it was read
+	Update(ctx context.Context, obj *unstructured.Unstructured, dryRun bool) (*unstructured.Unstructured, error)
+
+	// Patch applies a patch of the given type to an object
+	Patch(ctx context.Context, obj *unstructured.Unstructured, patchType types.PatchType, data []byte, dryRun bool) (*unstructured.Unstructured, error)
+
+	// SetMetadata adds, changes or removes labels or annotations on objects
+	SetMetadata(ctx context.Context, objs []*unstructured.Unstructured, opts MetadataOptions) ([]Result, error)
+
+	// Delete deletes objects, optionally waiting until they are gone
+	Delete(ctx context.Context, objs []*unstructured.Unstructured, opts DeleteOptions) ([]Result, error)
+
+	// Restart rolls out new pods for workloads and deletes controlled pods so they are replaced
+	Restart(ctx context.Context, objs []*unstructured.Unstructured, opts RestartOptions) ([]Result, error)
+}
+
+// NewResourceService creates a new resource service instance
+func NewResourceService(clientset *kubernetes.Clientset, dynamicClient dynamic.Interface) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	if dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client is required")
+	}
+	discoveryClient := memory.NewMemCacheClient(clientset.Discovery())
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+	return newService(dynamicClient, mapper, restmapper.NewShortcutExpander(mapper, discoveryClient, nil)), nil
+}
@@ -0,0 +1,38 @@
+package resources
+
+import (
+	"fmt"
+
+	"k8stool/internal/k8s/errs"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// Kustomize builds a kustomization directory or git URL like `kustomize build` and returns the
+// resulting objects. Unless the kustomization sets sortOptions, namespaces and CRDs are ordered
+// before the objects using them.
+func Kustomize(path string) ([]*unstructured.Unstructured, error) {
+	opts := krusty.MakeDefaultOptions()
+	opts.Reorder = krusty.ReorderOptionUnspecified
+	k := krusty.MakeKustomizer(opts)
+	resMap, err := k.Run(filesys.MakeFsOnDisk(), path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization %s: %w", path, err)
+	}
+
+	data, err := resMap.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render kustomization %s: %w", path, err)
+	}
+
+	objs, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) == 0 {
+		return nil, errs.Validationf("no objects found in %s", path)
+	}
+	return objs, nil
+}
@@ -0,0 +1,166 @@
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// manifestExtensions are the file extensions read from directories
+var manifestExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// Load reads objects from manifest files, directories, http(s) URLs or stdin ("-"),
+// in the order given. Directories are only descended into when recursive is set.
+func Load(sources []string, recursive bool) ([]*unstructured.Unstructured, error) {
+	docs, err := LoadDocuments(sources, recursive)
+	if err != nil {
+		return nil, err
+	}
+	return objects(docs), nil
+}
+
+// LoadDocuments is like Load but also reports where each object was read from
+func LoadDocuments(sources []string, recursive bool) ([]Document, error) {
+	var docs []Document
+	for _, source := range sources {
+		var loaded []Document
+		var err error
+
+		switch {
+		case source == "-":
+			loaded, err = decodeFrom("stdin", os.Stdin)
+		case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+			loaded, err = loadURL(source)
+		default:
+			loaded, err = loadPath(source, recursive)
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, loaded...)
+	}
+
+	if len(docs) == 0 {
+		return nil, errs.Validationf("no objects found in %s", strings.Join(sources, ", "))
+	}
+	return docs, nil
+}
+
+func loadURL(url string) ([]Document, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+	return decodeFrom(url, resp.Body)
+}
+
+func loadPath(path string, recursive bool) ([]Document, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return decodeFrom(path, f)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []Document
+	for _, entry := range entries {
+		child := filepath.Join(path, entry.Name())
+		if entry.IsDir() {
+			if !recursive {
+				continue
+			}
+		} else if !manifestExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			continue
+		}
+
+		loaded, err := loadPath(child, recursive)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, loaded...)
+	}
+	return docs, nil
+}
+
+// Decode reads every object from a multi-document YAML or JSON stream, expanding lists
+func Decode(data []byte) ([]*unstructured.Unstructured, error) {
+	docs, err := decodeFrom("input", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return objects(docs), nil
+}
+
+func decodeFrom(source string, r io.Reader) ([]Document, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	var docs []Document
+	index := 0
+	for {
+		var content map[string]interface{}
+		if err := decoder.Decode(&content); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+		}
+		if len(content) == 0 {
+			continue // Empty document, e.g. between two separators
+		}
+		index++
+
+		obj := &unstructured.Unstructured{Object: content}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+			}
+			for i := range list.Items {
+				docs = append(docs, Document{Source: source, Index: index, Object: &list.Items[i]})
+			}
+			continue
+		}
+
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			return nil, errs.Validationf("failed to parse %s: object is missing apiVersion or kind", source)
+		}
+		docs = append(docs, Document{Source: source, Index: index, Object: obj})
+	}
+	return docs, nil
+}
+
+func objects(docs []Document) []*unstructured.Unstructured {
+	objs := make([]*unstructured.Unstructured, len(docs))
+	for i := range docs {
+		objs[i] = docs[i].Object
+	}
+	return objs
+}
@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultConcurrency is how many objects bulk operations work on at a time
+const DefaultConcurrency = 5
+
+// forEach calls fn with the indexes 0 to n-1 from a pool of up to workers goroutines. It stops
+// handing out indexes once ctx is done and returns ctx.Err() after the running calls finish.
+func forEach(ctx context.Context, n, workers int, fn func(i int)) error {
+	if workers <= 0 {
+		workers = DefaultConcurrency
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return ctx.Err()
+}
+
+// completed drops the results of objects that were never worked on because the operation
+// was cancelled, keeping the order of the rest
+func completed(results []Result) []Result {
+	done := results[:0]
+	for _, r := range results {
+		if r.Action != "" {
+			done = append(done, r)
+		}
+	}
+	return done
+}
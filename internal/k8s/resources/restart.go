@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RestartedAtAnnotation is the pod template annotation kubectl rollout restart sets
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// Restart restarts objects concurrently. Deployments, StatefulSets and DaemonSets roll out new
+// pods, as kubectl rollout restart does; pods are deleted so their controller replaces them.
+// Pods without a controller are refused since they would not come back.
+func (s *service) Restart(ctx context.Context, objs []*unstructured.Unstructured, opts RestartOptions) ([]Result, error) {
+	results := make([]Result, len(objs))
+	err := forEach(ctx, len(objs), opts.Concurrency, func(i int) {
+		obj := objs[i]
+		result := newResult(obj)
+		if err := s.restart(ctx, obj, opts.DryRun); err != nil {
+			result.Action, result.Error = Failed, err.Error()
+		} else {
+			result.Action = Restarted
+		}
+		results[i] = result
+	})
+	return completed(results), err
+}
+
+func (s *service) restart(ctx context.Context, obj *unstructured.Unstructured, dryRun bool) error {
+	gvk := obj.GroupVersionKind()
+	switch {
+	case gvk.Group == "apps" && (gvk.Kind == "Deployment" || gvk.Kind == "StatefulSet" || gvk.Kind == "DaemonSet"):
+		data, err := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]string{RestartedAtAnnotation: time.Now().Format(time.RFC3339)},
+					},
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = s.Patch(ctx, obj, types.StrategicMergePatchType, data, dryRun)
+		return err
+	case gvk.Group == "" && gvk.Kind == "Pod":
+		if metav1.GetControllerOf(obj) == nil {
+			return fmt.Errorf("pod has no controller and would not be recreated, delete it instead")
+		}
+		client, err := s.resourceFor(obj, "")
+		if err != nil {
+			return err
+		}
+		uid := obj.GetUID()
+		deleteOpts := metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}
+		if dryRun {
+			deleteOpts.DryRun = []string{metav1.DryRunAll}
+		}
+		if err := client.Delete(ctx, obj.GetName(), deleteOpts); err != nil {
+			return fmt.Errorf("failed to delete pod: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s cannot be restarted, only deployments, statefulsets, daemonsets and pods", gvk.Kind)
+	}
+}
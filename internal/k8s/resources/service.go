@@ -0,0 +1,417 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// deletionPollInterval is how often to check whether a deleted object is gone
+const deletionPollInterval = time.Second
+
+type service struct {
+	dynamicClient dynamic.Interface
+	mapper        *restmapper.DeferredDiscoveryRESTMapper
+
+	// typeMapper also understands short names such as deploy or svc
+	typeMapper meta.RESTMapper
+}
+
+// newService creates a new resource service instance
+func newService(dynamicClient dynamic.Interface, mapper *restmapper.DeferredDiscoveryRESTMapper, typeMapper meta.RESTMapper) Service {
+	return &service{
+		dynamicClient: dynamicClient,
+		mapper:        mapper,
+		typeMapper:    typeMapper,
+	}
+}
+
+// mappingForType resolves a resource type argument such as po, deployments or deployments.v1.apps
+func (s *service) mappingForType(resourceType string) (*meta.RESTMapping, error) {
+	fullySpecified, groupResource := schema.ParseResourceArg(strings.ToLower(resourceType))
+
+	var gvk schema.GroupVersionKind
+	var err error
+	if fullySpecified != nil {
+		gvk, err = s.typeMapper.KindFor(*fullySpecified)
+	}
+	if gvk.Empty() {
+		gvk, err = s.typeMapper.KindFor(groupResource.WithVersion(""))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unknown resource type %q: %w", resourceType, err)
+	}
+
+	mapping, err := s.typeMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s: %w", gvk, err)
+	}
+	return mapping, nil
+}
+
+func (s *service) clientFor(mapping *meta.RESTMapping, namespace string) dynamic.ResourceInterface {
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return s.dynamicClient.Resource(mapping.Resource)
+	}
+	return s.dynamicClient.Resource(mapping.Resource).Namespace(namespace)
+}
+
+// Get returns a single object by resource type and name
+func (s *service) Get(ctx context.Context, resourceType, namespace, name string) (*unstructured.Unstructured, error) {
+	mapping, err := s.mappingForType(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := s.clientFor(mapping, namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", mapping.Resource.Resource, name, err)
+	}
+	return obj, nil
+}
+
+// List returns the objects of a resource type matching a label selector
+func (s *service) List(ctx context.Context, resourceType string, opts ListOptions) ([]*unstructured.Unstructured, error) {
+	mapping, err := s.mappingForType(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+	list, err := s.clientFor(mapping, namespace).List(ctx, metav1.ListOptions{LabelSelector: opts.Selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", mapping.Resource.Resource, err)
+	}
+
+	objs := make([]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		objs[i] = &list.Items[i]
+	}
+	return objs, nil
+}
+
+// resourceFor returns the client for an object's resource, defaulting the namespace of namespaced objects
+func (s *service) resourceFor(obj *unstructured.Unstructured, namespace string) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// The kind may have been registered by a CRD applied moments ago
+		s.mapper.Reset()
+		mapping, err = s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s: %w", gvk, err)
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		obj.SetNamespace("")
+		return s.dynamicClient.Resource(mapping.Resource), nil
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(namespace)
+	}
+	return s.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
+
+// Apply creates or updates objects with server-side apply
+func (s *service) Apply(ctx context.Context, objs []*unstructured.Unstructured, opts ApplyOptions) ([]Result, error) {
+	applyOpts := applyOptions(opts)
+
+	results := make([]Result, 0, len(objs))
+	for _, obj := range objs {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		comparison, err := s.apply(ctx, obj, opts.Namespace, applyOpts)
+		if err != nil {
+			comparison.Action = Failed
+			comparison.Error = err.Error()
+		}
+		results = append(results, comparison.Result)
+	}
+	return results, nil
+}
+
+// Diff server-side applies objects as a dry run and returns the result next to the live objects
+func (s *service) Diff(ctx context.Context, objs []*unstructured.Unstructured, opts ApplyOptions) ([]Comparison, error) {
+	opts.DryRun = true
+	applyOpts := applyOptions(opts)
+
+	comparisons := make([]Comparison, 0, len(objs))
+	for _, obj := range objs {
+		if err := ctx.Err(); err != nil {
+			return comparisons, err
+		}
+		comparison, err := s.apply(ctx, obj, opts.Namespace, applyOpts)
+		if err != nil {
+			comparison.Action = Failed
+			comparison.Error = err.Error()
+		}
+		comparisons = append(comparisons, comparison)
+	}
+	return comparisons, nil
+}
+
+func applyOptions(opts ApplyOptions) metav1.ApplyOptions {
+	if opts.FieldManager == "" {
+		opts.FieldManager = DefaultFieldManager
+	}
+	applyOpts := metav1.ApplyOptions{FieldManager: opts.FieldManager, Force: opts.Force}
+	if opts.DryRun {
+		applyOpts.DryRun = []string{metav1.DryRunAll}
+	}
+	return applyOpts
+}
+
+func (s *service) apply(ctx context.Context, obj *unstructured.Unstructured, namespace string, opts metav1.ApplyOptions) (Comparison, error) {
+	comparison := Comparison{Result: newResult(obj)}
+	if obj.GetName() == "" {
+		return comparison, errs.Validationf("server-side apply requires metadata.name")
+	}
+
+	client, err := s.resourceFor(obj, namespace)
+	if err != nil {
+		return comparison, err
+	}
+	comparison.Namespace = obj.GetNamespace()
+
+	existing, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return comparison, fmt.Errorf("failed to get %s: %w", comparison.Ref(), err)
+	}
+	if err == nil {
+		comparison.Live = existing
+	}
+
+	applied, err := client.Apply(ctx, obj.GetName(), obj, opts)
+	if err != nil {
+		return comparison, fmt.Errorf("failed to apply %s: %w", comparison.Ref(), err)
+	}
+	comparison.Applied = applied
+
+	switch {
+	case comparison.Live == nil:
+		comparison.Action = Created
+	case unchanged(comparison.Live, applied, len(opts.DryRun) > 0):
+		comparison.Action = Unchanged
+	default:
+		comparison.Action = Configured
+	}
+	return comparison, nil
+}
+
+// unchanged reports whether an apply left the object as it was. Dry runs do not bump the
+// resource version, so their result is compared by content instead.
+func unchanged(before, after *unstructured.Unstructured, dryRun bool) bool {
+	if !dryRun {
+		return before.GetResourceVersion() == after.GetResourceVersion()
+	}
+	a, b := before.DeepCopy(), after.DeepCopy()
+	for _, obj := range []*unstructured.Unstructured{a, b} {
+		obj.SetManagedFields(nil)
+		obj.SetResourceVersion("")
+		obj.SetGeneration(0)
+	}
+	return equality.Semantic.DeepEqual(a.Object, b.Object)
+}
+
+// Update replaces an object, failing if it changed since it was read
+func (s *service) Update(ctx context.Context, obj *unstructured.Unstructured, dryRun bool) (*unstructured.Unstructured, error) {
+	client, err := s.resourceFor(obj, obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := metav1.UpdateOptions{FieldManager: DefaultFieldManager}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	updated, err := client.Update(ctx, obj, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", newResult(obj).Ref(), err)
+	}
+	return updated, nil
+}
+
+// Patch applies a patch of the given type to an object
+func (s *service) Patch(ctx context.Context, obj *unstructured.Unstructured, patchType types.PatchType, data []byte, dryRun bool) (*unstructured.Unstructured, error) {
+	client, err := s.resourceFor(obj, obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := metav1.PatchOptions{FieldManager: DefaultFieldManager}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	patched, err := client.Patch(ctx, obj.GetName(), patchType, data, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch %s: %w", newResult(obj).Ref(), err)
+	}
+	return patched, nil
+}
+
+// SetMetadata adds, changes or removes labels or annotations on objects with a merge patch
+func (s *service) SetMetadata(ctx context.Context, objs []*unstructured.Unstructured, opts MetadataOptions) ([]Result, error) {
+	if opts.Field != Labels && opts.Field != Annotations {
+		return nil, fmt.Errorf("unsupported metadata field: %s", opts.Field)
+	}
+
+	results := make([]Result, len(objs))
+	err := forEach(ctx, len(objs), opts.Concurrency, func(i int) {
+		obj := objs[i]
+		result := newResult(obj)
+		current := obj.GetLabels()
+		if opts.Field == Annotations {
+			current = obj.GetAnnotations()
+		}
+
+		// A null value removes the key in a JSON merge patch
+		changes := make(map[string]interface{})
+		var conflict string
+		for key, value := range opts.Set {
+			existing, ok := current[key]
+			if ok && existing == value {
+				continue
+			}
+			if ok && !opts.Overwrite {
+				conflict = fmt.Sprintf("%s %q already has value %q, use --overwrite to change it", opts.Field, key, existing)
+				break
+			}
+			changes[key] = value
+		}
+		for _, key := range opts.Remove {
+			if _, ok := current[key]; ok {
+				changes[key] = nil
+			}
+		}
+
+		switch {
+		case conflict != "":
+			result.Action, result.Error = Failed, conflict
+		case len(changes) == 0:
+			result.Action = Unchanged
+		default:
+			data, err := json.Marshal(map[string]interface{}{
+				"metadata": map[string]interface{}{string(opts.Field): changes},
+			})
+			if err == nil {
+				_, err = s.Patch(ctx, obj, types.MergePatchType, data, opts.DryRun)
+			}
+			if err != nil {
+				result.Action, result.Error = Failed, err.Error()
+			} else {
+				result.Action = Configured
+			}
+		}
+		results[i] = result
+	})
+	return completed(results), err
+}
+
+// Delete deletes objects, optionally waiting until they are gone
+func (s *service) Delete(ctx context.Context, objs []*unstructured.Unstructured, opts DeleteOptions) ([]Result, error) {
+	deleteOpts := metav1.DeleteOptions{}
+	if opts.Propagation != "" {
+		deleteOpts.PropagationPolicy = &opts.Propagation
+	}
+	if opts.DryRun {
+		deleteOpts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	results := make([]Result, len(objs))
+	uids := make([]types.UID, len(objs))
+	clients := make([]dynamic.ResourceInterface, len(objs))
+	err := forEach(ctx, len(objs), opts.Concurrency, func(i int) {
+		obj := objs[i]
+		result := newResult(obj)
+		client, err := s.resourceFor(obj, opts.Namespace)
+		if err != nil {
+			result.Action, result.Error = Failed, err.Error()
+			results[i] = result
+			return
+		}
+		result.Namespace = obj.GetNamespace()
+
+		// Deleting by UID avoids removing an object recreated under the same name meanwhile
+		objOpts := deleteOpts
+		if uid := obj.GetUID(); uid != "" {
+			objOpts.Preconditions = &metav1.Preconditions{UID: &uid}
+		}
+
+		err = client.Delete(ctx, obj.GetName(), objOpts)
+		switch {
+		case err == nil:
+			result.Action = Deleted
+			clients[i], uids[i] = client, obj.GetUID()
+		case apierrors.IsNotFound(err) && opts.IgnoreNotFound:
+			result.Action = NotFound
+		default:
+			result.Action, result.Error = Failed, fmt.Sprintf("failed to delete %s: %v", result.Ref(), err)
+		}
+		results[i] = result
+	})
+	if err != nil || !opts.Wait || opts.DryRun {
+		return completed(results), err
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	// Every object is waited on so the timeout is reported on each one still present
+	_ = forEach(context.WithoutCancel(ctx), len(objs), opts.Concurrency, func(i int) {
+		if clients[i] == nil {
+			return
+		}
+		if err := waitForDeletion(ctx, clients[i], results[i].Name, uids[i]); err != nil {
+			results[i].Action = Failed
+			results[i].Error = fmt.Sprintf("%s was not removed: %v", results[i].Ref(), err)
+		}
+	})
+	return results, nil
+}
+
+// waitForDeletion polls until the object is gone or replaced by one with a different UID
+func waitForDeletion(ctx context.Context, client dynamic.ResourceInterface, name string, uid types.UID) error {
+	for {
+		obj, err := client.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && uid != "" && obj.GetUID() != uid) {
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(deletionPollInterval):
+		}
+	}
+}
+
+func newResult(obj *unstructured.Unstructured) Result {
+	return Result{
+		Group:     obj.GroupVersionKind().Group,
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
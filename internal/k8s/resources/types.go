@@ -0,0 +1,157 @@
+package resources
+
+import (
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultFieldManager is the field manager recorded for changes made by k8stool
+const DefaultFieldManager = "k8stool"
+
+// Action describes what happened to an object
+type Action string
+
+const (
+	// Created means the object did not exist before
+	Created Action = "created"
+	// Configured means an existing object was changed
+	Configured Action = "configured"
+	// Unchanged means the object already matched
+	Unchanged Action = "unchanged"
+	// Deleted means the object was deleted
+	Deleted Action = "deleted"
+	// Restarted means the pods of the object are being replaced
+	Restarted Action = "restarted"
+	// NotFound means the object did not exist
+	NotFound Action = "not found"
+	// Failed means the operation returned an error
+	Failed Action = "failed"
+)
+
+// Document is an object read from a manifest together with where it was read from
+type Document struct {
+	// Source is the file or URL, or stdin
+	Source string
+
+	// Index is the 1-based position of the YAML document in its source; the items of a
+	// List share the index of the List
+	Index int
+
+	Object *unstructured.Unstructured
+}
+
+// ApplyOptions configures server-side apply
+type ApplyOptions struct {
+	// Namespace is used for namespaced objects that do not set one
+	Namespace string
+
+	// FieldManager owns the applied fields; DefaultFieldManager when empty
+	FieldManager string
+
+	// Force takes ownership of fields managed by other field managers
+	Force bool
+
+	// DryRun submits the request without persisting it
+	DryRun bool
+}
+
+// ListOptions selects the objects of a resource type
+type ListOptions struct {
+	Namespace     string
+	AllNamespaces bool
+	Selector      string
+}
+
+// DeleteOptions configures deletion
+type DeleteOptions struct {
+	// Namespace is used for namespaced objects that do not set one
+	Namespace string
+
+	// Propagation controls how dependents are deleted: Background, Foreground or Orphan
+	Propagation metav1.DeletionPropagation
+
+	// IgnoreNotFound reports missing objects as not found instead of failed
+	IgnoreNotFound bool
+
+	// Wait blocks until every deleted object is gone, up to Timeout
+	Wait    bool
+	Timeout time.Duration
+
+	// DryRun submits the request without persisting it
+	DryRun bool
+
+	// Concurrency is how many objects are deleted at a time; DefaultConcurrency when zero
+	Concurrency int
+}
+
+// MetadataField is the metadata map changed by SetMetadata
+type MetadataField string
+
+const (
+	// Labels changes metadata.labels
+	Labels MetadataField = "labels"
+	// Annotations changes metadata.annotations
+	Annotations MetadataField = "annotations"
+)
+
+// MetadataOptions configures a label or annotation change
+type MetadataOptions struct {
+	Field MetadataField
+
+	// Set adds or changes keys
+	Set map[string]string
+
+	// Remove deletes keys; missing keys are ignored
+	Remove []string
+
+	// Overwrite allows changing keys that already have a different value
+	Overwrite bool
+
+	// DryRun submits the request without persisting it
+	DryRun bool
+
+	// Concurrency is how many objects are changed at a time; DefaultConcurrency when zero
+	Concurrency int
+}
+
+// RestartOptions configures a restart
+type RestartOptions struct {
+	// DryRun submits the request without persisting it
+	DryRun bool
+
+	// Concurrency is how many objects are restarted at a time; DefaultConcurrency when zero
+	Concurrency int
+}
+
+// Result is the outcome of an operation on a single object
+type Result struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Action    Action `json:"action"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Ref returns the object reference in kind.group/name form, e.g. deployment.apps/web
+func (r Result) Ref() string {
+	kind := strings.ToLower(r.Kind)
+	if r.Group != "" {
+		kind += "." + r.Group
+	}
+	return kind + "/" + r.Name
+}
+
+// Comparison is the outcome of a dry-run apply together with the objects before and after
+type Comparison struct {
+	Result
+
+	// Live is the object in the cluster, nil if it does not exist yet
+	Live *unstructured.Unstructured
+
+	// Applied is the object as it would be after the apply, nil if the apply failed
+	Applied *unstructured.Unstructured
+}
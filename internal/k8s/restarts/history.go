@@ -0,0 +1,167 @@
+package restarts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+)
+
+// Retention is how long samples are kept, and MaxSamples how many are kept per workload
+const (
+	Retention  = 30 * 24 * time.Hour
+	MaxSamples = 1000
+)
+
+// History is the samples recorded for each workload by Key, oldest first
+type History map[string][]Sample
+
+// Key identifies a workload in the history
+func Key(contextName, namespace, kind, name string) string {
+	return strings.Join([]string{contextName, namespace, kind, name}, "/")
+}
+
+// DefaultPath returns the file the history is kept in, under the user cache directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the cache directory: %w", err)
+	}
+	return filepath.Join(dir, "k8stool", "restarts.json"), nil
+}
+
+// Load reads the history file; a missing file has no history
+func Load(path string) (History, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return History{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read restart history: %w", err)
+	}
+	history := History{}
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, errs.New(errs.Validation, "failed to read restart history from %s: %v", path, err)
+	}
+	return history, nil
+}
+
+// Save writes the history file, replacing it atomically
+func Save(path string, history History) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to write restart history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to write restart history: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write restart history: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write restart history: %w", err)
+	}
+	return nil
+}
+
+// Record adds a sample of the workload key and drops the samples past Retention, of every
+// workload, and past MaxSamples
+func (h History) Record(key string, sample Sample) {
+	h[key] = append(h[key], sample)
+	cutoff := sample.Time.Add(-Retention)
+	for k, samples := range h {
+		sort.SliceStable(samples, func(i, j int) bool { return samples[i].Time.Before(samples[j].Time) })
+		first := sort.Search(len(samples), func(i int) bool { return samples[i].Time.After(cutoff) })
+		if len(samples)-first > MaxSamples {
+			first = len(samples) - MaxSamples
+		}
+		if first == len(samples) {
+			delete(h, k)
+			continue
+		}
+		h[k] = samples[first:]
+	}
+}
+
+// NewReport computes the restarts since a time from the samples of a workload, oldest first,
+// the last one being the current counters. A counter going up between two samples is that
+// many restarts; the counters of a pod first seen in a sample are all restarts since the one
+// before, as the pod was created in between.
+func NewReport(samples []Sample, since time.Time) *Report {
+	report := &Report{Since: since, Intervals: []Interval{}, Containers: []ContainerDelta{}}
+	if len(samples) == 0 {
+		return report
+	}
+
+	start := 0
+	for i, s := range samples {
+		if !s.Time.After(since) {
+			start = i
+		}
+	}
+	window := samples[start:]
+	report.From, report.Until, report.Samples = window[0].Time, window[len(window)-1].Time, len(window)
+
+	type containerKey struct{ podUID, container string }
+	deltas := make(map[containerKey]*ContainerDelta)
+	lastSeen := make(map[containerKey]int)
+	var previous map[containerKey]int32
+	for i, sample := range window {
+		current := make(map[containerKey]int32, len(sample.Containers))
+		var restarts int32
+		for _, c := range sample.Containers {
+			k := containerKey{c.PodUID, c.Container}
+			current[k] = c.Restarts
+			lastSeen[k] = i
+			if deltas[k] == nil {
+				deltas[k] = &ContainerDelta{Pod: c.Pod, Container: c.Container}
+			}
+			deltas[k].Lifetime = c.Restarts
+			if i == 0 {
+				continue
+			}
+			delta := c.Restarts
+			if before, ok := previous[k]; ok && before <= c.Restarts {
+				delta -= before
+			}
+			deltas[k].Restarts += delta
+			restarts += delta
+		}
+		if i > 0 {
+			report.Intervals = append(report.Intervals, Interval{From: window[i-1].Time, Until: sample.Time, Restarts: restarts})
+			report.Restarts += restarts
+		}
+		previous = current
+	}
+
+	for k, d := range deltas {
+		d.Gone = lastSeen[k] != len(window)-1
+		if d.Gone && d.Restarts == 0 {
+			continue
+		}
+		if !d.Gone {
+			report.Lifetime += d.Lifetime
+		}
+		report.Containers = append(report.Containers, *d)
+	}
+	sort.Slice(report.Containers, func(i, j int) bool {
+		a, b := report.Containers[i], report.Containers[j]
+		if a.Gone != b.Gone {
+			return !a.Gone
+		}
+		if a.Pod != b.Pod {
+			return a.Pod < b.Pod
+		}
+		return a.Container < b.Container
+	})
+	return report
+}
@@ -0,0 +1,22 @@
+package restarts
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for reading the restart counters of workloads
+type Service interface {
+	// Sample reads the restart counters of the containers of a workload's pods
+	Sample(ctx context.Context, opts Options) (*Sample, error)
+}
+
+// NewRestartsService creates a new restarts service instance
+func NewRestartsService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
@@ -0,0 +1,97 @@
+package restarts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new restarts service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// Sample reads the restart counters of a workload. Init containers are included, since
+// sidecars run as init containers and restart like any other container.
+func (s *service) Sample(ctx context.Context, opts Options) (*Sample, error) {
+	selector, err := s.selector(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of %s %q: %w", opts.Kind, opts.Name, err)
+	}
+	podList, err := s.clientset.CoreV1().Pods(opts.Namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	sample := &Sample{Time: time.Now(), Containers: []ContainerRestarts{}}
+	for _, pod := range podList.Items {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			sample.Containers = append(sample.Containers, ContainerRestarts{
+				Pod:       pod.Name,
+				PodUID:    string(pod.UID),
+				Container: status.Name,
+				Restarts:  status.RestartCount,
+			})
+		}
+	}
+	sort.Slice(sample.Containers, func(i, j int) bool {
+		a, b := sample.Containers[i], sample.Containers[j]
+		if a.Pod != b.Pod {
+			return a.Pod < b.Pod
+		}
+		return a.Container < b.Container
+	})
+	return sample, nil
+}
+
+// selector returns the pod selector of a deployment, statefulset or daemonset
+func (s *service) selector(ctx context.Context, opts Options) (*metav1.LabelSelector, error) {
+	apps := s.clientset.AppsV1()
+	var selector *metav1.LabelSelector
+	var err error
+	switch opts.Kind {
+	case "deployment":
+		d, getErr := apps.Deployments(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			selector = d.Spec.Selector
+		}
+	case "statefulset":
+		sts, getErr := apps.StatefulSets(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			selector = sts.Spec.Selector
+		}
+	case "daemonset":
+		ds, getErr := apps.DaemonSets(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			selector = ds.Spec.Selector
+		}
+	default:
+		return nil, errs.Validationf("unsupported kind %q: must be deployment, statefulset or daemonset", opts.Kind)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errs.NotFoundf("%s %q not found in namespace %q", opts.Kind, opts.Name, opts.Namespace)
+		}
+		return nil, fmt.Errorf("failed to get %s: %w", opts.Kind, err)
+	}
+	return selector, nil
+}
@@ -0,0 +1,71 @@
+package restarts
+
+import "time"
+
+// Options selects the workload to sample
+type Options struct {
+	Namespace string
+	// Kind is deployment, statefulset or daemonset
+	Kind string
+	Name string
+}
+
+// Sample is the restart counters of the containers of a workload at one time
+type Sample struct {
+	Time       time.Time           `json:"time"`
+	Containers []ContainerRestarts `json:"containers"`
+}
+
+// ContainerRestarts is the restart counter of a container. The pod UID tells a statefulset
+// pod recreated under the same name, whose counter starts again from zero, from the old one.
+type ContainerRestarts struct {
+	Pod       string `json:"pod"`
+	PodUID    string `json:"podUID"`
+	Container string `json:"container"`
+	Restarts  int32  `json:"restarts"`
+}
+
+// Report is the restarts of a workload over a period, computed from the samples recorded
+// between invocations
+type Report struct {
+	Context   string `json:"context"`
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+
+	// Since is the start of the period asked for. From is the sample restarts are counted
+	// from: the last one before Since, or the first one after it when the history is shorter.
+	Since time.Time `json:"since"`
+	From  time.Time `json:"from"`
+	Until time.Time `json:"until"`
+
+	// Samples is the number of samples from From to Until
+	Samples int `json:"samples"`
+
+	// Restarts is the number of restarts from From to Until, and Lifetime the sum of the
+	// counters of the current pods
+	Restarts int32 `json:"restarts"`
+	Lifetime int32 `json:"lifetime"`
+
+	Intervals  []Interval       `json:"intervals"`
+	Containers []ContainerDelta `json:"containers"`
+}
+
+// Interval is the restarts between two consecutive samples
+type Interval struct {
+	From     time.Time `json:"from"`
+	Until    time.Time `json:"until"`
+	Restarts int32     `json:"restarts"`
+}
+
+// ContainerDelta is the restarts of one container over the period of a report
+type ContainerDelta struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Restarts  int32  `json:"restarts"`
+	Lifetime  int32  `json:"lifetime"`
+
+	// Gone is set for the containers of pods no longer in the last sample; restarts after
+	// the last sample they were in are not known
+	Gone bool `json:"gone,omitempty"`
+}
@@ -0,0 +1,22 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+
+	"k8stool/internal/k8s/resources"
+)
+
+// Service defines the interface for image vulnerability scans
+type Service interface {
+	// Scan runs trivy against every unique image of a workload
+	Scan(ctx context.Context, opts Options) (*Report, error)
+}
+
+// NewScanService creates a new scan service instance
+func NewScanService(resourceService resources.Service) (Service, error) {
+	if resourceService == nil {
+		return nil, fmt.Errorf("resource service is required")
+	}
+	return newService(resourceService), nil
+}
@@ -0,0 +1,185 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+	"k8stool/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// podSpecPaths are the fields holding the pod spec of each workload kind
+var podSpecPaths = map[string][]string{
+	"Pod":                   {"spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+type service struct {
+	resourceService resources.Service
+}
+
+// newService creates a new scan service instance
+func newService(resourceService resources.Service) Service {
+	return &service{
+		resourceService: resourceService,
+	}
+}
+
+// trivyReport is the subset of trivy's JSON output that is used
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID  string
+			PkgName          string
+			InstalledVersion string
+			FixedVersion     string
+			Severity         string
+			Title            string
+		}
+	}
+}
+
+// Scan runs trivy against every unique image of a workload
+func (s *service) Scan(ctx context.Context, opts Options) (*Report, error) {
+	resourceType, name, ok := strings.Cut(opts.Workload, "/")
+	if !ok || resourceType == "" || name == "" {
+		return nil, errs.Validationf("workload must be TYPE/NAME, got %q", opts.Workload)
+	}
+
+	trivy := opts.Trivy
+	if trivy == "" {
+		trivy = "trivy"
+	}
+	trivy, err := exec.LookPath(trivy)
+	if err != nil {
+		return nil, fmt.Errorf("trivy not found, install it from https://trivy.dev: %w", err)
+	}
+
+	obj, err := s.resourceService.Get(ctx, resourceType, opts.Namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := podSpec(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Namespace: obj.GetNamespace(),
+		Workload:  obj.GetKind() + "/" + obj.GetName(),
+	}
+
+	// Containers frequently share images, e.g. init containers running the app's migrations
+	scanned := make(map[string]*ContainerReport)
+	containers := append([]corev1.Container{}, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, c := range containers {
+		result, ok := scanned[c.Image]
+		if !ok {
+			result = scanImage(ctx, trivy, c.Image)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			scanned[c.Image] = result
+		}
+		cr := *result
+		cr.Container = c.Name
+		report.Containers = append(report.Containers, cr)
+	}
+
+	return report, nil
+}
+
+// podSpec extracts the pod spec of a workload object
+func podSpec(obj *unstructured.Unstructured) (*corev1.PodSpec, error) {
+	path, ok := podSpecPaths[obj.GetKind()]
+	if !ok {
+		return nil, errs.Validationf("%s is not a workload with containers", obj.GetKind())
+	}
+	raw, found, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil || !found {
+		return nil, fmt.Errorf("failed to read pod spec of %s/%s", obj.GetKind(), obj.GetName())
+	}
+	spec := &corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, spec); err != nil {
+		return nil, fmt.Errorf("failed to decode pod spec: %w", err)
+	}
+	return spec, nil
+}
+
+// scanImage runs trivy against an image, recording failures in the report instead of returning them
+func scanImage(ctx context.Context, trivy, image string) *ContainerReport {
+	result := &ContainerReport{Image: image, Counts: make(map[Severity]int)}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, trivy, "image", "--quiet", "--format", "json", "--scanners", "vuln", image)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			err = errors.New(utils.LastLine(stderr.String()))
+		}
+		result.Error = fmt.Sprintf("scan failed: %v", err)
+		return result
+	}
+
+	var parsed trivyReport
+	if err := json.Unmarshal(stdout.Bytes(), &parsed); err != nil {
+		result.Error = fmt.Sprintf("failed to parse trivy output: %v", err)
+		return result
+	}
+
+	// The same CVE is reported once per target, e.g. for a library vendored into several binaries
+	seen := make(map[string]bool)
+	for _, r := range parsed.Results {
+		for _, v := range r.Vulnerabilities {
+			key := v.VulnerabilityID + "/" + v.PkgName + "/" + v.InstalledVersion
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			severity := Severity(v.Severity)
+			if severity.Rank() == 0 {
+				severity = Unknown
+			}
+			result.Counts[severity]++
+			result.Vulnerabilities = append(result.Vulnerabilities, Vulnerability{
+				ID:               v.VulnerabilityID,
+				Package:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Severity:         severity,
+				Title:            v.Title,
+			})
+		}
+	}
+
+	sort.SliceStable(result.Vulnerabilities, func(i, j int) bool {
+		a, b := result.Vulnerabilities[i], result.Vulnerabilities[j]
+		if a.Severity != b.Severity {
+			return a.Severity.Rank() > b.Severity.Rank()
+		}
+		return a.ID < b.ID
+	})
+
+	return result
+}
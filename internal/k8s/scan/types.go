@@ -0,0 +1,92 @@
+package scan
+
+import (
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+)
+
+// Severity is a vulnerability severity as reported by trivy
+type Severity string
+
+const (
+	Unknown  Severity = "UNKNOWN"
+	Low      Severity = "LOW"
+	Medium   Severity = "MEDIUM"
+	High     Severity = "HIGH"
+	Critical Severity = "CRITICAL"
+)
+
+// Severities lists all severities from least to most serious
+var Severities = []Severity{Unknown, Low, Medium, High, Critical}
+
+// Rank orders severities, starting at 1 for Unknown; invalid severities rank 0
+func (s Severity) Rank() int {
+	for i, severity := range Severities {
+		if s == severity {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ParseSeverity converts a case-insensitive severity name into a Severity
+func ParseSeverity(name string) (Severity, error) {
+	if s := Severity(strings.ToUpper(name)); s.Rank() > 0 {
+		return s, nil
+	}
+	return "", errs.Validationf("invalid severity %q: must be one of low, medium, high, critical", name)
+}
+
+// Options configures a workload scan
+type Options struct {
+	Namespace string
+
+	// Workload is TYPE/NAME, e.g. deploy/web or statefulsets.apps/db
+	Workload string
+
+	// Trivy is the trivy binary to run; defaults to trivy on the PATH
+	Trivy string
+}
+
+// Report holds the scan results of a workload
+type Report struct {
+	Namespace  string            `json:"namespace"`
+	Workload   string            `json:"workload"`
+	Containers []ContainerReport `json:"containers"`
+}
+
+// ContainerReport holds the vulnerabilities found in the image of one container
+type ContainerReport struct {
+	Container string `json:"container"`
+	Image     string `json:"image"`
+
+	// Counts holds the number of vulnerabilities per severity
+	Counts map[Severity]int `json:"counts"`
+
+	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty"`
+
+	// Error is set when the image could not be scanned
+	Error string `json:"error,omitempty"`
+}
+
+// Vulnerability is a single CVE found in a package of an image
+type Vulnerability struct {
+	ID               string   `json:"id"`
+	Package          string   `json:"package"`
+	InstalledVersion string   `json:"installedVersion"`
+	FixedVersion     string   `json:"fixedVersion,omitempty"`
+	Severity         Severity `json:"severity"`
+	Title            string   `json:"title,omitempty"`
+}
+
+// AtLeast returns the number of vulnerabilities with the given severity or a more serious one
+func (c ContainerReport) AtLeast(severity Severity) int {
+	total := 0
+	for s, n := range c.Counts {
+		if s.Rank() >= severity.Rank() {
+			total += n
+		}
+	}
+	return total
+}
@@ -0,0 +1,22 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for pod scheduling analysis
+type Service interface {
+	// WhyPending explains which scheduling constraints exclude which nodes for a pod
+	WhyPending(ctx context.Context, namespace, name string) (*Analysis, error)
+}
+
+// NewSchedulingService creates a new scheduling analysis service instance
+func NewSchedulingService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
@@ -0,0 +1,335 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new scheduling analysis service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// WhyPending explains which scheduling constraints exclude which nodes for a pod
+func (s *service) WhyPending(ctx context.Context, namespace, name string) (*Analysis, error) {
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	requests := podRequests(pod)
+	analysis := &Analysis{
+		Name:          pod.Name,
+		Namespace:     pod.Namespace,
+		Phase:         string(pod.Status.Phase),
+		NodeName:      pod.Spec.NodeName,
+		CPURequest:    requests.Cpu().MilliValue(),
+		MemoryRequest: requests.Memory().Value(),
+	}
+
+	if analysis.SchedulerMessages, err = s.schedulerMessages(ctx, pod); err != nil {
+		return nil, err
+	}
+
+	if analysis.PodIssues, err = s.podIssues(ctx, pod); err != nil {
+		return nil, err
+	}
+
+	nodeList, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	// Only pods that still hold resources count against node allocatable
+	podList, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	used := make(map[string]corev1.ResourceList)
+	podCount := make(map[string]int64)
+	for i := range podList.Items {
+		p := &podList.Items[i]
+		if p.Spec.NodeName == "" || p.UID == pod.UID {
+			continue
+		}
+		addResources(used, p.Spec.NodeName, podRequests(p))
+		podCount[p.Spec.NodeName]++
+	}
+
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		analysis.Nodes = append(analysis.Nodes, NodeFit{
+			Name:    node.Name,
+			Reasons: nodeReasons(pod, node, requests, used[node.Name], podCount[node.Name]),
+		})
+	}
+
+	sort.Slice(analysis.Nodes, func(i, j int) bool {
+		return analysis.Nodes[i].Name < analysis.Nodes[j].Name
+	})
+
+	return analysis, nil
+}
+
+// schedulerMessages returns the distinct FailedScheduling messages of a pod, newest first
+func (s *service) schedulerMessages(ctx context.Context, pod *corev1.Pod) ([]string, error) {
+	events, err := s.clientset.CoreV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.name=%s,involvedObject.kind=Pod,reason=FailedScheduling", pod.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod events: %w", err)
+	}
+
+	items := events.Items
+	sort.Slice(items, func(i, j int) bool {
+		return eventTime(items[i]).After(eventTime(items[j]).Time)
+	})
+
+	seen := make(map[string]bool)
+	var messages []string
+	for _, e := range items {
+		if e.InvolvedObject.UID != "" && e.InvolvedObject.UID != pod.UID {
+			continue // Event of an older pod with the same name
+		}
+		if !seen[e.Message] {
+			seen[e.Message] = true
+			messages = append(messages, e.Message)
+		}
+	}
+	return messages, nil
+}
+
+func eventTime(e corev1.Event) metav1.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp
+	}
+	return metav1.NewTime(e.EventTime.Time)
+}
+
+// podIssues finds problems that block scheduling on every node
+func (s *service) podIssues(ctx context.Context, pod *corev1.Pod) ([]string, error) {
+	var issues []string
+
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim == nil {
+			continue
+		}
+		claim := v.PersistentVolumeClaim.ClaimName
+		pvc, err := s.clientset.CoreV1().PersistentVolumeClaims(pod.Namespace).Get(ctx, claim, metav1.GetOptions{})
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("persistent volume claim %s: %v", claim, err))
+			continue
+		}
+		if pvc.Status.Phase == corev1.ClaimBound {
+			continue
+		}
+		if pvc.Spec.StorageClassName != nil {
+			sc, err := s.clientset.StorageV1().StorageClasses().Get(ctx, *pvc.Spec.StorageClassName, metav1.GetOptions{})
+			if err == nil && sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+				// These claims are only bound once the pod is scheduled
+				continue
+			}
+		}
+		issues = append(issues, fmt.Sprintf("persistent volume claim %s is not bound (%s)", claim, pvc.Status.Phase))
+	}
+
+	if affinity := pod.Spec.Affinity; affinity != nil && (affinity.PodAffinity != nil || affinity.PodAntiAffinity != nil) {
+		issues = append(issues, "pod affinity/anti-affinity rules are not evaluated here; check the scheduler messages")
+	}
+	if len(pod.Spec.TopologySpreadConstraints) > 0 {
+		issues = append(issues, "topology spread constraints are not evaluated here; check the scheduler messages")
+	}
+
+	return issues, nil
+}
+
+// nodeReasons returns every constraint that excludes the node for the pod
+func nodeReasons(pod *corev1.Pod, node *corev1.Node, requests, used corev1.ResourceList, pods int64) []string {
+	var reasons []string
+
+	if pod.Spec.NodeName != "" && pod.Spec.NodeName != node.Name {
+		reasons = append(reasons, fmt.Sprintf("pod is bound to node %s", pod.Spec.NodeName))
+	}
+
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady && c.Status != corev1.ConditionTrue {
+			reasons = append(reasons, "node is not ready")
+		}
+	}
+
+	if node.Spec.Unschedulable && !tolerates(pod, &corev1.Taint{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}) {
+		reasons = append(reasons, "node is cordoned")
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule || taint.Key == corev1.TaintNodeUnschedulable {
+			continue
+		}
+		if !tolerates(pod, taint) {
+			reasons = append(reasons, fmt.Sprintf("untolerated taint %s", taint.ToString()))
+		}
+	}
+
+	for k, v := range pod.Spec.NodeSelector {
+		if actual, ok := node.Labels[k]; !ok || actual != v {
+			reasons = append(reasons, fmt.Sprintf("nodeSelector %s=%s does not match", k, v))
+		}
+	}
+
+	if affinity := pod.Spec.Affinity; affinity != nil && affinity.NodeAffinity != nil {
+		if required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			if !matchesNodeSelectorTerms(node, required.NodeSelectorTerms) {
+				reasons = append(reasons, "required node affinity does not match")
+			}
+		}
+	}
+
+	allocatable := node.Status.Allocatable
+	if cpu := requests.Cpu().MilliValue(); cpu > 0 {
+		free := allocatable.Cpu().MilliValue() - used.Cpu().MilliValue()
+		if cpu > free {
+			reasons = append(reasons, fmt.Sprintf("insufficient cpu (requested %dm, free %dm)", cpu, free))
+		}
+	}
+	if mem := requests.Memory().Value(); mem > 0 {
+		free := allocatable.Memory().Value() - used.Memory().Value()
+		if mem > free {
+			reasons = append(reasons, fmt.Sprintf("insufficient memory (requested %dMi, free %dMi)", mem/(1024*1024), free/(1024*1024)))
+		}
+	}
+	if maxPods := allocatable.Pods().Value(); maxPods > 0 && pods >= maxPods {
+		reasons = append(reasons, fmt.Sprintf("too many pods (%d/%d)", pods, maxPods))
+	}
+
+	return reasons
+}
+
+func tolerates(pod *corev1.Pod, taint *corev1.Taint) bool {
+	for i := range pod.Spec.Tolerations {
+		if pod.Spec.Tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNodeSelectorTerms reports whether the node matches any of the terms
+func matchesNodeSelectorTerms(node *corev1.Node, terms []corev1.NodeSelectorTerm) bool {
+	for _, term := range terms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue // An empty term matches no objects
+		}
+		if matchesRequirements(node.Labels, term.MatchExpressions) &&
+			matchesRequirements(labels.Set{"metadata.name": node.Name}, term.MatchFields) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesRequirements(set labels.Set, requirements []corev1.NodeSelectorRequirement) bool {
+	for _, req := range requirements {
+		value, exists := set[req.Key]
+		switch req.Operator {
+		case corev1.NodeSelectorOpIn:
+			if !exists || !contains(req.Values, value) {
+				return false
+			}
+		case corev1.NodeSelectorOpNotIn:
+			if exists && contains(req.Values, value) {
+				return false
+			}
+		case corev1.NodeSelectorOpExists:
+			if !exists {
+				return false
+			}
+		case corev1.NodeSelectorOpDoesNotExist:
+			if exists {
+				return false
+			}
+		case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+			if !exists || len(req.Values) != 1 {
+				return false
+			}
+			actual, err1 := strconv.ParseInt(value, 10, 64)
+			bound, err2 := strconv.ParseInt(req.Values[0], 10, 64)
+			if err1 != nil || err2 != nil {
+				return false
+			}
+			if req.Operator == corev1.NodeSelectorOpGt && actual <= bound {
+				return false
+			}
+			if req.Operator == corev1.NodeSelectorOpLt && actual >= bound {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// podRequests returns the effective resource requests of a pod: the larger of the sum of
+// its containers and its largest init container, plus the pod overhead
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		for name, q := range c.Resources.Requests {
+			sum := total[name]
+			sum.Add(q)
+			total[name] = sum
+		}
+	}
+	for _, c := range pod.Spec.InitContainers {
+		for name, q := range c.Resources.Requests {
+			if current, ok := total[name]; !ok || q.Cmp(current) > 0 {
+				total[name] = q.DeepCopy()
+			}
+		}
+	}
+	for name, q := range pod.Spec.Overhead {
+		sum := total[name]
+		sum.Add(q)
+		total[name] = sum
+	}
+	return total
+}
+
+func addResources(used map[string]corev1.ResourceList, node string, requests corev1.ResourceList) {
+	list, ok := used[node]
+	if !ok {
+		list = corev1.ResourceList{}
+		used[node] = list
+	}
+	for name, q := range requests {
+		sum := list[name]
+		sum.Add(q)
+		list[name] = sum
+	}
+}
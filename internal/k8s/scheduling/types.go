@@ -0,0 +1,39 @@
+package scheduling
+
+// Analysis explains why a pod is or is not scheduled
+type Analysis struct {
+	Name      string
+	Namespace string
+	Phase     string
+
+	// NodeName is set when the pod has already been scheduled
+	NodeName string
+
+	// CPURequest is the effective CPU request of the pod in millicores
+	CPURequest int64
+
+	// MemoryRequest is the effective memory request of the pod in bytes
+	MemoryRequest int64
+
+	// SchedulerMessages holds the messages of FailedScheduling events, newest first
+	SchedulerMessages []string
+
+	// PodIssues lists problems that block scheduling regardless of the node
+	PodIssues []string
+
+	// Nodes holds the fit result of every node
+	Nodes []NodeFit
+}
+
+// NodeFit describes whether a pod fits on a node and why not
+type NodeFit struct {
+	Name string
+
+	// Reasons lists every constraint that excludes the node; empty means the pod fits
+	Reasons []string
+}
+
+// Fits reports whether no constraint excludes the node
+func (n NodeFit) Fits() bool {
+	return len(n.Reasons) == 0
+}
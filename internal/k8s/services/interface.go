@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for inspecting Kubernetes services
+type Service interface {
+	// Health reports the backend pods of a service and whether they receive traffic
+	Health(ctx context.Context, namespace, name string) (*Health, error)
+}
+
+// NewServiceService creates a new service inspection instance
+func NewServiceService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
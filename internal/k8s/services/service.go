@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/pods"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new service inspection instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// endpoint is the state of a pod address in the EndpointSlices of a service
+type endpoint struct {
+	ready bool
+}
+
+// Health reports the backend pods of a service and whether they receive traffic
+func (s *service) Health(ctx context.Context, namespace, name string) (*Health, error) {
+	svc, err := s.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	health := &Health{
+		Namespace: svc.Namespace,
+		Name:      svc.Name,
+		Type:      string(svc.Spec.Type),
+		ClusterIP: svc.Spec.ClusterIP,
+		Selector:  svc.Spec.Selector,
+	}
+	for _, p := range svc.Spec.Ports {
+		health.Ports = append(health.Ports, fmt.Sprintf("%d→%s/%s", p.Port, p.TargetPort.String(), p.Protocol))
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		health.Warnings = append(health.Warnings, fmt.Sprintf("ExternalName service resolves to %s and has no backends", svc.Spec.ExternalName))
+		return health, nil
+	}
+
+	endpoints, err := s.endpoints(ctx, svc)
+	if err != nil {
+		return nil, err
+	}
+
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	probeFailures, err := s.probeFailures(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+	var selectedPods []*corev1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		selected := len(svc.Spec.Selector) > 0 && selector.Matches(labels.Set(pod.Labels))
+		ep, inEndpoints := endpoints[pod.Name]
+		if !selected && !inEndpoints {
+			continue
+		}
+		if selected {
+			selectedPods = append(selectedPods, pod)
+		}
+
+		backend := Backend{
+			Pod:              pod.Name,
+			Node:             pod.Spec.NodeName,
+			IP:               pod.Status.PodIP,
+			Phase:            string(pod.Status.Phase),
+			Ready:            pods.IsReady(pod),
+			Terminating:      pod.DeletionTimestamp != nil,
+			Selected:         selected,
+			InEndpoints:      inEndpoints,
+			EndpointReady:    ep.ready,
+			LastProbeFailure: probeFailures[pod.Name],
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			backend.Restarts += cs.RestartCount
+		}
+		health.Backends = append(health.Backends, backend)
+	}
+
+	sort.Slice(health.Backends, func(i, j int) bool {
+		return health.Backends[i].Pod < health.Backends[j].Pod
+	})
+
+	health.Warnings = append(health.Warnings, warnings(svc, selectedPods, health)...)
+	return health, nil
+}
+
+// endpoints returns the pod endpoints of a service by pod name
+func (s *service) endpoints(ctx context.Context, svc *corev1.Service) (map[string]endpoint, error) {
+	slices, err := s.clientset.DiscoveryV1().EndpointSlices(svc.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + svc.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoint slices: %w", err)
+	}
+
+	endpoints := make(map[string]endpoint)
+	for _, slice := range slices.Items {
+		for _, e := range slice.Endpoints {
+			if e.TargetRef == nil || e.TargetRef.Kind != "Pod" {
+				continue
+			}
+			// A missing ready condition means ready
+			ready := e.Conditions.Ready == nil || *e.Conditions.Ready
+			endpoints[e.TargetRef.Name] = endpoint{ready: endpoints[e.TargetRef.Name].ready || ready}
+		}
+	}
+	return endpoints, nil
+}
+
+// probeFailures returns the most recent failed probe event per pod
+func (s *service) probeFailures(ctx context.Context, namespace string) (map[string]*ProbeFailure, error) {
+	events, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "involvedObject.kind=Pod,reason=Unhealthy",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	failures := make(map[string]*ProbeFailure)
+	for _, e := range events.Items {
+		last := e.LastTimestamp.Time
+		if last.IsZero() {
+			last = e.EventTime.Time
+		}
+		if current, ok := failures[e.InvolvedObject.Name]; ok && !last.After(current.Time) {
+			continue
+		}
+		count := e.Count
+		if e.Series != nil {
+			count = e.Series.Count
+		}
+		failures[e.InvolvedObject.Name] = &ProbeFailure{
+			Message: strings.Join(strings.Fields(e.Message), " "),
+			Count:   max(count, 1),
+			Time:    last,
+		}
+	}
+	return failures, nil
+}
+
+// warnings finds problems that affect every backend of the service
+func warnings(svc *corev1.Service, selected []*corev1.Pod, health *Health) []string {
+	var warnings []string
+
+	if len(svc.Spec.Selector) == 0 {
+		warnings = append(warnings, "service has no selector; its endpoints are managed manually")
+	} else if len(selected) == 0 {
+		warnings = append(warnings, fmt.Sprintf("selector %s matches no pods", labels.SelectorFromSet(svc.Spec.Selector)))
+	}
+
+	// Named target ports are resolved per pod; a pod without the name gets no endpoint
+	for _, p := range svc.Spec.Ports {
+		if p.TargetPort.Type != intstr.String || len(selected) == 0 {
+			continue
+		}
+		var missing int
+		for _, pod := range selected {
+			if !exposesPort(pod, p.TargetPort.StrVal) {
+				missing++
+			}
+		}
+		if missing > 0 {
+			warnings = append(warnings, fmt.Sprintf("target port %q is not declared by %d of %d selected pods", p.TargetPort.StrVal, missing, len(selected)))
+		}
+	}
+
+	if len(health.Backends) > 0 && health.Serving() == 0 {
+		warnings = append(warnings, "no backend is ready; requests to the service will fail")
+	}
+
+	return warnings
+}
+
+func exposesPort(pod *corev1.Pod, name string) bool {
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"time"
+)
+
+// Health describes a service and the pods behind it
+type Health struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	ClusterIP string            `json:"clusterIP,omitempty"`
+	Ports     []string          `json:"ports,omitempty"`
+	Selector  map[string]string `json:"selector,omitempty"`
+
+	// Backends are the pods matching the selector and any other pods listed in the endpoints
+	Backends []Backend `json:"backends"`
+
+	// Warnings are problems that affect every backend, such as a target port no pod exposes
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Serving returns the number of backends that are ready and receive traffic
+func (h *Health) Serving() int {
+	var n int
+	for _, b := range h.Backends {
+		if b.Serving() {
+			n++
+		}
+	}
+	return n
+}
+
+// Backend is a pod behind a service
+type Backend struct {
+	Pod      string `json:"pod"`
+	Node     string `json:"node,omitempty"`
+	IP       string `json:"ip,omitempty"`
+	Phase    string `json:"phase"`
+	Ready    bool   `json:"ready"`
+	Restarts int32  `json:"restarts"`
+
+	// Terminating reports whether the pod is being deleted
+	Terminating bool `json:"terminating,omitempty"`
+
+	// Selected reports whether the pod matches the service selector
+	Selected bool `json:"selected"`
+
+	// InEndpoints reports whether the pod is listed in the service's EndpointSlices
+	InEndpoints bool `json:"inEndpoints"`
+
+	// EndpointReady reports whether the endpoint is marked ready, so it receives traffic
+	EndpointReady bool `json:"endpointReady"`
+
+	// LastProbeFailure is the most recent failed probe event of the pod, nil if there is none
+	LastProbeFailure *ProbeFailure `json:"lastProbeFailure,omitempty"`
+}
+
+// Serving reports whether the backend receives traffic
+func (b Backend) Serving() bool {
+	return b.InEndpoints && b.EndpointReady
+}
+
+// ProbeFailure is a failed liveness, readiness or startup probe reported by the kubelet
+type ProbeFailure struct {
+	Message string    `json:"message"`
+	Count   int32     `json:"count"`
+	Time    time.Time `json:"time"`
+}
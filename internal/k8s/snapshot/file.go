@@ -0,0 +1,51 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"k8stool/internal/k8s/errs"
+)
+
+// Write writes a snapshot as gzipped JSON
+func Write(w io.Writer, snap *Snapshot) error {
+	gz := gzip.NewWriter(w)
+	if err := json.NewEncoder(gz).Encode(snap); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Read reads a snapshot written by Write
+func Read(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errs.New(errs.Validation, "not a snapshot file: %v", err)
+	}
+	defer gz.Close()
+
+	var snap Snapshot
+	if err := json.NewDecoder(gz).Decode(&snap); err != nil {
+		return nil, errs.New(errs.Validation, "failed to read snapshot: %v", err)
+	}
+	if snap.Version > FormatVersion {
+		return nil, errs.New(errs.Validation, "snapshot format %d is newer than this k8stool supports (%d), upgrade k8stool", snap.Version, FormatVersion)
+	}
+	return &snap, nil
+}
+
+// Load reads a snapshot file
+func Load(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
@@ -0,0 +1,23 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for capturing cluster snapshots
+type Service interface {
+	// Create captures the discovery documents and the objects of every listable resource,
+	// including events and metrics. Secret values are removed.
+	Create(ctx context.Context, opts CreateOptions) (*Snapshot, error)
+}
+
+// NewSnapshotService creates a new snapshot service instance
+func NewSnapshotService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
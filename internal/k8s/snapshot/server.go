@@ -0,0 +1,175 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// server answers read requests from a snapshot the way the API server would have answered
+// them when it was taken
+type server struct {
+	snap *Snapshot
+
+	// lists indexes the captured lists by cluster-wide path
+	lists map[string]*ResourceList
+}
+
+// Handler returns a read-only API server for a snapshot. It serves discovery, gets and lists,
+// with label and field selectors, so clients work against it unchanged. Writes, watches and
+// subresources such as logs are refused.
+func Handler(snap *Snapshot) http.Handler {
+	s := &server{snap: snap, lists: make(map[string]*ResourceList, len(snap.Resources))}
+	for i := range snap.Resources {
+		s.lists[snap.Resources[i].Path] = &snap.Resources[i]
+	}
+	return s
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeStatus(w, apierrors.NewMethodNotSupported(schema.GroupResource{Resource: "snapshot"}, r.Method),
+			"the snapshot is read-only")
+		return
+	}
+	if r.URL.Query().Get("watch") == "true" || r.URL.Query().Get("watch") == "1" {
+		writeStatus(w, apierrors.NewMethodNotSupported(schema.GroupResource{Resource: "snapshot"}, "watch"),
+			"a snapshot cannot be watched")
+		return
+	}
+
+	p := strings.TrimSuffix(r.URL.Path, "/")
+	if data, ok := s.snap.Discovery[p]; ok {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+		return
+	}
+
+	prefix, rest, ok := splitPath(p)
+	if !ok {
+		writeStatus(w, apierrors.NewNotFound(schema.GroupResource{}, p), "")
+		return
+	}
+
+	// Namespaced requests look like namespaces/NS/RESOURCE[/NAME[/SUBRESOURCE]]; a get of a
+	// namespace itself is namespaces/NAME
+	namespace := ""
+	if len(rest) >= 3 && rest[0] == "namespaces" {
+		namespace, rest = rest[1], rest[2:]
+	}
+
+	list, ok := s.lists[prefix+"/"+rest[0]]
+	if !ok {
+		writeStatus(w, apierrors.NewNotFound(schema.GroupResource{Resource: rest[0]}, ""),
+			fmt.Sprintf("%s were not captured in the snapshot", rest[0]))
+		return
+	}
+	gr := schema.FromAPIVersionAndKind(list.APIVersion, list.Kind).GroupKind()
+	resource := schema.GroupResource{Group: gr.Group, Resource: rest[0]}
+
+	switch len(rest) {
+	case 1:
+		s.serveList(w, r, list, namespace)
+	case 2:
+		for _, item := range list.Items {
+			obj := unstructured.Unstructured{Object: item}
+			if obj.GetName() == rest[1] && obj.GetNamespace() == namespace {
+				writeJSON(w, http.StatusOK, item)
+				return
+			}
+		}
+		writeStatus(w, apierrors.NewNotFound(resource, rest[1]), "")
+	default:
+		writeStatus(w, apierrors.NewNotFound(resource, rest[1]),
+			fmt.Sprintf("%s of %s/%s are not part of snapshots", rest[2], rest[0], rest[1]))
+	}
+}
+
+// serveList writes the objects of a list in a namespace, or all namespaces when empty, that
+// match the label and field selectors of the request
+func (s *server) serveList(w http.ResponseWriter, r *http.Request, list *ResourceList, namespace string) {
+	labelSelector, err := labels.Parse(r.URL.Query().Get("labelSelector"))
+	if err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()), "")
+		return
+	}
+	fieldSelector, err := fields.ParseSelector(r.URL.Query().Get("fieldSelector"))
+	if err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()), "")
+		return
+	}
+
+	items := []map[string]interface{}{}
+	for _, item := range list.Items {
+		obj := unstructured.Unstructured{Object: item}
+		if namespace != "" && obj.GetNamespace() != namespace {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(obj.GetLabels())) || !fieldSelector.Matches(fieldSet(item, fieldSelector)) {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"apiVersion": list.APIVersion,
+		"kind":       list.Kind + "List",
+		"metadata":   map[string]interface{}{},
+		"items":      items,
+	})
+}
+
+// fieldSet returns the values of the fields a selector refers to, such as spec.nodeName or
+// involvedObject.uid. Missing fields are empty.
+func fieldSet(item map[string]interface{}, selector fields.Selector) fields.Set {
+	set := fields.Set{}
+	for _, req := range selector.Requirements() {
+		value, found, _ := unstructured.NestedFieldNoCopy(item, strings.Split(req.Field, ".")...)
+		if found && value != nil {
+			set[req.Field] = fmt.Sprint(value)
+		} else {
+			set[req.Field] = ""
+		}
+	}
+	return set
+}
+
+// splitPath splits a resource path into its group version prefix, /api/v1 or
+// /apis/GROUP/VERSION, and the remaining segments
+func splitPath(p string) (string, []string, bool) {
+	parts := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	var n int
+	switch {
+	case len(parts) >= 3 && parts[0] == "api":
+		n = 2
+	case len(parts) >= 4 && parts[0] == "apis":
+		n = 3
+	default:
+		return "", nil, false
+	}
+	return "/" + strings.Join(parts[:n], "/"), parts[n:], true
+}
+
+// writeStatus writes an API error as a Status object, so clients see the same errors as from a
+// real API server. A non-empty message replaces the generic one.
+func writeStatus(w http.ResponseWriter, err *apierrors.StatusError, message string) {
+	status := err.ErrStatus
+	if message != "" {
+		status.Message = message
+	}
+	status.Kind, status.APIVersion = "Status", "v1"
+	writeJSON(w, int(status.Code), status)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,201 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// listPageSize is how many objects are fetched per list request
+const listPageSize = 500
+
+// lastAppliedAnnotation holds the manifest last applied with kubectl, including secret values
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new snapshot service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{clientset: clientset}
+}
+
+// Create captures the discovery documents and the objects of every listable resource
+func (s *service) Create(ctx context.Context, opts CreateOptions) (*Snapshot, error) {
+	rc := s.clientset.Discovery().RESTClient()
+	snap := &Snapshot{
+		Version:    FormatVersion,
+		Created:    time.Now().UTC(),
+		Namespaces: opts.Namespaces,
+		Discovery:  make(map[string]json.RawMessage),
+		Skipped:    make(map[string]string),
+	}
+
+	for _, p := range []string{"/version", "/api"} {
+		data, err := rc.Get().AbsPath(p).DoRaw(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", p, err)
+		}
+		snap.Discovery[p] = data
+	}
+
+	// Only the preferred version of each group is captured, so the group list is trimmed to
+	// it and clients never ask for a version the snapshot does not have
+	data, err := rc.Get().AbsPath("/apis").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get /apis: %w", err)
+	}
+	var groups metav1.APIGroupList
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return nil, fmt.Errorf("failed to decode API groups: %w", err)
+	}
+
+	if err := s.captureGroupVersion(ctx, rc, snap, "/api/v1", opts.Namespaces); err != nil {
+		return nil, err
+	}
+	captured := groups.Groups[:0]
+	for _, g := range groups.Groups {
+		gvPath := "/apis/" + g.PreferredVersion.GroupVersion
+		if err := s.captureGroupVersion(ctx, rc, snap, gvPath, opts.Namespaces); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// An unavailable aggregated API, e.g. a broken metrics server, leaves its group out
+			snap.Skipped[gvPath] = err.Error()
+			continue
+		}
+		g.Versions = []metav1.GroupVersionForDiscovery{g.PreferredVersion}
+		captured = append(captured, g)
+	}
+	groups.Groups = captured
+	groups.Kind, groups.APIVersion = "APIGroupList", "v1"
+	if snap.Discovery["/apis"], err = json.Marshal(groups); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(snap.Resources, func(i, j int) bool {
+		return snap.Resources[i].Path < snap.Resources[j].Path
+	})
+	return snap, nil
+}
+
+// captureGroupVersion captures the discovery document of a group version and the objects of its
+// listable resources. Resources that cannot be listed, e.g. because access is forbidden, are
+// recorded as skipped.
+func (s *service) captureGroupVersion(ctx context.Context, rc rest.Interface, snap *Snapshot, gvPath string, namespaces []string) error {
+	data, err := rc.Get().AbsPath(gvPath).DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", gvPath, err)
+	}
+	var resources metav1.APIResourceList
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return fmt.Errorf("failed to decode resources of %s: %w", gvPath, err)
+	}
+	snap.Discovery[gvPath] = data
+
+	for _, r := range resources.APIResources {
+		if strings.Contains(r.Name, "/") || !hasVerb(r.Verbs, "list") {
+			continue
+		}
+		list, err := s.captureList(ctx, rc, gvPath, resources.GroupVersion, r, namespaces)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			snap.Skipped[path.Join(gvPath, r.Name)] = err.Error()
+			continue
+		}
+		snap.Resources = append(snap.Resources, *list)
+	}
+	return nil
+}
+
+// captureList lists every object of a resource, page by page, in all namespaces or in each of
+// the given ones
+func (s *service) captureList(ctx context.Context, rc rest.Interface, gvPath, groupVersion string, r metav1.APIResource, namespaces []string) (*ResourceList, error) {
+	list := &ResourceList{
+		Path:       path.Join(gvPath, r.Name),
+		APIVersion: groupVersion,
+		Kind:       r.Kind,
+		Namespaced: r.Namespaced,
+		Items:      []map[string]interface{}{},
+	}
+
+	paths := []string{list.Path}
+	if r.Namespaced && len(namespaces) > 0 {
+		paths = paths[:0]
+		for _, ns := range namespaces {
+			paths = append(paths, path.Join(gvPath, "namespaces", ns, r.Name))
+		}
+	}
+
+	for _, p := range paths {
+		token := ""
+		for {
+			req := rc.Get().AbsPath(p).Param("limit", fmt.Sprint(listPageSize))
+			if token != "" {
+				req = req.Param("continue", token)
+			}
+			data, err := req.DoRaw(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s: %w", r.Name, err)
+			}
+
+			var page struct {
+				Metadata metav1.ListMeta          `json:"metadata"`
+				Items    []map[string]interface{} `json:"items"`
+			}
+			if err := json.Unmarshal(data, &page); err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", r.Name, err)
+			}
+			for _, item := range page.Items {
+				// Items of typed lists carry no kind, which dynamic clients need
+				item["apiVersion"], item["kind"] = groupVersion, r.Kind
+				if groupVersion == "v1" && r.Kind == "Secret" {
+					redactSecret(item)
+				}
+				list.Items = append(list.Items, item)
+			}
+
+			token = page.Metadata.Continue
+			if token == "" {
+				break
+			}
+		}
+	}
+	return list, nil
+}
+
+// redactSecret empties the values of a secret so a snapshot can be shared. The keys are kept,
+// since they show what the secret provides.
+func redactSecret(item map[string]interface{}) {
+	if data, ok := item["data"].(map[string]interface{}); ok {
+		for key := range data {
+			data[key] = ""
+		}
+	}
+	delete(item, "stringData")
+	if metadata, ok := item["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, lastAppliedAnnotation)
+		}
+	}
+}
+
+func hasVerb(verbs []string, verb string) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
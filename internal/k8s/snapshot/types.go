@@ -0,0 +1,54 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// FormatVersion is the version of the snapshot file format written by this build
+const FormatVersion = 1
+
+// CreateOptions selects what a snapshot captures
+type CreateOptions struct {
+	// Namespaces limits namespaced resources to these namespaces; all namespaces when empty.
+	// Cluster-scoped resources such as nodes are always captured.
+	Namespaces []string
+}
+
+// Snapshot is the state of a cluster at one point in time: the discovery documents and the
+// objects of every listable resource, stored as the API server returned them
+type Snapshot struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+
+	// Context and Cluster name the kubeconfig context and cluster the snapshot was taken from
+	Context string `json:"context"`
+	Cluster string `json:"cluster,omitempty"`
+
+	// Namespace is the default namespace of commands run against the snapshot
+	Namespace string `json:"namespace,omitempty"`
+
+	// Namespaces are the captured namespaces, empty when all were captured
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Discovery holds the raw responses for /version, /api, /apis and each group version,
+	// keyed by path
+	Discovery map[string]json.RawMessage `json:"discovery"`
+
+	// Resources are the captured lists, one per resource
+	Resources []ResourceList `json:"resources"`
+
+	// Skipped maps the list paths that could not be captured to the reason, e.g. forbidden
+	Skipped map[string]string `json:"skipped,omitempty"`
+}
+
+// ResourceList holds every object of a resource
+type ResourceList struct {
+	// Path is the cluster-wide list path, e.g. /api/v1/pods or /apis/apps/v1/deployments
+	Path       string `json:"path"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespaced bool   `json:"namespaced"`
+
+	Items []map[string]interface{} `json:"items"`
+}
@@ -0,0 +1,23 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for building resource timelines
+type Service interface {
+	// Build merges the events, status transitions and optionally log errors of a resource
+	// into one chronological timeline
+	Build(ctx context.Context, opts Options) (*Timeline, error)
+}
+
+// NewTimelineService creates a new timeline service instance
+func NewTimelineService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
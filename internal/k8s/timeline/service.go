@@ -0,0 +1,328 @@
+package timeline
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// errorLine matches log lines that look like failures
+var errorLine = regexp.MustCompile(`(?i)\b(error|fatal|panic|exception|failed|failure|refused|timed out|timeout)\b`)
+
+// containerFieldPath extracts the container name from an event field path like spec.containers{app}
+var containerFieldPath = regexp.MustCompile(`^spec\.(?:init)?[cC]ontainers\{(.+)\}$`)
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new timeline service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// Build merges the events, status transitions and optionally log errors of a resource
+// into one chronological timeline
+func (s *service) Build(ctx context.Context, opts Options) (*Timeline, error) {
+	if opts.Kind == "" || opts.Name == "" {
+		return nil, errs.Validationf("resource kind and name are required")
+	}
+	if opts.LogLines <= 0 {
+		opts.LogLines = 1000
+	}
+
+	var entries []Entry
+	var err error
+	switch opts.Kind {
+	case "Pod":
+		entries, err = s.podEntries(ctx, opts)
+	case "Deployment":
+		entries, err = s.deploymentEntries(ctx, opts)
+	default:
+		entries, err = s.eventEntries(ctx, opts.Namespace, opts.Kind, opts.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Since > 0 {
+		cutoff := time.Now().Add(-opts.Since)
+		kept := entries[:0]
+		for _, e := range entries {
+			if !e.Time.Before(cutoff) {
+				kept = append(kept, e)
+			}
+		}
+		entries = kept
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+
+	return &Timeline{
+		Kind:      opts.Kind,
+		Name:      opts.Name,
+		Namespace: opts.Namespace,
+		Entries:   entries,
+	}, nil
+}
+
+// podEntries returns the events, status transitions and log errors of a pod
+func (s *service) podEntries(ctx context.Context, opts Options) ([]Entry, error) {
+	pod, err := s.clientset.CoreV1().Pods(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errs.NotFoundf("pod %q not found in namespace %q", opts.Name, opts.Namespace)
+		}
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	object := "Pod/" + pod.Name
+	entries := []Entry{{
+		Time:   pod.CreationTimestamp.Time,
+		Source: SourceStatus,
+		Object: object,
+		Reason: "Created",
+	}}
+
+	for _, c := range pod.Status.Conditions {
+		if !c.LastTransitionTime.IsZero() {
+			entries = append(entries, conditionEntry(object, string(c.Type), c.Status, c.LastTransitionTime, c.Reason, c.Message))
+		}
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		object := "container " + cs.Name
+		entries = append(entries, stateEntries(object, cs.LastTerminationState)...)
+		entries = append(entries, stateEntries(object, cs.State)...)
+	}
+
+	events, err := s.eventEntries(ctx, pod.Namespace, "Pod", pod.Name)
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, events...)
+
+	if opts.Logs {
+		for _, cs := range statuses {
+			entries = append(entries, s.logEntries(ctx, pod, cs.Name, false, opts.LogLines)...)
+			if cs.RestartCount > 0 {
+				entries = append(entries, s.logEntries(ctx, pod, cs.Name, true, opts.LogLines)...)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// deploymentEntries returns the events and condition changes of a deployment and the events
+// of its ReplicaSets, which report pods that could not be created
+func (s *service) deploymentEntries(ctx context.Context, opts Options) ([]Entry, error) {
+	deploy, err := s.clientset.AppsV1().Deployments(opts.Namespace).Get(ctx, opts.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errs.NotFoundf("deployment %q not found in namespace %q", opts.Name, opts.Namespace)
+		}
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	object := "Deployment/" + deploy.Name
+	entries := []Entry{{
+		Time:   deploy.CreationTimestamp.Time,
+		Source: SourceStatus,
+		Object: object,
+		Reason: "Created",
+	}}
+	for _, c := range deploy.Status.Conditions {
+		if !c.LastTransitionTime.IsZero() {
+			entries = append(entries, conditionEntry(object, string(c.Type), c.Status, c.LastTransitionTime, c.Reason, c.Message))
+		}
+	}
+
+	events, err := s.eventEntries(ctx, deploy.Namespace, "Deployment", deploy.Name)
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, events...)
+
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment selector: %w", err)
+	}
+	rsList, err := s.clientset.AppsV1().ReplicaSets(deploy.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	for _, rs := range rsList.Items {
+		if ref := metav1.GetControllerOf(&rs); ref == nil || ref.UID != deploy.UID {
+			continue
+		}
+		events, err := s.eventEntries(ctx, rs.Namespace, "ReplicaSet", rs.Name)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, events...)
+	}
+
+	return entries, nil
+}
+
+// eventEntries returns the events about an object, placed at their first occurrence
+func (s *service) eventEntries(ctx context.Context, namespace, kind, name string) ([]Entry, error) {
+	list, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=%s,involvedObject.name=%s", kind, name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(list.Items))
+	for _, ev := range list.Items {
+		t := ev.FirstTimestamp.Time
+		if t.IsZero() {
+			t = ev.EventTime.Time
+		}
+		if t.IsZero() {
+			t = ev.LastTimestamp.Time
+		}
+
+		object := ev.InvolvedObject.Kind + "/" + ev.InvolvedObject.Name
+		if m := containerFieldPath.FindStringSubmatch(ev.InvolvedObject.FieldPath); m != nil {
+			object = "container " + m[1]
+		}
+
+		count := ev.Count
+		if ev.Series != nil && ev.Series.Count > count {
+			count = ev.Series.Count
+		}
+		entries = append(entries, Entry{
+			Time:    t,
+			Source:  SourceEvent,
+			Object:  object,
+			Type:    ev.Type,
+			Reason:  ev.Reason,
+			Message: strings.TrimSpace(ev.Message),
+			Count:   count,
+		})
+	}
+	return entries, nil
+}
+
+// conditionEntry returns the transition of a status condition, a warning unless it became true
+func conditionEntry(object, condType string, status corev1.ConditionStatus, at metav1.Time, reason, message string) Entry {
+	entry := Entry{
+		Time:    at.Time,
+		Source:  SourceStatus,
+		Object:  object,
+		Reason:  fmt.Sprintf("%s=%s", condType, status),
+		Message: joinNonEmpty(reason, message),
+	}
+	if status != corev1.ConditionTrue {
+		entry.Type = string(corev1.EventTypeWarning)
+	}
+	return entry
+}
+
+// stateEntries returns the transitions recorded in a container state
+func stateEntries(object string, state corev1.ContainerState) []Entry {
+	var entries []Entry
+	switch {
+	case state.Running != nil:
+		entries = append(entries, Entry{
+			Time:   state.Running.StartedAt.Time,
+			Source: SourceStatus,
+			Object: object,
+			Reason: "Started",
+		})
+	case state.Terminated != nil:
+		t := state.Terminated
+		if !t.StartedAt.IsZero() {
+			entries = append(entries, Entry{
+				Time:   t.StartedAt.Time,
+				Source: SourceStatus,
+				Object: object,
+				Reason: "Started",
+			})
+		}
+		entry := Entry{
+			Time:    t.FinishedAt.Time,
+			Source:  SourceStatus,
+			Object:  object,
+			Reason:  "Terminated",
+			Message: joinNonEmpty(fmt.Sprintf("%s, exit code %d", t.Reason, t.ExitCode), t.Message),
+		}
+		if t.ExitCode != 0 {
+			entry.Type = string(corev1.EventTypeWarning)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// logEntries returns the error lines of a container log. Logs that cannot be read, such as
+// those of a container that never started, add nothing.
+func (s *service) logEntries(ctx context.Context, pod *corev1.Pod, container string, previous bool, lines int64) []Entry {
+	data, err := s.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  container,
+		Previous:   previous,
+		Timestamps: true,
+		TailLines:  &lines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return nil
+	}
+
+	object := "container " + container
+	if previous {
+		object += " (previous)"
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		ts, line, found := strings.Cut(scanner.Text(), " ")
+		if !found || !errorLine.MatchString(line) {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, Entry{
+			Time:    t,
+			Source:  SourceLog,
+			Object:  object,
+			Type:    string(corev1.EventTypeWarning),
+			Reason:  "LogError",
+			Message: strings.TrimSpace(line),
+		})
+	}
+	return entries
+}
+
+// joinNonEmpty joins the non-empty parts with ": "
+func joinNonEmpty(parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(strings.Trim(p, ", ")); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, ": ")
+}
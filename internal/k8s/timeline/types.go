@@ -0,0 +1,60 @@
+package timeline
+
+import (
+	"time"
+)
+
+// Source tells where a timeline entry comes from
+type Source string
+
+const (
+	// SourceEvent is a Kubernetes event about the resource
+	SourceEvent Source = "event"
+	// SourceStatus is a transition recorded in the resource status, such as a container start
+	SourceStatus Source = "status"
+	// SourceLog is an error line from a container log
+	SourceLog Source = "log"
+)
+
+// Options configures the timeline
+type Options struct {
+	Namespace string
+
+	// Kind is the resource kind, e.g. Pod or Deployment
+	Kind string
+	Name string
+
+	// Logs adds error lines from the container logs of a pod
+	Logs bool
+
+	// LogLines is the number of recent lines scanned per container, 1000 when zero
+	LogLines int64
+
+	// Since limits the timeline to entries within this window, zero keeps everything
+	Since time.Duration
+}
+
+// Entry is a single point on the timeline
+type Entry struct {
+	Time   time.Time `json:"time"`
+	Source Source    `json:"source"`
+
+	// Object is what the entry is about, e.g. Pod/web-0 or container app
+	Object string `json:"object"`
+
+	// Type is Normal or Warning for events, and Warning for failures found in status and logs
+	Type    string `json:"type,omitempty"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+
+	// Count is the number of times an event occurred since Time
+	Count int32 `json:"count,omitempty"`
+}
+
+// Timeline is the chronological history of a resource
+type Timeline struct {
+	Kind      string  `json:"kind"`
+	Name      string  `json:"name"`
+	Namespace string  `json:"namespace"`
+	Entries   []Entry `json:"entries"`
+}
@@ -0,0 +1,22 @@
+package tree
+
+import (
+	"context"
+	"fmt"
+
+	"k8stool/internal/k8s/resources"
+)
+
+// Service defines the interface for building ownership trees
+type Service interface {
+	// Tree returns an object and, recursively, the objects it owns or routes to
+	Tree(ctx context.Context, namespace, ref string) (*Node, error)
+}
+
+// NewTreeService creates a new ownership tree service instance
+func NewTreeService(resourceService resources.Service) (Service, error) {
+	if resourceService == nil {
+		return nil, fmt.Errorf("resource service is required")
+	}
+	return newService(resourceService), nil
+}
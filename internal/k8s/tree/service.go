@@ -0,0 +1,196 @@
+package tree
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/resources"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// childTypes lists the resource types that may be owned by objects of each kind
+var childTypes = map[string][]string{
+	"Deployment":  {"replicasets.apps"},
+	"ReplicaSet":  {"pods"},
+	"StatefulSet": {"pods", "controllerrevisions.apps", "persistentvolumeclaims"},
+	"DaemonSet":   {"pods", "controllerrevisions.apps"},
+	"CronJob":     {"jobs.batch"},
+	"Job":         {"pods"},
+	"Service":     {"endpoints", "endpointslices.discovery.k8s.io"},
+}
+
+type service struct {
+	resourceService resources.Service
+}
+
+// newService creates a new ownership tree service instance
+func newService(resourceService resources.Service) Service {
+	return &service{
+		resourceService: resourceService,
+	}
+}
+
+// builder lists each resource type of the namespace at most once per tree
+type builder struct {
+	resourceService resources.Service
+	namespace       string
+	lists           map[string][]*unstructured.Unstructured
+}
+
+// Tree returns an object and, recursively, the objects it owns or routes to
+func (s *service) Tree(ctx context.Context, namespace, ref string) (*Node, error) {
+	resourceType, name, ok := strings.Cut(ref, "/")
+	if !ok || resourceType == "" || name == "" {
+		return nil, errs.Validationf("invalid resource %q: must be TYPE/NAME", ref)
+	}
+
+	root, err := s.resourceService.Get(ctx, resourceType, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &builder{
+		resourceService: s.resourceService,
+		namespace:       namespace,
+		lists:           make(map[string][]*unstructured.Unstructured),
+	}
+	return b.build(ctx, root)
+}
+
+// build returns the node of an object together with its descendants
+func (b *builder) build(ctx context.Context, obj *unstructured.Unstructured) (*Node, error) {
+	node := newNode(obj)
+
+	for _, resourceType := range childTypes[obj.GetKind()] {
+		objs, err := b.list(ctx, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range objs {
+			if !ownedBy(child, obj.GetUID()) {
+				continue
+			}
+			childNode, err := b.build(ctx, child)
+			if err != nil {
+				return nil, err
+			}
+			node.Children = append(node.Children, childNode)
+		}
+	}
+
+	// Endpoints are not owned by their Service but share its name
+	if obj.GetKind() == "Service" {
+		endpoints, err := b.endpoints(ctx, obj.GetName())
+		if err != nil {
+			return nil, err
+		}
+		if endpoints != nil {
+			node.Children = append(node.Children, endpoints)
+		}
+	}
+
+	sortNodes(node.Children)
+	return node, nil
+}
+
+// endpoints returns the Endpoints of a Service with the pods they route to
+func (b *builder) endpoints(ctx context.Context, name string) (*Node, error) {
+	objs, err := b.list(ctx, "endpoints")
+	if err != nil {
+		return nil, err
+	}
+	pods, err := b.list(ctx, "pods")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objs {
+		if obj.GetName() != name {
+			continue
+		}
+		node := newNode(obj)
+		for _, uid := range targetPods(obj) {
+			for _, pod := range pods {
+				if pod.GetUID() == uid {
+					node.Children = append(node.Children, newNode(pod))
+				}
+			}
+		}
+		sortNodes(node.Children)
+		return node, nil
+	}
+	return nil, nil
+}
+
+func (b *builder) list(ctx context.Context, resourceType string) ([]*unstructured.Unstructured, error) {
+	if objs, ok := b.lists[resourceType]; ok {
+		return objs, nil
+	}
+	objs, err := b.resourceService.List(ctx, resourceType, resources.ListOptions{Namespace: b.namespace})
+	if err != nil {
+		return nil, err
+	}
+	b.lists[resourceType] = objs
+	return objs, nil
+}
+
+func ownedBy(obj *unstructured.Unstructured, uid types.UID) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// targetPods returns the UIDs of the pods behind the addresses of an Endpoints object
+func targetPods(endpoints *unstructured.Unstructured) []types.UID {
+	var uids []types.UID
+	subsets, _, _ := unstructured.NestedSlice(endpoints.Object, "subsets")
+	for _, subset := range subsets {
+		for _, field := range []string{"addresses", "notReadyAddresses"} {
+			addresses, _, _ := unstructured.NestedSlice(asMap(subset), field)
+			for _, address := range addresses {
+				if uid, _, _ := unstructured.NestedString(asMap(address), "targetRef", "uid"); uid != "" {
+					uids = append(uids, types.UID(uid))
+				}
+			}
+		}
+	}
+	return uids
+}
+
+func newNode(obj *unstructured.Unstructured) *Node {
+	status, health := statusOf(obj)
+	return &Node{
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Age:       time.Since(obj.GetCreationTimestamp().Time),
+		Status:    status,
+		Health:    health,
+	}
+}
+
+// sortNodes orders nodes by kind, then newest first, so the current ReplicaSet comes first
+func sortNodes(nodes []*Node) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Kind != nodes[j].Kind {
+			return nodes[i].Kind < nodes[j].Kind
+		}
+		if nodes[i].Age != nodes[j].Age {
+			return nodes[i].Age < nodes[j].Age
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+}
+
+func asMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
@@ -0,0 +1,172 @@
+package tree
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// statusOf describes the state of an object from its spec and status
+func statusOf(obj *unstructured.Unstructured) (string, Health) {
+	if obj.GetDeletionTimestamp() != nil {
+		return "Terminating", Progressing
+	}
+
+	switch obj.GetKind() {
+	case "Deployment", "StatefulSet", "ReplicaSet":
+		desired := int64Field(obj, 1, "spec", "replicas")
+		ready := int64Field(obj, 0, "status", "readyReplicas")
+		return fmt.Sprintf("%d/%d ready", ready, desired), replicaHealth(ready, desired)
+	case "DaemonSet":
+		desired := int64Field(obj, 0, "status", "desiredNumberScheduled")
+		ready := int64Field(obj, 0, "status", "numberReady")
+		return fmt.Sprintf("%d/%d ready", ready, desired), replicaHealth(ready, desired)
+	case "Pod":
+		return podStatus(obj)
+	case "Job":
+		return jobStatus(obj)
+	case "CronJob":
+		active, _, _ := unstructured.NestedSlice(obj.Object, "status", "active")
+		if suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend"); suspended {
+			return "suspended", Unknown
+		}
+		return fmt.Sprintf("%d active", len(active)), Healthy
+	case "Endpoints":
+		return endpointsStatus(obj)
+	case "EndpointSlice":
+		return endpointSliceStatus(obj)
+	case "PersistentVolumeClaim":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		if phase == "Bound" {
+			return phase, Healthy
+		}
+		return phase, Progressing
+	case "ControllerRevision":
+		return fmt.Sprintf("revision %d", int64Field(obj, 0, "revision")), Healthy
+	case "Service":
+		serviceType, _, _ := unstructured.NestedString(obj.Object, "spec", "type")
+		return serviceType, Healthy
+	default:
+		return "", Unknown
+	}
+}
+
+func replicaHealth(ready, desired int64) Health {
+	switch {
+	case ready >= desired:
+		return Healthy
+	case ready > 0:
+		return Progressing
+	default:
+		return Degraded
+	}
+}
+
+// podStatus reports the waiting or terminated reason of a failing container over the pod phase
+func podStatus(obj *unstructured.Unstructured) (string, Health) {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	statuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "containerStatuses")
+
+	var ready int
+	for _, s := range statuses {
+		status := asMap(s)
+		if r, _, _ := unstructured.NestedBool(status, "ready"); r {
+			ready++
+		}
+		if reason, _, _ := unstructured.NestedString(status, "state", "waiting", "reason"); reason != "" && reason != "ContainerCreating" && reason != "PodInitializing" {
+			return reason, Degraded
+		}
+		if reason, _, _ := unstructured.NestedString(status, "state", "terminated", "reason"); reason != "" && reason != "Completed" {
+			return reason, Degraded
+		}
+	}
+
+	summary := fmt.Sprintf("%s %d/%d ready", phase, ready, len(statuses))
+	switch phase {
+	case "Running":
+		if ready == len(statuses) {
+			return summary, Healthy
+		}
+		return summary, Progressing
+	case "Succeeded":
+		return "Completed", Healthy
+	case "Pending":
+		return phase, Progressing
+	case "Failed":
+		reason, _, _ := unstructured.NestedString(obj.Object, "status", "reason")
+		if reason == "" {
+			reason = phase
+		}
+		return reason, Degraded
+	default:
+		return phase, Unknown
+	}
+}
+
+func jobStatus(obj *unstructured.Unstructured) (string, Health) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition := asMap(c)
+		if status, _, _ := unstructured.NestedString(condition, "status"); status != "True" {
+			continue
+		}
+		switch conditionType, _, _ := unstructured.NestedString(condition, "type"); conditionType {
+		case "Complete":
+			return "Complete", Healthy
+		case "Failed":
+			reason, _, _ := unstructured.NestedString(condition, "reason")
+			return "Failed: " + reason, Degraded
+		}
+	}
+
+	completions := int64Field(obj, 1, "spec", "completions")
+	succeeded := int64Field(obj, 0, "status", "succeeded")
+	return fmt.Sprintf("%d/%d complete", succeeded, completions), Progressing
+}
+
+func endpointsStatus(obj *unstructured.Unstructured) (string, Health) {
+	var ready, notReady int
+	subsets, _, _ := unstructured.NestedSlice(obj.Object, "subsets")
+	for _, subset := range subsets {
+		addresses, _, _ := unstructured.NestedSlice(asMap(subset), "addresses")
+		pending, _, _ := unstructured.NestedSlice(asMap(subset), "notReadyAddresses")
+		ready += len(addresses)
+		notReady += len(pending)
+	}
+	return addressStatus(ready, notReady)
+}
+
+func endpointSliceStatus(obj *unstructured.Unstructured) (string, Health) {
+	var ready, notReady int
+	endpoints, _, _ := unstructured.NestedSlice(obj.Object, "endpoints")
+	for _, e := range endpoints {
+		// A missing ready condition means ready
+		if r, found, _ := unstructured.NestedBool(asMap(e), "conditions", "ready"); !found || r {
+			ready++
+		} else {
+			notReady++
+		}
+	}
+	return addressStatus(ready, notReady)
+}
+
+func addressStatus(ready, notReady int) (string, Health) {
+	status := fmt.Sprintf("%d ready, %d not ready", ready, notReady)
+	switch {
+	case ready == 0:
+		return status, Degraded
+	case notReady > 0:
+		return status, Progressing
+	default:
+		return status, Healthy
+	}
+}
+
+// int64Field returns an integer field, or def when it is not set
+func int64Field(obj *unstructured.Unstructured, def int64, fields ...string) int64 {
+	v, found, err := unstructured.NestedInt64(obj.Object, fields...)
+	if !found || err != nil {
+		return def
+	}
+	return v
+}
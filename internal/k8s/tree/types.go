@@ -0,0 +1,31 @@
+package tree
+
+import "time"
+
+// Health summarizes the state of a node
+type Health string
+
+const (
+	// Healthy means the object is in its desired state
+	Healthy Health = "Healthy"
+	// Progressing means the object is moving towards its desired state
+	Progressing Health = "Progressing"
+	// Degraded means the object is failing or has unavailable parts
+	Degraded Health = "Degraded"
+	// Unknown means the state cannot be determined from the object
+	Unknown Health = "Unknown"
+)
+
+// Node is an object in the ownership tree
+type Node struct {
+	Kind      string        `json:"kind"`
+	Namespace string        `json:"namespace,omitempty"`
+	Name      string        `json:"name"`
+	Age       time.Duration `json:"age"`
+
+	// Status is a short description of the object's state, e.g. 2/3 ready
+	Status string `json:"status,omitempty"`
+	Health Health `json:"health"`
+
+	Children []*Node `json:"children,omitempty"`
+}
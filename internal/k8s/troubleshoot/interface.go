@@ -0,0 +1,27 @@
+package troubleshoot
+
+import (
+	"context"
+	"fmt"
+
+	"k8stool/internal/k8s/pods"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for collecting troubleshooting reports
+type Service interface {
+	// Pod collects a troubleshooting report for a single pod
+	Pod(ctx context.Context, namespace, name string, opts Options) (*PodReport, error)
+}
+
+// NewTroubleshootService creates a new troubleshoot service instance
+func NewTroubleshootService(clientset *kubernetes.Clientset, podService pods.Service) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	if podService == nil {
+		return nil, fmt.Errorf("pod service is required")
+	}
+	return newService(clientset, podService), nil
+}
@@ -0,0 +1,336 @@
+package troubleshoot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/pods"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type service struct {
+	clientset  *kubernetes.Clientset
+	podService pods.Service
+}
+
+// newService creates a new troubleshoot service instance
+func newService(clientset *kubernetes.Clientset, podService pods.Service) Service {
+	return &service{
+		clientset:  clientset,
+		podService: podService,
+	}
+}
+
+// Pod collects a troubleshooting report for a single pod
+func (s *service) Pod(ctx context.Context, namespace, name string, opts Options) (*PodReport, error) {
+	if opts.EventLimit <= 0 {
+		opts.EventLimit = 10
+	}
+	if opts.TailLines <= 0 {
+		opts.TailLines = 50
+	}
+
+	details, err := s.podService.Describe(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	report := &PodReport{Pod: details}
+
+	report.WarningEvents, err = s.warningEvents(ctx, namespace, name, opts.EventLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range details.Containers {
+		report.Logs = append(report.Logs, s.containerLogs(ctx, pod, c, opts.TailLines))
+	}
+
+	// A missing owner should not prevent the rest of the report from being shown
+	if owner, err := s.ownerStatus(ctx, pod); err == nil {
+		report.Owner = owner
+	} else {
+		report.Findings = append(report.Findings, fmt.Sprintf("could not determine owner rollout status: %v", err))
+	}
+
+	report.Findings = append(report.Findings, analyze(details, report.Owner)...)
+
+	return report, nil
+}
+
+// warningEvents returns the most recent warning events of a pod, newest first
+func (s *service) warningEvents(ctx context.Context, namespace, name string, limit int) ([]Event, error) {
+	fieldSelector := fmt.Sprintf("involvedObject.name=%s,involvedObject.kind=Pod,type=%s", name, corev1.EventTypeWarning)
+	list, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod events: %w", err)
+	}
+
+	events := make([]Event, 0, len(list.Items))
+	for _, e := range list.Items {
+		lastSeen := e.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = e.EventTime.Time
+		}
+		events = append(events, Event{
+			Reason:   e.Reason,
+			Message:  e.Message,
+			Count:    e.Count,
+			LastSeen: lastSeen,
+		})
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].LastSeen.After(events[j].LastSeen)
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+// containerLogs returns the current and, after a restart, previous log tail of a container
+func (s *service) containerLogs(ctx context.Context, pod *corev1.Pod, c pods.ContainerInfo, tailLines int64) ContainerLogs {
+	result := ContainerLogs{Container: c.Name}
+
+	if c.State.Status != "Waiting" {
+		current, err := s.tail(ctx, pod, c.Name, false, tailLines)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		result.Current = current
+	}
+
+	if c.RestartCount > 0 {
+		previous, err := s.tail(ctx, pod, c.Name, true, tailLines)
+		if err != nil && result.Error == "" {
+			result.Error = err.Error()
+		}
+		result.Previous = previous
+	}
+
+	return result
+}
+
+func (s *service) tail(ctx context.Context, pod *corev1.Pod, container string, previous bool, tailLines int64) (string, error) {
+	data, err := s.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &tailLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs: %w", err)
+	}
+	return string(data), nil
+}
+
+// ownerStatus resolves the workload owning the pod and reports its rollout status
+func (s *service) ownerStatus(ctx context.Context, pod *corev1.Pod) (*OwnerStatus, error) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return nil, nil
+	}
+
+	switch ref.Kind {
+	case "ReplicaSet":
+		rs, err := s.clientset.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replicaset: %w", err)
+		}
+		if deployRef := metav1.GetControllerOf(rs); deployRef != nil && deployRef.Kind == "Deployment" {
+			deploy, err := s.clientset.AppsV1().Deployments(pod.Namespace).Get(ctx, deployRef.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get deployment: %w", err)
+			}
+			return deploymentStatus(deploy), nil
+		}
+		desired := int32(1)
+		if rs.Spec.Replicas != nil {
+			desired = *rs.Spec.Replicas
+		}
+		return &OwnerStatus{
+			Kind:      "ReplicaSet",
+			Name:      rs.Name,
+			Desired:   desired,
+			Ready:     rs.Status.ReadyReplicas,
+			Available: rs.Status.AvailableReplicas,
+			Rollout:   fmt.Sprintf("%d of %d replicas available", rs.Status.AvailableReplicas, desired),
+			Complete:  rs.Status.AvailableReplicas >= desired,
+		}, nil
+	case "StatefulSet":
+		sts, err := s.clientset.AppsV1().StatefulSets(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset: %w", err)
+		}
+		return statefulSetStatus(sts), nil
+	case "DaemonSet":
+		ds, err := s.clientset.AppsV1().DaemonSets(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daemonset: %w", err)
+		}
+		return daemonSetStatus(ds), nil
+	default:
+		return &OwnerStatus{
+			Kind:     ref.Kind,
+			Name:     ref.Name,
+			Rollout:  "rollout status not available for this kind",
+			Complete: true,
+		}, nil
+	}
+}
+
+// deploymentStatus mirrors the rollout status logic of kubectl
+func deploymentStatus(d *appsv1.Deployment) *OwnerStatus {
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	status := &OwnerStatus{
+		Kind:      "Deployment",
+		Name:      d.Name,
+		Desired:   desired,
+		Updated:   d.Status.UpdatedReplicas,
+		Ready:     d.Status.ReadyReplicas,
+		Available: d.Status.AvailableReplicas,
+	}
+
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			status.Rollout = fmt.Sprintf("rollout failed: %s", c.Message)
+			return status
+		}
+	}
+
+	switch {
+	case d.Generation > d.Status.ObservedGeneration:
+		status.Rollout = "waiting for the rollout to be observed"
+	case d.Status.UpdatedReplicas < desired:
+		status.Rollout = fmt.Sprintf("%d of %d new replicas have been updated", d.Status.UpdatedReplicas, desired)
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		status.Rollout = fmt.Sprintf("%d old replicas are pending termination", d.Status.Replicas-d.Status.UpdatedReplicas)
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		status.Rollout = fmt.Sprintf("%d of %d updated replicas are available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas)
+	default:
+		status.Rollout = "successfully rolled out"
+		status.Complete = true
+	}
+	return status
+}
+
+func statefulSetStatus(sts *appsv1.StatefulSet) *OwnerStatus {
+	desired := int32(1)
+	if sts.Spec.Replicas != nil {
+		desired = *sts.Spec.Replicas
+	}
+	status := &OwnerStatus{
+		Kind:      "StatefulSet",
+		Name:      sts.Name,
+		Desired:   desired,
+		Updated:   sts.Status.UpdatedReplicas,
+		Ready:     sts.Status.ReadyReplicas,
+		Available: sts.Status.AvailableReplicas,
+	}
+
+	switch {
+	case sts.Generation > sts.Status.ObservedGeneration:
+		status.Rollout = "waiting for the rollout to be observed"
+	case sts.Status.ReadyReplicas < desired:
+		status.Rollout = fmt.Sprintf("%d of %d replicas are ready", sts.Status.ReadyReplicas, desired)
+	case sts.Status.UpdateRevision != sts.Status.CurrentRevision:
+		status.Rollout = fmt.Sprintf("%d of %d replicas have been updated", sts.Status.UpdatedReplicas, desired)
+	default:
+		status.Rollout = "successfully rolled out"
+		status.Complete = true
+	}
+	return status
+}
+
+func daemonSetStatus(ds *appsv1.DaemonSet) *OwnerStatus {
+	desired := ds.Status.DesiredNumberScheduled
+	status := &OwnerStatus{
+		Kind:      "DaemonSet",
+		Name:      ds.Name,
+		Desired:   desired,
+		Updated:   ds.Status.UpdatedNumberScheduled,
+		Ready:     ds.Status.NumberReady,
+		Available: ds.Status.NumberAvailable,
+	}
+
+	switch {
+	case ds.Generation > ds.Status.ObservedGeneration:
+		status.Rollout = "waiting for the rollout to be observed"
+	case ds.Status.UpdatedNumberScheduled < desired:
+		status.Rollout = fmt.Sprintf("%d of %d updated pods have been scheduled", ds.Status.UpdatedNumberScheduled, desired)
+	case ds.Status.NumberAvailable < desired:
+		status.Rollout = fmt.Sprintf("%d of %d updated pods are available", ds.Status.NumberAvailable, desired)
+	default:
+		status.Rollout = "successfully rolled out"
+		status.Complete = true
+	}
+	return status
+}
+
+// analyze derives findings from the pod state that point at a likely cause
+func analyze(details *pods.PodDetails, owner *OwnerStatus) []string {
+	var findings []string
+
+	for _, c := range details.Conditions {
+		if c.Type == string(corev1.PodScheduled) && c.Status == string(corev1.ConditionFalse) {
+			findings = append(findings, fmt.Sprintf("pod is not scheduled (%s): %s", c.Reason, c.Message))
+		}
+	}
+
+	for _, c := range details.Containers {
+		switch c.State.Reason {
+		case "CrashLoopBackOff":
+			msg := fmt.Sprintf("container %s is crash looping", c.Name)
+			if c.LastState.Reason != "" {
+				msg += fmt.Sprintf(" (last exit: %s, code %d)", c.LastState.Reason, c.LastState.ExitCode)
+			}
+			findings = append(findings, msg+"; check the previous logs below")
+		case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+			findings = append(findings, fmt.Sprintf("container %s cannot pull image %s: %s", c.Name, c.Image, c.State.Message))
+		case "CreateContainerConfigError", "CreateContainerError":
+			findings = append(findings, fmt.Sprintf("container %s cannot be created: %s", c.Name, c.State.Message))
+		}
+
+		if c.LastState.Reason == "OOMKilled" {
+			limit := c.Resources.Limits.Memory
+			if limit == "" || limit == "0" {
+				limit = "unset"
+			}
+			findings = append(findings, fmt.Sprintf("container %s was OOMKilled (memory limit %s); consider raising the limit", c.Name, limit))
+		}
+
+		if c.State.Status == "Running" && !c.Ready {
+			if c.ReadinessProbe != nil {
+				findings = append(findings, fmt.Sprintf("container %s is running but not ready; its readiness probe is failing", c.Name))
+			} else {
+				findings = append(findings, fmt.Sprintf("container %s is running but not ready", c.Name))
+			}
+		}
+
+		if c.LivenessProbe == nil && c.ReadinessProbe == nil && c.StartupProbe == nil {
+			findings = append(findings, fmt.Sprintf("container %s has no probes configured", c.Name))
+		}
+	}
+
+	if owner != nil && !owner.Complete {
+		findings = append(findings, fmt.Sprintf("%s %s rollout is not complete: %s", strings.ToLower(owner.Kind), owner.Name, owner.Rollout))
+	}
+
+	return findings
+}
@@ -0,0 +1,72 @@
+package troubleshoot
+
+import (
+	"time"
+
+	"k8stool/internal/k8s/pods"
+)
+
+// Options configures how much data is collected for a report
+type Options struct {
+	// EventLimit is the maximum number of warning events to include
+	EventLimit int
+
+	// TailLines is the number of log lines collected per container instance
+	TailLines int64
+}
+
+// PodReport aggregates everything needed to troubleshoot a pod
+type PodReport struct {
+	// Pod holds the describe output of the pod
+	Pod *pods.PodDetails
+
+	// WarningEvents lists the most recent warning events, newest first
+	WarningEvents []Event
+
+	// Logs holds the current and previous log tails of each container
+	Logs []ContainerLogs
+
+	// Owner is the rollout status of the workload owning the pod, if any
+	Owner *OwnerStatus
+
+	// Findings are annotations pointing at the likely cause of a problem
+	Findings []string
+}
+
+// Event represents a warning event related to the pod
+type Event struct {
+	Reason   string
+	Message  string
+	Count    int32
+	LastSeen time.Time
+}
+
+// ContainerLogs contains the log tails of a single container
+type ContainerLogs struct {
+	Container string
+
+	// Current holds the logs of the running container instance
+	Current string
+
+	// Previous holds the logs of the last terminated instance, if it restarted
+	Previous string
+
+	// Error records why logs could not be retrieved
+	Error string
+}
+
+// OwnerStatus describes the rollout status of the pod's owning workload
+type OwnerStatus struct {
+	Kind      string
+	Name      string
+	Desired   int32
+	Updated   int32
+	Ready     int32
+	Available int32
+
+	// Rollout is a human readable rollout status
+	Rollout string
+
+	// Complete reports whether the rollout has finished
+	Complete bool
+}
@@ -0,0 +1,27 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+
+	"k8stool/internal/k8s/metrics"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for aggregating resource consumption
+type Service interface {
+	// Report sums the requests, limits and live usage of pods per group
+	Report(ctx context.Context, opts Options) (*Report, error)
+}
+
+// NewUsageService creates a new usage service instance
+func NewUsageService(clientset *kubernetes.Clientset, metricsService metrics.Service) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	if metricsService == nil {
+		return nil, fmt.Errorf("metrics service is required")
+	}
+	return newService(clientset, metricsService), nil
+}
@@ -0,0 +1,126 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/metrics"
+	"k8stool/internal/k8s/pods"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// noGroup is the group of pods without the grouping label
+const noGroup = "<none>"
+
+type service struct {
+	clientset      *kubernetes.Clientset
+	metricsService metrics.Service
+}
+
+// newService creates a new usage service instance
+func newService(clientset *kubernetes.Clientset, metricsService metrics.Service) Service {
+	return &service{
+		clientset:      clientset,
+		metricsService: metricsService,
+	}
+}
+
+// Report sums the requests, limits and live usage of pods per group
+func (s *service) Report(ctx context.Context, opts Options) (*Report, error) {
+	groupKey, err := groupKeyFunc(opts.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := opts.Namespace
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	// Only pods that still hold resources are counted
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: opts.Selector,
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	report := &Report{GroupBy: opts.GroupBy, Groups: []Group{}, Total: Group{Name: "TOTAL"}}
+	groups := make(map[string]*Group)
+	podGroups := make(map[string]*Group, len(podList.Items))
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		key := groupKey(pod)
+		group, ok := groups[key]
+		if !ok {
+			group = &Group{Name: key}
+			groups[key] = group
+		}
+		podGroups[pod.Namespace+"/"+pod.Name] = group
+
+		requests := pods.Requests(&pod.Spec)
+		limits := pods.Limits(&pod.Spec)
+		group.Pods++
+		group.CPURequests += requests.Cpu().MilliValue()
+		group.CPULimits += limits.Cpu().MilliValue()
+		group.MemoryRequests += requests.Memory().Value()
+		group.MemoryLimits += limits.Memory().Value()
+	}
+
+	// Live usage is best effort, requests and limits are still useful without metrics-server
+	podMetrics, err := s.metricsService.ListPodMetrics(namespace)
+	if err == nil {
+		report.MetricsAvailable = true
+		for _, pm := range podMetrics {
+			group, ok := podGroups[pm.Namespace+"/"+pm.Name]
+			if !ok {
+				continue // Filtered out by the selector or finished
+			}
+			group.CPUUsage += pm.TotalResources.CPU.UsageNanoCores / 1e6
+			group.MemoryUsage += pm.TotalResources.Memory.UsageBytes
+		}
+	}
+
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+		report.Total.Pods += group.Pods
+		report.Total.CPURequests += group.CPURequests
+		report.Total.CPULimits += group.CPULimits
+		report.Total.CPUUsage += group.CPUUsage
+		report.Total.MemoryRequests += group.MemoryRequests
+		report.Total.MemoryLimits += group.MemoryLimits
+		report.Total.MemoryUsage += group.MemoryUsage
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.CPURequests != b.CPURequests {
+			return a.CPURequests > b.CPURequests
+		}
+		return a.Name < b.Name
+	})
+
+	return report, nil
+}
+
+// groupKeyFunc returns the function assigning a pod to its group
+func groupKeyFunc(groupBy string) (func(pod *corev1.Pod) string, error) {
+	if groupBy == "namespace" {
+		return func(pod *corev1.Pod) string { return pod.Namespace }, nil
+	}
+	if label, ok := strings.CutPrefix(groupBy, "label:"); ok && label != "" {
+		return func(pod *corev1.Pod) string {
+			if value, ok := pod.Labels[label]; ok && value != "" {
+				return value
+			}
+			return noGroup
+		}, nil
+	}
+	return nil, errs.Validationf("invalid group %q: must be namespace or label:KEY", groupBy)
+}
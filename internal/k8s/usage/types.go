@@ -0,0 +1,36 @@
+package usage
+
+// Options selects the pods to aggregate and how to group them
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+	Selector      string
+
+	// GroupBy is "namespace" or "label:KEY" to group by the value of a pod label
+	GroupBy string
+}
+
+// Group is the consumption of the pods sharing a group key. CPU values are in millicores and
+// memory values in bytes.
+type Group struct {
+	// Name is the group key, the label value or namespace; pods without the label are in "<none>"
+	Name string `json:"name"`
+	Pods int    `json:"pods"`
+
+	CPURequests    int64 `json:"cpuRequests"`
+	CPULimits      int64 `json:"cpuLimits"`
+	CPUUsage       int64 `json:"cpuUsage"`
+	MemoryRequests int64 `json:"memoryRequests"`
+	MemoryLimits   int64 `json:"memoryLimits"`
+	MemoryUsage    int64 `json:"memoryUsage"`
+}
+
+// Report is the consumption per group, largest CPU requests first
+type Report struct {
+	GroupBy string  `json:"groupBy"`
+	Groups  []Group `json:"groups"`
+	Total   Group   `json:"total"`
+
+	// MetricsAvailable is false when the metrics API could not be queried; usage is then zero
+	MetricsAvailable bool `json:"metricsAvailable"`
+}
@@ -0,0 +1,29 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"k8stool/internal/k8s/resources"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Service defines the interface for manifest validation
+type Service interface {
+	// Validate submits every document as a server-side dry run and reports what the API server
+	// and its admission webhooks would reject or warn about
+	Validate(ctx context.Context, docs []resources.Document, opts Options) ([]Result, error)
+}
+
+// NewValidateService creates a new validation service instance
+func NewValidateService(clientset *kubernetes.Clientset, config *rest.Config) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	if config == nil {
+		return nil, fmt.Errorf("rest config is required")
+	}
+	return newService(clientset, config)
+}
@@ -0,0 +1,251 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8stool/internal/k8s/resources"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+var crdGroupKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+type service struct {
+	dynamicClient dynamic.Interface
+	mapper        *restmapper.DeferredDiscoveryRESTMapper
+	warnings      *warningRecorder
+}
+
+// newService creates a new validation service instance. It uses its own dynamic client so the
+// warnings returned with each dry run can be attributed to the document that caused them.
+func newService(clientset *kubernetes.Clientset, config *rest.Config) (Service, error) {
+	warnings := &warningRecorder{}
+	cfg := rest.CopyConfig(config)
+	cfg.WarningHandler = warnings
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	return &service{
+		dynamicClient: dynamicClient,
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientset.Discovery())),
+		warnings:      warnings,
+	}, nil
+}
+
+// warningRecorder collects the warning headers of API responses
+type warningRecorder struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (w *warningRecorder) HandleWarningHeader(code int, agent string, text string) {
+	if code != 299 || text == "" {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messages = append(w.messages, text)
+}
+
+// take returns the recorded warnings and clears them
+func (w *warningRecorder) take() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	messages := w.messages
+	w.messages = nil
+	return messages
+}
+
+// pending records namespaces and kinds that the validated manifests create themselves
+type pending struct {
+	namespaces map[string]bool
+	kinds      map[schema.GroupKind]bool
+}
+
+// Validate submits every document as a server-side dry run, one at a time
+func (s *service) Validate(ctx context.Context, docs []resources.Document, opts Options) ([]Result, error) {
+	created := pending{namespaces: make(map[string]bool), kinds: make(map[schema.GroupKind]bool)}
+	for _, doc := range docs {
+		switch doc.Object.GroupVersionKind().GroupKind() {
+		case schema.GroupKind{Kind: "Namespace"}:
+			created.namespaces[doc.Object.GetName()] = true
+		case crdGroupKind:
+			group, _, _ := unstructured.NestedString(doc.Object.Object, "spec", "group")
+			kind, _, _ := unstructured.NestedString(doc.Object.Object, "spec", "names", "kind")
+			created.kinds[schema.GroupKind{Group: group, Kind: kind}] = true
+		}
+	}
+
+	results := make([]Result, 0, len(docs))
+	for _, doc := range docs {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		results = append(results, s.validate(ctx, doc, opts.Namespace, created))
+	}
+	return results, nil
+}
+
+func (s *service) validate(ctx context.Context, doc resources.Document, namespace string, created pending) Result {
+	obj := doc.Object.DeepCopy()
+	result := Result{
+		Source:     doc.Source,
+		Index:      doc.Index,
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Name:       obj.GetName(),
+	}
+	if result.Name == "" {
+		result.Name = obj.GetGenerateName()
+	}
+
+	gvk := obj.GroupVersionKind()
+	mapping, err := s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		result.Issues = append(result.Issues, s.unservedIssue(gvk, created))
+		return result
+	}
+	if err != nil {
+		result.Issues = append(result.Issues, Issue{Type: Error, Message: fmt.Sprintf("failed to map %s: %v", gvk, err)})
+		return result
+	}
+
+	var client dynamic.ResourceInterface
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+		result.Namespace = obj.GetNamespace()
+		client = s.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+	} else {
+		obj.SetNamespace("")
+		client = s.dynamicClient.Resource(mapping.Resource)
+	}
+
+	s.warnings.take()
+	err = dryRun(ctx, client, obj)
+	for _, warning := range s.warnings.take() {
+		result.Issues = append(result.Issues, warningIssue(warning))
+	}
+
+	switch {
+	case err == nil:
+	case isNamespaceNotFound(err) && created.namespaces[obj.GetNamespace()]:
+		result.Issues = append(result.Issues, Issue{
+			Type:    Warning,
+			Message: fmt.Sprintf("namespace %s is created by these manifests; the object is checked once it exists", obj.GetNamespace()),
+		})
+	default:
+		result.Issues = append(result.Issues, errorIssues(err)...)
+	}
+	return result
+}
+
+// unservedIssue explains why the cluster does not know a kind at the given version
+func (s *service) unservedIssue(gvk schema.GroupVersionKind, created pending) Issue {
+	if created.kinds[gvk.GroupKind()] {
+		return Issue{
+			Type:    Warning,
+			Message: fmt.Sprintf("%s is defined by a CustomResourceDefinition in these manifests; the object is checked once it is installed", gvk.Kind),
+		}
+	}
+	if mapping, err := s.mapper.RESTMapping(gvk.GroupKind()); err == nil {
+		return Issue{
+			Type:    Schema,
+			Message: fmt.Sprintf("apiVersion %s is not served for %s; use %s", gvk.GroupVersion(), gvk.Kind, mapping.GroupVersionKind.GroupVersion()),
+		}
+	}
+	return Issue{
+		Type:    Schema,
+		Message: fmt.Sprintf("kind %s is not served by the cluster in %s", gvk.Kind, gvk.GroupVersion()),
+	}
+}
+
+// dryRun submits the object with strict field validation, so unknown and duplicate fields are
+// rejected instead of dropped. Named objects are server-side applied; objects that only set
+// generateName are created.
+func dryRun(ctx context.Context, client dynamic.ResourceInterface, obj *unstructured.Unstructured) error {
+	if obj.GetName() == "" {
+		_, err := client.Create(ctx, obj, metav1.CreateOptions{
+			DryRun:          []string{metav1.DryRunAll},
+			FieldManager:    resources.DefaultFieldManager,
+			FieldValidation: metav1.FieldValidationStrict,
+		})
+		return err
+	}
+
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return fmt.Errorf("failed to encode object: %w", err)
+	}
+	// Conflicts with other field managers say nothing about whether the manifest is valid
+	force := true
+	_, err = client.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		DryRun:          []string{metav1.DryRunAll},
+		FieldManager:    resources.DefaultFieldManager,
+		Force:           &force,
+		FieldValidation: metav1.FieldValidationStrict,
+	})
+	return err
+}
+
+// errorIssues classifies a dry-run error, splitting schema errors into one issue per field
+func errorIssues(err error) []Issue {
+	message := err.Error()
+	if strings.Contains(message, "admission webhook") || strings.Contains(message, "ValidatingAdmissionPolicy") {
+		return []Issue{{Type: Admission, Message: message}}
+	}
+	if !apierrors.IsInvalid(err) && !apierrors.IsBadRequest(err) {
+		return []Issue{{Type: Error, Message: message}}
+	}
+
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		if details := status.Status().Details; details != nil && len(details.Causes) > 0 {
+			issues := make([]Issue, 0, len(details.Causes))
+			for _, cause := range details.Causes {
+				msg := cause.Message
+				if cause.Field != "" && !strings.HasPrefix(msg, cause.Field) {
+					msg = cause.Field + ": " + msg
+				}
+				issues = append(issues, Issue{Type: Schema, Message: msg})
+			}
+			return issues
+		}
+	}
+	return []Issue{{Type: Schema, Message: message}}
+}
+
+func warningIssue(warning string) Issue {
+	if strings.Contains(strings.ToLower(warning), "deprecated") {
+		return Issue{Type: Deprecated, Message: warning}
+	}
+	return Issue{Type: Warning, Message: warning}
+}
+
+func isNamespaceNotFound(err error) bool {
+	var status apierrors.APIStatus
+	if !apierrors.IsNotFound(err) || !errors.As(err, &status) {
+		return false
+	}
+	details := status.Status().Details
+	return details != nil && details.Kind == "namespaces"
+}
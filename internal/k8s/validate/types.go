@@ -0,0 +1,57 @@
+package validate
+
+// IssueType classifies a validation finding
+type IssueType string
+
+const (
+	// Schema means the object does not match the schema of its kind, e.g. an unknown field
+	Schema IssueType = "schema"
+	// Admission means an admission webhook or policy rejected the object
+	Admission IssueType = "admission"
+	// Deprecated means the apiVersion is deprecated and will be removed
+	Deprecated IssueType = "deprecated"
+	// Warning is any other warning returned by the API server
+	Warning IssueType = "warning"
+	// Error is any other failure, e.g. missing permissions
+	Error IssueType = "error"
+)
+
+// Options configures validation
+type Options struct {
+	// Namespace is used for namespaced objects that do not set one
+	Namespace string
+}
+
+// Issue is a single validation finding
+type Issue struct {
+	Type    IssueType `json:"type"`
+	Message string    `json:"message"`
+}
+
+// Failed reports whether the issue would make applying the document fail
+func (i Issue) Failed() bool {
+	return i.Type == Schema || i.Type == Admission || i.Type == Error
+}
+
+// Result is the validation outcome of a single document
+type Result struct {
+	// Source and Index locate the document, e.g. deploy.yaml and 2 for its second document
+	Source string `json:"source"`
+	Index  int    `json:"index"`
+
+	APIVersion string  `json:"apiVersion"`
+	Kind       string  `json:"kind"`
+	Namespace  string  `json:"namespace,omitempty"`
+	Name       string  `json:"name"`
+	Issues     []Issue `json:"issues,omitempty"`
+}
+
+// Failed reports whether any issue would make applying the document fail
+func (r Result) Failed() bool {
+	for _, issue := range r.Issues {
+		if issue.Failed() {
+			return true
+		}
+	}
+	return false
+}
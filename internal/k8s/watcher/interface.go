@@ -0,0 +1,23 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for streaming changes of resources
+type Service interface {
+	// Watch streams every change of the selected objects until ctx is done. The channel is
+	// closed when the watch ends.
+	Watch(ctx context.Context, opts Options) (<-chan Event, error)
+}
+
+// NewWatcherService creates a new watcher service instance
+func NewWatcherService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
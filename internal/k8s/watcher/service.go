@@ -0,0 +1,162 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8stool/internal/k8s/deployments"
+	"k8stool/internal/k8s/errs"
+	"k8stool/internal/k8s/events"
+	"k8stool/internal/k8s/nodes"
+	"k8stool/internal/k8s/pods"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// watchedResource describes how to watch a resource and convert its objects
+type watchedResource struct {
+	kind       string
+	namespaced bool
+	getter     func(cs *kubernetes.Clientset) cache.Getter
+	object     runtime.Object
+	convert    func(obj runtime.Object) interface{}
+}
+
+// resources are the resources that can be watched, by name
+var resources = map[string]watchedResource{
+	"pods": {
+		kind:       "Pod",
+		namespaced: true,
+		getter:     func(cs *kubernetes.Clientset) cache.Getter { return cs.CoreV1().RESTClient() },
+		object:     &corev1.Pod{},
+		convert:    func(obj runtime.Object) interface{} { return pods.FromCorePod(obj.(*corev1.Pod)) },
+	},
+	"deployments": {
+		kind:       "Deployment",
+		namespaced: true,
+		getter:     func(cs *kubernetes.Clientset) cache.Getter { return cs.AppsV1().RESTClient() },
+		object:     &appsv1.Deployment{},
+		convert: func(obj runtime.Object) interface{} {
+			return deployments.FromAppsDeployment(obj.(*appsv1.Deployment))
+		},
+	},
+	"nodes": {
+		kind:    "Node",
+		getter:  func(cs *kubernetes.Clientset) cache.Getter { return cs.CoreV1().RESTClient() },
+		object:  &corev1.Node{},
+		convert: func(obj runtime.Object) interface{} { return nodes.FromCoreNode(obj.(*corev1.Node)) },
+	},
+	"events": {
+		kind:       "Event",
+		namespaced: true,
+		getter:     func(cs *kubernetes.Clientset) cache.Getter { return cs.CoreV1().RESTClient() },
+		object:     &corev1.Event{},
+		convert:    func(obj runtime.Object) interface{} { return events.FromCoreEvent(obj.(*corev1.Event)) },
+	},
+}
+
+// aliases maps the singular and short names of the resources to their names
+var aliases = map[string]string{
+	"pod": "pods", "po": "pods",
+	"deployment": "deployments", "deploy": "deployments",
+	"node": "nodes", "no": "nodes",
+	"event": "events", "ev": "events",
+}
+
+// Resources returns the names of the resources that can be watched
+func Resources() []string {
+	return []string{"pods", "deployments", "nodes", "events"}
+}
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new watcher service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{clientset: clientset}
+}
+
+// Watch streams the changes of the selected objects through an informer, which lists them,
+// watches from there and lists again whenever the watch cannot be resumed
+func (s *service) Watch(ctx context.Context, opts Options) (<-chan Event, error) {
+	if name, ok := aliases[opts.Resource]; ok {
+		opts.Resource = name
+	}
+	r, ok := resources[opts.Resource]
+	if !ok {
+		return nil, errs.Validationf("cannot watch %q, supported resources are %s", opts.Resource, strings.Join(Resources(), ", "))
+	}
+	namespace := opts.Namespace
+	if opts.AllNamespaces || !r.namespaced {
+		namespace = metav1.NamespaceAll
+	}
+
+	lw := cache.NewFilteredListWatchFromClient(r.getter(s.clientset), opts.Resource, namespace, func(o *metav1.ListOptions) {
+		o.LabelSelector = opts.Selector
+	})
+
+	// The informer retries failed lists forever, so access and selector errors are reported
+	// up front
+	if _, err := lw.List(metav1.ListOptions{Limit: 1}); err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", opts.Resource, err)
+	}
+
+	changes := make(chan Event, 100)
+	send := func(eventType watch.EventType, obj interface{}, initial bool) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		o, ok := obj.(runtime.Object)
+		if !ok {
+			return
+		}
+		select {
+		case changes <- Event{Type: string(eventType), Kind: r.kind, Initial: initial, Object: r.convert(o)}:
+		case <-ctx.Done():
+		}
+	}
+
+	_, informer := cache.NewInformerWithOptions(cache.InformerOptions{
+		ListerWatcher: lw,
+		ObjectType:    r.object,
+		Handler: cache.ResourceEventHandlerDetailedFuncs{
+			AddFunc: func(obj interface{}, initial bool) {
+				send(watch.Added, obj, initial)
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				// A list after an expired watch replays unchanged objects as updates
+				oldMeta, err1 := meta(oldObj)
+				newMeta, err2 := meta(newObj)
+				if err1 == nil && err2 == nil && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+					return
+				}
+				send(watch.Modified, newObj, false)
+			},
+			DeleteFunc: func(obj interface{}) {
+				send(watch.Deleted, obj, false)
+			},
+		},
+	})
+
+	go func() {
+		defer close(changes)
+		informer.Run(ctx.Done())
+	}()
+	return changes, nil
+}
+
+func meta(obj interface{}) (metav1.Object, error) {
+	o, ok := obj.(metav1.Object)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object %T", obj)
+	}
+	return o, nil
+}
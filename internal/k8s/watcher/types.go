@@ -0,0 +1,29 @@
+package watcher
+
+// Options selects the objects to watch
+type Options struct {
+	// Resource is pods, deployments, nodes or events
+	Resource string
+
+	Namespace     string
+	AllNamespaces bool
+
+	// Selector is a label selector
+	Selector string
+}
+
+// Event is a change of a watched object
+type Event struct {
+	// Type is ADDED, MODIFIED or DELETED; objects present when the watch starts are ADDED
+	Type string `json:"type"`
+
+	// Kind is the kind of the object, e.g. Pod
+	Kind string `json:"kind"`
+
+	// Initial is set for the objects listed when the watch starts
+	Initial bool `json:"initial,omitempty"`
+
+	// Object is the object converted to the struct k8stool uses for its kind: a pods.Pod,
+	// deployments.Deployment, nodes.Node or events.Event
+	Object interface{} `json:"object"`
+}
@@ -0,0 +1,23 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service defines the interface for admission webhook inspection
+type Service interface {
+	// List returns the mutating and validating admission webhooks with the health of their
+	// target service, probing their latency when requested
+	List(ctx context.Context, opts ListOptions) ([]Webhook, error)
+}
+
+// NewWebhookService creates a new webhook service instance
+func NewWebhookService(clientset *kubernetes.Clientset) (Service, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is required")
+	}
+	return newService(clientset), nil
+}
@@ -0,0 +1,343 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultTimeoutSeconds is the API server's timeout for a webhook that does not set one
+const defaultTimeoutSeconds = 10
+
+type service struct {
+	clientset *kubernetes.Clientset
+}
+
+// newService creates a new webhook service instance
+func newService(clientset *kubernetes.Clientset) Service {
+	return &service{
+		clientset: clientset,
+	}
+}
+
+// webhookEntry is a webhook with the parts of its spec needed to check and probe it
+type webhookEntry struct {
+	webhook        Webhook
+	clientConfig   admissionregistrationv1.WebhookClientConfig
+	rules          []admissionregistrationv1.RuleWithOperations
+	reviewVersions []string
+}
+
+// List returns the mutating and validating admission webhooks with the health of their
+// target service, probing their latency when requested
+func (s *service) List(ctx context.Context, opts ListOptions) ([]Webhook, error) {
+	var entries []webhookEntry
+
+	if opts.Type == "" || opts.Type == Mutating {
+		configs, err := s.clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list mutating webhook configurations: %w", err)
+		}
+		for _, config := range configs.Items {
+			for _, wh := range config.Webhooks {
+				entries = append(entries, newEntry(Mutating, config.Name, wh.Name, wh.ClientConfig, wh.Rules,
+					wh.FailurePolicy, wh.SideEffects, wh.TimeoutSeconds, wh.NamespaceSelector, wh.ObjectSelector, wh.AdmissionReviewVersions))
+			}
+		}
+	}
+
+	if opts.Type == "" || opts.Type == Validating {
+		configs, err := s.clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list validating webhook configurations: %w", err)
+		}
+		for _, config := range configs.Items {
+			for _, wh := range config.Webhooks {
+				entries = append(entries, newEntry(Validating, config.Name, wh.Name, wh.ClientConfig, wh.Rules,
+					wh.FailurePolicy, wh.SideEffects, wh.TimeoutSeconds, wh.NamespaceSelector, wh.ObjectSelector, wh.AdmissionReviewVersions))
+			}
+		}
+	}
+
+	webhooks := make([]Webhook, 0, len(entries))
+	for i := range entries {
+		s.checkHealth(ctx, &entries[i])
+		if opts.Probe {
+			entries[i].webhook.Probe = s.probe(ctx, &entries[i], max(opts.Count, 1))
+		}
+		webhooks = append(webhooks, entries[i].webhook)
+	}
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		if webhooks[i].Type != webhooks[j].Type {
+			return webhooks[i].Type < webhooks[j].Type
+		}
+		if webhooks[i].Configuration != webhooks[j].Configuration {
+			return webhooks[i].Configuration < webhooks[j].Configuration
+		}
+		return webhooks[i].Name < webhooks[j].Name
+	})
+	return webhooks, nil
+}
+
+// newEntry summarizes a webhook of either configuration type, applying the API defaults
+func newEntry(webhookType, configuration, name string, clientConfig admissionregistrationv1.WebhookClientConfig,
+	rules []admissionregistrationv1.RuleWithOperations, failurePolicy *admissionregistrationv1.FailurePolicyType,
+	sideEffects *admissionregistrationv1.SideEffectClass, timeoutSeconds *int32,
+	namespaceSelector, objectSelector *metav1.LabelSelector, reviewVersions []string) webhookEntry {
+
+	wh := Webhook{
+		Type:           webhookType,
+		Configuration:  configuration,
+		Name:           name,
+		FailurePolicy:  string(admissionregistrationv1.Fail),
+		TimeoutSeconds: defaultTimeoutSeconds,
+		Target:         formatTarget(clientConfig),
+	}
+	if failurePolicy != nil {
+		wh.FailurePolicy = string(*failurePolicy)
+	}
+	if sideEffects != nil {
+		wh.SideEffects = string(*sideEffects)
+	}
+	if timeoutSeconds != nil {
+		wh.TimeoutSeconds = *timeoutSeconds
+	}
+	wh.NamespaceSelector = formatSelector(namespaceSelector)
+	wh.ObjectSelector = formatSelector(objectSelector)
+	for _, rule := range rules {
+		wh.Rules = append(wh.Rules, formatRule(rule))
+	}
+
+	return webhookEntry{webhook: wh, clientConfig: clientConfig, rules: rules, reviewVersions: reviewVersions}
+}
+
+// formatTarget renders the service or URL a webhook is called at
+func formatTarget(config admissionregistrationv1.WebhookClientConfig) string {
+	if config.URL != nil {
+		return *config.URL
+	}
+	if svc := config.Service; svc != nil {
+		target := fmt.Sprintf("%s/%s:%d", svc.Namespace, svc.Name, servicePort(svc))
+		if svc.Path != nil {
+			target += *svc.Path
+		}
+		return target
+	}
+	return ""
+}
+
+// servicePort returns the port of a webhook service, 443 when unset
+func servicePort(svc *admissionregistrationv1.ServiceReference) int32 {
+	if svc.Port != nil {
+		return *svc.Port
+	}
+	return 443
+}
+
+// formatSelector renders a label selector, empty when it matches everything
+func formatSelector(selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	s := metav1.FormatLabelSelector(selector)
+	if s == "<none>" {
+		return ""
+	}
+	return s
+}
+
+// formatRule renders a rule as its operations followed by the group/version and resources
+func formatRule(rule admissionregistrationv1.RuleWithOperations) string {
+	groups := make([]string, 0, len(rule.APIGroups))
+	for _, group := range rule.APIGroups {
+		if group == "" {
+			group = "core"
+		}
+		groups = append(groups, group)
+	}
+	return fmt.Sprintf("%s %s/%s %s", strings.Join(opStrings(rule.Operations), ","), strings.Join(groups, ","),
+		strings.Join(rule.APIVersions, ","), strings.Join(rule.Resources, ","))
+}
+
+// checkHealth checks that the service of a webhook exists and has ready endpoints
+func (s *service) checkHealth(ctx context.Context, e *webhookEntry) {
+	wh := &e.webhook
+	ref := e.clientConfig.Service
+	if ref == nil {
+		wh.Health = External
+		return
+	}
+
+	svc, err := s.clientset.CoreV1().Services(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			wh.Health = ServiceNotFound
+			wh.Details = fmt.Sprintf("service %s/%s does not exist", ref.Namespace, ref.Name)
+			return
+		}
+		wh.Health = Unknown
+		wh.Details = err.Error()
+		return
+	}
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		wh.Health = External
+		wh.Details = "ExternalName " + svc.Spec.ExternalName
+		return
+	}
+
+	endpoints, err := s.clientset.CoreV1().Endpoints(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		wh.Health = Unknown
+		wh.Details = err.Error()
+		return
+	}
+	ready, notReady := 0, 0
+	if err == nil {
+		for _, subset := range endpoints.Subsets {
+			ready += len(subset.Addresses)
+			notReady += len(subset.NotReadyAddresses)
+		}
+	}
+	if ready == 0 {
+		wh.Health = NoReadyEndpoints
+		wh.Details = fmt.Sprintf("service %s/%s has no ready endpoints", ref.Namespace, ref.Name)
+		if notReady > 0 {
+			wh.Details += fmt.Sprintf(" (%d not ready)", notReady)
+		}
+		return
+	}
+	wh.Health = Healthy
+	wh.Details = fmt.Sprintf("%d ready endpoints", ready)
+	if notReady > 0 {
+		wh.Details += fmt.Sprintf(", %d not ready", notReady)
+	}
+}
+
+// probe sends dry-run admission reviews to a webhook through the API server's service proxy and
+// measures their round trip. Webhooks with side effects are not probed, since they might act on
+// the review; neither are webhooks called by URL, which the proxy cannot reach.
+func (s *service) probe(ctx context.Context, e *webhookEntry, count int) *ProbeResult {
+	wh := e.webhook
+	switch {
+	case e.clientConfig.Service == nil:
+		return &ProbeResult{Skipped: "called by URL"}
+	case wh.Health != Healthy:
+		return &ProbeResult{Skipped: "no ready endpoints"}
+	case wh.SideEffects != string(admissionregistrationv1.SideEffectClassNone) &&
+		wh.SideEffects != string(admissionregistrationv1.SideEffectClassNoneOnDryRun):
+		return &ProbeResult{Skipped: "may have side effects"}
+	}
+
+	ref := e.clientConfig.Service
+	path := fmt.Sprintf("/api/v1/namespaces/%s/services/https:%s:%d/proxy", ref.Namespace, ref.Name, servicePort(ref))
+	if ref.Path != nil {
+		path += *ref.Path
+	}
+	// The timeout is also passed to the webhook, as the API server does
+	timeout := time.Duration(wh.TimeoutSeconds) * time.Second
+
+	result := &ProbeResult{}
+	var total time.Duration
+	for i := 0; i < count; i++ {
+		body, err := json.Marshal(probeReview(e))
+		if err != nil {
+			result.Error = err.Error()
+			break
+		}
+
+		start := time.Now()
+		err = s.clientset.CoreV1().RESTClient().Post().
+			AbsPath(path).
+			SetHeader("Content-Type", "application/json").
+			Timeout(timeout).
+			Body(body).
+			Do(ctx).
+			Error()
+		elapsed := time.Since(start)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+
+		result.Count++
+		total += elapsed
+		result.Max = max(result.Max, elapsed)
+	}
+	if result.Count > 0 {
+		result.Average = total / time.Duration(result.Count)
+	}
+	return result
+}
+
+// probeReview builds a dry-run admission review for the first resource a webhook intercepts.
+// The object is empty, so the webhook may deny it; the round trip is what is measured.
+func probeReview(e *webhookEntry) *admissionv1.AdmissionReview {
+	version := "v1"
+	if len(e.reviewVersions) > 0 && e.reviewVersions[0] != "v1" {
+		version = e.reviewVersions[0]
+	}
+
+	resource := metav1.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	operation := admissionv1.Create
+	if len(e.rules) > 0 {
+		rule := e.rules[0]
+		resource = metav1.GroupVersionResource{
+			Group:    concrete(rule.APIGroups, ""),
+			Version:  concrete(rule.APIVersions, "v1"),
+			Resource: concrete(rule.Resources, "configmaps"),
+		}
+		if op := concrete(opStrings(rule.Operations), "CREATE"); op != "CONNECT" {
+			operation = admissionv1.Operation(op)
+		}
+	}
+
+	dryRun := true
+	return &admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/" + version, Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       types.UID("k8stool-probe-" + utilrand.String(8)),
+			Kind:      metav1.GroupVersionKind{Group: resource.Group, Version: resource.Version},
+			Resource:  resource,
+			Name:      "k8stool-webhook-probe",
+			Namespace: "default",
+			Operation: operation,
+			UserInfo:  authenticationv1.UserInfo{Username: "k8stool-webhook-probe"},
+			Object:    runtime.RawExtension{Raw: []byte(`{}`)},
+			DryRun:    &dryRun,
+		},
+	}
+}
+
+// concrete returns the first value that is not a wildcard, or the fallback
+func concrete(values []string, fallback string) string {
+	for _, v := range values {
+		if v != "*" && !strings.Contains(v, "/") {
+			return v
+		}
+	}
+	return fallback
+}
+
+// opStrings converts rule operations to strings
+func opStrings(ops []admissionregistrationv1.OperationType) []string {
+	values := make([]string, 0, len(ops))
+	for _, op := range ops {
+		values = append(values, string(op))
+	}
+	return values
+}
@@ -0,0 +1,76 @@
+package webhooks
+
+import "time"
+
+// ListOptions configures which webhooks are listed and whether they are probed
+type ListOptions struct {
+	// Type limits the list to mutating or validating webhooks; empty lists both
+	Type string
+
+	// Probe sends test admission reviews to each webhook to measure its latency
+	Probe bool
+
+	// Count is the number of probes sent to each webhook
+	Count int
+}
+
+// Webhook types
+const (
+	Mutating   = "mutating"
+	Validating = "validating"
+)
+
+// Health states of a webhook's target
+const (
+	// Healthy means the target service has ready endpoints
+	Healthy = "Healthy"
+	// ServiceNotFound means the target service does not exist
+	ServiceNotFound = "ServiceNotFound"
+	// NoReadyEndpoints means the target service has no ready endpoints
+	NoReadyEndpoints = "NoReadyEndpoints"
+	// External means the webhook is called by URL, outside the cluster's services
+	External = "External"
+	// Unknown means the target could not be checked
+	Unknown = "Unknown"
+)
+
+// Webhook is a single webhook of a mutating or validating webhook configuration
+type Webhook struct {
+	Type          string `json:"type"`
+	Configuration string `json:"configuration"`
+	Name          string `json:"name"`
+
+	FailurePolicy  string `json:"failurePolicy"`
+	SideEffects    string `json:"sideEffects"`
+	TimeoutSeconds int32  `json:"timeoutSeconds"`
+
+	// NamespaceSelector and ObjectSelector are in label selector syntax; empty matches everything
+	NamespaceSelector string `json:"namespaceSelector,omitempty"`
+	ObjectSelector    string `json:"objectSelector,omitempty"`
+
+	// Rules are the operations and resources the webhook intercepts, such as
+	// "CREATE,UPDATE apps/v1 deployments"
+	Rules []string `json:"rules"`
+
+	// Target is the service (namespace/name:port/path) or URL the webhook is called at
+	Target string `json:"target"`
+
+	Health  string `json:"health"`
+	Details string `json:"details,omitempty"`
+
+	// Probe is the measured latency, set when probing
+	Probe *ProbeResult `json:"probe,omitempty"`
+}
+
+// ProbeResult is the latency of test admission reviews sent to a webhook
+type ProbeResult struct {
+	// Skipped explains why the webhook was not probed
+	Skipped string `json:"skipped,omitempty"`
+
+	Count   int           `json:"count"`
+	Average time.Duration `json:"average"`
+	Max     time.Duration `json:"max"`
+
+	// Error is the last error of a probe that got no response
+	Error string `json:"error,omitempty"`
+}
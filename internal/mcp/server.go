@@ -0,0 +1,240 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Server is a Model Context Protocol server speaking JSON-RPC over a stream
+type Server struct {
+	info  ServerInfo
+	tools map[string]Tool
+	mu    sync.Mutex
+}
+
+// NewServer creates a new MCP server instance
+func NewServer(info ServerInfo) *Server {
+	return &Server{
+		info:  info,
+		tools: make(map[string]Tool),
+	}
+}
+
+// Register adds a tool to the server
+func (s *Server) Register(tool Tool) error {
+	if tool.Name == "" {
+		return fmt.Errorf("tool name is required")
+	}
+	if tool.Handler == nil {
+		return fmt.Errorf("tool %s has no handler", tool.Name)
+	}
+	if _, exists := s.tools[tool.Name]; exists {
+		return fmt.Errorf("tool %s is already registered", tool.Name)
+	}
+	s.tools[tool.Name] = tool
+	return nil
+}
+
+// Tools returns the registered tools sorted by name
+func (s *Server) Tools() []Tool {
+	tools := make([]Tool, 0, len(s.tools))
+	for _, t := range s.tools {
+		tools = append(tools, t)
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].Name < tools[j].Name
+	})
+	return tools
+}
+
+// Serve reads newline-delimited JSON-RPC messages from in and writes responses to out
+// until in is exhausted or the context is cancelled
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	encoder := json.NewEncoder(out)
+
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		resp := s.handle(ctx, line)
+		if resp == nil {
+			continue
+		}
+
+		s.mu.Lock()
+		err := encoder.Encode(resp)
+		s.mu.Unlock()
+		if err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+	return nil
+}
+
+// handle processes a single message and returns the response, or nil for notifications
+func (s *Server) handle(ctx context.Context, data []byte) *Response {
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), ParseError, fmt.Sprintf("invalid JSON: %v", err))
+	}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, InvalidRequest, "invalid JSON-RPC request")
+	}
+
+	// Notifications carry no ID and never get a response
+	isNotification := len(req.ID) == 0
+
+	var result interface{}
+	var rpcErr *Error
+
+	switch req.Method {
+	case "initialize":
+		result = map[string]interface{}{
+			"protocolVersion": ProtocolVersion,
+			"capabilities": map[string]interface{}{
+				"tools": map[string]interface{}{},
+			},
+			"serverInfo": s.info,
+		}
+	case "notifications/initialized", "notifications/cancelled":
+		return nil
+	case "ping":
+		result = map[string]interface{}{}
+	case "tools/list":
+		result = map[string]interface{}{
+			"tools": s.Tools(),
+		}
+	case "tools/call":
+		result, rpcErr = s.callTool(ctx, req.Params)
+	default:
+		rpcErr = &Error{Code: MethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+
+	if isNotification {
+		return nil
+	}
+
+	if rpcErr != nil {
+		return errorResponse(req.ID, rpcErr.Code, rpcErr.Message)
+	}
+
+	return &Response{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  result,
+	}
+}
+
+// callTool dispatches a tools/call request to the registered handler
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (*ToolResult, *Error) {
+	var call struct {
+		Name      string    `json:"name"`
+		Arguments Arguments `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &call); err != nil {
+		return nil, &Error{Code: InvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+
+	tool, ok := s.tools[call.Name]
+	if !ok {
+		return nil, &Error{Code: InvalidParams, Message: fmt.Sprintf("unknown tool: %s", call.Name)}
+	}
+
+	if call.Arguments == nil {
+		call.Arguments = Arguments{}
+	}
+
+	for _, name := range tool.InputSchema.Required {
+		if _, ok := call.Arguments[name]; !ok {
+			return &ToolResult{
+				Content: []Content{{Type: "text", Text: fmt.Sprintf("missing required argument: %s", name)}},
+				IsError: true,
+			}, nil
+		}
+	}
+
+	text, err := tool.Handler(ctx, call.Arguments)
+	if err != nil {
+		// Tool failures are reported in the result so the client model can see them
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: err.Error()}},
+			IsError: true,
+		}, nil
+	}
+
+	return &ToolResult{
+		Content: []Content{{Type: "text", Text: text}},
+	}, nil
+}
+
+func errorResponse(id json.RawMessage, code int, message string) *Response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &Response{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &Error{Code: code, Message: message},
+	}
+}
+
+// String returns a string argument, or the default if it is not set
+func (a Arguments) String(name, def string) (string, error) {
+	v, ok := a[name]
+	if !ok || v == nil {
+		return def, nil
+	}
+	str, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("argument %s must be a string", name)
+	}
+	return str, nil
+}
+
+// Int returns an integer argument, or the default if it is not set
+func (a Arguments) Int(name string, def int64) (int64, error) {
+	v, ok := a[name]
+	if !ok || v == nil {
+		return def, nil
+	}
+	// JSON numbers decode to float64
+	num, ok := v.(float64)
+	if !ok || num != float64(int64(num)) {
+		return 0, fmt.Errorf("argument %s must be an integer", name)
+	}
+	return int64(num), nil
+}
+
+// Bool returns a boolean argument, or the default if it is not set
+func (a Arguments) Bool(name string, def bool) (bool, error) {
+	v, ok := a[name]
+	if !ok || v == nil {
+		return def, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("argument %s must be a boolean", name)
+	}
+	return b, nil
+}
@@ -0,0 +1,318 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// response is a response of the server as a client decodes it
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *Error          `json:"error"`
+}
+
+// newTestServer returns a server with an echo tool and a tool that always fails
+func newTestServer(t *testing.T) *Server {
+	s := NewServer(ServerInfo{Name: "k8stool", Version: "test"})
+	require.NoError(t, s.Register(Tool{
+		Name:        "echo",
+		Description: "Echo a message",
+		InputSchema: InputSchema{
+			Type:       "object",
+			Properties: map[string]Property{"message": {Type: "string"}},
+			Required:   []string{"message"},
+		},
+		Handler: func(ctx context.Context, args Arguments) (string, error) {
+			return args.String("message", "")
+		},
+	}))
+	require.NoError(t, s.Register(Tool{
+		Name:        "broken",
+		Description: "Always fail",
+		InputSchema: InputSchema{Type: "object"},
+		Handler: func(ctx context.Context, args Arguments) (string, error) {
+			return "", fmt.Errorf("cluster unreachable")
+		},
+	}))
+	return s
+}
+
+// serve runs the server over the request lines and returns its responses
+func serve(t *testing.T, s *Server, requests ...string) []response {
+	var out bytes.Buffer
+	require.NoError(t, s.Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")+"\n"), &out))
+
+	var responses []response
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var resp response
+		require.NoError(t, decoder.Decode(&resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// toolResult decodes the result of a tools/call response
+func toolResult(t *testing.T, resp response) ToolResult {
+	require.Nil(t, resp.Error)
+	var result ToolResult
+	require.NoError(t, json.Unmarshal(resp.Result, &result))
+	require.Len(t, result.Content, 1)
+	return result
+}
+
+func TestServe(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  string
+		validate func(t *testing.T, responses []response)
+	}{
+		{
+			name:    "initialize",
+			request: `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				assert.Equal(t, "2.0", responses[0].JSONRPC)
+				assert.JSONEq(t, `1`, string(responses[0].ID))
+				assert.JSONEq(t, `{
+					"protocolVersion": "2024-11-05",
+					"capabilities": {"tools": {}},
+					"serverInfo": {"name": "k8stool", "version": "test"}
+				}`, string(responses[0].Result))
+			},
+		},
+		{
+			name:    "ping",
+			request: `{"jsonrpc":"2.0","id":"a","method":"ping"}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				assert.JSONEq(t, `"a"`, string(responses[0].ID))
+				assert.JSONEq(t, `{}`, string(responses[0].Result))
+			},
+		},
+		{
+			name:    "initialized notification",
+			request: `{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+			validate: func(t *testing.T, responses []response) {
+				assert.Empty(t, responses)
+			},
+		},
+		{
+			name:    "request without id",
+			request: `{"jsonrpc":"2.0","method":"tools/list"}`,
+			validate: func(t *testing.T, responses []response) {
+				assert.Empty(t, responses)
+			},
+		},
+		{
+			name:    "list tools",
+			request: `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				var result struct {
+					Tools []Tool `json:"tools"`
+				}
+				require.NoError(t, json.Unmarshal(responses[0].Result, &result))
+				require.Len(t, result.Tools, 2)
+				assert.Equal(t, "broken", result.Tools[0].Name)
+				assert.Equal(t, "echo", result.Tools[1].Name)
+				assert.Equal(t, []string{"message"}, result.Tools[1].InputSchema.Required)
+			},
+		},
+		{
+			name:    "call a tool",
+			request: `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"message":"hello"}}}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				result := toolResult(t, responses[0])
+				assert.False(t, result.IsError)
+				assert.Equal(t, Content{Type: "text", Text: "hello"}, result.Content[0])
+			},
+		},
+		{
+			name:    "call a tool without a required argument",
+			request: `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"echo"}}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				result := toolResult(t, responses[0])
+				assert.True(t, result.IsError)
+				assert.Equal(t, "missing required argument: message", result.Content[0].Text)
+			},
+		},
+		{
+			name:    "call a tool with an argument of the wrong type",
+			request: `{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"echo","arguments":{"message":42}}}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				result := toolResult(t, responses[0])
+				assert.True(t, result.IsError)
+				assert.Equal(t, "argument message must be a string", result.Content[0].Text)
+			},
+		},
+		{
+			name:    "call a failing tool",
+			request: `{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"broken"}}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				result := toolResult(t, responses[0])
+				assert.True(t, result.IsError)
+				assert.Equal(t, "cluster unreachable", result.Content[0].Text)
+			},
+		},
+		{
+			name:    "call an unknown tool",
+			request: `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"delete_cluster"}}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				require.NotNil(t, responses[0].Error)
+				assert.Equal(t, InvalidParams, responses[0].Error.Code)
+				assert.Equal(t, "unknown tool: delete_cluster", responses[0].Error.Message)
+			},
+		},
+		{
+			name:    "call with invalid params",
+			request: `{"jsonrpc":"2.0","id":8,"method":"tools/call","params":"echo"}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				require.NotNil(t, responses[0].Error)
+				assert.Equal(t, InvalidParams, responses[0].Error.Code)
+			},
+		},
+		{
+			name:    "unknown method",
+			request: `{"jsonrpc":"2.0","id":9,"method":"resources/list"}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				require.NotNil(t, responses[0].Error)
+				assert.Equal(t, MethodNotFound, responses[0].Error.Code)
+				assert.Equal(t, "method not found: resources/list", responses[0].Error.Message)
+			},
+		},
+		{
+			name:    "unknown notification",
+			request: `{"jsonrpc":"2.0","method":"notifications/progress"}`,
+			validate: func(t *testing.T, responses []response) {
+				assert.Empty(t, responses)
+			},
+		},
+		{
+			name:    "invalid JSON",
+			request: `{"jsonrpc":"2.0","id":10,`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				require.NotNil(t, responses[0].Error)
+				assert.Equal(t, ParseError, responses[0].Error.Code)
+				assert.JSONEq(t, `null`, string(responses[0].ID))
+			},
+		},
+		{
+			name:    "wrong protocol version",
+			request: `{"jsonrpc":"1.0","id":11,"method":"ping"}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				require.NotNil(t, responses[0].Error)
+				assert.Equal(t, InvalidRequest, responses[0].Error.Code)
+				assert.JSONEq(t, `11`, string(responses[0].ID))
+			},
+		},
+		{
+			name:    "request without method",
+			request: `{"jsonrpc":"2.0","id":12}`,
+			validate: func(t *testing.T, responses []response) {
+				require.Len(t, responses, 1)
+				require.NotNil(t, responses[0].Error)
+				assert.Equal(t, InvalidRequest, responses[0].Error.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.validate(t, serve(t, newTestServer(t), tt.request))
+		})
+	}
+}
+
+func TestServeSession(t *testing.T) {
+	// Blank lines are skipped and responses come in the order of the requests
+	responses := serve(t, newTestServer(t),
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		``,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"message":"one"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"message":"two"}}}`,
+	)
+
+	require.Len(t, responses, 3)
+	assert.JSONEq(t, `1`, string(responses[0].ID))
+	assert.Equal(t, "one", toolResult(t, responses[1]).Content[0].Text)
+	assert.Equal(t, "two", toolResult(t, responses[2]).Content[0].Text)
+}
+
+func TestServeCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+	err := newTestServer(t).Serve(ctx, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`+"\n"), &out)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, out.String())
+}
+
+func TestRegister(t *testing.T) {
+	handler := func(ctx context.Context, args Arguments) (string, error) { return "", nil }
+
+	tests := []struct {
+		name    string
+		tool    Tool
+		wantErr string
+	}{
+		{name: "without a name", tool: Tool{Handler: handler}, wantErr: "tool name is required"},
+		{name: "without a handler", tool: Tool{Name: "list_pods"}, wantErr: "tool list_pods has no handler"},
+		{name: "already registered", tool: Tool{Name: "echo", Handler: handler}, wantErr: "tool echo is already registered"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newTestServer(t).Register(tt.tool)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestArguments(t *testing.T) {
+	args := Arguments{"name": "web", "replicas": float64(3), "half": 1.5, "all": true, "unset": nil}
+
+	s, err := args.String("name", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "web", s)
+	s, err = args.String("unset", "default")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", s)
+	_, err = args.String("replicas", "")
+	assert.EqualError(t, err, "argument replicas must be a string")
+
+	n, err := args.Int("replicas", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	n, err = args.Int("missing", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	_, err = args.Int("half", 0)
+	assert.EqualError(t, err, "argument half must be an integer")
+
+	b, err := args.Bool("all", false)
+	assert.NoError(t, err)
+	assert.True(t, b)
+	_, err = args.Bool("name", false)
+	assert.EqualError(t, err, "argument name must be a boolean")
+}
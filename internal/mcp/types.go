@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ProtocolVersion is the MCP protocol revision implemented by the server
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes used by the server
+const (
+	// ParseError is returned when the request is not valid JSON
+	ParseError = -32700
+	// InvalidRequest is returned when the request is not a valid JSON-RPC request
+	InvalidRequest = -32600
+	// MethodNotFound is returned for unknown methods
+	MethodNotFound = -32601
+	// InvalidParams is returned when method parameters are invalid
+	InvalidParams = -32602
+	// InternalError is returned for unexpected server errors
+	InternalError = -32603
+)
+
+// Request represents an incoming JSON-RPC request or notification
+type Request struct {
+	// JSONRPC is the protocol version, always "2.0"
+	JSONRPC string `json:"jsonrpc"`
+
+	// ID identifies the request; it is empty for notifications
+	ID json.RawMessage `json:"id,omitempty"`
+
+	// Method is the method to invoke
+	Method string `json:"method"`
+
+	// Params holds the raw method parameters
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Response represents an outgoing JSON-RPC response
+type Response struct {
+	// JSONRPC is the protocol version, always "2.0"
+	JSONRPC string `json:"jsonrpc"`
+
+	// ID echoes the ID of the request being answered
+	ID json.RawMessage `json:"id"`
+
+	// Result holds the method result on success
+	Result interface{} `json:"result,omitempty"`
+
+	// Error holds the error on failure
+	Error *Error `json:"error,omitempty"`
+}
+
+// Error represents a JSON-RPC error object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Tool describes a tool exposed to MCP clients
+type Tool struct {
+	// Name is the unique tool name
+	Name string `json:"name"`
+
+	// Description explains what the tool does
+	Description string `json:"description"`
+
+	// InputSchema is the JSON schema of the tool arguments
+	InputSchema InputSchema `json:"inputSchema"`
+
+	// Handler executes the tool
+	Handler ToolHandler `json:"-"`
+}
+
+// InputSchema is a minimal JSON schema describing tool arguments
+type InputSchema struct {
+	Type       string              `json:"type"`
+	Properties map[string]Property `json:"properties,omitempty"`
+	Required   []string            `json:"required,omitempty"`
+}
+
+// Property describes a single tool argument
+type Property struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+// Arguments holds the decoded arguments of a tool call
+type Arguments map[string]interface{}
+
+// ToolHandler executes a tool call and returns its text result
+type ToolHandler func(ctx context.Context, args Arguments) (string, error)
+
+// Content is a single content block of a tool result
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// ToolResult is the result of a tools/call request
+type ToolResult struct {
+	Content []Content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// ServerInfo identifies the server during initialization
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
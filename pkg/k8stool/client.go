@@ -0,0 +1,107 @@
+package k8stool
+
+import (
+	"fmt"
+
+	k8s "k8stool/internal/k8s/client"
+)
+
+// Options configures how a Client connects to a cluster
+type Options struct {
+	// Context is the kubeconfig context to use; the current context if empty
+	Context string
+	// Impersonate acts as another user, like kubectl --as
+	Impersonate string
+	// ImpersonateGroups acts as additional groups, like kubectl --as-group
+	ImpersonateGroups []string
+}
+
+// Client gives access to the k8stool services for a single cluster context.
+// It loads the kubeconfig the same way the CLI does, honouring KUBECONFIG.
+type Client struct {
+	client *k8s.Client
+}
+
+// New creates a client for the current kubeconfig context
+func New() (*Client, error) {
+	return NewWithOptions(Options{})
+}
+
+// NewWithOptions creates a client using the given options
+func NewWithOptions(opts Options) (*Client, error) {
+	client, err := k8s.NewClientForContextWithOptions(opts.Context, opts.Impersonate, opts.ImpersonateGroups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create k8stool client: %w", err)
+	}
+	return &Client{client: client}, nil
+}
+
+// Namespace returns the namespace of the client's kubeconfig context
+func (c *Client) Namespace() string {
+	return c.client.GetCurrentNamespace()
+}
+
+// Pods returns the pod service
+func (c *Client) Pods() PodService {
+	return c.client.PodService
+}
+
+// Deployments returns the deployment service
+func (c *Client) Deployments() DeploymentService {
+	return c.client.DeploymentService
+}
+
+// Events returns the event service
+func (c *Client) Events() EventService {
+	return c.client.EventService
+}
+
+// Logs returns the log service
+func (c *Client) Logs() LogService {
+	return c.client.LogService
+}
+
+// Metrics returns the metrics service; it requires metrics-server in the cluster
+func (c *Client) Metrics() MetricsService {
+	return c.client.MetricsService
+}
+
+// Namespaces returns the namespace service
+func (c *Client) Namespaces() NamespaceService {
+	return c.client.NamespaceService
+}
+
+// Describe returns the describe service
+func (c *Client) Describe() DescribeService {
+	return c.client.DescribeSvc
+}
+
+// Exec returns the exec service
+func (c *Client) Exec() ExecService {
+	return c.client.ExecService
+}
+
+// PortForward returns the port-forward service
+func (c *Client) PortForward() PortForwardService {
+	return c.client.PortForwardService
+}
+
+// Nodes returns the node service
+func (c *Client) Nodes() NodeService {
+	return c.client.NodeService
+}
+
+// Resources returns the generic resource service used by apply, get and delete
+func (c *Client) Resources() ResourceService {
+	return c.client.ResourceService
+}
+
+// Doctor returns the cluster health check service
+func (c *Client) Doctor() DoctorService {
+	return c.client.DoctorService
+}
+
+// Troubleshoot returns the pod troubleshooting service
+func (c *Client) Troubleshoot() TroubleshootService {
+	return c.client.TroubleshootSvc
+}
@@ -0,0 +1,21 @@
+package k8stool
+
+import "k8stool/internal/k8s/errs"
+
+// ErrorCategory is the cause of a failure returned by a service
+type ErrorCategory = errs.Category
+
+// Error categories, matching the CLI exit codes
+const (
+	ErrUnknown    = errs.Unknown
+	ErrNotFound   = errs.NotFound
+	ErrForbidden  = errs.Forbidden
+	ErrTimeout    = errs.Timeout
+	ErrConflict   = errs.Conflict
+	ErrValidation = errs.Validation
+)
+
+// CategoryOf classifies an error returned by any service
+func CategoryOf(err error) ErrorCategory {
+	return errs.CategoryOf(err)
+}
@@ -0,0 +1,103 @@
+package k8stool
+
+import (
+	"k8stool/internal/k8s/deployments"
+	desc "k8stool/internal/k8s/describe"
+	"k8stool/internal/k8s/doctor"
+	"k8stool/internal/k8s/events"
+	ex "k8stool/internal/k8s/exec"
+	"k8stool/internal/k8s/logs"
+	"k8stool/internal/k8s/metrics"
+	ns "k8stool/internal/k8s/namespace"
+	"k8stool/internal/k8s/nodes"
+	"k8stool/internal/k8s/pods"
+	pf "k8stool/internal/k8s/portforward"
+	"k8stool/internal/k8s/resources"
+	"k8stool/internal/k8s/troubleshoot"
+)
+
+// Service interfaces
+type PodService = pods.Service
+type DeploymentService = deployments.Service
+type EventService = events.EventService
+type LogService = logs.LogService
+type MetricsService = metrics.Service
+type NamespaceService = ns.Service
+type DescribeService = desc.DescribeService
+type ExecService = ex.ExecService
+type PortForwardService = pf.Service
+type NodeService = nodes.Service
+type ResourceService = resources.Service
+type DoctorService = doctor.Service
+type TroubleshootService = troubleshoot.Service
+
+// Pod types
+type Pod = pods.Pod
+type PodDetails = pods.PodDetails
+type ContainerInfo = pods.ContainerInfo
+type PodWatchOptions = pods.WatchOptions
+type PodWatchEvent = pods.WatchEvent
+
+// Deployment types
+type Deployment = deployments.Deployment
+type DeploymentDetails = deployments.DeploymentDetails
+type DeploymentMetrics = deployments.DeploymentMetrics
+type DeploymentOptions = deployments.DeploymentOptions
+type DeploymentTemplateChange = deployments.TemplateChange
+
+// Event types
+type Event = events.Event
+type EventList = events.EventList
+type EventFilter = events.EventFilter
+type EventOptions = events.EventOptions
+
+// Log types
+type LogOptions = logs.LogOptions
+type LogResult = logs.LogResult
+type LogConnection = logs.LogConnection
+
+// Metrics types
+type PodMetrics = metrics.PodMetrics
+type NodeMetrics = metrics.NodeMetrics
+type MetricsSortOption = metrics.MetricsSortOption
+
+// Namespace types
+type Namespace = ns.Namespace
+type NamespaceDetails = ns.NamespaceDetails
+type ResourceQuota = ns.ResourceQuota
+type LimitRange = ns.LimitRange
+
+// Describe types
+type ResourceType = desc.ResourceType
+type ResourceDescription = desc.ResourceDescription
+
+// Exec types
+type ExecOptions = ex.ExecOptions
+type ExecResult = ex.ExecResult
+type ExecConnection = ex.ExecConnection
+
+// Port-forward types
+type PortForwardOptions = pf.PortForwardOptions
+type PortMapping = pf.PortMapping
+type PortForwardResult = pf.PortForwardResult
+type ForwardedPort = pf.ForwardedPort
+
+// Node types
+type DrainOptions = nodes.DrainOptions
+type DrainPlan = nodes.DrainPlan
+type DrainSimulation = nodes.DrainSimulation
+type AllocationOptions = nodes.AllocationOptions
+type Allocation = nodes.Allocation
+
+// Resource types
+type ApplyOptions = resources.ApplyOptions
+type ListOptions = resources.ListOptions
+type DeleteOptions = resources.DeleteOptions
+type MetadataOptions = resources.MetadataOptions
+type Result = resources.Result
+
+// Doctor and troubleshoot types
+type DoctorOptions = doctor.Options
+type DoctorReport = doctor.Report
+type TroubleshootOptions = troubleshoot.Options
+type PodReport = troubleshoot.PodReport
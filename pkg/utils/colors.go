@@ -19,19 +19,19 @@ var (
 func ColorizeStatus(status string) string {
 	switch status {
 	case "Running":
-		return Green(status)
+		return Green(withIcon(IconOK, status))
 	case "Pending":
-		return Yellow(status)
+		return Yellow(withIcon(IconWarn, status))
 	case "Succeeded":
-		return HiGreen(status)
+		return HiGreen(withIcon(IconOK, status))
 	case "Failed", "Evicted":
-		return Red(status)
+		return Red(withIcon(IconFail, status))
 	case "CrashLoopBackOff":
-		return HiRed(status)
+		return HiRed(withIcon(IconFail, status))
 	case "Completed":
-		return HiGreen(status)
+		return HiGreen(withIcon(IconOK, status))
 	case "Terminating":
-		return HiYellow(status)
+		return HiYellow(withIcon(IconWarn, status))
 	default:
 		return status
 	}
@@ -40,10 +40,25 @@ func ColorizeStatus(status string) string {
 func ColorizeEventType(eventType string) string {
 	switch eventType {
 	case "Normal":
-		return Green(eventType)
+		return Green(withIcon(IconOK, eventType))
 	case "Warning":
-		return Yellow(eventType)
+		return Yellow(withIcon(IconWarn, eventType))
 	default:
 		return eventType
 	}
 }
+
+// StatusOK colors a healthy status green, with the ok icon when icons are enabled
+func StatusOK(status string) string {
+	return Green(withIcon(IconOK, status))
+}
+
+// StatusWarn colors a status needing attention yellow, with the warning icon when icons are enabled
+func StatusWarn(status string) string {
+	return Yellow(withIcon(IconWarn, status))
+}
+
+// StatusFail colors a failed status red, with the failure icon when icons are enabled
+func StatusFail(status string) string {
+	return Red(withIcon(IconFail, status))
+}
@@ -0,0 +1,250 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+type diffLine struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// UnifiedDiff returns a unified diff of two texts, or an empty string if they are equal
+func UnifiedDiff(from, to, fromName, toName string) string {
+	if from == to {
+		return ""
+	}
+
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+
+	// Line numbers in from and to at the start of each diff line
+	fromLine, toLine := make([]int, len(lines)+1), make([]int, len(lines)+1)
+	for i, l := range lines {
+		fromLine[i+1], toLine[i+1] = fromLine[i], toLine[i]
+		if l.op != '+' {
+			fromLine[i+1]++
+		}
+		if l.op != '-' {
+			toLine[i+1]++
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			i++
+			continue
+		}
+
+		// Extend the hunk while the next change is close enough to share context
+		start := max(0, i-diffContext)
+		end := i
+		for j := i; j < len(lines); j++ {
+			if lines[j].op != ' ' {
+				end = j
+			} else if j-end > 2*diffContext {
+				break
+			}
+		}
+		end = min(len(lines), end+diffContext+1)
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			hunkRange(fromLine[start], fromLine[end]), hunkRange(toLine[start], toLine[end]))
+		for _, l := range lines[start:end] {
+			b.WriteByte(l.op)
+			b.WriteString(l.text)
+			b.WriteByte('\n')
+		}
+		i = end
+	}
+
+	return b.String()
+}
+
+// hunkRange formats the start,count of a hunk side; empty sides start at the line before
+func hunkRange(start, end int) string {
+	if end == start {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, end-start)
+}
+
+// ColorizeDiff colors removed lines red, added lines green and hunk headers blue
+func ColorizeDiff(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(text, "---") || strings.HasPrefix(text, "+++"):
+			lines[i] = Bold(text) + line[len(text):]
+		case strings.HasPrefix(text, "@@"):
+			lines[i] = Blue(text) + line[len(text):]
+		case strings.HasPrefix(text, "-"):
+			lines[i] = Red(text) + line[len(text):]
+		case strings.HasPrefix(text, "+"):
+			lines[i] = Green(text) + line[len(text):]
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// SideBySideDiff returns two texts side by side in columns filling width, with removed lines
+// red on the left, added lines green on the right and a few unchanged lines around each change.
+// Lines too long for their column are cut. It returns an empty string if the texts are equal.
+func SideBySideDiff(from, to, fromName, toName string, width int) string {
+	if from == to {
+		return ""
+	}
+	column := max(20, (width-3)/2)
+
+	// Pair the removed and added lines of each change, so a modified line lines up with its
+	// new version
+	type row struct {
+		left, right    string
+		removed, added bool
+	}
+	var rows []row
+	lines := diffLines(splitLines(from), splitLines(to))
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			rows = append(rows, row{left: lines[i].text, right: lines[i].text})
+			i++
+			continue
+		}
+		var removed, added []string
+		for ; i < len(lines) && lines[i].op != ' '; i++ {
+			if lines[i].op == '-' {
+				removed = append(removed, lines[i].text)
+			} else {
+				added = append(added, lines[i].text)
+			}
+		}
+		for j := 0; j < max(len(removed), len(added)); j++ {
+			var r row
+			if j < len(removed) {
+				r.left, r.removed = removed[j], true
+			}
+			if j < len(added) {
+				r.right, r.added = added[j], true
+			}
+			rows = append(rows, r)
+		}
+	}
+
+	cell := func(text string) string {
+		runes := []rune(text)
+		if len(runes) > column {
+			return string(runes[:column-1]) + "…"
+		}
+		return text + strings.Repeat(" ", column-len(runes))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s │ %s\n", Bold(cell(fromName)), Bold(toName))
+	shown := -1
+	for i := 0; i < len(rows); i++ {
+		near := false
+		for j := max(0, i-diffContext); j <= min(len(rows)-1, i+diffContext); j++ {
+			if rows[j].removed || rows[j].added {
+				near = true
+				break
+			}
+		}
+		if !near {
+			continue
+		}
+		if shown >= 0 && i > shown+1 {
+			fmt.Fprintf(&b, "%s │\n", Blue(cell("···")))
+		}
+		shown = i
+
+		left, right := cell(rows[i].left), strings.TrimRight(cell(rows[i].right), " ")
+		if rows[i].removed {
+			left = Red(left)
+		}
+		if rows[i].added {
+			right = Green(right)
+		}
+		fmt.Fprintf(&b, "%s │ %s\n", left, right)
+	}
+	return b.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes the shortest edit script between a and b with Myers' algorithm
+func diffLines(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+
+	var trace [][]int
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk the trace backwards from the end to recover the edits
+	var lines []diffLine
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			lines = append(lines, diffLine{' ', a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				lines = append(lines, diffLine{'+', b[y-1]})
+				y--
+			} else {
+				lines = append(lines, diffLine{'-', a[x-1]})
+				x--
+			}
+		}
+	}
+
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines
+}
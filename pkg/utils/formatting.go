@@ -1,5 +1,10 @@
 package utils
 
+import (
+	"fmt"
+	"strings"
+)
+
 // FormatResourceValue formats resource values (CPU/Memory) with appropriate units
 func FormatResourceValue(value string) string {
 	if value == "" {
@@ -15,3 +20,36 @@ func TruncateString(str string, maxLen int) string {
 	}
 	return str[:maxLen-3] + "..."
 }
+
+// LastLine returns the last non-blank line of a command's output, trimmed
+func LastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// FormatMilliCPU formats a CPU quantity given in millicores, e.g. 250m or 1.5
+func FormatMilliCPU(milli int64) string {
+	if milli >= 1000 && milli%100 == 0 {
+		return fmt.Sprintf("%g", float64(milli)/1000)
+	}
+	return fmt.Sprintf("%dm", milli)
+}
+
+// FormatBytes formats a memory quantity given in bytes using binary units, e.g. 128Mi
+func FormatBytes(bytes int64) string {
+	const (
+		ki = 1024
+		mi = 1024 * ki
+		gi = 1024 * mi
+	)
+	switch {
+	case bytes >= gi && bytes%gi == 0:
+		return fmt.Sprintf("%dGi", bytes/gi)
+	case bytes >= mi:
+		return fmt.Sprintf("%dMi", (bytes+mi-1)/mi)
+	case bytes >= ki:
+		return fmt.Sprintf("%dKi", (bytes+ki-1)/ki)
+	default:
+		return fmt.Sprintf("%d", bytes)
+	}
+}
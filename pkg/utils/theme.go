@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+)
+
+// Color palettes a theme can use
+const (
+	// ColorsDefault uses the 16 basic terminal colors
+	ColorsDefault = "default"
+	// Colors256 uses softer colors of the 256-color palette
+	Colors256 = "256"
+	// ColorsNone disables colors
+	ColorsNone = "none"
+)
+
+// Status icons shown before statuses when a theme enables them
+const (
+	IconOK   = "✓"
+	IconWarn = "⚠"
+	IconFail = "✗"
+)
+
+// Theme configures how tables and statuses are rendered
+type Theme struct {
+	// Colors is the palette: default, 256 or none
+	Colors string `json:"colors,omitempty"`
+
+	// Icons prefixes statuses with ✓, ⚠ or ✗, so they stay readable without colors
+	Icons bool `json:"icons,omitempty"`
+
+	// MaxColumnWidth cuts table cells longer than this many characters; 0 means no limit
+	MaxColumnWidth int `json:"maxColumnWidth,omitempty"`
+}
+
+// currentTheme is the theme applied last
+var currentTheme = Theme{Colors: ColorsDefault}
+
+// CurrentTheme returns the theme applied last
+func CurrentTheme() Theme {
+	return currentTheme
+}
+
+// ApplyTheme switches the color functions and status icons to a theme. NO_COLOR and output
+// that is not a terminal still disable colors whatever the theme.
+func ApplyTheme(theme Theme) error {
+	if theme.Colors == "" {
+		theme.Colors = ColorsDefault
+	}
+	if theme.MaxColumnWidth < 0 {
+		return fmt.Errorf("invalid maxColumnWidth %d: must not be negative", theme.MaxColumnWidth)
+	}
+
+	switch theme.Colors {
+	case ColorsDefault, ColorsNone:
+		Green = color.New(color.FgGreen).SprintFunc()
+		Yellow = color.New(color.FgYellow).SprintFunc()
+		Red = color.New(color.FgRed).SprintFunc()
+		Blue = color.New(color.FgBlue).SprintFunc()
+		HiGreen = color.New(color.FgHiGreen).SprintFunc()
+		HiYellow = color.New(color.FgHiYellow).SprintFunc()
+		HiRed = color.New(color.FgHiRed).SprintFunc()
+	case Colors256:
+		// Every code has three digits, so colored cells of a column keep equal widths
+		Green = color256(114)
+		Yellow = color256(221)
+		Red = color256(203)
+		Blue = color256(111)
+		HiGreen = color256(120)
+		HiYellow = color256(228)
+		HiRed = color256(196)
+	default:
+		return fmt.Errorf("invalid colors %q: must be default, 256 or none", theme.Colors)
+	}
+	if theme.Colors == ColorsNone {
+		color.NoColor = true
+	}
+
+	currentTheme = theme
+	return nil
+}
+
+// color256 returns a function coloring text with a color of the 256-color palette
+func color256(code int) func(a ...interface{}) string {
+	return color.New(38, 5, color.Attribute(code)).SprintFunc()
+}
+
+// withIcon prefixes a status with an icon when the theme enables icons
+func withIcon(icon, status string) string {
+	if !currentTheme.Icons {
+		return status
+	}
+	return icon + " " + status
+}